@@ -58,6 +58,39 @@ func (c *Client) ListAll(arg params.Entities) (params.ActionsByReceivers, error)
 	return results, err
 }
 
+// EnqueueService takes a list of ServiceActions and queues each of them
+// on the units of its service, returning the group of Actions queued
+// for each.
+func (c *Client) EnqueueService(arg params.ServiceActions) (params.ActionGroupResults, error) {
+	results := params.ActionGroupResults{}
+	if c.BestAPIVersion() < 2 {
+		return results, errors.NotSupportedf("running actions on a service on this controller")
+	}
+	err := c.facade.FacadeCall("EnqueueService", arg, &results)
+	return results, err
+}
+
+// ActionGroups fetches the Actions in each of the given groups.
+func (c *Client) ActionGroups(arg params.ActionGroups) (params.ActionGroupResults, error) {
+	results := params.ActionGroupResults{}
+	if c.BestAPIVersion() < 2 {
+		return results, errors.NotSupportedf("action groups on this controller")
+	}
+	err := c.facade.FacadeCall("ActionGroups", arg, &results)
+	return results, err
+}
+
+// History returns, for each of the given queries, the matching Actions
+// from the history of all Actions queued in the model.
+func (c *Client) History(arg params.ActionHistoryQueries) (params.ActionHistoryResults, error) {
+	results := params.ActionHistoryResults{}
+	if c.BestAPIVersion() < 2 {
+		return results, errors.NotSupportedf("action history on this controller")
+	}
+	err := c.facade.FacadeCall("History", arg, &results)
+	return results, err
+}
+
 // ListPending takes a list of Entities representing ActionReceivers
 // and returns all of the Actions that are queued for each of those
 // Entities.
@@ -76,8 +109,8 @@ func (c *Client) ListCompleted(arg params.Entities) (params.ActionsByReceivers,
 	return results, err
 }
 
-// Cancel attempts to cancel a queued up Action from running.
-func (c *Client) Cancel(arg params.Actions) (params.ActionResults, error) {
+// Cancel removes queued Actions, and asks running Actions to stop.
+func (c *Client) Cancel(arg params.Entities) (params.ActionResults, error) {
 	results := params.ActionResults{}
 	err := c.facade.FacadeCall("Cancel", arg, &results)
 	return results, err
@@ -132,3 +132,21 @@ func patchServiceCharmActions(c *gc.C, apiCli *action.Client, patchResults []par
 		},
 	)
 }
+
+func (s *actionSuite) TestServiceActionsNeedV2(c *gc.C) {
+	cleanup := action.PatchClientFacadeCall(s.client,
+		func(req string, paramsIn interface{}, resp interface{}) error {
+			c.Fatalf("unexpected call to %s", req)
+			return nil
+		},
+	)
+	defer cleanup()
+	action.SetBestAPIVersion(s.client, 1)
+
+	_, err := s.client.EnqueueService(params.ServiceActions{})
+	c.Check(err, gc.ErrorMatches, "running actions on a service on this controller not supported")
+	_, err = s.client.ActionGroups(params.ActionGroups{})
+	c.Check(err, gc.ErrorMatches, "action groups on this controller not supported")
+	_, err = s.client.History(params.ActionHistoryQueries{})
+	c.Check(err, gc.ErrorMatches, "action history on this controller not supported")
+}
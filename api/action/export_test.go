@@ -43,3 +43,18 @@ func (f *resultCaller) BestAPIVersion() int {
 func (f *resultCaller) RawAPICaller() base.APICaller {
 	return nil
 }
+
+// SetBestAPIVersion makes the client behave as if the best version of
+// the Action facade the controller offers is the given one.
+func SetBestAPIVersion(c *Client, version int) {
+	c.ClientFacade = bestVersionFacade{c.ClientFacade, version}
+}
+
+type bestVersionFacade struct {
+	base.ClientFacade
+	version int
+}
+
+func (f bestVersionFacade) BestAPIVersion() int {
+	return f.version
+}
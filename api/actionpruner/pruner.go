@@ -0,0 +1,38 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionpruner
+
+import (
+	"time"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/api/common"
+	"github.com/juju/juju/apiserver/params"
+)
+
+const apiName = "ActionPruner"
+
+// Facade allows calls to "ActionPruner" endpoints.
+type Facade struct {
+	*common.ModelWatcher
+	facade base.FacadeCaller
+}
+
+// NewFacade returns an "ActionPruner" Facade.
+func NewFacade(caller base.APICaller) *Facade {
+	facadeCaller := base.NewFacadeCaller(caller, apiName)
+	return &Facade{
+		ModelWatcher: common.NewModelWatcher(facadeCaller),
+		facade:       facadeCaller,
+	}
+}
+
+// Prune calls "ActionPruner.Prune".
+func (s *Facade) Prune(maxAge time.Duration, maxCount int) error {
+	p := params.ActionPruneArgs{
+		MaxAge:   maxAge,
+		MaxCount: maxCount,
+	}
+	return s.facade.FacadeCall("Prune", p, nil)
+}
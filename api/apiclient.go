@@ -21,6 +21,7 @@ import (
 	"github.com/juju/names"
 	"github.com/juju/utils"
 	"github.com/juju/utils/parallel"
+	"golang.org/x/net/context"
 	"golang.org/x/net/websocket"
 	"gopkg.in/macaroon-bakery.v1/httpbakery"
 
@@ -28,7 +29,7 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/rpc"
-	"github.com/juju/juju/rpc/jsoncodec"
+	"github.com/juju/juju/rpc/bsoncodec"
 	"github.com/juju/juju/version"
 )
 
@@ -49,6 +50,7 @@ var (
 // state is the internal implementation of the Connection interface.
 type state struct {
 	client *rpc.Conn
+	codec  *bsoncodec.Codec
 	conn   *websocket.Conn
 
 	// addr is the address used to connect to the API server.
@@ -79,6 +81,10 @@ type state struct {
 	// Login
 	facadeVersions map[string][]int
 
+	// serverCodecs holds the message formats, other than JSON,
+	// that the server reported it accepts at login.
+	serverCodecs []string
+
 	// authTag holds the authenticated entity's tag after login.
 	authTag names.Tag
 
@@ -143,7 +149,8 @@ func open(info *Info, opts DialOpts, loginFunc func(st *state, tag names.Tag, pw
 		return nil, errors.Trace(err)
 	}
 
-	client := rpc.NewConn(jsoncodec.NewWebsocket(conn), nil)
+	codec := bsoncodec.NewWebsocket(conn)
+	client := rpc.NewConn(codec, nil)
 	client.Start()
 
 	bakeryClient := opts.BakeryClient
@@ -166,6 +173,7 @@ func open(info *Info, opts DialOpts, loginFunc func(st *state, tag names.Tag, pw
 
 	st := &state{
 		client: client,
+		codec:  codec,
 		conn:   conn,
 		addr:   apiHost,
 		cookieURL: &url.URL{
@@ -188,6 +196,9 @@ func open(info *Info, opts DialOpts, loginFunc func(st *state, tag names.Tag, pw
 			conn.Close()
 			return nil, err
 		}
+		if opts.UseBSON && st.serverAcceptsCodec(params.CodecBSON) {
+			st.codec.SetBSON(true)
+		}
 	}
 	st.broken = make(chan struct{})
 	st.closed = make(chan struct{})
@@ -548,6 +559,18 @@ func (s *state) APICall(facade string, version int, id, method string, args, res
 	return errors.Trace(err)
 }
 
+// APICallContext places a call to the remote machine that is
+// abandoned when ctx is done.
+func (s *state) APICallContext(ctx context.Context, facade string, version int, id, method string, args, response interface{}) error {
+	err := s.client.CallContext(ctx, rpc.Request{
+		Type:    facade,
+		Version: version,
+		Id:      id,
+		Action:  method,
+	}, args, response)
+	return errors.Trace(err)
+}
+
 func (s *state) Close() error {
 	err := s.client.Close()
 	select {
@@ -629,6 +652,17 @@ func (s *state) serverRoot() string {
 	return s.serverScheme + "://" + s.serverRootAddress
 }
 
+// serverAcceptsCodec reports whether the server said at login that
+// it accepts messages in the named format.
+func (s *state) serverAcceptsCodec(name string) bool {
+	for _, codec := range s.serverCodecs {
+		if codec == name {
+			return true
+		}
+	}
+	return false
+}
+
 func (s *state) isLoggedIn() bool {
 	return atomic.LoadInt32(&s.loggedIn) == 1
 }
@@ -117,6 +117,29 @@ func (s *apiclientSuite) TestOpen(c *gc.C) {
 	c.Assert(remoteVersion, gc.Equals, version.Current)
 }
 
+func (s *apiclientSuite) TestOpenUseBSON(c *gc.C) {
+	info := s.APIInfo(c)
+	jsonSt, err := api.Open(info, api.DialOpts{})
+	c.Assert(err, jc.ErrorIsNil)
+	defer jsonSt.Close()
+	c.Assert(api.UsingBSON(jsonSt), jc.IsFalse)
+
+	bsonSt, err := api.Open(info, api.DialOpts{UseBSON: true})
+	c.Assert(err, jc.ErrorIsNil)
+	defer bsonSt.Close()
+	c.Assert(api.UsingBSON(bsonSt), jc.IsTrue)
+
+	s.Factory.MakeMachine(c, nil)
+	jsonStatus, err := jsonSt.Client().Status(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	bsonStatus, err := bsonSt.Client().Status(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(bsonStatus.Machines, gc.HasLen, 1)
+	c.Assert(bsonStatus.Machines["0"].Id, gc.Equals, jsonStatus.Machines["0"].Id)
+	c.Assert(bsonStatus.Machines["0"].Series, gc.Equals, jsonStatus.Machines["0"].Series)
+	c.Assert(bsonStatus.ModelName, gc.Equals, jsonStatus.ModelName)
+}
+
 func (s *apiclientSuite) TestOpenHonorsModelTag(c *gc.C) {
 	info := s.APIInfo(c)
 
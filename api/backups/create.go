@@ -9,11 +9,28 @@ import (
 	"github.com/juju/juju/apiserver/params"
 )
 
-// Create sends a request to create a backup of juju's state.  It
+// Create sends a request to create a backup of juju's state.  If key
+// is not empty, the backup archive is encrypted with it.  It returns
+// the metadata associated with the resulting backup.
+func (c *Client) Create(notes, key string) (*params.BackupsMetadataResult, error) {
+	return c.create(params.BackupsCreateArgs{Notes: notes, Key: key})
+}
+
+// CreateIncremental sends a request to create a backup holding only
+// the changes made to juju's state since the most recent backup.  If
+// key is not empty, the backup archive is encrypted with it.  It
 // returns the metadata associated with the resulting backup.
-func (c *Client) Create(notes string) (*params.BackupsMetadataResult, error) {
+func (c *Client) CreateIncremental(notes, key string) (*params.BackupsMetadataResult, error) {
+	return c.create(params.BackupsCreateArgs{Notes: notes, Incremental: true, Key: key})
+}
+
+func (c *Client) create(args params.BackupsCreateArgs) (*params.BackupsMetadataResult, error) {
+	// Older controllers ignore the arguments they do not know, and
+	// would create a full, unencrypted backup instead.
+	if (args.Incremental || args.Key != "") && c.BestAPIVersion() < 2 {
+		return nil, errors.NotSupportedf("incremental or encrypted backups on this controller")
+	}
 	var result params.BackupsMetadataResult
-	args := params.BackupsCreateArgs{Notes: notes}
 	if err := c.facade.FacadeCall("Create", args, &result); err != nil {
 		return nil, errors.Trace(err)
 	}
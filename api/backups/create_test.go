@@ -39,9 +39,54 @@ func (s *createSuite) TestCreate(c *gc.C) {
 	)
 	defer cleanup()
 
-	result, err := s.client.Create("important")
+	result, err := s.client.Create("important", "")
 	c.Assert(err, jc.ErrorIsNil)
 
 	meta := backupstesting.UpdateNotes(s.Meta, "important")
 	s.checkMetadataResult(c, result, meta)
 }
+
+func (s *createSuite) TestCreateIncremental(c *gc.C) {
+	cleanup := backups.PatchClientFacadeCall(s.client,
+		func(req string, paramsIn interface{}, resp interface{}) error {
+			c.Check(req, gc.Equals, "Create")
+
+			c.Assert(paramsIn, gc.FitsTypeOf, params.BackupsCreateArgs{})
+			p := paramsIn.(params.BackupsCreateArgs)
+			c.Check(p.Notes, gc.Equals, "hourly")
+			c.Check(p.Incremental, jc.IsTrue)
+			c.Check(p.Key, gc.Equals, "sekrit")
+
+			if result, ok := resp.(*params.BackupsMetadataResult); ok {
+				*result = apiserverbackups.ResultFromMetadata(s.Meta)
+				result.Notes = p.Notes
+			} else {
+				c.Fatalf("wrong output structure")
+			}
+			return nil
+		},
+	)
+	defer cleanup()
+
+	result, err := s.client.CreateIncremental("hourly", "sekrit")
+	c.Assert(err, jc.ErrorIsNil)
+
+	meta := backupstesting.UpdateNotes(s.Meta, "hourly")
+	s.checkMetadataResult(c, result, meta)
+}
+
+func (s *createSuite) TestCreateIncrementalNeedsV2(c *gc.C) {
+	cleanup := backups.PatchClientFacadeCall(s.client,
+		func(req string, paramsIn interface{}, resp interface{}) error {
+			c.Fatalf("unexpected call to %s", req)
+			return nil
+		},
+	)
+	defer cleanup()
+	backups.SetBestAPIVersion(s.client, 1)
+
+	_, err := s.client.CreateIncremental("hourly", "")
+	c.Assert(err, gc.ErrorMatches, "incremental or encrypted backups on this controller not supported")
+	_, err = s.client.Create("important", "sekrit")
+	c.Assert(err, gc.ErrorMatches, "incremental or encrypted backups on this controller not supported")
+}
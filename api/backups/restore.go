@@ -61,8 +61,13 @@ func prepareRestore(newClient ClientConnection) error {
 	return errors.Annotatef(err, "could not start restore process: %v", remoteError)
 }
 
-// RestoreReader restores the contents of backupFile as backup.
-func (c *Client) RestoreReader(r io.ReadSeeker, meta *params.BackupsMetadataResult, newClient ClientConnection) error {
+// RestoreReader restores the contents of backupFile as backup.  The key
+// is needed only if the backup is encrypted; chainKeys holds the keys of
+// the earlier backups in its chain that were encrypted with other keys.
+func (c *Client) RestoreReader(r io.ReadSeeker, meta *params.BackupsMetadataResult, key string, chainKeys map[string]string, newClient ClientConnection) error {
+	if err := c.checkRestoreKeys(key, chainKeys); err != nil {
+		return errors.Trace(err)
+	}
 	if err := prepareRestore(newClient); err != nil {
 		return errors.Trace(err)
 	}
@@ -75,16 +80,30 @@ func (c *Client) RestoreReader(r io.ReadSeeker, meta *params.BackupsMetadataResu
 		logger.Errorf("could not exit restoring status: %v", finishErr)
 		return errors.Annotatef(err, "cannot upload backup file")
 	}
-	return c.restore(backupId, newClient)
+	return c.restore(backupId, key, chainKeys, newClient)
 }
 
 // Restore performs restore using a backup id corresponding to a backup stored in the server.
-func (c *Client) Restore(backupId string, newClient ClientConnection) error {
+// The key is needed only if the backup is encrypted; chainKeys holds the keys of
+// the earlier backups in its chain that were encrypted with other keys.
+func (c *Client) Restore(backupId, key string, chainKeys map[string]string, newClient ClientConnection) error {
+	if err := c.checkRestoreKeys(key, chainKeys); err != nil {
+		return errors.Trace(err)
+	}
 	if err := prepareRestore(newClient); err != nil {
 		return errors.Trace(err)
 	}
 	logger.Debugf("Server in 'about to restore' mode")
-	return c.restore(backupId, newClient)
+	return c.restore(backupId, key, chainKeys, newClient)
+}
+
+// checkRestoreKeys returns an error if keys are given but the
+// controller cannot restore encrypted backups.
+func (c *Client) checkRestoreKeys(key string, chainKeys map[string]string) error {
+	if (key != "" || len(chainKeys) > 0) && c.BestAPIVersion() < 2 {
+		return errors.NotSupportedf("restoring encrypted backups on this controller")
+	}
+	return nil
 }
 
 func restoreAttempt(client *Client, closer closerFunc, restoreArgs params.RestoreArgs) (error, error) {
@@ -97,15 +116,19 @@ func restoreAttempt(client *Client, closer closerFunc, restoreArgs params.Restor
 // restore is responsible for triggering the whole restore process in a remote
 // machine. The backup information for the process should already be in the
 // server and loaded in the backup storage under the backupId id.
-// It takes backupId as the identifier for the remote backup file and a
+// It takes backupId as the identifier for the remote backup file, the
+// keys with which it and the earlier backups in its chain were
+// encrypted, if they were, and a
 // client connection factory newClient (newClient should no longer be
 // necessary when lp:1399722 is sorted out).
-func (c *Client) restore(backupId string, newClient ClientConnection) error {
+func (c *Client) restore(backupId, key string, chainKeys map[string]string, newClient ClientConnection) error {
 	var err, remoteError error
 
 	// Restore
 	restoreArgs := params.RestoreArgs{
-		BackupId: backupId,
+		BackupId:  backupId,
+		Key:       key,
+		ChainKeys: chainKeys,
 	}
 
 	for a := restoreStrategy.Start(); a.Next(); {
@@ -0,0 +1,25 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Verify sends a request to check that the identified backup is whole
+// and unmodified, and could be restored.  The key is needed only if
+// the backup is encrypted.
+func (c *Client) Verify(id, key string) (*params.BackupsVerifyResult, error) {
+	if c.BestAPIVersion() < 2 {
+		return nil, errors.NotSupportedf("verifying backups on this controller")
+	}
+	var result params.BackupsVerifyResult
+	args := params.BackupsVerifyArgs{ID: id, Key: key}
+	if err := c.facade.FacadeCall("Verify", args, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &result, nil
+}
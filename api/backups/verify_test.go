@@ -0,0 +1,43 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/backups"
+	"github.com/juju/juju/apiserver/params"
+)
+
+type verifySuite struct {
+	backupsSuite
+}
+
+var _ = gc.Suite(&verifySuite{})
+
+func (s *verifySuite) TestVerify(c *gc.C) {
+	cleanup := backups.PatchClientFacadeCall(s.client,
+		func(req string, paramsIn interface{}, resp interface{}) error {
+			c.Check(req, gc.Equals, "Verify")
+
+			c.Assert(paramsIn, gc.FitsTypeOf, params.BackupsVerifyArgs{})
+			p := paramsIn.(params.BackupsVerifyArgs)
+			c.Check(p.ID, gc.Equals, s.Meta.ID())
+			c.Check(p.Key, gc.Equals, "sekrit")
+
+			if result, ok := resp.(*params.BackupsVerifyResult); ok {
+				*result = params.BackupsVerifyResult{Encrypted: true, Files: 3}
+			} else {
+				c.Fatalf("wrong output structure")
+			}
+			return nil
+		},
+	)
+	defer cleanup()
+
+	result, err := s.client.Verify(s.Meta.ID(), "sekrit")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result, jc.DeepEquals, &params.BackupsVerifyResult{Encrypted: true, Files: 3})
+}
@@ -46,6 +46,17 @@ func (APICallerFunc) ConnectStream(path string, attrs url.Values) (base.Stream,
 	return nil, errors.New("stream connection unimplemented")
 }
 
+// BestVersionCaller is an APICallerFunc that reports BestVersion
+// as the best version of every facade.
+type BestVersionCaller struct {
+	APICallerFunc
+	BestVersion int
+}
+
+func (c BestVersionCaller) BestFacadeVersion(facade string) int {
+	return c.BestVersion
+}
+
 // CheckArgs holds the possible arguments to CheckingAPICaller(). Any
 // fields non empty fields will be checked to match the arguments
 // recieved by the APICall() method of the returned APICallerFunc. If
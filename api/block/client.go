@@ -49,9 +49,19 @@ func (c *Client) List() ([]params.Block, error) {
 // SwitchBlockOn switches desired block on for the current model.
 // Valid block types are "BlockDestroy", "BlockRemove" and "BlockChange".
 func (c *Client) SwitchBlockOn(blockType, msg string) error {
-	args := params.BlockSwitchParams{
+	return c.SwitchBlockOnWithParams(params.BlockSwitchParams{
 		Type:    blockType,
 		Message: msg,
+	})
+}
+
+// SwitchBlockOnWithParams switches the block described by args on for
+// the current model. A block may expire, apply only during a recurring
+// window, and not apply to some users. Expiring, scheduled and
+// user-exempt blocks need Block facade version 3.
+func (c *Client) SwitchBlockOnWithParams(args params.BlockSwitchParams) error {
+	if c.BestAPIVersion() < 3 && (args.Expires != nil || args.Schedule != nil || len(args.ExemptUsers) > 0) {
+		return errors.NotSupportedf("expiring, scheduled or user-exempt blocks on this controller")
 	}
 	result := params.ErrorResult{}
 	if err := c.facade.FacadeCall("SwitchBlockOn", args, &result); err != nil {
@@ -4,6 +4,8 @@
 package block_test
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
@@ -77,6 +79,61 @@ func (s *blockMockSuite) TestSwitchBlockOnError(c *gc.C) {
 	c.Assert(errors.Cause(err), gc.ErrorMatches, errmsg)
 }
 
+func (s *blockMockSuite) TestSwitchBlockOnWithParams(c *gc.C) {
+	called := false
+	expires := time.Date(2016, 3, 1, 12, 0, 0, 0, time.UTC)
+	expected := params.BlockSwitchParams{
+		Type:    state.ChangeBlock.String(),
+		Message: "working hours",
+		Expires: &expires,
+		Schedule: &params.BlockSchedule{
+			Start:    "0 9 * * 1-5",
+			Duration: 8 * time.Hour,
+		},
+		ExemptUsers: []string{"ops-oncall"},
+	}
+
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string,
+			version int,
+			id, request string,
+			a, response interface{},
+		) error {
+			called = true
+			c.Check(objType, gc.Equals, "Block")
+			c.Check(version, gc.Equals, 3)
+			c.Check(request, gc.Equals, "SwitchBlockOn")
+			c.Assert(a, jc.DeepEquals, expected)
+			return nil
+		},
+		BestVersion: 3,
+	}
+	blockClient := block.NewClient(apiCaller)
+	err := blockClient.SwitchBlockOnWithParams(expected)
+	c.Assert(called, jc.IsTrue)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *blockMockSuite) TestSwitchBlockOnWithParamsNeedsV3(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string,
+			version int,
+			id, request string,
+			a, response interface{},
+		) error {
+			c.Fatalf("unexpected call to %s.%s", objType, request)
+			return nil
+		},
+		BestVersion: 2,
+	}
+	blockClient := block.NewClient(apiCaller)
+	err := blockClient.SwitchBlockOnWithParams(params.BlockSwitchParams{
+		Type:        state.ChangeBlock.String(),
+		ExemptUsers: []string{"ops-oncall"},
+	})
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
 func (s *blockMockSuite) TestSwitchBlockOff(c *gc.C) {
 	called := false
 	blockType := state.DestroyBlock.String()
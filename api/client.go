@@ -16,6 +16,7 @@ import (
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"github.com/juju/names"
+	"golang.org/x/net/context"
 	"golang.org/x/net/websocket"
 	"gopkg.in/juju/charm.v6-unstable"
 	"gopkg.in/macaroon.v1"
@@ -45,6 +46,20 @@ func (c *Client) Status(patterns []string) (*params.FullStatus, error) {
 	return &result, nil
 }
 
+// StatusContext is like Status, except that the call is abandoned,
+// and the controller asked to stop gathering the status, when ctx is
+// done. Use context.WithTimeout to bound how long to wait for the
+// status of a large model.
+func (c *Client) StatusContext(ctx context.Context, patterns []string) (*params.FullStatus, error) {
+	var result params.FullStatus
+	p := params.StatusParams{Patterns: patterns}
+	err := c.st.APICallContext(ctx, c.facade.Name(), c.facade.BestAPIVersion(), "", "FullStatus", p, &result)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &result, nil
+}
+
 // UnitStatusHistory retrieves the last <size> results of <kind:combined|agent|workload> status
 // for <unitName> unit
 func (c *Client) UnitStatusHistory(kind params.HistoryKind, unitName string, size int) (*params.UnitStatusHistory, error) {
@@ -61,6 +76,23 @@ func (c *Client) UnitStatusHistory(kind params.HistoryKind, unitName string, siz
 	return &results, nil
 }
 
+// UnitHookHistory retrieves up to size of the hooks most recently run
+// by the named unit, newest first.
+func (c *Client) UnitHookHistory(unitName string, size int) ([]params.HookRecord, error) {
+	if c.BestAPIVersion() < 2 {
+		return nil, errors.NotSupportedf("hook history on this controller")
+	}
+	var result params.HookHistoryResult
+	args := params.HookHistoryArgs{
+		Unit: unitName,
+		Size: size,
+	}
+	if err := c.facade.FacadeCall("UnitHookHistory", args, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return result.Records, nil
+}
+
 // LegacyStatus is a stub version of Status that 1.16 introduced. Should be
 // removed along with structs when api versioning makes it safe to do so.
 func (c *Client) LegacyStatus() (*params.LegacyStatus, error) {
@@ -113,6 +145,11 @@ func (c *Client) PrivateAddress(target string) (string, error) {
 
 // AddMachines adds new machines with the supplied parameters.
 func (c *Client) AddMachines(machineParams []params.AddMachineParams) ([]params.AddMachinesResult, error) {
+	for _, p := range machineParams {
+		if err := c.checkConstraints(p.Constraints); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
 	args := params.AddMachines{
 		MachineParams: machineParams,
 	}
@@ -152,12 +189,24 @@ func (c *Client) GetModelConstraints() (constraints.Value, error) {
 
 // SetModelConstraints specifies the constraints for the model.
 func (c *Client) SetModelConstraints(constraints constraints.Value) error {
+	if err := c.checkConstraints(constraints); err != nil {
+		return errors.Trace(err)
+	}
 	params := params.SetConstraints{
 		Constraints: constraints,
 	}
 	return c.facade.FacadeCall("SetModelConstraints", params, nil)
 }
 
+// checkConstraints returns an error if the constraints use forms the
+// controller would not understand.
+func (c *Client) checkConstraints(cons constraints.Value) error {
+	if c.BestAPIVersion() < 4 && cons.HasExtendedForms() {
+		return errors.NotSupportedf("constraint maximums, alternatives and preferences on this controller")
+	}
+	return nil
+}
+
 // CharmInfo holds information about a charm.
 type CharmInfo struct {
 	Revision int
@@ -266,6 +315,38 @@ func (c *Client) UnshareModel(users ...names.UserTag) error {
 	return result.Combine()
 }
 
+// GrantModelRole grants the role to the given users of the model. If
+// service is not empty, the role applies only to that service.
+func (c *Client) GrantModelRole(role, service string, users ...names.UserTag) error {
+	return c.modifyModelRoles(params.GrantModelRole, role, service, users)
+}
+
+// RevokeModelRole revokes the role from the given users of the model.
+// The service must match the one the role was granted with.
+func (c *Client) RevokeModelRole(role, service string, users ...names.UserTag) error {
+	return c.modifyModelRoles(params.RevokeModelRole, role, service, users)
+}
+
+func (c *Client) modifyModelRoles(action params.ModelAction, role, service string, users []names.UserTag) error {
+	if c.BestAPIVersion() < 3 {
+		return errors.NotSupportedf("model user roles on this controller")
+	}
+	var args params.ModifyModelUsers
+	for _, user := range users {
+		args.Changes = append(args.Changes, params.ModifyModelUser{
+			UserTag: user.String(),
+			Action:  action,
+			Role:    role,
+			Service: service,
+		})
+	}
+	var result params.ErrorResults
+	if err := c.facade.FacadeCall("ShareModel", args, &result); err != nil {
+		return errors.Trace(err)
+	}
+	return result.Combine()
+}
+
 // WatchAll holds the id of the newly-created AllWatcher/AllModelWatcher.
 type WatchAll struct {
 	AllWatcherId string
@@ -553,6 +634,20 @@ type DebugLogParams struct {
 	// ExcludeModule lists logging modules to exclude from the resposne. If a
 	// module is specified, all the submodules are also excluded.
 	ExcludeModule []string
+	// IncludeFields restricts the response to lines with one of the
+	// given values for each named field, e.g. {"hook": {"install"}}.
+	IncludeFields map[string][]string
+	// Grep is a regular expression that messages must match to be
+	// included in the response.
+	Grep string
+	// ExcludeGrep is a regular expression that messages must not
+	// match to be included in the response.
+	ExcludeGrep string
+	// Since, if not zero, excludes messages logged before this time.
+	Since time.Time
+	// Until, if not zero, excludes messages logged at or after this
+	// time. Once it has passed, no new messages are waited for.
+	Until time.Time
 	// Limit defines the maximum number of lines to return. Once this many
 	// have been sent, the socket is closed.  If zero, all filtered lines are
 	// sent down the connection until the client closes the connection.
@@ -569,6 +664,9 @@ type DebugLogParams struct {
 	// NoTail tells the server to only return the logs it has now, and not
 	// to wait for new logs to arrive.
 	NoTail bool
+	// FromArchive tells the server to return logs from the archives
+	// of pruned logs rather than the database. NoTail is implied.
+	FromArchive bool
 }
 
 // WatchDebugLog returns a ReadCloser that the caller can read the log
@@ -600,6 +698,9 @@ func (c *Client) WatchDebugLog(args DebugLogParams) (io.ReadCloser, error) {
 	if args.NoTail {
 		attrs.Set("noTail", fmt.Sprint(args.NoTail))
 	}
+	if args.FromArchive {
+		attrs.Set("fromArchive", fmt.Sprint(args.FromArchive))
+	}
 	if args.Limit > 0 {
 		attrs.Set("maxLines", fmt.Sprint(args.Limit))
 	}
@@ -609,6 +710,21 @@ func (c *Client) WatchDebugLog(args DebugLogParams) (io.ReadCloser, error) {
 	if args.Level != loggo.UNSPECIFIED {
 		attrs.Set("level", fmt.Sprint(args.Level))
 	}
+	for name, values := range args.IncludeFields {
+		attrs["field."+name] = values
+	}
+	if args.Grep != "" {
+		attrs.Set("grep", args.Grep)
+	}
+	if args.ExcludeGrep != "" {
+		attrs.Set("excludeGrep", args.ExcludeGrep)
+	}
+	if !args.Since.IsZero() {
+		attrs.Set("startTime", args.Since.Format(time.RFC3339))
+	}
+	if !args.Until.IsZero() {
+		attrs.Set("endTime", args.Until.Format(time.RFC3339))
+	}
 
 	connection, err := c.st.ConnectStream("/log", attrs)
 	if err != nil {
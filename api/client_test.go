@@ -14,12 +14,14 @@ import (
 	"net/url"
 	"path"
 	"strings"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/httprequest"
 	"github.com/juju/loggo"
 	"github.com/juju/names"
 	jc "github.com/juju/testing/checkers"
+	"golang.org/x/net/context"
 	"golang.org/x/net/websocket"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/charm.v6-unstable"
@@ -202,6 +204,26 @@ func (s *clientSuite) TestClientEnvironmentUUID(c *gc.C) {
 	c.Assert(client.ModelUUID(), gc.Equals, environ.Tag().Id())
 }
 
+func (s *clientSuite) TestStatusContext(c *gc.C) {
+	s.Factory.MakeMachine(c, nil)
+	ctx, cancel := context.WithTimeout(context.Background(), coretesting.LongWait)
+	defer cancel()
+	status, err := s.APIState.Client().StatusContext(ctx, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(status.Machines, gc.HasLen, 1)
+}
+
+func (s *clientSuite) TestStatusContextCancelled(c *gc.C) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := s.APIState.Client().StatusContext(ctx, nil)
+	c.Assert(errors.Cause(err), gc.Equals, context.Canceled)
+
+	// The connection remains usable.
+	_, err = s.APIState.Client().Status(nil)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
 func (s *clientSuite) TestClientEnvironmentUsers(c *gc.C) {
 	client := s.APIState.Client()
 	cleanup := api.PatchClientFacadeCall(client,
@@ -315,6 +337,34 @@ func (s *clientSuite) TestShareEnvironmentThreeUsers(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, `existing user`)
 }
 
+func (s *clientSuite) TestGrantModelRole(c *gc.C) {
+	client := s.APIState.Client()
+	user := names.NewUserTag("bob@local")
+	var called bool
+	cleanup := api.PatchClientFacadeCall(client,
+		func(request string, paramsIn interface{}, response interface{}) error {
+			called = true
+			c.Assert(request, gc.Equals, "ShareModel")
+			c.Assert(paramsIn, jc.DeepEquals, params.ModifyModelUsers{
+				Changes: []params.ModifyModelUser{{
+					UserTag: user.String(),
+					Action:  params.GrantModelRole,
+					Role:    "deployer",
+					Service: "wordpress",
+				}},
+			})
+			result := response.(*params.ErrorResults)
+			*result = params.ErrorResults{Results: []params.ErrorResult{{}}}
+			return nil
+		},
+	)
+	defer cleanup()
+
+	err := client.GrantModelRole("deployer", "wordpress", user)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
 func (s *clientSuite) TestUnshareEnvironmentThreeUsers(c *gc.C) {
 	client := s.APIState.Client()
 	missingUser := s.Factory.MakeModelUser(c, nil)
@@ -444,11 +494,17 @@ func (s *clientSuite) TestWatchDebugLogParamsEncoded(c *gc.C) {
 		IncludeModule: []string{"c", "d"},
 		ExcludeEntity: []string{"e", "f"},
 		ExcludeModule: []string{"g", "h"},
+		IncludeFields: map[string][]string{"hook": {"install", "start"}},
+		Grep:          "hook (install|start)",
+		ExcludeGrep:   "retry",
+		Since:         time.Date(2016, 4, 1, 12, 0, 0, 0, time.UTC),
+		Until:         time.Date(2016, 4, 1, 13, 0, 0, 0, time.UTC),
 		Limit:         100,
 		Backlog:       200,
 		Level:         loggo.ERROR,
 		Replay:        true,
 		NoTail:        true,
+		FromArchive:   true,
 	}
 
 	client := s.APIState.Client()
@@ -462,11 +518,17 @@ func (s *clientSuite) TestWatchDebugLogParamsEncoded(c *gc.C) {
 		"includeModule": params.IncludeModule,
 		"excludeEntity": params.ExcludeEntity,
 		"excludeModule": params.ExcludeModule,
+		"field.hook":    {"install", "start"},
+		"grep":          {"hook (install|start)"},
+		"excludeGrep":   {"retry"},
+		"startTime":     {"2016-04-01T12:00:00Z"},
+		"endTime":       {"2016-04-01T13:00:00Z"},
 		"maxLines":      {"100"},
 		"backlog":       {"200"},
 		"level":         {"ERROR"},
 		"replay":        {"true"},
 		"noTail":        {"true"},
+		"fromArchive":   {"true"},
 	})
 }
 
@@ -54,6 +54,16 @@ func (c *Client) AllModels() ([]base.UserModel, error) {
 	return result, nil
 }
 
+// AuditLog returns the entries in the controller audit log that match
+// the given filter, most recent first.
+func (c *Client) AuditLog(filter params.AuditLogFilter) ([]params.AuditLogEntry, error) {
+	var result params.AuditLogResults
+	if err := c.facade.FacadeCall("AuditLog", filter, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return result.Entries, nil
+}
+
 // ModelConfig returns all model settings for the
 // controller model.
 func (c *Client) ModelConfig() (map[string]interface{}, error) {
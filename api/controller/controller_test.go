@@ -115,6 +115,24 @@ func (s *controllerSuite) TestRemoveBlocks(c *gc.C) {
 	c.Assert(blocks, gc.HasLen, 0)
 }
 
+func (s *controllerSuite) TestAuditLog(c *gc.C) {
+	sysManager := s.OpenAPI(c)
+	err := sysManager.RemoveBlocks()
+	c.Assert(err, jc.ErrorIsNil)
+
+	entries, err := sysManager.AuditLog(params.AuditLogFilter{
+		UserTag: s.AdminUserTag(c).String(),
+		Facade:  "Controller",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(entries, gc.HasLen, 1)
+	c.Check(entries[0].OriginTag, gc.Equals, s.AdminUserTag(c).String())
+	c.Check(entries[0].ModelTag, gc.Equals, s.State.ModelTag().String())
+	c.Check(entries[0].Method, gc.Equals, "RemoveBlocks")
+	c.Check(entries[0].Args, gc.Equals, `{"all":true}`)
+	c.Check(entries[0].Outcome, gc.Equals, "succeeded")
+}
+
 func (s *controllerSuite) TestWatchAllModels(c *gc.C) {
 	// The WatchAllModels infrastructure is comprehensively tested
 	// else. This test just ensure that the API calls work end-to-end.
@@ -26,6 +26,12 @@ func SetServerAddress(c *Client, scheme, addr string) {
 	c.st.addr = addr
 }
 
+// UsingBSON reports whether the connection is sending
+// messages in BSON format.
+func UsingBSON(c Connection) bool {
+	return c.(*state).codec.IsBSON()
+}
+
 // ServerRoot is exported so that we can test the built URL.
 func ServerRoot(c *Client) string {
 	return c.st.serverRoot()
@@ -15,18 +15,19 @@ import (
 // New facades should start at 1.
 // Facades that existed before versioning start at 0.
 var facadeVersions = map[string]int{
-	"Action":                       1,
+	"Action":                       2,
+	"ActionPruner":                 1,
 	"Addresser":                    2,
 	"Agent":                        2,
 	"AgentTools":                   1,
 	"AllWatcher":                   1,
 	"AllModelWatcher":              2,
 	"Annotations":                  2,
-	"Backups":                      1,
-	"Block":                        2,
+	"Backups":                      2,
+	"Block":                        3,
 	"Charms":                       2,
 	"CharmRevisionUpdater":         1,
-	"Client":                       1,
+	"Client":                       4,
 	"Cleaner":                      2,
 	"Controller":                   2,
 	"Deployer":                     1,
@@ -35,7 +36,7 @@ var facadeVersions = map[string]int{
 	"EntityWatcher":                2,
 	"FilesystemAttachmentsWatcher": 2,
 	"Firewaller":                   2,
-	"HighAvailability":             2,
+	"HighAvailability":             3,
 	"ImageManager":                 2,
 	"ImageMetadata":                2,
 	"InstancePoller":               2,
@@ -57,7 +58,7 @@ var facadeVersions = map[string]int{
 	"Reboot":                       2,
 	"RelationUnitsWatcher":         1,
 	"Resumer":                      2,
-	"Service":                      3,
+	"Service":                      5,
 	"Storage":                      2,
 	"Spaces":                       2,
 	"Subnets":                      2,
@@ -66,7 +67,7 @@ var facadeVersions = map[string]int{
 	"StringsWatcher":               1,
 	"Upgrader":                     1,
 	"UnitAssigner":                 1,
-	"Uniter":                       3,
+	"Uniter":                       4,
 	"UserManager":                  1,
 	"VolumeAttachmentsWatcher":     2,
 	"Undertaker":                   1,
@@ -36,6 +36,9 @@ func NewClient(caller base.APICallCloser) *Client {
 func (c *Client) EnableHA(
 	numControllers int, cons constraints.Value, series string, placement []string,
 ) (params.ControllersChanges, error) {
+	if c.BestAPIVersion() < 3 && cons.HasExtendedForms() {
+		return params.ControllersChanges{}, errors.NotSupportedf("constraint maximums, alternatives and preferences on this controller")
+	}
 
 	var results params.ControllersChangeResults
 	arg := params.ControllersSpecs{
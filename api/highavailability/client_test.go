@@ -9,6 +9,7 @@ import (
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
+	basetesting "github.com/juju/juju/api/base/testing"
 	"github.com/juju/juju/api/highavailability"
 	"github.com/juju/juju/constraints"
 	jujutesting "github.com/juju/juju/juju/testing"
@@ -77,5 +78,18 @@ func (s *clientSuite) TestClientEnableHA(c *gc.C) {
 
 func (s *clientSuite) TestClientEnableHAVersion(c *gc.C) {
 	client := highavailability.NewClient(s.APIState)
-	c.Assert(client.BestAPIVersion(), gc.Equals, 2)
+	c.Assert(client.BestAPIVersion(), gc.Equals, 3)
+}
+
+func (s *clientSuite) TestClientEnableHAExtendedConstraintsNeedV3(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, result interface{}) error {
+			c.Fatalf("unexpected call to %s.%s", objType, request)
+			return nil
+		},
+		BestVersion: 2,
+	}
+	client := highavailability.NewClient(apiCaller)
+	_, err := client.EnableHA(3, constraints.MustParse("mem=4G..16G"), "", nil)
+	c.Assert(err, gc.ErrorMatches, "constraint maximums, alternatives and preferences on this controller not supported")
 }
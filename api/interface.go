@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/juju/names"
+	"golang.org/x/net/context"
 	"gopkg.in/macaroon-bakery.v1/httpbakery"
 
 	"github.com/juju/juju/api/addresser"
@@ -92,6 +93,15 @@ type DialOpts struct {
 	// by Open, and any RoundTripper field
 	// the HTTP client is ignored.
 	BakeryClient *httpbakery.Client
+
+	// UseBSON requests that, after login, API requests and
+	// responses are encoded as BSON rather than JSON if the
+	// controller supports it. BSON is cheaper to encode and
+	// decode for large results such as FullStatus. Note that
+	// BSON ignores the json tags on the params types, so the
+	// wire field names differ from the JSON API; see the
+	// rpc/bsoncodec package for details.
+	UseBSON bool
 }
 
 // DefaultDialOpts returns a DialOpts representing the default
@@ -129,6 +139,11 @@ type Connection interface {
 	// This should not be used outside the api/* packages or tests.
 	base.APICaller
 
+	// APICallContext is like APICall, except that the call is
+	// abandoned when ctx is done, and the server is asked to
+	// cancel it. The server is also told of ctx's deadline.
+	APICallContext(ctx context.Context, facade string, version int, id, method string, args, response interface{}) error
+
 	// ControllerTag returns the model tag of the controller
 	// (as opposed to the model tag of the currently connected
 	// model inside that controller).
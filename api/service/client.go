@@ -96,6 +96,9 @@ type DeployArgs struct {
 // using constraints. Placement directives, if provided, specify the
 // machine on which the charm is deployed.
 func (c *Client) Deploy(args DeployArgs) error {
+	if err := c.checkConstraints(args.Cons); err != nil {
+		return errors.Trace(err)
+	}
 	deployArgs := params.ServicesDeploy{
 		Services: []params.ServiceDeploy{{
 			ServiceName:      args.ServiceName,
@@ -135,6 +138,22 @@ func (c *Client) GetCharmURL(serviceName string) (*charm.URL, error) {
 	return charm.ParseURL(result.Result)
 }
 
+// ExportBundle returns a bundle, in YAML format, describing the
+// services, machines and relations in the model.
+func (c *Client) ExportBundle() (string, error) {
+	if c.BestAPIVersion() < 5 {
+		return "", errors.NotSupportedf("exporting bundles on this controller")
+	}
+	var result params.StringResult
+	if err := c.facade.FacadeCall("ExportBundle", nil, &result); err != nil {
+		return "", errors.Trace(err)
+	}
+	if result.Error != nil {
+		return "", result.Error
+	}
+	return result.Result, nil
+}
+
 // SetCharm sets the charm for a given service.
 func (c *Client) SetCharm(serviceName string, charmUrl string, forceSeries, forceUnits bool) error {
 	args := params.ServiceSetCharm{
@@ -149,6 +168,11 @@ func (c *Client) SetCharm(serviceName string, charmUrl string, forceSeries, forc
 // Update updates the service attributes, including charm URL,
 // minimum number of units, settings and constraints.
 func (c *Client) Update(args params.ServiceUpdate) error {
+	if args.Constraints != nil {
+		if err := c.checkConstraints(*args.Constraints); err != nil {
+			return errors.Trace(err)
+		}
+	}
 	return c.facade.FacadeCall("Update", args, nil)
 }
 
@@ -188,6 +212,9 @@ func (c *Client) GetConstraints(service string) (constraints.Value, error) {
 
 // SetConstraints specifies the constraints for the given service.
 func (c *Client) SetConstraints(service string, constraints constraints.Value) error {
+	if err := c.checkConstraints(constraints); err != nil {
+		return errors.Trace(err)
+	}
 	params := params.SetConstraints{
 		ServiceName: service,
 		Constraints: constraints,
@@ -195,6 +222,15 @@ func (c *Client) SetConstraints(service string, constraints constraints.Value) e
 	return c.facade.FacadeCall("SetConstraints", params, nil)
 }
 
+// checkConstraints returns an error if the constraints use forms the
+// controller would not understand.
+func (c *Client) checkConstraints(cons constraints.Value) error {
+	if c.BestAPIVersion() < 4 && cons.HasExtendedForms() {
+		return errors.NotSupportedf("constraint maximums, alternatives and preferences on this controller")
+	}
+	return nil
+}
+
 // Expose changes the juju-managed firewall to expose any ports that
 // were also explicitly marked by units as open.
 func (c *Client) Expose(service string) error {
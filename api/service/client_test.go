@@ -117,6 +117,23 @@ func (s *serviceSuite) TestSetServiceDeploy(c *gc.C) {
 	c.Assert(called, jc.IsTrue)
 }
 
+func (s *serviceSuite) TestSetServiceDeployExtendedConstraintsNeedV4(c *gc.C) {
+	service.PatchFacadeCall(s, s.client, func(request string, a, response interface{}) error {
+		c.Fatalf("unexpected call to %s", request)
+		return nil
+	})
+	service.SetBestAPIVersion(s.client, 3)
+
+	err := s.client.Deploy(service.DeployArgs{
+		CharmURL:    "charmURL",
+		ServiceName: "serviceA",
+		Cons:        constraints.MustParse("arch=amd64|arm64"),
+	})
+	c.Assert(err, gc.ErrorMatches, "constraint maximums, alternatives and preferences on this controller not supported")
+	err = s.client.SetConstraints("serviceA", constraints.MustParse("mem=prefer:4G"))
+	c.Assert(err, gc.ErrorMatches, "constraint maximums, alternatives and preferences on this controller not supported")
+}
+
 func (s *serviceSuite) TestServiceGetCharmURL(c *gc.C) {
 	var called bool
 	service.PatchFacadeCall(s, s.client, func(request string, a, response interface{}) error {
@@ -136,6 +153,34 @@ func (s *serviceSuite) TestServiceGetCharmURL(c *gc.C) {
 	c.Assert(called, jc.IsTrue)
 }
 
+func (s *serviceSuite) TestServiceExportBundle(c *gc.C) {
+	var called bool
+	service.PatchFacadeCall(s, s.client, func(request string, a, response interface{}) error {
+		called = true
+		c.Assert(request, gc.Equals, "ExportBundle")
+		c.Assert(a, gc.IsNil)
+
+		result := response.(*params.StringResult)
+		result.Result = "services: {}\n"
+		return nil
+	})
+	bundle, err := s.client.ExportBundle()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(bundle, gc.Equals, "services: {}\n")
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *serviceSuite) TestServiceExportBundleNeedsV5(c *gc.C) {
+	service.PatchFacadeCall(s, s.client, func(request string, a, response interface{}) error {
+		c.Fatalf("unexpected call to %s", request)
+		return nil
+	})
+	service.SetBestAPIVersion(s.client, 4)
+
+	_, err := s.client.ExportBundle()
+	c.Assert(err, gc.ErrorMatches, "exporting bundles on this controller not supported")
+}
+
 func (s *serviceSuite) TestServiceSetCharm(c *gc.C) {
 	var called bool
 	service.PatchFacadeCall(s, s.client, func(request string, a, response interface{}) error {
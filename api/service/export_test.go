@@ -4,6 +4,7 @@
 package service
 
 import (
+	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/api/base/testing"
 )
 
@@ -13,3 +14,18 @@ import (
 func PatchFacadeCall(p testing.Patcher, client *Client, f func(request string, params, response interface{}) error) {
 	testing.PatchFacadeCall(p, &client.facade, f)
 }
+
+// SetBestAPIVersion makes the client behave as if the best version of
+// the Service facade the controller offers is the given one.
+func SetBestAPIVersion(client *Client, version int) {
+	client.ClientFacade = bestVersionFacade{client.ClientFacade, version}
+}
+
+type bestVersionFacade struct {
+	base.ClientFacade
+	version int
+}
+
+func (f bestVersionFacade) BestAPIVersion() int {
+	return f.version
+}
@@ -119,6 +119,7 @@ func (st *state) loginV2(tag names.Tag, password, nonce string) error {
 	if err != nil {
 		return errors.Trace(err)
 	}
+	st.serverCodecs = result.Codecs
 	return nil
 }
 
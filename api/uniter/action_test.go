@@ -4,6 +4,8 @@
 package uniter_test
 
 import (
+	"time"
+
 	"github.com/juju/names"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
@@ -70,6 +72,32 @@ func (s *actionSuite) TestActionNotFound(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, `action "feedface-0123-4567-8901-2345deadbeef" not found`)
 }
 
+func (s *actionSuite) TestActionTimeout(c *gc.C) {
+	a, err := s.uniterSuite.wordpressUnit.AddActionWithTimeout("fakeaction", nil, time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+
+	retrievedAction, err := s.uniter.Action(a.ActionTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(retrievedAction.Timeout(), gc.Equals, time.Minute)
+}
+
+func (s *actionSuite) TestActionCancelRequested(c *gc.C) {
+	a, err := s.uniterSuite.wordpressUnit.AddAction("fakeaction", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.uniter.ActionBegin(a.ActionTag())
+	c.Assert(err, jc.ErrorIsNil)
+
+	requested, err := s.uniter.ActionCancelRequested(a.ActionTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(requested, jc.IsFalse)
+
+	_, err = a.Cancel()
+	c.Assert(err, jc.ErrorIsNil)
+	requested, err = s.uniter.ActionCancelRequested(a.ActionTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(requested, jc.IsTrue)
+}
+
 func (s *actionSuite) TestNewActionAndAccessors(c *gc.C) {
 	testAction, err := uniter.NewAction("snapshot", basicParams)
 	c.Assert(err, jc.ErrorIsNil)
@@ -5,6 +5,7 @@ package uniter
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/names"
@@ -277,6 +278,43 @@ func (u *Unit) Resolved() (params.ResolvedMode, error) {
 	return result.Mode, nil
 }
 
+// HookTimeout returns the duration after which a hook run by the unit
+// is killed. A zero duration means hooks are never timed out.
+func (u *Unit) HookTimeout() (time.Duration, error) {
+	var results params.HookTimeoutResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: u.tag.String()}},
+	}
+	err := u.st.facade.FacadeCall("HookTimeouts", args, &results)
+	if err != nil {
+		return 0, err
+	}
+	if len(results.Results) != 1 {
+		return 0, fmt.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return 0, result.Error
+	}
+	return result.Timeout, nil
+}
+
+// AddHookRecord records that the unit ran a hook.
+func (u *Unit) AddHookRecord(record params.HookRecord) error {
+	if u.st.facade.BestAPIVersion() < 4 {
+		return errors.NotImplementedf("AddHookRecord() (need V4+)")
+	}
+	var result params.ErrorResults
+	args := params.UnitHookRecords{
+		Records: []params.UnitHookRecord{{Tag: u.tag.String(), Record: record}},
+	}
+	err := u.st.facade.FacadeCall("AddHookRecords", args, &result)
+	if err != nil {
+		return err
+	}
+	return result.OneError()
+}
+
 // AssignedMachine returns the unit's assigned machine tag or an error
 // satisfying params.IsCodeNotAssigned when the unit has no assigned
 // machine..
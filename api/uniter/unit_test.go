@@ -248,6 +248,36 @@ func (s *unitSuite) TestResolve(c *gc.C) {
 	c.Assert(mode, gc.Equals, params.ResolvedNone)
 }
 
+func (s *unitSuite) TestHookTimeout(c *gc.C) {
+	timeout, err := s.apiUnit.HookTimeout()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(timeout, gc.Equals, time.Duration(0))
+
+	err = s.wordpressService.SetHookTimeout(time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+	timeout, err = s.apiUnit.HookTimeout()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(timeout, gc.Equals, time.Minute)
+}
+
+func (s *unitSuite) TestAddHookRecord(c *gc.C) {
+	started := time.Date(2016, 4, 1, 12, 0, 0, 0, time.UTC)
+	err := s.apiUnit.AddHookRecord(params.HookRecord{
+		Hook:     "install",
+		Started:  started,
+		Duration: time.Minute,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	history, err := s.wordpressUnit.HookHistory(10)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, jc.DeepEquals, []state.HookRecord{{
+		Hook:     "install",
+		Started:  started,
+		Duration: time.Minute,
+	}})
+}
+
 func (s *unitSuite) TestAssignedMachine(c *gc.C) {
 	machineTag, err := s.apiUnit.AssignedMachine()
 	c.Assert(err, jc.ErrorIsNil)
@@ -69,12 +69,12 @@ func newStateForVersionFn(version int) func(base.APICaller, names.UnitTag) *Stat
 	}
 }
 
-// newStateV3 creates a new client-side Uniter facade, version 3.
-var newStateV3 = newStateForVersionFn(3)
+// newStateV4 creates a new client-side Uniter facade, version 4.
+var newStateV4 = newStateForVersionFn(4)
 
 // NewState creates a new client-side Uniter facade.
 // Defined like this to allow patching during tests.
-var NewState = newStateV3
+var NewState = newStateV4
 
 // BestAPIVersion returns the API version that we were able to
 // determine is supported by both the client and the API Server.
@@ -217,11 +217,38 @@ func (st *State) Action(tag names.ActionTag) (*Action, error) {
 		return nil, err
 	}
 	return &Action{
-		name:   result.Action.Action.Name,
-		params: result.Action.Action.Parameters,
+		name:    result.Action.Action.Name,
+		params:  result.Action.Action.Parameters,
+		timeout: result.Action.Action.Timeout,
 	}, nil
 }
 
+// ActionCancelRequested reports whether the running action has been
+// asked to stop.
+func (st *State) ActionCancelRequested(tag names.ActionTag) (bool, error) {
+	if st.BestAPIVersion() < 4 {
+		return false, errors.NotImplementedf("ActionCancelRequested() (need V4+)")
+	}
+	var results params.BoolResults
+	args := params.Entities{
+		Entities: []params.Entity{
+			{Tag: tag.String()},
+		},
+	}
+	err := st.facade.FacadeCall("ActionsCancelRequested", args, &results)
+	if err != nil {
+		return false, err
+	}
+	if len(results.Results) != 1 {
+		return false, fmt.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.Result, nil
+}
+
 // ActionBegin marks an action as running.
 func (st *State) ActionBegin(tag names.ActionTag) error {
 	var outcome params.ErrorResults
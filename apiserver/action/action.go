@@ -4,6 +4,7 @@
 package action
 
 import (
+	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"github.com/juju/names"
 
@@ -16,6 +17,7 @@ var logger = loggo.GetLogger("juju.apiserver.action")
 
 func init() {
 	common.RegisterStandardFacade("Action", 1, NewActionAPI)
+	common.RegisterStandardFacade("Action", 2, NewActionAPIV2)
 }
 
 // ActionAPI implements the client API for interacting with Actions
@@ -38,6 +40,21 @@ func NewActionAPI(st *state.State, resources *common.Resources, authorizer commo
 	}, nil
 }
 
+// ActionAPIV2 implements version 2 of the Action API, which adds
+// queueing Actions on the units of a service, and the action history.
+type ActionAPIV2 struct {
+	*ActionAPI
+}
+
+// NewActionAPIV2 returns an initialized ActionAPIV2.
+func NewActionAPIV2(st *state.State, resources *common.Resources, authorizer common.Authorizer) (*ActionAPIV2, error) {
+	api, err := NewActionAPI(st, resources, authorizer)
+	if err != nil {
+		return nil, err
+	}
+	return &ActionAPIV2{api}, nil
+}
+
 // Actions takes a list of ActionTags, and returns the full Action for
 // each ID.
 func (a *ActionAPI) Actions(arg params.Entities) (params.ActionResults, error) {
@@ -97,7 +114,7 @@ func (a *ActionAPI) Enqueue(arg params.Actions) (params.ActionResults, error) {
 			currentResult.Error = common.ServerError(err)
 			continue
 		}
-		enqueued, err := receiver.AddAction(action.Name, action.Parameters)
+		enqueued, err := receiver.AddActionWithTimeout(action.Name, action.Parameters, action.Timeout)
 		if err != nil {
 			currentResult.Error = common.ServerError(err)
 			continue
@@ -108,6 +125,150 @@ func (a *ActionAPI) Enqueue(arg params.Actions) (params.ActionResults, error) {
 	return response, nil
 }
 
+// EnqueueService queues up each of the given Actions on every unit of
+// its service, or only on the leader unit if requested. The Actions
+// queued for each service share a group id, with which their results
+// can be fetched together by ActionGroups.
+func (a *ActionAPIV2) EnqueueService(arg params.ServiceActions) (params.ActionGroupResults, error) {
+	response := params.ActionGroupResults{Results: make([]params.ActionGroupResult, len(arg.Actions))}
+	for i, action := range arg.Actions {
+		currentResult := &response.Results[i]
+		units, err := a.serviceActionUnits(action)
+		if err != nil {
+			currentResult.Error = common.ServerError(err)
+			continue
+		}
+		group, err := state.NewActionGroup()
+		if err != nil {
+			currentResult.Error = common.ServerError(err)
+			continue
+		}
+		currentResult.Group = group
+		currentResult.Actions = make([]params.ActionResult, len(units))
+		for j, unit := range units {
+			enqueued, err := unit.AddActionInGroup(group, action.Name, action.Parameters, action.Timeout)
+			if err != nil {
+				currentResult.Actions[j] = params.ActionResult{
+					Action: &params.Action{Receiver: unit.Tag().String(), Name: action.Name},
+					Error:  common.ServerError(err),
+				}
+				continue
+			}
+			currentResult.Actions[j] = makeActionResult(unit.Tag(), enqueued)
+		}
+	}
+	return response, nil
+}
+
+// serviceActionUnits returns the units on which the given Action
+// should be queued.
+func (a *ActionAPI) serviceActionUnits(action params.ServiceAction) ([]*state.Unit, error) {
+	serviceTag, err := names.ParseServiceTag(action.Service)
+	if err != nil {
+		return nil, common.ErrBadId
+	}
+	service, err := a.state.Service(serviceTag.Id())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	units, err := service.AllUnits()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(units) == 0 {
+		return nil, errors.Errorf("service %q has no units", service.Name())
+	}
+	if !action.Leader {
+		return units, nil
+	}
+	checker := a.state.LeadershipChecker()
+	for _, unit := range units {
+		token := checker.LeadershipCheck(service.Name(), unit.Name())
+		if token.Check(nil) == nil {
+			return []*state.Unit{unit}, nil
+		}
+	}
+	return nil, errors.Errorf("service %q has no leader", service.Name())
+}
+
+// ActionGroups returns, for each of the given groups, the results of
+// all the Actions queued together in that group.
+func (a *ActionAPIV2) ActionGroups(arg params.ActionGroups) (params.ActionGroupResults, error) {
+	response := params.ActionGroupResults{Results: make([]params.ActionGroupResult, len(arg.Groups))}
+	for i, group := range arg.Groups {
+		currentResult := &response.Results[i]
+		currentResult.Group = group
+		actions, err := a.state.ActionsInGroup(group)
+		if err != nil {
+			currentResult.Error = common.ServerError(err)
+			continue
+		}
+		currentResult.Actions = make([]params.ActionResult, len(actions))
+		for j, action := range actions {
+			receiverTag, err := names.ActionReceiverTag(action.Receiver())
+			if err != nil {
+				currentResult.Actions[j].Error = common.ServerError(err)
+				continue
+			}
+			currentResult.Actions[j] = makeActionResult(receiverTag, action)
+		}
+	}
+	return response, nil
+}
+
+// History returns, for each of the given queries, the matching Actions
+// from the history of all Actions queued in the model, most recently
+// queued first.
+func (a *ActionAPIV2) History(arg params.ActionHistoryQueries) (params.ActionHistoryResults, error) {
+	response := params.ActionHistoryResults{Results: make([]params.ActionHistoryResult, len(arg.Queries))}
+	for i, query := range arg.Queries {
+		currentResult := &response.Results[i]
+		stateQuery, err := historyQuery(query)
+		if err != nil {
+			currentResult.Error = common.ServerError(err)
+			continue
+		}
+		actions, err := a.state.FindActions(stateQuery)
+		if err != nil {
+			currentResult.Error = common.ServerError(err)
+			continue
+		}
+		currentResult.Actions = make([]params.ActionResult, len(actions))
+		for j, action := range actions {
+			receiverTag, err := names.ActionReceiverTag(action.Receiver())
+			if err != nil {
+				currentResult.Actions[j].Error = common.ServerError(err)
+				continue
+			}
+			currentResult.Actions[j] = makeActionResult(receiverTag, action)
+		}
+	}
+	return response, nil
+}
+
+// historyQuery converts an ActionHistoryQuery into the equivalent
+// state.ActionsQuery.
+func historyQuery(query params.ActionHistoryQuery) (state.ActionsQuery, error) {
+	result := state.ActionsQuery{
+		Name:           query.Name,
+		EnqueuedAfter:  query.EnqueuedAfter,
+		EnqueuedBefore: query.EnqueuedBefore,
+		Offset:         query.Offset,
+		Limit:          query.Limit,
+	}
+	if query.Receiver != "" {
+		receiverTag, err := names.ParseTag(query.Receiver)
+		if err != nil {
+			return state.ActionsQuery{}, common.ErrBadId
+		}
+		result.Receiver = receiverTag.Id()
+	}
+	for _, status := range query.Statuses {
+		result.Statuses = append(result.Statuses, state.ActionStatus(status))
+	}
+	return result, nil
+}
+
 // ListAll takes a list of Entities representing ActionReceivers and
 // returns all of the Actions that have been enqueued or run by each of
 // those Entities.
@@ -136,7 +297,8 @@ func (a *ActionAPI) ListCompleted(arg params.Entities) (params.ActionsByReceiver
 	return a.internalList(arg, completedActions)
 }
 
-// Cancel attempts to cancel enqueued Actions from running.
+// Cancel attempts to cancel Actions. Pending Actions are cancelled
+// straight away, and running Actions are asked to stop.
 func (a *ActionAPI) Cancel(arg params.Entities) (params.ActionResults, error) {
 	response := params.ActionResults{Results: make([]params.ActionResult, len(arg.Entities))}
 	for i, entity := range arg.Entities {
@@ -156,7 +318,7 @@ func (a *ActionAPI) Cancel(arg params.Entities) (params.ActionResults, error) {
 			currentResult.Error = common.ServerError(err)
 			continue
 		}
-		result, err := action.Finish(state.ActionResults{Status: state.ActionCancelled, Message: "action cancelled via the API"})
+		result, err := action.Cancel()
 		if err != nil {
 			currentResult.Error = common.ServerError(err)
 			continue
@@ -313,12 +475,15 @@ func makeActionResult(actionReceiverTag names.Tag, action *state.Action) params.
 			Tag:        action.ActionTag().String(),
 			Name:       action.Name(),
 			Parameters: action.Parameters(),
+			Timeout:    action.Timeout(),
+			Group:      action.Group(),
 		},
-		Status:    string(action.Status()),
-		Message:   message,
-		Output:    output,
-		Enqueued:  action.Enqueued(),
-		Started:   action.Started(),
-		Completed: action.Completed(),
+		Status:          string(action.Status()),
+		Message:         message,
+		Output:          output,
+		Enqueued:        action.Enqueued(),
+		Started:         action.Started(),
+		Completed:       action.Completed(),
+		CancelRequested: action.CancelRequested(),
 	}
 }
@@ -6,6 +6,7 @@ package action_test
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/juju/names"
 	jc "github.com/juju/testing/checkers"
@@ -29,7 +30,7 @@ func TestAll(t *testing.T) {
 type actionSuite struct {
 	jujutesting.JujuConnSuite
 
-	action     *action.ActionAPI
+	action     *action.ActionAPIV2
 	authorizer apiservertesting.FakeAuthorizer
 	resources  *common.Resources
 
@@ -52,7 +53,7 @@ func (s *actionSuite) SetUpTest(c *gc.C) {
 		Tag: s.AdminUserTag(c),
 	}
 	var err error
-	s.action, err = action.NewActionAPI(s.State, nil, s.authorizer)
+	s.action, err = action.NewActionAPIV2(s.State, nil, s.authorizer)
 	c.Assert(err, jc.ErrorIsNil)
 
 	factory := jujuFactory.NewFactory(s.State)
@@ -574,6 +575,120 @@ func (s *actionSuite) TestCancel(c *gc.C) {
 	c.Assert(myActions[1].Status, gc.Equals, params.ActionCancelled)
 }
 
+func (s *actionSuite) TestCancelRunning(c *gc.C) {
+	action, err := s.wordpressUnit.AddAction("fakeaction", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = action.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := s.action.Cancel(params.Entities{
+		Entities: []params.Entity{{Tag: action.Tag().String()}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[0].Status, gc.Equals, params.ActionRunning)
+	c.Assert(results.Results[0].CancelRequested, jc.IsTrue)
+}
+
+func (s *actionSuite) TestEnqueueWithTimeout(c *gc.C) {
+	results, err := s.action.Enqueue(params.Actions{
+		Actions: []params.Action{{
+			Receiver: s.wordpressUnit.Tag().String(),
+			Name:     "fakeaction",
+			Timeout:  5 * time.Minute,
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[0].Action.Timeout, gc.Equals, 5*time.Minute)
+}
+
+func (s *actionSuite) TestEnqueueService(c *gc.C) {
+	results, err := s.action.EnqueueService(params.ServiceActions{
+		Actions: []params.ServiceAction{{
+			Service: s.wordpress.Tag().String(),
+			Name:    "fakeaction",
+		}, {
+			Service: s.wordpress.Tag().String(),
+			Name:    "fakeaction",
+			Leader:  true,
+		}, {
+			Service: "unit-wordpress-0",
+			Name:    "fakeaction",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 3)
+
+	result := results.Results[0]
+	c.Assert(result.Error, gc.IsNil)
+	c.Assert(result.Group, gc.Not(gc.Equals), "")
+	c.Assert(result.Actions, gc.HasLen, 1)
+	c.Assert(result.Actions[0].Error, gc.IsNil)
+	c.Assert(result.Actions[0].Action.Receiver, gc.Equals, s.wordpressUnit.Tag().String())
+	c.Assert(result.Actions[0].Action.Group, gc.Equals, result.Group)
+	c.Assert(result.Actions[0].Status, gc.Equals, params.ActionPending)
+
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, `service "wordpress" has no leader`)
+	c.Assert(results.Results[2].Error, gc.ErrorMatches, common.ErrBadId.Error())
+
+	groups, err := s.action.ActionGroups(params.ActionGroups{
+		Groups: []string{result.Group, "no-such-group"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(groups.Results, gc.HasLen, 2)
+	c.Assert(groups.Results[0].Error, gc.IsNil)
+	c.Assert(groups.Results[0].Actions, gc.HasLen, 1)
+	c.Assert(groups.Results[0].Actions[0].Action.Tag, gc.Equals, result.Actions[0].Action.Tag)
+	c.Assert(groups.Results[1].Error, jc.Satisfies, params.IsCodeNotFound)
+}
+
+func (s *actionSuite) TestEnqueueServiceLeader(c *gc.C) {
+	err := s.State.LeadershipClaimer().ClaimLeadership("wordpress", s.wordpressUnit.Name(), time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := s.action.EnqueueService(params.ServiceActions{
+		Actions: []params.ServiceAction{{
+			Service: s.wordpress.Tag().String(),
+			Name:    "fakeaction",
+			Leader:  true,
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[0].Actions, gc.HasLen, 1)
+	c.Assert(results.Results[0].Actions[0].Action.Receiver, gc.Equals, s.wordpressUnit.Tag().String())
+}
+
+func (s *actionSuite) TestHistory(c *gc.C) {
+	_, err := s.wordpressUnit.AddAction("fakeaction", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.mysqlUnit.AddAction("fakeaction", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := s.action.History(params.ActionHistoryQueries{
+		Queries: []params.ActionHistoryQuery{{
+			Receiver: s.wordpressUnit.Tag().String(),
+		}, {
+			Statuses: []string{params.ActionPending},
+			Limit:    1,
+		}, {
+			Receiver: "bad-tag",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 3)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[0].Actions, gc.HasLen, 1)
+	c.Assert(results.Results[0].Actions[0].Action.Receiver, gc.Equals, s.wordpressUnit.Tag().String())
+	c.Assert(results.Results[1].Error, gc.IsNil)
+	c.Assert(results.Results[1].Actions, gc.HasLen, 1)
+	c.Assert(results.Results[2].Error, gc.ErrorMatches, common.ErrBadId.Error())
+}
+
 func (s *actionSuite) TestServicesCharmActions(c *gc.C) {
 	actionSchemas := map[string]map[string]interface{}{
 		"snapshot": {
@@ -0,0 +1,39 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionpruner
+
+import (
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+func init() {
+	common.RegisterStandardFacade("ActionPruner", 1, NewAPI)
+}
+
+// API implements the API used by the action pruner worker.
+type API struct {
+	*common.ModelWatcher
+	st         *state.State
+	authorizer common.Authorizer
+}
+
+// NewAPI returns an API Instance.
+func NewAPI(st *state.State, resources *common.Resources, auth common.Authorizer) (*API, error) {
+	if !auth.AuthModelManager() {
+		return nil, common.ErrPerm
+	}
+	return &API{
+		ModelWatcher: common.NewModelWatcher(st, resources, auth),
+		st:           st,
+		authorizer:   auth,
+	}, nil
+}
+
+// Prune removes the records of finished actions beyond the given
+// age and count.
+func (api *API) Prune(p params.ActionPruneArgs) error {
+	return state.PruneActions(api.st, p.MaxAge, p.MaxCount)
+}
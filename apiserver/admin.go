@@ -177,6 +177,7 @@ func (a *admin) doLogin(req params.LoginRequest, loginVersion int) (params.Login
 		Facades:       DescribeFacades(),
 		UserInfo:      maybeUserInfo,
 		ServerVersion: version.Current.String(),
+		Codecs:        []string{params.CodecBSON},
 	}
 
 	// For sufficiently modern login versions, stop serving the
@@ -199,6 +200,9 @@ func (a *admin) doLogin(req params.LoginRequest, loginVersion int) (params.Login
 	if envUser != nil {
 		authedApi = newClientAuthRoot(authedApi, envUser)
 	}
+	if isUser {
+		authedApi = newAuditingRoot(authedApi, a.root.state, a.srv.auditSink, a.root.state.ModelUUID(), entity.Tag())
+	}
 
 	a.root.rpcConn.ServeFinder(authedApi, serverError)
 
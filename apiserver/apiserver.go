@@ -23,8 +23,10 @@ import (
 
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/audit"
 	resourceapi "github.com/juju/juju/resource/api"
 	"github.com/juju/juju/rpc"
+	"github.com/juju/juju/rpc/bsoncodec"
 	"github.com/juju/juju/rpc/jsoncodec"
 	"github.com/juju/juju/state"
 )
@@ -51,6 +53,8 @@ type Server struct {
 	mongoUnavailable  uint32 // non zero if mongoUnavailable
 	modelUUID         string
 	authCtxt          *authContext
+	auditSink         audit.Sink
+	rpcMetrics        *rpc.Metrics
 	connections       int32 // count of active websocket connections
 }
 
@@ -68,6 +72,10 @@ type ServerConfig struct {
 	LogDir      string
 	Validator   LoginValidator
 	CertChanged chan params.StateServingInfo
+
+	// AuditSink, if not nil, is sent every audit entry recorded
+	// by the server, in addition to the database.
+	AuditSink audit.Sink
 }
 
 // changeCertListener wraps a TLS net.Listener.
@@ -190,14 +198,16 @@ func newServer(s *state.State, lis *net.TCPListener, cfg ServerConfig) (_ *Serve
 		MinVersion:   tls.VersionTLS10,
 	}
 	srv := &Server{
-		state:     s,
-		statePool: state.NewStatePool(s),
-		lis:       newChangeCertListener(lis, cfg.CertChanged, tlsConfig),
-		tag:       cfg.Tag,
-		dataDir:   cfg.DataDir,
-		logDir:    cfg.LogDir,
-		limiter:   utils.NewLimiter(loginRateLimit),
-		validator: cfg.Validator,
+		state:      s,
+		statePool:  state.NewStatePool(s),
+		lis:        newChangeCertListener(lis, cfg.CertChanged, tlsConfig),
+		tag:        cfg.Tag,
+		dataDir:    cfg.DataDir,
+		logDir:     cfg.LogDir,
+		limiter:    utils.NewLimiter(loginRateLimit),
+		validator:  cfg.Validator,
+		auditSink:  cfg.AuditSink,
+		rpcMetrics: rpc.NewMetrics(),
 		adminApiFactories: map[int]adminApiFactory{
 			2: newAdminApiV2,
 		},
@@ -404,6 +414,15 @@ func (srv *Server) run() {
 	// For backwards compatibility we register all the old paths
 	handleAll(mux, "/log", newDebugLogDBHandler(httpCtxt, srvDying))
 
+	controllerCtxt := httpCtxt
+	controllerCtxt.controllerModelOnly = true
+	handleAll(mux, "/metrics",
+		&metricsHandler{
+			ctxt:    controllerCtxt,
+			metrics: srv.rpcMetrics,
+		},
+	)
+
 	handleAll(mux, "/charms",
 		&charmsHandler{
 			ctxt:    httpCtxt,
@@ -461,8 +480,11 @@ func (srv *Server) apiHandler(w http.ResponseWriter, req *http.Request) {
 }
 
 func (srv *Server) serveConn(wsConn *websocket.Conn, reqNotifier *requestNotifier, modelUUID string) error {
-	codec := jsoncodec.NewWebsocket(wsConn)
-	if loggo.GetLogger("juju.rpc.jsoncodec").EffectiveLogLevel() <= loggo.TRACE {
+	// The codec replies in BSON once the client starts sending
+	// BSON, which it does only after being told at login that
+	// the server supports it.
+	codec := bsoncodec.NewWebsocket(wsConn)
+	if loggo.GetLogger("juju.rpc.bsoncodec").EffectiveLogLevel() <= loggo.TRACE {
 		codec.SetLogging(true)
 	}
 	var notifier rpc.RequestNotifier
@@ -472,6 +494,7 @@ func (srv *Server) serveConn(wsConn *websocket.Conn, reqNotifier *requestNotifie
 		notifier = reqNotifier
 	}
 	conn := rpc.NewConn(codec, notifier)
+	conn.SetMetrics(srv.rpcMetrics)
 
 	h, err := srv.newAPIHandler(conn, reqNotifier, modelUUID)
 	if err != nil {
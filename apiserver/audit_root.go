@@ -0,0 +1,276 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"encoding/json"
+	"reflect"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/names"
+	"github.com/juju/utils/set"
+	"golang.org/x/net/context"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/audit"
+	"github.com/juju/juju/rpc"
+	"github.com/juju/juju/rpc/rpcreflect"
+	"github.com/juju/juju/state"
+)
+
+// maxAuditArgsLength is the maximum length of the argument summary
+// recorded with each audit entry.
+const maxAuditArgsLength = 1024
+
+// unauditedCalls holds the calls that, while not read only, are made
+// so frequently and change so little that recording them would only
+// drown out the interesting entries.
+var unauditedCalls = set.NewStrings(
+	"AllModelWatcher.Next",
+	"AllModelWatcher.Stop",
+	"AllWatcher.Next",
+	"AllWatcher.Stop",
+	"NotifyWatcher.Next",
+	"NotifyWatcher.Stop",
+	"Pinger.Ping",
+	"Pinger.Stop",
+	"StringsWatcher.Next",
+	"StringsWatcher.Stop",
+)
+
+// loggableArgsCalls holds the audited calls whose arguments are known
+// to hold no secrets, and so may be recorded in the audit log. The
+// arguments of any other call are left out.
+var loggableArgsCalls = set.NewStrings(
+	"Action.Cancel",
+	"Annotations.Get",
+	"Annotations.Set",
+	"Backups.FinishRestore",
+	"Backups.Info",
+	"Backups.List",
+	"Backups.PrepareRestore",
+	"Backups.Remove",
+	"Block.SwitchBlockOff",
+	"Block.SwitchBlockOn",
+	"Client.AbortCurrentUpgrade",
+	"Client.AddCharm",
+	"Client.AddMachines",
+	"Client.AddMachinesV2",
+	"Client.DestroyMachines",
+	"Client.DestroyModel",
+	"Client.FindTools",
+	"Client.InjectMachines",
+	"Client.ModelUnset",
+	"Client.ProvisioningScript",
+	"Client.ResolveCharms",
+	"Client.Resolved",
+	"Client.RetryProvisioning",
+	"Client.SetModelAgentVersion",
+	"Client.SetModelConstraints",
+	"Client.ShareModel",
+	"Client.Status",
+	"Controller.AllModels",
+	"Controller.DestroyController",
+	"Controller.ListBlockedModels",
+	"Controller.ModelConfig",
+	"Controller.ModelStatus",
+	"Controller.RemoveBlocks",
+	"Controller.WatchAllModels",
+	"HighAvailability.EnableHA",
+	"ImageManager.DeleteImages",
+	"ImageManager.ListImages",
+	"ImageMetadata.Delete",
+	"ImageMetadata.List",
+	"ImageMetadata.Save",
+	"ImageMetadata.UpdateFromPublishedImages",
+	"KeyManager.AddKeys",
+	"KeyManager.DeleteKeys",
+	"KeyManager.ImportKeys",
+	"MachineManager.AddMachines",
+	"MetricsDebug.GetMetrics",
+	"MetricsDebug.SetMeterStatus",
+	"ModelManager.ConfigSkeleton",
+	"ModelManager.ListModels",
+	"Service.AddRelation",
+	"Service.AddUnits",
+	"Service.Destroy",
+	"Service.DestroyRelation",
+	"Service.DestroyUnits",
+	"Service.Expose",
+	"Service.GetCharmURL",
+	"Service.SetCharm",
+	"Service.SetConstraints",
+	"Service.Unexpose",
+	"Service.Unset",
+	"Spaces.CreateSpaces",
+	"Storage.AddToUnit",
+	"Storage.StorageDetails",
+	"Subnets.AddSubnets",
+	"UserManager.DisableUser",
+	"UserManager.EnableUser",
+	"payloads.List",
+	"resources.ListResourceRevisions",
+	"resources.ListResources",
+	"resources.SetResourceRevision",
+)
+
+// secretArgsCalls holds the audited calls whose arguments may contain
+// secrets. Their arguments are left out of the audit log, as are those
+// of any call not in loggableArgsCalls; they are listed so that every
+// audited call is known to have been considered. Calls that set model,
+// service or storage pool config are included, because config values
+// may hold credentials, as are calls that take action parameters,
+// commands to run, charm store macaroons or backup archive keys.
+var secretArgsCalls = set.NewStrings(
+	"Action.Enqueue",
+	"Action.EnqueueService",
+	"Backups.Create",
+	"Backups.Restore",
+	"Backups.Verify",
+	"Client.AddCharmWithAuthorization",
+	"Client.ModelSet",
+	"Client.Run",
+	"Client.RunOnAllMachines",
+	"ModelManager.CreateModel",
+	"Service.Deploy",
+	"Service.Set",
+	"Service.SetMetricCredentials",
+	"Service.Update",
+	"Storage.CreatePool",
+	"UserManager.AddUser",
+	"UserManager.SetPassword",
+	"resources.AddPendingResources",
+)
+
+// auditingRoot records an audit entry for every call made through it
+// that is not known to be read only.
+type auditingRoot struct {
+	rpc.MethodFinder
+	st        *state.State
+	sink      audit.Sink
+	modelUUID string
+	origin    names.Tag
+}
+
+// newAuditingRoot returns a new auditingRoot which records calls made
+// by origin against the model with the given UUID. If sink is not nil,
+// entries are also sent to it.
+func newAuditingRoot(finder rpc.MethodFinder, st *state.State, sink audit.Sink, modelUUID string, origin names.Tag) *auditingRoot {
+	return &auditingRoot{
+		MethodFinder: finder,
+		st:           st,
+		sink:         sink,
+		modelUUID:    modelUUID,
+		origin:       origin,
+	}
+}
+
+// FindMethod implements rpc.MethodFinder.
+func (r *auditingRoot) FindMethod(rootName string, version int, methodName string) (rpcreflect.MethodCaller, error) {
+	caller, err := r.MethodFinder.FindMethod(rootName, version, methodName)
+	if !isCallAudited(rootName, methodName) {
+		return caller, err
+	}
+	if err != nil {
+		// Calls refused for lack of permission are exactly the
+		// ones that auditors care about, so record them too.
+		if errors.Cause(err) == common.ErrPerm {
+			entry := r.newEntry(rootName, version, methodName)
+			entry.Outcome = audit.OutcomeFailed
+			entry.Error = err.Error()
+			r.record(entry)
+		}
+		return nil, err
+	}
+	return &auditingCaller{
+		MethodCaller: caller,
+		root:         r,
+		facade:       rootName,
+		version:      version,
+		method:       methodName,
+	}, nil
+}
+
+// newEntry returns an audit entry describing a call to the given
+// facade method, with its Outcome set to OutcomeSucceeded.
+func (r *auditingRoot) newEntry(facade string, version int, method string) audit.AuditEntry {
+	return audit.AuditEntry{
+		Timestamp: time.Now().UTC(),
+		ModelUUID: r.modelUUID,
+		OriginTag: r.origin.String(),
+		Facade:    facade,
+		Version:   version,
+		Method:    method,
+		Outcome:   audit.OutcomeSucceeded,
+	}
+}
+
+// record writes the entry to the audit log, and to the configured
+// sink. Failure to do so is logged but does not cause the call to fail.
+func (r *auditingRoot) record(entry audit.AuditEntry) {
+	if err := r.st.AddAuditEntry(entry); err != nil {
+		logger.Errorf("failed to record audit entry: %v", err)
+	}
+	if r.sink != nil {
+		if err := r.sink.Put(entry); err != nil {
+			logger.Errorf("failed to send audit entry to sink: %v", err)
+		}
+	}
+}
+
+// isCallAudited returns whether or not calls to the method on the
+// facade should be recorded in the audit log.
+func isCallAudited(facade, method string) bool {
+	key := facade + "." + method
+	return !isCallReadOnly(facade, method) && !unauditedCalls.Contains(key)
+}
+
+// isCallArgsLoggable returns whether or not the arguments of calls to
+// the method on the facade may be recorded in the audit log.
+func isCallArgsLoggable(facade, method string) bool {
+	key := facade + "." + method
+	return loggableArgsCalls.Contains(key) && !secretArgsCalls.Contains(key)
+}
+
+// auditingCaller wraps a rpcreflect.MethodCaller, recording the
+// outcome of each call in the audit log.
+type auditingCaller struct {
+	rpcreflect.MethodCaller
+	root    *auditingRoot
+	facade  string
+	version int
+	method  string
+}
+
+// Call implements rpcreflect.MethodCaller.
+func (c *auditingCaller) Call(ctx context.Context, objId string, arg reflect.Value) (reflect.Value, error) {
+	result, err := c.MethodCaller.Call(ctx, objId, arg)
+	entry := c.root.newEntry(c.facade, c.version, c.method)
+	if isCallArgsLoggable(c.facade, c.method) {
+		entry.Args = summariseArgs(arg)
+	}
+	if err != nil {
+		entry.Outcome = audit.OutcomeFailed
+		entry.Error = err.Error()
+	}
+	c.root.record(entry)
+	return result, err
+}
+
+// summariseArgs returns a string describing the call arguments,
+// truncated to at most maxAuditArgsLength bytes.
+func summariseArgs(arg reflect.Value) string {
+	if !arg.IsValid() || !arg.CanInterface() {
+		return ""
+	}
+	data, err := json.Marshal(arg.Interface())
+	if err != nil {
+		return ""
+	}
+	if len(data) > maxAuditArgsLength {
+		return string(data[:maxAuditArgsLength-3]) + "..."
+	}
+	return string(data)
+}
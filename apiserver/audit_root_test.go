@@ -0,0 +1,281 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"reflect"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/set"
+	"golang.org/x/net/context"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/audit"
+	"github.com/juju/juju/rpc/rpcreflect"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/testing"
+)
+
+type auditingRootSuite struct {
+	testing.StateSuite
+}
+
+var _ = gc.Suite(&auditingRootSuite{})
+
+func (s *auditingRootSuite) newRoot(finder *fakeFinder) *auditingRoot {
+	return newAuditingRoot(finder, s.State, nil, s.State.ModelUUID(), s.Owner)
+}
+
+func (s *auditingRootSuite) call(c *gc.C, root *auditingRoot, facade string, version int, method string, arg interface{}) error {
+	caller, err := root.FindMethod(facade, version, method)
+	if err != nil {
+		return err
+	}
+	_, err = caller.Call(context.Background(), "", reflect.ValueOf(arg))
+	return err
+}
+
+func (s *auditingRootSuite) entries(c *gc.C) []audit.AuditEntry {
+	entries, err := s.State.AuditEntries(state.AuditEntryFilter{})
+	c.Assert(err, jc.ErrorIsNil)
+	return entries
+}
+
+func (s *auditingRootSuite) TestMutatingCallRecorded(c *gc.C) {
+	root := s.newRoot(&fakeFinder{})
+	err := s.call(c, root, "Service", 3, "Expose", params.ServiceExpose{ServiceName: "wordpress"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	entries := s.entries(c)
+	c.Assert(entries, gc.HasLen, 1)
+	c.Check(entries[0].OriginTag, gc.Equals, s.Owner.String())
+	c.Check(entries[0].ModelUUID, gc.Equals, s.State.ModelUUID())
+	c.Check(entries[0].Facade, gc.Equals, "Service")
+	c.Check(entries[0].Version, gc.Equals, 3)
+	c.Check(entries[0].Method, gc.Equals, "Expose")
+	c.Check(entries[0].Args, gc.Equals, `{"ServiceName":"wordpress"}`)
+	c.Check(entries[0].Outcome, gc.Equals, audit.OutcomeSucceeded)
+}
+
+func (s *auditingRootSuite) TestReadOnlyCallNotRecorded(c *gc.C) {
+	root := s.newRoot(&fakeFinder{})
+	err := s.call(c, root, "Client", 1, "FullStatus", params.StatusParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.entries(c), gc.HasLen, 0)
+}
+
+func (s *auditingRootSuite) TestSecretArgsNotRecorded(c *gc.C) {
+	root := s.newRoot(&fakeFinder{})
+	err := s.call(c, root, "UserManager", 1, "SetPassword", params.EntityPasswords{
+		Changes: []params.EntityPassword{{Tag: "user-bob", Password: "sekrit"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	entries := s.entries(c)
+	c.Assert(entries, gc.HasLen, 1)
+	c.Check(entries[0].Method, gc.Equals, "SetPassword")
+	c.Check(entries[0].Args, gc.Equals, "")
+}
+
+func (s *auditingRootSuite) TestConfigArgsNotRecorded(c *gc.C) {
+	root := s.newRoot(&fakeFinder{})
+	err := s.call(c, root, "Client", 1, "ModelSet", params.ModelSet{
+		Config: map[string]interface{}{"secret-key": "sekrit"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.call(c, root, "Service", 3, "Set", params.ServiceSet{
+		ServiceName: "wordpress",
+		Options:     map[string]string{"password": "sekrit"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	entries := s.entries(c)
+	c.Assert(entries, gc.HasLen, 2)
+	for _, entry := range entries {
+		c.Check(entry.Args, gc.Equals, "")
+	}
+}
+
+func (s *auditingRootSuite) TestUnclassifiedArgsNotRecorded(c *gc.C) {
+	c.Assert(isCallArgsLoggable("Service", "NewMethod"), jc.IsFalse)
+	root := s.newRoot(&fakeFinder{})
+	root.MethodFinder = &failingFinder{}
+	err := s.call(c, root, "Service", 3, "NewMethod", params.Entities{
+		Entities: []params.Entity{{Tag: "service-wordpress"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	entries := s.entries(c)
+	c.Assert(entries, gc.HasLen, 1)
+	c.Check(entries[0].Method, gc.Equals, "NewMethod")
+	c.Check(entries[0].Args, gc.Equals, "")
+}
+
+// agentFacades holds the facades that only agents may use. Only calls
+// made by users are audited, so their methods need not be classified.
+var agentFacades = set.NewStrings(
+	"ActionPruner",
+	"Addresser",
+	"Agent",
+	"AgentTools",
+	"CharmRevisionUpdater",
+	"Cleaner",
+	"Deployer",
+	"DiscoverSpaces",
+	"DiskManager",
+	"EntityWatcher",
+	"FilesystemAttachmentsWatcher",
+	"Firewaller",
+	"InstancePoller",
+	"KeyUpdater",
+	"LeadershipService",
+	"Logger",
+	"Machiner",
+	"MeterStatus",
+	"MetricsAdder",
+	"MetricsManager",
+	"Provisioner",
+	"ProxyUpdater",
+	"Reboot",
+	"RelationUnitsWatcher",
+	"Resumer",
+	"Singular",
+	"StatusHistory",
+	"StorageProvisioner",
+	"Undertaker",
+	"UnitAssigner",
+	"Uniter",
+	"Upgrader",
+	"VolumeAttachmentsWatcher",
+	"payloads-hook-context",
+	"resources-hook-context",
+)
+
+func (s *auditingRootSuite) TestAuditedCallsClassified(c *gc.C) {
+	// Every audited call must be explicitly listed as having
+	// arguments that may or may not be logged, so that a new
+	// facade method cannot leak secrets into the audit log by
+	// being overlooked.
+	for _, facade := range common.Facades.List() {
+		if agentFacades.Contains(facade.Name) {
+			continue
+		}
+		for _, version := range facade.Versions {
+			goType, err := common.Facades.GetType(facade.Name, version)
+			c.Assert(err, jc.ErrorIsNil)
+			for _, method := range rpcreflect.ObjTypeOf(goType).MethodNames() {
+				if !isCallAudited(facade.Name, method) {
+					continue
+				}
+				key := facade.Name + "." + method
+				loggable := loggableArgsCalls.Contains(key)
+				secret := secretArgsCalls.Contains(key)
+				c.Check(loggable || secret, jc.IsTrue, gc.Commentf(
+					"%s (version %d) is in neither loggableArgsCalls nor secretArgsCalls", key, version,
+				))
+				c.Check(loggable && secret, jc.IsFalse, gc.Commentf(
+					"%s is in both loggableArgsCalls and secretArgsCalls", key,
+				))
+			}
+		}
+	}
+}
+
+func (s *auditingRootSuite) TestFailedCallRecorded(c *gc.C) {
+	root := s.newRoot(&fakeFinder{})
+	root.MethodFinder = &failingFinder{callErr: errors.New("boom")}
+	err := s.call(c, root, "Service", 3, "Expose", params.ServiceExpose{})
+	c.Assert(err, gc.ErrorMatches, "boom")
+
+	entries := s.entries(c)
+	c.Assert(entries, gc.HasLen, 1)
+	c.Check(entries[0].Outcome, gc.Equals, audit.OutcomeFailed)
+	c.Check(entries[0].Error, gc.Equals, "boom")
+}
+
+func (s *auditingRootSuite) TestPermissionDeniedRecorded(c *gc.C) {
+	root := s.newRoot(&fakeFinder{})
+	root.MethodFinder = &failingFinder{findErr: common.ErrPerm}
+	err := s.call(c, root, "Service", 3, "Expose", params.ServiceExpose{})
+	c.Assert(errors.Cause(err), gc.Equals, common.ErrPerm)
+
+	entries := s.entries(c)
+	c.Assert(entries, gc.HasLen, 1)
+	c.Check(entries[0].Method, gc.Equals, "Expose")
+	c.Check(entries[0].Outcome, gc.Equals, audit.OutcomeFailed)
+	c.Check(entries[0].Error, gc.Equals, "permission denied")
+}
+
+func (s *auditingRootSuite) TestEntriesSentToSink(c *gc.C) {
+	sink := &recordingSink{}
+	root := newAuditingRoot(&fakeFinder{}, s.State, sink, s.State.ModelUUID(), s.Owner)
+	err := s.call(c, root, "Service", 3, "Expose", params.ServiceExpose{ServiceName: "wordpress"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	entries := s.entries(c)
+	c.Assert(entries, gc.HasLen, 1)
+	c.Assert(sink.entries, gc.HasLen, 1)
+	c.Check(sink.entries[0].Method, gc.Equals, "Expose")
+	c.Check(sink.entries[0].Args, gc.Equals, entries[0].Args)
+}
+
+func (s *auditingRootSuite) TestSinkErrorDoesNotFailCall(c *gc.C) {
+	sink := &recordingSink{err: errors.New("disk full")}
+	root := newAuditingRoot(&fakeFinder{}, s.State, sink, s.State.ModelUUID(), s.Owner)
+	err := s.call(c, root, "Service", 3, "Expose", params.ServiceExpose{ServiceName: "wordpress"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.entries(c), gc.HasLen, 1)
+}
+
+func (s *auditingRootSuite) TestSummariseArgsTruncates(c *gc.C) {
+	long := make([]byte, maxAuditArgsLength*2)
+	for i := range long {
+		long[i] = 'x'
+	}
+	summary := summariseArgs(reflect.ValueOf(string(long)))
+	c.Check(summary, gc.HasLen, maxAuditArgsLength)
+	c.Check(summary[len(summary)-3:], gc.Equals, "...")
+	c.Check(summariseArgs(reflect.Value{}), gc.Equals, "")
+}
+
+// failingFinder returns a caller that fails with callErr, or fails
+// to find the method at all with findErr.
+type failingFinder struct {
+	findErr error
+	callErr error
+}
+
+func (f *failingFinder) FindMethod(rootName string, version int, methodName string) (rpcreflect.MethodCaller, error) {
+	if f.findErr != nil {
+		return nil, f.findErr
+	}
+	return &failingCaller{err: f.callErr}, nil
+}
+
+type failingCaller struct {
+	fakeCaller
+	err error
+}
+
+func (c *failingCaller) Call(_ context.Context, _ /*objId*/ string, _ /*arg*/ reflect.Value) (reflect.Value, error) {
+	return reflect.Value{}, c.err
+}
+
+// recordingSink is an audit.Sink that remembers the entries put to it.
+type recordingSink struct {
+	entries []audit.AuditEntry
+	err     error
+}
+
+func (s *recordingSink) Put(entry audit.AuditEntry) error {
+	s.entries = append(s.entries, entry)
+	return s.err
+}
+
+func (s *recordingSink) Close() error {
+	return nil
+}
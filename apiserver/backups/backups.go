@@ -17,6 +17,7 @@ import (
 
 func init() {
 	common.RegisterStandardFacade("Backups", 1, NewAPI)
+	common.RegisterStandardFacade("Backups", 2, NewAPIV2)
 }
 
 var logger = loggo.GetLogger("juju.apiserver.backups")
@@ -68,6 +69,22 @@ func NewAPI(st *state.State, resources *common.Resources, authorizer common.Auth
 	return &b, nil
 }
 
+// APIV2 serves version 2 of the backups API, which adds incremental
+// and encrypted backups, and Verify.
+type APIV2 struct {
+	*API
+}
+
+// NewAPIV2 creates a new instance of version 2 of the Backups API
+// facade.
+func NewAPIV2(st *state.State, resources *common.Resources, authorizer common.Authorizer) (*APIV2, error) {
+	api, err := NewAPI(st, resources, authorizer)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIV2{api}, nil
+}
+
 func extractResourceValue(resources *common.Resources, key string) (string, error) {
 	res := resources.Get(key)
 	strRes, ok := res.(common.StringResource)
@@ -111,6 +128,12 @@ func ResultFromMetadata(meta *backups.Metadata) params.BackupsMetadataResult {
 	result.Hostname = meta.Origin.Hostname
 	result.Version = meta.Origin.Version
 
+	result.Parent = meta.Parent
+	result.OplogStart = meta.OplogStart
+	result.OplogEnd = meta.OplogEnd
+	result.Scheduled = meta.Scheduled
+	result.Encrypted = meta.Encrypted
+
 	return result
 }
 
@@ -128,6 +151,11 @@ func MetadataFromResult(result params.BackupsMetadataResult) *backups.Metadata {
 	meta.Origin.Hostname = result.Hostname
 	meta.Origin.Version = result.Version
 	meta.Notes = result.Notes
+	meta.Parent = result.Parent
+	meta.OplogStart = result.OplogStart
+	meta.OplogEnd = result.OplogEnd
+	meta.Scheduled = result.Scheduled
+	meta.Encrypted = result.Encrypted
 	meta.SetFileInfo(result.Size, result.Checksum, result.ChecksumFormat)
 	return meta
 }
@@ -26,7 +26,7 @@ type backupsSuite struct {
 	testing.JujuConnSuite
 	resources  *common.Resources
 	authorizer *apiservertesting.FakeAuthorizer
-	api        *backupsAPI.API
+	api        *backupsAPI.APIV2
 	meta       *backups.Metadata
 }
 
@@ -39,7 +39,7 @@ func (s *backupsSuite) SetUpTest(c *gc.C) {
 	tag := names.NewLocalUserTag("spam")
 	s.authorizer = &apiservertesting.FakeAuthorizer{Tag: tag}
 	var err error
-	s.api, err = backupsAPI.NewAPI(s.State, s.resources, s.authorizer)
+	s.api, err = backupsAPI.NewAPIV2(s.State, s.resources, s.authorizer)
 	c.Assert(err, jc.ErrorIsNil)
 	s.meta = backupstesting.NewMetadataStarted()
 }
@@ -65,6 +65,8 @@ func (s *backupsSuite) setBackups(c *gc.C, meta *backups.Metadata, err string) *
 func (s *backupsSuite) TestRegistered(c *gc.C) {
 	_, err := common.Facades.GetType("Backups", 1)
 	c.Check(err, jc.ErrorIsNil)
+	_, err = common.Facades.GetType("Backups", 2)
+	c.Check(err, jc.ErrorIsNil)
 }
 
 func (s *backupsSuite) TestNewAPIOkay(c *gc.C) {
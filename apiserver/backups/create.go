@@ -6,16 +6,38 @@ package backups
 import (
 	"github.com/juju/errors"
 	"github.com/juju/replicaset"
+	"golang.org/x/net/context"
 
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/state/backups"
 )
 
-var waitUntilReady = replicaset.WaitUntilReady
+var (
+	waitUntilReady = replicaset.WaitUntilReady
+	newOplogRange  = backups.NewOplogRange
+)
+
+// Create is the API method that requests juju to create a new backup
+// of its state.  It returns the metadata for that backup. No backup is
+// started if ctx is cancelled while waiting for the replicaset to
+// become ready. Version 1 clients cannot ask for incremental or
+// encrypted backups.
+func (a *API) Create(ctx context.Context, args params.BackupsCreateArgs) (params.BackupsMetadataResult, error) {
+	if args.Incremental || args.Key != "" {
+		return params.BackupsMetadataResult{}, errors.NotSupportedf("incremental or encrypted backups in Backups v1")
+	}
+	return a.create(ctx, args)
+}
 
 // Create is the API method that requests juju to create a new backup
-// of its state.  It returns the metadata for that backup.
-func (a *API) Create(args params.BackupsCreateArgs) (p params.BackupsMetadataResult, err error) {
+// of its state.  It returns the metadata for that backup. No backup is
+// started if ctx is cancelled while waiting for the replicaset to
+// become ready.
+func (a *APIV2) Create(ctx context.Context, args params.BackupsCreateArgs) (params.BackupsMetadataResult, error) {
+	return a.create(ctx, args)
+}
+
+func (a *API) create(ctx context.Context, args params.BackupsCreateArgs) (p params.BackupsMetadataResult, err error) {
 	backupsMethods, closer := newBackups(a.st)
 	defer closer.Close()
 
@@ -27,20 +49,43 @@ func (a *API) Create(args params.BackupsCreateArgs) (p params.BackupsMetadataRes
 	if err != nil {
 		return p, errors.Annotatef(err, "HA not ready; try again later")
 	}
+	if err := ctx.Err(); err != nil {
+		return p, errors.Trace(err)
+	}
 
 	mgoInfo := a.st.MongoConnectionInfo()
 	dbInfo, err := backups.NewDBInfo(mgoInfo, session)
 	if err != nil {
 		return p, errors.Trace(err)
 	}
+	dbInfo.Oplog, err = newOplogRange(session)
+	if err != nil {
+		return p, errors.Trace(err)
+	}
 
 	meta, err := backups.NewMetadataState(a.st, a.machineID)
 	if err != nil {
 		return p, errors.Trace(err)
 	}
 	meta.Notes = args.Notes
+	keys, err := backups.NewArchiveKeys(a.st, args.Key)
+	if err != nil {
+		return p, errors.Trace(err)
+	}
 
-	err = backupsMethods.Create(meta, a.paths, dbInfo)
+	if args.Incremental {
+		list, err := backupsMethods.List()
+		if err != nil {
+			return p, errors.Trace(err)
+		}
+		parent := backups.Latest(list)
+		if parent == nil {
+			return p, errors.New("no backup to build an incremental backup on; create a full backup")
+		}
+		err = backupsMethods.CreateIncremental(meta, a.paths, dbInfo, parent.ID(), keys)
+	} else {
+		err = backupsMethods.Create(meta, a.paths, dbInfo, keys)
+	}
 	if err != nil {
 		return p, errors.Trace(err)
 	}
@@ -4,12 +4,15 @@
 package backups_test
 
 import (
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
+	"golang.org/x/net/context"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/mgo.v2"
 
 	"github.com/juju/juju/apiserver/backups"
 	"github.com/juju/juju/apiserver/params"
+	statebackups "github.com/juju/juju/state/backups"
 )
 
 func (s *backupsSuite) TestCreateOkay(c *gc.C) {
@@ -18,7 +21,7 @@ func (s *backupsSuite) TestCreateOkay(c *gc.C) {
 	)
 	s.setBackups(c, s.meta, "")
 	var args params.BackupsCreateArgs
-	result, err := s.api.Create(args)
+	result, err := s.api.Create(context.Background(), args)
 	c.Assert(err, jc.ErrorIsNil)
 	expected := backups.ResultFromMetadata(s.meta)
 
@@ -34,7 +37,7 @@ func (s *backupsSuite) TestCreateNotes(c *gc.C) {
 	args := params.BackupsCreateArgs{
 		Notes: "this backup is important",
 	}
-	result, err := s.api.Create(args)
+	result, err := s.api.Create(context.Background(), args)
 	c.Assert(err, jc.ErrorIsNil)
 	expected := backups.ResultFromMetadata(s.meta)
 	expected.Notes = "this backup is important"
@@ -42,13 +45,85 @@ func (s *backupsSuite) TestCreateNotes(c *gc.C) {
 	c.Check(result, gc.DeepEquals, expected)
 }
 
+func (s *backupsSuite) TestCreateV1RefusesIncrementalAndEncrypted(c *gc.C) {
+	fake := s.setBackups(c, s.meta, "")
+	for _, args := range []params.BackupsCreateArgs{
+		{Incremental: true},
+		{Key: "sekrit"},
+	} {
+		_, err := s.api.API.Create(context.Background(), args)
+		c.Check(err, gc.ErrorMatches, "incremental or encrypted backups in Backups v1 not supported")
+	}
+	c.Check(fake.Calls, gc.HasLen, 0)
+}
+
+func (s *backupsSuite) TestCreateEncrypted(c *gc.C) {
+	s.PatchValue(backups.WaitUntilReady,
+		func(*mgo.Session, int) error { return nil },
+	)
+	fake := s.setBackups(c, s.meta, "")
+	args := params.BackupsCreateArgs{Key: "sekrit"}
+	_, err := s.api.Create(context.Background(), args)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(fake.Calls, jc.DeepEquals, []string{"Create"})
+	c.Check(fake.KeysArg.Secret, gc.Equals, "sekrit")
+}
+
+func (s *backupsSuite) TestCreateIncremental(c *gc.C) {
+	s.PatchValue(backups.WaitUntilReady,
+		func(*mgo.Session, int) error { return nil },
+	)
+	s.PatchValue(backups.NewOplogRange, func(*mgo.Session) (statebackups.OplogRange, error) {
+		return statebackups.OplogRange{First: 50, Last: 200}, nil
+	})
+	s.meta.SetID("parent-id")
+	s.meta.OplogEnd = 100
+	finished := s.meta.Started
+	s.meta.Finished = &finished
+	fake := s.setBackups(c, s.meta, "")
+	args := params.BackupsCreateArgs{Incremental: true}
+	_, err := s.api.Create(context.Background(), args)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(fake.Calls, jc.DeepEquals, []string{"List", "CreateIncremental"})
+	c.Check(fake.ParentArg, gc.Equals, "parent-id")
+	c.Check(fake.DBInfoArg.Oplog, gc.Equals, statebackups.OplogRange{First: 50, Last: 200})
+}
+
+func (s *backupsSuite) TestCreateIncrementalNoParent(c *gc.C) {
+	s.PatchValue(backups.WaitUntilReady,
+		func(*mgo.Session, int) error { return nil },
+	)
+	s.setBackups(c, nil, "")
+	args := params.BackupsCreateArgs{Incremental: true}
+	_, err := s.api.Create(context.Background(), args)
+
+	c.Check(err, gc.ErrorMatches, "no backup to build an incremental backup on; create a full backup")
+}
+
 func (s *backupsSuite) TestCreateError(c *gc.C) {
 	s.setBackups(c, nil, "failed!")
 	s.PatchValue(backups.WaitUntilReady,
 		func(*mgo.Session, int) error { return nil },
 	)
 	var args params.BackupsCreateArgs
-	_, err := s.api.Create(args)
+	_, err := s.api.Create(context.Background(), args)
 
 	c.Check(err, gc.ErrorMatches, "failed!")
 }
+
+func (s *backupsSuite) TestCreateCancelled(c *gc.C) {
+	ctx, cancel := context.WithCancel(context.Background())
+	s.PatchValue(backups.WaitUntilReady,
+		func(*mgo.Session, int) error {
+			cancel()
+			return nil
+		},
+	)
+	s.setBackups(c, s.meta, "")
+	var args params.BackupsCreateArgs
+	_, err := s.api.Create(ctx, args)
+
+	c.Check(errors.Cause(err), gc.Equals, context.Canceled)
+}
@@ -19,8 +19,21 @@ import (
 
 var bootstrapNode = names.NewMachineTag("0")
 
-// Restore implements the server side of Backups.Restore.
+// Restore implements the server side of Backups.Restore. Version 1
+// clients cannot restore encrypted backups.
 func (a *API) Restore(p params.RestoreArgs) error {
+	if p.Key != "" || len(p.ChainKeys) > 0 {
+		return errors.NotSupportedf("encrypted backups in Backups v1")
+	}
+	return a.restore(p)
+}
+
+// Restore implements the server side of Backups.Restore.
+func (a *APIV2) Restore(p params.RestoreArgs) error {
+	return a.restore(p)
+}
+
+func (a *API) restore(p params.RestoreArgs) error {
 
 	// Get hold of a backup file Reader
 	backup, closer := newBackups(a.st)
@@ -70,6 +83,8 @@ func (a *API) Restore(p params.RestoreArgs) error {
 		NewInstId:      instanceId,
 		NewInstTag:     machine.Tag(),
 		NewInstSeries:  machine.Series(),
+		Secret:         p.Key,
+		ChainSecrets:   p.ChainKeys,
 	}
 
 	oldTagString, err := backup.Restore(p.BackupId, restoreArgs)
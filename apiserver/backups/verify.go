@@ -0,0 +1,45 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state/backups"
+)
+
+// Verify is the API method that checks that a stored backup is whole
+// and unmodified, and could be restored, without restoring it.
+func (a *APIV2) Verify(args params.BackupsVerifyArgs) (params.BackupsVerifyResult, error) {
+	backupsMethods, closer := newBackups(a.st)
+	defer closer.Close()
+
+	meta, archive, err := backupsMethods.Get(args.ID)
+	if err != nil {
+		return params.BackupsVerifyResult{}, errors.Trace(err)
+	}
+	defer archive.Close()
+
+	// The signature is checked only if the controller signs the
+	// backups it creates, which it does only for encrypted ones.
+	keys, err := backups.NewArchiveKeys(a.st, args.Key)
+	if err != nil {
+		return params.BackupsVerifyResult{}, errors.Trace(err)
+	}
+	result, err := backups.Verify(archive, backups.VerifyArgs{
+		Secret:   args.Key,
+		CACert:   keys.CACert,
+		Checksum: meta.Checksum(),
+	})
+	if err != nil {
+		return params.BackupsVerifyResult{}, errors.Annotatef(err, "backup %q failed verification", args.ID)
+	}
+	return params.BackupsVerifyResult{
+		Encrypted: result.Encrypted,
+		Signed:    result.Signed,
+		Files:     result.Files,
+		Documents: result.Documents,
+	}, nil
+}
@@ -0,0 +1,34 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups_test
+
+import (
+	"bytes"
+	"io/ioutil"
+
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+func (s *backupsSuite) TestVerifyCorruptArchive(c *gc.C) {
+	impl := s.setBackups(c, s.meta, "")
+	impl.Archive = ioutil.NopCloser(bytes.NewBufferString("spamspamspam"))
+	args := params.BackupsVerifyArgs{
+		ID: "some-id",
+	}
+	_, err := s.api.Verify(args)
+
+	c.Check(err, gc.ErrorMatches, `backup "some-id" failed verification: cannot unpack archive: .*`)
+}
+
+func (s *backupsSuite) TestVerifyError(c *gc.C) {
+	s.setBackups(c, nil, "failed!")
+	args := params.BackupsVerifyArgs{
+		ID: "some-id",
+	}
+	_, err := s.api.Verify(args)
+
+	c.Check(err, gc.ErrorMatches, "failed!")
+}
@@ -5,6 +5,7 @@ package block
 
 import (
 	"github.com/juju/errors"
+	"github.com/juju/names"
 
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/params"
@@ -13,6 +14,7 @@ import (
 
 func init() {
 	common.RegisterStandardFacade("Block", 2, NewAPI)
+	common.RegisterStandardFacade("Block", 3, NewAPIV3)
 }
 
 // Block defines the methods on the block API end point.
@@ -52,6 +54,25 @@ func NewAPI(
 	}, nil
 }
 
+// APIV3 implements the version 3 block API, which adds expiring,
+// scheduled and user-exempt blocks.
+type APIV3 struct {
+	*API
+}
+
+// NewAPIV3 returns a new version 3 block API facade.
+func NewAPIV3(
+	st *state.State,
+	resources *common.Resources,
+	authorizer common.Authorizer,
+) (*APIV3, error) {
+	api, err := NewAPI(st, resources, authorizer)
+	if err != nil {
+		return nil, err
+	}
+	return &APIV3{api}, nil
+}
+
 var getState = func(st *state.State) blockAccess {
 	return stateShim{st}
 }
@@ -77,17 +98,58 @@ func convertBlock(b state.Block) params.BlockResult {
 		result.Error = common.ServerError(err)
 	}
 	result.Result = params.Block{
-		Id:      b.Id(),
-		Tag:     tag.String(),
-		Type:    b.Type().String(),
-		Message: b.Message(),
+		Id:          b.Id(),
+		Tag:         tag.String(),
+		Type:        b.Type().String(),
+		Message:     b.Message(),
+		Expires:     b.Expires(),
+		ExemptUsers: b.ExemptUsers(),
+	}
+	if schedule := b.Schedule(); schedule != nil {
+		result.Result.Schedule = &params.BlockSchedule{
+			Start:    schedule.Start,
+			Duration: schedule.Duration,
+			Location: schedule.Location,
+		}
 	}
 	return result
 }
 
-// SwitchBlockOn implements Block.SwitchBlockOn().
+// SwitchBlockOn implements Block.SwitchBlockOn(). Version 2 clients
+// cannot ask for expiring, scheduled or user-exempt blocks.
 func (a *API) SwitchBlockOn(args params.BlockSwitchParams) params.ErrorResult {
-	err := a.access.SwitchBlockOn(state.ParseBlockType(args.Type), args.Message)
+	if args.Expires != nil || args.Schedule != nil || len(args.ExemptUsers) > 0 {
+		err := errors.NotSupportedf("expiring, scheduled or user-exempt blocks in Block v2")
+		return params.ErrorResult{Error: common.ServerError(err)}
+	}
+	return a.switchBlockOn(args)
+}
+
+// SwitchBlockOn implements Block.SwitchBlockOn().
+func (a *APIV3) SwitchBlockOn(args params.BlockSwitchParams) params.ErrorResult {
+	return a.switchBlockOn(args)
+}
+
+func (a *API) switchBlockOn(args params.BlockSwitchParams) params.ErrorResult {
+	blockArgs := state.BlockArgs{
+		Message: args.Message,
+		Expires: args.Expires,
+	}
+	if args.Schedule != nil {
+		blockArgs.Schedule = &state.BlockSchedule{
+			Start:    args.Schedule.Start,
+			Duration: args.Schedule.Duration,
+			Location: args.Schedule.Location,
+		}
+	}
+	for _, user := range args.ExemptUsers {
+		if !names.IsValidUser(user) {
+			err := errors.NotValidf("user name %q", user)
+			return params.ErrorResult{Error: common.ServerError(err)}
+		}
+		blockArgs.ExemptUsers = append(blockArgs.ExemptUsers, names.NewUserTag(user))
+	}
+	err := a.access.SwitchBlockOnWithArgs(state.ParseBlockType(args.Type), blockArgs)
 	return params.ErrorResult{Error: common.ServerError(err)}
 }
 
@@ -4,6 +4,8 @@
 package block_test
 
 import (
+	"time"
+
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
@@ -18,7 +20,7 @@ import (
 type blockSuite struct {
 	// TODO(anastasiamac) mock to remove JujuConnSuite
 	jujutesting.JujuConnSuite
-	api *block.API
+	api *block.APIV3
 }
 
 var _ = gc.Suite(&blockSuite{})
@@ -31,7 +33,7 @@ func (s *blockSuite) SetUpTest(c *gc.C) {
 		Tag:            s.AdminUserTag(c),
 		EnvironManager: true,
 	}
-	s.api, err = block.NewAPI(s.State, common.NewResources(), auth)
+	s.api, err = block.NewAPIV3(s.State, common.NewResources(), auth)
 	c.Assert(err, jc.ErrorIsNil)
 }
 
@@ -59,6 +61,52 @@ func (s *blockSuite) assertSwitchBlockOn(c *gc.C, blockType, msg string) {
 	s.assertBlockList(c, 1)
 }
 
+func (s *blockSuite) TestSwitchScheduledBlockOn(c *gc.C) {
+	expires := time.Now().Add(time.Hour).UTC().Round(time.Second)
+	schedule := &params.BlockSchedule{
+		Start:    "0 9 * * 1-5",
+		Duration: 8 * time.Hour,
+	}
+	on := params.BlockSwitchParams{
+		Type:        state.ChangeBlock.String(),
+		Message:     "working hours",
+		Expires:     &expires,
+		Schedule:    schedule,
+		ExemptUsers: []string{"ops-oncall"},
+	}
+	err := s.api.SwitchBlockOn(on)
+	c.Assert(err.Error, gc.IsNil)
+
+	all, listErr := s.api.List()
+	c.Assert(listErr, jc.ErrorIsNil)
+	c.Assert(all.Results, gc.HasLen, 1)
+	result := all.Results[0].Result
+	c.Assert(result.Message, gc.Equals, "working hours")
+	c.Assert(result.Expires, jc.DeepEquals, &expires)
+	c.Assert(result.Schedule, jc.DeepEquals, schedule)
+	c.Assert(result.ExemptUsers, jc.DeepEquals, []string{"ops-oncall@local"})
+}
+
+func (s *blockSuite) TestSwitchScheduledBlockOnV2(c *gc.C) {
+	on := params.BlockSwitchParams{
+		Type:        state.ChangeBlock.String(),
+		ExemptUsers: []string{"ops-oncall"},
+	}
+	err := s.api.API.SwitchBlockOn(on)
+	c.Assert(err.Error, gc.ErrorMatches, "expiring, scheduled or user-exempt blocks in Block v2 not supported")
+	s.assertBlockList(c, 0)
+}
+
+func (s *blockSuite) TestSwitchBlockOnInvalidSchedule(c *gc.C) {
+	on := params.BlockSwitchParams{
+		Type:     state.ChangeBlock.String(),
+		Schedule: &params.BlockSchedule{Start: "0 9 * * 1-5"},
+	}
+	err := s.api.SwitchBlockOn(on)
+	c.Assert(err.Error, gc.ErrorMatches, "block window duration 0 not valid")
+	s.assertBlockList(c, 0)
+}
+
 func (s *blockSuite) TestSwitchInvalidBlockOn(c *gc.C) {
 	on := params.BlockSwitchParams{
 		Type:    "invalid_block_type",
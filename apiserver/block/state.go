@@ -7,7 +7,7 @@ import "github.com/juju/juju/state"
 
 type blockAccess interface {
 	AllBlocks() ([]state.Block, error)
-	SwitchBlockOn(t state.BlockType, msg string) error
+	SwitchBlockOnWithArgs(t state.BlockType, args state.BlockArgs) error
 	SwitchBlockOff(t state.BlockType) error
 }
 
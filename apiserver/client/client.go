@@ -27,6 +27,12 @@ import (
 
 func init() {
 	common.RegisterStandardFacade("Client", 1, NewClient)
+	common.RegisterStandardFacade("Client", 2, NewClientV2)
+	common.RegisterStandardFacade("Client", 3, NewClientV3)
+	// Version 4 is otherwise the same as version 3, but tells clients
+	// that constraint maximums, alternatives and preferences are
+	// understood.
+	common.RegisterStandardFacade("Client", 4, NewClientV3)
 }
 
 var logger = loggo.GetLogger("juju.apiserver.client")
@@ -59,6 +65,36 @@ var getState = func(st *state.State) stateInterface {
 	return &stateShim{st}
 }
 
+// ClientV2 implements version 2 of the Client facade. It adds
+// UnitHookHistory.
+type ClientV2 struct {
+	*Client
+}
+
+// NewClientV2 creates a new instance of version 2 of the Client Facade.
+func NewClientV2(st *state.State, resources *common.Resources, authorizer common.Authorizer) (*ClientV2, error) {
+	client, err := NewClient(st, resources, authorizer)
+	if err != nil {
+		return nil, err
+	}
+	return &ClientV2{client}, nil
+}
+
+// ClientV3 implements version 3 of the Client facade. Its ShareModel
+// also grants and revokes model user roles.
+type ClientV3 struct {
+	*ClientV2
+}
+
+// NewClientV3 creates a new instance of version 3 of the Client Facade.
+func NewClientV3(st *state.State, resources *common.Resources, authorizer common.Authorizer) (*ClientV3, error) {
+	client, err := NewClientV2(st, resources, authorizer)
+	if err != nil {
+		return nil, err
+	}
+	return &ClientV3{client}, nil
+}
+
 // NewClient creates a new instance of the Client Facade.
 func NewClient(st *state.State, resources *common.Resources, authorizer common.Authorizer) (*Client, error) {
 	if !authorizer.AuthClient() {
@@ -74,7 +110,7 @@ func NewClient(st *state.State, resources *common.Resources, authorizer common.A
 			statusSetter:  common.NewStatusSetter(st, common.AuthAlways()),
 			toolsFinder:   common.NewToolsFinder(st, st, urlGetter),
 		},
-		check: common.NewBlockChecker(st)}
+		check: common.NewUserBlockChecker(st, authorizer.GetAuthTag())}
 	return client, nil
 }
 
@@ -353,7 +389,17 @@ func (c *Client) ModelInfo() (params.ModelInfo, error) {
 }
 
 // ShareModel manages allowing and denying the given user(s) access to the model.
-func (c *Client) ShareModel(args params.ModifyModelUsers) (result params.ErrorResults, err error) {
+func (c *Client) ShareModel(args params.ModifyModelUsers) (params.ErrorResults, error) {
+	return c.shareModel(args, false)
+}
+
+// ShareModel manages allowing and denying the given user(s) access to
+// the model, and the roles they have on it.
+func (c *ClientV3) ShareModel(args params.ModifyModelUsers) (params.ErrorResults, error) {
+	return c.shareModel(args, true)
+}
+
+func (c *Client) shareModel(args params.ModifyModelUsers, withRoles bool) (result params.ErrorResults, err error) {
 	var createdBy names.UserTag
 	var ok bool
 	if createdBy, ok = c.api.auth.GetAuthTag().(names.UserTag); !ok {
@@ -388,6 +434,21 @@ func (c *Client) ShareModel(args params.ModifyModelUsers) (result params.ErrorRe
 				err = errors.Annotate(err, "could not unshare model")
 				result.Results[i].Error = common.ServerError(err)
 			}
+		case params.GrantModelRole, params.RevokeModelRole:
+			if !withRoles {
+				err := errors.NotSupportedf("%q action before Client v3", arg.Action)
+				result.Results[i].Error = common.ServerError(err)
+				continue
+			}
+			grant := state.RoleGrant{Role: state.ModelUserRole(arg.Role), Service: arg.Service}
+			if arg.Action == params.GrantModelRole {
+				err = c.api.stateAccessor.GrantModelUserRole(user, grant)
+			} else {
+				err = c.api.stateAccessor.RevokeModelUserRole(user, grant)
+			}
+			if err != nil {
+				result.Results[i].Error = common.ServerError(err)
+			}
 		default:
 			result.Results[i].Error = common.ServerError(errors.Errorf("unknown action %q", arg.Action))
 		}
@@ -417,6 +478,10 @@ func (c *Client) ModelUserInfo() (params.ModelUserInfoResults, error) {
 		} else {
 			lastConn = &userLastConn
 		}
+		var roles []string
+		for _, grant := range user.Roles() {
+			roles = append(roles, grant.String())
+		}
 		results.Results = append(results.Results, params.ModelUserInfoResult{
 			Result: &params.ModelUserInfo{
 				UserName:       user.UserName(),
@@ -424,6 +489,7 @@ func (c *Client) ModelUserInfo() (params.ModelUserInfoResults, error) {
 				CreatedBy:      user.CreatedBy(),
 				DateCreated:    user.DateCreated(),
 				LastConnection: lastConn,
+				Roles:          roles,
 			},
 		})
 	}
@@ -581,5 +647,5 @@ func (c *Client) DestroyModel() (err error) {
 	}
 
 	modelTag := c.api.stateAccessor.ModelTag()
-	return errors.Trace(common.DestroyModel(c.api.state(), modelTag))
+	return errors.Trace(common.DestroyModel(c.api.state(), modelTag, c.api.auth.GetAuthTag()))
 }
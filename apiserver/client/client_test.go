@@ -14,6 +14,7 @@ import (
 	"github.com/juju/errors"
 	"github.com/juju/names"
 	jc "github.com/juju/testing/checkers"
+	"golang.org/x/net/context"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/charm.v6-unstable"
 
@@ -74,6 +75,13 @@ func (s *serverSuite) setAgentPresence(c *gc.C, machineId string) *presence.Ping
 	return pinger
 }
 
+func (s *serverSuite) TestFullStatusCancelled(c *gc.C) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := s.client.FullStatus(ctx, params.StatusParams{})
+	c.Assert(errors.Cause(err), gc.Equals, context.Canceled)
+}
+
 func (s *serverSuite) TestModelUsersInfo(c *gc.C) {
 	testAdmin := s.AdminUserTag(c)
 	owner, err := s.State.ModelUser(testAdmin)
@@ -126,6 +134,7 @@ func (s *serverSuite) TestModelUsersInfo(c *gc.C) {
 		r.info.CreatedBy = owner.UserName()
 		r.info.DateCreated = r.user.DateCreated()
 		r.info.LastConnection = lastConnPointer(c, r.user)
+		r.info.Roles = []string{"admin"}
 		expected.Results = append(expected.Results, params.ModelUserInfoResult{Result: r.info})
 	}
 
@@ -384,6 +393,74 @@ func (s *serverSuite) TestShareModelInvalidAction(c *gc.C) {
 	c.Assert(result.Results[0].Error, gc.ErrorMatches, expectedErr)
 }
 
+func (s *serverSuite) clientV3(c *gc.C) *client.ClientV3 {
+	auth := testing.FakeAuthorizer{
+		Tag:            s.AdminUserTag(c),
+		EnvironManager: true,
+	}
+	clientV3, err := client.NewClientV3(s.State, common.NewResources(), auth)
+	c.Assert(err, jc.ErrorIsNil)
+	return clientV3
+}
+
+func (s *serverSuite) TestShareModelGrantAndRevokeRole(c *gc.C) {
+	user := s.Factory.MakeModelUser(c, &factory.ModelUserParams{ReadOnly: true})
+	args := params.ModifyModelUsers{
+		Changes: []params.ModifyModelUser{{
+			UserTag: user.UserTag().String(),
+			Action:  params.GrantModelRole,
+			Role:    "operator",
+		}}}
+
+	clientV3 := s.clientV3(c)
+	result, err := clientV3.ShareModel(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.OneError(), gc.IsNil)
+	user, err = s.State.ModelUser(user.UserTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(user.Roles(), jc.DeepEquals, []state.RoleGrant{{Role: state.OperatorRole}})
+
+	args.Changes[0].Action = params.RevokeModelRole
+	result, err = clientV3.ShareModel(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.OneError(), gc.IsNil)
+	user, err = s.State.ModelUser(user.UserTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(user.Roles(), jc.DeepEquals, []state.RoleGrant{{Role: state.ReadRole}})
+}
+
+func (s *serverSuite) TestShareModelGrantInvalidRole(c *gc.C) {
+	user := s.Factory.MakeModelUser(c, nil)
+	args := params.ModifyModelUsers{
+		Changes: []params.ModifyModelUser{{
+			UserTag: user.UserTag().String(),
+			Action:  params.GrantModelRole,
+			Role:    "admin",
+			Service: "wordpress",
+		}}}
+
+	result, err := s.clientV3(c).ShareModel(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.OneError(), gc.ErrorMatches, `admin role scoped to a service not valid`)
+}
+
+func (s *serverSuite) TestShareModelGrantRoleNeedsV3(c *gc.C) {
+	user := s.Factory.MakeModelUser(c, nil)
+	args := params.ModifyModelUsers{
+		Changes: []params.ModifyModelUser{{
+			UserTag: user.UserTag().String(),
+			Action:  params.GrantModelRole,
+			Role:    "operator",
+		}}}
+
+	result, err := s.client.ShareModel(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.OneError(), gc.ErrorMatches, `"grant" action before Client v3 not supported`)
+	user, err = s.State.ModelUser(user.UserTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(user.Roles(), jc.DeepEquals, []state.RoleGrant{{Role: state.AdminRole}})
+}
+
 func (s *serverSuite) TestSetEnvironAgentVersion(c *gc.C) {
 	args := params.SetModelAgentVersion{
 		Version: version.MustParse("9.8.7"),
@@ -6,6 +6,7 @@ package client
 import (
 	"github.com/juju/names"
 	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/mgo.v2"
 
 	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/environs/config"
@@ -25,6 +26,7 @@ type Unit interface {
 	PrivateAddress() (network.Address, error)
 	Resolve(retryHooks bool) error
 	AgentHistory() state.StatusHistoryGetter
+	HookHistory(size int) ([]state.HookRecord, error)
 }
 
 // stateInterface contains the state.State methods used in this package,
@@ -58,9 +60,12 @@ type stateInterface interface {
 	AddRelation(...state.Endpoint) (*state.Relation, error)
 	AddModelUser(state.ModelUserSpec) (*state.ModelUser, error)
 	RemoveModelUser(names.UserTag) error
+	GrantModelUserRole(names.UserTag, state.RoleGrant) error
+	RevokeModelUserRole(names.UserTag, state.RoleGrant) error
 	Watch() *state.Multiwatcher
 	AbortCurrentUpgrade() error
 	APIHostPorts() ([][]network.HostPort, error)
+	MongoSession() *mgo.Session
 }
 
 type stateShim struct {
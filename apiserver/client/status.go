@@ -10,6 +10,7 @@ import (
 
 	"github.com/juju/errors"
 	"github.com/juju/utils/set"
+	"golang.org/x/net/context"
 	"gopkg.in/juju/charm.v6-unstable"
 	"gopkg.in/juju/charm.v6-unstable/hooks"
 
@@ -84,8 +85,41 @@ func (c *Client) UnitStatusHistory(args params.StatusHistory) (params.UnitStatus
 	return statuses, nil
 }
 
-// FullStatus gives the information needed for juju status over the api
-func (c *Client) FullStatus(args params.StatusParams) (params.FullStatus, error) {
+// UnitHookHistory returns up to the given number of the hooks most
+// recently run by a unit, newest first.
+func (c *ClientV2) UnitHookHistory(args params.HookHistoryArgs) (params.HookHistoryResult, error) {
+	if args.Size < 1 {
+		return params.HookHistoryResult{}, errors.Errorf("invalid history size: %d", args.Size)
+	}
+	unit, err := c.api.stateAccessor.Unit(args.Unit)
+	if err != nil {
+		return params.HookHistoryResult{}, errors.Trace(err)
+	}
+	records, err := unit.HookHistory(args.Size)
+	if err != nil {
+		return params.HookHistoryResult{}, errors.Trace(err)
+	}
+	result := params.HookHistoryResult{
+		Records: make([]params.HookRecord, len(records)),
+	}
+	for i, record := range records {
+		result.Records[i] = params.HookRecord{
+			Hook:       record.Hook,
+			Relation:   record.Relation,
+			RemoteUnit: record.RemoteUnit,
+			Started:    record.Started,
+			Duration:   record.Duration,
+			ExitCode:   record.ExitCode,
+			Stderr:     record.Stderr,
+		}
+	}
+	return result, nil
+}
+
+// FullStatus gives the information needed for juju status over the api.
+// Gathering the status of a large model can take a while, so it gives
+// up early if ctx is cancelled.
+func (c *Client) FullStatus(ctx context.Context, args params.StatusParams) (params.FullStatus, error) {
 	cfg, err := c.api.stateAccessor.ModelConfig()
 	if err != nil {
 		return params.FullStatus{}, errors.Annotate(err, "could not get environ config")
@@ -101,6 +135,8 @@ func (c *Client) FullStatus(args params.StatusParams) (params.FullStatus, error)
 		return noStatus, errors.Annotate(err, "could not fetch relations")
 	} else if context.networks, err = fetchNetworks(c.api.stateAccessor); err != nil {
 		return noStatus, errors.Annotate(err, "could not fetch networks")
+	} else if err := ctx.Err(); err != nil {
+		return noStatus, errors.Trace(err)
 	}
 
 	logger.Debugf("Services: %v", context.services)
@@ -175,12 +211,26 @@ func (c *Client) FullStatus(args params.StatusParams) (params.FullStatus, error)
 			context.machines[status] = filteredList
 		}
 	}
+	if err := ctx.Err(); err != nil {
+		return noStatus, errors.Trace(err)
+	}
 
 	newToolsVersion, err := c.newToolsVersionAvailable()
 	if err != nil {
 		return noStatus, errors.Annotate(err, "cannot determine if there is a new tools version available")
 	}
 
+	// Log usage is informational, so failing to determine it
+	// shouldn't stop the rest of the status being reported.
+	var logsMB float64
+	var logsMaxMB int
+	if logsMB, err = state.ModelLogsMB(c.api.stateAccessor); err != nil {
+		logger.Warningf("cannot determine log usage: %v", err)
+		logsMB = 0
+	} else {
+		logsMaxMB, _ = cfg.LogsMaxSize()
+	}
+
 	return params.FullStatus{
 		ModelName:        cfg.Name(),
 		AvailableVersion: newToolsVersion,
@@ -188,6 +238,8 @@ func (c *Client) FullStatus(args params.StatusParams) (params.FullStatus, error)
 		Services:         context.processServices(),
 		Networks:         context.processNetworks(),
 		Relations:        context.processRelations(),
+		LogsMB:           logsMB,
+		LogsMaxMB:        logsMaxMB,
 	}, nil
 }
 
@@ -218,7 +270,7 @@ func (c *Client) newToolsVersionAvailable() (string, error) {
 // Status is a stub version of FullStatus that was introduced in 1.16
 func (c *Client) Status() (params.LegacyStatus, error) {
 	var legacyStatus params.LegacyStatus
-	status, err := c.FullStatus(params.StatusParams{})
+	status, err := c.FullStatus(context.Background(), params.StatusParams{})
 	if err != nil {
 		return legacyStatus, err
 	}
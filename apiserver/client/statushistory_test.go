@@ -23,7 +23,7 @@ var _ = gc.Suite(&statusHistoryTestSuite{})
 type statusHistoryTestSuite struct {
 	testing.BaseSuite
 	st  *mockState
-	api *client.Client
+	api *client.ClientV2
 }
 
 func (s *statusHistoryTestSuite) SetUpTest(c *gc.C) {
@@ -32,7 +32,7 @@ func (s *statusHistoryTestSuite) SetUpTest(c *gc.C) {
 	tag := names.NewUserTag("user")
 	authorizer := &apiservertesting.FakeAuthorizer{Tag: tag}
 	var err error
-	s.api, err = client.NewClient(nil, nil, authorizer)
+	s.api, err = client.NewClientV2(nil, nil, authorizer)
 	c.Assert(err, jc.ErrorIsNil)
 }
 
@@ -170,10 +170,52 @@ func (s *statusHistoryTestSuite) TestStatusHistoryCombined(c *gc.C) {
 	checkStatusInfo(c, h.Statuses, expected)
 }
 
+func (s *statusHistoryTestSuite) TestHookHistorySizeRequired(c *gc.C) {
+	_, err := s.api.UnitHookHistory(params.HookHistoryArgs{
+		Unit: "unit/0",
+		Size: 0,
+	})
+	c.Assert(err, gc.ErrorMatches, "invalid history size: 0")
+}
+
+func (s *statusHistoryTestSuite) TestHookHistory(c *gc.C) {
+	started := time.Date(2016, 4, 1, 12, 0, 0, 0, time.UTC)
+	s.st.hookHistory = []state.HookRecord{{
+		Hook:       "db-relation-changed",
+		Relation:   "db:1",
+		RemoteUnit: "mysql/0",
+		Started:    started.Add(time.Minute),
+		Duration:   3 * time.Second,
+		ExitCode:   1,
+		Stderr:     "boom",
+	}, {
+		Hook:     "install",
+		Started:  started,
+		Duration: time.Minute,
+	}}
+	h, err := s.api.UnitHookHistory(params.HookHistoryArgs{
+		Unit: "unit/0",
+		Size: 1,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(h, jc.DeepEquals, params.HookHistoryResult{
+		Records: []params.HookRecord{{
+			Hook:       "db-relation-changed",
+			Relation:   "db:1",
+			RemoteUnit: "mysql/0",
+			Started:    started.Add(time.Minute),
+			Duration:   3 * time.Second,
+			ExitCode:   1,
+			Stderr:     "boom",
+		}},
+	})
+}
+
 type mockState struct {
 	client.StateInterface
 	unitHistory  []state.StatusInfo
 	agentHistory []state.StatusInfo
+	hookHistory  []state.HookRecord
 }
 
 func (m *mockState) ModelUUID() string {
@@ -187,12 +229,14 @@ func (m *mockState) Unit(name string) (client.Unit, error) {
 	return &mockUnit{
 		status: m.unitHistory,
 		agent:  &mockUnitAgent{m.agentHistory},
+		hooks:  m.hookHistory,
 	}, nil
 }
 
 type mockUnit struct {
 	status statuses
 	agent  *mockUnitAgent
+	hooks  []state.HookRecord
 	client.Unit
 }
 
@@ -204,6 +248,13 @@ func (m *mockUnit) AgentHistory() state.StatusHistoryGetter {
 	return m.agent
 }
 
+func (m *mockUnit) HookHistory(size int) ([]state.HookRecord, error) {
+	if size > len(m.hooks) {
+		size = len(m.hooks)
+	}
+	return m.hooks[:size], nil
+}
+
 type mockUnitAgent struct {
 	statuses
 }
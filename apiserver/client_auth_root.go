@@ -4,7 +4,11 @@
 package apiserver
 
 import (
+	"reflect"
+
 	"github.com/juju/errors"
+	"github.com/juju/utils/set"
+	"golang.org/x/net/context"
 
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/rpc"
@@ -12,9 +16,8 @@ import (
 	"github.com/juju/juju/state"
 )
 
-// clientAuthRoot restricts API calls for users of a model. Initially the
-// authorisation checks are only for read only access to the model, but in the
-// near future, full ACL support is desirable.
+// clientAuthRoot restricts API calls for users of a model to those
+// allowed by the roles they have been granted on it.
 type clientAuthRoot struct {
 	finder rpc.MethodFinder
 	user   *state.ModelUser
@@ -25,8 +28,10 @@ func newClientAuthRoot(finder rpc.MethodFinder, user *state.ModelUser) *clientAu
 	return &clientAuthRoot{finder, user}
 }
 
-// FindMethod returns a not supported error if the rootName is not one of the
-// facades available at the server root when there is no active model.
+// FindMethod returns a permission error if the user's roles do not
+// allow the method on the facade to be called. If they allow it only
+// on some services, the returned caller checks the services each call
+// acts on.
 func (r *clientAuthRoot) FindMethod(rootName string, version int, methodName string) (rpcreflect.MethodCaller, error) {
 	// The lookup of the name is done first to return a not found error if the
 	// user is looking for a method that we just don't have.
@@ -34,15 +39,48 @@ func (r *clientAuthRoot) FindMethod(rootName string, version int, methodName str
 	if err != nil {
 		return nil, err
 	}
-	if r.user.ReadOnly() {
-		canCall := isCallAllowableByReadOnlyUser(rootName, methodName) ||
-			isCallReadOnly(rootName, methodName)
-		if !canCall {
-			return nil, errors.Trace(common.ErrPerm)
-		}
+	if isCallAllowableByReadOnlyUser(rootName, methodName) || isCallReadOnly(rootName, methodName) {
+		return caller, nil
+	}
+	all, services := allowedServices(r.user.Roles(), rootName, methodName)
+	if all {
+		return caller, nil
 	}
+	callServices, ok := serviceCallServices[rootName+"."+methodName]
+	if !ok || services.IsEmpty() {
+		return nil, errors.Trace(common.ErrPerm)
+	}
+	return &serviceScopedCaller{
+		MethodCaller: caller,
+		services:     services,
+		callServices: callServices,
+	}, nil
+}
+
+// serviceScopedCaller wraps a rpcreflect.MethodCaller, refusing calls
+// that act on any service not in scope.
+type serviceScopedCaller struct {
+	rpcreflect.MethodCaller
+	services     set.Strings
+	callServices func(arg interface{}) ([]string, error)
+}
 
-	return caller, nil
+// Call implements rpcreflect.MethodCaller.
+func (c *serviceScopedCaller) Call(ctx context.Context, objId string, arg reflect.Value) (reflect.Value, error) {
+	if !arg.IsValid() {
+		return reflect.Value{}, errors.Trace(common.ErrPerm)
+	}
+	services, err := c.callServices(arg.Interface())
+	if err != nil {
+		logger.Debugf("cannot check services for scoped call: %v", err)
+		return reflect.Value{}, errors.Trace(common.ErrPerm)
+	}
+	for _, service := range services {
+		if !c.services.Contains(service) {
+			return reflect.Value{}, errors.Trace(common.ErrPerm)
+		}
+	}
+	return c.MethodCaller.Call(ctx, objId, arg)
 }
 
 // isCallAllowableByReadOnlyUser returns whether or not the method on the facade
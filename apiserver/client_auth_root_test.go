@@ -11,9 +11,12 @@ import (
 	"github.com/juju/juju/testing/factory"
 
 	jc "github.com/juju/testing/checkers"
+	"golang.org/x/net/context"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/rpc/rpcreflect"
+	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/testing"
 )
 
@@ -63,6 +66,82 @@ func (s *clientAuthRootSuite) TestReadOnlyUser(c *gc.C) {
 	s.AssertCallNotImplemented(c, client, "Unknown", 1, "Method")
 }
 
+func (s *clientAuthRootSuite) TestOperatorUser(c *gc.C) {
+	envUser := s.Factory.MakeModelUser(c, &factory.ModelUserParams{
+		ReadOnly: true,
+		Roles:    []state.RoleGrant{{Role: state.OperatorRole}},
+	})
+	client := newClientAuthRoot(&fakeFinder{}, envUser)
+	s.AssertCallGood(c, client, "Client", 1, "Run")
+	s.AssertCallGood(c, client, "Client", 1, "Resolved")
+	s.AssertCallGood(c, client, "Action", 1, "Enqueue")
+	s.AssertCallGood(c, client, "Client", 1, "FullStatus")
+	s.AssertCallErrPerm(c, client, "Service", 3, "Deploy")
+	s.AssertCallErrPerm(c, client, "Service", 3, "Destroy")
+	s.AssertCallErrPerm(c, client, "Client", 1, "DestroyMachines")
+}
+
+func (s *clientAuthRootSuite) TestScopedDeployerUser(c *gc.C) {
+	s.Factory.MakeService(c, &factory.ServiceParams{Name: "wordpress"})
+	envUser := s.Factory.MakeModelUser(c, &factory.ModelUserParams{
+		ReadOnly: true,
+		Roles:    []state.RoleGrant{{Role: state.DeployerRole, Service: "wordpress"}},
+	})
+	client := newClientAuthRoot(&fakeFinder{}, envUser)
+	s.AssertCallGood(c, client, "Client", 1, "FullStatus")
+	// Calls that act on the model as a whole are not allowed.
+	s.AssertCallErrPerm(c, client, "Client", 1, "SetModelConstraints")
+	s.AssertCallErrPerm(c, client, "Client", 1, "Run")
+
+	caller, err := client.FindMethod("Service", 3, "Set")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = caller.Call(context.Background(), "", reflect.ValueOf(params.ServiceSet{ServiceName: "wordpress"}))
+	c.Check(err, jc.ErrorIsNil)
+	_, err = caller.Call(context.Background(), "", reflect.ValueOf(params.ServiceSet{ServiceName: "mysql"}))
+	c.Check(errors.Cause(err), gc.Equals, common.ErrPerm)
+
+	caller, err = client.FindMethod("Service", 3, "AddRelation")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = caller.Call(context.Background(), "", reflect.ValueOf(params.AddRelation{
+		Endpoints: []string{"wordpress:db", "mysql:server"},
+	}))
+	c.Check(errors.Cause(err), gc.Equals, common.ErrPerm)
+
+	caller, err = client.FindMethod("Client", 1, "AddCharm")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = caller.Call(context.Background(), "", reflect.ValueOf(params.CharmURL{URL: "cs:trusty/wordpress-2"}))
+	c.Check(err, jc.ErrorIsNil)
+}
+
+func (s *clientAuthRootSuite) TestScopedOperatorUser(c *gc.C) {
+	s.Factory.MakeService(c, &factory.ServiceParams{Name: "wordpress"})
+	envUser := s.Factory.MakeModelUser(c, &factory.ModelUserParams{
+		ReadOnly: true,
+		Roles:    []state.RoleGrant{{Role: state.OperatorRole, Service: "wordpress"}},
+	})
+	client := newClientAuthRoot(&fakeFinder{}, envUser)
+	s.AssertCallErrPerm(c, client, "Client", 1, "RunOnAllMachines")
+	s.AssertCallErrPerm(c, client, "Service", 3, "Set")
+
+	caller, err := client.FindMethod("Client", 1, "Run")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = caller.Call(context.Background(), "", reflect.ValueOf(params.RunParams{
+		Services: []string{"wordpress"},
+		Units:    []string{"wordpress/0"},
+	}))
+	c.Check(err, jc.ErrorIsNil)
+	_, err = caller.Call(context.Background(), "", reflect.ValueOf(params.RunParams{
+		Units:    []string{"wordpress/0"},
+		Machines: []string{"0"},
+	}))
+	c.Check(errors.Cause(err), gc.Equals, common.ErrPerm)
+
+	caller, err = client.FindMethod("Client", 1, "Resolved")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = caller.Call(context.Background(), "", reflect.ValueOf(params.Resolved{UnitName: "mysql/0"}))
+	c.Check(errors.Cause(err), gc.Equals, common.ErrPerm)
+}
+
 func isCallNotImplementedError(err error) bool {
 	_, ok := err.(*rpcreflect.CallNotImplementedError)
 	return ok
@@ -93,6 +172,6 @@ func (*fakeCaller) ResultType() reflect.Type {
 	return reflect.TypeOf("")
 }
 
-func (*fakeCaller) Call(_ /*objId*/ string, _ /*arg*/ reflect.Value) (reflect.Value, error) {
+func (*fakeCaller) Call(_ context.Context, _ /*objId*/ string, _ /*arg*/ reflect.Value) (reflect.Value, error) {
 	return reflect.ValueOf(""), nil
 }
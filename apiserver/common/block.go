@@ -4,7 +4,10 @@
 package common
 
 import (
+	"time"
+
 	"github.com/juju/errors"
+	"github.com/juju/names"
 
 	"github.com/juju/juju/state"
 )
@@ -13,13 +16,23 @@ type BlockGetter interface {
 	GetBlockForType(t state.BlockType) (state.Block, bool, error)
 }
 
+// blockCheckerNow returns the time at which blocks are checked.
+var blockCheckerNow = time.Now
+
 // BlockChecker checks for current blocks if any.
 type BlockChecker struct {
 	getter BlockGetter
+	user   names.Tag
 }
 
 func NewBlockChecker(s BlockGetter) *BlockChecker {
-	return &BlockChecker{s}
+	return &BlockChecker{getter: s}
+}
+
+// NewUserBlockChecker returns a BlockChecker for operations requested
+// by the given entity, to which blocks that exempt it do not apply.
+func NewUserBlockChecker(s BlockGetter, user names.Tag) *BlockChecker {
+	return &BlockChecker{getter: s, user: user}
 }
 
 // ChangeAllowed checks if change block is in place.
@@ -62,8 +75,33 @@ func (c *BlockChecker) checkBlock(blockType state.BlockType) error {
 	if err != nil {
 		return errors.Trace(err)
 	}
-	if isEnabled {
+	if !isEnabled {
+		return nil
+	}
+	applies, err := c.blockApplies(aBlock)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if applies {
 		return OperationBlockedError(aBlock.Message())
 	}
 	return nil
 }
+
+// blockApplies returns whether the block applies to the checked
+// operation now: that it has not expired, that one of its windows is
+// open if it has a schedule, and that it does not exempt the user.
+func (c *BlockChecker) blockApplies(b state.Block) (bool, error) {
+	applies, err := state.BlockAppliesAt(b, blockCheckerNow())
+	if err != nil || !applies {
+		return false, errors.Trace(err)
+	}
+	if user, ok := c.user.(names.UserTag); ok {
+		for _, exempt := range b.ExemptUsers() {
+			if exempt == user.Canonical() {
+				return false, nil
+			}
+		}
+	}
+	return true, nil
+}
@@ -4,6 +4,8 @@
 package common_test
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	"github.com/juju/names"
 	jc "github.com/juju/testing/checkers"
@@ -17,8 +19,11 @@ import (
 
 type mockBlock struct {
 	state.Block
-	t state.BlockType
-	m string
+	t        state.BlockType
+	m        string
+	expires  *time.Time
+	schedule *state.BlockSchedule
+	exempt   []string
 }
 
 func (m mockBlock) Id() string { return "" }
@@ -31,6 +36,12 @@ func (m mockBlock) Message() string { return m.m }
 
 func (m mockBlock) ModelUUID() string { return "" }
 
+func (m mockBlock) Expires() *time.Time { return m.expires }
+
+func (m mockBlock) Schedule() *state.BlockSchedule { return m.schedule }
+
+func (m mockBlock) ExemptUsers() []string { return m.exempt }
+
 type blockCheckerSuite struct {
 	testing.FakeJujuXDGDataHomeSuite
 	aBlock                  state.Block
@@ -90,6 +101,45 @@ func (s *blockCheckerSuite) TestChangeBlockChecker(c *gc.C) {
 	s.assertErrorBlocked(c, true, s.blockchecker.ChangeAllowed(), s.change.Message())
 }
 
+func (s *blockCheckerSuite) TestExpiredBlockChecker(c *gc.C) {
+	now := time.Date(2016, 4, 1, 12, 0, 0, 0, time.UTC)
+	s.PatchValue(common.BlockCheckerNow, func() time.Time { return now })
+	expires := now.Add(time.Minute)
+	s.aBlock = mockBlock{t: state.ChangeBlock, m: "expiring", expires: &expires}
+	s.assertErrorBlocked(c, true, s.blockchecker.ChangeAllowed(), "expiring")
+
+	now = expires
+	s.assertErrorBlocked(c, false, s.blockchecker.ChangeAllowed(), "expiring")
+}
+
+func (s *blockCheckerSuite) TestScheduledBlockChecker(c *gc.C) {
+	// Friday 1st April 2016.
+	now := time.Date(2016, 4, 1, 12, 0, 0, 0, time.UTC)
+	s.PatchValue(common.BlockCheckerNow, func() time.Time { return now })
+	s.aBlock = mockBlock{t: state.ChangeBlock, m: "working hours", schedule: &state.BlockSchedule{
+		Start:    "0 9 * * 1-5",
+		Duration: 8 * time.Hour,
+	}}
+	s.assertErrorBlocked(c, true, s.blockchecker.ChangeAllowed(), "working hours")
+
+	now = time.Date(2016, 4, 1, 18, 0, 0, 0, time.UTC)
+	s.assertErrorBlocked(c, false, s.blockchecker.ChangeAllowed(), "working hours")
+
+	now = time.Date(2016, 4, 2, 12, 0, 0, 0, time.UTC)
+	s.assertErrorBlocked(c, false, s.blockchecker.ChangeAllowed(), "working hours")
+}
+
+func (s *blockCheckerSuite) TestExemptUserBlockChecker(c *gc.C) {
+	s.aBlock = mockBlock{t: state.ChangeBlock, m: "frozen", exempt: []string{"ops-oncall@local"}}
+	s.assertErrorBlocked(c, true, s.blockchecker.ChangeAllowed(), "frozen")
+
+	checker := common.NewUserBlockChecker(s, names.NewUserTag("ops-oncall"))
+	s.assertErrorBlocked(c, false, checker.ChangeAllowed(), "frozen")
+
+	checker = common.NewUserBlockChecker(s, names.NewUserTag("bob"))
+	s.assertErrorBlocked(c, true, checker.ChangeAllowed(), "frozen")
+}
+
 func (s *blockCheckerSuite) assertErrorBlocked(c *gc.C, blocked bool, err error, msg string) {
 	if blocked {
 		c.Assert(params.IsCodeOperationBlocked(err), jc.IsTrue)
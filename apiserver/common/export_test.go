@@ -11,6 +11,7 @@ var (
 	EnvtoolsFindTools       = &envtoolsFindTools
 	SendMetrics             = &sendMetrics
 	MockableDestroyMachines = destroyMachines
+	BlockCheckerNow         = &blockCheckerNow
 )
 
 type Patcher interface {
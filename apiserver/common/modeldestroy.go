@@ -20,21 +20,23 @@ var sendMetrics = func(st *state.State) error {
 // all services and non-manager, non-manual machine instances in the specified
 // model. This function assumes that all necessary authentication checks
 // have been done. If the model is a controller hosting other
-// models, they will also be destroyed.
-func DestroyModelIncludingHosted(st *state.State, modelTag names.ModelTag) error {
-	return destroyModel(st, modelTag, true)
+// models, they will also be destroyed. Blocks that exempt the given
+// user do not prevent the destruction.
+func DestroyModelIncludingHosted(st *state.State, modelTag names.ModelTag, user names.Tag) error {
+	return destroyModel(st, modelTag, user, true)
 }
 
 // DestroyModel sets the environment to dying. Cleanup jobs then destroy
 // all services and non-manager, non-manual machine instances in the specified
 // model. This function assumes that all necessary authentication checks
 // have been done. An error will be returned if this model is a
-// controller hosting other model.
-func DestroyModel(st *state.State, modelTag names.ModelTag) error {
-	return destroyModel(st, modelTag, false)
+// controller hosting other model. Blocks that exempt the given user
+// do not prevent the destruction.
+func DestroyModel(st *state.State, modelTag names.ModelTag, user names.Tag) error {
+	return destroyModel(st, modelTag, user, false)
 }
 
-func destroyModel(st *state.State, modelTag names.ModelTag, destroyHostedModels bool) error {
+func destroyModel(st *state.State, modelTag names.ModelTag, user names.Tag, destroyHostedModels bool) error {
 	var err error
 	if modelTag != st.ModelTag() {
 		if st, err = st.ForModel(modelTag); err != nil {
@@ -54,13 +56,13 @@ func destroyModel(st *state.State, modelTag names.ModelTag, destroyHostedModels
 			if err != nil {
 				return errors.Trace(err)
 			}
-			check := NewBlockChecker(envSt)
+			check := NewUserBlockChecker(envSt, user)
 			if err = check.DestroyAllowed(); err != nil {
 				return errors.Trace(err)
 			}
 		}
 	} else {
-		check := NewBlockChecker(st)
+		check := NewUserBlockChecker(st, user)
 		if err = check.DestroyAllowed(); err != nil {
 			return errors.Trace(err)
 		}
@@ -91,7 +91,7 @@ func (s *destroyModelSuite) TestMetrics(c *gc.C) {
 	metricSender := &testMetricSender{}
 	s.PatchValue(common.SendMetrics, metricSender.SendMetrics)
 
-	err := common.DestroyModel(s.State, s.State.ModelTag())
+	err := common.DestroyModel(s.State, s.State.ModelTag(), s.AdminUserTag(c))
 	c.Assert(err, jc.ErrorIsNil)
 
 	metricSender.CheckCalls(c, []jtesting.StubCall{{FuncName: "SendMetrics"}})
@@ -102,7 +102,7 @@ func (s *destroyModelSuite) TestDestroyModelManual(c *gc.C) {
 
 	// If there are any non-manager manual machines in state, DestroyModel will
 	// error. It will not set the Dying flag on the environment.
-	err := common.DestroyModel(s.State, s.State.ModelTag())
+	err := common.DestroyModel(s.State, s.State.ModelTag(), s.AdminUserTag(c))
 	c.Assert(err, gc.ErrorMatches, fmt.Sprintf("failed to destroy model: manually provisioned machines must first be destroyed with `juju destroy-machine %s`", nonManager.Id()))
 	env, err := s.State.Model()
 	c.Assert(err, jc.ErrorIsNil)
@@ -114,7 +114,7 @@ func (s *destroyModelSuite) TestDestroyModelManual(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	err = nonManager.Remove()
 	c.Assert(err, jc.ErrorIsNil)
-	err = common.DestroyModel(s.State, s.State.ModelTag())
+	err = common.DestroyModel(s.State, s.State.ModelTag(), s.AdminUserTag(c))
 	c.Assert(err, jc.ErrorIsNil)
 	err = env.Refresh()
 	c.Assert(err, jc.ErrorIsNil)
@@ -136,7 +136,7 @@ func (s *destroyModelSuite) TestDestroyModel(c *gc.C) {
 	services, err := s.State.AllServices()
 	c.Assert(err, jc.ErrorIsNil)
 
-	err = common.DestroyModel(s.State, s.State.ModelTag())
+	err = common.DestroyModel(s.State, s.State.ModelTag(), s.AdminUserTag(c))
 	c.Assert(err, jc.ErrorIsNil)
 
 	runAllCleanups(c, s.State)
@@ -175,7 +175,7 @@ func (s *destroyModelSuite) TestBlockDestroyDestroyEnvironment(c *gc.C) {
 	// Setup environment
 	s.setUpInstances(c)
 	s.BlockDestroyModel(c, "TestBlockDestroyDestroyModel")
-	err := common.DestroyModel(s.State, s.State.ModelTag())
+	err := common.DestroyModel(s.State, s.State.ModelTag(), s.AdminUserTag(c))
 	s.AssertBlocked(c, err, "TestBlockDestroyDestroyModel")
 }
 
@@ -190,7 +190,7 @@ func (s *destroyModelSuite) TestBlockDestroyDestroyHostedModel(c *gc.C) {
 	defer block.Close()
 
 	block.BlockDestroyModel(c, "TestBlockDestroyDestroyModel")
-	err = common.DestroyModelIncludingHosted(s.State, s.State.ModelTag())
+	err = common.DestroyModelIncludingHosted(s.State, s.State.ModelTag(), s.AdminUserTag(c))
 	s.AssertBlocked(c, err, "TestBlockDestroyDestroyModel")
 }
 
@@ -198,7 +198,7 @@ func (s *destroyModelSuite) TestBlockRemoveDestroyModel(c *gc.C) {
 	// Setup model
 	s.setUpInstances(c)
 	s.BlockRemoveObject(c, "TestBlockRemoveDestroyModel")
-	err := common.DestroyModel(s.State, s.State.ModelTag())
+	err := common.DestroyModel(s.State, s.State.ModelTag(), s.AdminUserTag(c))
 	s.AssertBlocked(c, err, "TestBlockRemoveDestroyModel")
 }
 
@@ -207,7 +207,7 @@ func (s *destroyModelSuite) TestBlockChangesDestroyModel(c *gc.C) {
 	s.setUpInstances(c)
 	// lock model: can't destroy locked model
 	s.BlockAllChanges(c, "TestBlockChangesDestroyModel")
-	err := common.DestroyModel(s.State, s.State.ModelTag())
+	err := common.DestroyModel(s.State, s.State.ModelTag(), s.AdminUserTag(c))
 	s.AssertBlocked(c, err, "TestBlockChangesDestroyModel")
 }
 
@@ -249,7 +249,7 @@ func (s *destroyTwoModelsSuite) TestCleanupModelResources(c *gc.C) {
 	m := otherFactory.MakeMachine(c, nil)
 	otherFactory.MakeMachineNested(c, m.Id(), nil)
 
-	err := common.DestroyModel(s.otherState, s.otherState.ModelTag())
+	err := common.DestroyModel(s.otherState, s.otherState.ModelTag(), s.AdminUserTag(c))
 	c.Assert(err, jc.ErrorIsNil)
 
 	// Assert that the machines are not removed until the cleanup runs.
@@ -299,7 +299,7 @@ func (s *destroyTwoModelsSuite) TestDifferentStateModel(c *gc.C) {
 
 	// NOTE: pass in the main test State instance, which is 'bound'
 	// to the controller model.
-	err := common.DestroyModel(s.State, s.otherState.ModelTag())
+	err := common.DestroyModel(s.State, s.otherState.ModelTag(), s.AdminUserTag(c))
 	c.Assert(err, jc.ErrorIsNil)
 
 	runAllCleanups(c, s.otherState)
@@ -322,17 +322,17 @@ func (s *destroyTwoModelsSuite) TestDestroyControllerAfterNonControllerIsDestroy
 	m := otherFactory.MakeMachine(c, nil)
 	otherFactory.MakeMachineNested(c, m.Id(), nil)
 
-	err := common.DestroyModel(s.State, s.State.ModelTag())
+	err := common.DestroyModel(s.State, s.State.ModelTag(), s.AdminUserTag(c))
 	c.Assert(err, gc.ErrorMatches, "failed to destroy model: hosting 1 other models")
 
 	needsCleanup, err := s.State.NeedsCleanup()
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(needsCleanup, jc.IsFalse)
 
-	err = common.DestroyModel(s.State, s.otherState.ModelTag())
+	err = common.DestroyModel(s.State, s.otherState.ModelTag(), s.AdminUserTag(c))
 	c.Assert(err, jc.ErrorIsNil)
 
-	err = common.DestroyModel(s.State, s.State.ModelTag())
+	err = common.DestroyModel(s.State, s.State.ModelTag(), s.AdminUserTag(c))
 	c.Assert(err, jc.ErrorIsNil)
 
 	// Make sure we can continue to take the hosted model down while the
@@ -359,7 +359,7 @@ func (s *destroyTwoModelsSuite) TestDestroyControllerAndNonController(c *gc.C) {
 	m := otherFactory.MakeMachine(c, nil)
 	otherFactory.MakeMachineNested(c, m.Id(), nil)
 
-	err := common.DestroyModelIncludingHosted(s.State, s.State.ModelTag())
+	err := common.DestroyModelIncludingHosted(s.State, s.State.ModelTag(), s.AdminUserTag(c))
 	c.Assert(err, jc.ErrorIsNil)
 
 	runAllCleanups(c, s.State)
@@ -377,10 +377,10 @@ func (s *destroyTwoModelsSuite) TestCanDestroyNonBlockedModel(c *gc.C) {
 
 	bh.BlockDestroyModel(c, "TestBlockDestroyDestroyModel")
 
-	err := common.DestroyModel(s.State, s.otherState.ModelTag())
+	err := common.DestroyModel(s.State, s.otherState.ModelTag(), s.AdminUserTag(c))
 	c.Assert(err, jc.ErrorIsNil)
 
-	err = common.DestroyModel(s.State, s.State.ModelTag())
+	err = common.DestroyModel(s.State, s.State.ModelTag(), s.AdminUserTag(c))
 	bh.AssertBlocked(c, err, "TestBlockDestroyDestroyModel")
 }
 
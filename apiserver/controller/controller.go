@@ -27,6 +27,7 @@ func init() {
 // Controller defines the methods on the controller API end point.
 type Controller interface {
 	AllModels() (params.UserModelList, error)
+	AuditLog(args params.AuditLogFilter) (params.AuditLogResults, error)
 	DestroyController(args params.DestroyControllerArgs) error
 	ModelConfig() (params.ModelConfigResults, error)
 	ListBlockedModels() (params.ModelBlockInfoList, error)
@@ -132,6 +133,64 @@ func (s *ControllerAPI) AllModels() (params.UserModelList, error) {
 	return result, nil
 }
 
+// AuditLog returns the entries in the controller audit log that match
+// the given filter, most recent first. Callers must be controller
+// administrators to read the audit log.
+func (s *ControllerAPI) AuditLog(args params.AuditLogFilter) (params.AuditLogResults, error) {
+	result := params.AuditLogResults{}
+
+	filter := state.AuditEntryFilter{
+		Facade: args.Facade,
+		Limit:  args.Limit,
+	}
+	if args.UserTag != "" {
+		userTag, err := names.ParseUserTag(args.UserTag)
+		if err != nil {
+			return result, errors.Trace(err)
+		}
+		// Users are recorded by their canonical tag, so that
+		// "user-bob" finds the entries for "user-bob@local".
+		filter.OriginTag = names.NewUserTag(userTag.Canonical()).String()
+	}
+	if args.ModelTag != "" {
+		modelTag, err := names.ParseModelTag(args.ModelTag)
+		if err != nil {
+			return result, errors.Trace(err)
+		}
+		filter.ModelUUID = modelTag.Id()
+	}
+	if args.After != nil {
+		filter.After = *args.After
+	}
+	if args.Before != nil {
+		filter.Before = *args.Before
+	}
+
+	entries, err := s.state.AuditEntries(filter)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	result.Entries = make([]params.AuditLogEntry, len(entries))
+	for i, entry := range entries {
+		var modelTag string
+		if entry.ModelUUID != "" {
+			modelTag = names.NewModelTag(entry.ModelUUID).String()
+		}
+		result.Entries[i] = params.AuditLogEntry{
+			Timestamp: entry.Timestamp,
+			ModelTag:  modelTag,
+			OriginTag: entry.OriginTag,
+			Facade:    entry.Facade,
+			Version:   entry.Version,
+			Method:    entry.Method,
+			Args:      entry.Args,
+			Outcome:   entry.Outcome,
+			Error:     entry.Error,
+		}
+	}
+	return result, nil
+}
+
 // ListBlockedModels returns a list of all environments on the controller
 // which have a block in place.  The resulting slice is sorted by environment
 // name, then owner. Callers must be controller administrators to retrieve the
@@ -16,6 +16,7 @@ import (
 	"github.com/juju/juju/apiserver/controller"
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/audit"
 	jujutesting "github.com/juju/juju/juju/testing"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/multiwatcher"
@@ -189,6 +190,70 @@ func (s *controllerSuite) TestRemoveBlocksNotAll(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "not supported")
 }
 
+func (s *controllerSuite) addAuditEntry(c *gc.C, t time.Time, origin, facade string) {
+	err := s.State.AddAuditEntry(audit.AuditEntry{
+		Timestamp: t,
+		ModelUUID: s.State.ModelUUID(),
+		OriginTag: origin,
+		Facade:    facade,
+		Version:   1,
+		Method:    "Frob",
+		Outcome:   audit.OutcomeSucceeded,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *controllerSuite) TestAuditLog(c *gc.C) {
+	now := time.Now().Round(time.Second).UTC()
+	s.addAuditEntry(c, now, "user-alice@local", "Service")
+	s.addAuditEntry(c, now.Add(time.Second), "user-bob@local", "Client")
+
+	results, err := s.controller.AuditLog(params.AuditLogFilter{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Entries, jc.DeepEquals, []params.AuditLogEntry{{
+		Timestamp: now.Add(time.Second),
+		ModelTag:  s.State.ModelTag().String(),
+		OriginTag: "user-bob@local",
+		Facade:    "Client",
+		Version:   1,
+		Method:    "Frob",
+		Outcome:   audit.OutcomeSucceeded,
+	}, {
+		Timestamp: now,
+		ModelTag:  s.State.ModelTag().String(),
+		OriginTag: "user-alice@local",
+		Facade:    "Service",
+		Version:   1,
+		Method:    "Frob",
+		Outcome:   audit.OutcomeSucceeded,
+	}})
+}
+
+func (s *controllerSuite) TestAuditLogFiltered(c *gc.C) {
+	now := time.Now().Round(time.Second).UTC()
+	s.addAuditEntry(c, now, "user-alice@local", "Service")
+	s.addAuditEntry(c, now.Add(time.Second), "user-bob@local", "Client")
+	s.addAuditEntry(c, now.Add(2*time.Second), "user-alice@local", "Client")
+
+	after := now.Add(time.Second)
+	results, err := s.controller.AuditLog(params.AuditLogFilter{
+		UserTag:  "user-alice@local",
+		ModelTag: s.State.ModelTag().String(),
+		After:    &after,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Entries, gc.HasLen, 1)
+	c.Check(results.Entries[0].Facade, gc.Equals, "Client")
+	c.Check(results.Entries[0].OriginTag, gc.Equals, "user-alice@local")
+}
+
+func (s *controllerSuite) TestAuditLogBadTags(c *gc.C) {
+	_, err := s.controller.AuditLog(params.AuditLogFilter{UserTag: "machine-0"})
+	c.Assert(err, gc.ErrorMatches, `"machine-0" is not a valid user tag`)
+	_, err = s.controller.AuditLog(params.AuditLogFilter{ModelTag: "user-bob"})
+	c.Assert(err, gc.ErrorMatches, `"user-bob" is not a valid model tag`)
+}
+
 func (s *controllerSuite) TestWatchAllModels(c *gc.C) {
 	watcherId, err := s.controller.WatchAllModels()
 	c.Assert(err, jc.ErrorIsNil)
@@ -8,8 +8,11 @@ import (
 	"net"
 	"net/http"
 	"net/url"
+	"regexp"
 	"strconv"
+	"strings"
 	"syscall"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
@@ -70,6 +73,17 @@ func newDebugLogHandler(
 //   replay -> string - one of [true, false], if true, start the file from the start
 //   noTail -> string - one of [true, false], if true, existing logs are sent back,
 //      - but the command does not wait for new ones.
+//   field.<name> -> []string - only lines with one of these values for the
+//      - named field are included, e.g. field.hook=config-changed
+//   grep -> string - a regular expression that messages must match
+//   excludeGrep -> string - a regular expression that messages must not match
+//   startTime -> string - RFC3339 time; only lines logged at or after this
+//      - time are included
+//   endTime -> string - RFC3339 time; only lines logged before this time
+//      - are included, and no new lines are waited for once it has passed
+//   fromArchive -> string - one of [true, false], if true, lines are read
+//      - from the archives of pruned logs rather than the database; noTail
+//      - is implied
 func (h *debugLogHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 	server := websocket.Server{
 		Handler: func(conn *websocket.Conn) {
@@ -153,8 +167,18 @@ type debugLogParams struct {
 	excludeEntity []string
 	includeModule []string
 	excludeModule []string
+	includeFields map[string][]string
+	grep          string
+	excludeGrep   string
+	startTime     time.Time
+	endTime       time.Time
+	fromArchive   bool
 }
 
+// fieldParamPrefix prefixes the names of query parameters that filter
+// on log record fields.
+const fieldParamPrefix = "field."
+
 func readDebugLogParams(queryMap url.Values) (*debugLogParams, error) {
 	params := new(debugLogParams)
 
@@ -182,6 +206,14 @@ func readDebugLogParams(queryMap url.Values) (*debugLogParams, error) {
 		params.noTail = noTail
 	}
 
+	if value := queryMap.Get("fromArchive"); value != "" {
+		fromArchive, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, errors.Errorf("fromArchive value %q is not a valid boolean", value)
+		}
+		params.fromArchive = fromArchive
+	}
+
 	if value := queryMap.Get("backlog"); value != "" {
 		num, err := strconv.ParseUint(value, 10, 64)
 		if err != nil {
@@ -205,5 +237,54 @@ func readDebugLogParams(queryMap url.Values) (*debugLogParams, error) {
 	params.includeModule = queryMap["includeModule"]
 	params.excludeModule = queryMap["excludeModule"]
 
+	for _, name := range []string{"grep", "excludeGrep"} {
+		value := queryMap.Get(name)
+		if value == "" {
+			continue
+		}
+		if _, err := regexp.Compile(value); err != nil {
+			return nil, errors.Errorf("%s value %q is not a valid regular expression", name, value)
+		}
+		if name == "grep" {
+			params.grep = value
+		} else {
+			params.excludeGrep = value
+		}
+	}
+
+	if value := queryMap.Get("startTime"); value != "" {
+		t, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return nil, errors.Errorf("startTime value %q is not a valid RFC3339 time", value)
+		}
+		params.startTime = t
+	}
+
+	if value := queryMap.Get("endTime"); value != "" {
+		t, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return nil, errors.Errorf("endTime value %q is not a valid RFC3339 time", value)
+		}
+		params.endTime = t
+	}
+
+	if !params.startTime.IsZero() && !params.endTime.IsZero() && !params.endTime.After(params.startTime) {
+		return nil, errors.Errorf("endTime must be after startTime")
+	}
+
+	for key, values := range queryMap {
+		if !strings.HasPrefix(key, fieldParamPrefix) {
+			continue
+		}
+		name := strings.TrimPrefix(key, fieldParamPrefix)
+		if !state.IsValidLogFieldName(name) {
+			return nil, errors.Errorf("field name %q is not valid", name)
+		}
+		if params.includeFields == nil {
+			params.includeFields = make(map[string][]string)
+		}
+		params.includeFields[name] = values
+	}
+
 	return params, nil
 }
@@ -24,7 +24,12 @@ func handleDebugLogDBRequest(
 	stop <-chan struct{},
 ) error {
 	params := makeLogTailerParams(reqParams)
-	tailer := newLogTailer(st, params)
+	var tailer state.LogTailer
+	if reqParams.fromArchive {
+		tailer = newArchivedLogTailer(st, params)
+	} else {
+		tailer = newLogTailer(st, params)
+	}
 	defer tailer.Stop()
 
 	// Indicate that all is well.
@@ -56,13 +61,18 @@ func handleDebugLogDBRequest(
 
 func makeLogTailerParams(reqParams *debugLogParams) *state.LogTailerParams {
 	params := &state.LogTailerParams{
-		MinLevel:      reqParams.filterLevel,
-		NoTail:        reqParams.noTail,
-		InitialLines:  int(reqParams.backlog),
-		IncludeEntity: reqParams.includeEntity,
-		ExcludeEntity: reqParams.excludeEntity,
-		IncludeModule: reqParams.includeModule,
-		ExcludeModule: reqParams.excludeModule,
+		StartTime:      reqParams.startTime,
+		EndTime:        reqParams.endTime,
+		MinLevel:       reqParams.filterLevel,
+		NoTail:         reqParams.noTail,
+		InitialLines:   int(reqParams.backlog),
+		IncludeEntity:  reqParams.includeEntity,
+		ExcludeEntity:  reqParams.excludeEntity,
+		IncludeModule:  reqParams.includeModule,
+		ExcludeModule:  reqParams.excludeModule,
+		IncludeFields:  reqParams.includeFields,
+		IncludeMessage: reqParams.grep,
+		ExcludeMessage: reqParams.excludeGrep,
 	}
 	if reqParams.fromTheStart {
 		params.InitialLines = 0
@@ -90,3 +100,9 @@ var newLogTailer = _newLogTailer // For replacing in tests
 func _newLogTailer(st state.LoggingState, params *state.LogTailerParams) state.LogTailer {
 	return state.NewLogTailer(st, params)
 }
+
+var newArchivedLogTailer = _newArchivedLogTailer // For replacing in tests
+
+func _newArchivedLogTailer(st state.LoggingState, params *state.LogTailerParams) state.LogTailer {
+	return state.NewArchivedLogTailer(st, params)
+}
@@ -37,6 +37,7 @@ func (s *debugLogDBIntSuite) TestParamConversion(c *gc.C) {
 		includeModule: []string{"bar"},
 		excludeEntity: []string{"baz"},
 		excludeModule: []string{"qux"},
+		includeFields: map[string][]string{"hook": {"install"}},
 	}
 
 	called := false
@@ -53,6 +54,7 @@ func (s *debugLogDBIntSuite) TestParamConversion(c *gc.C) {
 		c.Assert(params.IncludeModule, jc.DeepEquals, []string{"bar"})
 		c.Assert(params.ExcludeEntity, jc.DeepEquals, []string{"baz"})
 		c.Assert(params.ExcludeModule, jc.DeepEquals, []string{"qux"})
+		c.Assert(params.IncludeFields, jc.DeepEquals, map[string][]string{"hook": {"install"}})
 
 		return newFakeLogTailer()
 	})
@@ -87,6 +89,69 @@ func (s *debugLogDBIntSuite) TestParamConversionReplay(c *gc.C) {
 	c.Assert(called, jc.IsTrue)
 }
 
+func (s *debugLogDBIntSuite) TestParamConversionSearch(c *gc.C) {
+	startTime := time.Date(2016, 4, 1, 12, 0, 0, 0, time.UTC)
+	endTime := startTime.Add(time.Hour)
+	reqParams := &debugLogParams{
+		grep:        "hook (install|start)",
+		excludeGrep: "retry",
+		startTime:   startTime,
+		endTime:     endTime,
+	}
+
+	called := false
+	s.PatchValue(&newLogTailer, func(_ state.LoggingState, params *state.LogTailerParams) state.LogTailer {
+		called = true
+
+		c.Assert(params.StartTime, gc.Equals, startTime)
+		c.Assert(params.EndTime, gc.Equals, endTime)
+		c.Assert(params.IncludeMessage, gc.Equals, "hook (install|start)")
+		c.Assert(params.ExcludeMessage, gc.Equals, "retry")
+
+		return newFakeLogTailer()
+	})
+
+	stop := make(chan struct{})
+	close(stop) // Stop the request immediately.
+	err := handleDebugLogDBRequest(nil, reqParams, s.sock, stop)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *debugLogDBIntSuite) TestFromArchive(c *gc.C) {
+	tailer := newFakeLogTailer()
+	tailer.logsCh <- &state.LogRecord{
+		Time:     time.Date(2015, 6, 19, 15, 34, 37, 0, time.UTC),
+		Entity:   "machine-99",
+		Module:   "some.where",
+		Location: "code.go:42",
+		Level:    loggo.INFO,
+		Message:  "archived stuff",
+	}
+	close(tailer.logsCh)
+	s.PatchValue(&newLogTailer, func(_ state.LoggingState, params *state.LogTailerParams) state.LogTailer {
+		c.Fatalf("live log tailer used")
+		return nil
+	})
+	s.PatchValue(&newArchivedLogTailer, func(_ state.LoggingState, params *state.LogTailerParams) state.LogTailer {
+		c.Assert(params.IncludeEntity, jc.DeepEquals, []string{"machine-99"})
+		return tailer
+	})
+
+	done := s.runRequest(&debugLogParams{
+		fromArchive:   true,
+		includeEntity: []string{"machine-99"},
+	}, nil)
+
+	s.assertOutput(c, []string{
+		"ok",
+		"machine-99: 2015-06-19 15:34:37 INFO some.where code.go:42 archived stuff\n",
+	})
+
+	// The request finishes once the archived logs have been sent.
+	s.assertStops(c, done, tailer)
+}
+
 func (s *debugLogDBIntSuite) TestFullRequest(c *gc.C) {
 	// Set up a fake log tailer with a 2 log records ready to send.
 	tailer := newFakeLogTailer()
@@ -28,6 +28,27 @@ func (s *debugLogBaseSuite) TestBadParams(c *gc.C) {
 	s.assertWebsocketClosed(c, reader)
 }
 
+func (s *debugLogBaseSuite) TestBadFieldName(c *gc.C) {
+	reader := s.openWebsocket(c, url.Values{"field.Bad.Name": {"foo"}})
+	assertJSONError(c, reader, `field name "Bad.Name" is not valid`)
+	s.assertWebsocketClosed(c, reader)
+}
+
+func (s *debugLogBaseSuite) TestBadGrep(c *gc.C) {
+	reader := s.openWebsocket(c, url.Values{"grep": {"hook ("}})
+	assertJSONError(c, reader, `grep value "hook \(" is not a valid regular expression`)
+	s.assertWebsocketClosed(c, reader)
+}
+
+func (s *debugLogBaseSuite) TestBadTimeWindow(c *gc.C) {
+	reader := s.openWebsocket(c, url.Values{
+		"startTime": {"2016-04-01T12:00:00Z"},
+		"endTime":   {"2016-04-01T11:00:00Z"},
+	})
+	assertJSONError(c, reader, `endTime must be after startTime`)
+	s.assertWebsocketClosed(c, reader)
+}
+
 func (s *debugLogBaseSuite) TestWithHTTP(c *gc.C) {
 	uri := s.logURL(c, "http", nil).String()
 	s.sendRequest(c, httpRequestParams{
@@ -16,6 +16,10 @@ import (
 
 func init() {
 	common.RegisterStandardFacade("HighAvailability", 2, NewHighAvailabilityAPI)
+	// Version 3 is otherwise the same as version 2, but tells clients
+	// that constraint maximums, alternatives and preferences are
+	// understood.
+	common.RegisterStandardFacade("HighAvailability", 3, NewHighAvailabilityAPI)
 }
 
 // HighAvailability defines the methods on the highavailability API end point.
@@ -49,7 +53,7 @@ func NewHighAvailabilityAPI(st *state.State, resources *common.Resources, author
 func (api *HighAvailabilityAPI) EnableHA(args params.ControllersSpecs) (params.ControllersChangeResults, error) {
 	results := params.ControllersChangeResults{Results: make([]params.ControllersChangeResult, len(args.Specs))}
 	for i, controllersServersSpec := range args.Specs {
-		result, err := EnableHASingle(api.state, controllersServersSpec)
+		result, err := EnableHASingle(api.state, api.authorizer.GetAuthTag(), controllersServersSpec)
 		results.Results[i].Result = result
 		results.Results[i].Error = common.ServerError(err)
 	}
@@ -79,12 +83,12 @@ func controllersChanges(change state.ControllersChanges) params.ControllersChang
 
 // EnableHASingle applies a single ControllersServersSpec specification to the current environment.
 // Exported so it can be called by the legacy client API in the client package.
-func EnableHASingle(st *state.State, spec params.ControllersSpec) (params.ControllersChanges, error) {
+func EnableHASingle(st *state.State, user names.Tag, spec params.ControllersSpec) (params.ControllersChanges, error) {
 	if !st.IsController() {
 		return params.ControllersChanges{}, errors.New("unsupported with hosted models")
 	}
 	// Check if changes are allowed and the command may proceed.
-	blockChecker := common.NewBlockChecker(st)
+	blockChecker := common.NewUserBlockChecker(st, user)
 	if err := blockChecker.ChangeAllowed(); err != nil {
 		return params.ControllersChanges{}, errors.Trace(err)
 	}
@@ -50,7 +50,7 @@ func NewImageManagerAPI(st *state.State, resources *common.Resources, authorizer
 		state:      getState(st),
 		resources:  resources,
 		authorizer: authorizer,
-		check:      common.NewBlockChecker(st),
+		check:      common.NewUserBlockChecker(st, authorizer.GetAuthTag()),
 	}, nil
 }
 
@@ -88,7 +88,7 @@ func NewKeyManagerAPI(st *state.State, resources *common.Resources, authorizer c
 		authorizer: authorizer,
 		canRead:    canRead,
 		canWrite:   canWrite,
-		check:      common.NewBlockChecker(st),
+		check:      common.NewUserBlockChecker(st, authorizer.GetAuthTag()),
 	}, nil
 }
 
@@ -79,6 +79,9 @@ func (h *logSinkHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 			defer dbLogger.Close()
 			m := new(params.LogRecord)
 			for {
+				// Receiving into a map merges with its existing
+				// contents, so start afresh for each record.
+				*m = params.LogRecord{}
 				if err := websocket.JSON.Receive(socket, m); err != nil {
 					if err != io.EOF {
 						logger.Errorf("error while receiving logs: %v", err)
@@ -91,7 +94,7 @@ func (h *logSinkHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 					logger.Errorf("logging to logsink.log failed: %v", fileErr)
 				}
 
-				dbErr := dbLogger.Log(m.Time, m.Module, m.Location, m.Level, m.Message)
+				dbErr := dbLogger.Log(m.Time, m.Module, m.Location, m.Level, m.Message, m.Fields)
 				if dbErr != nil {
 					logger.Errorf("logging to DB failed: %v", err)
 				}
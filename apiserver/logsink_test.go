@@ -114,6 +114,7 @@ func (s *logsinkSuite) TestLogging(c *gc.C) {
 		Location: "foo.go:42",
 		Level:    loggo.INFO,
 		Message:  "all is well",
+		Fields:   map[string]string{"hook": "install"},
 	})
 	c.Assert(err, jc.ErrorIsNil)
 
@@ -153,6 +154,7 @@ func (s *logsinkSuite) TestLogging(c *gc.C) {
 	c.Assert(docs[0]["l"], gc.Equals, "foo.go:42")
 	c.Assert(docs[0]["v"], gc.Equals, int(loggo.INFO))
 	c.Assert(docs[0]["x"], gc.Equals, "all is well")
+	c.Assert(docs[0]["f"], jc.DeepEquals, bson.M{"hook": "install"})
 
 	c.Assert(docs[1]["t"].(time.Time).Sub(t1), gc.Equals, time.Duration(0))
 	c.Assert(docs[1]["e"], gc.Equals, modelUUID)
@@ -161,6 +163,9 @@ func (s *logsinkSuite) TestLogging(c *gc.C) {
 	c.Assert(docs[1]["l"], gc.Equals, "bar.go:99")
 	c.Assert(docs[1]["v"], gc.Equals, int(loggo.ERROR))
 	c.Assert(docs[1]["x"], gc.Equals, "oh noes")
+	// Fields must not leak from one record to the next.
+	_, hasFields := docs[1]["f"]
+	c.Assert(hasFields, jc.IsFalse)
 
 	// Close connection.
 	err = conn.Close()
@@ -45,7 +45,7 @@ func NewMachineManagerAPI(
 	return &MachineManagerAPI{
 		st:         s,
 		authorizer: authorizer,
-		check:      common.NewBlockChecker(s),
+		check:      common.NewUserBlockChecker(s, authorizer.GetAuthTag()),
 	}, nil
 }
 
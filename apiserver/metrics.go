@@ -0,0 +1,51 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"net/http"
+
+	"github.com/juju/errors"
+	"github.com/juju/names"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/rpc"
+)
+
+// metricsContentType is the content type of the Prometheus text
+// exposition format.
+const metricsContentType = "text/plain; version=0.0.4"
+
+// metricsHandler serves the statistics collected about the API calls
+// made to the server, in the Prometheus text format. Only controller
+// administrators may read them.
+type metricsHandler struct {
+	ctxt    httpContext
+	metrics *rpc.Metrics
+}
+
+func (h *metricsHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != "GET" {
+		sendError(w, errors.MethodNotAllowedf("unsupported method: %q", req.Method))
+		return
+	}
+	st, entity, err := h.ctxt.stateForRequestAuthenticatedUser(req)
+	if err != nil {
+		sendError(w, err)
+		return
+	}
+	isAdmin, err := st.IsControllerAdministrator(entity.Tag().(names.UserTag))
+	if err != nil {
+		sendError(w, err)
+		return
+	}
+	if !isAdmin {
+		sendError(w, common.ErrPerm)
+		return
+	}
+	w.Header().Set("Content-Type", metricsContentType)
+	if err := h.metrics.WritePrometheus(w); err != nil {
+		logger.Errorf("cannot write API metrics: %v", err)
+	}
+}
@@ -0,0 +1,70 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+type metricsSuite struct {
+	authHttpSuite
+}
+
+var _ = gc.Suite(&metricsSuite{})
+
+func (s *metricsSuite) metricsURL(c *gc.C) string {
+	return s.makeURL(c, "https", "/metrics", nil).String()
+}
+
+func (s *metricsSuite) assertErrorResponse(c *gc.C, resp *http.Response, statusCode int, msg string) {
+	body, err := ioutil.ReadAll(resp.Body)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(resp.StatusCode, gc.Equals, statusCode, gc.Commentf("body: %s", body))
+	c.Assert(resp.Header.Get("Content-Type"), gc.Equals, params.ContentTypeJSON)
+
+	var result params.ErrorResult
+	err = json.Unmarshal(body, &result)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.ErrorMatches, msg)
+}
+
+func (s *metricsSuite) TestRequiresAuth(c *gc.C) {
+	resp := s.sendRequest(c, httpRequestParams{method: "GET", url: s.metricsURL(c)})
+	s.assertErrorResponse(c, resp, http.StatusUnauthorized, "no credentials provided")
+}
+
+func (s *metricsSuite) TestRequiresControllerAdmin(c *gc.C) {
+	resp := s.authRequest(c, httpRequestParams{method: "GET", url: s.metricsURL(c)})
+	s.assertErrorResponse(c, resp, http.StatusUnauthorized, "permission denied")
+}
+
+func (s *metricsSuite) TestInvalidHTTPMethod(c *gc.C) {
+	resp := s.authRequest(c, httpRequestParams{method: "POST", url: s.metricsURL(c)})
+	s.assertErrorResponse(c, resp, http.StatusMethodNotAllowed, `unsupported method: "POST"`)
+}
+
+func (s *metricsSuite) TestMetrics(c *gc.C) {
+	// Make an API call so that there is something to report.
+	_, err := s.APIState.Client().Status(nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	resp := s.sendRequest(c, httpRequestParams{
+		tag:      s.AdminUserTag(c).String(),
+		password: "dummy-secret",
+		method:   "GET",
+		url:      s.metricsURL(c),
+	})
+	body, err := ioutil.ReadAll(resp.Body)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(resp.StatusCode, gc.Equals, http.StatusOK, gc.Commentf("body: %s", body))
+	c.Assert(resp.Header.Get("Content-Type"), gc.Equals, "text/plain; version=0.0.4")
+	c.Assert(string(body), jc.Contains, `juju_rpc_calls_total{facade="Client",version="1",method="FullStatus"}`)
+}
@@ -12,9 +12,13 @@ import (
 
 const (
 	// ActionCancelled is the status for an Action that has been
-	// cancelled prior to execution.
+	// cancelled before it completed.
 	ActionCancelled string = "cancelled"
 
+	// ActionTimedOut is the status of an Action that was stopped
+	// because it ran for longer than its timeout.
+	ActionTimedOut string = "timed-out"
+
 	// ActionCompleted is the status of an Action that has completed
 	// successfully.
 	ActionCompleted string = "completed"
@@ -43,6 +47,14 @@ type Action struct {
 	Receiver   string                 `json:"receiver"`
 	Name       string                 `json:"name"`
 	Parameters map[string]interface{} `json:"parameters,omitempty"`
+
+	// Timeout holds how long the Action may run for before it is
+	// stopped. Zero means that it may run indefinitely.
+	Timeout time.Duration `json:"timeout,omitempty"`
+
+	// Group identifies the Actions queued together on the units of
+	// a service, if the Action was queued that way.
+	Group string `json:"group,omitempty"`
 }
 
 // ActionResults is a slice of ActionResult for bulk requests.
@@ -60,6 +72,84 @@ type ActionResult struct {
 	Message   string                 `json:"message,omitempty"`
 	Output    map[string]interface{} `json:"output,omitempty"`
 	Error     *Error                 `json:"error,omitempty"`
+
+	// CancelRequested is set when the running Action has been
+	// asked to stop.
+	CancelRequested bool `json:"cancel-requested,omitempty"`
+}
+
+// ServiceActions is a slice of ServiceAction for bulk requests.
+type ServiceActions struct {
+	Actions []ServiceAction `json:"actions,omitempty"`
+}
+
+// ServiceAction describes an Action to be queued on the units of a
+// service.
+type ServiceAction struct {
+	Service    string                 `json:"service"`
+	Name       string                 `json:"name"`
+	Parameters map[string]interface{} `json:"parameters,omitempty"`
+	Timeout    time.Duration          `json:"timeout,omitempty"`
+
+	// Leader restricts the Action to the service's leader unit,
+	// rather than running it on every unit.
+	Leader bool `json:"leader,omitempty"`
+}
+
+// ActionGroups holds the ids of groups of Actions.
+type ActionGroups struct {
+	Groups []string `json:"groups,omitempty"`
+}
+
+// ActionGroupResults is a slice of ActionGroupResult for bulk requests.
+type ActionGroupResults struct {
+	Results []ActionGroupResult `json:"results,omitempty"`
+}
+
+// ActionGroupResult describes the Actions queued together on the units
+// of a service.
+type ActionGroupResult struct {
+	Group   string         `json:"group,omitempty"`
+	Actions []ActionResult `json:"actions,omitempty"`
+	Error   *Error         `json:"error,omitempty"`
+}
+
+// ActionHistoryQueries holds a slice of ActionHistoryQuery for bulk
+// requests.
+type ActionHistoryQueries struct {
+	Queries []ActionHistoryQuery `json:"queries,omitempty"`
+}
+
+// ActionHistoryQuery describes the Actions to be returned from the
+// history of Actions. Zero values match any Action.
+type ActionHistoryQuery struct {
+	Receiver       string    `json:"receiver,omitempty"`
+	Name           string    `json:"name,omitempty"`
+	Statuses       []string  `json:"statuses,omitempty"`
+	EnqueuedAfter  time.Time `json:"enqueued-after,omitempty"`
+	EnqueuedBefore time.Time `json:"enqueued-before,omitempty"`
+	Offset         int       `json:"offset,omitempty"`
+	Limit          int       `json:"limit,omitempty"`
+}
+
+// ActionHistoryResults holds a slice of ActionHistoryResult for bulk
+// requests.
+type ActionHistoryResults struct {
+	Results []ActionHistoryResult `json:"results,omitempty"`
+}
+
+// ActionHistoryResult holds the Actions matching an
+// ActionHistoryQuery, most recently queued first.
+type ActionHistoryResult struct {
+	Actions []ActionResult `json:"actions,omitempty"`
+	Error   *Error         `json:"error,omitempty"`
+}
+
+// ActionPruneArgs holds the limits beyond which the records of
+// finished Actions are pruned.
+type ActionPruneArgs struct {
+	MaxAge   time.Duration `json:"max-age"`
+	MaxCount int           `json:"max-count"`
 }
 
 // ActionsByReceivers wrap a slice of Actions for API calls.
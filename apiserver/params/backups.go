@@ -12,6 +12,14 @@ import (
 // BackupsCreateArgs holds the args for the API Create method.
 type BackupsCreateArgs struct {
 	Notes string
+
+	// Incremental requests a backup holding only the changes made
+	// since the most recent backup.
+	Incremental bool
+
+	// Key, if set, is the key or passphrase with which the backup
+	// archive is encrypted.
+	Key string
 }
 
 // BackupsInfoArgs holds the args for the API Info method.
@@ -34,6 +42,23 @@ type BackupsUploadArgs struct {
 	Metadata BackupsMetadataResult
 }
 
+// BackupsVerifyArgs holds the args for the API Verify method.
+type BackupsVerifyArgs struct {
+	ID string
+
+	// Key is the key or passphrase with which the backup archive was
+	// encrypted, if it was.
+	Key string
+}
+
+// BackupsVerifyResult holds the result of an API Verify call.
+type BackupsVerifyResult struct {
+	Encrypted bool
+	Signed    bool
+	Files     int
+	Documents int
+}
+
 // BackupsRemoveArgs holds the args for the API Remove method.
 type BackupsRemoveArgs struct {
 	ID string
@@ -66,10 +91,25 @@ type BackupsMetadataResult struct {
 	Machine  string
 	Hostname string
 	Version  version.Number
+
+	// Parent holds the ID of the backup an incremental backup builds
+	// on; it is empty for full backups.
+	Parent     string
+	OplogStart int64
+	OplogEnd   int64
+	Scheduled  bool
+	Encrypted  bool
 }
 
 // RestoreArgs Holds the backup file or id
 type RestoreArgs struct {
 	// BackupId holds the id of the backup in server if any
 	BackupId string
+	// Key is the key or passphrase with which the backup was
+	// encrypted, if it was.
+	Key string
+	// ChainKeys holds the keys or passphrases of the earlier backups
+	// in the chain of an incremental backup, by backup ID. Those not
+	// given are assumed to have been encrypted with Key.
+	ChainKeys map[string]string
 }
@@ -3,6 +3,8 @@
 
 package params
 
+import "time"
+
 // Block describes a Juju block that protects model from
 // corruption.
 type Block struct {
@@ -19,6 +21,31 @@ type Block struct {
 	// Message is a descriptive or an explanatory message
 	// that the block was created with.
 	Message string `json:"message,omitempty"`
+
+	// Expires, if set, is when the block is lifted.
+	Expires *time.Time `json:"expires,omitempty"`
+
+	// Schedule, if set, holds the recurring window during which
+	// the block applies.
+	Schedule *BlockSchedule `json:"schedule,omitempty"`
+
+	// ExemptUsers holds the names of the users the block does not
+	// apply to.
+	ExemptUsers []string `json:"exempt-users,omitempty"`
+}
+
+// BlockSchedule describes a recurring window during which a block
+// applies.
+type BlockSchedule struct {
+	// Start is a cron-style schedule of when each window opens.
+	Start string `json:"start"`
+
+	// Duration is how long each window stays open.
+	Duration time.Duration `json:"duration"`
+
+	// Location is the time zone Start is interpreted in. If it is
+	// empty, UTC is used.
+	Location string `json:"location,omitempty"`
 }
 
 // BlockSwitchParams holds the parameters for switching
@@ -31,6 +58,17 @@ type BlockSwitchParams struct {
 	// Message is a descriptive or an explanatory message
 	// that accompanies the switch.
 	Message string `json:"message,omitempty"`
+
+	// Expires, if set, is when a block being switched on is lifted.
+	Expires *time.Time `json:"expires,omitempty"`
+
+	// Schedule, if set, restricts a block being switched on to a
+	// recurring window.
+	Schedule *BlockSchedule `json:"schedule,omitempty"`
+
+	// ExemptUsers holds the names of the users a block being
+	// switched on does not apply to.
+	ExemptUsers []string `json:"exempt-users,omitempty"`
 }
 
 // BlockResult holds the result of an API call to retrieve details
@@ -140,6 +140,19 @@ type ResolvedModeResults struct {
 	Results []ResolvedModeResult
 }
 
+// HookTimeoutResult holds the duration after which a unit's hooks
+// are killed, or an error. A zero Timeout means hooks never time out.
+type HookTimeoutResult struct {
+	Error   *Error
+	Timeout time.Duration
+}
+
+// HookTimeoutResults holds the bulk operation result of an API call
+// that returns hook timeouts.
+type HookTimeoutResults struct {
+	Results []HookTimeoutResult
+}
+
 // StringBoolResult holds the result of an API call that returns a
 // string and a boolean.
 type StringBoolResult struct {
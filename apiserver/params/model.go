@@ -39,12 +39,18 @@ type ModelAction string
 const (
 	AddModelUser    ModelAction = "add"
 	RemoveModelUser ModelAction = "remove"
+	GrantModelRole  ModelAction = "grant"
+	RevokeModelRole ModelAction = "revoke"
 )
 
 // ModifyModelUser stores the parameters used for a Client.ShareModel call.
+// Role and Service are only used by the grant and revoke actions; a
+// role granted with a service applies only to that service.
 type ModifyModelUser struct {
 	UserTag string      `json:"user-tag"`
 	Action  ModelAction `json:"action"`
+	Role    string      `json:"role,omitempty"`
+	Service string      `json:"service,omitempty"`
 }
 
 // SetModelAgentVersion contains the arguments for
@@ -60,6 +66,10 @@ type ModelUserInfo struct {
 	CreatedBy      string     `json:"createdby"`
 	DateCreated    time.Time  `json:"datecreated"`
 	LastConnection *time.Time `json:"lastconnection"`
+
+	// Roles holds the roles the user has on the model, each as
+	// "role" or "role:service".
+	Roles []string `json:"roles,omitempty"`
 }
 
 // ModelUserInfoResult holds the result of an ModelUserInfo call.
@@ -217,6 +217,9 @@ type ServiceUpdate struct {
 	SettingsStrings map[string]string
 	SettingsYAML    string // Takes precedence over SettingsStrings if both are present.
 	Constraints     *constraints.Value
+	// HookTimeout, if set, overrides the model's hook-timeout for
+	// the service's units; zero reverts to the model's setting.
+	HookTimeout *time.Duration
 }
 
 // ServiceSetCharm sets the charm for a given service.
@@ -620,8 +623,16 @@ type LoginResultV1 struct {
 	// ServerVersion is the string representation of the server version
 	// if the server supports it.
 	ServerVersion string `json:"server-version,omitempty"`
+
+	// Codecs lists the message formats, other than JSON, that the
+	// server accepts on the connection after login.
+	Codecs []string `json:"codecs,omitempty"`
 }
 
+// CodecBSON names the rpc/bsoncodec message format in
+// LoginResultV1.Codecs.
+const CodecBSON = "bson"
+
 // ControllersServersSpec contains arguments for
 // the EnableHA client API call.
 type ControllersSpec struct {
@@ -734,11 +745,12 @@ type RebootActionResult struct {
 // endpoint.  Single character field names are used for serialisation
 // to keep the size down. These messages are going to be sent a lot.
 type LogRecord struct {
-	Time     time.Time   `json:"t"`
-	Module   string      `json:"m"`
-	Location string      `json:"l"`
-	Level    loggo.Level `json:"v"`
-	Message  string      `json:"x"`
+	Time     time.Time         `json:"t"`
+	Module   string            `json:"m"`
+	Location string            `json:"l"`
+	Level    loggo.Level       `json:"v"`
+	Message  string            `json:"x"`
+	Fields   map[string]string `json:"f,omitempty"`
 }
 
 // GetBundleChangesParams holds parameters for making GetBundleChanges calls.
@@ -10,6 +10,7 @@ import (
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/mgo.v2/bson"
 
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/constraints"
@@ -175,6 +176,31 @@ func (s *MarshalSuite) TestDeltaUnmarshalJSON(c *gc.C) {
 	}
 }
 
+func (s *MarshalSuite) TestDeltaBSONRoundTrip(c *gc.C) {
+	type doc struct {
+		Delta multiwatcher.Delta
+	}
+	for i, t := range marshalTestCases {
+		c.Logf("test %d. %s", i, t.about)
+		data, err := bson.Marshal(doc{t.value})
+		c.Assert(err, jc.ErrorIsNil)
+		var unmarshalled doc
+		err = bson.Unmarshal(data, &unmarshalled)
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(unmarshalled.Delta, jc.DeepEquals, t.value)
+	}
+}
+
+func (s *MarshalSuite) TestDeltaSetBSONUnknownEntity(c *gc.C) {
+	data, err := bson.Marshal(bson.M{"delta": []interface{}{"qwan", "change", bson.M{}}})
+	c.Assert(err, jc.ErrorIsNil)
+	var unmarshalled struct {
+		Delta multiwatcher.Delta
+	}
+	err = bson.Unmarshal(data, &unmarshalled)
+	c.Check(err, gc.ErrorMatches, `Unexpected entity name "qwan"`)
+}
+
 func (s *MarshalSuite) TestDeltaMarshalJSONCardinality(c *gc.C) {
 	err := json.Unmarshal([]byte(`[1,2]`), new(multiwatcher.Delta))
 	c.Check(err, gc.ErrorMatches, "Expected 3 elements in top-level of JSON but got 2")
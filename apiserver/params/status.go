@@ -30,6 +30,15 @@ type FullStatus struct {
 	Services         map[string]ServiceStatus
 	Networks         map[string]NetworkStatus
 	Relations        []RelationStatus
+
+	// LogsMB is the approximate size of the logs stored for the
+	// model, in megabytes. It and LogsMaxMB are zero if the log
+	// usage could not be determined.
+	LogsMB float64
+
+	// LogsMaxMB is the size limit on the model's logs, or zero if
+	// there is none.
+	LogsMaxMB int
 }
 
 // MachineStatus holds status info about a machine.
@@ -171,6 +180,41 @@ type UnitStatusHistory struct {
 	Statuses []AgentStatus
 }
 
+// HookHistoryArgs holds the parameters of a hook history query.
+type HookHistoryArgs struct {
+	Unit string
+	Size int
+}
+
+// HookRecord describes a hook run by a unit.
+type HookRecord struct {
+	Hook       string
+	Relation   string
+	RemoteUnit string
+	Started    time.Time
+	Duration   time.Duration
+	ExitCode   int
+	Stderr     string
+}
+
+// HookHistoryResult holds the hooks most recently run by a unit,
+// newest first.
+type HookHistoryResult struct {
+	Records []HookRecord
+}
+
+// UnitHookRecord holds a hook run by the unit with the given tag.
+type UnitHookRecord struct {
+	Tag    string
+	Record HookRecord
+}
+
+// UnitHookRecords holds the arguments for recording hooks run by
+// units.
+type UnitHookRecords struct {
+	Records []UnitHookRecord
+}
+
 const (
 	// DefaultMaxLogsPerEntity is the default value for logs for each entity
 	// that should be kept at any given time.
@@ -3,6 +3,10 @@
 
 package params
 
+import (
+	"time"
+)
+
 // DestroyControllerArgs holds the arguments for destroying a controller.
 type DestroyControllerArgs struct {
 	// DestroyModels specifies whether or not the hosted models
@@ -46,3 +50,33 @@ type ModelStatus struct {
 type ModelStatusResults struct {
 	Results []ModelStatus `json:"models"`
 }
+
+// AuditLogFilter holds the parameters used to select entries from
+// the controller audit log. Empty fields are not used to filter.
+type AuditLogFilter struct {
+	UserTag  string     `json:"user-tag,omitempty"`
+	ModelTag string     `json:"model-tag,omitempty"`
+	Facade   string     `json:"facade,omitempty"`
+	After    *time.Time `json:"after,omitempty"`
+	Before   *time.Time `json:"before,omitempty"`
+	Limit    int        `json:"limit,omitempty"`
+}
+
+// AuditLogEntry holds a single entry from the controller audit log.
+type AuditLogEntry struct {
+	Timestamp time.Time `json:"timestamp"`
+	ModelTag  string    `json:"model-tag"`
+	OriginTag string    `json:"origin-tag"`
+	Facade    string    `json:"facade"`
+	Version   int       `json:"version"`
+	Method    string    `json:"method"`
+	Args      string    `json:"args,omitempty"`
+	Outcome   string    `json:"outcome"`
+	Error     string    `json:"error,omitempty"`
+}
+
+// AuditLogResults holds the entries selected from the controller
+// audit log, most recent first.
+type AuditLogResults struct {
+	Entries []AuditLogEntry `json:"entries"`
+}
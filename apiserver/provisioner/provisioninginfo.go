@@ -386,7 +386,7 @@ func (p *ProvisionerAPI) constructImageConstraint(m *state.Machine) (*imagemetad
 	}
 
 	if mcons.Arch != nil {
-		lookup.Arches = []string{*mcons.Arch}
+		lookup.Arches = mcons.Arches()
 	}
 	if cloud != nil {
 		lookup.CloudSpec = *cloud
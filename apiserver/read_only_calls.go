@@ -11,8 +11,10 @@ import (
 // modify the database. The format of the calls is "<facade>.<method>".
 // At this stage, we are explicitly ignoring the facade version.
 var readOnlyCalls = set.NewStrings(
+	"Action.ActionGroups",
 	"Action.Actions",
 	"Action.FindActionTagsByPrefix",
+	"Action.History",
 	"Action.ListAll",
 	"Action.ListPending",
 	"Action.ListRunning",
@@ -41,12 +43,15 @@ var readOnlyCalls = set.NewStrings(
 	// ResolveCharms, while being technically read only, isn't a useful
 	// command for a read only user to run.
 	// Status is so old it shouldn't be used.
+	"Client.UnitHookHistory",
 	"Client.UnitStatusHistory",
 	"Client.WatchAll",
+	"Controller.AuditLog",
 	// TODO: add controller work.
 	"KeyManager.ListKeys",
 	"Service.GetConstraints",
 	"Service.CharmRelations",
+	"Service.ExportBundle",
 	"Service.Get",
 	"Spaces.ListSpaces",
 	"Storage.ListStorageDetails",
@@ -0,0 +1,244 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/names"
+	"github.com/juju/utils/set"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+// operatorCalls are the API calls, beyond the read only ones, that a
+// user with the operator role may make. The format of the calls is
+// "<facade>.<method>".
+var operatorCalls = set.NewStrings(
+	"Action.Cancel",
+	"Action.Enqueue",
+	"Action.EnqueueService",
+	"Client.Resolved",
+	"Client.RetryProvisioning",
+	"Client.Run",
+	"Client.RunOnAllMachines",
+)
+
+// deployerCalls are the API calls, beyond the read only ones, that a
+// user with the deployer role may make. Destroying services, units
+// and relations is left to admins.
+var deployerCalls = set.NewStrings(
+	"Client.AddCharm",
+	"Client.AddCharmWithAuthorization",
+	"Client.ResolveCharms",
+	"Service.AddRelation",
+	"Service.AddUnits",
+	"Service.Deploy",
+	"Service.Expose",
+	"Service.Set",
+	"Service.SetCharm",
+	"Service.SetConstraints",
+	"Service.SetMetricCredentials",
+	"Service.Unexpose",
+	"Service.Unset",
+	"Service.Update",
+)
+
+// roleCalls maps each role to the calls it allows beyond the read only
+// ones. The admin role allows every call.
+var roleCalls = map[state.ModelUserRole]set.Strings{
+	state.ReadRole:     set.NewStrings(),
+	state.OperatorRole: operatorCalls,
+	state.DeployerRole: deployerCalls,
+}
+
+// roleAllowsCall returns whether the role allows the method on the
+// facade to be called.
+func roleAllowsCall(role state.ModelUserRole, facade, method string) bool {
+	if role == state.AdminRole {
+		return true
+	}
+	return roleCalls[role].Contains(facade + "." + method)
+}
+
+// serviceCallServices maps the calls that a role scoped to services
+// may make to a function that returns the services a call with the
+// given arguments acts on. A call is only allowed if every one of
+// them is in scope; calls not in the map act on the model as a whole
+// and are never allowed by a scoped role.
+var serviceCallServices = map[string]func(arg interface{}) ([]string, error){
+	// Charms are not deployed by being added, so a scoped deployer
+	// may add the ones they need to deploy or upgrade their services.
+	"Client.AddCharm":                  noServices,
+	"Client.AddCharmWithAuthorization": noServices,
+	"Client.ResolveCharms":             noServices,
+
+	"Action.Enqueue":        actionsServices,
+	"Action.EnqueueService": serviceActionsServices,
+	"Client.Resolved":       resolvedServices,
+	"Client.Run":            runServices,
+
+	"Service.AddRelation":          addRelationServices,
+	"Service.AddUnits":             serviceNameServices,
+	"Service.Deploy":               deployServices,
+	"Service.Expose":               serviceNameServices,
+	"Service.Set":                  serviceNameServices,
+	"Service.SetCharm":             serviceNameServices,
+	"Service.SetConstraints":       serviceNameServices,
+	"Service.SetMetricCredentials": metricCredentialsServices,
+	"Service.Unexpose":             serviceNameServices,
+	"Service.Unset":                serviceNameServices,
+	"Service.Update":               serviceNameServices,
+}
+
+// allowedServices returns whether the grants allow the method on the
+// facade to be called on every service, and if not, the services they
+// allow it to be called on.
+func allowedServices(grants []state.RoleGrant, facade, method string) (all bool, services set.Strings) {
+	services = set.NewStrings()
+	for _, grant := range grants {
+		if !roleAllowsCall(grant.Role, facade, method) {
+			continue
+		}
+		if grant.Service == "" {
+			return true, nil
+		}
+		services.Add(grant.Service)
+	}
+	return false, services
+}
+
+func noServices(interface{}) ([]string, error) {
+	return nil, nil
+}
+
+func serviceNameServices(arg interface{}) ([]string, error) {
+	var name string
+	switch arg := arg.(type) {
+	case params.AddServiceUnits:
+		name = arg.ServiceName
+	case params.ServiceExpose:
+		name = arg.ServiceName
+	case params.ServiceSet:
+		name = arg.ServiceName
+	case params.ServiceSetCharm:
+		name = arg.ServiceName
+	case params.SetConstraints:
+		name = arg.ServiceName
+	case params.ServiceUnexpose:
+		name = arg.ServiceName
+	case params.ServiceUnset:
+		name = arg.ServiceName
+	case params.ServiceUpdate:
+		name = arg.ServiceName
+	default:
+		return nil, errors.Errorf("unexpected argument type %T", arg)
+	}
+	return []string{name}, nil
+}
+
+func deployServices(arg interface{}) ([]string, error) {
+	args, ok := arg.(params.ServicesDeploy)
+	if !ok {
+		return nil, errors.Errorf("unexpected argument type %T", arg)
+	}
+	var services []string
+	for _, deploy := range args.Services {
+		services = append(services, deploy.ServiceName)
+	}
+	return services, nil
+}
+
+func metricCredentialsServices(arg interface{}) ([]string, error) {
+	args, ok := arg.(params.ServiceMetricCredentials)
+	if !ok {
+		return nil, errors.Errorf("unexpected argument type %T", arg)
+	}
+	var services []string
+	for _, creds := range args.Creds {
+		services = append(services, creds.ServiceName)
+	}
+	return services, nil
+}
+
+func addRelationServices(arg interface{}) ([]string, error) {
+	args, ok := arg.(params.AddRelation)
+	if !ok {
+		return nil, errors.Errorf("unexpected argument type %T", arg)
+	}
+	var services []string
+	for _, endpoint := range args.Endpoints {
+		services = append(services, strings.SplitN(endpoint, ":", 2)[0])
+	}
+	return services, nil
+}
+
+func actionsServices(arg interface{}) ([]string, error) {
+	args, ok := arg.(params.Actions)
+	if !ok {
+		return nil, errors.Errorf("unexpected argument type %T", arg)
+	}
+	var services []string
+	for _, action := range args.Actions {
+		unitTag, err := names.ParseUnitTag(action.Receiver)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		service, err := names.UnitService(unitTag.Id())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		services = append(services, service)
+	}
+	return services, nil
+}
+
+func serviceActionsServices(arg interface{}) ([]string, error) {
+	args, ok := arg.(params.ServiceActions)
+	if !ok {
+		return nil, errors.Errorf("unexpected argument type %T", arg)
+	}
+	var services []string
+	for _, action := range args.Actions {
+		serviceTag, err := names.ParseServiceTag(action.Service)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		services = append(services, serviceTag.Id())
+	}
+	return services, nil
+}
+
+func resolvedServices(arg interface{}) ([]string, error) {
+	args, ok := arg.(params.Resolved)
+	if !ok {
+		return nil, errors.Errorf("unexpected argument type %T", arg)
+	}
+	service, err := names.UnitService(args.UnitName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return []string{service}, nil
+}
+
+func runServices(arg interface{}) ([]string, error) {
+	args, ok := arg.(params.RunParams)
+	if !ok {
+		return nil, errors.Errorf("unexpected argument type %T", arg)
+	}
+	if len(args.Machines) > 0 {
+		return nil, errors.New("cannot run commands on machines")
+	}
+	services := append([]string(nil), args.Services...)
+	for _, unit := range args.Units {
+		service, err := names.UnitService(unit)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		services = append(services, service)
+	}
+	return services, nil
+}
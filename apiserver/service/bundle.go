@@ -0,0 +1,237 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package service
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6-unstable"
+	goyaml "gopkg.in/yaml.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+// ExportBundle returns a bundle, in YAML format, that describes
+// the services, machines and relations in the model, such that
+// deploying it into an empty model recreates the same topology.
+func (api *APIV5) ExportBundle() (params.StringResult, error) {
+	data, err := exportBundleData(api.state)
+	if err != nil {
+		return params.StringResult{}, errors.Trace(err)
+	}
+	out, err := goyaml.Marshal(data)
+	if err != nil {
+		return params.StringResult{}, errors.Annotate(err, "cannot marshal bundle")
+	}
+	return params.StringResult{Result: string(out)}, nil
+}
+
+// exportBundleData walks the model in the given state and builds
+// the equivalent bundle data. Services, units and relations that
+// are being removed are left out.
+func exportBundleData(st *state.State) (*charm.BundleData, error) {
+	services, err := st.AllServices()
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot get services")
+	}
+	data := &charm.BundleData{
+		Services: make(map[string]*charm.ServiceSpec),
+	}
+	machines := make(map[string]*state.Machine)
+	for _, service := range services {
+		if service.Life() != state.Alive {
+			continue
+		}
+		spec, err := exportService(st, service, machines)
+		if err != nil {
+			return nil, errors.Annotatef(err, "cannot export service %q", service.Name())
+		}
+		data.Services[service.Name()] = spec
+	}
+	if len(machines) > 0 {
+		data.Machines = make(map[string]*charm.MachineSpec)
+	}
+	for id, machine := range machines {
+		spec, err := exportMachine(st, machine)
+		if err != nil {
+			return nil, errors.Annotatef(err, "cannot export machine %s", id)
+		}
+		data.Machines[id] = spec
+	}
+	relations, err := st.AllRelations()
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot get relations")
+	}
+	for _, relation := range relations {
+		if relation.Life() != state.Alive {
+			continue
+		}
+		endpoints := relation.Endpoints()
+		if len(endpoints) != 2 {
+			// Peer relations are implied by the charms.
+			continue
+		}
+		pair := []string{
+			endpoints[0].ServiceName + ":" + endpoints[0].Name,
+			endpoints[1].ServiceName + ":" + endpoints[1].Name,
+		}
+		sort.Strings(pair)
+		data.Relations = append(data.Relations, pair)
+	}
+	sort.Sort(relationsByEndpoint(data.Relations))
+	return data, nil
+}
+
+// exportService returns the bundle description of the given
+// service. The top level machines hosting the service units
+// are added to the given machines map.
+func exportService(st *state.State, service *state.Service, machines map[string]*state.Machine) (*charm.ServiceSpec, error) {
+	curl, _ := service.CharmURL()
+	spec := &charm.ServiceSpec{
+		Charm:  curl.String(),
+		Expose: service.IsExposed(),
+	}
+	options, err := service.ConfigSettings()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(options) > 0 {
+		spec.Options = options
+	}
+	annotations, err := st.Annotations(service)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(annotations) > 0 {
+		spec.Annotations = annotations
+	}
+	bindings, err := service.EndpointBindings()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for endpoint, space := range bindings {
+		if space == "" {
+			continue
+		}
+		if spec.EndpointBindings == nil {
+			spec.EndpointBindings = make(map[string]string)
+		}
+		spec.EndpointBindings[endpoint] = space
+	}
+	if !service.IsPrincipal() {
+		// Subordinate units are created by relations,
+		// so there is nothing else to describe.
+		return spec, nil
+	}
+	cons, err := service.Constraints()
+	if err != nil && !errors.IsNotFound(err) {
+		return nil, errors.Trace(err)
+	}
+	spec.Constraints = cons.String()
+	storageCons, err := service.StorageConstraints()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for name, cons := range storageCons {
+		if spec.Storage == nil {
+			spec.Storage = make(map[string]string)
+		}
+		spec.Storage[name] = fmt.Sprintf("%s,%d,%dM", cons.Pool, cons.Count, cons.Size)
+	}
+	units, err := service.AllUnits()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	sort.Sort(unitsByNumber(units))
+	for _, unit := range units {
+		if unit.Life() != state.Alive {
+			continue
+		}
+		spec.NumUnits++
+		machineId, err := unit.AssignedMachineId()
+		if errors.IsNotAssigned(err) {
+			continue
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		to, err := exportPlacement(st, machineId, machines)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		spec.To = append(spec.To, to)
+	}
+	return spec, nil
+}
+
+// exportPlacement returns the bundle placement directive for a
+// unit assigned to the given machine. Units in containers are
+// placed in a new container of the same type on the host machine.
+func exportPlacement(st *state.State, machineId string, machines map[string]*state.Machine) (string, error) {
+	machine, err := st.Machine(machineId)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	containerType := machine.ContainerType()
+	for {
+		parentId, ok := machine.ParentId()
+		if !ok {
+			break
+		}
+		if machine, err = st.Machine(parentId); err != nil {
+			return "", errors.Trace(err)
+		}
+	}
+	machines[machine.Id()] = machine
+	if containerType != "" {
+		return string(containerType) + ":" + machine.Id(), nil
+	}
+	return machine.Id(), nil
+}
+
+// exportMachine returns the bundle description of the given
+// top level machine.
+func exportMachine(st *state.State, machine *state.Machine) (*charm.MachineSpec, error) {
+	spec := &charm.MachineSpec{
+		Series: machine.Series(),
+	}
+	cons, err := machine.Constraints()
+	if err != nil && !errors.IsNotFound(err) {
+		return nil, errors.Trace(err)
+	}
+	spec.Constraints = cons.String()
+	annotations, err := st.Annotations(machine)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(annotations) > 0 {
+		spec.Annotations = annotations
+	}
+	return spec, nil
+}
+
+type unitsByNumber []*state.Unit
+
+func (u unitsByNumber) Len() int      { return len(u) }
+func (u unitsByNumber) Swap(i, j int) { u[i], u[j] = u[j], u[i] }
+func (u unitsByNumber) Less(i, j int) bool {
+	return unitNumber(u[i].Name()) < unitNumber(u[j].Name())
+}
+
+func unitNumber(name string) int {
+	n, _ := strconv.Atoi(name[strings.Index(name, "/")+1:])
+	return n
+}
+
+type relationsByEndpoint [][]string
+
+func (r relationsByEndpoint) Len() int      { return len(r) }
+func (r relationsByEndpoint) Swap(i, j int) { r[i], r[j] = r[j], r[i] }
+func (r relationsByEndpoint) Less(i, j int) bool {
+	return strings.Join(r[i], " ") < strings.Join(r[j], " ")
+}
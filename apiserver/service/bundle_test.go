@@ -0,0 +1,162 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package service_test
+
+import (
+	"strings"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6-unstable"
+
+	"github.com/juju/juju/apiserver/service"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/instance"
+	jujutesting "github.com/juju/juju/juju/testing"
+	"github.com/juju/juju/state"
+)
+
+type exportBundleSuite struct {
+	jujutesting.JujuConnSuite
+
+	serviceApi *service.APIV5
+}
+
+var _ = gc.Suite(&exportBundleSuite{})
+
+func (s *exportBundleSuite) SetUpTest(c *gc.C) {
+	s.JujuConnSuite.SetUpTest(c)
+	authorizer := apiservertesting.FakeAuthorizer{
+		Tag: s.AdminUserTag(c),
+	}
+	var err error
+	s.serviceApi, err = service.NewAPIV5(s.State, nil, authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *exportBundleSuite) exportBundle(c *gc.C) *charm.BundleData {
+	result, err := s.serviceApi.ExportBundle()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+	data, err := charm.ReadBundleData(strings.NewReader(result.Result))
+	c.Assert(err, jc.ErrorIsNil)
+	return data
+}
+
+func (s *exportBundleSuite) TestExportBundleEmpty(c *gc.C) {
+	c.Assert(s.exportBundle(c), jc.DeepEquals, &charm.BundleData{
+		Services: map[string]*charm.ServiceSpec{},
+	})
+}
+
+func (s *exportBundleSuite) TestExportBundle(c *gc.C) {
+	wordpress := s.AddTestingService(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	err := wordpress.UpdateConfigSettings(charm.Settings{"blog-title": "exported"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = wordpress.SetConstraints(constraints.MustParse("mem=4G"))
+	c.Assert(err, jc.ErrorIsNil)
+	err = wordpress.SetExposed()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetAnnotations(wordpress, map[string]string{"gui-x": "10"})
+	c.Assert(err, jc.ErrorIsNil)
+	mysql := s.AddTestingService(c, "mysql", s.AddTestingCharm(c, "mysql"))
+
+	host, err := s.State.AddOneMachine(state.MachineTemplate{
+		Series:      "quantal",
+		Jobs:        []state.MachineJob{state.JobHostUnits},
+		Constraints: constraints.MustParse("mem=8G"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetAnnotations(host, map[string]string{"rack": "a"})
+	c.Assert(err, jc.ErrorIsNil)
+	container, err := s.State.AddMachineInsideMachine(state.MachineTemplate{
+		Series: "quantal",
+		Jobs:   []state.MachineJob{state.JobHostUnits},
+	}, host.Id(), instance.LXC)
+	c.Assert(err, jc.ErrorIsNil)
+
+	unit, err := wordpress.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.AssignToMachine(host)
+	c.Assert(err, jc.ErrorIsNil)
+	unit, err = mysql.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.AssignToMachine(container)
+	c.Assert(err, jc.ErrorIsNil)
+	// Unassigned units are counted without placement.
+	_, err = mysql.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+
+	eps, err := s.State.InferEndpoints("wordpress", "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddRelation(eps...)
+	c.Assert(err, jc.ErrorIsNil)
+
+	wordpressURL, _ := wordpress.CharmURL()
+	mysqlURL, _ := mysql.CharmURL()
+	c.Assert(s.exportBundle(c), jc.DeepEquals, &charm.BundleData{
+		Services: map[string]*charm.ServiceSpec{
+			"wordpress": {
+				Charm:       wordpressURL.String(),
+				NumUnits:    1,
+				To:          []string{host.Id()},
+				Expose:      true,
+				Options:     map[string]interface{}{"blog-title": "exported"},
+				Annotations: map[string]string{"gui-x": "10"},
+				Constraints: "mem=4096M",
+			},
+			"mysql": {
+				Charm:    mysqlURL.String(),
+				NumUnits: 2,
+				To:       []string{"lxc:" + host.Id()},
+			},
+		},
+		Machines: map[string]*charm.MachineSpec{
+			host.Id(): {
+				Series:      "quantal",
+				Constraints: "mem=8192M",
+				Annotations: map[string]string{"rack": "a"},
+			},
+		},
+		Relations: [][]string{{"mysql:server", "wordpress:db"}},
+	})
+}
+
+func (s *exportBundleSuite) TestExportBundleSkipsDying(c *gc.C) {
+	wordpress := s.AddTestingService(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	mysql := s.AddTestingService(c, "mysql", s.AddTestingCharm(c, "mysql"))
+	eps, err := s.State.InferEndpoints("wordpress", "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	rel, err := s.State.AddRelation(eps...)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = wordpress.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	dying, err := wordpress.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	// Units in relation scope are not removed straight away.
+	ru, err := rel.Unit(dying)
+	c.Assert(err, jc.ErrorIsNil)
+	err = ru.EnterScope(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = dying.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	err = rel.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = mysql.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	err = mysql.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+
+	wordpressURL, _ := wordpress.CharmURL()
+	c.Assert(s.exportBundle(c), jc.DeepEquals, &charm.BundleData{
+		Services: map[string]*charm.ServiceSpec{
+			"wordpress": {
+				Charm:    wordpressURL.String(),
+				NumUnits: 1,
+			},
+		},
+	})
+}
@@ -29,6 +29,11 @@ var (
 
 func init() {
 	common.RegisterStandardFacade("Service", 3, NewAPI)
+	// Version 4 is otherwise the same as version 3, but tells clients
+	// that constraint maximums, alternatives and preferences are
+	// understood.
+	common.RegisterStandardFacade("Service", 4, NewAPI)
+	common.RegisterStandardFacade("Service", 5, NewAPIV5)
 }
 
 // Service defines the methods on the service API end point.
@@ -57,10 +62,29 @@ func NewAPI(
 	return &API{
 		state:      st,
 		authorizer: authorizer,
-		check:      common.NewBlockChecker(st),
+		check:      common.NewUserBlockChecker(st, authorizer.GetAuthTag()),
 	}, nil
 }
 
+// APIV5 implements version 5 of the Service API, which adds
+// exporting the model as a bundle.
+type APIV5 struct {
+	*API
+}
+
+// NewAPIV5 returns a new service API facade, version 5.
+func NewAPIV5(
+	st *state.State,
+	resources *common.Resources,
+	authorizer common.Authorizer,
+) (*APIV5, error) {
+	api, err := NewAPI(st, resources, authorizer)
+	if err != nil {
+		return nil, err
+	}
+	return &APIV5{api}, nil
+}
+
 // SetMetricCredentials sets credentials on the service.
 func (api *API) SetMetricCredentials(args params.ServiceMetricCredentials) (params.ErrorResults, error) {
 	result := params.ErrorResults{
@@ -270,6 +294,12 @@ func (api *API) Update(args params.ServiceUpdate) error {
 			return errors.Trace(err)
 		}
 	}
+	// Update the timeout of the service's hooks.
+	if args.HookTimeout != nil {
+		if err = svc.SetHookTimeout(*args.HookTimeout); err != nil {
+			return errors.Trace(err)
+		}
+	}
 	// Update service's constraints.
 	if args.Constraints != nil {
 		return svc.SetConstraints(*args.Constraints)
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io"
 	"sync"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/names"
@@ -1242,6 +1243,24 @@ func (s *serviceSuite) TestServiceUpdateSetMinUnits(c *gc.C) {
 	c.Assert(service.MinUnits(), gc.Equals, minUnits)
 }
 
+func (s *serviceSuite) TestServiceUpdateSetHookTimeout(c *gc.C) {
+	service := s.AddTestingService(c, "dummy", s.AddTestingCharm(c, "dummy"))
+
+	timeout := 10 * time.Minute
+	args := params.ServiceUpdate{
+		ServiceName: "dummy",
+		HookTimeout: &timeout,
+	}
+	err := s.serviceApi.Update(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(service.Refresh(), gc.IsNil)
+	c.Assert(service.HookTimeout(), gc.Equals, timeout)
+
+	timeout = -time.Minute
+	err = s.serviceApi.Update(args)
+	c.Assert(err, gc.ErrorMatches, "negative hook timeout -1m0s not valid")
+}
+
 func (s *serviceSuite) TestServiceUpdateSetMinUnitsError(c *gc.C) {
 	service := s.AddTestingService(c, "dummy", s.AddTestingCharm(c, "dummy"))
 
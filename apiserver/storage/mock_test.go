@@ -4,6 +4,8 @@
 package storage_test
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	"github.com/juju/names"
 	"gopkg.in/juju/charm.v6-unstable"
@@ -347,3 +349,15 @@ func (b mockBlock) Type() state.BlockType {
 func (b mockBlock) Message() string {
 	return b.msg
 }
+
+func (b mockBlock) Expires() *time.Time {
+	return nil
+}
+
+func (b mockBlock) Schedule() *state.BlockSchedule {
+	return nil
+}
+
+func (b mockBlock) ExemptUsers() []string {
+	return nil
+}
@@ -658,7 +658,7 @@ func createFilesystemDetails(
 // A "CHANGE" block can block this operation.
 func (a *API) AddToUnit(args params.StoragesAddParams) (params.ErrorResults, error) {
 	// Check if changes are allowed and the operation may proceed.
-	blockChecker := common.NewBlockChecker(a.storage)
+	blockChecker := common.NewUserBlockChecker(a.storage, a.authorizer.GetAuthTag())
 	if err := blockChecker.ChangeAllowed(); err != nil {
 		return params.ErrorResults{}, errors.Trace(err)
 	}
@@ -14,6 +14,7 @@ import (
 	"strings"
 
 	"github.com/juju/errors"
+	"github.com/juju/names"
 	"github.com/juju/utils"
 
 	"github.com/juju/juju/apiserver/common"
@@ -60,7 +61,7 @@ func (h *toolsDownloadHandler) ServeHTTP(w http.ResponseWriter, r *http.Request)
 func (h *toolsUploadHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	// Validate before authenticate because the authentication is dependent
 	// on the state connection that is determined during the validation.
-	st, _, err := h.ctxt.stateForRequestAuthenticatedUser(r)
+	st, entity, err := h.ctxt.stateForRequestAuthenticatedUser(r)
 	if err != nil {
 		sendError(w, err)
 		return
@@ -69,7 +70,7 @@ func (h *toolsUploadHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	switch r.Method {
 	case "POST":
 		// Add tools to storage.
-		agentTools, err := h.processPost(r, st)
+		agentTools, err := h.processPost(r, st, entity.Tag())
 		if err != nil {
 			sendError(w, err)
 			return
@@ -179,7 +180,7 @@ func (h *toolsDownloadHandler) sendTools(w http.ResponseWriter, statusCode int,
 }
 
 // processPost handles a tools upload POST request after authentication.
-func (h *toolsUploadHandler) processPost(r *http.Request, st *state.State) (*tools.Tools, error) {
+func (h *toolsUploadHandler) processPost(r *http.Request, st *state.State, user names.Tag) (*tools.Tools, error) {
 	query := r.URL.Query()
 
 	binaryVersionParam := query.Get("binaryVersion")
@@ -219,7 +220,7 @@ func (h *toolsUploadHandler) processPost(r *http.Request, st *state.State) (*too
 			toolsVersions = append(toolsVersions, v)
 		}
 	}
-	return h.handleUpload(r.Body, toolsVersions, serverRoot, st)
+	return h.handleUpload(r.Body, toolsVersions, serverRoot, st, user)
 }
 
 func (h *toolsUploadHandler) getServerRoot(r *http.Request, query url.Values, st *state.State) (string, error) {
@@ -235,9 +236,9 @@ func (h *toolsUploadHandler) getServerRoot(r *http.Request, query url.Values, st
 }
 
 // handleUpload uploads the tools data from the reader to env storage as the specified version.
-func (h *toolsUploadHandler) handleUpload(r io.Reader, toolsVersions []version.Binary, serverRoot string, st *state.State) (*tools.Tools, error) {
+func (h *toolsUploadHandler) handleUpload(r io.Reader, toolsVersions []version.Binary, serverRoot string, st *state.State, user names.Tag) (*tools.Tools, error) {
 	// Check if changes are allowed and the command may proceed.
-	blockChecker := common.NewBlockChecker(st)
+	blockChecker := common.NewUserBlockChecker(st, user)
 	if err := blockChecker.ChangeAllowed(); err != nil {
 		return nil, errors.Trace(err)
 	}
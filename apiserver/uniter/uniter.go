@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"net/url"
 	"path"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
@@ -30,6 +31,13 @@ var logger = loggo.GetLogger("juju.apiserver.uniter")
 
 func init() {
 	common.RegisterStandardFacade("Uniter", 3, NewUniterAPIV3)
+	common.RegisterStandardFacade("Uniter", 4, NewUniterAPIV4)
+}
+
+// UniterAPIV4 implements the API version 4, used by the uniter worker.
+// It adds HookTimeouts, ActionsCancelRequested and AddHookRecords.
+type UniterAPIV4 struct {
+	UniterAPIV3
 }
 
 // UniterAPIV3 implements the API version 3, used by the uniter worker.
@@ -141,6 +149,17 @@ func NewUniterAPIV3(st *state.State, resources *common.Resources, authorizer com
 	}, nil
 }
 
+// NewUniterAPIV4 creates a new instance of the Uniter API, version 4.
+func NewUniterAPIV4(st *state.State, resources *common.Resources, authorizer common.Authorizer) (*UniterAPIV4, error) {
+	baseAPI, err := NewUniterAPIV3(st, resources, authorizer)
+	if err != nil {
+		return nil, err
+	}
+	return &UniterAPIV4{
+		UniterAPIV3: *baseAPI,
+	}, nil
+}
+
 // AllMachinePorts returns all opened port ranges for each given
 // machine (on all networks).
 func (u *UniterAPIV3) AllMachinePorts(args params.Entities) (params.MachinePortsResults, error) {
@@ -409,6 +428,88 @@ func (u *UniterAPIV3) Resolved(args params.Entities) (params.ResolvedModeResults
 	return result, nil
 }
 
+// HookTimeouts returns, for each given unit, the duration after which
+// a running hook is killed. The service's own timeout takes precedence
+// over the model's hook-timeout setting; zero means no timeout.
+func (u *UniterAPIV4) HookTimeouts(args params.Entities) (params.HookTimeoutResults, error) {
+	result := params.HookTimeoutResults{
+		Results: make([]params.HookTimeoutResult, len(args.Entities)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.HookTimeoutResults{}, err
+	}
+	for i, entity := range args.Entities {
+		tag, err := names.ParseUnitTag(entity.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		err = common.ErrPerm
+		if canAccess(tag) {
+			result.Results[i].Timeout, err = u.hookTimeout(tag)
+		}
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+// AddHookRecords records hooks run by each given unit.
+func (u *UniterAPIV4) AddHookRecords(args params.UnitHookRecords) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Records)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+	for i, arg := range args.Records {
+		tag, err := names.ParseUnitTag(arg.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		err = common.ErrPerm
+		if canAccess(tag) {
+			var unit *state.Unit
+			unit, err = u.getUnit(tag)
+			if err == nil {
+				err = unit.AddHookRecord(state.HookRecord{
+					Hook:       arg.Record.Hook,
+					Relation:   arg.Record.Relation,
+					RemoteUnit: arg.Record.RemoteUnit,
+					Started:    arg.Record.Started,
+					Duration:   arg.Record.Duration,
+					ExitCode:   arg.Record.ExitCode,
+					Stderr:     arg.Record.Stderr,
+				})
+			}
+		}
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+func (u *UniterAPIV4) hookTimeout(tag names.UnitTag) (time.Duration, error) {
+	unit, err := u.getUnit(tag)
+	if err != nil {
+		return 0, err
+	}
+	service, err := unit.Service()
+	if err != nil {
+		return 0, err
+	}
+	if timeout := service.HookTimeout(); timeout > 0 {
+		return timeout, nil
+	}
+	config, err := u.st.ModelConfig()
+	if err != nil {
+		return 0, err
+	}
+	timeout, _ := config.HookTimeout()
+	return timeout, nil
+}
+
 // ClearResolved removes any resolved setting from each given unit.
 func (u *UniterAPIV3) ClearResolved(args params.Entities) (params.ErrorResults, error) {
 	result := params.ErrorResults{
@@ -909,6 +1010,7 @@ func (u *UniterAPIV3) Actions(args params.Entities) (params.ActionsQueryResults,
 		results.Results[i].Action.Action = &params.Action{
 			Name:       action.Name(),
 			Parameters: action.Parameters(),
+			Timeout:    action.Timeout(),
 		}
 	}
 
@@ -943,6 +1045,27 @@ func (u *UniterAPIV3) BeginActions(args params.Entities) (params.ErrorResults, e
 	return results, nil
 }
 
+// ActionsCancelRequested reports, for each of the given Actions,
+// whether the running Action has been asked to stop.
+func (u *UniterAPIV4) ActionsCancelRequested(args params.Entities) (params.BoolResults, error) {
+	actionFn, err := u.authAndActionFromTagFn()
+	if err != nil {
+		return params.BoolResults{}, err
+	}
+	results := params.BoolResults{
+		Results: make([]params.BoolResult, len(args.Entities)),
+	}
+	for i, arg := range args.Entities {
+		action, err := actionFn(arg.Tag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].Result = action.CancelRequested()
+	}
+	return results, nil
+}
+
 // FinishActions saves the result of a completed Action
 func (u *UniterAPIV3) FinishActions(args params.ActionExecutionResults) (params.ErrorResults, error) {
 	nothing := params.ErrorResults{}
@@ -987,6 +1110,8 @@ func paramsActionExecutionResultsToStateActionResults(arg params.ActionExecution
 		status = state.ActionCompleted
 	case params.ActionFailed:
 		status = state.ActionFailed
+	case params.ActionTimedOut:
+		status = state.ActionTimedOut
 	case params.ActionPending:
 		status = state.ActionPending
 	default:
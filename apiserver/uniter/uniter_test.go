@@ -37,7 +37,7 @@ type uniterSuite struct {
 
 	authorizer apiservertesting.FakeAuthorizer
 	resources  *common.Resources
-	uniter     *uniter.UniterAPIV3
+	uniter     *uniter.UniterAPIV4
 
 	machine0      *state.Machine
 	machine1      *state.Machine
@@ -116,13 +116,13 @@ func (s *uniterSuite) SetUpTest(c *gc.C) {
 	s.resources = common.NewResources()
 	s.AddCleanup(func(_ *gc.C) { s.resources.StopAll() })
 
-	uniterAPIV3, err := uniter.NewUniterAPIV3(
+	uniterAPIV4, err := uniter.NewUniterAPIV4(
 		s.State,
 		s.resources,
 		s.authorizer,
 	)
 	c.Assert(err, jc.ErrorIsNil)
-	s.uniter = uniterAPIV3
+	s.uniter = uniterAPIV4
 }
 
 func (s *uniterSuite) TestUniterFailsWithNonUnitAgentUser(c *gc.C) {
@@ -512,6 +512,72 @@ func (s *uniterSuite) TestResolved(c *gc.C) {
 	})
 }
 
+func (s *uniterSuite) TestHookTimeouts(c *gc.C) {
+	args := params.Entities{Entities: []params.Entity{
+		{Tag: "unit-mysql-0"},
+		{Tag: "unit-wordpress-0"},
+		{Tag: "unit-foo-42"},
+	}}
+	assertTimeout := func(expected time.Duration) {
+		result, err := s.uniter.HookTimeouts(args)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(result, gc.DeepEquals, params.HookTimeoutResults{
+			Results: []params.HookTimeoutResult{
+				{Error: apiservertesting.ErrUnauthorized},
+				{Timeout: expected},
+				{Error: apiservertesting.ErrUnauthorized},
+			},
+		})
+	}
+	assertTimeout(0)
+
+	err := s.State.UpdateModelConfig(map[string]interface{}{"hook-timeout": "30m"}, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	assertTimeout(30 * time.Minute)
+
+	err = s.wordpress.SetHookTimeout(5 * time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+	assertTimeout(5 * time.Minute)
+}
+
+func (s *uniterSuite) TestAddHookRecords(c *gc.C) {
+	started := time.Date(2016, 4, 1, 12, 0, 0, 0, time.UTC)
+	record := params.HookRecord{
+		Hook:     "config-changed",
+		Started:  started,
+		Duration: time.Second,
+		ExitCode: 1,
+		Stderr:   "oops",
+	}
+	args := params.UnitHookRecords{Records: []params.UnitHookRecord{
+		{Tag: "unit-mysql-0", Record: record},
+		{Tag: "unit-wordpress-0", Record: record},
+		{Tag: "unit-foo-42", Record: record},
+	}}
+	result, err := s.uniter.AddHookRecords(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{apiservertesting.ErrUnauthorized},
+			{nil},
+			{apiservertesting.ErrUnauthorized},
+		},
+	})
+
+	history, err := s.wordpressUnit.HookHistory(10)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, jc.DeepEquals, []state.HookRecord{{
+		Hook:     "config-changed",
+		Started:  started,
+		Duration: time.Second,
+		ExitCode: 1,
+		Stderr:   "oops",
+	}})
+	history, err = s.mysqlUnit.HookHistory(10)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 0)
+}
+
 func (s *uniterSuite) TestClearResolved(c *gc.C) {
 	err := s.wordpressUnit.SetResolved(state.ResolvedRetryHooks)
 	c.Assert(err, jc.ErrorIsNil)
@@ -1300,6 +1366,33 @@ func (s *uniterSuite) TestBeginActions(c *gc.C) {
 	c.Assert(started.After(enqueued) || started.Equal(enqueued), jc.IsTrue, gc.Commentf("started should be after or equal to enqueued time"))
 }
 
+func (s *uniterSuite) TestActionsCancelRequested(c *gc.C) {
+	running, err := s.wordpressUnit.AddAction("fakeaction", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	running, err = running.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = running.Cancel()
+	c.Assert(err, jc.ErrorIsNil)
+	pending, err := s.wordpressUnit.AddAction("fakeaction", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	other, err := s.mysqlUnit.AddAction("fakeaction", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.Entities{Entities: []params.Entity{
+		{Tag: running.ActionTag().String()},
+		{Tag: pending.ActionTag().String()},
+		{Tag: other.ActionTag().String()},
+		{Tag: "action-foo"},
+	}}
+	results, err := s.uniter.ActionsCancelRequested(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 4)
+	c.Assert(results.Results[0], jc.DeepEquals, params.BoolResult{Result: true})
+	c.Assert(results.Results[1], jc.DeepEquals, params.BoolResult{Result: false})
+	c.Assert(results.Results[2].Error, jc.Satisfies, params.IsCodeUnauthorized)
+	c.Assert(results.Results[3].Error, gc.NotNil)
+}
+
 func (s *uniterSuite) TestRelation(c *gc.C) {
 	rel := s.addRelation(c, "wordpress", "mysql")
 	wpEp, err := rel.Endpoint("wordpress")
@@ -2437,7 +2530,7 @@ func (s *uniterNetworkConfigSuite) setupUniterAPIForUnit(c *gc.C, givenUnit *sta
 	}
 
 	var err error
-	s.base.uniter, err = uniter.NewUniterAPIV3(
+	s.base.uniter, err = uniter.NewUniterAPIV4(
 		s.base.State,
 		s.base.resources,
 		s.base.authorizer,
@@ -41,7 +41,7 @@ func NewUserManagerAPI(
 	return &UserManagerAPI{
 		state:      st,
 		authorizer: authorizer,
-		check:      common.NewBlockChecker(st),
+		check:      common.NewUserBlockChecker(st, authorizer.GetAuthTag()),
 	}, nil
 }
 
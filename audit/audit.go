@@ -6,7 +6,9 @@ package audit
 
 import (
 	"fmt"
+	"time"
 
+	"github.com/juju/errors"
 	"github.com/juju/loggo"
 )
 
@@ -33,3 +35,71 @@ func Audit(user Tagger, format string, args ...interface{}) {
 	// which incorrectly flags the Logf call.
 	logger.LogCallf(1, loggo.INFO, fmt.Sprintf("%s: %s", user.Tag(), format), args...)
 }
+
+// Outcome values recorded against an AuditEntry.
+const (
+	OutcomeSucceeded = "succeeded"
+	OutcomeFailed    = "failed"
+)
+
+// AuditEntry is a structured record of a single auditable event
+// performed through the API.
+type AuditEntry struct {
+	// Timestamp is when the event occurred.
+	Timestamp time.Time `json:"timestamp"`
+
+	// ModelUUID is the UUID of the model against which the event
+	// was performed.
+	ModelUUID string `json:"model-uuid"`
+
+	// OriginTag is the tag of the entity that performed the event.
+	OriginTag string `json:"origin-tag"`
+
+	// Facade, Version and Method identify the API call that was
+	// made.
+	Facade  string `json:"facade"`
+	Version int    `json:"version"`
+	Method  string `json:"method"`
+
+	// Args is a summary of the arguments passed to the API call.
+	Args string `json:"args,omitempty"`
+
+	// Outcome is either OutcomeSucceeded or OutcomeFailed.
+	Outcome string `json:"outcome"`
+
+	// Error holds the error message for a failed call.
+	Error string `json:"error,omitempty"`
+}
+
+// Validate ensures that the entry holds enough information to be
+// usefully recorded.
+func (e AuditEntry) Validate() error {
+	if e.Timestamp.IsZero() {
+		return errors.NotValidf("missing Timestamp")
+	}
+	if e.OriginTag == "" {
+		return errors.NotValidf("missing OriginTag")
+	}
+	if e.Facade == "" {
+		return errors.NotValidf("missing Facade")
+	}
+	if e.Method == "" {
+		return errors.NotValidf("missing Method")
+	}
+	switch e.Outcome {
+	case OutcomeSucceeded, OutcomeFailed:
+	default:
+		return errors.NotValidf("Outcome %q", e.Outcome)
+	}
+	return nil
+}
+
+// String returns a single line description of the entry, suitable
+// for writing to a log.
+func (e AuditEntry) String() string {
+	s := fmt.Sprintf("%s: %s(%d).%s %s in model %s", e.OriginTag, e.Facade, e.Version, e.Method, e.Outcome, e.ModelUUID)
+	if e.Error != "" {
+		s += ": " + e.Error
+	}
+	return s
+}
@@ -5,7 +5,9 @@ package audit
 
 import (
 	"testing"
+	"time"
 
+	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
@@ -56,3 +58,58 @@ func (*auditSuite) TestAuditWithEmptyTagWillPanic(c *gc.C) {
 	f := func() { Audit(&mockUser{}, "should never be written") }
 	c.Assert(f, gc.PanicMatches, "user tag cannot be blank")
 }
+
+func validEntry() AuditEntry {
+	return AuditEntry{
+		Timestamp: time.Date(2016, 4, 1, 12, 0, 0, 0, time.UTC),
+		ModelUUID: "deadbeef-0bad-400d-8000-4b1d0d06f00d",
+		OriginTag: "user-agnus",
+		Facade:    "Service",
+		Version:   1,
+		Method:    "Deploy",
+		Outcome:   OutcomeSucceeded,
+	}
+}
+
+func (*auditSuite) TestAuditEntryValidate(c *gc.C) {
+	c.Assert(validEntry().Validate(), jc.ErrorIsNil)
+}
+
+func (*auditSuite) TestAuditEntryValidateErrors(c *gc.C) {
+	for i, test := range []struct {
+		mutate func(*AuditEntry)
+		err    string
+	}{{
+		mutate: func(e *AuditEntry) { e.Timestamp = time.Time{} },
+		err:    "missing Timestamp not valid",
+	}, {
+		mutate: func(e *AuditEntry) { e.OriginTag = "" },
+		err:    "missing OriginTag not valid",
+	}, {
+		mutate: func(e *AuditEntry) { e.Facade = "" },
+		err:    "missing Facade not valid",
+	}, {
+		mutate: func(e *AuditEntry) { e.Method = "" },
+		err:    "missing Method not valid",
+	}, {
+		mutate: func(e *AuditEntry) { e.Outcome = "maybe" },
+		err:    `Outcome "maybe" not valid`,
+	}} {
+		c.Logf("test %d: %s", i, test.err)
+		entry := validEntry()
+		test.mutate(&entry)
+		err := entry.Validate()
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (*auditSuite) TestAuditEntryString(c *gc.C) {
+	entry := validEntry()
+	c.Check(entry.String(), gc.Equals,
+		"user-agnus: Service(1).Deploy succeeded in model deadbeef-0bad-400d-8000-4b1d0d06f00d")
+	entry.Outcome = OutcomeFailed
+	entry.Error = "permission denied"
+	c.Check(entry.String(), gc.Equals,
+		"user-agnus: Service(1).Deploy failed in model deadbeef-0bad-400d-8000-4b1d0d06f00d: permission denied")
+}
@@ -0,0 +1,55 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package audit
+
+import (
+	"encoding/json"
+	"sync"
+
+	"github.com/juju/errors"
+	"gopkg.in/natefinch/lumberjack.v2"
+)
+
+// FileSink writes audit entries to a file as JSON, one entry per line.
+// The file is rotated when it grows too large.
+type FileSink struct {
+	mu     sync.Mutex
+	logger *lumberjack.Logger
+}
+
+// NewFileSink returns a FileSink that writes to the file at the given
+// path, rotating it when it reaches maxSizeMB megabytes and keeping
+// at most maxBackups rotated files.
+func NewFileSink(path string, maxSizeMB, maxBackups int) *FileSink {
+	return &FileSink{
+		logger: &lumberjack.Logger{
+			Filename:   path,
+			MaxSize:    maxSizeMB,
+			MaxBackups: maxBackups,
+		},
+	}
+}
+
+// Put is part of the Sink interface.
+func (s *FileSink) Put(entry AuditEntry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	data = append(data, '\n')
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, err := s.logger.Write(data); err != nil {
+		return errors.Annotate(err, "cannot write audit entry")
+	}
+	return nil
+}
+
+// Close is part of the Sink interface.
+func (s *FileSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return errors.Trace(s.logger.Close())
+}
@@ -0,0 +1,183 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package audit
+
+import (
+	"net/url"
+	"sync"
+
+	"github.com/juju/errors"
+)
+
+// Sink is implemented by destinations for audit entries.
+type Sink interface {
+	// Put records the given entry. Implementations must be safe
+	// for concurrent use, and should not block for long: sinks
+	// that talk to remote services are expected to buffer.
+	Put(entry AuditEntry) error
+
+	// Close releases any resources held by the sink. Entries must
+	// not be Put after Close has been called.
+	Close() error
+}
+
+// Fanout is a Sink which passes each entry on to a set of other
+// sinks. The set of sinks can be replaced at any time, which allows
+// the audit configuration to change without restarting the API
+// server.
+type Fanout struct {
+	mu      sync.Mutex
+	current *sinkSet
+}
+
+// sinkSet holds the sinks a Fanout passes entries on to, and counts
+// the Puts still writing to them so that they are not closed too
+// early.
+type sinkSet struct {
+	sinks    []Sink
+	inflight sync.WaitGroup
+}
+
+// NewFanout returns a new Fanout which passes entries on to the
+// given sinks.
+func NewFanout(sinks ...Sink) *Fanout {
+	return &Fanout{current: &sinkSet{sinks: sinks}}
+}
+
+// SetSinks replaces the sinks that entries are passed on to. The
+// sinks being replaced are closed once the entries already being
+// written to them have been.
+func (f *Fanout) SetSinks(sinks ...Sink) {
+	f.mu.Lock()
+	old := f.current
+	f.current = &sinkSet{sinks: sinks}
+	f.mu.Unlock()
+	old.inflight.Wait()
+	closeSinks(old.sinks)
+}
+
+// Put is part of the Sink interface. The entry is passed on to every
+// sink, even if some of them fail; the first failure is returned.
+// The lock is only held to pick the sinks, so a slow sink delays the
+// caller writing to it but not other callers.
+func (f *Fanout) Put(entry AuditEntry) error {
+	f.mu.Lock()
+	set := f.current
+	set.inflight.Add(1)
+	f.mu.Unlock()
+	defer set.inflight.Done()
+
+	var firstErr error
+	for _, sink := range set.sinks {
+		if err := sink.Put(entry); err != nil {
+			logger.Errorf("cannot write audit entry to %T: %v", sink, err)
+			if firstErr == nil {
+				firstErr = errors.Trace(err)
+			}
+		}
+	}
+	return firstErr
+}
+
+// Close is part of the Sink interface. It closes all the sinks.
+func (f *Fanout) Close() error {
+	f.SetSinks()
+	return nil
+}
+
+func closeSinks(sinks []Sink) {
+	for _, sink := range sinks {
+		if err := sink.Close(); err != nil {
+			logger.Errorf("cannot close audit sink %T: %v", sink, err)
+		}
+	}
+}
+
+// SinkConfig describes the sinks to which audit entries should be
+// sent. A zero SinkConfig describes no sinks at all.
+type SinkConfig struct {
+	// FilePath, if set, is the path of a file to which entries
+	// are written as JSON, one per line.
+	FilePath string
+
+	// FileMaxSizeMB is the size in megabytes at which the file is
+	// rotated.
+	FileMaxSizeMB int
+
+	// FileMaxBackups is the number of rotated files to keep.
+	FileMaxBackups int
+
+	// Syslog, if true, causes entries to be written to the local
+	// syslog daemon.
+	Syslog bool
+
+	// WebhookURL, if set, is the http or https URL to which
+	// entries are POSTed.
+	WebhookURL string
+
+	// WebhookBufferDir is the directory in which entries waiting
+	// to be POSTed to WebhookURL are buffered.
+	WebhookBufferDir string
+}
+
+// Validate ensures that the config is internally consistent.
+func (cfg SinkConfig) Validate() error {
+	if cfg.FilePath != "" {
+		if cfg.FileMaxSizeMB <= 0 {
+			return errors.NotValidf("audit file max size %d", cfg.FileMaxSizeMB)
+		}
+		if cfg.FileMaxBackups < 0 {
+			return errors.NotValidf("audit file max backups %d", cfg.FileMaxBackups)
+		}
+	}
+	if cfg.WebhookURL != "" {
+		u, err := url.Parse(cfg.WebhookURL)
+		if err != nil {
+			return errors.NotValidf("audit webhook URL %q", cfg.WebhookURL)
+		}
+		if u.Scheme != "http" && u.Scheme != "https" {
+			return errors.NotValidf("audit webhook URL scheme %q", u.Scheme)
+		}
+		if cfg.WebhookBufferDir == "" {
+			return errors.NotValidf("missing audit webhook buffer directory")
+		}
+	}
+	return nil
+}
+
+// NewSinks returns the sinks described by the given config. If any
+// sink cannot be created, those already created are closed and an
+// error is returned.
+func NewSinks(cfg SinkConfig) (_ []Sink, err error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	var sinks []Sink
+	defer func() {
+		if err != nil {
+			closeSinks(sinks)
+		}
+	}()
+	if cfg.FilePath != "" {
+		sinks = append(sinks, NewFileSink(cfg.FilePath, cfg.FileMaxSizeMB, cfg.FileMaxBackups))
+	}
+	if cfg.Syslog {
+		sink, err := NewSyslogSink()
+		if err != nil {
+			return nil, errors.Annotate(err, "cannot create syslog audit sink")
+		}
+		sinks = append(sinks, sink)
+	}
+	if cfg.WebhookURL != "" {
+		sink, err := NewWebhookSink(WebhookConfig{
+			URL:       cfg.WebhookURL,
+			BufferDir: cfg.WebhookBufferDir,
+		})
+		if err != nil {
+			return nil, errors.Annotate(err, "cannot create webhook audit sink")
+		}
+		sinks = append(sinks, sink)
+	}
+	return sinks, nil
+}
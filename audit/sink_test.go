@@ -0,0 +1,236 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package audit
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	coretesting "github.com/juju/juju/testing"
+)
+
+type sinkSuite struct{}
+
+var _ = gc.Suite(&sinkSuite{})
+
+// recordingSink is a Sink that remembers the entries put to it.
+type recordingSink struct {
+	entries []AuditEntry
+	err     error
+	closed  bool
+}
+
+func (s *recordingSink) Put(entry AuditEntry) error {
+	s.entries = append(s.entries, entry)
+	return s.err
+}
+
+func (s *recordingSink) Close() error {
+	s.closed = true
+	return nil
+}
+
+func (*sinkSuite) TestFanoutPut(c *gc.C) {
+	sink1 := &recordingSink{}
+	sink2 := &recordingSink{}
+	fanout := NewFanout(sink1, sink2)
+	err := fanout.Put(validEntry())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(sink1.entries, jc.DeepEquals, []AuditEntry{validEntry()})
+	c.Check(sink2.entries, jc.DeepEquals, []AuditEntry{validEntry()})
+}
+
+func (*sinkSuite) TestFanoutPutContinuesAfterError(c *gc.C) {
+	sink1 := &recordingSink{err: errors.New("disk full")}
+	sink2 := &recordingSink{}
+	fanout := NewFanout(sink1, sink2)
+	err := fanout.Put(validEntry())
+	c.Assert(err, gc.ErrorMatches, "disk full")
+	c.Check(sink2.entries, jc.DeepEquals, []AuditEntry{validEntry()})
+}
+
+func (*sinkSuite) TestFanoutSetSinksClosesOld(c *gc.C) {
+	old := &recordingSink{}
+	fanout := NewFanout(old)
+	replacement := &recordingSink{}
+	fanout.SetSinks(replacement)
+	c.Check(old.closed, jc.IsTrue)
+
+	err := fanout.Put(validEntry())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(old.entries, gc.HasLen, 0)
+	c.Check(replacement.entries, gc.HasLen, 1)
+
+	err = fanout.Close()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(replacement.closed, jc.IsTrue)
+}
+
+// blockingSink is a Sink whose Put blocks until it is released.
+type blockingSink struct {
+	started chan struct{}
+	release chan struct{}
+	closed  chan struct{}
+}
+
+func newBlockingSink() *blockingSink {
+	return &blockingSink{
+		started: make(chan struct{}, 1),
+		release: make(chan struct{}),
+		closed:  make(chan struct{}),
+	}
+}
+
+func (s *blockingSink) Put(entry AuditEntry) error {
+	s.started <- struct{}{}
+	<-s.release
+	return nil
+}
+
+func (s *blockingSink) Close() error {
+	close(s.closed)
+	return nil
+}
+
+func (*sinkSuite) TestFanoutPutDoesNotHoldLockWhileWriting(c *gc.C) {
+	slow := newBlockingSink()
+	fanout := NewFanout(slow)
+	done := make(chan error, 1)
+	go func() {
+		done <- fanout.Put(validEntry())
+	}()
+	select {
+	case <-slow.started:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for the slow put to start")
+	}
+
+	// Replacing the sinks must not wait for the slow put, but the
+	// slow sink must not be closed until it has finished.
+	fast := &recordingSink{}
+	replaced := make(chan struct{})
+	go func() {
+		fanout.SetSinks(fast)
+		close(replaced)
+	}()
+	for a := coretesting.LongAttempt.Start(); a.Next(); {
+		fanout.mu.Lock()
+		swapped := len(fanout.current.sinks) == 1 && fanout.current.sinks[0] == fast
+		fanout.mu.Unlock()
+		if swapped {
+			break
+		}
+		if !a.HasNext() {
+			c.Fatalf("timed out waiting for the sinks to be swapped")
+		}
+	}
+	err := fanout.Put(validEntry())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(fast.entries, gc.HasLen, 1)
+	select {
+	case <-slow.closed:
+		c.Fatalf("slow sink closed while an entry was being written to it")
+	case <-time.After(coretesting.ShortWait):
+	}
+
+	close(slow.release)
+	select {
+	case err := <-done:
+		c.Assert(err, jc.ErrorIsNil)
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for the slow put to finish")
+	}
+	select {
+	case <-replaced:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for the sinks to be replaced")
+	}
+	select {
+	case <-slow.closed:
+	default:
+		c.Fatalf("slow sink not closed")
+	}
+}
+
+func (*sinkSuite) TestSinkConfigValidate(c *gc.C) {
+	for i, test := range []struct {
+		cfg SinkConfig
+		err string
+	}{{
+		cfg: SinkConfig{},
+	}, {
+		cfg: SinkConfig{FilePath: "/var/log/juju/audit.log", FileMaxSizeMB: 100},
+	}, {
+		cfg: SinkConfig{FilePath: "/var/log/juju/audit.log"},
+		err: "audit file max size 0 not valid",
+	}, {
+		cfg: SinkConfig{FilePath: "/var/log/juju/audit.log", FileMaxSizeMB: 1, FileMaxBackups: -1},
+		err: "audit file max backups -1 not valid",
+	}, {
+		cfg: SinkConfig{WebhookURL: "https://audit.example.com/", WebhookBufferDir: "/tmp"},
+	}, {
+		cfg: SinkConfig{WebhookURL: "ftp://audit.example.com/", WebhookBufferDir: "/tmp"},
+		err: `audit webhook URL scheme "ftp" not valid`,
+	}, {
+		cfg: SinkConfig{WebhookURL: "https://audit.example.com/"},
+		err: "missing audit webhook buffer directory not valid",
+	}} {
+		c.Logf("test %d", i)
+		err := test.cfg.Validate()
+		if test.err == "" {
+			c.Check(err, jc.ErrorIsNil)
+		} else {
+			c.Check(err, jc.Satisfies, errors.IsNotValid)
+			c.Check(err, gc.ErrorMatches, test.err)
+		}
+	}
+}
+
+func (*sinkSuite) TestNewSinksEmpty(c *gc.C) {
+	sinks, err := NewSinks(SinkConfig{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(sinks, gc.HasLen, 0)
+}
+
+func (*sinkSuite) TestNewSinksInvalid(c *gc.C) {
+	_, err := NewSinks(SinkConfig{FilePath: "audit.log"})
+	c.Assert(err, gc.ErrorMatches, "audit file max size 0 not valid")
+}
+
+func (*sinkSuite) TestFileSink(c *gc.C) {
+	path := filepath.Join(c.MkDir(), "audit.log")
+	sinks, err := NewSinks(SinkConfig{
+		FilePath:       path,
+		FileMaxSizeMB:  1,
+		FileMaxBackups: 1,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(sinks, gc.HasLen, 1)
+	sink := sinks[0]
+
+	entry1 := validEntry()
+	entry2 := validEntry()
+	entry2.Method = "Destroy"
+	c.Assert(sink.Put(entry1), jc.ErrorIsNil)
+	c.Assert(sink.Put(entry2), jc.ErrorIsNil)
+	c.Assert(sink.Close(), jc.ErrorIsNil)
+
+	data, err := ioutil.ReadFile(path)
+	c.Assert(err, jc.ErrorIsNil)
+	lines := strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+	c.Assert(lines, gc.HasLen, 2)
+	for i, expect := range []AuditEntry{entry1, entry2} {
+		var entry AuditEntry
+		err := json.Unmarshal([]byte(lines[i]), &entry)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(entry, jc.DeepEquals, expect)
+	}
+}
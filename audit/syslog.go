@@ -0,0 +1,44 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// +build !windows
+
+package audit
+
+import (
+	"log/syslog"
+
+	"github.com/juju/errors"
+)
+
+// syslogTag is the tag with which audit entries are written to syslog.
+const syslogTag = "juju-audit"
+
+// syslogSink writes audit entries to the local syslog daemon.
+type syslogSink struct {
+	writer *syslog.Writer
+}
+
+// NewSyslogSink returns a Sink that writes audit entries to the local
+// syslog daemon, using the auth facility.
+func NewSyslogSink() (Sink, error) {
+	writer, err := syslog.New(syslog.LOG_INFO|syslog.LOG_AUTH, syslogTag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &syslogSink{writer: writer}, nil
+}
+
+// Put is part of the Sink interface. Failed calls are written with
+// warning priority, so they stand out.
+func (s *syslogSink) Put(entry AuditEntry) error {
+	if entry.Outcome == OutcomeFailed {
+		return errors.Trace(s.writer.Warning(entry.String()))
+	}
+	return errors.Trace(s.writer.Info(entry.String()))
+}
+
+// Close is part of the Sink interface.
+func (s *syslogSink) Close() error {
+	return errors.Trace(s.writer.Close())
+}
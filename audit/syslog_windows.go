@@ -0,0 +1,13 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package audit
+
+import (
+	"github.com/juju/errors"
+)
+
+// NewSyslogSink is not supported on windows.
+func NewSyslogSink() (Sink, error) {
+	return nil, errors.NotSupportedf("syslog audit sink on windows")
+}
@@ -0,0 +1,285 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package audit
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils"
+	"github.com/juju/utils/clock"
+	"launchpad.net/tomb"
+)
+
+const (
+	// webhookBufferFile is the name of the file, within the buffer
+	// directory, that holds entries waiting to be sent.
+	webhookBufferFile = "audit-webhook.buffer"
+
+	// defaultMaxBufferSize is the default limit on the size of the
+	// on-disk buffer.
+	defaultMaxBufferSize = 64 * 1024 * 1024
+
+	// defaultMinRetryDelay and defaultMaxRetryDelay bound the delay
+	// between attempts to send entries to an unavailable endpoint.
+	defaultMinRetryDelay = time.Second
+	defaultMaxRetryDelay = 5 * time.Minute
+
+	// maxWebhookBatch is the maximum number of entries sent in a
+	// single request.
+	maxWebhookBatch = 100
+)
+
+// WebhookConfig holds the configuration for a webhook sink.
+type WebhookConfig struct {
+	// URL is the http or https URL to which entries are POSTed,
+	// as a JSON array.
+	URL string
+
+	// BufferDir is the directory in which entries are buffered
+	// until they have been accepted by the endpoint. Entries
+	// buffered when the sink is closed are sent by the next sink
+	// created with the same BufferDir.
+	BufferDir string
+
+	// MaxBufferSize is the size in bytes beyond which the buffer
+	// will not grow; entries Put while it is full are rejected.
+	// If zero, a default of 64MB is used.
+	MaxBufferSize int64
+
+	// MinRetryDelay and MaxRetryDelay bound the exponential backoff
+	// used when the endpoint cannot be reached. If zero, defaults
+	// of one second and five minutes are used.
+	MinRetryDelay time.Duration
+	MaxRetryDelay time.Duration
+
+	// Client is used to make requests. If nil, a client with a
+	// 30 second timeout is used.
+	Client *http.Client
+
+	// Clock is used to schedule retries. If nil, the wall clock
+	// is used.
+	Clock clock.Clock
+}
+
+// webhookSink POSTs audit entries to an HTTP endpoint, buffering them
+// on disk until the endpoint has accepted them.
+type webhookSink struct {
+	tomb       tomb.Tomb
+	config     WebhookConfig
+	bufferPath string
+	wake       chan struct{}
+
+	// mu guards the buffer file.
+	mu sync.Mutex
+}
+
+// NewWebhookSink returns a Sink that POSTs audit entries to the
+// configured URL.
+func NewWebhookSink(config WebhookConfig) (Sink, error) {
+	if config.URL == "" {
+		return nil, errors.NotValidf("missing URL")
+	}
+	if config.BufferDir == "" {
+		return nil, errors.NotValidf("missing BufferDir")
+	}
+	if config.MaxBufferSize == 0 {
+		config.MaxBufferSize = defaultMaxBufferSize
+	}
+	if config.MinRetryDelay == 0 {
+		config.MinRetryDelay = defaultMinRetryDelay
+	}
+	if config.MaxRetryDelay == 0 {
+		config.MaxRetryDelay = defaultMaxRetryDelay
+	}
+	if config.Client == nil {
+		config.Client = &http.Client{Timeout: 30 * time.Second}
+	}
+	if config.Clock == nil {
+		config.Clock = clock.WallClock
+	}
+	if err := os.MkdirAll(config.BufferDir, 0700); err != nil {
+		return nil, errors.Annotate(err, "cannot create buffer directory")
+	}
+	s := &webhookSink{
+		config:     config,
+		bufferPath: filepath.Join(config.BufferDir, webhookBufferFile),
+		wake:       make(chan struct{}, 1),
+	}
+	go func() {
+		defer s.tomb.Done()
+		s.tomb.Kill(s.loop())
+	}()
+	return s, nil
+}
+
+// Put is part of the Sink interface. The entry is appended to the
+// on-disk buffer, and sent asynchronously.
+func (s *webhookSink) Put(entry AuditEntry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	data = append(data, '\n')
+	if err := s.appendToBuffer(data); err != nil {
+		return errors.Trace(err)
+	}
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// Close is part of the Sink interface. Entries that have not yet been
+// sent remain in the buffer.
+func (s *webhookSink) Close() error {
+	s.tomb.Kill(nil)
+	return s.tomb.Wait()
+}
+
+func (s *webhookSink) appendToBuffer(data []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	f, err := os.OpenFile(s.bufferPath, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return errors.Annotate(err, "cannot open audit buffer")
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return errors.Annotate(err, "cannot open audit buffer")
+	}
+	if info.Size()+int64(len(data)) > s.config.MaxBufferSize {
+		return errors.Errorf("audit buffer full, discarding entry")
+	}
+	if _, err := f.Write(data); err != nil {
+		return errors.Annotate(err, "cannot write audit buffer")
+	}
+	return nil
+}
+
+func (s *webhookSink) loop() error {
+	var backoff time.Duration
+	for {
+		wake := s.wake
+		var retry <-chan time.Time
+		if err := s.flush(); err != nil {
+			backoff = nextBackoff(backoff, s.config.MinRetryDelay, s.config.MaxRetryDelay)
+			logger.Warningf("cannot send audit entries to %s (retrying in %v): %v", s.config.URL, backoff, err)
+			// Don't retry on every new entry while the
+			// endpoint is unavailable; wait out the backoff.
+			wake = nil
+			retry = s.config.Clock.After(backoff)
+		} else {
+			backoff = 0
+		}
+		select {
+		case <-s.tomb.Dying():
+			return tomb.ErrDying
+		case <-wake:
+		case <-retry:
+		}
+	}
+}
+
+// nextBackoff returns the delay to use after a failure that followed
+// the given delay.
+func nextBackoff(last, min, max time.Duration) time.Duration {
+	if last < min {
+		return min
+	}
+	next := last * 2
+	if next > max {
+		return max
+	}
+	return next
+}
+
+// flush sends all buffered entries to the endpoint, removing them
+// from the buffer once they have been accepted.
+func (s *webhookSink) flush() error {
+	for {
+		s.mu.Lock()
+		data, err := ioutil.ReadFile(s.bufferPath)
+		s.mu.Unlock()
+		if os.IsNotExist(err) {
+			return nil
+		} else if err != nil {
+			return errors.Annotate(err, "cannot read audit buffer")
+		}
+		batch, consumed := nextBatch(data, maxWebhookBatch)
+		if consumed == 0 {
+			return nil
+		}
+		if err := s.send(batch); err != nil {
+			return errors.Trace(err)
+		}
+		if err := s.discard(consumed); err != nil {
+			return errors.Trace(err)
+		}
+		select {
+		case <-s.tomb.Dying():
+			return nil
+		default:
+		}
+	}
+}
+
+// nextBatch returns a JSON array holding up to max entries from the
+// start of the buffer, and the number of bytes of the buffer that
+// they occupied.
+func nextBatch(data []byte, max int) ([]byte, int) {
+	var batch bytes.Buffer
+	batch.WriteByte('[')
+	consumed := 0
+	for count := 0; count < max; count++ {
+		i := bytes.IndexByte(data[consumed:], '\n')
+		if i < 0 {
+			break
+		}
+		if count > 0 {
+			batch.WriteByte(',')
+		}
+		batch.Write(data[consumed : consumed+i])
+		consumed += i + 1
+	}
+	batch.WriteByte(']')
+	return batch.Bytes(), consumed
+}
+
+func (s *webhookSink) send(batch []byte) error {
+	resp, err := s.config.Client.Post(s.config.URL, "application/json", bytes.NewReader(batch))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return errors.New(fmt.Sprintf("unexpected response %q", resp.Status))
+	}
+	return nil
+}
+
+// discard removes the first n bytes from the buffer. Entries are only
+// ever appended, so those bytes are the ones already sent.
+func (s *webhookSink) discard(n int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	data, err := ioutil.ReadFile(s.bufferPath)
+	if err != nil {
+		return errors.Annotate(err, "cannot read audit buffer")
+	}
+	if err := utils.AtomicWriteFile(s.bufferPath, data[n:], 0600); err != nil {
+		return errors.Annotate(err, "cannot update audit buffer")
+	}
+	return nil
+}
@@ -0,0 +1,197 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package audit
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync"
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	coretesting "github.com/juju/juju/testing"
+)
+
+type webhookSuite struct {
+	server    *httptest.Server
+	bufferDir string
+
+	mu       sync.Mutex
+	fail     bool
+	received []AuditEntry
+	requests chan struct{}
+}
+
+var _ = gc.Suite(&webhookSuite{})
+
+func (s *webhookSuite) SetUpTest(c *gc.C) {
+	s.bufferDir = c.MkDir()
+	s.fail = false
+	s.received = nil
+	s.requests = make(chan struct{}, 100)
+	s.server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+}
+
+func (s *webhookSuite) TearDownTest(c *gc.C) {
+	s.server.Close()
+}
+
+func (s *webhookSuite) serveHTTP(w http.ResponseWriter, req *http.Request) {
+	defer func() { s.requests <- struct{}{} }()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.fail {
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+		return
+	}
+	var entries []AuditEntry
+	if err := json.NewDecoder(req.Body).Decode(&entries); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	s.received = append(s.received, entries...)
+}
+
+func (s *webhookSuite) setFail(fail bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.fail = fail
+}
+
+func (s *webhookSuite) waitReceived(c *gc.C, n int) []AuditEntry {
+	timeout := time.After(coretesting.LongWait)
+	for {
+		s.mu.Lock()
+		received := s.received
+		s.mu.Unlock()
+		if len(received) >= n {
+			return received
+		}
+		select {
+		case <-s.requests:
+		case <-timeout:
+			c.Fatalf("timed out waiting for %d entries, got %d", n, len(received))
+		}
+	}
+}
+
+func (s *webhookSuite) waitRequest(c *gc.C) {
+	select {
+	case <-s.requests:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for request")
+	}
+}
+
+func (s *webhookSuite) newSink(c *gc.C, clock *coretesting.Clock) Sink {
+	config := WebhookConfig{
+		URL:       s.server.URL,
+		BufferDir: s.bufferDir,
+	}
+	if clock != nil {
+		config.Clock = clock
+	}
+	sink, err := NewWebhookSink(config)
+	c.Assert(err, jc.ErrorIsNil)
+	return sink
+}
+
+func (s *webhookSuite) bufferContents(c *gc.C) string {
+	data, err := ioutil.ReadFile(filepath.Join(s.bufferDir, webhookBufferFile))
+	c.Assert(err, jc.ErrorIsNil)
+	return string(data)
+}
+
+func (s *webhookSuite) TestInvalidConfig(c *gc.C) {
+	_, err := NewWebhookSink(WebhookConfig{BufferDir: s.bufferDir})
+	c.Check(err, gc.ErrorMatches, "missing URL not valid")
+	_, err = NewWebhookSink(WebhookConfig{URL: s.server.URL})
+	c.Check(err, gc.ErrorMatches, "missing BufferDir not valid")
+}
+
+func (s *webhookSuite) TestPut(c *gc.C) {
+	sink := s.newSink(c, nil)
+	defer sink.Close()
+
+	entry1 := validEntry()
+	entry2 := validEntry()
+	entry2.Method = "Destroy"
+	c.Assert(sink.Put(entry1), jc.ErrorIsNil)
+	c.Assert(sink.Put(entry2), jc.ErrorIsNil)
+
+	received := s.waitReceived(c, 2)
+	c.Check(received, jc.DeepEquals, []AuditEntry{entry1, entry2})
+}
+
+func (s *webhookSuite) TestRetryAfterFailure(c *gc.C) {
+	s.setFail(true)
+	clock := coretesting.NewClock(time.Now())
+	sink := s.newSink(c, clock)
+	defer sink.Close()
+
+	c.Assert(sink.Put(validEntry()), jc.ErrorIsNil)
+	s.waitRequest(c)
+
+	// The entry stays buffered until the endpoint accepts it.
+	data, err := json.Marshal(validEntry())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.bufferContents(c), gc.Equals, string(data)+"\n")
+
+	s.setFail(false)
+	select {
+	case <-clock.Alarms():
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for retry to be scheduled")
+	}
+	clock.Advance(defaultMinRetryDelay)
+	received := s.waitReceived(c, 1)
+	c.Check(received, jc.DeepEquals, []AuditEntry{validEntry()})
+}
+
+func (s *webhookSuite) TestBufferedAcrossRestart(c *gc.C) {
+	s.setFail(true)
+	clock := coretesting.NewClock(time.Now())
+	sink := s.newSink(c, clock)
+	c.Assert(sink.Put(validEntry()), jc.ErrorIsNil)
+	s.waitRequest(c)
+	c.Assert(sink.Close(), jc.ErrorIsNil)
+
+	s.setFail(false)
+	sink = s.newSink(c, nil)
+	defer sink.Close()
+	received := s.waitReceived(c, 1)
+	c.Check(received, jc.DeepEquals, []AuditEntry{validEntry()})
+}
+
+func (s *webhookSuite) TestBufferFull(c *gc.C) {
+	s.setFail(true)
+	sink, err := NewWebhookSink(WebhookConfig{
+		URL:           s.server.URL,
+		BufferDir:     s.bufferDir,
+		MaxBufferSize: 10,
+		Clock:         coretesting.NewClock(time.Now()),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	defer sink.Close()
+	err = sink.Put(validEntry())
+	c.Assert(err, gc.ErrorMatches, "audit buffer full, discarding entry")
+}
+
+func (*webhookSuite) TestNextBackoff(c *gc.C) {
+	min, max := time.Second, 5*time.Second
+	var delays []time.Duration
+	var delay time.Duration
+	for i := 0; i < 5; i++ {
+		delay = nextBackoff(delay, min, max)
+		delays = append(delays, delay)
+	}
+	c.Check(delays, jc.DeepEquals, []time.Duration{
+		time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second,
+	})
+}
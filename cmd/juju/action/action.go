@@ -36,6 +36,7 @@ func NewSuperCommand() cmd.Command {
 	actionCmd.Register(newDoCommand())
 	actionCmd.Register(newFetchCommand())
 	actionCmd.Register(newStatusCommand())
+	actionCmd.Register(newCancelCommand())
 	return actionCmd
 }
 
@@ -49,6 +50,14 @@ type APIClient interface {
 	// Action.
 	Enqueue(params.Actions) (params.ActionResults, error)
 
+	// EnqueueService takes a list of ServiceActions and queues each of
+	// them on the units of its service, returning the group of Actions
+	// queued for each.
+	EnqueueService(params.ServiceActions) (params.ActionGroupResults, error)
+
+	// ActionGroups fetches the Actions in each of the given groups.
+	ActionGroups(params.ActionGroups) (params.ActionGroupResults, error)
+
 	// ListAll takes a list of Tags representing ActionReceivers and returns
 	// all of the Actions that have been queued or run by each of those
 	// Entities.
@@ -64,8 +73,9 @@ type APIClient interface {
 	// Entities.
 	ListCompleted(params.Entities) (params.ActionsByReceivers, error)
 
-	// Cancel attempts to cancel a queued up Action from running.
-	Cancel(params.Actions) (params.ActionResults, error)
+	// Cancel removes queued Actions, and asks running Actions to
+	// stop.
+	Cancel(params.Entities) (params.ActionResults, error)
 
 	// ServiceCharmActions is a single query which uses ServicesCharmActions to
 	// get the charm.Actions for a single Service by tag.
@@ -44,6 +44,7 @@ func (s *ActionCommandSuite) TestHelp(c *gc.C) {
 
 func (s *ActionCommandSuite) checkHelpSubCommands(c *gc.C, ctx *cmd.Context) {
 	var expectedSubCommmands = [][]string{
+		{"cancel", "cancel queued or running actions"},
 		{"defined", "show actions defined for a service"},
 		{"do", "queue an action for execution"},
 		{"fetch", "show results of an action by ID"},
@@ -0,0 +1,73 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action_test
+
+import (
+	"bytes"
+	"time"
+
+	"github.com/juju/cmd"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/action"
+	"github.com/juju/juju/testing"
+)
+
+type CancelSuite struct {
+	BaseActionSuite
+	subcommand cmd.Command
+}
+
+var _ = gc.Suite(&CancelSuite{})
+
+func (s *CancelSuite) SetUpTest(c *gc.C) {
+	s.BaseActionSuite.SetUpTest(c)
+	s.subcommand = action.NewCancelCommand()
+}
+
+func (s *CancelSuite) TestHelp(c *gc.C) {
+	s.checkHelp(c, s.subcommand)
+}
+
+func (s *CancelSuite) TestInit(c *gc.C) {
+	err := testing.InitCommand(action.NewCancelCommand(), []string{})
+	c.Assert(err, gc.ErrorMatches, "no action ID specified")
+}
+
+func (s *CancelSuite) TestRun(c *gc.C) {
+	prefix := "deadbeef"
+	faketag := "action-" + prefix + "-0000-4000-8000-feedfacebeef"
+	results := []params.ActionResult{{
+		Action: &params.Action{Tag: faketag, Receiver: "unit-mysql-0"},
+		Status: params.ActionCancelled,
+	}}
+
+	for _, modelFlag := range s.modelFlags {
+		fakeClient := makeFakeClient(0, 5*time.Second, tagsForIdPrefix(prefix, faketag), results, "")
+		restore := s.patchAPIClient(fakeClient)
+		defer restore()
+
+		ctx, err := testing.RunCommand(c, action.NewCancelCommand(), modelFlag, "dummymodel", prefix)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(fakeClient.cancelledActions, jc.DeepEquals, params.Entities{
+			Entities: []params.Entity{{Tag: faketag}},
+		})
+		buf, err := cmd.DefaultFormatters["yaml"](action.ActionResultsToMap(results))
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(ctx.Stdout.(*bytes.Buffer).String(), gc.Equals, string(buf)+"\n")
+	}
+}
+
+func (s *CancelSuite) TestRunNotFound(c *gc.C) {
+	for _, modelFlag := range s.modelFlags {
+		fakeClient := makeFakeClient(0, 5*time.Second, tagsForIdPrefix("deadbeef"), nil, "")
+		restore := s.patchAPIClient(fakeClient)
+		defer restore()
+
+		_, err := testing.RunCommand(c, action.NewCancelCommand(), modelFlag, "dummymodel", "deadbeef")
+		c.Assert(err, gc.ErrorMatches, `actions for identifier "deadbeef" not found`)
+	}
+}
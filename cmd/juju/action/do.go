@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
@@ -25,14 +26,18 @@ func newDoCommand() cmd.Command {
 	return modelcmd.Wrap(&doCommand{})
 }
 
-// doCommand enqueues an Action for running on the given unit with given
-// params
+// doCommand enqueues an Action for running on the given unit, or on
+// the units of the given service, with given params
 type doCommand struct {
 	ActionCommandBase
 	unitTag      names.UnitTag
+	serviceTag   names.ServiceTag
+	allUnits     bool
+	leader       bool
 	actionName   string
 	paramsYAML   cmd.FileVar
 	parseStrings bool
+	timeout      time.Duration
 	out          cmd.Output
 	args         [][]string
 }
@@ -41,6 +46,12 @@ const doDoc = `
 Queue an Action for execution on a given unit, with a given set of params.
 Displays the ID of the Action for use with 'juju kill', 'juju status', etc.
 
+An Action may instead be queued on the units of a service, by giving the
+service name. It is run on every unit of the service, or only on the
+service's leader if --leader is given. The Actions queued are grouped
+together, and the group ID displayed may be used with
+'juju action fetch --group' to wait for and summarise their results.
+
 Params are validated according to the charm for the unit's service.  The 
 valid params can be seen using "juju action defined <service> --schema".
 Params may be in a yaml file which is passed with the --params flag, or they
@@ -53,6 +64,11 @@ is a boolean true in YAML.
 If --params is passed, along with key.key...=value explicit arguments, the
 explicit arguments will override the parameter file.
 
+If --timeout is given, the Action is stopped, along with any processes it
+started, if it has not finished within that time; its status is then
+"timed-out". A queued or running Action may be stopped with
+"juju action cancel".
+
 Examples:
 
 $ juju action do mysql/3 backup 
@@ -66,6 +82,15 @@ result:
     units: GB
     name: foo.sql
 
+$ juju action do mysql backup
+Action group queued with id: <group ID>
+actions:
+  mysql/0: <ID>
+  mysql/1: <ID>
+
+$ juju action fetch --group <group ID>
+...
+
 $ juju action do mysql/3 backup --params parameters.yml
 ...
 Params sent will be the contents of parameters.yml.
@@ -113,12 +138,15 @@ func (c *doCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.out.AddFlags(f, "smart", cmd.DefaultFormatters)
 	f.Var(&c.paramsYAML, "params", "path to yaml-formatted params file")
 	f.BoolVar(&c.parseStrings, "string-args", false, "use raw string values of CLI args")
+	f.DurationVar(&c.timeout, "timeout", 0, "stop the action if it runs for longer than this")
+	f.BoolVar(&c.allUnits, "all-units", false, "run the action on every unit of the given service (the default)")
+	f.BoolVar(&c.leader, "leader", false, "run the action on the leader unit of the given service")
 }
 
 func (c *doCommand) Info() *cmd.Info {
 	return &cmd.Info{
 		Name:    "do",
-		Args:    "<unit> <action name> [key.key.key...=value]",
+		Args:    "<unit>|<service> <action name> [key.key.key...=value]",
 		Purpose: "queue an action for execution",
 		Doc:     doDoc,
 	}
@@ -126,22 +154,26 @@ func (c *doCommand) Info() *cmd.Info {
 
 // Init gets the unit tag, and checks for other correct args.
 func (c *doCommand) Init(args []string) error {
+	if c.timeout < 0 {
+		return errors.Errorf("invalid timeout %v", c.timeout)
+	}
+	if c.allUnits && c.leader {
+		return errors.New("--all-units and --leader cannot be used together")
+	}
 	switch len(args) {
 	case 0:
 		return errors.New("no unit specified")
 	case 1:
 		return errors.New("no action specified")
 	default:
-		// Grab and verify the unit and action names.
-		unitName := args[0]
-		if !names.IsValidUnit(unitName) {
-			return errors.Errorf("invalid unit name %q", unitName)
+		// Grab and verify the unit or service, and action names.
+		if err := c.initTarget(args[0]); err != nil {
+			return err
 		}
 		ActionName := args[1]
 		if valid := ActionNameRule.MatchString(ActionName); !valid {
 			return fmt.Errorf("invalid action name %q", ActionName)
 		}
-		c.unitTag = names.NewUnitTag(unitName)
 		c.actionName = ActionName
 		if len(args) == 2 {
 			return nil
@@ -167,6 +199,23 @@ func (c *doCommand) Init(args []string) error {
 	}
 }
 
+// initTarget records the unit, or the service, on which the Action
+// is to be queued.
+func (c *doCommand) initTarget(target string) error {
+	switch {
+	case names.IsValidUnit(target):
+		if c.allUnits || c.leader {
+			return errors.New("--all-units and --leader can only be used with a service")
+		}
+		c.unitTag = names.NewUnitTag(target)
+	case names.IsValidService(target):
+		c.serviceTag = names.NewServiceTag(target)
+	default:
+		return errors.Errorf("invalid unit or service name %q", target)
+	}
+	return nil
+}
+
 func (c *doCommand) Run(ctx *cmd.Context) error {
 	api, err := c.NewActionAPIClient()
 	if err != nil {
@@ -227,11 +276,16 @@ func (c *doCommand) Run(ctx *cmd.Context) error {
 		return errors.Errorf("params must be a map, got %T", typedConformantParams)
 	}
 
+	if c.serviceTag.Id() != "" {
+		return c.enqueueService(ctx, api, actionParams)
+	}
+
 	actionParam := params.Actions{
 		Actions: []params.Action{{
 			Receiver:   c.unitTag.String(),
 			Name:       c.actionName,
 			Parameters: actionParams,
+			Timeout:    c.timeout,
 		}},
 	}
 
@@ -261,3 +315,62 @@ func (c *doCommand) Run(ctx *cmd.Context) error {
 	output := map[string]string{"Action queued with id": tag.Id()}
 	return c.out.Write(ctx, output)
 }
+
+// enqueueService queues the Action on the units of the service, and
+// writes the ID of the group of Actions queued and of each Action.
+func (c *doCommand) enqueueService(ctx *cmd.Context, api APIClient, actionParams map[string]interface{}) error {
+	results, err := api.EnqueueService(params.ServiceActions{
+		Actions: []params.ServiceAction{{
+			Service:    c.serviceTag.String(),
+			Name:       c.actionName,
+			Parameters: actionParams,
+			Timeout:    c.timeout,
+			Leader:     c.leader,
+		}},
+	})
+	if err != nil {
+		return err
+	}
+	if len(results.Results) != 1 {
+		return errors.New("illegal number of results returned")
+	}
+
+	result := results.Results[0]
+	if result.Error != nil {
+		return result.Error
+	}
+
+	actions := make(map[string]string)
+	var failed int
+	for _, actionResult := range result.Actions {
+		if actionResult.Action == nil {
+			return errors.New("action failed to enqueue")
+		}
+		unitTag, err := names.ParseUnitTag(actionResult.Action.Receiver)
+		if err != nil {
+			return err
+		}
+		if actionResult.Error != nil {
+			actions[unitTag.Id()] = fmt.Sprintf("error: %v", actionResult.Error)
+			failed++
+			continue
+		}
+		actionTag, err := names.ParseActionTag(actionResult.Action.Tag)
+		if err != nil {
+			return err
+		}
+		actions[unitTag.Id()] = actionTag.Id()
+	}
+
+	output := map[string]interface{}{
+		"Action group queued with id": result.Group,
+		"actions":                     actions,
+	}
+	if err := c.out.Write(ctx, output); err != nil {
+		return err
+	}
+	if failed > 0 {
+		return errors.Errorf("action failed to enqueue on %d of %d units", failed, len(result.Actions))
+	}
+	return nil
+}
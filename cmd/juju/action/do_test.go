@@ -7,6 +7,7 @@ import (
 	"bytes"
 	"errors"
 	"strings"
+	"time"
 	"unicode/utf8"
 
 	"github.com/juju/names"
@@ -65,9 +66,12 @@ func (s *DoSuite) TestInit(c *gc.C) {
 		should               string
 		args                 []string
 		expectUnit           names.UnitTag
+		expectService        names.ServiceTag
+		expectLeader         bool
 		expectAction         string
 		expectParamsYamlPath string
 		expectParseStrings   bool
+		expectTimeout        time.Duration
 		expectKVArgs         [][]string
 		expectOutput         string
 		expectError          string
@@ -82,7 +86,31 @@ func (s *DoSuite) TestInit(c *gc.C) {
 	}, {
 		should:      "fail with invalid unit tag",
 		args:        []string{invalidUnitId, "valid-action-name"},
-		expectError: "invalid unit name \"something-strange-\"",
+		expectError: "invalid unit or service name \"something-strange-\"",
+	}, {
+		should:        "handle service with all units by default",
+		args:          []string{validServiceId, "valid-action-name"},
+		expectService: names.NewServiceTag(validServiceId),
+		expectAction:  "valid-action-name",
+	}, {
+		should:      "fail with unit and --all-units",
+		args:        []string{validUnitId, "valid-action-name", "--all-units"},
+		expectError: "--all-units and --leader can only be used with a service",
+	}, {
+		should:      "fail with --all-units and --leader",
+		args:        []string{validServiceId, "valid-action-name", "--all-units", "--leader"},
+		expectError: "--all-units and --leader cannot be used together",
+	}, {
+		should:        "handle service with --all-units",
+		args:          []string{validServiceId, "valid-action-name", "--all-units"},
+		expectService: names.NewServiceTag(validServiceId),
+		expectAction:  "valid-action-name",
+	}, {
+		should:        "handle service with --leader",
+		args:          []string{validServiceId, "valid-action-name", "--leader"},
+		expectService: names.NewServiceTag(validServiceId),
+		expectAction:  "valid-action-name",
+		expectLeader:  true,
 	}, {
 		should:      "fail with invalid action name",
 		args:        []string{validUnitId, "BadName"},
@@ -111,6 +139,16 @@ func (s *DoSuite) TestInit(c *gc.C) {
 		expectUnit:         names.NewUnitTag(validUnitId),
 		expectAction:       "valid-action-name",
 		expectParseStrings: true,
+	}, {
+		should:        "handle --timeout",
+		args:          []string{validUnitId, "valid-action-name", "--timeout=5m"},
+		expectUnit:    names.NewUnitTag(validUnitId),
+		expectAction:  "valid-action-name",
+		expectTimeout: 5 * time.Minute,
+	}, {
+		should:      "fail with negative --timeout",
+		args:        []string{validUnitId, "valid-action-name", "--timeout=-1s"},
+		expectError: "invalid timeout -1s",
 	}, {
 		// cf. worker/uniter/runner/jujuc/action-set_test.go per @fwereade
 		should:       "work with multiple '=' signs",
@@ -174,10 +212,13 @@ func (s *DoSuite) TestInit(c *gc.C) {
 			err := testing.InitCommand(wrappedCommand, args)
 			if t.expectError == "" {
 				c.Check(command.UnitTag(), gc.Equals, t.expectUnit)
+				c.Check(command.ServiceTag(), gc.Equals, t.expectService)
+				c.Check(command.Leader(), gc.Equals, t.expectLeader)
 				c.Check(command.ActionName(), gc.Equals, t.expectAction)
 				c.Check(command.ParamsYAML().Path, gc.Equals, t.expectParamsYamlPath)
 				c.Check(command.Args(), jc.DeepEquals, t.expectKVArgs)
 				c.Check(command.ParseStrings(), gc.Equals, t.expectParseStrings)
+				c.Check(command.Timeout(), gc.Equals, t.expectTimeout)
 			} else {
 				c.Check(err, gc.ErrorMatches, t.expectError)
 			}
@@ -404,3 +445,44 @@ func (s *DoSuite) TestRun(c *gc.C) {
 		}
 	}
 }
+
+func (s *DoSuite) TestRunService(c *gc.C) {
+	fakeClient := &fakeAPIClient{
+		actionGroupResults: []params.ActionGroupResult{{
+			Group: "some-group",
+			Actions: []params.ActionResult{{
+				Action: &params.Action{Tag: validActionTagString, Receiver: "unit-mysql-0"},
+			}, {
+				Action: &params.Action{Receiver: "unit-mysql-1"},
+				Error:  common.ServerError(errors.New("database error")),
+			}},
+		}},
+	}
+	restore := s.patchAPIClient(fakeClient)
+	defer restore()
+
+	wrappedCommand, _ := action.NewDoCommand()
+	ctx, err := testing.RunCommand(c, wrappedCommand,
+		"-m", "dummymodel", validServiceId, "some-action", "--leader", "--timeout=1h")
+	c.Assert(err, gc.ErrorMatches, "action failed to enqueue on 1 of 2 units")
+
+	c.Check(fakeClient.enqueuedServiceActions, jc.DeepEquals, params.ServiceActions{
+		Actions: []params.ServiceAction{{
+			Service:    names.NewServiceTag(validServiceId).String(),
+			Name:       "some-action",
+			Parameters: map[string]interface{}{},
+			Timeout:    time.Hour,
+			Leader:     true,
+		}},
+	})
+	var output map[string]interface{}
+	err = yaml.Unmarshal(ctx.Stdout.(*bytes.Buffer).Bytes(), &output)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(output, jc.DeepEquals, map[string]interface{}{
+		"Action group queued with id": "some-group",
+		"actions": map[interface{}]interface{}{
+			"mysql/0": validActionId,
+			"mysql/1": "error: database error",
+		},
+	})
+}
@@ -4,6 +4,8 @@
 package action
 
 import (
+	"time"
+
 	"github.com/juju/cmd"
 	"github.com/juju/names"
 
@@ -16,6 +18,7 @@ var (
 	AddValueToMap      = addValueToMap
 	NewFetchCommand    = newFetchCommand
 	NewStatusCommand   = newStatusCommand
+	NewCancelCommand   = newCancelCommand
 )
 
 type DoCommand struct {
@@ -26,6 +29,14 @@ func (c *DoCommand) UnitTag() names.UnitTag {
 	return c.unitTag
 }
 
+func (c *DoCommand) ServiceTag() names.ServiceTag {
+	return c.serviceTag
+}
+
+func (c *DoCommand) Leader() bool {
+	return c.leader
+}
+
 func (c *DoCommand) ActionName() string {
 	return c.actionName
 }
@@ -38,6 +49,10 @@ func (c *DoCommand) ParamsYAML() cmd.FileVar {
 	return c.paramsYAML
 }
 
+func (c *DoCommand) Timeout() time.Duration {
+	return c.timeout
+}
+
 func (c *DoCommand) Args() [][]string {
 	return c.args
 }
@@ -9,6 +9,7 @@ import (
 
 	"github.com/juju/cmd"
 	errors "github.com/juju/errors"
+	"github.com/juju/names"
 	"launchpad.net/gnuflag"
 
 	"github.com/juju/juju/apiserver/params"
@@ -26,6 +27,7 @@ type fetchCommand struct {
 	requestedId string
 	fullSchema  bool
 	wait        string
+	group       bool
 }
 
 const fetchDoc = `
@@ -37,18 +39,25 @@ to wait indefinitely.  If units are left off, seconds are assumed.
 The default behavior without --wait is to immediately check and return; if
 the results are "pending" then only the available information will be
 displayed.  This is also the behavior when any negative time is given.
+
+With --group, the given ID is that of a group of actions queued on the units
+of a service by "juju action do <service>". The results of every action in
+the group are shown along with a count of actions by status.  This waits
+until all the actions have finished, or until the --wait duration, if
+positive, has passed.
 `
 
 // Set up the output.
 func (c *fetchCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.out.AddFlags(f, "smart", cmd.DefaultFormatters)
 	f.StringVar(&c.wait, "wait", "-1s", "wait for results")
+	f.BoolVar(&c.group, "group", false, "show the results of a group of actions queued on a service")
 }
 
 func (c *fetchCommand) Info() *cmd.Info {
 	return &cmd.Info{
 		Name:    "fetch",
-		Args:    "<action ID>",
+		Args:    "<action ID>|<group ID>",
 		Purpose: "show results of an action by ID",
 		Doc:     fetchDoc,
 	}
@@ -92,6 +101,20 @@ func (c *fetchCommand) Run(ctx *cmd.Context) error {
 	tick := time.NewTimer(2 * time.Second)
 	wait := time.NewTimer(0 * time.Second)
 
+	if c.group {
+		// Groups are waited on indefinitely unless a limit is given.
+		if waitDur.Nanoseconds() > 0 {
+			wait = time.NewTimer(waitDur)
+		} else {
+			_ = <-wait.C
+		}
+		result, err := groupTimerLoop(api, c.requestedId, wait, tick)
+		if err != nil {
+			return err
+		}
+		return c.out.Write(ctx, formatActionGroupResult(result))
+	}
+
 	switch {
 	case waitDur.Nanoseconds() < 0:
 		// Negative duration signals immediate return.  All is well.
@@ -148,6 +171,58 @@ func timerLoop(api APIClient, requestedId string, wait, tick *time.Timer) (param
 	}
 }
 
+// groupTimerLoop is like timerLoop, but waits for every Action in the
+// given group to finish.
+func groupTimerLoop(api APIClient, group string, wait, tick *time.Timer) (params.ActionGroupResult, error) {
+	for {
+		result, err := fetchGroupResult(api, group)
+		if err != nil {
+			return result, err
+		}
+
+		finished := true
+		for _, actionResult := range result.Actions {
+			switch actionResult.Status {
+			case params.ActionRunning, params.ActionPending:
+				finished = false
+			}
+		}
+		if finished {
+			return result, nil
+		}
+
+		// Block until a tick happens, or the timeout arrives.
+		select {
+		case _ = <-wait.C:
+			return result, nil
+
+		case _ = <-tick.C:
+			tick.Reset(2 * time.Second)
+		}
+	}
+}
+
+// fetchGroupResult queries the given API for the Actions in the given
+// group.
+func fetchGroupResult(api APIClient, group string) (params.ActionGroupResult, error) {
+	none := params.ActionGroupResult{}
+
+	results, err := api.ActionGroups(params.ActionGroups{Groups: []string{group}})
+	if err != nil {
+		return none, err
+	}
+	if len(results.Results) != 1 {
+		return none, errors.Errorf("expected 1 result for action group %s, got %d", group, len(results.Results))
+	}
+
+	result := results.Results[0]
+	if result.Error != nil {
+		return none, result.Error
+	}
+
+	return result, nil
+}
+
 // fetchResult queries the given API for the given Action ID prefix, and
 // makes sure the results are acceptable, returning an error if they are not.
 func fetchResult(api APIClient, requestedId string) (params.ActionResult, error) {
@@ -211,3 +286,37 @@ func formatActionResult(result params.ActionResult) map[string]interface{} {
 
 	return response
 }
+
+// formatActionGroupResult summarises the results of the Actions in a
+// group, keyed on the unit each ran on, for cmd.Output to write.
+func formatActionGroupResult(result params.ActionGroupResult) map[string]interface{} {
+	summary := make(map[string]int)
+	units := make(map[string]interface{})
+	for _, actionResult := range result.Actions {
+		if actionResult.Action == nil {
+			continue
+		}
+		unit := actionResult.Action.Receiver
+		if unitTag, err := names.ParseUnitTag(unit); err == nil {
+			unit = unitTag.Id()
+		}
+		if actionResult.Error != nil {
+			summary["error"]++
+			units[unit] = map[string]interface{}{"error": actionResult.Error.Error()}
+			continue
+		}
+		summary[actionResult.Status]++
+		response := formatActionResult(actionResult)
+		if actionTag, err := names.ParseActionTag(actionResult.Action.Tag); err == nil {
+			response["id"] = actionTag.Id()
+		}
+		// Timings are of little interest across many units.
+		delete(response, "timing")
+		units[unit] = response
+	}
+	return map[string]interface{}{
+		"group":   result.Group,
+		"summary": summary,
+		"units":   units,
+	}
+}
@@ -294,6 +294,45 @@ timing:
 	}
 }
 
+func (s *FetchSuite) TestRunGroup(c *gc.C) {
+	client := &fakeAPIClient{
+		actionGroupResults: []params.ActionGroupResult{{
+			Group: "some-group",
+			Actions: []params.ActionResult{{
+				Action:    &params.Action{Tag: validActionTagString, Receiver: "unit-mysql-0"},
+				Status:    params.ActionCompleted,
+				Output:    map[string]interface{}{"foo": "bar"},
+				Completed: time.Date(2015, time.February, 14, 8, 15, 30, 0, time.UTC),
+			}, {
+				Action:  &params.Action{Tag: validActionTagString, Receiver: "unit-mysql-1"},
+				Status:  params.ActionFailed,
+				Message: "oops",
+			}},
+		}},
+	}
+	unpatch := s.BaseActionSuite.patchAPIClient(client)
+	defer unpatch()
+
+	ctx, err := testing.RunCommand(c, action.NewFetchCommand(), "-m", "dummymodel", "--group", "some-group")
+	c.Assert(err, gc.IsNil)
+	c.Check(ctx.Stdout.(*bytes.Buffer).String(), gc.Equals, `
+group: some-group
+summary:
+  completed: 1
+  failed: 1
+units:
+  mysql/0:
+    id: `+validActionId+`
+    results:
+      foo: bar
+    status: completed
+  mysql/1:
+    id: `+validActionId+`
+    message: oops
+    status: failed
+`[1:])
+}
+
 func testRunHelper(c *gc.C, s *FetchSuite, client *fakeAPIClient, expectedErr, expectedOutput, wait, query, modelFlag string) {
 	unpatch := s.BaseActionSuite.patchAPIClient(client)
 	defer unpatch()
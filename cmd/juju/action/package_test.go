@@ -130,14 +130,17 @@ func setupValueFile(c *gc.C, dir, filename, value string) string {
 }
 
 type fakeAPIClient struct {
-	delay              *time.Timer
-	timeout            *time.Timer
-	actionResults      []params.ActionResult
-	enqueuedActions    params.Actions
-	actionsByReceivers []params.ActionsByReceiver
-	actionTagMatches   params.FindTagsResults
-	charmActions       *charm.Actions
-	apiErr             error
+	delay                  *time.Timer
+	timeout                *time.Timer
+	actionResults          []params.ActionResult
+	enqueuedActions        params.Actions
+	cancelledActions       params.Entities
+	enqueuedServiceActions params.ServiceActions
+	actionGroupResults     []params.ActionGroupResult
+	actionsByReceivers     []params.ActionsByReceiver
+	actionTagMatches       params.FindTagsResults
+	charmActions           *charm.Actions
+	apiErr                 error
 }
 
 var _ action.APIClient = (*fakeAPIClient)(nil)
@@ -157,6 +160,15 @@ func (c *fakeAPIClient) Enqueue(args params.Actions) (params.ActionResults, erro
 	return params.ActionResults{Results: c.actionResults}, c.apiErr
 }
 
+func (c *fakeAPIClient) EnqueueService(args params.ServiceActions) (params.ActionGroupResults, error) {
+	c.enqueuedServiceActions = args
+	return params.ActionGroupResults{Results: c.actionGroupResults}, c.apiErr
+}
+
+func (c *fakeAPIClient) ActionGroups(args params.ActionGroups) (params.ActionGroupResults, error) {
+	return params.ActionGroupResults{Results: c.actionGroupResults}, c.apiErr
+}
+
 func (c *fakeAPIClient) ListAll(args params.Entities) (params.ActionsByReceivers, error) {
 	return params.ActionsByReceivers{
 		Actions: c.actionsByReceivers,
@@ -175,7 +187,8 @@ func (c *fakeAPIClient) ListCompleted(args params.Entities) (params.ActionsByRec
 	}, c.apiErr
 }
 
-func (c *fakeAPIClient) Cancel(args params.Actions) (params.ActionResults, error) {
+func (c *fakeAPIClient) Cancel(args params.Entities) (params.ActionResults, error) {
+	c.cancelledActions = args
 	return params.ActionResults{
 		Results: c.actionResults,
 	}, c.apiErr
@@ -122,5 +122,8 @@ func resultToMap(result params.ActionResult) map[string]interface{} {
 
 	}
 	item["status"] = result.Status
+	if result.CancelRequested {
+		item["cancel-requested"] = true
+	}
 	return item
 }
@@ -6,7 +6,9 @@ package backups
 import (
 	"fmt"
 	"io"
+	"io/ioutil"
 	"os"
+	"strings"
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
@@ -50,6 +52,7 @@ func NewSuperCommand() cmd.Command {
 	backupsCmd.Register(newUploadCommand())
 	backupsCmd.Register(newRemoveCommand())
 	backupsCmd.Register(newRestoreCommand())
+	backupsCmd.Register(newVerifyCommand())
 	return backupsCmd
 }
 
@@ -57,8 +60,13 @@ func NewSuperCommand() cmd.Command {
 // the backups command.
 type APIClient interface {
 	io.Closer
-	// Create sends an RPC request to create a new backup.
-	Create(notes string) (*params.BackupsMetadataResult, error)
+	// Create sends an RPC request to create a new backup, encrypted
+	// with the key if one is given.
+	Create(notes, key string) (*params.BackupsMetadataResult, error)
+	// CreateIncremental sends an RPC request to create a new backup
+	// holding the changes made since the most recent backup, encrypted
+	// with the key if one is given.
+	CreateIncremental(notes, key string) (*params.BackupsMetadataResult, error)
 	// Info gets the backup's metadata.
 	Info(id string) (*params.BackupsMetadataResult, error)
 	// List gets all stored metadata.
@@ -69,10 +77,12 @@ type APIClient interface {
 	Upload(ar io.ReadSeeker, meta params.BackupsMetadataResult) (string, error)
 	// Remove removes the stored backup.
 	Remove(id string) error
+	// Verify checks the stored backup without restoring it.
+	Verify(id, key string) (*params.BackupsVerifyResult, error)
 	// Restore will restore a backup with the given id into the controller.
-	Restore(string, backups.ClientConnection) error
+	Restore(string, string, map[string]string, backups.ClientConnection) error
 	// RestoreReader will restore a backup file into the controller.
-	RestoreReader(io.ReadSeeker, *params.BackupsMetadataResult, backups.ClientConnection) error
+	RestoreReader(io.ReadSeeker, *params.BackupsMetadataResult, string, map[string]string, backups.ClientConnection) error
 }
 
 // CommandBase is the base type for backups sub-commands.
@@ -113,6 +123,15 @@ func (c *CommandBase) dumpMetadata(ctx *cmd.Context, result *params.BackupsMetad
 	fmt.Fprintf(ctx.Stdout, "started:         %v\n", result.Started)
 	fmt.Fprintf(ctx.Stdout, "finished:        %v\n", result.Finished)
 	fmt.Fprintf(ctx.Stdout, "notes:           %q\n", result.Notes)
+	if result.Parent != "" {
+		fmt.Fprintf(ctx.Stdout, "parent:          %q\n", result.Parent)
+	}
+	if result.Scheduled {
+		fmt.Fprintf(ctx.Stdout, "scheduled:       %v\n", result.Scheduled)
+	}
+	if result.Encrypted {
+		fmt.Fprintf(ctx.Stdout, "encrypted:       %v\n", result.Encrypted)
+	}
 
 	fmt.Fprintf(ctx.Stdout, "model ID:        %q\n", result.Model)
 	fmt.Fprintf(ctx.Stdout, "machine ID:      %q\n", result.Machine)
@@ -125,7 +144,24 @@ type readSeekCloser interface {
 	io.Closer
 }
 
-func getArchive(filename string) (rc readSeekCloser, metaResult *params.BackupsMetadataResult, err error) {
+// readKeyFile returns the key or passphrase held in the named file.
+// A trailing newline is not part of it.
+func readKeyFile(filename string) (string, error) {
+	data, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return "", errors.Annotate(err, "cannot read key file")
+	}
+	key := strings.TrimRight(string(data), "\r\n")
+	if key == "" {
+		return "", errors.Errorf("key file %q is empty", filename)
+	}
+	return key, nil
+}
+
+// getArchive opens the named backup archive and extracts its metadata.
+// The metadata of an encrypted archive can be read only if its key is
+// given; otherwise only the file's own details are known.
+func getArchive(filename, key string) (rc readSeekCloser, metaResult *params.BackupsMetadataResult, err error) {
 	defer func() {
 		if err != nil && rc != nil {
 			rc.Close()
@@ -138,24 +174,38 @@ func getArchive(filename string) (rc readSeekCloser, metaResult *params.BackupsM
 	}
 
 	// Extract the metadata.
-	ad, err := statebackups.NewArchiveDataReader(archive)
+	encrypted, content, err := statebackups.IsEncrypted(archive)
 	if err != nil {
 		return nil, nil, errors.Trace(err)
 	}
+	var meta *statebackups.Metadata
+	if !encrypted || key != "" {
+		if encrypted {
+			if content, err = statebackups.NewDecryptingReader(content, key); err != nil {
+				return nil, nil, errors.Trace(err)
+			}
+		}
+		ad, err := statebackups.NewArchiveDataReader(content)
+		if err != nil {
+			return nil, nil, errors.Trace(err)
+		}
+		meta, err = ad.Metadata()
+		if err != nil && !errors.IsNotFound(err) {
+			return nil, nil, errors.Trace(err)
+		}
+	}
 	_, err = archive.Seek(0, os.SEEK_SET)
 	if err != nil {
 		return nil, nil, errors.Trace(err)
 	}
-	meta, err := ad.Metadata()
-	if err != nil {
-		if !errors.IsNotFound(err) {
-			return nil, nil, errors.Trace(err)
-		}
+	if meta == nil {
 		meta, err = statebackups.BuildMetadata(archive)
 		if err != nil {
 			return nil, nil, errors.Trace(err)
 		}
 	}
+	meta.Encrypted = encrypted
+
 	// Make sure the file info is set.
 	fileMeta, err := statebackups.BuildMetadata(archive)
 	if err != nil {
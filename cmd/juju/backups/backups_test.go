@@ -19,6 +19,7 @@ var expectedSubCommmandNames = []string{
 	"remove",
 	"restore",
 	"upload",
+	"verify",
 }
 
 type backupsSuite struct {
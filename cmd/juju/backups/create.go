@@ -33,6 +33,14 @@ The --download option may be used without the --filename option.  In
 that case, the backup archive will be stored in the current working
 directory with a name matching juju-backup-<date>-<time>.tar.gz.
 
+The --incremental option creates a backup holding only the changes made
+since the most recent backup.  Restoring it also restores the backups
+it builds on, so those must still be available.
+
+The --key-file option encrypts the backup archive with the key or
+passphrase held in the given file.  The same key is needed to verify
+or restore the backup; if it is lost, so is the backup.
+
 WARNING: Remotely stored backups will be lost when the model is
 destroyed.  Furthermore, the remotely backup is not guaranteed to be
 available.
@@ -56,6 +64,12 @@ type createCommand struct {
 	Filename string
 	// Notes is the custom message to associated with the new backup.
 	Notes string
+	// Incremental means only the changes made since the most recent
+	// backup should be backed up.
+	Incremental bool
+	// KeyFile is the file holding the key with which the backup
+	// archive is encrypted.
+	KeyFile string
 }
 
 // Info implements Command.Info.
@@ -73,6 +87,8 @@ func (c *createCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.CommandBase.SetFlags(f)
 	f.BoolVar(&c.NoDownload, "no-download", false, "do not download the archive")
 	f.StringVar(&c.Filename, "filename", notset, "download to this file")
+	f.BoolVar(&c.Incremental, "incremental", false, "back up only the changes since the most recent backup")
+	f.StringVar(&c.KeyFile, "key-file", "", "encrypt the backup with the key in this file")
 }
 
 // Init implements Command.Init.
@@ -100,13 +116,24 @@ func (c *createCommand) Run(ctx *cmd.Context) error {
 			return err
 		}
 	}
+	var key string
+	if c.KeyFile != "" {
+		var err error
+		if key, err = readKeyFile(c.KeyFile); err != nil {
+			return errors.Trace(err)
+		}
+	}
 	client, err := c.NewAPIClient()
 	if err != nil {
 		return errors.Trace(err)
 	}
 	defer client.Close()
 
-	result, err := client.Create(c.Notes)
+	create := client.Create
+	if c.Incremental {
+		create = client.CreateIncremental
+	}
+	result, err := create(c.Notes, key)
 	if err != nil {
 		return errors.Trace(err)
 	}
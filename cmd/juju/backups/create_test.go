@@ -5,6 +5,8 @@ package backups_test
 
 import (
 	"bytes"
+	"io/ioutil"
+	"path/filepath"
 	"strings"
 
 	"github.com/juju/cmd"
@@ -77,6 +79,27 @@ func (s *createSuite) TestNoArgs(c *gc.C) {
 	client.Check(c, s.metaresult.ID, "", "Create", "Download")
 }
 
+func (s *createSuite) TestIncremental(c *gc.C) {
+	client := s.BaseBackupsSuite.setDownload()
+	_, err := testing.RunCommand(c, s.wrappedCommand, "--quiet", "--no-download", "--incremental", "hourly")
+	c.Assert(err, jc.ErrorIsNil)
+
+	client.Check(c, "", "hourly", "CreateIncremental")
+}
+
+func (s *createSuite) TestKeyFile(c *gc.C) {
+	client := s.BaseBackupsSuite.setDownload()
+	keyFile := filepath.Join(c.MkDir(), "key")
+	err := ioutil.WriteFile(keyFile, []byte("sekrit\n"), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = testing.RunCommand(c, s.wrappedCommand, "--quiet", "--no-download", "--key-file", keyFile)
+	c.Assert(err, jc.ErrorIsNil)
+
+	client.Check(c, "", "", "Create")
+	c.Check(client.key, gc.Equals, "sekrit")
+}
+
 func (s *createSuite) TestDefaultDownload(c *gc.C) {
 	s.setDownload()
 	ctx, err := testing.RunCommand(c, s.wrappedCommand, "--quiet", "--filename", s.defaultFilename)
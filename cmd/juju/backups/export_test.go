@@ -15,7 +15,8 @@ const (
 )
 
 var (
-	NewAPIClient = &newAPIClient
+	NewAPIClient     = &newAPIClient
+	ControllerCACert = &controllerCACert
 )
 
 type CreateCommand struct {
@@ -67,3 +68,9 @@ func NewRestoreCommand() cmd.Command {
 	c.Log = &cmd.Log{}
 	return modelcmd.Wrap(c)
 }
+
+func NewVerifyCommand() cmd.Command {
+	c := &verifyCommand{}
+	c.Log = &cmd.Log{}
+	return modelcmd.Wrap(c)
+}
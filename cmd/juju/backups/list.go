@@ -5,15 +5,20 @@ package backups
 
 import (
 	"fmt"
+	"sort"
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
 
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/modelcmd"
 )
 
 const listDoc = `
 "list" provides the metadata associated with all backups.
+
+Incremental backups are listed, indented, after the full backup
+at the root of their chain.
 `
 
 func newListCommand() cmd.Command {
@@ -67,18 +72,61 @@ func (c *listCommand) Run(ctx *cmd.Context) error {
 	}
 
 	verbose := c.Log != nil && c.Log.Verbose
-	if verbose {
-		c.dumpMetadata(ctx, &result.List[0])
-	} else {
-		fmt.Fprintln(ctx.Stdout, result.List[0].ID)
-	}
-	for _, resultItem := range result.List[1:] {
+	for i, resultItem := range orderChains(result.List) {
 		if verbose {
-			fmt.Fprintln(ctx.Stdout)
+			if i > 0 {
+				fmt.Fprintln(ctx.Stdout)
+			}
 			c.dumpMetadata(ctx, &resultItem)
+		} else if resultItem.Parent != "" {
+			fmt.Fprintln(ctx.Stdout, "  "+resultItem.ID)
 		} else {
 			fmt.Fprintln(ctx.Stdout, resultItem.ID)
 		}
 	}
 	return nil
 }
+
+// orderChains orders the backups by the time they started, except that
+// each full backup is followed by the incremental backups that build on
+// it, directly or not.
+func orderChains(list []params.BackupsMetadataResult) []params.BackupsMetadataResult {
+	sorted := make([]params.BackupsMetadataResult, len(list))
+	copy(sorted, list)
+	sort.Sort(byStarted(sorted))
+
+	byID := make(map[string]params.BackupsMetadataResult)
+	for _, item := range sorted {
+		byID[item.ID] = item
+	}
+	root := func(item params.BackupsMetadataResult) string {
+		for i := 0; item.Parent != "" && i < len(list); i++ {
+			parent, ok := byID[item.Parent]
+			if !ok {
+				break
+			}
+			item = parent
+		}
+		return item.ID
+	}
+	chains := make(map[string][]params.BackupsMetadataResult)
+	var roots []string
+	for _, item := range sorted {
+		id := root(item)
+		if id == item.ID {
+			roots = append(roots, id)
+		}
+		chains[id] = append(chains[id], item)
+	}
+	ordered := make([]params.BackupsMetadataResult, 0, len(list))
+	for _, id := range roots {
+		ordered = append(ordered, chains[id]...)
+	}
+	return ordered
+}
+
+type byStarted []params.BackupsMetadataResult
+
+func (b byStarted) Len() int           { return len(b) }
+func (b byStarted) Swap(i, j int)      { b[i], b[j] = b[j], b[i] }
+func (b byStarted) Less(i, j int) bool { return b[i].Started.Before(b[j].Started) }
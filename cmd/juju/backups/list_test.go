@@ -4,12 +4,15 @@
 package backups_test
 
 import (
+	"time"
+
 	"github.com/juju/cmd"
 	"github.com/juju/cmd/cmdtesting"
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/juju/backups"
 	"github.com/juju/juju/testing"
 )
@@ -48,6 +51,36 @@ func (s *listSuite) TestBrief(c *gc.C) {
 	s.checkStd(c, ctx, out, "")
 }
 
+func (s *listSuite) TestBriefChains(c *gc.C) {
+	client := s.setSuccess()
+	started := time.Date(2016, 4, 1, 3, 0, 0, 0, time.UTC)
+	backup := func(id, parent string, hours int) params.BackupsMetadataResult {
+		return params.BackupsMetadataResult{
+			ID:      id,
+			Parent:  parent,
+			Started: started.Add(time.Duration(hours) * time.Hour),
+		}
+	}
+	client.list = []params.BackupsMetadataResult{
+		backup("full-2", "", 24),
+		backup("inc-1b", "inc-1a", 2),
+		backup("full-1", "", 0),
+		backup("inc-2a", "full-2", 25),
+		backup("inc-1a", "full-1", 1),
+		backup("manual", "", 12),
+	}
+	ctx, err := testing.RunCommand(c, s.subcommand)
+	c.Assert(err, jc.ErrorIsNil)
+	out := "" +
+		"full-1\n" +
+		"  inc-1a\n" +
+		"  inc-1b\n" +
+		"manual\n" +
+		"full-2\n" +
+		"  inc-2a\n"
+	s.checkStd(c, ctx, out, "")
+}
+
 func (s *listSuite) TestError(c *gc.C) {
 	s.setFailure("failed!")
 	_, err := testing.RunCommand(c, s.subcommand)
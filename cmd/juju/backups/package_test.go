@@ -140,14 +140,17 @@ func (s *BaseBackupsSuite) checkStd(c *gc.C, ctx *cmd.Context, out, err string)
 }
 
 type fakeAPIClient struct {
-	metaresult *params.BackupsMetadataResult
-	archive    io.ReadCloser
-	err        error
+	metaresult   *params.BackupsMetadataResult
+	list         []params.BackupsMetadataResult
+	verifyresult *params.BackupsVerifyResult
+	archive      io.ReadCloser
+	err          error
 
 	calls []string
 	args  []string
 	idArg string
 	notes string
+	key   string
 }
 
 func (f *fakeAPIClient) Check(c *gc.C, id, notes string, calls ...string) {
@@ -156,10 +159,22 @@ func (f *fakeAPIClient) Check(c *gc.C, id, notes string, calls ...string) {
 	c.Check(f.notes, gc.Equals, notes)
 }
 
-func (c *fakeAPIClient) Create(notes string) (*params.BackupsMetadataResult, error) {
+func (c *fakeAPIClient) Create(notes, key string) (*params.BackupsMetadataResult, error) {
 	c.calls = append(c.calls, "Create")
-	c.args = append(c.args, "notes")
+	c.args = append(c.args, "notes", "key")
+	c.notes = notes
+	c.key = key
+	if c.err != nil {
+		return nil, c.err
+	}
+	return c.metaresult, nil
+}
+
+func (c *fakeAPIClient) CreateIncremental(notes, key string) (*params.BackupsMetadataResult, error) {
+	c.calls = append(c.calls, "CreateIncremental")
+	c.args = append(c.args, "notes", "key")
 	c.notes = notes
+	c.key = key
 	if c.err != nil {
 		return nil, c.err
 	}
@@ -182,7 +197,11 @@ func (c *fakeAPIClient) List() (*params.BackupsListResult, error) {
 		return nil, c.err
 	}
 	var result params.BackupsListResult
-	result.List = []params.BackupsMetadataResult{*c.metaresult}
+	if c.list != nil {
+		result.List = c.list
+	} else {
+		result.List = []params.BackupsMetadataResult{*c.metaresult}
+	}
 	return &result, nil
 }
 
@@ -214,14 +233,25 @@ func (c *fakeAPIClient) Remove(id string) error {
 	return nil
 }
 
+func (c *fakeAPIClient) Verify(id, key string) (*params.BackupsVerifyResult, error) {
+	c.calls = append(c.calls, "Verify")
+	c.args = append(c.args, "id", "key")
+	c.idArg = id
+	c.key = key
+	if c.err != nil {
+		return nil, c.err
+	}
+	return c.verifyresult, nil
+}
+
 func (c *fakeAPIClient) Close() error {
 	return nil
 }
 
-func (c *fakeAPIClient) RestoreReader(io.ReadSeeker, *params.BackupsMetadataResult, apibackups.ClientConnection) error {
+func (c *fakeAPIClient) RestoreReader(io.ReadSeeker, *params.BackupsMetadataResult, string, map[string]string, apibackups.ClientConnection) error {
 	return nil
 }
 
-func (c *fakeAPIClient) Restore(string, apibackups.ClientConnection) error {
+func (c *fakeAPIClient) Restore(string, string, map[string]string, apibackups.ClientConnection) error {
 	return nil
 }
@@ -6,6 +6,7 @@ package backups
 import (
 	"fmt"
 	"path/filepath"
+	"sort"
 	"strings"
 
 	"github.com/juju/cmd"
@@ -33,6 +34,8 @@ type restoreCommand struct {
 	backupId    string
 	bootstrap   bool
 	uploadTools bool
+	keyFile     string
+	chainKeys   chainKeyFiles
 }
 
 var restoreDoc = `
@@ -51,6 +54,12 @@ If the provided state cannot be restored, this command will fail with
 an appropriate message.  For instance, if the existing bootstrap
 instance is already running then the command will fail with a message
 to that effect.
+
+An encrypted backup can only be restored if the file holding its key
+or passphrase is given with --key-file.  An incremental backup is
+restored together with the earlier backups in its chain; those that
+were encrypted with a different key need their key files given with
+--chain-key-file <backup-id>=<filename>, once for each backup.
 `
 
 // Info returns the content for --help.
@@ -73,6 +82,8 @@ func (c *restoreCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.StringVar(&c.filename, "file", "", "provide a file to be used as the backup.")
 	f.StringVar(&c.backupId, "id", "", "provide the name of the backup to be restored.")
 	f.BoolVar(&c.uploadTools, "upload-tools", false, "upload tools if bootstraping a new machine.")
+	f.StringVar(&c.keyFile, "key-file", "", "read the key of an encrypted backup from this file.")
+	f.Var(&c.chainKeys, "chain-key-file", "read the key of an earlier backup in the chain from a file, as <backup-id>=<filename>.")
 }
 
 // Init is where the preconditions for this commands can be checked.
@@ -99,6 +110,24 @@ func (c *restoreCommand) Init(args []string) error {
 // runRestore will implement the actual calls to the different Client parts
 // of restore.
 func (c *restoreCommand) runRestore(ctx *cmd.Context) error {
+	var key string
+	if c.keyFile != "" {
+		var err error
+		if key, err = readKeyFile(c.keyFile); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	var chainKeys map[string]string
+	for id, filename := range c.chainKeys {
+		chainKey, err := readKeyFile(filename)
+		if err != nil {
+			return errors.Annotatef(err, "backup %q", id)
+		}
+		if chainKeys == nil {
+			chainKeys = make(map[string]string)
+		}
+		chainKeys[id] = chainKey
+	}
 	client, closer, err := c.newClient()
 	if err != nil {
 		return errors.Trace(err)
@@ -108,16 +137,19 @@ func (c *restoreCommand) runRestore(ctx *cmd.Context) error {
 	var rErr error
 	if c.filename != "" {
 		target = c.filename
-		archive, meta, err := getArchive(c.filename)
+		archive, meta, err := getArchive(c.filename, key)
 		if err != nil {
 			return errors.Trace(err)
 		}
 		defer archive.Close()
 
-		rErr = client.RestoreReader(archive, meta, c.newClient)
+		if meta.Encrypted && key == "" {
+			return errors.Errorf("backup %q is encrypted; use --key-file to give its key", c.filename)
+		}
+		rErr = client.RestoreReader(archive, meta, key, chainKeys, c.newClient)
 	} else {
 		target = c.backupId
-		rErr = client.Restore(c.backupId, c.newClient)
+		rErr = client.Restore(c.backupId, key, chainKeys, c.newClient)
 	}
 	if rErr != nil {
 		return errors.Trace(rErr)
@@ -199,3 +231,35 @@ func (c *restoreCommand) Run(ctx *cmd.Context) error {
 	}
 	return c.runRestore(ctx)
 }
+
+// chainKeyFiles holds the names of the files holding the keys of the
+// earlier backups in a chain, by backup ID. It implements gnuflag.Value,
+// taking a <backup-id>=<filename> pair each time the flag is given.
+type chainKeyFiles map[string]string
+
+// Set implements gnuflag.Value.
+func (f *chainKeyFiles) Set(s string) error {
+	parts := strings.SplitN(s, "=", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return errors.Errorf("expected <backup-id>=<filename>, got %q", s)
+	}
+	if *f == nil {
+		*f = make(chainKeyFiles)
+	}
+	id, filename := parts[0], parts[1]
+	if _, ok := (*f)[id]; ok {
+		return errors.Errorf("key file for backup %q given more than once", id)
+	}
+	(*f)[id] = filename
+	return nil
+}
+
+// String implements gnuflag.Value.
+func (f *chainKeyFiles) String() string {
+	var pairs []string
+	for id, filename := range *f {
+		pairs = append(pairs, id+"="+filename)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
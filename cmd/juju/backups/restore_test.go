@@ -33,3 +33,12 @@ func (s *restoreSuite) TestRestoreArgs(c *gc.C) {
 	_, err = testing.RunCommand(c, s.command, "restore", "--id", "anid", "-b")
 	c.Assert(err, gc.ErrorMatches, "it is not possible to rebootstrap and restore from an id.")
 }
+
+func (s *restoreSuite) TestRestoreChainKeyFileArgs(c *gc.C) {
+	_, err := testing.RunCommand(c, s.command, "restore", "--id", "anid", "--chain-key-file", "afile")
+	c.Assert(err, gc.ErrorMatches, `.*expected <backup-id>=<filename>, got "afile"`)
+
+	_, err = testing.RunCommand(c, s.command, "restore", "--id", "anid",
+		"--chain-key-file", "full=afile", "--chain-key-file", "full=another")
+	c.Assert(err, gc.ErrorMatches, `.*key file for backup "full" given more than once`)
+}
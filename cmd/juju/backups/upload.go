@@ -64,7 +64,7 @@ func (c *uploadCommand) Run(ctx *cmd.Context) error {
 	}
 	defer client.Close()
 
-	archive, meta, err := getArchive(c.Filename)
+	archive, meta, err := getArchive(c.Filename, "")
 	if err != nil {
 		return errors.Trace(err)
 	}
@@ -0,0 +1,161 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"launchpad.net/gnuflag"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+	statebackups "github.com/juju/juju/state/backups"
+)
+
+const verifyDoc = `
+"verify" checks that a backup is whole and unmodified, and that it
+could be restored, without restoring it.  The backup may be given by
+the ID of a backup stored by juju, or by the name of a backup archive
+file.
+
+The archive is unpacked, its files are checked against the hashes in
+its manifest, the signature of the manifest is checked, and every
+document in its database dump is read.  Only encrypted backups are
+signed: the key they are signed with is held in the backup itself, so
+the signature of an unencrypted backup could be forged by anyone
+holding it.  A stored backup is checked by
+the controller; a file is checked locally, against the CA certificate
+of the current controller.
+
+Encrypted backups can only be verified if the file holding their key
+or passphrase is given with --key-file.
+`
+
+func newVerifyCommand() cmd.Command {
+	return modelcmd.Wrap(&verifyCommand{})
+}
+
+// verifyCommand is the sub-command for verifying a backup.
+type verifyCommand struct {
+	CommandBase
+	// Target is the ID of the backup, or the name of its file.
+	Target string
+	// KeyFile is the file holding the key of an encrypted backup.
+	KeyFile string
+}
+
+// Info implements Command.Info.
+func (c *verifyCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "verify",
+		Args:    "<ID>|<filename>",
+		Purpose: "check a backup without restoring it",
+		Doc:     verifyDoc,
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *verifyCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.CommandBase.SetFlags(f)
+	f.StringVar(&c.KeyFile, "key-file", "", "read the key of an encrypted backup from this file")
+}
+
+// Init implements Command.Init.
+func (c *verifyCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("missing ID or filename")
+	}
+	target, args := args[0], args[1:]
+	if err := cmd.CheckEmpty(args); err != nil {
+		return errors.Trace(err)
+	}
+	c.Target = target
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *verifyCommand) Run(ctx *cmd.Context) error {
+	if c.Log != nil {
+		if err := c.Log.Start(ctx); err != nil {
+			return err
+		}
+	}
+	var key string
+	if c.KeyFile != "" {
+		var err error
+		if key, err = readKeyFile(c.KeyFile); err != nil {
+			return errors.Trace(err)
+		}
+	}
+
+	var result *params.BackupsVerifyResult
+	var err error
+	if _, statErr := os.Stat(ctx.AbsPath(c.Target)); statErr == nil {
+		result, err = c.verifyFile(ctx.AbsPath(c.Target), key)
+	} else {
+		result, err = c.verifyStored(c.Target, key)
+	}
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	signature := "not checked"
+	if result.Signed {
+		signature = "valid"
+	}
+	fmt.Fprintf(ctx.Stdout, "verified:  %s\n", c.Target)
+	fmt.Fprintf(ctx.Stdout, "encrypted: %v\n", result.Encrypted)
+	fmt.Fprintf(ctx.Stdout, "signature: %s\n", signature)
+	fmt.Fprintf(ctx.Stdout, "files:     %d\n", result.Files)
+	fmt.Fprintf(ctx.Stdout, "documents: %d\n", result.Documents)
+	return nil
+}
+
+func (c *verifyCommand) verifyStored(id, key string) (*params.BackupsVerifyResult, error) {
+	client, err := c.NewAPIClient()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer client.Close()
+
+	result, err := client.Verify(id, key)
+	return result, errors.Trace(err)
+}
+
+func (c *verifyCommand) verifyFile(filename, key string) (*params.BackupsVerifyResult, error) {
+	caCert, err := controllerCACert(&c.CommandBase)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot get the controller's CA certificate")
+	}
+	archive, err := os.Open(filename)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer archive.Close()
+
+	result, err := statebackups.Verify(archive, statebackups.VerifyArgs{
+		Secret: key,
+		CACert: caCert,
+	})
+	if err != nil {
+		return nil, errors.Annotatef(err, "backup %q failed verification", filename)
+	}
+	return &params.BackupsVerifyResult{
+		Encrypted: result.Encrypted,
+		Signed:    result.Signed,
+		Files:     result.Files,
+		Documents: result.Documents,
+	}, nil
+}
+
+var controllerCACert = func(c *CommandBase) (string, error) {
+	endpoint, err := c.ConnectionEndpoint(false)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return endpoint.CACert, nil
+}
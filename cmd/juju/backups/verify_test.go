@@ -0,0 +1,90 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/backups"
+	"github.com/juju/juju/testing"
+)
+
+type verifySuite struct {
+	BaseBackupsSuite
+	command cmd.Command
+}
+
+var _ = gc.Suite(&verifySuite{})
+
+func (s *verifySuite) SetUpTest(c *gc.C) {
+	s.BaseBackupsSuite.SetUpTest(c)
+	s.command = backups.NewVerifyCommand()
+	s.PatchValue(backups.ControllerCACert, func(*backups.CommandBase) (string, error) {
+		return testing.CACert, nil
+	})
+}
+
+func (s *verifySuite) TestHelp(c *gc.C) {
+	s.checkHelp(c, s.command)
+}
+
+func (s *verifySuite) TestStored(c *gc.C) {
+	client := s.setSuccess()
+	client.verifyresult = &params.BackupsVerifyResult{
+		Encrypted: true,
+		Signed:    true,
+		Files:     3,
+		Documents: 1234,
+	}
+	keyFile := filepath.Join(c.MkDir(), "key")
+	err := ioutil.WriteFile(keyFile, []byte("sekrit\n"), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+
+	ctx, err := testing.RunCommand(c, s.command, "spam", "--key-file", keyFile)
+	c.Assert(err, jc.ErrorIsNil)
+
+	client.Check(c, "spam", "", "Verify")
+	c.Check(client.key, gc.Equals, "sekrit")
+	out := "" +
+		"verified:  spam\n" +
+		"encrypted: true\n" +
+		"signature: valid\n" +
+		"files:     3\n" +
+		"documents: 1234\n"
+	s.checkStd(c, ctx, out, "")
+}
+
+func (s *verifySuite) TestFile(c *gc.C) {
+	client := s.setSuccess()
+	filename := filepath.Join(c.MkDir(), "juju-backup.tar.gz")
+	err := ioutil.WriteFile(filename, []byte("not an archive"), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = testing.RunCommand(c, s.command, filename)
+	c.Check(err, gc.ErrorMatches, `backup ".*juju-backup.tar.gz" failed verification: cannot unpack archive: .*`)
+	c.Check(client.calls, gc.HasLen, 0)
+}
+
+func (s *verifySuite) TestEmptyKeyFile(c *gc.C) {
+	s.setSuccess()
+	keyFile := filepath.Join(c.MkDir(), "key")
+	err := ioutil.WriteFile(keyFile, nil, 0600)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = testing.RunCommand(c, s.command, "spam", "--key-file", keyFile)
+	c.Check(err, gc.ErrorMatches, `key file ".*key" is empty`)
+}
+
+func (s *verifySuite) TestError(c *gc.C) {
+	s.setFailure("failed!")
+	_, err := testing.RunCommand(c, s.command, "spam")
+	c.Check(errors.Cause(err), gc.ErrorMatches, "failed!")
+}
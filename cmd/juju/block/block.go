@@ -4,10 +4,13 @@
 package block
 
 import (
+	"time"
+
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
 	"launchpad.net/gnuflag"
 
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/modelcmd"
 )
 
@@ -16,8 +19,19 @@ import (
 type BaseBlockCommand struct {
 	modelcmd.ModelCommandBase
 	desc string
+
+	expiresArg  string
+	schedule    string
+	duration    time.Duration
+	timezone    string
+	exemptUsers []string
+
+	expires *time.Time
 }
 
+// blockTimeNow is used to resolve expiry durations; it is replaced in tests.
+var blockTimeNow = time.Now
+
 // Init initializes the command.
 // Satisfying Command interface.
 func (c *BaseBlockCommand) Init(args []string) error {
@@ -28,9 +42,39 @@ func (c *BaseBlockCommand) Init(args []string) error {
 	if len(args) == 1 {
 		c.desc = args[0]
 	}
+	if c.expiresArg != "" {
+		expires, err := parseExpiry(c.expiresArg, blockTimeNow())
+		if err != nil {
+			return errors.Trace(err)
+		}
+		c.expires = &expires
+	}
+	if c.schedule == "" {
+		if c.duration != 0 || c.timezone != "" {
+			return errors.New("--duration and --timezone need a --schedule")
+		}
+	} else if c.duration <= 0 {
+		return errors.New("a --schedule needs a positive --duration")
+	}
 	return nil
 }
 
+// parseExpiry parses the value of the --expires flag, which is either
+// a duration from now or an RFC3339 time.
+func parseExpiry(value string, now time.Time) (time.Time, error) {
+	if d, err := time.ParseDuration(value); err == nil {
+		if d <= 0 {
+			return time.Time{}, errors.Errorf("expiry duration %v not valid", d)
+		}
+		return now.Add(d).UTC(), nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, errors.Errorf("expiry %q is neither a duration nor an RFC3339 time", value)
+	}
+	return t.UTC(), nil
+}
+
 // internalRun blocks commands from running successfully.
 func (c *BaseBlockCommand) internalRun(operation string) error {
 	client, err := getBlockClientAPI(c)
@@ -39,24 +83,56 @@ func (c *BaseBlockCommand) internalRun(operation string) error {
 	}
 	defer client.Close()
 
-	return client.SwitchBlockOn(TypeFromOperation(operation), c.desc)
+	args := params.BlockSwitchParams{
+		Type:        TypeFromOperation(operation),
+		Message:     c.desc,
+		Expires:     c.expires,
+		ExemptUsers: c.exemptUsers,
+	}
+	if c.schedule != "" {
+		args.Schedule = &params.BlockSchedule{
+			Start:    c.schedule,
+			Duration: c.duration,
+			Location: c.timezone,
+		}
+	}
+	return client.SwitchBlockOnWithParams(args)
 }
 
 // SetFlags implements Command.SetFlags.
 func (c *BaseBlockCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.ModelCommandBase.SetFlags(f)
+	f.StringVar(&c.expiresArg, "expires", "", "lift the block after this duration, or at this RFC3339 time")
+	f.StringVar(&c.schedule, "schedule", "", "only apply the block in windows starting at the times in this cron spec")
+	f.DurationVar(&c.duration, "duration", 0, "how long each scheduled window lasts")
+	f.StringVar(&c.timezone, "timezone", "", "the time zone in which the schedule is evaluated (default UTC)")
+	f.Var(cmd.NewAppendStringsValue(&c.exemptUsers), "exempt", "do not apply the block to this user")
 }
 
 // BlockClientAPI defines the client API methods that block command uses.
 type BlockClientAPI interface {
 	Close() error
-	SwitchBlockOn(blockType, msg string) error
+	SwitchBlockOnWithParams(args params.BlockSwitchParams) error
 }
 
 var getBlockClientAPI = func(p *BaseBlockCommand) (BlockClientAPI, error) {
 	return getBlockAPI(&p.ModelCommandBase)
 }
 
+const blockOptionsDoc = `
+A block applies until it is lifted unless --expires is given, after
+which it is lifted by the controller. The expiry is either a duration
+from now, such as 2h, or an RFC3339 time.
+
+With --schedule, the block only applies in recurring windows, each
+--duration long, that start at the times matched by the given
+five-field cron spec. The spec is evaluated in UTC unless --timezone
+names another location.
+
+The block does not apply to users given with --exempt, which may be
+repeated.
+`
+
 func newDestroyCommand() cmd.Command {
 	return modelcmd.Wrap(&destroyCommand{})
 }
@@ -74,11 +150,14 @@ To disable the block, run unblock command - see "juju help unblock".
 To by-pass the block, run destroy-model with --force option.
 
 "juju block destroy-model" only blocks destroy-model command.
-   
+` + blockOptionsDoc + `
 Examples:
    To prevent the model from being destroyed:
    juju block destroy-model
 
+   To prevent the model from being destroyed for the next week:
+   juju block destroy-model --expires 168h
+
 `
 
 // Info provides information about command.
@@ -119,7 +198,7 @@ To by-pass the block, where available, run desired remove command with --force o
     remove-relation
     remove-service
     remove-unit
-   
+` + blockOptionsDoc + `
 Examples:
    To prevent the machines, services, units and relations from being removed:
    juju block remove-object
@@ -189,11 +268,16 @@ To by-pass the block, where available, run desired remove command with --force o
     change-user-password
     disable-user
     enable-user
-   
+` + blockOptionsDoc + `
 Examples:
    To prevent changes to the model:
    juju block all-changes
 
+   To prevent changes during working hours on weekdays, New York time,
+   except by the user on call:
+   juju block all-changes --schedule "0 9 * * 1-5" --duration 8h \
+       --timezone America/New_York --exempt ops-oncall
+
 `
 
 // Info provides information about command.
@@ -4,7 +4,9 @@
 package block_test
 
 import (
+	"regexp"
 	"strings"
+	"time"
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
@@ -12,6 +14,7 @@ import (
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/juju/block"
 	"github.com/juju/juju/testing"
 )
@@ -73,6 +76,66 @@ func (s *BlockCommandSuite) TestBlockChangeOperations(c *gc.C) {
 	s.assertBlock(c, command.Info().Name, "TestBlockChangeOperations")
 }
 
+func (s *BlockCommandSuite) TestBlockWithSchedule(c *gc.C) {
+	command := block.NewChangeCommand()
+	_, err := testing.RunCommand(c, command, "working hours",
+		"--schedule", "0 9 * * 1-5", "--duration", "8h",
+		"--timezone", "America/New_York",
+		"--exempt", "ops-oncall", "--exempt", "bob",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertBlock(c, command.Info().Name, "working hours")
+	c.Assert(s.mockClient.Args.Schedule, jc.DeepEquals, &params.BlockSchedule{
+		Start:    "0 9 * * 1-5",
+		Duration: 8 * time.Hour,
+		Location: "America/New_York",
+	})
+	c.Assert(s.mockClient.Args.ExemptUsers, jc.DeepEquals, []string{"ops-oncall", "bob"})
+	c.Assert(s.mockClient.Args.Expires, gc.IsNil)
+}
+
+func (s *BlockCommandSuite) TestBlockExpiresDuration(c *gc.C) {
+	now := time.Date(2016, 3, 1, 12, 0, 0, 0, time.UTC)
+	s.PatchValue(block.BlockTimeNow, func() time.Time { return now })
+	_, err := testing.RunCommand(c, block.NewDestroyCommand(), "--expires", "2h")
+	c.Assert(err, jc.ErrorIsNil)
+	expires := now.Add(2 * time.Hour)
+	c.Assert(s.mockClient.Args.Expires, jc.DeepEquals, &expires)
+}
+
+func (s *BlockCommandSuite) TestBlockExpiresTime(c *gc.C) {
+	_, err := testing.RunCommand(c, block.NewDestroyCommand(), "--expires", "2016-03-01T17:00:00-05:00")
+	c.Assert(err, jc.ErrorIsNil)
+	expires := time.Date(2016, 3, 1, 22, 0, 0, 0, time.UTC)
+	c.Assert(s.mockClient.Args.Expires, jc.DeepEquals, &expires)
+}
+
+func (s *BlockCommandSuite) TestBlockInvalidFlags(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: []string{"--expires", "tomorrow"},
+		err:  `expiry "tomorrow" is neither a duration nor an RFC3339 time`,
+	}, {
+		args: []string{"--expires", "-1h"},
+		err:  `expiry duration -1h0m0s not valid`,
+	}, {
+		args: []string{"--schedule", "0 9 * * *"},
+		err:  `a --schedule needs a positive --duration`,
+	}, {
+		args: []string{"--duration", "1h"},
+		err:  `--duration and --timezone need a --schedule`,
+	}, {
+		args: []string{"--timezone", "UTC"},
+		err:  `--duration and --timezone need a --schedule`,
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		_, err := testing.RunCommand(c, block.NewChangeCommand(), test.args...)
+		c.Check(err, gc.ErrorMatches, regexp.QuoteMeta(test.err))
+	}
+}
+
 func (s *BlockCommandSuite) processErrorTest(c *gc.C, tstError error, blockType block.Block, expectedError error, expectedWarning string) {
 	if tstError != nil {
 		c.Assert(errors.Cause(block.ProcessBlockedError(tstError, blockType)), gc.Equals, expectedError)
@@ -19,11 +19,14 @@ var (
 	NewRemoveCommand  = newRemoveCommand
 	NewChangeCommand  = newChangeCommand
 	NewListCommand    = newListCommand
+
+	BlockTimeNow = &blockTimeNow
 )
 
 type MockBlockClient struct {
 	BlockType string
 	Msg       string
+	Args      params.BlockSwitchParams
 }
 
 func (c *MockBlockClient) Close() error {
@@ -31,14 +34,23 @@ func (c *MockBlockClient) Close() error {
 }
 
 func (c *MockBlockClient) SwitchBlockOn(blockType, msg string) error {
-	c.BlockType = blockType
-	c.Msg = msg
+	return c.SwitchBlockOnWithParams(params.BlockSwitchParams{
+		Type:    blockType,
+		Message: msg,
+	})
+}
+
+func (c *MockBlockClient) SwitchBlockOnWithParams(args params.BlockSwitchParams) error {
+	c.BlockType = args.Type
+	c.Msg = args.Message
+	c.Args = args
 	return nil
 }
 
 func (c *MockBlockClient) SwitchBlockOff(blockType string) error {
 	c.BlockType = blockType
 	c.Msg = ""
+	c.Args = params.BlockSwitchParams{}
 	return nil
 }
 
@@ -49,8 +61,11 @@ func (c *MockBlockClient) List() ([]params.Block, error) {
 
 	return []params.Block{
 		params.Block{
-			Type:    c.BlockType,
-			Message: c.Msg,
+			Type:        c.BlockType,
+			Message:     c.Msg,
+			Expires:     c.Args.Expires,
+			Schedule:    c.Args.Schedule,
+			ExemptUsers: c.Args.ExemptUsers,
 		},
 	}, nil
 }
@@ -6,7 +6,9 @@ package block
 import (
 	"bytes"
 	"fmt"
+	"strings"
 	"text/tabwriter"
+	"time"
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
@@ -23,7 +25,9 @@ func newListCommand() cmd.Command {
 const listCommandDoc = `
 List blocks for Juju model.
 This command shows if each block type is enabled. 
-For enabled blocks, block message is shown if it was specified.
+For enabled blocks, block message is shown if it was specified,
+as are when the block expires, the windows in which it applies,
+and the users to whom it does not apply.
 `
 
 // listCommand list blocks.
@@ -86,6 +90,18 @@ type BlockInfo struct {
 	Operation string  `yaml:"block" json:"block"`
 	Enabled   bool    `yaml:"enabled" json:"enabled"`
 	Message   *string `yaml:"message,omitempty" json:"message,omitempty"`
+
+	Expires     string             `yaml:"expires,omitempty" json:"expires,omitempty"`
+	Schedule    *BlockScheduleInfo `yaml:"schedule,omitempty" json:"schedule,omitempty"`
+	ExemptUsers []string           `yaml:"exempt-users,omitempty" json:"exempt-users,omitempty"`
+}
+
+// BlockScheduleInfo defines the serialization behaviour of the
+// recurring windows in which a block applies.
+type BlockScheduleInfo struct {
+	Start    string `yaml:"start" json:"start"`
+	Duration string `yaml:"duration" json:"duration"`
+	Timezone string `yaml:"timezone,omitempty" json:"timezone,omitempty"`
 }
 
 // formatBlockInfo takes a set of Block and creates a
@@ -100,8 +116,19 @@ func formatBlockInfo(all []params.Block) []BlockInfo {
 		bi := BlockInfo{
 			Operation: op,
 			// If client returned it, it means that it is enabled
-			Enabled: true,
-			Message: &one.Message,
+			Enabled:     true,
+			Message:     &one.Message,
+			ExemptUsers: one.ExemptUsers,
+		}
+		if one.Expires != nil {
+			bi.Expires = one.Expires.UTC().Format(time.RFC3339)
+		}
+		if one.Schedule != nil {
+			bi.Schedule = &BlockScheduleInfo{
+				Start:    one.Schedule.Start,
+				Duration: one.Schedule.Duration.String(),
+				Timezone: one.Schedule.Location,
+			}
 		}
 		info[op] = bi
 	}
@@ -136,9 +163,21 @@ func formatBlocks(value interface{}) ([]byte, error) {
 		fmt.Fprintf(tw, "%v\t", ablock.Operation)
 		if ablock.Message != nil {
 			fmt.Fprintf(tw, "\t=%v, %v", switched, *ablock.Message)
-			continue
+		} else {
+			fmt.Fprintf(tw, "\t=%v", switched)
+		}
+		if ablock.Schedule != nil {
+			fmt.Fprintf(tw, ", in %v windows from %q", ablock.Schedule.Duration, ablock.Schedule.Start)
+			if ablock.Schedule.Timezone != "" {
+				fmt.Fprintf(tw, " (%v)", ablock.Schedule.Timezone)
+			}
+		}
+		if ablock.Expires != "" {
+			fmt.Fprintf(tw, ", expires %v", ablock.Expires)
+		}
+		if len(ablock.ExemptUsers) > 0 {
+			fmt.Fprintf(tw, ", except %v", strings.Join(ablock.ExemptUsers, ","))
 		}
-		fmt.Fprintf(tw, "\t=%v", switched)
 	}
 
 	tw.Flush()
@@ -4,9 +4,12 @@
 package block_test
 
 import (
+	"time"
+
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/juju/block"
 	"github.com/juju/juju/cmd/modelcmd"
 	"github.com/juju/juju/state/multiwatcher"
@@ -71,3 +74,51 @@ func (s *listCommandSuite) TestListJson(c *gc.C) {
 	c.Assert(testing.Stdout(ctx), gc.Equals, `[{"block":"destroy-model","enabled":false},{"block":"remove-object","enabled":true,"message":"Test this one"},{"block":"all-changes","enabled":false}]
 `)
 }
+
+func (s *listCommandSuite) switchScheduledBlockOn() {
+	expires := time.Date(2016, 3, 1, 12, 0, 0, 0, time.UTC)
+	s.mockClient.SwitchBlockOnWithParams(params.BlockSwitchParams{
+		Type:    string(multiwatcher.BlockChange),
+		Message: "working hours",
+		Expires: &expires,
+		Schedule: &params.BlockSchedule{
+			Start:    "0 9 * * 1-5",
+			Duration: 8 * time.Hour,
+			Location: "America/New_York",
+		},
+		ExemptUsers: []string{"ops-oncall@local"},
+	})
+}
+
+func (s *listCommandSuite) TestListScheduled(c *gc.C) {
+	s.switchScheduledBlockOn()
+	ctx, err := testing.RunCommand(c, block.NewListCommand())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), gc.Equals, `
+destroy-model  =off
+remove-object  =off
+all-changes    =on, working hours, in 8h0m0s windows from "0 9 * * 1-5" (America/New_York), expires 2016-03-01T12:00:00Z, except ops-oncall@local
+`)
+}
+
+func (s *listCommandSuite) TestListScheduledYaml(c *gc.C) {
+	s.switchScheduledBlockOn()
+	ctx, err := testing.RunCommand(c, block.NewListCommand(), "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), gc.Equals, `
+- block: destroy-model
+  enabled: false
+- block: remove-object
+  enabled: false
+- block: all-changes
+  enabled: true
+  message: working hours
+  expires: 2016-03-01T12:00:00Z
+  schedule:
+    start: 0 9 * * 1-5
+    duration: 8h0m0s
+    timezone: America/New_York
+  exempt-users:
+  - ops-oncall@local
+`[1:])
+}
@@ -6,6 +6,9 @@ package commands
 import (
 	"fmt"
 	"io"
+	"regexp"
+	"strings"
+	"time"
 
 	"github.com/juju/cmd"
 	"github.com/juju/loggo"
@@ -23,6 +26,9 @@ type debugLogCommand struct {
 	modelcmd.ModelCommandBase
 
 	level  string
+	fields []string
+	since  string
+	until  string
 	params api.DebugLogParams
 }
 
@@ -33,6 +39,28 @@ const defaultLineCount = 10
 const debuglogDoc = `
 Stream the consolidated debug log file. This file contains the log messages
 from all nodes in the model.
+
+Log messages may carry fields describing where they came from, such as the
+hook being run. Use --field to only show messages with a given field value;
+if a field is given more than once, any of its values may match.
+
+Messages may also be searched for on the controller, which avoids sending
+unwanted messages to the client: --grep and --exclude-grep take regular
+expressions that messages must and must not match. Use --since and --until
+to restrict messages to a time window, given in RFC3339 format; once the
+--until time has passed, no new messages are waited for.
+
+If log archival is enabled for the controller (log-archive=true), messages
+are archived before they are pruned from the database. Use --from-archive to
+read archived messages instead of the live log; all the other filtering
+options apply as usual, and the command exits once the archive has been read.
+
+Examples:
+    juju debug-log --field hook=config-changed
+    juju debug-log --field hook=install --field hook=start
+    juju debug-log --grep "hook failed" --exclude-grep "will retry"
+    juju debug-log --since 2016-04-01T12:00:00Z --until 2016-04-01T13:00:00Z
+    juju debug-log --from-archive --replay --since 2016-03-01T00:00:00Z
 `
 
 func (c *debugLogCommand) Info() *cmd.Info {
@@ -50,6 +78,11 @@ func (c *debugLogCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.Var(cmd.NewAppendStringsValue(&c.params.ExcludeEntity), "exclude", "do not show log messages for these entities")
 	f.Var(cmd.NewAppendStringsValue(&c.params.IncludeModule), "include-module", "only show log messages for these logging modules")
 	f.Var(cmd.NewAppendStringsValue(&c.params.ExcludeModule), "exclude-module", "do not show log messages for these logging modules")
+	f.Var(cmd.NewAppendStringsValue(&c.fields), "field", "only show log messages with this field value, given as name=value")
+	f.StringVar(&c.params.Grep, "grep", "", "only show log messages matching this regular expression")
+	f.StringVar(&c.params.ExcludeGrep, "exclude-grep", "", "do not show log messages matching this regular expression")
+	f.StringVar(&c.since, "since", "", "only show log messages logged at or after this time")
+	f.StringVar(&c.until, "until", "", "only show log messages logged before this time")
 
 	f.StringVar(&c.level, "l", "", "log level to show, one of [TRACE, DEBUG, INFO, WARNING, ERROR]")
 	f.StringVar(&c.level, "level", "", "")
@@ -60,6 +93,7 @@ func (c *debugLogCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.BoolVar(&c.params.Replay, "replay", false, "start filtering from the start")
 	f.BoolVar(&c.params.NoTail, "T", false, "stop after returning existing log messages")
 	f.BoolVar(&c.params.NoTail, "no-tail", false, "")
+	f.BoolVar(&c.params.FromArchive, "from-archive", false, "show log messages from the archive of pruned logs")
 }
 
 func (c *debugLogCommand) Init(args []string) error {
@@ -71,9 +105,51 @@ func (c *debugLogCommand) Init(args []string) error {
 		}
 		c.params.Level = level
 	}
+	for _, field := range c.fields {
+		parts := strings.SplitN(field, "=", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			return fmt.Errorf("field %q is not of the form name=value", field)
+		}
+		if c.params.IncludeFields == nil {
+			c.params.IncludeFields = make(map[string][]string)
+		}
+		name, value := parts[0], parts[1]
+		c.params.IncludeFields[name] = append(c.params.IncludeFields[name], value)
+	}
+	for _, re := range []struct {
+		flag  string
+		value string
+	}{{"--grep", c.params.Grep}, {"--exclude-grep", c.params.ExcludeGrep}} {
+		if _, err := regexp.Compile(re.value); err != nil {
+			return fmt.Errorf("%s: %v", re.flag, err)
+		}
+	}
+	var err error
+	if c.params.Since, err = parseDebugLogTime("--since", c.since); err != nil {
+		return err
+	}
+	if c.params.Until, err = parseDebugLogTime("--until", c.until); err != nil {
+		return err
+	}
+	if !c.params.Since.IsZero() && !c.params.Until.IsZero() && !c.params.Until.After(c.params.Since) {
+		return fmt.Errorf("--until must be after --since")
+	}
 	return cmd.CheckEmpty(args)
 }
 
+// parseDebugLogTime parses the value of a time flag, returning the
+// zero time if it was not set.
+func parseDebugLogTime(flag, value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%s: expected time in RFC3339 format, got %q", flag, value)
+	}
+	return t, nil
+}
+
 type DebugLogAPI interface {
 	WatchDebugLog(params api.DebugLogParams) (io.ReadCloser, error)
 	Close() error
@@ -7,6 +7,7 @@ import (
 	"io"
 	"io/ioutil"
 	"strings"
+	"time"
 
 	"github.com/juju/loggo"
 	jc "github.com/juju/testing/checkers"
@@ -73,6 +74,41 @@ func (s *DebugLogSuite) TestArgParsing(c *gc.C) {
 				ExcludeModule: []string{"juju.foo", "unit"},
 				Backlog:       10,
 			},
+		}, {
+			args: []string{"--field", "hook=install", "--field", "unit=mysql/0", "--field", "hook=start"},
+			expected: api.DebugLogParams{
+				IncludeFields: map[string][]string{
+					"hook": {"install", "start"},
+					"unit": {"mysql/0"},
+				},
+				Backlog: 10,
+			},
+		}, {
+			args:     []string{"--field", "hook"},
+			errMatch: `field "hook" is not of the form name=value`,
+		}, {
+			args: []string{"--grep", "hook (install|start)", "--exclude-grep", "retry"},
+			expected: api.DebugLogParams{
+				Grep:        "hook (install|start)",
+				ExcludeGrep: "retry",
+				Backlog:     10,
+			},
+		}, {
+			args:     []string{"--grep", "hook ("},
+			errMatch: `--grep: error parsing regexp: .*`,
+		}, {
+			args: []string{"--since", "2016-04-01T12:00:00Z", "--until", "2016-04-01T13:00:00Z"},
+			expected: api.DebugLogParams{
+				Since:   time.Date(2016, 4, 1, 12, 0, 0, 0, time.UTC),
+				Until:   time.Date(2016, 4, 1, 13, 0, 0, 0, time.UTC),
+				Backlog: 10,
+			},
+		}, {
+			args:     []string{"--since", "yesterday"},
+			errMatch: `--since: expected time in RFC3339 format, got "yesterday"`,
+		}, {
+			args:     []string{"--since", "2016-04-01T12:00:00Z", "--until", "2016-04-01T12:00:00Z"},
+			errMatch: `--until must be after --since`,
 		}, {
 			args: []string{"--replay"},
 			expected: api.DebugLogParams{
@@ -85,6 +121,12 @@ func (s *DebugLogSuite) TestArgParsing(c *gc.C) {
 				Backlog: 10,
 				NoTail:  true,
 			},
+		}, {
+			args: []string{"--from-archive"},
+			expected: api.DebugLogParams{
+				Backlog:     10,
+				FromArchive: true,
+			},
 		}, {
 			args: []string{"--limit", "100"},
 			expected: api.DebugLogParams{
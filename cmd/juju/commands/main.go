@@ -137,6 +137,7 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(newEndpointCommand())
 	r.Register(newAPIInfoCommand())
 	r.Register(status.NewStatusHistoryCommand())
+	r.Register(status.NewHookHistoryCommand())
 
 	// Error resolution and debugging commands.
 	r.Register(newRunCommand())
@@ -199,6 +200,8 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 
 	r.Register(model.NewShareCommand())
 	r.Register(model.NewUnshareCommand())
+	r.Register(model.NewGrantCommand())
+	r.Register(model.NewRevokeCommand())
 	r.Register(model.NewUsersCommand())
 
 	// Manage and control actions
@@ -216,6 +219,8 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(service.NewGetCommand())
 	r.Register(service.NewSetCommand())
 	r.Register(service.NewDeployCommand())
+	r.Register(service.NewExportBundleCommand())
+	r.Register(service.NewDiffBundleCommand())
 	r.Register(service.NewExposeCommand())
 	r.Register(service.NewUnexposeCommand())
 	r.Register(service.NewServiceGetConstraintsCommand())
@@ -241,6 +246,7 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.RegisterSuperAlias("add-subnet", "subnet", "add", nil)
 
 	// Manage controllers
+	r.Register(controller.NewAuditLogCommand())
 	r.Register(controller.NewCreateModelCommand())
 	r.Register(controller.NewDestroyCommand())
 	r.Register(controller.NewModelsCommand())
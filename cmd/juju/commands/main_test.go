@@ -207,6 +207,7 @@ var commandNames = []string{
 	"add-storage",
 	"add-subnet",
 	"add-user",
+	"audit-log",
 	"backups",
 	"block",
 	"bootstrap",
@@ -226,9 +227,11 @@ var commandNames = []string{
 	"destroy-relation",
 	"destroy-service",
 	"destroy-unit",
+	"diff-bundle",
 	"disable-user",
 	"enable-ha",
 	"enable-user",
+	"export-bundle",
 	"expose",
 	"generate-config", // alias for init
 	"get-config",
@@ -237,6 +240,7 @@ var commandNames = []string{
 	"get-model-config",
 	"get-model-constraints",
 	"get-user-credentials",
+	"grant",
 	"help",
 	"help-tool",
 	"import-ssh-key",
@@ -272,6 +276,7 @@ var commandNames = []string{
 	"resolved",
 	"restore-backup",
 	"retry-provisioning",
+	"revoke",
 	"run",
 	"run-action",
 	"scp",
@@ -293,6 +298,7 @@ var commandNames = []string{
 	"show-machines",
 	"show-status",
 	"show-storage",
+	"show-unit-history",
 	"show-user",
 	"space",
 	"ssh",
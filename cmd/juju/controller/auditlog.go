@@ -0,0 +1,197 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller
+
+import (
+	"bytes"
+	"fmt"
+	"text/tabwriter"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/names"
+	"launchpad.net/gnuflag"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+// NewAuditLogCommand returns a command to query the controller audit log.
+func NewAuditLogCommand() cmd.Command {
+	return modelcmd.WrapController(&auditLogCommand{})
+}
+
+// auditLogCommand shows entries from the controller audit log.
+type auditLogCommand struct {
+	modelcmd.ControllerCommandBase
+	out    cmd.Output
+	api    auditLogAPI
+	apierr error
+
+	user   string
+	model  string
+	facade string
+	after  string
+	before string
+	limit  int
+}
+
+var auditLogDoc = `
+Show the record of changes made to models in the controller.
+
+Every API call made by a user that may change a model is recorded in the
+controller audit log, along with its arguments and whether it succeeded.
+Entries are shown most recent first, and may be filtered by user, model
+UUID, API facade and time range. Times are given in RFC3339 format.
+
+Examples:
+    juju audit-log --user bob
+    juju audit-log --model 3b2a4c2e-... --facade Service
+    juju audit-log --after 2016-04-01T00:00:00Z --limit 20
+`
+
+// auditLogAPI defines the methods on the controller API endpoint
+// that the audit-log command calls.
+type auditLogAPI interface {
+	Close() error
+	AuditLog(params.AuditLogFilter) ([]params.AuditLogEntry, error)
+}
+
+// Info implements Command.Info.
+func (c *auditLogCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "audit-log",
+		Purpose: "show the controller audit log",
+		Doc:     auditLogDoc,
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *auditLogCommand) SetFlags(f *gnuflag.FlagSet) {
+	f.StringVar(&c.user, "user", "", "only show changes made by this user")
+	f.StringVar(&c.model, "model", "", "only show changes made to the model with this UUID")
+	f.StringVar(&c.facade, "facade", "", "only show calls made to this API facade")
+	f.StringVar(&c.after, "after", "", "only show changes made at or after this time")
+	f.StringVar(&c.before, "before", "", "only show changes made before this time")
+	f.IntVar(&c.limit, "limit", 0, "show at most this many entries")
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatAuditLogTabular,
+	})
+}
+
+// Init implements Command.Init.
+func (c *auditLogCommand) Init(args []string) error {
+	if c.user != "" && !names.IsValidUser(c.user) {
+		return errors.NotValidf("user name %q", c.user)
+	}
+	if c.model != "" && !names.IsValidModel(c.model) {
+		return errors.NotValidf("model UUID %q", c.model)
+	}
+	if c.limit < 0 {
+		return errors.New("--limit must not be negative")
+	}
+	for _, t := range []struct {
+		flag  string
+		value string
+	}{{"--after", c.after}, {"--before", c.before}} {
+		if t.value == "" {
+			continue
+		}
+		if _, err := time.Parse(time.RFC3339, t.value); err != nil {
+			return errors.Errorf("%s: expected time in RFC3339 format, got %q", t.flag, t.value)
+		}
+	}
+	return cmd.CheckEmpty(args)
+}
+
+func (c *auditLogCommand) getAPI() (auditLogAPI, error) {
+	if c.api != nil {
+		return c.api, c.apierr
+	}
+	return c.NewControllerAPIClient()
+}
+
+// filter returns the audit log filter described by the command's
+// flags, which have already been validated by Init.
+func (c *auditLogCommand) filter() params.AuditLogFilter {
+	filter := params.AuditLogFilter{
+		Facade: c.facade,
+		Limit:  c.limit,
+	}
+	if c.user != "" {
+		filter.UserTag = names.NewUserTag(c.user).String()
+	}
+	if c.model != "" {
+		filter.ModelTag = names.NewModelTag(c.model).String()
+	}
+	if c.after != "" {
+		after, _ := time.Parse(time.RFC3339, c.after)
+		filter.After = &after
+	}
+	if c.before != "" {
+		before, _ := time.Parse(time.RFC3339, c.before)
+		filter.Before = &before
+	}
+	return filter
+}
+
+// Run implements Command.Run.
+func (c *auditLogCommand) Run(ctx *cmd.Context) error {
+	api, err := c.getAPI()
+	if err != nil {
+		return errors.Annotate(err, "cannot connect to the API")
+	}
+	defer api.Close()
+
+	entries, err := api.AuditLog(c.filter())
+	if err != nil {
+		return errors.Annotate(err, "cannot get audit log")
+	}
+	return c.out.Write(ctx, entries)
+}
+
+// formatAuditLogTabular returns a tabular summary of audit log entries.
+func formatAuditLogTabular(value interface{}) ([]byte, error) {
+	entries, ok := value.([]params.AuditLogEntry)
+	if !ok {
+		return nil, errors.Errorf("expected value of type %T, got %T", entries, value)
+	}
+
+	var out bytes.Buffer
+	const (
+		// To format things into columns.
+		minwidth = 0
+		tabwidth = 1
+		padding  = 2
+		padchar  = ' '
+		flags    = 0
+	)
+	tw := tabwriter.NewWriter(&out, minwidth, tabwidth, padding, padchar, flags)
+	fmt.Fprintf(tw, "TIME\tUSER\tMODEL UUID\tCALL\tOUTCOME\n")
+	for _, entry := range entries {
+		user := entry.OriginTag
+		if tag, err := names.ParseTag(entry.OriginTag); err == nil {
+			user = tag.Id()
+		}
+		model := entry.ModelTag
+		if tag, err := names.ParseModelTag(entry.ModelTag); err == nil {
+			model = tag.Id()
+		}
+		outcome := entry.Outcome
+		if entry.Error != "" {
+			outcome += ": " + entry.Error
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s(%d).%s\t%s\n",
+			entry.Timestamp.UTC().Format(time.RFC3339),
+			user, model,
+			entry.Facade, entry.Version, entry.Method,
+			outcome,
+		)
+	}
+	tw.Flush()
+	return out.Bytes(), nil
+}
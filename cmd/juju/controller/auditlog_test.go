@@ -0,0 +1,162 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller_test
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/controller"
+	_ "github.com/juju/juju/provider/dummy"
+	"github.com/juju/juju/testing"
+)
+
+type AuditLogSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	api      *fakeAuditLogAPI
+	apierror error
+}
+
+var _ = gc.Suite(&AuditLogSuite{})
+
+// fakeAuditLogAPI mocks out the controller API
+type fakeAuditLogAPI struct {
+	err     error
+	filter  params.AuditLogFilter
+	entries []params.AuditLogEntry
+}
+
+func (f *fakeAuditLogAPI) Close() error { return nil }
+
+func (f *fakeAuditLogAPI) AuditLog(filter params.AuditLogFilter) ([]params.AuditLogEntry, error) {
+	f.filter = filter
+	return f.entries, f.err
+}
+
+func (s *AuditLogSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.apierror = nil
+	s.api = &fakeAuditLogAPI{
+		entries: []params.AuditLogEntry{{
+			Timestamp: time.Date(2016, 4, 1, 12, 30, 0, 0, time.UTC),
+			ModelTag:  "model-deadbeef-0bad-400d-8000-4b1d0d06f00d",
+			OriginTag: "user-bob@local",
+			Facade:    "Service",
+			Version:   3,
+			Method:    "Destroy",
+			Args:      `{"ServiceName":"mysql"}`,
+			Outcome:   "failed",
+			Error:     "permission denied",
+		}, {
+			Timestamp: time.Date(2016, 4, 1, 12, 0, 0, 0, time.UTC),
+			ModelTag:  "model-deadbeef-0bad-400d-8000-4b1d0d06f00d",
+			OriginTag: "user-cheryl@local",
+			Facade:    "Service",
+			Version:   3,
+			Method:    "Deploy",
+			Outcome:   "succeeded",
+		}},
+	}
+}
+
+func (s *AuditLogSuite) runAuditLogCommand(c *gc.C, args ...string) (*cmd.Context, error) {
+	cmd := controller.NewAuditLogCommandForTest(s.api, s.apierror)
+	args = append(args, []string{"-c", "dummysys"}...)
+	return testing.RunCommand(c, cmd, args...)
+}
+
+func (s *AuditLogSuite) TestInitErrors(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: []string{"--user", "not valid!"},
+		err:  `user name "not valid!" not valid`,
+	}, {
+		args: []string{"--model", "foo"},
+		err:  `model UUID "foo" not valid`,
+	}, {
+		args: []string{"--after", "yesterday"},
+		err:  `--after: expected time in RFC3339 format, got "yesterday"`,
+	}, {
+		args: []string{"--before", "2016-04-01"},
+		err:  `--before: expected time in RFC3339 format, got "2016-04-01"`,
+	}, {
+		args: []string{"--limit", "-1"},
+		err:  "--limit must not be negative",
+	}, {
+		args: []string{"extra"},
+		err:  `unrecognized args: \["extra"\]`,
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		_, err := s.runAuditLogCommand(c, test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *AuditLogSuite) TestCannotConnectToAPI(c *gc.C) {
+	s.apierror = errors.New("connection refused")
+	_, err := s.runAuditLogCommand(c)
+	c.Assert(err, gc.ErrorMatches, "cannot connect to the API: connection refused")
+}
+
+func (s *AuditLogSuite) TestAPIError(c *gc.C) {
+	s.api.err = errors.New("unexpected api error")
+	_, err := s.runAuditLogCommand(c)
+	c.Assert(err, gc.ErrorMatches, "cannot get audit log: unexpected api error")
+}
+
+func (s *AuditLogSuite) TestFilter(c *gc.C) {
+	_, err := s.runAuditLogCommand(c,
+		"--user", "bob",
+		"--model", "deadbeef-0bad-400d-8000-4b1d0d06f00d",
+		"--facade", "Service",
+		"--after", "2016-04-01T00:00:00Z",
+		"--before", "2016-04-02T00:00:00+01:00",
+		"--limit", "10",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+
+	after := time.Date(2016, 4, 1, 0, 0, 0, 0, time.UTC)
+	before := time.Date(2016, 4, 1, 23, 0, 0, 0, time.UTC)
+	c.Check(s.api.filter.UserTag, gc.Equals, "user-bob")
+	c.Check(s.api.filter.ModelTag, gc.Equals, "model-deadbeef-0bad-400d-8000-4b1d0d06f00d")
+	c.Check(s.api.filter.Facade, gc.Equals, "Service")
+	c.Check(s.api.filter.After.Equal(after), jc.IsTrue)
+	c.Check(s.api.filter.Before.Equal(before), jc.IsTrue)
+	c.Check(s.api.filter.Limit, gc.Equals, 10)
+}
+
+func (s *AuditLogSuite) TestNoFilter(c *gc.C) {
+	_, err := s.runAuditLogCommand(c)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.api.filter, jc.DeepEquals, params.AuditLogFilter{})
+}
+
+func (s *AuditLogSuite) TestTabular(c *gc.C) {
+	ctx, err := s.runAuditLogCommand(c)
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(testing.Stdout(ctx), gc.Equals, ""+
+		"TIME                  USER          MODEL UUID                            CALL                OUTCOME\n"+
+		"2016-04-01T12:30:00Z  bob@local     deadbeef-0bad-400d-8000-4b1d0d06f00d  Service(3).Destroy  failed: permission denied\n"+
+		"2016-04-01T12:00:00Z  cheryl@local  deadbeef-0bad-400d-8000-4b1d0d06f00d  Service(3).Deploy   succeeded\n"+
+		"\n")
+}
+
+func (s *AuditLogSuite) TestJSON(c *gc.C) {
+	s.api.entries = s.api.entries[1:]
+	ctx, err := s.runAuditLogCommand(c, "--format", "json")
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(testing.Stdout(ctx), gc.Equals, "["+
+		`{"timestamp":"2016-04-01T12:00:00Z",`+
+		`"model-tag":"model-deadbeef-0bad-400d-8000-4b1d0d06f00d",`+
+		`"origin-tag":"user-cheryl@local","facade":"Service","version":3,`+
+		`"method":"Deploy","outcome":"succeeded"}`+
+		"]\n")
+}
@@ -120,6 +120,15 @@ func NewListBlocksCommandForTest(api listBlocksAPI, apierr error) cmd.Command {
 	})
 }
 
+// NewAuditLogCommandForTest returns an AuditLogCommand with the controller
+// endpoint mocked out.
+func NewAuditLogCommandForTest(api auditLogAPI, apierr error) cmd.Command {
+	return modelcmd.WrapController(&auditLogCommand{
+		api:    api,
+		apierr: apierr,
+	})
+}
+
 type CtrData ctrData
 type EnvData envData
 
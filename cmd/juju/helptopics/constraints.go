@@ -32,6 +32,18 @@ constraints, which override the juju default constraints.
 Constraints are specified as key value pairs separated by an equals sign, with
 multiple constraints delimited by a space.
 
+The mem, cpu-cores and root-disk constraints may be given as a range, with a
+maximum as well as a minimum, as in "mem=4G..16G"; the minimum may be left
+out, as in "cpu-cores=..8". The arch and instance-type constraints may list
+alternatives separated by "|", as in "arch=amd64|arm64"; any of them will do.
+
+Any constraint other than container, spaces and networks may be made a
+preference rather than a requirement by prefixing its value with "prefer:",
+as in "mem=prefer:8G" or "instance-type=prefer:m4.large|m4.xlarge". A
+preference is honoured if some machine can satisfy it along with the other
+constraints, and is otherwise ignored; alternatives given for a preference are
+tried in the order given.
+
 Constraint Types:
 
 arch
@@ -101,9 +113,10 @@ instance-type
    conflict with other constraints depending on the provider (since the instance
    type my determine things like memory size etc.)
 
-Example:
+Examples:
 
    juju add-machine --constraints "arch=amd64 mem=8G tags=foo,^bar"
+   juju add-machine --constraints "arch=amd64|arm64 mem=4G..16G cpu-cores=prefer:4"
 
 See Also:
    juju help set-constraints
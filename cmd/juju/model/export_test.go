@@ -65,6 +65,30 @@ func NewUnshareCommandForTest(api UnshareEnvironmentAPI) (cmd.Command, *UnshareC
 	return modelcmd.Wrap(cmd), &UnshareCommand{cmd}
 }
 
+type GrantCommand struct {
+	*grantCommand
+}
+
+// NewGrantCommandForTest returns a GrantCommand with the api provided as specified.
+func NewGrantCommandForTest(api GrantAPI) (cmd.Command, *GrantCommand) {
+	cmd := &grantCommand{
+		api: api,
+	}
+	return modelcmd.Wrap(cmd), &GrantCommand{cmd}
+}
+
+type RevokeCommand struct {
+	*revokeCommand
+}
+
+// NewRevokeCommandForTest returns a RevokeCommand with the api provided as specified.
+func NewRevokeCommandForTest(api RevokeAPI) (cmd.Command, *RevokeCommand) {
+	cmd := &revokeCommand{
+		api: api,
+	}
+	return modelcmd.Wrap(cmd), &RevokeCommand{cmd}
+}
+
 // NewUsersCommandForTest returns a UsersCommand with the api provided as specified.
 func NewUsersCommandForTest(api UsersAPI) cmd.Command {
 	cmd := &usersCommand{
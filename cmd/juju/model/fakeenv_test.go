@@ -32,6 +32,10 @@ type fakeEnvAPI struct {
 	keys        []string
 	addUsers    []names.UserTag
 	removeUsers []names.UserTag
+	roleAction  string
+	role        string
+	service     string
+	roleUsers   []names.UserTag
 }
 
 func (f *fakeEnvAPI) Close() error {
@@ -61,3 +65,13 @@ func (f *fakeEnvAPI) UnshareModel(users ...names.UserTag) error {
 	f.removeUsers = users
 	return f.err
 }
+
+func (f *fakeEnvAPI) GrantModelRole(role, service string, users ...names.UserTag) error {
+	f.roleAction, f.role, f.service, f.roleUsers = "grant", role, service, users
+	return f.err
+}
+
+func (f *fakeEnvAPI) RevokeModelRole(role, service string, users ...names.UserTag) error {
+	f.roleAction, f.role, f.service, f.roleUsers = "revoke", role, service, users
+	return f.err
+}
@@ -0,0 +1,133 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model
+
+import (
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/names"
+	"launchpad.net/gnuflag"
+
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+const grantHelpDoc = `
+Grant a role on the current model to users it is shared with.
+
+The roles are:
+    read      look at the model without changing it
+    operator  run actions and commands, resolve unit errors and retry
+              provisioning, but not deploy or destroy anything
+    deployer  deploy, upgrade, configure and expose services, add units
+              and relations, but not destroy anything
+    admin     do anything to the model
+
+The operator and deployer roles may be limited to a single service with
+--service. A user who has never been granted a role is an admin, or a
+reader if the model was shared with them read-only. The first role
+granted replaces that: granting "deployer --service wordpress" to a
+user who was an admin leaves them able to deploy only wordpress.
+
+Roles are read when a user logs in, so a user who is already connected
+keeps their old roles until they next log in.
+
+Examples:
+ juju grant operator joe
+     Let local user "joe" run actions and commands on the model
+
+ juju grant deployer sam --service wordpress
+     Let local user "sam" deploy and upgrade the wordpress service
+
+See Also:
+    juju help revoke
+    juju help share-model
+    juju help list-shares
+`
+
+func NewGrantCommand() cmd.Command {
+	return modelcmd.Wrap(&grantCommand{})
+}
+
+// grantCommand grants a role on the model to users.
+type grantCommand struct {
+	modelcmd.ModelCommandBase
+	api GrantAPI
+
+	Role    string
+	Service string
+	Users   []names.UserTag
+}
+
+// Info implements Command.Info.
+func (c *grantCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "grant",
+		Args:    "<role> <user> ...",
+		Purpose: "grant a role on the current model to users",
+		Doc:     strings.TrimSpace(grantHelpDoc),
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *grantCommand) SetFlags(f *gnuflag.FlagSet) {
+	f.StringVar(&c.Service, "service", "", "limit the role to the named service")
+}
+
+// Init implements Command.Init.
+func (c *grantCommand) Init(args []string) error {
+	role, users, err := parseRoleArgs(args, c.Service)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	c.Role, c.Users = role, users
+	return nil
+}
+
+// parseRoleArgs parses the arguments of the grant and revoke commands.
+func parseRoleArgs(args []string, service string) (string, []names.UserTag, error) {
+	if len(args) == 0 {
+		return "", nil, errors.New("no role specified")
+	}
+	if len(args) == 1 {
+		return "", nil, errors.New("no users specified")
+	}
+	if service != "" && !names.IsValidService(service) {
+		return "", nil, errors.Errorf("invalid service name: %q", service)
+	}
+	var users []names.UserTag
+	for _, arg := range args[1:] {
+		if !names.IsValidUser(arg) {
+			return "", nil, errors.Errorf("invalid username: %q", arg)
+		}
+		users = append(users, names.NewUserTag(arg))
+	}
+	return args[0], users, nil
+}
+
+func (c *grantCommand) getAPI() (GrantAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	return c.NewAPIClient()
+}
+
+// GrantAPI defines the API functions used by the grant command.
+type GrantAPI interface {
+	Close() error
+	GrantModelRole(role, service string, users ...names.UserTag) error
+}
+
+// Run implements Command.Run.
+func (c *grantCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	return block.ProcessBlockedError(client.GrantModelRole(c.Role, c.Service, c.Users...), block.BlockChange)
+}
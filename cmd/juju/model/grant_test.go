@@ -0,0 +1,74 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/names"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/model"
+	"github.com/juju/juju/testing"
+)
+
+type grantSuite struct {
+	fakeEnvSuite
+}
+
+var _ = gc.Suite(&grantSuite{})
+
+func (s *grantSuite) TestInit(c *gc.C) {
+	wrappedCmd, grantCmd := model.NewGrantCommandForTest(s.fake)
+	err := testing.InitCommand(wrappedCmd, []string{})
+	c.Assert(err, gc.ErrorMatches, "no role specified")
+
+	err = testing.InitCommand(wrappedCmd, []string{"operator"})
+	c.Assert(err, gc.ErrorMatches, "no users specified")
+
+	err = testing.InitCommand(wrappedCmd, []string{"deployer", "bob@local", "sam", "--service", "wordpress"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(grantCmd.Role, gc.Equals, "deployer")
+	c.Assert(grantCmd.Service, gc.Equals, "wordpress")
+	c.Assert(grantCmd.Users, jc.DeepEquals, []names.UserTag{
+		names.NewUserTag("bob@local"), names.NewUserTag("sam"),
+	})
+
+	err = testing.InitCommand(wrappedCmd, []string{"operator", "not valid/0"})
+	c.Assert(err, gc.ErrorMatches, `invalid username: "not valid/0"`)
+
+	err = testing.InitCommand(wrappedCmd, []string{"operator", "sam", "--service", "no/good"})
+	c.Assert(err, gc.ErrorMatches, `invalid service name: "no/good"`)
+}
+
+func (s *grantSuite) TestGrant(c *gc.C) {
+	command, _ := model.NewGrantCommandForTest(s.fake)
+	_, err := testing.RunCommand(c, command, "operator", "sam", "--service", "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fake.roleAction, gc.Equals, "grant")
+	c.Assert(s.fake.role, gc.Equals, "operator")
+	c.Assert(s.fake.service, gc.Equals, "mysql")
+	c.Assert(s.fake.roleUsers, jc.DeepEquals, []names.UserTag{names.NewUserTag("sam")})
+}
+
+func (s *grantSuite) TestRevoke(c *gc.C) {
+	command, _ := model.NewRevokeCommandForTest(s.fake)
+	_, err := testing.RunCommand(c, command, "deployer", "sam", "ralph")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fake.roleAction, gc.Equals, "revoke")
+	c.Assert(s.fake.role, gc.Equals, "deployer")
+	c.Assert(s.fake.service, gc.Equals, "")
+	c.Assert(s.fake.roleUsers, jc.DeepEquals, []names.UserTag{
+		names.NewUserTag("sam"), names.NewUserTag("ralph"),
+	})
+}
+
+func (s *grantSuite) TestBlockGrant(c *gc.C) {
+	s.fake.err = &params.Error{Code: params.CodeOperationBlocked}
+	command, _ := model.NewGrantCommandForTest(s.fake)
+	_, err := testing.RunCommand(c, command, "operator", "sam")
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+	c.Check(c.GetTestLog(), jc.Contains, "To unblock changes")
+}
@@ -0,0 +1,102 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model
+
+import (
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/names"
+	"launchpad.net/gnuflag"
+
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+const revokeHelpDoc = `
+Revoke a role on the current model from users.
+
+A role limited to a service must be revoked with the same --service.
+Users whose every role has been revoked can still look at the model;
+use unshare-model to take that away too.
+
+Roles are read when a user logs in, so a user who is already connected
+keeps their old roles until they next log in.
+
+Examples:
+ juju revoke operator joe
+     Stop local user "joe" running actions and commands on the model
+
+ juju revoke deployer sam --service wordpress
+     Stop local user "sam" deploying and upgrading the wordpress service
+
+See Also:
+    juju help grant
+    juju help unshare-model
+    juju help list-shares
+`
+
+func NewRevokeCommand() cmd.Command {
+	return modelcmd.Wrap(&revokeCommand{})
+}
+
+// revokeCommand revokes a role on the model from users.
+type revokeCommand struct {
+	modelcmd.ModelCommandBase
+	api RevokeAPI
+
+	Role    string
+	Service string
+	Users   []names.UserTag
+}
+
+// Info implements Command.Info.
+func (c *revokeCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "revoke",
+		Args:    "<role> <user> ...",
+		Purpose: "revoke a role on the current model from users",
+		Doc:     strings.TrimSpace(revokeHelpDoc),
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *revokeCommand) SetFlags(f *gnuflag.FlagSet) {
+	f.StringVar(&c.Service, "service", "", "the service the role is limited to")
+}
+
+// Init implements Command.Init.
+func (c *revokeCommand) Init(args []string) error {
+	role, users, err := parseRoleArgs(args, c.Service)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	c.Role, c.Users = role, users
+	return nil
+}
+
+func (c *revokeCommand) getAPI() (RevokeAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	return c.NewAPIClient()
+}
+
+// RevokeAPI defines the API functions used by the revoke command.
+type RevokeAPI interface {
+	Close() error
+	RevokeModelRole(role, service string, users ...names.UserTag) error
+}
+
+// Run implements Command.Run.
+func (c *revokeCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	return block.ProcessBlockedError(client.RevokeModelRole(c.Role, c.Service, c.Users...), block.BlockChange)
+}
@@ -6,6 +6,7 @@ package model
 import (
 	"bytes"
 	"fmt"
+	"strings"
 	"text/tabwriter"
 	"time"
 
@@ -33,9 +34,10 @@ type usersCommand struct {
 
 // UserInfo defines the serialization behaviour of the user information.
 type UserInfo struct {
-	Username       string `yaml:"user-name" json:"user-name"`
-	DateCreated    string `yaml:"date-created" json:"date-created"`
-	LastConnection string `yaml:"last-connection" json:"last-connection"`
+	Username       string   `yaml:"user-name" json:"user-name"`
+	DateCreated    string   `yaml:"date-created" json:"date-created"`
+	LastConnection string   `yaml:"last-connection" json:"last-connection"`
+	Roles          []string `yaml:"roles,omitempty" json:"roles,omitempty"`
 }
 
 // UsersAPI defines the methods on the client API that the
@@ -102,9 +104,10 @@ func (c *usersCommand) formatTabular(value interface{}) ([]byte, error) {
 		flags    = 0
 	)
 	tw := tabwriter.NewWriter(&out, minwidth, tabwidth, padding, padchar, flags)
-	fmt.Fprintf(tw, "NAME\tDATE CREATED\tLAST CONNECTION\n")
+	fmt.Fprintf(tw, "NAME\tDATE CREATED\tLAST CONNECTION\tROLES\n")
 	for _, user := range users {
-		fmt.Fprintf(tw, "%s\t%s\t%s\n", user.Username, user.DateCreated, user.LastConnection)
+		roles := strings.Join(user.Roles, ",")
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", user.Username, user.DateCreated, user.LastConnection, roles)
 	}
 	tw.Flush()
 	return out.Bytes(), nil
@@ -113,7 +116,7 @@ func (c *usersCommand) formatTabular(value interface{}) ([]byte, error) {
 func (c *usersCommand) apiUsersToUserInfoSlice(users []params.ModelUserInfo) []UserInfo {
 	var output []UserInfo
 	for _, info := range users {
-		outInfo := UserInfo{Username: info.UserName}
+		outInfo := UserInfo{Username: info.UserName, Roles: info.Roles}
 		outInfo.DateCreated = user.UserFriendlyDuration(info.DateCreated, time.Now())
 		if info.LastConnection != nil {
 			outInfo.LastConnection = user.UserFriendlyDuration(*info.LastConnection, time.Now())
@@ -43,12 +43,14 @@ func (s *UsersCommandSuite) SetUpTest(c *gc.C) {
 			CreatedBy:      "admin@local",
 			DateCreated:    time.Date(2014, 7, 20, 9, 0, 0, 0, time.UTC),
 			LastConnection: &last1,
+			Roles:          []string{"admin"},
 		}, {
 			UserName:       "bob@local",
 			DisplayName:    "Bob",
 			CreatedBy:      "admin@local",
 			DateCreated:    time.Date(2015, 2, 15, 9, 0, 0, 0, time.UTC),
 			LastConnection: &last2,
+			Roles:          []string{"read", "operator:wordpress"},
 		}, {
 			UserName:    "charlie@ubuntu.com",
 			DisplayName: "Charlie",
@@ -64,10 +66,10 @@ func (s *UsersCommandSuite) TestModelUsers(c *gc.C) {
 	context, err := testing.RunCommand(c, model.NewUsersCommandForTest(s.fake), "-m", "dummymodel")
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(testing.Stdout(context), gc.Equals, ""+
-		"NAME                DATE CREATED  LAST CONNECTION\n"+
-		"admin@local         2014-07-20    2015-03-20\n"+
-		"bob@local           2015-02-15    2015-03-01\n"+
-		"charlie@ubuntu.com  2015-02-15    never connected\n"+
+		"NAME                DATE CREATED  LAST CONNECTION  ROLES\n"+
+		"admin@local         2014-07-20    2015-03-20       admin\n"+
+		"bob@local           2015-02-15    2015-03-01       read,operator:wordpress\n"+
+		"charlie@ubuntu.com  2015-02-15    never connected  \n"+
 		"\n")
 }
 
@@ -75,8 +77,8 @@ func (s *UsersCommandSuite) TestModelUsersFormatJson(c *gc.C) {
 	context, err := testing.RunCommand(c, model.NewUsersCommandForTest(s.fake), "-m", "dummymodel", "--format", "json")
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(testing.Stdout(context), gc.Equals, "["+
-		`{"user-name":"admin@local","date-created":"2014-07-20","last-connection":"2015-03-20"},`+
-		`{"user-name":"bob@local","date-created":"2015-02-15","last-connection":"2015-03-01"},`+
+		`{"user-name":"admin@local","date-created":"2014-07-20","last-connection":"2015-03-20","roles":["admin"]},`+
+		`{"user-name":"bob@local","date-created":"2015-02-15","last-connection":"2015-03-01","roles":["read","operator:wordpress"]},`+
 		`{"user-name":"charlie@ubuntu.com","date-created":"2015-02-15","last-connection":"never connected"}`+
 		"]\n")
 }
@@ -88,9 +90,14 @@ func (s *UsersCommandSuite) TestUserInfoFormatYaml(c *gc.C) {
 		"- user-name: admin@local\n"+
 		"  date-created: 2014-07-20\n"+
 		"  last-connection: 2015-03-20\n"+
+		"  roles:\n"+
+		"  - admin\n"+
 		"- user-name: bob@local\n"+
 		"  date-created: 2015-02-15\n"+
 		"  last-connection: 2015-03-01\n"+
+		"  roles:\n"+
+		"  - read\n"+
+		"  - operator:wordpress\n"+
 		"- user-name: charlie@ubuntu.com\n"+
 		"  date-created: 2015-02-15\n"+
 		"  last-connection: never connected\n")
@@ -0,0 +1,576 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package service
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/names"
+	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/yaml.v1"
+
+	"github.com/juju/juju/api"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/storage"
+)
+
+// bundleDiffAPI defines the methods on the service API used to
+// compare a model with a bundle and reconcile their differences.
+type bundleDiffAPI interface {
+	ExportBundle() (string, error)
+	Update(args params.ServiceUpdate) error
+	AddUnits(service string, numUnits int, placement []*instance.Placement) ([]string, error)
+	DestroyUnits(unitNames ...string) error
+	AddRelation(endpoints ...string) (*params.AddRelationResults, error)
+	DestroyRelation(endpoints ...string) error
+}
+
+// statusGetter defines the method used to find the units of the
+// services in the model.
+type statusGetter interface {
+	Status(patterns []string) (*params.FullStatus, error)
+}
+
+// bundleChange describes a single difference between a model and
+// a bundle.
+type bundleChange interface {
+	// String describes the change to the user.
+	String() string
+
+	// apply makes the change in the model. Changes to deploy
+	// or upgrade services are made by deploying the bundle, and
+	// apply does nothing for them.
+	apply(client bundleDiffAPI) error
+}
+
+// deployServiceChange reports a service in the bundle that is
+// not in the model.
+type deployServiceChange struct {
+	service string
+	charm   string
+}
+
+func (c *deployServiceChange) String() string {
+	return fmt.Sprintf("deploy service %s (charm: %s)", c.service, c.charm)
+}
+
+func (c *deployServiceChange) apply(bundleDiffAPI) error {
+	return nil
+}
+
+// upgradeCharmChange reports a service running a different
+// charm to the one in the bundle.
+type upgradeCharmChange struct {
+	service string
+	from    string
+	to      string
+}
+
+func (c *upgradeCharmChange) String() string {
+	return fmt.Sprintf("upgrade service %s from %s to %s", c.service, c.from, c.to)
+}
+
+func (c *upgradeCharmChange) apply(bundleDiffAPI) error {
+	return nil
+}
+
+// setConfigChange reports service options that differ from
+// those in the bundle.
+type setConfigChange struct {
+	service string
+	options map[string]interface{}
+}
+
+func (c *setConfigChange) String() string {
+	names := make([]string, 0, len(c.options))
+	for name := range c.options {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	settings := make([]string, len(names))
+	for i, name := range names {
+		settings[i] = fmt.Sprintf("%s=%v", name, c.options[name])
+	}
+	return fmt.Sprintf("set config for service %s: %s", c.service, strings.Join(settings, ", "))
+}
+
+func (c *setConfigChange) apply(client bundleDiffAPI) error {
+	settings, err := yaml.Marshal(map[string]map[string]interface{}{c.service: c.options})
+	if err != nil {
+		return errors.Annotatef(err, "cannot marshal options for service %q", c.service)
+	}
+	return client.Update(params.ServiceUpdate{
+		ServiceName:  c.service,
+		SettingsYAML: string(settings),
+	})
+}
+
+// addUnitsChange reports a service with fewer units than
+// the bundle. The placement holds the machine placement for each
+// new unit, resolved against the model; an empty placement puts
+// the unit on a new machine.
+type addUnitsChange struct {
+	service   string
+	count     int
+	placement []string
+}
+
+func (c *addUnitsChange) String() string {
+	msg := fmt.Sprintf("add %d unit(s) to service %s", c.count, c.service)
+	for _, placement := range c.placement {
+		if placement != "" {
+			return fmt.Sprintf("%s (to: %s)", msg, strings.Join(c.placementNames(), ", "))
+		}
+	}
+	return msg
+}
+
+func (c *addUnitsChange) placementNames() []string {
+	result := make([]string, c.count)
+	for i := range result {
+		result[i] = "new"
+		if i < len(c.placement) && c.placement[i] != "" {
+			result[i] = c.placement[i]
+		}
+	}
+	return result
+}
+
+func (c *addUnitsChange) apply(client bundleDiffAPI) error {
+	// Units are added one at a time, as when deploying a bundle,
+	// because a unit without a placement must not be followed by
+	// units with one.
+	for i := 0; i < c.count; i++ {
+		var placementArg []*instance.Placement
+		if i < len(c.placement) && c.placement[i] != "" {
+			placement, err := parsePlacement(c.placement[i])
+			if err != nil {
+				return errors.Trace(err)
+			}
+			placementArg = append(placementArg, placement)
+		}
+		if _, err := client.AddUnits(c.service, 1, placementArg); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// removeUnitsChange reports a service with more units than
+// the bundle. The most recently added units that are not already
+// being removed are removed.
+type removeUnitsChange struct {
+	service string
+	units   []string
+}
+
+func (c *removeUnitsChange) String() string {
+	return fmt.Sprintf("remove unit(s) %s from service %s", strings.Join(c.units, ", "), c.service)
+}
+
+func (c *removeUnitsChange) apply(client bundleDiffAPI) error {
+	return client.DestroyUnits(c.units...)
+}
+
+// addRelationChange reports a relation in the bundle that is
+// not in the model.
+type addRelationChange struct {
+	endpoints []string
+}
+
+func (c *addRelationChange) String() string {
+	return fmt.Sprintf("add relation %s", strings.Join(c.endpoints, " "))
+}
+
+func (c *addRelationChange) apply(client bundleDiffAPI) error {
+	_, err := client.AddRelation(c.endpoints...)
+	if err != nil && isErrRelationExists(err) {
+		return nil
+	}
+	return err
+}
+
+// removeRelationChange reports a relation between services in
+// the bundle that is not in the bundle itself.
+type removeRelationChange struct {
+	endpoints []string
+}
+
+func (c *removeRelationChange) String() string {
+	return fmt.Sprintf("remove relation %s", strings.Join(c.endpoints, " "))
+}
+
+func (c *removeRelationChange) apply(client bundleDiffAPI) error {
+	return client.DestroyRelation(c.endpoints...)
+}
+
+// extraServiceChange reports a service in the model that is not
+// in the bundle. Such services are left untouched.
+type extraServiceChange struct {
+	service string
+}
+
+func (c *extraServiceChange) String() string {
+	return fmt.Sprintf("service %s is not in the bundle and will be left untouched", c.service)
+}
+
+func (c *extraServiceChange) apply(bundleDiffAPI) error {
+	return nil
+}
+
+// applyBundle changes the model to match the given bundle. Services in
+// the bundle that are missing from the model or use a different charm
+// are deployed as by deployBundle, then service options, unit counts
+// and relations are changed to match the bundle. If dryRun is true,
+// the changes are reported but not made.
+func applyBundle(
+	data *charm.BundleData, client *api.Client, serviceDeployer *serviceDeployer,
+	csclient *csClient, repoPath string, conf *config.Config, log deploymentLogger,
+	bundleStorage map[string]map[string]storage.Constraints, dryRun bool,
+) error {
+	serviceClient, err := serviceDeployer.newServiceAPIClient()
+	if err != nil {
+		return errors.Annotate(err, "cannot get service client")
+	}
+	changes, err := modelBundleChanges(client, serviceClient, data)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(changes) == 0 {
+		log.Infof("no changes to apply")
+		return nil
+	}
+	if dryRun {
+		for _, change := range changes {
+			log.Infof("%s", change)
+		}
+		return nil
+	}
+	if needsDeploy(changes) {
+		if err := deployBundle(
+			data, client, serviceDeployer, csclient,
+			repoPath, conf, log, bundleStorage,
+		); err != nil {
+			return errors.Trace(err)
+		}
+		if changes, err = modelBundleChanges(client, serviceClient, data); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	for _, change := range changes {
+		if err := change.apply(serviceClient); err != nil {
+			return errors.Annotatef(err, "cannot %s", change)
+		}
+		log.Infof("%s", change)
+	}
+	return nil
+}
+
+// modelBundleChanges returns the changes needed to make the current
+// model match the given bundle.
+func modelBundleChanges(client statusGetter, serviceClient bundleDiffAPI, target *charm.BundleData) ([]bundleChange, error) {
+	exported, err := serviceClient.ExportBundle()
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot export model")
+	}
+	current, err := charm.ReadBundleData(strings.NewReader(exported))
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot read exported model")
+	}
+	status, err := client.Status(nil)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot get model status")
+	}
+	units := make(map[string][]string)
+	machines := make(map[string]string)
+	for service, serviceStatus := range status.Services {
+		for unit, unitStatus := range serviceStatus.Units {
+			if unitStatus.Life != "" {
+				// The unit is already being removed, and is
+				// left out of the export.
+				continue
+			}
+			units[service] = append(units[service], unit)
+			machines[unit] = unitStatus.Machine
+		}
+	}
+	return diffBundle(current, target, units, machines)
+}
+
+// diffBundle returns the changes needed to make the model described
+// by current match the target bundle. The units map holds the alive
+// units of each service in the model, and the machines map holds
+// the machine each unit is assigned to.
+func diffBundle(current, target *charm.BundleData, units map[string][]string, machines map[string]string) ([]bundleChange, error) {
+	var changes []bundleChange
+	for _, name := range sortedServices(target.Services) {
+		spec := target.Services[name]
+		existing, ok := current.Services[name]
+		if !ok {
+			changes = append(changes, &deployServiceChange{
+				service: name,
+				charm:   spec.Charm,
+			})
+			continue
+		}
+		if !charmMatches(spec.Charm, existing.Charm) {
+			changes = append(changes, &upgradeCharmChange{
+				service: name,
+				from:    existing.Charm,
+				to:      spec.Charm,
+			})
+		}
+		options := make(map[string]interface{})
+		for option, value := range spec.Options {
+			if !reflect.DeepEqual(existing.Options[option], value) {
+				options[option] = value
+			}
+		}
+		if len(options) > 0 {
+			changes = append(changes, &setConfigChange{
+				service: name,
+				options: options,
+			})
+		}
+		switch delta := spec.NumUnits - existing.NumUnits; {
+		case delta > 0:
+			placer := &unitPlacer{target: target, units: units, machines: machines}
+			placement := make([]string, delta)
+			for i := range placement {
+				p, err := placer.placement(name, existing.NumUnits+i)
+				if err != nil {
+					return nil, errors.Annotatef(err, "cannot place new unit of service %q", name)
+				}
+				placement[i] = p
+			}
+			changes = append(changes, &addUnitsChange{
+				service:   name,
+				count:     delta,
+				placement: placement,
+			})
+		case delta < 0:
+			serviceUnits := append([]string(nil), units[name]...)
+			sort.Sort(sort.Reverse(unitsByNumber(serviceUnits)))
+			if -delta < len(serviceUnits) {
+				serviceUnits = serviceUnits[:-delta]
+			}
+			sort.Sort(unitsByNumber(serviceUnits))
+			changes = append(changes, &removeUnitsChange{
+				service: name,
+				units:   serviceUnits,
+			})
+		}
+	}
+	for _, name := range sortedServices(current.Services) {
+		if _, ok := target.Services[name]; !ok {
+			changes = append(changes, &extraServiceChange{service: name})
+		}
+	}
+	for _, relation := range target.Relations {
+		if !relationIn(relation, current.Relations) {
+			changes = append(changes, &addRelationChange{endpoints: relation})
+		}
+	}
+	for _, relation := range current.Relations {
+		if !servicesIn(relation, target.Services) {
+			// Relations with services that are not in the
+			// bundle are left untouched.
+			continue
+		}
+		if !relationIn(relation, target.Relations) {
+			changes = append(changes, &removeRelationChange{endpoints: relation})
+		}
+	}
+	return changes, nil
+}
+
+// unitPlacer resolves the placement directives in a bundle against
+// the machines in the model.
+type unitPlacer struct {
+	target   *charm.BundleData
+	units    map[string][]string
+	machines map[string]string
+}
+
+// placement returns the placement, in the form accepted by add-unit
+// --to, for the unit with the given index in the bundle's service.
+// It returns an empty string if the unit should go on a new machine.
+func (p *unitPlacer) placement(service string, index int) (string, error) {
+	to := p.target.Services[service].To
+	if index >= len(to) {
+		return "", nil
+	}
+	directive := to[index]
+	containerType, target := "", directive
+	if i := strings.Index(directive, ":"); i >= 0 {
+		containerType, target = directive[:i], directive[i+1:]
+	}
+	machine := ""
+	switch {
+	case target == "new":
+	case names.IsValidUnit(target):
+		machine = p.machines[target]
+	case names.IsValidMachine(target):
+		machine = p.bundleMachine(target)
+	case names.IsValidService(target):
+		// Successive units placed with a service go alongside
+		// successive units of that service.
+		n := 0
+		for _, other := range to[:index] {
+			if other == directive {
+				n++
+			}
+		}
+		if units := p.sortedUnits(target); n < len(units) {
+			machine = p.machines[units[n]]
+		}
+	default:
+		return "", errors.NotValidf("placement %q", directive)
+	}
+	if target != "new" && machine == "" {
+		return "", errors.NotFoundf("machine for placement %q", directive)
+	}
+	switch {
+	case containerType == "":
+		return machine, nil
+	case machine == "":
+		return containerType, nil
+	}
+	return containerType + ":" + machine, nil
+}
+
+// bundleMachine returns the model machine that corresponds to the
+// given bundle machine, found through the units that the bundle
+// places directly on it and that are already in the model.
+func (p *unitPlacer) bundleMachine(id string) string {
+	for _, service := range sortedServices(p.target.Services) {
+		units := p.sortedUnits(service)
+		for i, directive := range p.target.Services[service].To {
+			if directive == id && i < len(units) {
+				return p.machines[units[i]]
+			}
+		}
+	}
+	return ""
+}
+
+// sortedUnits returns the units of the given service in the order
+// they were added.
+func (p *unitPlacer) sortedUnits(service string) []string {
+	units := append([]string(nil), p.units[service]...)
+	sort.Sort(unitsByNumber(units))
+	return units
+}
+
+// needsDeploy reports whether any of the given changes are made by
+// deploying the bundle.
+func needsDeploy(changes []bundleChange) bool {
+	for _, change := range changes {
+		switch change.(type) {
+		case *deployServiceChange, *upgradeCharmChange:
+			return true
+		}
+	}
+	return false
+}
+
+// charmMatches reports whether the charm URL in a bundle refers to the
+// current charm URL of a service. Bundle charm URLs may omit the series
+// and revision.
+func charmMatches(target, current string) bool {
+	if target == current {
+		return true
+	}
+	turl, err := charm.ParseURL(target)
+	if err != nil {
+		return false
+	}
+	curl, err := charm.ParseURL(current)
+	if err != nil {
+		return false
+	}
+	return turl.Schema == curl.Schema &&
+		turl.User == curl.User &&
+		turl.Name == curl.Name &&
+		(turl.Series == "" || turl.Series == curl.Series) &&
+		(turl.Revision == -1 || turl.Revision == curl.Revision)
+}
+
+// relationIn reports whether the given relation matches any of the
+// given relations, in either direction.
+func relationIn(relation []string, relations [][]string) bool {
+	if len(relation) != 2 {
+		return false
+	}
+	for _, other := range relations {
+		if len(other) != 2 {
+			continue
+		}
+		if endpointMatches(relation[0], other[0]) && endpointMatches(relation[1], other[1]) ||
+			endpointMatches(relation[0], other[1]) && endpointMatches(relation[1], other[0]) {
+			return true
+		}
+	}
+	return false
+}
+
+// endpointMatches reports whether the endpoints a and b refer to the
+// same service endpoint. An endpoint without a relation name matches
+// any relation of its service.
+func endpointMatches(a, b string) bool {
+	aService, aRelation := splitEndpoint(a)
+	bService, bRelation := splitEndpoint(b)
+	if aService != bService {
+		return false
+	}
+	return aRelation == "" || bRelation == "" || aRelation == bRelation
+}
+
+// servicesIn reports whether the services of all the given
+// endpoints are in services.
+func servicesIn(endpoints []string, services map[string]*charm.ServiceSpec) bool {
+	for _, endpoint := range endpoints {
+		service, _ := splitEndpoint(endpoint)
+		if _, ok := services[service]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func splitEndpoint(endpoint string) (service, relation string) {
+	if i := strings.Index(endpoint, ":"); i >= 0 {
+		return endpoint[:i], endpoint[i+1:]
+	}
+	return endpoint, ""
+}
+
+func sortedServices(services map[string]*charm.ServiceSpec) []string {
+	names := make([]string, 0, len(services))
+	for name := range services {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+type unitsByNumber []string
+
+func (u unitsByNumber) Len() int      { return len(u) }
+func (u unitsByNumber) Swap(i, j int) { u[i], u[j] = u[j], u[i] }
+func (u unitsByNumber) Less(i, j int) bool {
+	return unitNumber(u[i]) < unitNumber(u[j])
+}
+
+func unitNumber(name string) int {
+	n, _ := strconv.Atoi(name[strings.Index(name, "/")+1:])
+	return n
+}
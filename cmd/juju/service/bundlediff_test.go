@@ -0,0 +1,396 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package service
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6-unstable"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testcharms"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type BundleDiffSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&BundleDiffSuite{})
+
+func changeStrings(changes []bundleChange) []string {
+	result := make([]string, len(changes))
+	for i, change := range changes {
+		result[i] = change.String()
+	}
+	return result
+}
+
+func (s *BundleDiffSuite) TestDiffBundleNoChanges(c *gc.C) {
+	current := &charm.BundleData{
+		Services: map[string]*charm.ServiceSpec{
+			"wordpress": {
+				Charm:    "cs:trusty/wordpress-42",
+				NumUnits: 1,
+				Options:  map[string]interface{}{"blog-title": "title"},
+			},
+			"mysql": {Charm: "cs:trusty/mysql-42", NumUnits: 1},
+		},
+		Relations: [][]string{{"mysql:server", "wordpress:db"}},
+	}
+	target := &charm.BundleData{
+		Services: map[string]*charm.ServiceSpec{
+			"wordpress": {
+				Charm:    "wordpress",
+				NumUnits: 1,
+				Options:  map[string]interface{}{"blog-title": "title"},
+			},
+			"mysql": {Charm: "trusty/mysql-42", NumUnits: 1},
+		},
+		Relations: [][]string{{"wordpress", "mysql"}},
+	}
+	changes, err := diffBundle(current, target, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(changes, gc.HasLen, 0)
+	c.Assert(needsDeploy(changes), jc.IsFalse)
+}
+
+func (s *BundleDiffSuite) TestDiffBundle(c *gc.C) {
+	current := &charm.BundleData{
+		Services: map[string]*charm.ServiceSpec{
+			"wordpress": {
+				Charm:    "cs:trusty/wordpress-42",
+				NumUnits: 3,
+				Options:  map[string]interface{}{"blog-title": "title"},
+			},
+			"mysql":   {Charm: "cs:trusty/mysql-42", NumUnits: 1},
+			"varnish": {Charm: "cs:trusty/varnish-1", NumUnits: 1},
+			"logging": {Charm: "cs:trusty/logging-1"},
+		},
+		Relations: [][]string{
+			{"mysql:server", "wordpress:db"},
+			{"varnish:webcache", "wordpress:cache"},
+			{"logging:info", "wordpress:logging-dir"},
+		},
+	}
+	target := &charm.BundleData{
+		Services: map[string]*charm.ServiceSpec{
+			"wordpress": {
+				Charm:    "cs:trusty/wordpress-47",
+				NumUnits: 1,
+				Options:  map[string]interface{}{"blog-title": "new title", "skill-level": 9},
+			},
+			"mysql":   {Charm: "cs:trusty/mysql-42", NumUnits: 3},
+			"haproxy": {Charm: "cs:trusty/haproxy-2", NumUnits: 1},
+			"logging": {Charm: "cs:trusty/logging-1"},
+		},
+		Relations: [][]string{
+			{"wordpress:db", "mysql:server"},
+			{"haproxy", "wordpress"},
+		},
+	}
+	units := map[string][]string{
+		"wordpress": {"wordpress/10", "wordpress/2", "wordpress/9"},
+		"mysql":     {"mysql/0"},
+	}
+	changes, err := diffBundle(current, target, units, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(changeStrings(changes), jc.DeepEquals, []string{
+		"deploy service haproxy (charm: cs:trusty/haproxy-2)",
+		"add 2 unit(s) to service mysql",
+		"upgrade service wordpress from cs:trusty/wordpress-42 to cs:trusty/wordpress-47",
+		"set config for service wordpress: blog-title=new title, skill-level=9",
+		"remove unit(s) wordpress/9, wordpress/10 from service wordpress",
+		"service varnish is not in the bundle and will be left untouched",
+		"add relation haproxy wordpress",
+		"remove relation logging:info wordpress:logging-dir",
+	})
+	c.Assert(needsDeploy(changes), jc.IsTrue)
+}
+
+func (s *BundleDiffSuite) TestDiffBundlePlacement(c *gc.C) {
+	current := &charm.BundleData{
+		Services: map[string]*charm.ServiceSpec{
+			"wordpress": {Charm: "cs:trusty/wordpress-42", NumUnits: 1},
+			"mysql":     {Charm: "cs:trusty/mysql-42", NumUnits: 1},
+			"varnish":   {Charm: "cs:trusty/varnish-1", NumUnits: 1},
+		},
+	}
+	target := &charm.BundleData{
+		Services: map[string]*charm.ServiceSpec{
+			"wordpress": {
+				Charm:    "cs:trusty/wordpress-42",
+				NumUnits: 6,
+				To:       []string{"1", "lxc:1", "mysql/0", "varnish", "lxc:new"},
+			},
+			"mysql":   {Charm: "cs:trusty/mysql-42", NumUnits: 1, To: []string{"1"}},
+			"varnish": {Charm: "cs:trusty/varnish-1", NumUnits: 2},
+		},
+	}
+	units := map[string][]string{
+		"wordpress": {"wordpress/0"},
+		"mysql":     {"mysql/0"},
+		"varnish":   {"varnish/0"},
+	}
+	machines := map[string]string{
+		"wordpress/0": "4",
+		"mysql/0":     "4",
+		"varnish/0":   "5",
+	}
+	changes, err := diffBundle(current, target, units, machines)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(changeStrings(changes), jc.DeepEquals, []string{
+		"add 1 unit(s) to service varnish",
+		"add 5 unit(s) to service wordpress (to: lxc:4, 4, 5, lxc, new)",
+	})
+}
+
+func (s *BundleDiffSuite) TestDiffBundlePlacementNotFound(c *gc.C) {
+	current := &charm.BundleData{
+		Services: map[string]*charm.ServiceSpec{
+			"wordpress": {Charm: "cs:trusty/wordpress-42", NumUnits: 1},
+		},
+	}
+	target := &charm.BundleData{
+		Services: map[string]*charm.ServiceSpec{
+			"wordpress": {
+				Charm:    "cs:trusty/wordpress-42",
+				NumUnits: 2,
+				To:       []string{"new", "mysql/0"},
+			},
+		},
+	}
+	units := map[string][]string{"wordpress": {"wordpress/0"}}
+	_, err := diffBundle(current, target, units, nil)
+	c.Assert(err, gc.ErrorMatches, `cannot place new unit of service "wordpress": machine for placement "mysql/0" not found`)
+}
+
+func (s *BundleDiffSuite) TestAddUnitsChangeApply(c *gc.C) {
+	client := &fakeBundleDiffAPI{}
+	change := &addUnitsChange{
+		service:   "wordpress",
+		count:     3,
+		placement: []string{"", "lxc:4", "4"},
+	}
+	err := change.apply(client)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(client.addedUnits, jc.DeepEquals, [][]*instance.Placement{
+		nil,
+		{{Scope: "lxc", Directive: "4"}},
+		{{Scope: instance.MachineScope, Directive: "4"}},
+	})
+}
+
+func (s *BundleDiffSuite) TestModelBundleChangesSkipsDyingUnits(c *gc.C) {
+	client := &fakeBundleDiffAPI{
+		exported: `
+services:
+    wordpress:
+        charm: cs:trusty/wordpress-42
+        num_units: 2
+`,
+		status: &params.FullStatus{
+			Services: map[string]params.ServiceStatus{
+				"wordpress": {
+					Units: map[string]params.UnitStatus{
+						"wordpress/0": {Machine: "0"},
+						"wordpress/1": {Machine: "1"},
+						"wordpress/2": {Machine: "2", Life: "dying"},
+					},
+				},
+			},
+		},
+	}
+	target := &charm.BundleData{
+		Services: map[string]*charm.ServiceSpec{
+			"wordpress": {Charm: "cs:trusty/wordpress-42", NumUnits: 1},
+		},
+	}
+	changes, err := modelBundleChanges(client, client, target)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(changeStrings(changes), jc.DeepEquals, []string{
+		"remove unit(s) wordpress/1 from service wordpress",
+	})
+}
+
+// fakeBundleDiffAPI implements bundleDiffAPI and statusGetter.
+type fakeBundleDiffAPI struct {
+	bundleDiffAPI
+	exported   string
+	status     *params.FullStatus
+	addedUnits [][]*instance.Placement
+}
+
+func (f *fakeBundleDiffAPI) ExportBundle() (string, error) {
+	return f.exported, nil
+}
+
+func (f *fakeBundleDiffAPI) Status(patterns []string) (*params.FullStatus, error) {
+	return f.status, nil
+}
+
+func (f *fakeBundleDiffAPI) AddUnits(service string, numUnits int, placement []*instance.Placement) ([]string, error) {
+	f.addedUnits = append(f.addedUnits, placement)
+	return nil, nil
+}
+
+func (s *BundleDiffSuite) TestCharmMatches(c *gc.C) {
+	for i, test := range []struct {
+		target  string
+		current string
+		matches bool
+	}{
+		{"cs:trusty/mysql-42", "cs:trusty/mysql-42", true},
+		{"mysql", "cs:trusty/mysql-42", true},
+		{"trusty/mysql", "cs:trusty/mysql-42", true},
+		{"mysql-42", "cs:trusty/mysql-42", true},
+		{"mysql-47", "cs:trusty/mysql-42", false},
+		{"precise/mysql", "cs:trusty/mysql-42", false},
+		{"cs:~user/mysql", "cs:trusty/mysql-42", false},
+		{"local:trusty/mysql-42", "cs:trusty/mysql-42", false},
+		{"wordpress", "cs:trusty/mysql-42", false},
+	} {
+		c.Logf("test %d: %s %s", i, test.target, test.current)
+		c.Check(charmMatches(test.target, test.current), gc.Equals, test.matches)
+	}
+}
+
+func (s *BundleDiffSuite) TestDeployDryRunRequiresBundleApply(c *gc.C) {
+	err := coretesting.InitCommand(&DeployCommand{}, []string{"bundle.yaml", "--dry-run"})
+	c.Assert(err, gc.ErrorMatches, "--dry-run can only be used with --bundle-apply")
+}
+
+func (s *BundleDiffSuite) TestDiffBundleInit(c *gc.C) {
+	err := coretesting.InitCommand(&diffBundleCommand{}, nil)
+	c.Assert(err, gc.ErrorMatches, "no bundle specified")
+	err = coretesting.InitCommand(&diffBundleCommand{}, []string{"bundle.yaml", "extra"})
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["extra"\]`)
+}
+
+const bundleApplyInitial = `
+    services:
+        wordpress:
+            charm: cs:trusty/wordpress-42
+            num_units: 2
+            options:
+                blog-title: initial
+        mysql:
+            charm: cs:trusty/mysql-42
+            num_units: 1
+    relations:
+        - ["wordpress:db", "mysql:server"]
+`
+
+const bundleApplyChanged = `
+    services:
+        wordpress:
+            charm: cs:trusty/wordpress-42
+            num_units: 1
+            options:
+                blog-title: changed
+        mysql:
+            charm: cs:trusty/mysql-42
+            num_units: 2
+`
+
+// writeBundleFile writes the given bundle content to a bundle.yaml
+// file and returns its path.
+func (s *deployRepoCharmStoreSuite) writeBundleFile(c *gc.C, content string) string {
+	path := filepath.Join(c.MkDir(), "bundle.yaml")
+	err := ioutil.WriteFile(path, []byte(content), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	return path
+}
+
+// assertAliveUnits checks that the given units are the alive
+// units of the named service.
+func (s *deployRepoCharmStoreSuite) assertAliveUnits(c *gc.C, service string, expected ...string) {
+	svc, err := s.State.Service(service)
+	c.Assert(err, jc.ErrorIsNil)
+	units, err := svc.AllUnits()
+	c.Assert(err, jc.ErrorIsNil)
+	var alive []string
+	for _, unit := range units {
+		if unit.Life() == state.Alive {
+			alive = append(alive, unit.Name())
+		}
+	}
+	c.Assert(alive, jc.SameContents, expected)
+}
+
+func (s *deployRepoCharmStoreSuite) TestDiffBundle(c *gc.C) {
+	testcharms.UploadCharm(c, s.client, "trusty/wordpress-42", "wordpress")
+	testcharms.UploadCharm(c, s.client, "trusty/mysql-42", "mysql")
+	_, err := s.deployBundleYAML(c, bundleApplyInitial)
+	c.Assert(err, jc.ErrorIsNil)
+
+	ctx, err := coretesting.RunCommand(c, NewDiffBundleCommand(), s.writeBundleFile(c, bundleApplyInitial))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(coretesting.Stdout(ctx), gc.Equals, "no changes\n")
+
+	ctx, err = coretesting.RunCommand(c, NewDiffBundleCommand(), s.writeBundleFile(c, bundleApplyChanged))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(coretesting.Stdout(ctx), gc.Equals, strings.TrimLeft(`
+add 1 unit(s) to service mysql
+set config for service wordpress: blog-title=changed
+remove unit(s) wordpress/1 from service wordpress
+remove relation mysql:server wordpress:db
+`, "\n"))
+}
+
+func (s *deployRepoCharmStoreSuite) TestDeployBundleApplyDryRun(c *gc.C) {
+	testcharms.UploadCharm(c, s.client, "trusty/wordpress-42", "wordpress")
+	testcharms.UploadCharm(c, s.client, "trusty/mysql-42", "mysql")
+	_, err := s.deployBundleYAML(c, bundleApplyInitial)
+	c.Assert(err, jc.ErrorIsNil)
+
+	output, err := runDeployCommand(c, s.writeBundleFile(c, bundleApplyChanged), "--bundle-apply", "--dry-run")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(output, gc.Equals, strings.TrimSpace(`
+add 1 unit(s) to service mysql
+set config for service wordpress: blog-title=changed
+remove unit(s) wordpress/1 from service wordpress
+remove relation mysql:server wordpress:db`))
+	s.assertAliveUnits(c, "wordpress", "wordpress/0", "wordpress/1")
+	s.assertAliveUnits(c, "mysql", "mysql/0")
+	s.assertRelationsEstablished(c, "wordpress:db mysql:server")
+}
+
+func (s *deployRepoCharmStoreSuite) TestDeployBundleApply(c *gc.C) {
+	testcharms.UploadCharm(c, s.client, "trusty/wordpress-42", "wordpress")
+	testcharms.UploadCharm(c, s.client, "trusty/mysql-42", "mysql")
+	_, err := s.deployBundleYAML(c, bundleApplyInitial)
+	c.Assert(err, jc.ErrorIsNil)
+
+	path := s.writeBundleFile(c, bundleApplyChanged)
+	output, err := runDeployCommand(c, path, "--bundle-apply")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(output, gc.Equals, strings.TrimSpace(`
+add 1 unit(s) to service mysql
+set config for service wordpress: blog-title=changed
+remove unit(s) wordpress/1 from service wordpress
+remove relation mysql:server wordpress:db
+bundle "`+path+`" applied`))
+	s.assertServicesDeployed(c, map[string]serviceInfo{
+		"mysql": {charm: "cs:trusty/mysql-42"},
+		"wordpress": {
+			charm:  "cs:trusty/wordpress-42",
+			config: charm.Settings{"blog-title": "changed"},
+		},
+	})
+	s.assertRelationsEstablished(c)
+	s.assertAliveUnits(c, "wordpress", "wordpress/0")
+	s.assertAliveUnits(c, "mysql", "mysql/0", "mysql/1")
+
+	// Applying the bundle again makes no further changes.
+	output, err = runDeployCommand(c, path, "--bundle-apply", "--dry-run")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(output, gc.Equals, "no changes to apply")
+}
@@ -80,6 +80,15 @@ type DeployCommand struct {
 	Bindings map[string]string
 	Steps    []DeployStep
 
+	// BundleApply makes the model match the bundle being deployed,
+	// changing service options, unit counts and relations that
+	// differ from the bundle.
+	BundleApply bool
+
+	// DryRun reports the changes made by BundleApply without
+	// making them.
+	DryRun bool
+
 	flagSet *gnuflag.FlagSet
 }
 
@@ -132,6 +141,15 @@ To deploy this using a direct path:
 
   juju deploy $JUJU_REPOSITORY/bundle/openstack/bundle.yaml
 
+Deploying a bundle adds the services, units and relations that are missing
+from the model, but leaves existing services as they are. With --bundle-apply,
+the model is changed to match the bundle: service options are set, units are
+added or removed, and relations not in the bundle are removed between services
+that are in the bundle. Services not in the bundle are left untouched. Use
+--dry-run to see the changes without making them, or "juju diff-bundle".
+
+  juju deploy bundle.yaml --bundle-apply --dry-run
+
 <service name>, if omitted, will be derived from <charm name>.
 
 Constraints can be specified when using deploy by specifying the --constraints
@@ -231,7 +249,7 @@ var (
 	// charmOnlyFlags and bundleOnlyFlags are used to validate flags based on
 	// whether we are deploying a charm or a bundle.
 	charmOnlyFlags  = []string{"bind", "config", "constraints", "force", "n", "networks", "num-units", "series", "to", "u", "upgrade", "resource"}
-	bundleOnlyFlags = []string{}
+	bundleOnlyFlags = []string{"bundle-apply", "dry-run"}
 )
 
 func (c *DeployCommand) SetFlags(f *gnuflag.FlagSet) {
@@ -250,6 +268,8 @@ func (c *DeployCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.Var(storageFlag{&c.Storage, &c.BundleStorage}, "storage", "charm storage constraints")
 	f.Var(stringMap{&c.Resources}, "resource", "resource to be uploaded to the controller")
 	f.StringVar(&c.BindToSpaces, "bind", "", "Configure service endpoint bindings to spaces")
+	f.BoolVar(&c.BundleApply, "bundle-apply", false, "change the model to match the bundle")
+	f.BoolVar(&c.DryRun, "dry-run", false, "show the changes made by --bundle-apply without making them")
 
 	for _, step := range c.Steps {
 		step.SetFlags(f)
@@ -261,6 +281,9 @@ func (c *DeployCommand) Init(args []string) error {
 	if c.Force && c.Series == "" && c.PlacementSpec == "" {
 		return errors.New("--force is only used with --series")
 	}
+	if c.DryRun && !c.BundleApply {
+		return errors.New("--dry-run can only be used with --bundle-apply")
+	}
 	switch len(args) {
 	case 2:
 		if !names.IsValidService(args[1]) {
@@ -403,6 +426,18 @@ func (c *DeployCommand) deployCharmOrBundle(ctx *cmd.Context, client *api.Client
 		if flags := getFlags(c.flagSet, charmOnlyFlags); len(flags) > 0 {
 			return errors.Errorf("Flags provided but not supported when deploying a bundle: %s.", strings.Join(flags, ", "))
 		}
+		if c.BundleApply {
+			if err := applyBundle(
+				bundleData, client, &deployer, csClient,
+				repoPath, conf, ctx, c.BundleStorage, c.DryRun,
+			); err != nil {
+				return errors.Trace(err)
+			}
+			if !c.DryRun {
+				ctx.Infof("bundle %q applied", bundlePath)
+			}
+			return nil
+		}
 		if err := deployBundle(
 			bundleData, client, &deployer, csClient,
 			repoPath, conf, ctx, c.BundleStorage,
@@ -0,0 +1,117 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package service
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/juju/charmrepo.v2-unstable"
+
+	apiservice "github.com/juju/juju/api/service"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+// NewDiffBundleCommand returns a command used to compare a bundle
+// with the current model.
+func NewDiffBundleCommand() cmd.Command {
+	return modelcmd.Wrap(&diffBundleCommand{})
+}
+
+// diffBundleCommand shows the changes needed to make the current
+// model match a bundle.
+type diffBundleCommand struct {
+	modelcmd.ModelCommandBase
+	BundlePath string
+}
+
+const diffBundleDoc = `
+Shows the changes that "juju deploy --bundle-apply" would make to the current
+model to match the given bundle: services to deploy or upgrade, service
+options to set, units to add or remove, and relations to add or remove.
+
+<bundle> is the path to a bundle.yaml file, or to a bundle directory or
+archive.
+
+Examples:
+
+    juju diff-bundle bundle.yaml
+    juju export-bundle --filename current.yaml && juju diff-bundle current.yaml
+`
+
+func (c *diffBundleCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "diff-bundle",
+		Args:    "<bundle>",
+		Purpose: "compare a bundle with the current model",
+		Doc:     diffBundleDoc,
+	}
+}
+
+func (c *diffBundleCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no bundle specified")
+	}
+	c.BundlePath = args[0]
+	return cmd.CheckEmpty(args[1:])
+}
+
+// Run compares the bundle with the model and writes out the
+// changes needed to make the model match the bundle.
+func (c *diffBundleCommand) Run(ctx *cmd.Context) error {
+	data, err := readLocalBundle(ctx.AbsPath(c.BundlePath))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer root.Close()
+
+	changes, err := modelBundleChanges(root.Client(), apiservice.NewClient(root), data)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(changes) == 0 {
+		fmt.Fprintln(ctx.Stdout, "no changes")
+		return nil
+	}
+	for _, change := range changes {
+		fmt.Fprintln(ctx.Stdout, change)
+	}
+	return nil
+}
+
+// readLocalBundle reads the bundle data from the given bundle.yaml
+// file, bundle directory or bundle archive.
+func readLocalBundle(path string) (*charm.BundleData, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot read bundle")
+	}
+	if info.IsDir() || !isBundleFile(path) {
+		bundle, _, err := charmrepo.NewBundleAtPath(path)
+		if err != nil {
+			return nil, errors.Annotate(err, "cannot read bundle")
+		}
+		return bundle.Data(), nil
+	}
+	data, err := charmrepo.ReadBundleFile(path)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot read bundle")
+	}
+	return data, nil
+}
+
+// isBundleFile reports whether the given path looks like a YAML
+// bundle file rather than a bundle archive.
+func isBundleFile(path string) bool {
+	ext := filepath.Ext(path)
+	return ext == ".yaml" || ext == ".yml"
+}
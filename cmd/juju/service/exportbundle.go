@@ -0,0 +1,105 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package service
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"launchpad.net/gnuflag"
+
+	"github.com/juju/juju/api/service"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+// NewExportBundleCommand returns a command used to export the
+// current model as a bundle.
+func NewExportBundleCommand() cmd.Command {
+	return modelcmd.Wrap(&exportBundleCommand{})
+}
+
+// exportBundleCommand writes out a bundle describing the model.
+type exportBundleCommand struct {
+	modelcmd.ModelCommandBase
+	Filename string
+	api      exportBundleAPI
+}
+
+const exportBundleDoc = `
+Exports the services, machines and relations of the current model as a bundle.
+The bundle includes the charm, number of units, unit placement, configuration,
+constraints, annotations, exposure and endpoint bindings of each service, and
+may be deployed with "juju deploy" to recreate the same topology elsewhere.
+
+The bundle is written to standard output, or to the file given by --filename.
+
+Units on containers are placed in new containers of the same type on the
+exported host machine. Peer relations and subordinate units are implied by
+the charms and relations in the bundle.
+
+Examples:
+
+    juju export-bundle
+    juju export-bundle --filename mymodel.yaml
+`
+
+func (c *exportBundleCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "export-bundle",
+		Purpose: "export the current model as a bundle",
+		Doc:     exportBundleDoc,
+	}
+}
+
+func (c *exportBundleCommand) SetFlags(f *gnuflag.FlagSet) {
+	f.StringVar(&c.Filename, "filename", "", "write the bundle to the given file")
+}
+
+func (c *exportBundleCommand) Init(args []string) error {
+	return cmd.CheckEmpty(args)
+}
+
+// exportBundleAPI defines the methods on the service API
+// that the export-bundle command calls.
+type exportBundleAPI interface {
+	Close() error
+	ExportBundle() (string, error)
+}
+
+func (c *exportBundleCommand) getAPI() (exportBundleAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return service.NewClient(root), nil
+}
+
+// Run fetches the bundle describing the model and writes it out.
+func (c *exportBundleCommand) Run(ctx *cmd.Context) error {
+	apiclient, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer apiclient.Close()
+
+	bundle, err := apiclient.ExportBundle()
+	if err != nil {
+		return errors.Annotate(err, "cannot export bundle")
+	}
+	if c.Filename == "" {
+		_, err := fmt.Fprint(ctx.Stdout, bundle)
+		return err
+	}
+	path := ctx.AbsPath(c.Filename)
+	if err := ioutil.WriteFile(path, []byte(bundle), 0644); err != nil {
+		return errors.Annotate(err, "cannot write bundle")
+	}
+	ctx.Infof("bundle written to %s", path)
+	return nil
+}
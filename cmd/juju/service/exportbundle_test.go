@@ -0,0 +1,161 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package service
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6-unstable"
+
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/testcharms"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type ExportBundleSuite struct {
+	coretesting.FakeJujuXDGDataHomeSuite
+	fake *fakeExportBundleAPI
+}
+
+var _ = gc.Suite(&ExportBundleSuite{})
+
+func (s *ExportBundleSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.fake = &fakeExportBundleAPI{bundle: "services: {}\n"}
+}
+
+func (s *ExportBundleSuite) runExportBundle(c *gc.C, args ...string) (*cmd.Context, error) {
+	return coretesting.RunCommand(c, modelcmd.Wrap(&exportBundleCommand{api: s.fake}), args...)
+}
+
+func (s *ExportBundleSuite) TestInit(c *gc.C) {
+	err := coretesting.InitCommand(&exportBundleCommand{}, []string{"extra"})
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["extra"\]`)
+}
+
+func (s *ExportBundleSuite) TestExportBundle(c *gc.C) {
+	ctx, err := s.runExportBundle(c)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(coretesting.Stdout(ctx), gc.Equals, "services: {}\n")
+	c.Assert(s.fake.closed, jc.IsTrue)
+}
+
+func (s *ExportBundleSuite) TestExportBundleToFile(c *gc.C) {
+	ctx, err := s.runExportBundle(c, "--filename", "bundle.yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(coretesting.Stdout(ctx), gc.Equals, "")
+	content, err := ioutil.ReadFile(filepath.Join(ctx.Dir, "bundle.yaml"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(content), gc.Equals, "services: {}\n")
+}
+
+func (s *ExportBundleSuite) TestExportBundleError(c *gc.C) {
+	s.fake.err = errors.New("boom")
+	_, err := s.runExportBundle(c)
+	c.Assert(err, gc.ErrorMatches, "cannot export bundle: boom")
+}
+
+type fakeExportBundleAPI struct {
+	bundle string
+	err    error
+	closed bool
+}
+
+func (f *fakeExportBundleAPI) Close() error {
+	f.closed = true
+	return nil
+}
+
+func (f *fakeExportBundleAPI) ExportBundle() (string, error) {
+	return f.bundle, f.err
+}
+
+func (s *deployRepoCharmStoreSuite) TestExportBundleRoundTrip(c *gc.C) {
+	testcharms.UploadCharm(c, s.client, "trusty/wordpress-42", "wordpress")
+	testcharms.UploadCharm(c, s.client, "trusty/mysql-42", "mysql")
+	_, err := s.deployBundleYAML(c, `
+        services:
+            wordpress:
+                charm: cs:trusty/wordpress-42
+                num_units: 1
+                expose: true
+                options:
+                    blog-title: exported
+                annotations:
+                    gui-x: "10"
+                constraints: mem=2G
+                to: [1]
+            mysql:
+                charm: cs:trusty/mysql-42
+                num_units: 1
+                to: ["lxc:1"]
+        machines:
+            1:
+                series: trusty
+                constraints: cpu-cores=2
+                annotations: {rack: a}
+        relations:
+            - ["wordpress:db", "mysql:server"]
+    `)
+	c.Assert(err, jc.ErrorIsNil)
+	units := map[string]string{
+		"wordpress/0": "0",
+		"mysql/0":     "0/lxc/0",
+	}
+	s.assertUnitsCreated(c, units)
+
+	ctx, err := coretesting.RunCommand(c, NewExportBundleCommand())
+	c.Assert(err, jc.ErrorIsNil)
+	exported := coretesting.Stdout(ctx)
+	data, err := charm.ReadBundleData(strings.NewReader(exported))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(data, jc.DeepEquals, &charm.BundleData{
+		Services: map[string]*charm.ServiceSpec{
+			"wordpress": {
+				Charm:       "cs:trusty/wordpress-42",
+				NumUnits:    1,
+				To:          []string{"0"},
+				Expose:      true,
+				Options:     map[string]interface{}{"blog-title": "exported"},
+				Annotations: map[string]string{"gui-x": "10"},
+				Constraints: "mem=2048M",
+			},
+			"mysql": {
+				Charm:    "cs:trusty/mysql-42",
+				NumUnits: 1,
+				To:       []string{"lxc:0"},
+			},
+		},
+		Machines: map[string]*charm.MachineSpec{
+			"0": {
+				Series:      "trusty",
+				Constraints: "cpu-cores=2",
+				Annotations: map[string]string{"rack": "a"},
+			},
+		},
+		Relations: [][]string{{"mysql:server", "wordpress:db"}},
+	})
+
+	// Deploying the exported bundle into a fresh model recreates
+	// the same topology, and exporting it again gives the same bundle.
+	s.charmStoreSuite.JujuConnSuite.Reset(c)
+	testcharms.UploadCharm(c, s.client, "trusty/wordpress-42", "wordpress")
+	testcharms.UploadCharm(c, s.client, "trusty/mysql-42", "mysql")
+	services, err := s.State.AllServices()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(services, gc.HasLen, 0)
+	_, err = s.deployBundleYAML(c, exported)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertUnitsCreated(c, units)
+	s.assertRelationsEstablished(c, "wordpress:db mysql:server")
+	ctx, err = coretesting.RunCommand(c, NewExportBundleCommand())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(coretesting.Stdout(ctx), gc.Equals, exported)
+}
@@ -5,6 +5,7 @@ package service_test
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/juju/errors"
 
@@ -18,6 +19,7 @@ type fakeServiceAPI struct {
 	charmName   string
 	values      map[string]interface{}
 	config      string
+	hookTimeout *time.Duration
 	err         error
 }
 
@@ -31,6 +33,7 @@ func (f *fakeServiceAPI) Update(args params.ServiceUpdate) error {
 	}
 
 	f.config = args.SettingsYAML
+	f.hookTimeout = args.HookTimeout
 	return nil
 }
 
@@ -8,6 +8,7 @@ import (
 	"io/ioutil"
 	"os"
 	"strings"
+	"time"
 	"unicode/utf8"
 
 	"github.com/juju/cmd"
@@ -34,6 +35,8 @@ type setCommand struct {
 	Options         []string
 	SettingsYAML    cmd.FileVar
 	SetDefault      bool
+	HookTimeout     *time.Duration
+	hookTimeout     string
 	serviceApi      serviceAPI
 }
 
@@ -46,6 +49,15 @@ size of this value is 5M.
 
 Option values may be any UTF-8 encoded string. UTF-8 is accepted on the command
 line and in configuration files.
+
+The --hook-timeout option sets how long the service's hooks may run before
+they are killed and the unit is put into an error state, overriding the
+model's hook-timeout setting. A timeout of 0 reverts to the model's setting.
+
+Examples:
+
+    juju set-config mysql dataset-size=80%
+    juju set-config mysql --hook-timeout 30m
 `
 
 const maxValueSize = 5242880
@@ -65,6 +77,7 @@ func (c *setCommand) Info() *cmd.Info {
 func (c *setCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.Var(&c.SettingsYAML, "config", "path to yaml-formatted service config")
 	f.BoolVar(&c.SetDefault, "to-default", false, "set service option values to default")
+	f.StringVar(&c.hookTimeout, "hook-timeout", "", "time the service's hooks may run for before they are killed")
 }
 
 // Init implements Command.Init.
@@ -76,6 +89,13 @@ func (c *setCommand) Init(args []string) error {
 		return errors.New("cannot specify --config when using key=value arguments")
 	}
 	c.ServiceName = args[0]
+	if c.hookTimeout != "" {
+		timeout, err := time.ParseDuration(c.hookTimeout)
+		if err != nil || timeout < 0 {
+			return errors.Errorf("invalid hook timeout %q", c.hookTimeout)
+		}
+		c.HookTimeout = &timeout
+	}
 	if c.SetDefault {
 		c.Options = args[1:]
 		if len(c.Options) == 0 {
@@ -120,6 +140,15 @@ func (c *setCommand) Run(ctx *cmd.Context) error {
 	}
 	defer apiclient.Close()
 
+	if c.HookTimeout != nil {
+		err := apiclient.Update(params.ServiceUpdate{
+			ServiceName: c.ServiceName,
+			HookTimeout: c.HookTimeout,
+		})
+		if err != nil {
+			return block.ProcessBlockedError(err, block.BlockChange)
+		}
+	}
 	if c.SettingsYAML.Path != "" {
 		b, err := c.SettingsYAML.Read(ctx)
 		if err != nil {
@@ -8,6 +8,7 @@ import (
 	"io/ioutil"
 	"os"
 	"strings"
+	"time"
 	"unicode/utf8"
 
 	"github.com/juju/cmd"
@@ -68,6 +69,9 @@ func (s *SetSuite) TestSetCommandInit(c *gc.C) {
 	err = coretesting.InitCommand(service.NewSetCommandForTest(s.fakeServiceAPI), []string{"service", "--to-default"})
 	c.Assert(err, gc.ErrorMatches, "no configuration options specified")
 
+	// invalid --hook-timeout
+	err = coretesting.InitCommand(service.NewSetCommandForTest(s.fakeServiceAPI), []string{"service", "--hook-timeout", "-5m"})
+	c.Assert(err, gc.ErrorMatches, `invalid hook timeout "-5m"`)
 }
 
 func (s *SetSuite) TestSetOptionSuccess(c *gc.C) {
@@ -145,6 +149,17 @@ func (s *SetSuite) TestSetConfig(c *gc.C) {
 	c.Check(s.fakeServiceAPI.config, gc.Equals, yamlConfigValue)
 }
 
+func (s *SetSuite) TestSetHookTimeout(c *gc.C) {
+	ctx := coretesting.ContextForDir(c, s.dir)
+	code := cmd.Main(service.NewSetCommandForTest(s.fakeServiceAPI), ctx, []string{
+		"dummy-service",
+		"--hook-timeout",
+		"30m"})
+	c.Check(code, gc.Equals, 0)
+	c.Assert(s.fakeServiceAPI.hookTimeout, gc.NotNil)
+	c.Check(*s.fakeServiceAPI.hookTimeout, gc.Equals, 30*time.Minute)
+}
+
 func (s *SetSuite) TestSetConfigToDefault(c *gc.C) {
 	s.fakeServiceAPI = &fakeServiceAPI{serviceName: "dummy-service", values: map[string]interface{}{
 		"username": "hello",
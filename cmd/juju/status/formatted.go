@@ -30,6 +30,7 @@ type errorStatus struct {
 
 type modelStatus struct {
 	AvailableVersion string `json:"upgrade-available,omitempty" yaml:"upgrade-available,omitempty"`
+	LogUsage         string `json:"log-usage,omitempty" yaml:"log-usage,omitempty"`
 }
 
 type machineStatus struct {
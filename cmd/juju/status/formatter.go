@@ -4,6 +4,8 @@
 package status
 
 import (
+	"fmt"
+
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/juju/common"
 	"github.com/juju/juju/state/multiwatcher"
@@ -29,6 +31,18 @@ func NewStatusFormatter(status *params.FullStatus, isoTime bool) *statusFormatte
 	return &sf
 }
 
+// formatLogUsage describes the size of a model's logs, and its limit
+// if it has one.
+func formatLogUsage(logsMB float64, maxMB int) string {
+	switch {
+	case maxMB > 0:
+		return fmt.Sprintf("%.1fMB of %dMB", logsMB, maxMB)
+	case logsMB > 0:
+		return fmt.Sprintf("%.1fMB", logsMB)
+	}
+	return ""
+}
+
 func (sf *statusFormatter) format() formattedStatus {
 	if sf.status == nil {
 		return formattedStatus{}
@@ -38,9 +52,10 @@ func (sf *statusFormatter) format() formattedStatus {
 		Machines: make(map[string]machineStatus),
 		Services: make(map[string]serviceStatus),
 	}
-	if sf.status.AvailableVersion != "" {
+	if sf.status.AvailableVersion != "" || sf.status.LogsMB > 0 || sf.status.LogsMaxMB > 0 {
 		out.ModelStatus = &modelStatus{
 			AvailableVersion: sf.status.AvailableVersion,
+			LogUsage:         formatLogUsage(sf.status.LogsMB, sf.status.LogsMaxMB),
 		}
 	}
 
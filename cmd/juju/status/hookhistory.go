@@ -0,0 +1,200 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package status
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/names"
+	"launchpad.net/gnuflag"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/juju/osenv"
+)
+
+// NewHookHistoryCommand returns a command that reports the hooks
+// most recently run by a unit.
+func NewHookHistoryCommand() cmd.Command {
+	return modelcmd.Wrap(&hookHistoryCommand{})
+}
+
+// hookHistoryCommand shows the hooks most recently run by a unit.
+type hookHistoryCommand struct {
+	modelcmd.ModelCommandBase
+	out      cmd.Output
+	size     int
+	isoTime  bool
+	unitName string
+	api      hookHistoryAPI
+}
+
+const hookHistoryDoc = `
+Shows the hooks most recently run by a unit, oldest first: the name of each
+hook, the relation and remote unit of relation hooks, when the hook started,
+how long it ran for, its exit code and the end of its standard error output.
+
+Only the last 100 hooks run by each unit are kept.
+
+Examples:
+
+    juju show-unit-history wordpress/0
+    juju show-unit-history -n 50 --format yaml wordpress/0
+`
+
+// Info implements Command.Info.
+func (c *hookHistoryCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "show-unit-history",
+		Args:    "[-n N] <unit>",
+		Purpose: "show the hooks most recently run by a unit",
+		Doc:     hookHistoryDoc,
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *hookHistoryCommand) SetFlags(f *gnuflag.FlagSet) {
+	f.IntVar(&c.size, "n", 20, "number of hooks to show")
+	f.BoolVar(&c.isoTime, "utc", false, "display time as UTC in RFC3339 format")
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatHookHistoryTabular,
+	})
+}
+
+// Init implements Command.Init.
+func (c *hookHistoryCommand) Init(args []string) error {
+	switch len(args) {
+	case 0:
+		return errors.New("no unit name specified")
+	case 1:
+		c.unitName = args[0]
+	default:
+		return cmd.CheckEmpty(args[1:])
+	}
+	if !names.IsValidUnit(c.unitName) {
+		return errors.Errorf("invalid unit name %q", c.unitName)
+	}
+	if c.size < 1 {
+		return errors.Errorf("invalid history size %d", c.size)
+	}
+	// If use of ISO time not specified on command line,
+	// check env var.
+	if !c.isoTime {
+		var err error
+		envVarValue := os.Getenv(osenv.JujuStatusIsoTimeEnvKey)
+		if envVarValue != "" {
+			if c.isoTime, err = strconv.ParseBool(envVarValue); err != nil {
+				return errors.Annotatef(err, "invalid %s env var, expected true|false", osenv.JujuStatusIsoTimeEnvKey)
+			}
+		}
+	}
+	return nil
+}
+
+// hookHistoryAPI defines the methods on the client API that the
+// show-unit-history command calls.
+type hookHistoryAPI interface {
+	Close() error
+	UnitHookHistory(unitName string, size int) ([]params.HookRecord, error)
+}
+
+func (c *hookHistoryCommand) getAPI() (hookHistoryAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	return c.NewAPIClient()
+}
+
+// hookHistoryEntry defines the serialization of a hook run by a unit.
+type hookHistoryEntry struct {
+	Hook       string `yaml:"hook" json:"hook"`
+	Relation   string `yaml:"relation,omitempty" json:"relation,omitempty"`
+	RemoteUnit string `yaml:"remote-unit,omitempty" json:"remote-unit,omitempty"`
+	Started    string `yaml:"started" json:"started"`
+	Duration   string `yaml:"duration" json:"duration"`
+	ExitCode   int    `yaml:"exit-code" json:"exit-code"`
+	Stderr     string `yaml:"stderr,omitempty" json:"stderr,omitempty"`
+}
+
+// Run implements Command.Run.
+func (c *hookHistoryCommand) Run(ctx *cmd.Context) error {
+	apiclient, err := c.getAPI()
+	if err != nil {
+		return errors.Errorf(connectionError, c.ConnectionName(), err)
+	}
+	defer apiclient.Close()
+
+	records, err := apiclient.UnitHookHistory(c.unitName, c.size)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(records) == 0 {
+		return errors.Errorf("no hook history available for unit %q", c.unitName)
+	}
+	// Records come newest first; show them in the order they ran.
+	entries := make([]hookHistoryEntry, len(records))
+	for i, record := range records {
+		started := record.Started
+		entries[len(records)-1-i] = hookHistoryEntry{
+			Hook:       record.Hook,
+			Relation:   record.Relation,
+			RemoteUnit: record.RemoteUnit,
+			Started:    common.FormatTime(&started, c.isoTime),
+			Duration:   (record.Duration / time.Millisecond * time.Millisecond).String(),
+			ExitCode:   record.ExitCode,
+			Stderr:     record.Stderr,
+		}
+	}
+	return c.out.Write(ctx, entries)
+}
+
+// maxTabularStderr holds the number of characters of a hook's
+// standard error output shown in tabular output.
+const maxTabularStderr = 60
+
+// formatHookHistoryTabular writes the hook history as a table, showing
+// only the end of the last line each hook wrote to standard error.
+func formatHookHistoryTabular(value interface{}) ([]byte, error) {
+	entries, ok := value.([]hookHistoryEntry)
+	if !ok {
+		return nil, errors.Errorf("expected value of type %T, got %T", entries, value)
+	}
+	var out bytes.Buffer
+	const (
+		// To format things into columns.
+		minwidth = 0
+		tabwidth = 1
+		padding  = 2
+		padchar  = ' '
+		flags    = 0
+	)
+	tw := tabwriter.NewWriter(&out, minwidth, tabwidth, padding, padchar, flags)
+	fmt.Fprintf(tw, "TIME\tHOOK\tRELATION\tDURATION\tEXIT\tSTDERR\n")
+	for _, entry := range entries {
+		relation := strings.TrimSpace(entry.Relation + " " + entry.RemoteUnit)
+		stderr := entry.Stderr
+		if i := strings.LastIndex(stderr, "\n"); i >= 0 {
+			stderr = stderr[i+1:]
+		}
+		if len(stderr) > maxTabularStderr {
+			stderr = "..." + stderr[len(stderr)-maxTabularStderr:]
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%d\t%s\n",
+			entry.Started, entry.Hook, relation, entry.Duration, entry.ExitCode, stderr,
+		)
+	}
+	tw.Flush()
+	return out.Bytes(), nil
+}
@@ -0,0 +1,125 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package status
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type hookHistorySuite struct {
+	coretesting.BaseSuite
+	fake *fakeHookHistoryAPI
+}
+
+var _ = gc.Suite(&hookHistorySuite{})
+
+type fakeHookHistoryAPI struct {
+	unitName string
+	size     int
+	records  []params.HookRecord
+}
+
+func (f *fakeHookHistoryAPI) Close() error {
+	return nil
+}
+
+func (f *fakeHookHistoryAPI) UnitHookHistory(unitName string, size int) ([]params.HookRecord, error) {
+	f.unitName = unitName
+	f.size = size
+	return f.records, nil
+}
+
+func (s *hookHistorySuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	started := time.Date(2016, 4, 1, 12, 0, 0, 0, time.UTC)
+	s.fake = &fakeHookHistoryAPI{
+		records: []params.HookRecord{{
+			Hook:       "db-relation-joined",
+			Relation:   "db:2",
+			RemoteUnit: "mysql/0",
+			Started:    started.Add(2 * time.Minute),
+			Duration:   1500*time.Millisecond + 42*time.Microsecond,
+			ExitCode:   1,
+			Stderr:     "connecting\ncannot connect",
+		}, {
+			Hook:     "install",
+			Started:  started,
+			Duration: 90 * time.Second,
+		}},
+	}
+}
+
+func (s *hookHistorySuite) newCommand() cmd.Command {
+	return modelcmd.Wrap(&hookHistoryCommand{api: s.fake})
+}
+
+func (s *hookHistorySuite) TestInit(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: nil,
+		err:  "no unit name specified",
+	}, {
+		args: []string{"wordpress"},
+		err:  `invalid unit name "wordpress"`,
+	}, {
+		args: []string{"wordpress/0", "extra"},
+		err:  `unrecognized args: \["extra"\]`,
+	}, {
+		args: []string{"-n", "0", "wordpress/0"},
+		err:  "invalid history size 0",
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		_, err := coretesting.RunCommand(c, s.newCommand(), test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *hookHistorySuite) TestTabular(c *gc.C) {
+	ctx, err := coretesting.RunCommand(c, s.newCommand(), "-m", "dummymodel", "--utc", "-n", "5", "wordpress/0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fake.unitName, gc.Equals, "wordpress/0")
+	c.Assert(s.fake.size, gc.Equals, 5)
+	c.Assert(coretesting.Stdout(ctx), gc.Equals, ""+
+		"TIME                  HOOK                RELATION      DURATION  EXIT  STDERR\n"+
+		"2016-04-01T12:00:00Z  install                           1m30s     0     \n"+
+		"2016-04-01T12:02:00Z  db-relation-joined  db:2 mysql/0  1.5s      1     cannot connect\n",
+	)
+}
+
+func (s *hookHistorySuite) TestYAML(c *gc.C) {
+	ctx, err := coretesting.RunCommand(c, s.newCommand(), "-m", "dummymodel", "--utc", "--format", "yaml", "wordpress/0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fake.size, gc.Equals, 20)
+	c.Assert(coretesting.Stdout(ctx), gc.Equals, ""+
+		"- hook: install\n"+
+		"  started: 2016-04-01T12:00:00Z\n"+
+		"  duration: 1m30s\n"+
+		"  exit-code: 0\n"+
+		"- hook: db-relation-joined\n"+
+		"  relation: db:2\n"+
+		"  remote-unit: mysql/0\n"+
+		"  started: 2016-04-01T12:02:00Z\n"+
+		"  duration: 1.5s\n"+
+		"  exit-code: 1\n"+
+		"  stderr: |-\n"+
+		"    connecting\n"+
+		"    cannot connect\n",
+	)
+}
+
+func (s *hookHistorySuite) TestNoHistory(c *gc.C) {
+	s.fake.records = nil
+	_, err := coretesting.RunCommand(c, s.newCommand(), "-m", "dummymodel", "wordpress/0")
+	c.Assert(err, gc.ErrorMatches, `no hook history available for unit "wordpress/0"`)
+}
@@ -95,10 +95,17 @@ func FormatTabular(value interface{}) ([]byte, error) {
 
 	if envStatus := fs.ModelStatus; envStatus != nil {
 		p("[Model]")
+		var header, values []interface{}
 		if envStatus.AvailableVersion != "" {
-			p("UPGRADE-AVAILABLE")
-			p(envStatus.AvailableVersion)
+			header = append(header, "UPGRADE-AVAILABLE")
+			values = append(values, envStatus.AvailableVersion)
 		}
+		if envStatus.LogUsage != "" {
+			header = append(header, "LOG-USAGE")
+			values = append(values, envStatus.LogUsage)
+		}
+		p(header...)
+		p(values...)
 		p()
 		tw.Flush()
 	}
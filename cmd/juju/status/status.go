@@ -13,6 +13,7 @@ import (
 	"github.com/juju/loggo"
 	"launchpad.net/gnuflag"
 
+	"github.com/juju/juju/api"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/modelcmd"
 	"github.com/juju/juju/juju/osenv"
@@ -22,6 +23,7 @@ var logger = loggo.GetLogger("juju.cmd.juju.status")
 
 type statusAPI interface {
 	Status(patterns []string) (*params.FullStatus, error)
+	WatchAll() (*api.AllWatcher, error)
 	Close() error
 }
 
@@ -36,6 +38,7 @@ type statusCommand struct {
 	out      cmd.Output
 	patterns []string
 	isoTime  bool
+	watch    bool
 	api      statusAPI
 }
 
@@ -70,6 +73,10 @@ will be displayed.
 Wildcards ('*') may be specified in service/unit names to match any sequence
 of characters. For example, 'nova-*' will match any service whose name begins
 with 'nova-': 'nova-compute', 'nova-volume', etc.
+
+With --watch, the status is displayed in tabular format and updated in
+place whenever the model changes, with changed rows highlighted, until
+interrupted.
 `
 
 func (c *statusCommand) Info() *cmd.Info {
@@ -84,6 +91,7 @@ func (c *statusCommand) Info() *cmd.Info {
 
 func (c *statusCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.BoolVar(&c.isoTime, "utc", false, "display time as UTC in RFC3339 format")
+	f.BoolVar(&c.watch, "watch", false, "update the status as the model changes")
 
 	defaultFormat := "tabular"
 
@@ -100,6 +108,9 @@ func (c *statusCommand) SetFlags(f *gnuflag.FlagSet) {
 
 func (c *statusCommand) Init(args []string) error {
 	c.patterns = args
+	if c.watch && c.out.Name() != "tabular" {
+		return errors.Errorf("--watch can only be used with the tabular format")
+	}
 	// If use of ISO time not specified on command line,
 	// check env var.
 	if !c.isoTime {
@@ -132,6 +143,10 @@ func (c *statusCommand) Run(ctx *cmd.Context) error {
 	}
 	defer apiclient.Close()
 
+	if c.watch {
+		return c.runWatch(ctx, apiclient)
+	}
+
 	status, err := apiclient.Status(c.patterns)
 	if err != nil {
 		if status == nil {
@@ -13,11 +13,13 @@ import (
 	"time"
 
 	"github.com/juju/cmd"
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/charm.v6-unstable"
 	goyaml "gopkg.in/yaml.v2"
 
+	"github.com/juju/juju/api"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/modelcmd"
 	"github.com/juju/juju/constraints"
@@ -2809,6 +2811,10 @@ func (a *fakeApiClient) Status(patterns []string) (*params.FullStatus, error) {
 	return a.statusReturn, nil
 }
 
+func (a *fakeApiClient) WatchAll() (*api.AllWatcher, error) {
+	return nil, errors.NotImplementedf("WatchAll")
+}
+
 func (a *fakeApiClient) Close() error {
 	a.closeCalled = true
 	return nil
@@ -3107,6 +3113,36 @@ ID         STATE DNS INS-ID SERIES AZ
 `[1:])
 }
 
+func (s *StatusSuite) TestFormatLogUsage(c *gc.C) {
+	c.Check(formatLogUsage(0, 0), gc.Equals, "")
+	c.Check(formatLogUsage(12.34, 0), gc.Equals, "12.3MB")
+	c.Check(formatLogUsage(12.34, 100), gc.Equals, "12.3MB of 100MB")
+}
+
+func (s *StatusSuite) TestFormatTabularLogUsage(c *gc.C) {
+	status := formattedStatus{
+		ModelStatus: &modelStatus{
+			LogUsage: "12.3MB of 100MB",
+		},
+	}
+	out, err := FormatTabular(status)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(out), gc.Equals, `
+[Model]         
+LOG-USAGE       
+12.3MB of 100MB 
+
+[Services] 
+NAME       STATUS EXPOSED CHARM 
+
+[Units] 
+ID      WORKLOAD-STATE AGENT-STATE VERSION MACHINE PORTS PUBLIC-ADDRESS MESSAGE 
+
+[Machines] 
+ID         STATE DNS INS-ID SERIES AZ 
+`[1:])
+}
+
 func (s *StatusSuite) TestStatusWithNilStatusApi(c *gc.C) {
 	ctx := s.newContext(c)
 	defer s.resetContext(c, ctx)
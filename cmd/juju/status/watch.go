@@ -0,0 +1,214 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package status
+
+import (
+	"bytes"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/state/multiwatcher"
+)
+
+const (
+	// clearScreen moves the cursor to the top left of the
+	// terminal and clears it.
+	clearScreen = "\x1b[H\x1b[2J"
+
+	// highlightOn and highlightOff surround rows that have
+	// changed since the previous update.
+	highlightOn  = "\x1b[1m"
+	highlightOff = "\x1b[0m"
+)
+
+var (
+	// watchSettleDelay holds how long the model must go without
+	// changes before the status is displayed again, so that a burst
+	// of changes results in a single update.
+	watchSettleDelay = 2 * time.Second
+
+	// watchMaxDelay holds the longest time to wait for the model
+	// to settle, so that the status of a model that is always
+	// changing is still updated periodically.
+	watchMaxDelay = 10 * time.Second
+)
+
+// allWatcher is the part of api.AllWatcher used by status --watch.
+type allWatcher interface {
+	Next() ([]multiwatcher.Delta, error)
+	Stop() error
+}
+
+var watchAll = func(apiclient statusAPI) (allWatcher, error) {
+	w, err := apiclient.WatchAll()
+	if err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// pendingDeltas accumulates the changes reported by the AllWatcher
+// until they are applied to the status.
+type pendingDeltas struct {
+	mu     sync.Mutex
+	deltas []multiwatcher.Delta
+}
+
+func (p *pendingDeltas) add(deltas []multiwatcher.Delta) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.deltas = append(p.deltas, deltas...)
+}
+
+func (p *pendingDeltas) take() []multiwatcher.Delta {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	deltas := p.deltas
+	p.deltas = nil
+	return deltas
+}
+
+// runWatch displays the status in tabular form, then displays it
+// again each time the model changes, until interrupted. Rows that
+// have changed since the previous update are highlighted. The full
+// status is fetched once, and then kept up to date with the changes
+// reported by the AllWatcher. It is only fetched again if a change
+// cannot be applied.
+func (c *statusCommand) runWatch(ctx *cmd.Context, apiclient statusAPI) error {
+	w, err := watchAll(apiclient)
+	if err != nil {
+		return errors.Annotate(err, "cannot watch model")
+	}
+	var pending pendingDeltas
+	changes := make(chan struct{}, 1)
+	watchErr := make(chan error, 1)
+	go func() {
+		for {
+			deltas, err := w.Next()
+			if err != nil {
+				watchErr <- err
+				return
+			}
+			pending.add(deltas)
+			// Coalesce changes that arrive before the
+			// previous ones have been displayed.
+			select {
+			case changes <- struct{}{}:
+			default:
+			}
+		}
+	}()
+	defer w.Stop()
+
+	interrupted := make(chan os.Signal, 1)
+	ctx.InterruptNotify(interrupted)
+	defer ctx.StopInterruptNotify(interrupted)
+
+	var ws *watchStatus
+	var previous []byte
+	for {
+		if ws == nil || !ws.apply(pending.take()) {
+			status, err := apiclient.Status(c.patterns)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			if status == nil {
+				return errors.Errorf("unable to obtain the current status")
+			}
+			ws = newWatchStatus(status, len(c.patterns) > 0)
+		}
+		current, err := FormatTabular(NewStatusFormatter(ws.status, c.isoTime).format())
+		if err != nil {
+			return errors.Trace(err)
+		}
+		var out bytes.Buffer
+		out.WriteString(clearScreen)
+		out.Write(highlightChanges(previous, current))
+		ctx.Stdout.Write(out.Bytes())
+		previous = current
+
+		select {
+		case <-changes:
+		case err := <-watchErr:
+			return errors.Annotate(err, "watching model")
+		case <-interrupted:
+			return nil
+		}
+		if err := waitForSettle(changes, watchErr, interrupted); err != nil {
+			if err == errInterrupted {
+				return nil
+			}
+			return errors.Annotate(err, "watching model")
+		}
+	}
+}
+
+var errInterrupted = errors.New("interrupted")
+
+// waitForSettle waits until no changes have arrived for
+// watchSettleDelay, or until watchMaxDelay has passed, whichever
+// comes first. It returns errInterrupted if interrupted, or the
+// error from the watcher if it fails.
+func waitForSettle(changes <-chan struct{}, watchErr <-chan error, interrupted <-chan os.Signal) error {
+	deadline := time.After(watchMaxDelay)
+	settle := time.NewTimer(watchSettleDelay)
+	defer settle.Stop()
+	for {
+		select {
+		case <-changes:
+			if !settle.Stop() {
+				<-settle.C
+			}
+			settle.Reset(watchSettleDelay)
+		case <-settle.C:
+			return nil
+		case <-deadline:
+			return nil
+		case err := <-watchErr:
+			return err
+		case <-interrupted:
+			return errInterrupted
+		}
+	}
+}
+
+// highlightChanges returns the lines of current, highlighting
+// any that were not present in previous. Lines are compared
+// field by field, so that a change in column widths does not
+// highlight every row. Nothing is highlighted if there is no
+// previous output.
+func highlightChanges(previous, current []byte) []byte {
+	if previous == nil {
+		return current
+	}
+	seen := make(map[string]bool)
+	for _, line := range bytes.Split(previous, []byte("\n")) {
+		seen[rowKey(line)] = true
+	}
+	var out bytes.Buffer
+	lines := bytes.Split(current, []byte("\n"))
+	for i, line := range lines {
+		if key := rowKey(line); key != "" && !seen[key] {
+			out.WriteString(highlightOn)
+			out.Write(line)
+			out.WriteString(highlightOff)
+		} else {
+			out.Write(line)
+		}
+		if i < len(lines)-1 {
+			out.WriteByte('\n')
+		}
+	}
+	return out.Bytes()
+}
+
+// rowKey returns the fields of the given line separated by
+// single spaces.
+func rowKey(line []byte) string {
+	return string(bytes.Join(bytes.Fields(line), []byte(" ")))
+}
@@ -0,0 +1,224 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package status
+
+import (
+	"bytes"
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state/multiwatcher"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type watchSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&watchSuite{})
+
+func (s *watchSuite) TestHighlightChanges(c *gc.C) {
+	previous := []byte("ID  STATE\n0   pending\n1   started\n")
+	current := []byte("ID   STATE\n0    started\n1    started\n2    pending\n")
+	c.Assert(string(highlightChanges(previous, current)), gc.Equals,
+		"ID   STATE\n"+
+			highlightOn+"0    started"+highlightOff+"\n"+
+			"1    started\n"+
+			highlightOn+"2    pending"+highlightOff+"\n",
+	)
+}
+
+func (s *watchSuite) TestHighlightChangesFirstUpdate(c *gc.C) {
+	current := []byte("ID STATE\n0  pending\n")
+	c.Assert(string(highlightChanges(nil, current)), gc.Equals, string(current))
+}
+
+func (s *watchSuite) TestWatchRequiresTabularFormat(c *gc.C) {
+	err := coretesting.InitCommand(&statusCommand{}, []string{"--watch", "--format", "yaml"})
+	c.Assert(err, gc.ErrorMatches, "--watch can only be used with the tabular format")
+
+	err = coretesting.InitCommand(&statusCommand{}, []string{"--watch"})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *watchSuite) TestWatchUpdates(c *gc.C) {
+	s.PatchValue(&watchSettleDelay, time.Duration(0))
+	watcher, client, stdout, result := s.startWatch(c)
+
+	watcher.next <- nil
+	s.waitForUpdate(c, stdout)
+	s.stopWatch(c, watcher, result)
+	c.Assert(watcher.stopped, jc.IsTrue)
+	c.Assert(client.calls, gc.Equals, 1)
+
+	updates := bytes.Split(stdout.buf.Bytes(), []byte(clearScreen))
+	c.Assert(updates, gc.HasLen, 3)
+	c.Assert(string(updates[1]), gc.Not(jc.Contains), highlightOn)
+	c.Assert(string(updates[2]), gc.Matches, `(?s).*\n\x1b\[1m0 +started .*\x1b\[0m\n.*`)
+}
+
+func (s *watchSuite) TestWatchWaitsForChangesToSettle(c *gc.C) {
+	s.PatchValue(&watchSettleDelay, coretesting.ShortWait)
+	s.PatchValue(&watchMaxDelay, coretesting.LongWait)
+	watcher, _, stdout, result := s.startWatch(c)
+
+	for i := 0; i < 3; i++ {
+		watcher.next <- nil
+	}
+	s.waitForUpdate(c, stdout)
+	select {
+	case <-stdout.written:
+		c.Fatalf("status displayed more than once for a burst of changes")
+	case <-time.After(2 * coretesting.ShortWait):
+	}
+	s.stopWatch(c, watcher, result)
+}
+
+func (s *watchSuite) TestWatchMaxDelay(c *gc.C) {
+	s.PatchValue(&watchSettleDelay, time.Hour)
+	s.PatchValue(&watchMaxDelay, time.Duration(0))
+	watcher, _, stdout, result := s.startWatch(c)
+
+	watcher.next <- nil
+	s.waitForUpdate(c, stdout)
+	s.stopWatch(c, watcher, result)
+}
+
+func (s *watchSuite) TestWatchRefetchesUnknownUnit(c *gc.C) {
+	s.PatchValue(&watchSettleDelay, time.Duration(0))
+	watcher, client, stdout, result := s.startWatch(c)
+
+	watcher.deltas = []multiwatcher.Delta{{
+		Entity: &multiwatcher.UnitInfo{
+			Name:        "logging/0",
+			Service:     "logging",
+			Subordinate: true,
+		},
+	}}
+	client.statuses = append(client.statuses, machineStatus("started"))
+	watcher.next <- nil
+	s.waitForUpdate(c, stdout)
+	s.stopWatch(c, watcher, result)
+	c.Assert(client.calls, gc.Equals, 2)
+}
+
+// startWatch starts runWatch against a fake watcher and client,
+// and waits for the initial status to be displayed.
+func (s *watchSuite) startWatch(c *gc.C) (*fakeAllWatcher, *fakeWatchClient, *notifyWriter, <-chan error) {
+	watcher := &fakeAllWatcher{
+		next: make(chan error),
+		deltas: []multiwatcher.Delta{{
+			Entity: &multiwatcher.MachineInfo{
+				Id:     "0",
+				Status: multiwatcher.Status("started"),
+				Series: "trusty",
+			},
+		}},
+	}
+	s.PatchValue(&watchAll, func(statusAPI) (allWatcher, error) {
+		return watcher, nil
+	})
+	client := &fakeWatchClient{
+		statuses: []*params.FullStatus{machineStatus("pending")},
+	}
+	stdout := &notifyWriter{written: make(chan struct{}, 10)}
+	ctx := coretesting.Context(c)
+	ctx.Stdout = stdout
+	command := &statusCommand{}
+	result := make(chan error, 1)
+	go func() {
+		result <- command.runWatch(ctx, client)
+	}()
+	s.waitForUpdate(c, stdout)
+	return watcher, client, stdout, result
+}
+
+func (s *watchSuite) stopWatch(c *gc.C, watcher *fakeAllWatcher, result <-chan error) {
+	watcher.next <- errors.New("boom")
+	select {
+	case err := <-result:
+		c.Assert(err, gc.ErrorMatches, "watching model: boom")
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for watch to finish")
+	}
+}
+
+func (s *watchSuite) waitForUpdate(c *gc.C, stdout *notifyWriter) {
+	select {
+	case <-stdout.written:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for status")
+	}
+}
+
+func machineStatus(state params.Status) *params.FullStatus {
+	return &params.FullStatus{
+		ModelName: "dummymodel",
+		Machines: map[string]params.MachineStatus{
+			"0": {
+				Id:     "0",
+				Agent:  params.AgentStatus{Status: state},
+				Series: "trusty",
+			},
+		},
+	}
+}
+
+// notifyWriter records what is written to it, and signals
+// each write.
+type notifyWriter struct {
+	buf     bytes.Buffer
+	written chan struct{}
+}
+
+func (w *notifyWriter) Write(p []byte) (int, error) {
+	n, err := w.buf.Write(p)
+	w.written <- struct{}{}
+	return n, err
+}
+
+type fakeWatchClient struct {
+	statuses []*params.FullStatus
+	calls    int
+}
+
+func (f *fakeWatchClient) Status(patterns []string) (*params.FullStatus, error) {
+	status := f.statuses[0]
+	if len(f.statuses) > 1 {
+		f.statuses = f.statuses[1:]
+	}
+	f.calls++
+	return status, nil
+}
+
+func (f *fakeWatchClient) WatchAll() (*api.AllWatcher, error) {
+	return nil, errors.NotImplementedf("WatchAll")
+}
+
+func (f *fakeWatchClient) Close() error {
+	return nil
+}
+
+type fakeAllWatcher struct {
+	next    chan error
+	deltas  []multiwatcher.Delta
+	stopped bool
+}
+
+func (w *fakeAllWatcher) Next() ([]multiwatcher.Delta, error) {
+	if err := <-w.next; err != nil {
+		return nil, err
+	}
+	return w.deltas, nil
+}
+
+func (w *fakeAllWatcher) Stop() error {
+	w.stopped = true
+	return nil
+}
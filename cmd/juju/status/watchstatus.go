@@ -0,0 +1,349 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package status
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/juju/utils/set"
+	"gopkg.in/juju/charm.v6-unstable"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/state/multiwatcher"
+)
+
+// watchStatus keeps a model's status up to date with the changes
+// reported by an AllWatcher, so that status --watch need only fetch
+// the full status once. The parts of the status that the AllWatcher
+// does not report, such as the model's log usage, are left as they
+// were first fetched.
+type watchStatus struct {
+	status *params.FullStatus
+
+	// filtered is true if the status was fetched for a set of
+	// patterns. Entities that are not already in the status are
+	// then ignored, as they may not match the patterns.
+	filtered bool
+
+	// subordinates holds the names of the subordinate services.
+	subordinates set.Strings
+
+	// relations holds the model's relations, by key.
+	relations map[string]params.RelationStatus
+}
+
+func newWatchStatus(status *params.FullStatus, filtered bool) *watchStatus {
+	ws := &watchStatus{
+		status:       status,
+		filtered:     filtered,
+		subordinates: set.NewStrings(),
+		relations:    make(map[string]params.RelationStatus),
+	}
+	if status.Machines == nil {
+		status.Machines = make(map[string]params.MachineStatus)
+	}
+	if status.Services == nil {
+		status.Services = make(map[string]params.ServiceStatus)
+	}
+	for name, service := range status.Services {
+		if len(service.SubordinateTo) > 0 {
+			ws.subordinates.Add(name)
+		}
+	}
+	for _, relation := range status.Relations {
+		ws.relations[relation.Key] = relation
+	}
+	return ws
+}
+
+// apply updates the status with the given changes. It returns false
+// if a change could not be applied, in which case the full status
+// must be fetched again.
+func (ws *watchStatus) apply(deltas []multiwatcher.Delta) bool {
+	relationsChanged := false
+	for _, delta := range deltas {
+		switch info := delta.Entity.(type) {
+		case *multiwatcher.MachineInfo:
+			if !ws.applyMachine(info, delta.Removed) {
+				return false
+			}
+		case *multiwatcher.ServiceInfo:
+			ws.applyService(info, delta.Removed)
+			relationsChanged = true
+		case *multiwatcher.UnitInfo:
+			if !ws.applyUnit(info, delta.Removed) {
+				return false
+			}
+		case *multiwatcher.RelationInfo:
+			ws.applyRelation(info, delta.Removed)
+			relationsChanged = true
+		}
+	}
+	if relationsChanged {
+		ws.updateRelations()
+	}
+	return true
+}
+
+// machines returns the map that holds, or would hold, the status of
+// the identified machine, or nil if the machine is a container whose
+// host is not known.
+func (ws *watchStatus) machines(id string) map[string]params.MachineStatus {
+	parts := strings.Split(id, "/")
+	if len(parts) < 3 {
+		return ws.status.Machines
+	}
+	hostId := strings.Join(parts[:len(parts)-2], "/")
+	hosts := ws.machines(hostId)
+	if hosts == nil {
+		return nil
+	}
+	host, ok := hosts[hostId]
+	if !ok {
+		return nil
+	}
+	if host.Containers == nil {
+		host.Containers = make(map[string]params.MachineStatus)
+		hosts[hostId] = host
+	}
+	return host.Containers
+}
+
+func (ws *watchStatus) applyMachine(info *multiwatcher.MachineInfo, removed bool) bool {
+	machines := ws.machines(info.Id)
+	if machines == nil {
+		return ws.filtered || removed
+	}
+	if removed {
+		delete(machines, info.Id)
+		return true
+	}
+	machine, ok := machines[info.Id]
+	if !ok {
+		if ws.filtered {
+			return true
+		}
+		machine = params.MachineStatus{
+			Id:         info.Id,
+			Containers: make(map[string]params.MachineStatus),
+		}
+	}
+	machine.Agent.Status = params.Status(info.Status)
+	machine.Agent.Info = info.StatusInfo
+	machine.Agent.Data = info.StatusData
+	machine.Agent.Life = string(info.Life)
+	machine.Series = info.Series
+	machine.Jobs = info.Jobs
+	machine.HasVote = info.HasVote
+	machine.WantsVote = info.WantsVote
+	if info.InstanceId != "" {
+		machine.InstanceId = instance.Id(info.InstanceId)
+	} else {
+		machine.InstanceId = "pending"
+	}
+	if addr, ok := network.SelectPublicAddress(info.Addresses); ok {
+		machine.DNSName = addr.Value
+	}
+	if info.HardwareCharacteristics != nil {
+		machine.Hardware = info.HardwareCharacteristics.String()
+	}
+	machines[info.Id] = machine
+	return true
+}
+
+func (ws *watchStatus) applyService(info *multiwatcher.ServiceInfo, removed bool) {
+	if removed {
+		delete(ws.status.Services, info.Name)
+		return
+	}
+	if info.Subordinate {
+		ws.subordinates.Add(info.Name)
+	} else {
+		ws.subordinates.Remove(info.Name)
+	}
+	service, ok := ws.status.Services[info.Name]
+	if !ok && ws.filtered {
+		return
+	}
+	service.Charm = info.CharmURL
+	service.Exposed = info.Exposed
+	service.Life = string(info.Life)
+	if !info.Subordinate {
+		service.Status = agentStatus(info.Status)
+		if service.Units == nil {
+			service.Units = make(map[string]params.UnitStatus)
+		}
+	}
+	ws.status.Services[info.Name] = service
+}
+
+func (ws *watchStatus) applyUnit(info *multiwatcher.UnitInfo, removed bool) bool {
+	service, ok := ws.status.Services[info.Service]
+	if !ok {
+		// The service is filtered out, or its change has not
+		// arrived yet.
+		return ws.filtered || removed
+	}
+	units := service.Units
+	if units == nil && !info.Subordinate {
+		units = make(map[string]params.UnitStatus)
+		service.Units = units
+		ws.status.Services[info.Service] = service
+	}
+	if info.Subordinate {
+		units = ws.subordinateUnits(info.Name)
+		if units == nil {
+			// The principal unit is not known, so the unit
+			// cannot be placed.
+			return ws.filtered || removed
+		}
+	}
+	if removed {
+		delete(units, info.Name)
+		return true
+	}
+	unit, ok := units[info.Name]
+	if !ok && ws.filtered {
+		return true
+	}
+	unit.Workload = agentStatus(info.WorkloadStatus)
+	unit.UnitAgent = agentStatus(info.AgentStatus)
+	unit.UnitAgent.Life = unit.Life
+	unit.AgentState = params.Status(info.Status)
+	unit.AgentStateInfo = info.StatusInfo
+	unit.AgentVersion = info.AgentStatus.Version
+	unit.PublicAddress = info.PublicAddress
+	if !info.Subordinate {
+		unit.Machine = info.MachineId
+	}
+	unit.Charm = ""
+	if info.CharmURL != "" && info.CharmURL != service.Charm {
+		unit.Charm = info.CharmURL
+	}
+	unit.OpenedPorts = nil
+	for _, portRange := range info.PortRanges {
+		unit.OpenedPorts = append(unit.OpenedPorts, portRange.String())
+	}
+	units[info.Name] = unit
+	return true
+}
+
+// subordinateUnits returns the map that holds the status of the
+// named subordinate unit, or nil if the unit is not yet listed under
+// its principal. The AllWatcher does not report a subordinate unit's
+// principal, so a new subordinate unit cannot be placed.
+func (ws *watchStatus) subordinateUnits(name string) map[string]params.UnitStatus {
+	for _, service := range ws.status.Services {
+		for _, unit := range service.Units {
+			if _, ok := unit.Subordinates[name]; ok {
+				return unit.Subordinates
+			}
+		}
+	}
+	return nil
+}
+
+func (ws *watchStatus) applyRelation(info *multiwatcher.RelationInfo, removed bool) {
+	if removed {
+		delete(ws.relations, info.Key)
+		return
+	}
+	relation := params.RelationStatus{
+		Id:  info.Id,
+		Key: info.Key,
+	}
+	for _, ep := range info.Endpoints {
+		relation.Interface = ep.Relation.Interface
+		if ep.Relation.Scope == charm.ScopeContainer {
+			relation.Scope = charm.ScopeContainer
+		} else if relation.Scope == "" {
+			relation.Scope = ep.Relation.Scope
+		}
+		relation.Endpoints = append(relation.Endpoints, params.EndpointStatus{
+			ServiceName: ep.ServiceName,
+			Name:        ep.Relation.Name,
+			Role:        ep.Relation.Role,
+			Subordinate: ws.subordinates.Contains(ep.ServiceName),
+		})
+	}
+	ws.relations[info.Key] = relation
+}
+
+// updateRelations sets the relations in the status, and those of
+// each service, from the model's relations. Relations that involve
+// services that are not in the status are left out.
+func (ws *watchStatus) updateRelations() {
+	related := make(map[string]map[string]set.Strings)
+	subordinateTo := make(map[string]set.Strings)
+	ws.status.Relations = nil
+	for _, relation := range ws.relations {
+		included := true
+		for _, ep := range relation.Endpoints {
+			if _, ok := ws.status.Services[ep.ServiceName]; !ok {
+				included = false
+			}
+		}
+		if !included {
+			continue
+		}
+		ws.status.Relations = append(ws.status.Relations, relation)
+		for _, ep := range relation.Endpoints {
+			if related[ep.ServiceName] == nil {
+				related[ep.ServiceName] = make(map[string]set.Strings)
+			}
+			names := related[ep.ServiceName][ep.Name]
+			if names == nil {
+				names = set.NewStrings()
+				related[ep.ServiceName][ep.Name] = names
+			}
+			for _, other := range relation.Endpoints {
+				if len(relation.Endpoints) > 1 && other.ServiceName == ep.ServiceName {
+					continue
+				}
+				names.Add(other.ServiceName)
+				if relation.Scope == charm.ScopeContainer && ws.subordinates.Contains(ep.ServiceName) {
+					if subordinateTo[ep.ServiceName] == nil {
+						subordinateTo[ep.ServiceName] = set.NewStrings()
+					}
+					subordinateTo[ep.ServiceName].Add(other.ServiceName)
+				}
+			}
+		}
+	}
+	sort.Sort(relationsById(ws.status.Relations))
+
+	for name, service := range ws.status.Services {
+		service.Relations = make(map[string][]string)
+		for relationName, names := range related[name] {
+			service.Relations[relationName] = names.SortedValues()
+		}
+		service.SubordinateTo = nil
+		if names, ok := subordinateTo[name]; ok {
+			service.SubordinateTo = names.SortedValues()
+		}
+		ws.status.Services[name] = service
+	}
+}
+
+// agentStatus converts the status reported by the AllWatcher into
+// the form reported by FullStatus.
+func agentStatus(info multiwatcher.StatusInfo) params.AgentStatus {
+	return params.AgentStatus{
+		Status:  params.Status(info.Current),
+		Info:    info.Message,
+		Data:    info.Data,
+		Since:   info.Since,
+		Version: info.Version,
+		Err:     info.Err,
+	}
+}
+
+type relationsById []params.RelationStatus
+
+func (r relationsById) Len() int           { return len(r) }
+func (r relationsById) Swap(i, j int)      { r[i], r[j] = r[j], r[i] }
+func (r relationsById) Less(i, j int) bool { return r[i].Id < r[j].Id }
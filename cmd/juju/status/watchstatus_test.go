@@ -0,0 +1,143 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package status
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6-unstable"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state/multiwatcher"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type watchStatusSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&watchStatusSuite{})
+
+func (s *watchStatusSuite) TestApplyMachine(c *gc.C) {
+	ws := newWatchStatus(machineStatus("pending"), false)
+	ok := ws.apply([]multiwatcher.Delta{{
+		Entity: &multiwatcher.MachineInfo{
+			Id:         "0",
+			Status:     multiwatcher.Status("started"),
+			InstanceId: "inst-0",
+			Series:     "trusty",
+		},
+	}, {
+		Entity: &multiwatcher.MachineInfo{
+			Id:     "0/lxc/0",
+			Status: multiwatcher.Status("pending"),
+			Series: "trusty",
+		},
+	}})
+	c.Assert(ok, jc.IsTrue)
+	machine := ws.status.Machines["0"]
+	c.Assert(machine.Agent.Status, gc.Equals, params.Status("started"))
+	c.Assert(string(machine.InstanceId), gc.Equals, "inst-0")
+	c.Assert(string(machine.Containers["0/lxc/0"].InstanceId), gc.Equals, "pending")
+}
+
+func (s *watchStatusSuite) TestApplyRemoval(c *gc.C) {
+	ws := newWatchStatus(machineStatus("started"), false)
+	ok := ws.apply([]multiwatcher.Delta{{
+		Removed: true,
+		Entity:  &multiwatcher.MachineInfo{Id: "0"},
+	}})
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(ws.status.Machines, gc.HasLen, 0)
+}
+
+func (s *watchStatusSuite) TestApplyServiceUnitAndRelation(c *gc.C) {
+	ws := newWatchStatus(machineStatus("started"), false)
+	ok := ws.apply([]multiwatcher.Delta{{
+		Entity: &multiwatcher.ServiceInfo{
+			Name:     "wordpress",
+			CharmURL: "cs:trusty/wordpress-1",
+		},
+	}, {
+		Entity: &multiwatcher.ServiceInfo{
+			Name:     "mysql",
+			CharmURL: "cs:trusty/mysql-1",
+		},
+	}, {
+		Entity: &multiwatcher.UnitInfo{
+			Name:      "wordpress/0",
+			Service:   "wordpress",
+			MachineId: "0",
+			CharmURL:  "cs:trusty/wordpress-1",
+			WorkloadStatus: multiwatcher.StatusInfo{
+				Current: multiwatcher.Status("active"),
+			},
+		},
+	}, {
+		Entity: &multiwatcher.RelationInfo{
+			Key: "wordpress:db mysql:server",
+			Id:  1,
+			Endpoints: []multiwatcher.Endpoint{{
+				ServiceName: "wordpress",
+				Relation: charm.Relation{
+					Name:      "db",
+					Role:      charm.RoleRequirer,
+					Interface: "mysql",
+					Scope:     charm.ScopeGlobal,
+				},
+			}, {
+				ServiceName: "mysql",
+				Relation: charm.Relation{
+					Name:      "server",
+					Role:      charm.RoleProvider,
+					Interface: "mysql",
+					Scope:     charm.ScopeGlobal,
+				},
+			}},
+		},
+	}})
+	c.Assert(ok, jc.IsTrue)
+
+	unit := ws.status.Services["wordpress"].Units["wordpress/0"]
+	c.Assert(unit.Machine, gc.Equals, "0")
+	c.Assert(unit.Charm, gc.Equals, "")
+	c.Assert(unit.Workload.Status, gc.Equals, params.Status("active"))
+
+	c.Assert(ws.status.Relations, gc.HasLen, 1)
+	c.Assert(ws.status.Relations[0].Interface, gc.Equals, "mysql")
+	c.Assert(ws.status.Services["wordpress"].Relations, jc.DeepEquals, map[string][]string{
+		"db": {"mysql"},
+	})
+	c.Assert(ws.status.Services["mysql"].Relations, jc.DeepEquals, map[string][]string{
+		"server": {"wordpress"},
+	})
+}
+
+func (s *watchStatusSuite) TestApplyFilteredIgnoresNewEntities(c *gc.C) {
+	ws := newWatchStatus(machineStatus("started"), true)
+	ok := ws.apply([]multiwatcher.Delta{{
+		Entity: &multiwatcher.MachineInfo{Id: "1"},
+	}, {
+		Entity: &multiwatcher.ServiceInfo{Name: "mysql"},
+	}, {
+		Entity: &multiwatcher.UnitInfo{Name: "mysql/0", Service: "mysql"},
+	}})
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(ws.status.Machines, gc.HasLen, 1)
+	c.Assert(ws.status.Services, gc.HasLen, 0)
+}
+
+func (s *watchStatusSuite) TestApplyUnknownSubordinateNeedsRefetch(c *gc.C) {
+	ws := newWatchStatus(machineStatus("started"), false)
+	ok := ws.apply([]multiwatcher.Delta{{
+		Entity: &multiwatcher.ServiceInfo{Name: "logging", Subordinate: true},
+	}, {
+		Entity: &multiwatcher.UnitInfo{
+			Name:        "logging/0",
+			Service:     "logging",
+			Subordinate: true,
+		},
+	}})
+	c.Assert(ok, jc.IsFalse)
+}
@@ -37,6 +37,7 @@ import (
 	"github.com/juju/juju/agent"
 	"github.com/juju/juju/agent/tools"
 	"github.com/juju/juju/api"
+	apiactionpruner "github.com/juju/juju/api/actionpruner"
 	"github.com/juju/juju/api/agenttools"
 	apideployer "github.com/juju/juju/api/deployer"
 	apilogsender "github.com/juju/juju/api/logsender"
@@ -46,6 +47,7 @@ import (
 	apistorageprovisioner "github.com/juju/juju/api/storageprovisioner"
 	"github.com/juju/juju/apiserver"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/audit"
 	"github.com/juju/juju/cert"
 	"github.com/juju/juju/cmd/jujud/agent/machine"
 	"github.com/juju/juju/cmd/jujud/reboot"
@@ -65,6 +67,7 @@ import (
 	"github.com/juju/juju/service"
 	"github.com/juju/juju/service/common"
 	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/backups"
 	"github.com/juju/juju/state/multiwatcher"
 	statestorage "github.com/juju/juju/state/storage"
 	"github.com/juju/juju/storage/looputil"
@@ -72,10 +75,14 @@ import (
 	"github.com/juju/juju/version"
 	"github.com/juju/juju/watcher"
 	"github.com/juju/juju/worker"
+	"github.com/juju/juju/worker/actionpruner"
 	"github.com/juju/juju/worker/addresser"
 	"github.com/juju/juju/worker/apiaddressupdater"
 	"github.com/juju/juju/worker/apicaller"
+	"github.com/juju/juju/worker/auditsinkupdater"
 	"github.com/juju/juju/worker/authenticationworker"
+	"github.com/juju/juju/worker/backupscheduler"
+	"github.com/juju/juju/worker/blockexpirer"
 	"github.com/juju/juju/worker/certupdater"
 	"github.com/juju/juju/worker/charmrevision"
 	"github.com/juju/juju/worker/cleaner"
@@ -97,6 +104,7 @@ import (
 	"github.com/juju/juju/worker/peergrouper"
 	"github.com/juju/juju/worker/provisioner"
 	"github.com/juju/juju/worker/proxyupdater"
+	"github.com/juju/juju/worker/resourcegc"
 	"github.com/juju/juju/worker/resumer"
 	"github.com/juju/juju/worker/singular"
 	"github.com/juju/juju/worker/statushistorypruner"
@@ -1083,7 +1091,11 @@ func (a *MachineAgent) StateWorker() (worker.Worker, error) {
 				st, _, err := openState(agentConfig, stateWorkerDialOpts)
 				return st, err
 			}
-			runner.StartWorker("apiserver", a.apiserverWorkerStarter(stateOpener, certChangedChan))
+			// auditFanout is shared by the apiserver, which sends
+			// audit entries to it, and the auditsinkupdater, which
+			// keeps its sinks in line with the model config.
+			auditFanout := audit.NewFanout()
+			runner.StartWorker("apiserver", a.apiserverWorkerStarter(stateOpener, certChangedChan, auditFanout))
 			var stateServingSetter certupdater.StateServingInfoSetter = func(info params.StateServingInfo, done <-chan struct{}) error {
 				return a.ChangeConfig(func(config agent.ConfigSetter) error {
 					config.SetStateServingInfo(info)
@@ -1099,6 +1111,9 @@ func (a *MachineAgent) StateWorker() (worker.Worker, error) {
 			a.startWorkerAfterUpgrade(runner, "certupdater", func() (worker.Worker, error) {
 				return newCertificateUpdater(m, agentConfig, st, st, stateServingSetter), nil
 			})
+			a.startWorkerAfterUpgrade(runner, "auditsinkupdater", func() (worker.Worker, error) {
+				return auditsinkupdater.New(st, auditFanout, agentConfig.DataDir(), agentConfig.LogDir()), nil
+			})
 
 			a.startWorkerAfterUpgrade(singularRunner, "dblogpruner", func() (worker.Worker, error) {
 				return dblogpruner.New(st, dblogpruner.NewLogPruneParams()), nil
@@ -1107,6 +1122,27 @@ func (a *MachineAgent) StateWorker() (worker.Worker, error) {
 			a.startWorkerAfterUpgrade(singularRunner, "txnpruner", func() (worker.Worker, error) {
 				return txnpruner.New(st, time.Hour*2), nil
 			})
+
+			a.startWorkerAfterUpgrade(singularRunner, "blockexpirer", func() (worker.Worker, error) {
+				return blockexpirer.New(st, time.Minute), nil
+			})
+
+			a.startWorkerAfterUpgrade(singularRunner, "resourcegc", func() (worker.Worker, error) {
+				return resourcegc.New(st, time.Hour), nil
+			})
+
+			a.startWorkerAfterUpgrade(singularRunner, "backupscheduler", func() (worker.Worker, error) {
+				paths := backups.Paths{
+					DataDir: agentConfig.DataDir(),
+					LogsDir: agentConfig.LogDir(),
+				}
+				return backupscheduler.New(backupscheduler.Config{
+					Facade:        backupscheduler.NewFacade(st, paths, m.Id()),
+					Clock:         clock.WallClock,
+					CheckInterval: time.Minute,
+					NewTimer:      worker.NewTimer,
+				})
+			})
 		default:
 			return nil, errors.Errorf("unknown job type %q", job)
 		}
@@ -1301,6 +1337,18 @@ func (a *MachineAgent) startEnvWorkers(
 		logger.Debugf("not starting firewaller worker - firewall-mode is %q", fwMode)
 	}
 
+	singularRunner.StartWorker("actionpruner", func() (worker.Worker, error) {
+		w, err := actionpruner.New(actionpruner.Config{
+			Facade:        apiactionpruner.NewFacade(apiSt),
+			PruneInterval: params.DefaultPruneInterval,
+			NewTimer:      worker.NewTimer,
+		})
+		if err != nil {
+			return nil, errors.Annotate(err, "cannot start action pruner worker")
+		}
+		return w, nil
+	})
+
 	singularRunner.StartWorker("statushistorypruner", func() (worker.Worker, error) {
 		f := statushistory.NewFacade(apiSt)
 		conf := statushistorypruner.Config{
@@ -1417,18 +1465,18 @@ func _getFirewallMode(apiSt api.Connection) (string, error) {
 var stateWorkerDialOpts mongo.DialOpts
 
 func (a *MachineAgent) apiserverWorkerStarter(
-	stateOpener func() (*state.State, error), certChanged chan params.StateServingInfo,
+	stateOpener func() (*state.State, error), certChanged chan params.StateServingInfo, auditSink audit.Sink,
 ) func() (worker.Worker, error) {
 	return func() (worker.Worker, error) {
 		st, err := stateOpener()
 		if err != nil {
 			return nil, errors.Trace(err)
 		}
-		return a.newApiserverWorker(st, certChanged)
+		return a.newApiserverWorker(st, certChanged, auditSink)
 	}
 }
 
-func (a *MachineAgent) newApiserverWorker(st *state.State, certChanged chan params.StateServingInfo) (worker.Worker, error) {
+func (a *MachineAgent) newApiserverWorker(st *state.State, certChanged chan params.StateServingInfo, auditSink audit.Sink) (worker.Worker, error) {
 	agentConfig := a.CurrentConfig()
 	// If the configuration does not have the required information,
 	// it is currently not a recoverable error, so we kill the whole
@@ -1461,6 +1509,7 @@ func (a *MachineAgent) newApiserverWorker(st *state.State, certChanged chan para
 		LogDir:      logDir,
 		Validator:   a.limitLogins,
 		CertChanged: certChanged,
+		AuditSink:   auditSink,
 	})
 	if err != nil {
 		return nil, errors.Annotate(err, "cannot start api server worker")
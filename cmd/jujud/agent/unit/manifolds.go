@@ -21,6 +21,7 @@ import (
 	"github.com/juju/juju/worker/metrics/collect"
 	"github.com/juju/juju/worker/metrics/sender"
 	"github.com/juju/juju/worker/metrics/spool"
+	"github.com/juju/juju/worker/payloadhealth"
 	"github.com/juju/juju/worker/proxyupdater"
 	"github.com/juju/juju/worker/uniter"
 	"github.com/juju/juju/worker/upgrader"
@@ -177,6 +178,12 @@ func Manifolds(config ManifoldsConfig) dependency.Manifolds {
 			APICallerName:   APICallerName,
 			MetricSpoolName: MetricSpoolName,
 		}),
+
+		// The payload health worker runs the health probes of the unit's
+		// payloads, and sets their status according to the results.
+		PayloadHealthName: payloadhealth.Manifold(payloadhealth.ManifoldConfig{
+			APICallerName: APICallerName,
+		}),
 	}
 }
 
@@ -196,4 +203,5 @@ const (
 	MeterStatusName          = "meter-status"
 	MetricCollectName        = "metric-collect"
 	MetricSenderName         = "metric-sender"
+	PayloadHealthName        = "payload-health"
 )
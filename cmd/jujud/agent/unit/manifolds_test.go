@@ -52,6 +52,7 @@ func (s *ManifoldsSuite) TestManifoldNames(c *gc.C) {
 		unit.MetricCollectName,
 		unit.MeterStatusName,
 		unit.MetricSenderName,
+		unit.PayloadHealthName,
 		unit.CharmDirName,
 	}
 	keys := make([]string, 0, len(manifolds))
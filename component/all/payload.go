@@ -28,8 +28,6 @@ import (
 	"github.com/juju/juju/worker/uniter/runner/jujuc"
 )
 
-const payloadsHookContextFacade = payload.ComponentName + "-hook-context"
-
 type payloads struct{}
 
 func (c payloads) registerForServer() error {
@@ -123,7 +121,7 @@ func (c payloads) registerHookContext() {
 }
 
 func (payloads) newUnitFacadeClient(caller base.APICaller) context.APIClient {
-	facadeCaller := base.NewFacadeCallerForVersion(caller, payloadsHookContextFacade, 0)
+	facadeCaller := base.NewFacadeCallerForVersion(caller, payload.HookContextFacade, payload.HookContextFacadeVersion)
 	return internalclient.NewUnitFacadeClient(facadeCaller)
 }
 
@@ -136,14 +134,22 @@ func (payloads) newHookContextFacade(st *state.State, unit *state.Unit) (interfa
 }
 
 func (c payloads) registerHookContextFacade() {
-	const version = 0
+	// Version 0 is still served to the unit agents that ask for it,
+	// until they are upgraded.
+	common.RegisterHookContextFacade(
+		payload.HookContextFacade,
+		0,
+		c.newHookContextFacade,
+		reflect.TypeOf(&internalserver.UnitFacade{}),
+	)
+	const version = payload.HookContextFacadeVersion
 	common.RegisterHookContextFacade(
-		payloadsHookContextFacade,
+		payload.HookContextFacade,
 		version,
 		c.newHookContextFacade,
 		reflect.TypeOf(&internalserver.UnitFacade{}),
 	)
-	api.RegisterFacadeVersion(payloadsHookContextFacade, version)
+	api.RegisterFacadeVersion(payload.HookContextFacade, version)
 }
 
 type payloadsHookContext struct {
@@ -63,6 +63,13 @@ func (r resources) registerPublicFacade() {
 		return
 	}
 
+	// Version 1 is still served to the clients that ask for it, which
+	// do not know about the methods added since.
+	common.RegisterStandardFacade(
+		resource.ComponentName,
+		1,
+		r.newPublicFacade,
+	)
 	common.RegisterStandardFacade(
 		resource.ComponentName,
 		server.Version,
@@ -129,6 +136,15 @@ func (st resourceState) Storage() state.Storage {
 	return st.persist.NewStorage()
 }
 
+// ResourceHistoryLimit implements resource/state.RawState.
+func (st resourceState) ResourceHistoryLimit() (int, error) {
+	cfg, err := st.persist.ModelConfig()
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	return cfg.ResourceHistoryLimit(), nil
+}
+
 type resourcePersistence struct {
 	*persistence.Persistence
 }
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"math"
 	"reflect"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -32,6 +33,20 @@ const (
 	Spaces       = "spaces"
 )
 
+const (
+	// alternativeSeparator separates the alternatives given for the
+	// arch and instance-type constraints, eg "arch=amd64|arm64".
+	alternativeSeparator = "|"
+
+	// rangeSeparator separates the minimum and maximum given for
+	// the mem, cpu-cores and root-disk constraints, eg "mem=4G..16G".
+	rangeSeparator = ".."
+
+	// preferPrefix marks a constraint as a preference rather than a
+	// requirement, eg "instance-type=prefer:m4.large".
+	preferPrefix = "prefer:"
+)
+
 // Value describes a user's requirements of the hardware on which units
 // of a service will run. Constraints are used to choose an existing machine
 // onto which a unit will be deployed, or to provision a new machine if no
@@ -39,7 +54,7 @@ const (
 type Value struct {
 
 	// Arch, if not nil or empty, indicates that a machine must run the named
-	// architecture.
+	// architecture, or one of the "|"-separated architectures named.
 	Arch *string `json:"arch,omitempty" yaml:"arch,omitempty"`
 
 	// Container, if not nil, indicates that a machine must be the specified container type.
@@ -49,6 +64,10 @@ type Value struct {
 	// number of effective cores available.
 	CpuCores *uint64 `json:"cpu-cores,omitempty" yaml:"cpu-cores,omitempty"`
 
+	// MaxCpuCores, if not nil, indicates that a machine must have at
+	// most that number of effective cores available.
+	MaxCpuCores *uint64 `json:"max-cpu-cores,omitempty" yaml:"max-cpu-cores,omitempty"`
+
 	// CpuPower, if not nil, indicates that a machine must have at least that
 	// amount of CPU power available, where 100 CpuPower is considered to be
 	// equivalent to 1 Amazon ECU (or, roughly, a single 2007-era Xeon).
@@ -58,6 +77,10 @@ type Value struct {
 	// megabytes of RAM.
 	Mem *uint64 `json:"mem,omitempty" yaml:"mem,omitempty"`
 
+	// MaxMem, if not nil, indicates that a machine must have at most
+	// that many megabytes of RAM.
+	MaxMem *uint64 `json:"max-mem,omitempty" yaml:"max-mem,omitempty"`
+
 	// RootDisk, if not nil, indicates that a machine must have at least
 	// that many megabytes of disk space available in the root disk. In
 	// providers where the root disk is configurable at instance startup
@@ -65,13 +88,18 @@ type Value struct {
 	// disk might be requested.
 	RootDisk *uint64 `json:"root-disk,omitempty" yaml:"root-disk,omitempty"`
 
+	// MaxRootDisk, if not nil, indicates that a machine must have at
+	// most that many megabytes of disk space available in the root disk.
+	MaxRootDisk *uint64 `json:"max-root-disk,omitempty" yaml:"max-root-disk,omitempty"`
+
 	// Tags, if not nil, indicates tags that the machine must have applied to it.
 	// An empty list is treated the same as a nil (unspecified) list, except an
 	// empty list will override any default tags, where a nil list will not.
 	Tags *[]string `json:"tags,omitempty" yaml:"tags,omitempty"`
 
-	// InstanceType, if not nil, indicates that the specified cloud instance type
-	// be used. Only valid for clouds which support instance types.
+	// InstanceType, if not nil, indicates that the specified cloud instance type,
+	// or one of the "|"-separated instance types specified, be used. Only valid
+	// for clouds which support instance types.
 	InstanceType *string `json:"instance-type,omitempty" yaml:"instance-type,omitempty"`
 
 	// Spaces, if not nil, holds a list of juju network spaces that
@@ -88,6 +116,34 @@ type Value struct {
 	// TODO(dimitern): Drop this as soon as spaces can be used for
 	// deployments instead.
 	Networks *[]string `json:"networks,omitempty" yaml:"networks,omitempty"`
+
+	// Prefer, if not nil, holds the sorted names of the constraint
+	// attributes which are preferences rather than requirements: they
+	// are honoured when some machine can satisfy them, and ignored
+	// otherwise. Alternatives given for a preferred attribute are
+	// tried in the order given.
+	Prefer *[]string `json:"prefer,omitempty" yaml:"prefer,omitempty"`
+}
+
+// maxFieldNames maps the constraint attributes which may be given as a
+// range to the names of the fields holding their maximum values. The
+// maximum is part of the attribute: it is set, merged and cleared along
+// with the minimum.
+var maxFieldNames = map[string]string{
+	CpuCores: "MaxCpuCores",
+	Mem:      "MaxMem",
+	RootDisk: "MaxRootDisk",
+}
+
+// preferable holds the constraint attributes which may be preferences.
+var preferable = map[string]bool{
+	Arch:         true,
+	CpuCores:     true,
+	CpuPower:     true,
+	Mem:          true,
+	RootDisk:     true,
+	Tags:         true,
+	InstanceType: true,
 }
 
 // fieldNames records a mapping from the constraint tag to struct field name.
@@ -98,9 +154,18 @@ func init() {
 	// Create the fieldNames map by inspecting the json tags for each of
 	// the Value struct fields.
 	fieldNames = make(map[string]string)
+	// The maximum and preference fields qualify other attributes, and
+	// are not attributes themselves.
+	qualifiers := map[string]bool{"Prefer": true}
+	for _, maxFieldName := range maxFieldNames {
+		qualifiers[maxFieldName] = true
+	}
 	typ := reflect.TypeOf(Value{})
 	for i := 0; i < typ.NumField(); i++ {
 		field := typ.Field(i)
+		if qualifiers[field.Name] {
+			continue
+		}
 		if tag := field.Tag.Get("json"); tag != "" {
 			if i := strings.Index(tag, ","); i >= 0 {
 				tag = tag[0:i]
@@ -130,6 +195,89 @@ func (v *Value) HasInstanceType() bool {
 	return v.InstanceType != nil && *v.InstanceType != ""
 }
 
+// Arches returns the alternative architectures allowed by the arch
+// constraint, in order of preference, or nil if it is not set.
+func (v *Value) Arches() []string {
+	if v.Arch == nil {
+		return nil
+	}
+	return strings.Split(*v.Arch, alternativeSeparator)
+}
+
+// InstanceTypes returns the alternative instance types allowed by the
+// instance-type constraint, in order of preference, or nil if it is not
+// set.
+func (v *Value) InstanceTypes() []string {
+	if v.InstanceType == nil {
+		return nil
+	}
+	return strings.Split(*v.InstanceType, alternativeSeparator)
+}
+
+// IsPreferred returns true if the named constraint attribute is a
+// preference rather than a requirement.
+func (v *Value) IsPreferred(attrTag string) bool {
+	if v.Prefer == nil {
+		return false
+	}
+	for _, tag := range *v.Prefer {
+		if tag == attrTag {
+			return true
+		}
+	}
+	return false
+}
+
+// Preferred returns the names of the constraint attributes which are
+// preferences rather than requirements.
+func (v *Value) Preferred() []string {
+	if v.Prefer == nil {
+		return nil
+	}
+	return append([]string(nil), *v.Prefer...)
+}
+
+// Required returns a copy of the constraints without the attributes
+// which are preferences.
+func (v *Value) Required() Value {
+	// The preferred attributes are always known ones.
+	result, _ := v.without(v.Preferred()...)
+	return result
+}
+
+// HasExtendedForms returns true if the constraints give a maximum, a
+// list of alternatives or a preference, which controllers from before
+// they were introduced would silently drop.
+func (v *Value) HasExtendedForms() bool {
+	if v.MaxCpuCores != nil || v.MaxMem != nil || v.MaxRootDisk != nil {
+		return true
+	}
+	if v.Prefer != nil {
+		return true
+	}
+	return len(v.Arches()) > 1 || len(v.InstanceTypes()) > 1
+}
+
+// setPreferred records whether the named attribute is a preference,
+// keeping Prefer sorted, and nil when there are none.
+func (v *Value) setPreferred(attrTag string, preferred bool) {
+	var tags []string
+	for _, tag := range v.Preferred() {
+		if tag != attrTag {
+			tags = append(tags, tag)
+		}
+	}
+	if preferred {
+		tags = append(tags, attrTag)
+		sort.Strings(tags)
+	}
+	if len(tags) == 0 {
+		v.Prefer = nil
+	} else {
+		v.Prefer = &tags
+	}
+}
+
 // extractItems returns the list of entries in the given field which
 // are either positive (included) or negative (!included; with prefix
 // "^").
@@ -203,37 +351,29 @@ func (v *Value) HaveNetworks() bool {
 func (v Value) String() string {
 	var strs []string
 	if v.Arch != nil {
-		strs = append(strs, "arch="+*v.Arch)
+		strs = append(strs, v.attrStr(Arch, *v.Arch))
 	}
 	if v.Container != nil {
 		strs = append(strs, "container="+string(*v.Container))
 	}
-	if v.CpuCores != nil {
-		strs = append(strs, "cpu-cores="+uintStr(*v.CpuCores))
+	if v.CpuCores != nil || v.MaxCpuCores != nil {
+		strs = append(strs, v.attrStr(CpuCores, rangeStr(v.CpuCores, v.MaxCpuCores, "")))
 	}
 	if v.CpuPower != nil {
-		strs = append(strs, "cpu-power="+uintStr(*v.CpuPower))
+		strs = append(strs, v.attrStr(CpuPower, uintStr(*v.CpuPower)))
 	}
 	if v.InstanceType != nil {
-		strs = append(strs, "instance-type="+string(*v.InstanceType))
+		strs = append(strs, v.attrStr(InstanceType, *v.InstanceType))
 	}
-	if v.Mem != nil {
-		s := uintStr(*v.Mem)
-		if s != "" {
-			s += "M"
-		}
-		strs = append(strs, "mem="+s)
+	if v.Mem != nil || v.MaxMem != nil {
+		strs = append(strs, v.attrStr(Mem, rangeStr(v.Mem, v.MaxMem, "M")))
 	}
-	if v.RootDisk != nil {
-		s := uintStr(*v.RootDisk)
-		if s != "" {
-			s += "M"
-		}
-		strs = append(strs, "root-disk="+s)
+	if v.RootDisk != nil || v.MaxRootDisk != nil {
+		strs = append(strs, v.attrStr(RootDisk, rangeStr(v.RootDisk, v.MaxRootDisk, "M")))
 	}
 	if v.Tags != nil {
 		s := strings.Join(*v.Tags, ",")
-		strs = append(strs, "tags="+s)
+		strs = append(strs, v.attrStr(Tags, s))
 	}
 	if v.Spaces != nil {
 		s := strings.Join(*v.Spaces, ",")
@@ -246,6 +386,15 @@ func (v Value) String() string {
 	return strings.Join(strs, " ")
 }
 
+// attrStr returns the name=value string for the given attribute,
+// marking the value as a preference if it is one.
+func (v Value) attrStr(attrTag, value string) string {
+	if v.IsPreferred(attrTag) {
+		value = preferPrefix + value
+	}
+	return attrTag + "=" + value
+}
+
 // GoString allows printing a constraints.Value nicely with the fmt
 // package, especially when nested inside other types.
 func (v Value) GoString() string {
@@ -256,15 +405,24 @@ func (v Value) GoString() string {
 	if v.CpuCores != nil {
 		values = append(values, fmt.Sprintf("CpuCores: %v", *v.CpuCores))
 	}
+	if v.MaxCpuCores != nil {
+		values = append(values, fmt.Sprintf("MaxCpuCores: %v", *v.MaxCpuCores))
+	}
 	if v.CpuPower != nil {
 		values = append(values, fmt.Sprintf("CpuPower: %v", *v.CpuPower))
 	}
 	if v.Mem != nil {
 		values = append(values, fmt.Sprintf("Mem: %v", *v.Mem))
 	}
+	if v.MaxMem != nil {
+		values = append(values, fmt.Sprintf("MaxMem: %v", *v.MaxMem))
+	}
 	if v.RootDisk != nil {
 		values = append(values, fmt.Sprintf("RootDisk: %v", *v.RootDisk))
 	}
+	if v.MaxRootDisk != nil {
+		values = append(values, fmt.Sprintf("MaxRootDisk: %v", *v.MaxRootDisk))
+	}
 	if v.InstanceType != nil {
 		values = append(values, fmt.Sprintf("InstanceType: %q", *v.InstanceType))
 	}
@@ -286,6 +444,9 @@ func (v Value) GoString() string {
 	} else if v.Networks != nil {
 		values = append(values, "Networks: (*[]string)(nil)")
 	}
+	if v.Prefer != nil {
+		values = append(values, fmt.Sprintf("Prefer: %q", *v.Prefer))
+	}
 	return fmt.Sprintf("{%s}", strings.Join(values, ", "))
 }
 
@@ -296,9 +457,33 @@ func uintStr(i uint64) string {
 	return fmt.Sprintf("%d", i)
 }
 
+// rangeStr returns the string for a value which may be given as a
+// range, eg "4096M..16384M" or "..8"; either min or max may be nil,
+// but not both.
+func rangeStr(min, max *uint64, suffix string) string {
+	if max == nil {
+		s := uintStr(*min)
+		if s != "" {
+			s += suffix
+		}
+		return s
+	}
+	var s string
+	if min != nil {
+		s = fmt.Sprintf("%d%s", *min, suffix)
+	}
+	return s + rangeSeparator + fmt.Sprintf("%d%s", *max, suffix)
+}
+
 // Parse constructs a constraints.Value from the supplied arguments,
 // each of which must contain only spaces and name=value pairs. If any
 // name is specified more than once, an error is returned.
+//
+// The mem, cpu-cores and root-disk values may be ranges, eg "mem=4G..16G"
+// or "cpu-cores=..8"; the arch and instance-type values may list
+// alternatives, eg "arch=amd64|arm64"; and any value but those of
+// container, spaces and networks may be prefixed with "prefer:" to make
+// it a preference rather than a requirement.
 func Parse(args ...string) (Value, error) {
 	cons := Value{}
 	for _, arg := range args {
@@ -359,14 +544,44 @@ func (v *Value) fieldFromTag(tagName string) (reflect.Value, bool) {
 	return val, val.IsValid()
 }
 
+// hasAttribute returns whether the attribute with the given tag is set.
+func (v *Value) hasAttribute(tagName string) bool {
+	val, _ := v.fieldFromTag(tagName)
+	if !val.IsNil() {
+		return true
+	}
+	if maxFieldName, ok := maxFieldNames[tagName]; ok {
+		return !reflect.ValueOf(v).Elem().FieldByName(maxFieldName).IsNil()
+	}
+	return false
+}
+
 // attributesWithValues returns the non-zero attribute tags and their values from the constraint.
+// The value of a range is a slice of its bounds, and that of a list of
+// alternatives a slice of the alternatives.
 func (v *Value) attributesWithValues() (result map[string]interface{}) {
 	result = make(map[string]interface{})
 	for fieldTag, fieldName := range fieldNames {
 		val := reflect.ValueOf(v).Elem().FieldByName(fieldName)
-		if !val.IsNil() {
-			result[fieldTag] = val.Elem().Interface()
+		if maxFieldName, ok := maxFieldNames[fieldTag]; ok {
+			maxVal := reflect.ValueOf(v).Elem().FieldByName(maxFieldName)
+			if !maxVal.IsNil() {
+				var bounds []uint64
+				if !val.IsNil() {
+					bounds = append(bounds, val.Elem().Uint())
+				}
+				result[fieldTag] = append(bounds, maxVal.Elem().Uint())
+				continue
+			}
+		}
+		if val.IsNil() {
+			continue
+		}
+		value := val.Elem().Interface()
+		if s, ok := value.(string); ok && strings.Contains(s, alternativeSeparator) {
+			value = strings.Split(s, alternativeSeparator)
 		}
+		result[fieldTag] = value
 	}
 	return result
 }
@@ -394,6 +609,11 @@ func (v *Value) without(attrTags ...string) (Value, error) {
 			return Value{}, errors.Errorf("unknown constraint %q", tag)
 		}
 		val.Set(reflect.Zero(val.Type()))
+		if maxFieldName, ok := maxFieldNames[tag]; ok {
+			maxVal := reflect.ValueOf(&result).Elem().FieldByName(maxFieldName)
+			maxVal.Set(reflect.Zero(maxVal.Type()))
+		}
+		result.setPreferred(tag, false)
 	}
 	return result, nil
 }
@@ -405,6 +625,10 @@ func (v *Value) setRaw(raw string) error {
 		return errors.Errorf("malformed constraint %q", raw)
 	}
 	name, str := raw[:eq], raw[eq+1:]
+	preferred := strings.HasPrefix(str, preferPrefix)
+	if preferred {
+		str = strings.TrimPrefix(str, preferPrefix)
+	}
 	var err error
 	switch name {
 	case Arch:
@@ -430,6 +654,15 @@ func (v *Value) setRaw(raw string) error {
 	default:
 		return errors.Errorf("unknown constraint %q", name)
 	}
+	if err == nil && preferred {
+		if !preferable[name] {
+			err = errors.New("cannot be a preference")
+		} else if str == "" {
+			err = errors.New("preference has no value")
+		} else {
+			v.setPreferred(name, true)
+		}
+	}
 	if err != nil {
 		return errors.Annotatef(err, "bad %q constraint", name)
 	}
@@ -459,14 +692,22 @@ func (v *Value) UnmarshalYAML(unmarshal func(interface{}) error) error {
 			v.InstanceType = &vstr
 		case CpuCores:
 			v.CpuCores, err = parseUint64(vstr)
+		case "max-cpu-cores":
+			v.MaxCpuCores, err = parseUint64(vstr)
 		case CpuPower:
 			v.CpuPower, err = parseUint64(vstr)
 		case Mem:
 			v.Mem, err = parseUint64(vstr)
+		case "max-mem":
+			v.MaxMem, err = parseUint64(vstr)
 		case RootDisk:
 			v.RootDisk, err = parseUint64(vstr)
+		case "max-root-disk":
+			v.MaxRootDisk, err = parseUint64(vstr)
 		case Tags:
 			v.Tags, err = parseYamlStrings("tags", val)
+		case "prefer":
+			v.Prefer, err = parseYamlStrings("prefer", val)
 		case Spaces:
 			var spaces *[]string
 			spaces, err = parseYamlStrings("spaces", val)
@@ -523,18 +764,26 @@ func (v *Value) setArch(str string) error {
 	if v.Arch != nil {
 		return errors.Errorf("already set")
 	}
-	if str != "" && !arch.IsSupportedArch(str) {
-		return errors.Errorf("%q not recognized", str)
+	if str != "" {
+		arches, err := parseAlternatives(str)
+		if err != nil {
+			return err
+		}
+		for _, a := range arches {
+			if !arch.IsSupportedArch(a) {
+				return errors.Errorf("%q not recognized", a)
+			}
+		}
 	}
 	v.Arch = &str
 	return nil
 }
 
 func (v *Value) setCpuCores(str string) (err error) {
-	if v.CpuCores != nil {
+	if v.CpuCores != nil || v.MaxCpuCores != nil {
 		return errors.Errorf("already set")
 	}
-	v.CpuCores, err = parseUint64(str)
+	v.CpuCores, v.MaxCpuCores, err = parseRange(str, parseUint64)
 	return
 }
 
@@ -550,23 +799,28 @@ func (v *Value) setInstanceType(str string) error {
 	if v.InstanceType != nil {
 		return errors.Errorf("already set")
 	}
+	if str != "" {
+		if _, err := parseAlternatives(str); err != nil {
+			return err
+		}
+	}
 	v.InstanceType = &str
 	return nil
 }
 
 func (v *Value) setMem(str string) (err error) {
-	if v.Mem != nil {
+	if v.Mem != nil || v.MaxMem != nil {
 		return errors.Errorf("already set")
 	}
-	v.Mem, err = parseSize(str)
+	v.Mem, v.MaxMem, err = parseRange(str, parseSize)
 	return
 }
 
 func (v *Value) setRootDisk(str string) (err error) {
-	if v.RootDisk != nil {
+	if v.RootDisk != nil || v.MaxRootDisk != nil {
 		return errors.Errorf("already set")
 	}
-	v.RootDisk, err = parseSize(str)
+	v.RootDisk, v.MaxRootDisk, err = parseRange(str, parseSize)
 	return
 }
 
@@ -658,6 +912,53 @@ func parseSize(str string) (*uint64, error) {
 	return &value, nil
 }
 
+// parseRange parses a value which may be a range of the form "min..max",
+// where min may be omitted, using parse to parse the bounds. It returns
+// a nil max if the value is not a range.
+func parseRange(str string, parse func(string) (*uint64, error)) (min, max *uint64, err error) {
+	i := strings.Index(str, rangeSeparator)
+	if i < 0 {
+		min, err = parse(str)
+		return min, nil, err
+	}
+	minStr, maxStr := str[:i], str[i+len(rangeSeparator):]
+	if maxStr == "" {
+		return nil, nil, errors.Errorf("range %q has no maximum", str)
+	}
+	if minStr != "" {
+		if min, err = parse(minStr); err != nil {
+			return nil, nil, err
+		}
+	}
+	if max, err = parse(maxStr); err != nil {
+		return nil, nil, err
+	}
+	if *max == 0 {
+		return nil, nil, errors.Errorf("range %q has a zero maximum", str)
+	}
+	if min != nil && *min > *max {
+		return nil, nil, errors.Errorf("range %q has a minimum greater than its maximum", str)
+	}
+	return min, max, nil
+}
+
+// parseAlternatives returns the "|"-separated alternatives in the
+// value s, which must be neither empty nor repeated.
+func parseAlternatives(s string) ([]string, error) {
+	alternatives := strings.Split(s, alternativeSeparator)
+	seen := make(map[string]bool)
+	for _, alternative := range alternatives {
+		if alternative == "" {
+			return nil, errors.Errorf("empty alternative in %q", s)
+		}
+		if seen[alternative] {
+			return nil, errors.Errorf("%q listed more than once", alternative)
+		}
+		seen[alternative] = true
+	}
+	return alternatives, nil
+}
+
 // parseCommaDelimited returns the items in the value s. We expect the
 // items to be comma delimited strings.
 func parseCommaDelimited(s string) *[]string {
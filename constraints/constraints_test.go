@@ -103,6 +103,28 @@ var parseConstraintsTests = []struct {
 		summary: "double set arch separately",
 		args:    []string{"arch=armhf", "arch="},
 		err:     `bad "arch" constraint: already set`,
+	}, {
+		summary: "set arch alternatives",
+		args:    []string{"arch=amd64|arm64"},
+	}, {
+		summary: "set nonsense arch alternative",
+		args:    []string{"arch=amd64|cheese"},
+		err:     `bad "arch" constraint: "cheese" not recognized`,
+	}, {
+		summary: "set empty arch alternative",
+		args:    []string{"arch=amd64|"},
+		err:     `bad "arch" constraint: empty alternative in "amd64\|"`,
+	}, {
+		summary: "set repeated arch alternative",
+		args:    []string{"arch=amd64|i386|amd64"},
+		err:     `bad "arch" constraint: "amd64" listed more than once`,
+	}, {
+		summary: "set preferred arch",
+		args:    []string{"arch=prefer:arm64|amd64"},
+	}, {
+		summary: "set preferred arch empty",
+		args:    []string{"arch=prefer:"},
+		err:     `bad "arch" constraint: preference has no value`,
 	},
 
 	// "cpu-cores" in detail.
@@ -135,6 +157,32 @@ var parseConstraintsTests = []struct {
 		summary: "double set cpu-cores separately",
 		args:    []string{"cpu-cores=128", "cpu-cores=1"},
 		err:     `bad "cpu-cores" constraint: already set`,
+	}, {
+		summary: "set cpu-cores range",
+		args:    []string{"cpu-cores=2..8"},
+	}, {
+		summary: "set cpu-cores maximum",
+		args:    []string{"cpu-cores=..8"},
+	}, {
+		summary: "set cpu-cores range with no maximum",
+		args:    []string{"cpu-cores=2.."},
+		err:     `bad "cpu-cores" constraint: range "2.." has no maximum`,
+	}, {
+		summary: "set cpu-cores range with zero maximum",
+		args:    []string{"cpu-cores=..0"},
+		err:     `bad "cpu-cores" constraint: range "..0" has a zero maximum`,
+	}, {
+		summary: "set inverted cpu-cores range",
+		args:    []string{"cpu-cores=8..2"},
+		err:     `bad "cpu-cores" constraint: range "8..2" has a minimum greater than its maximum`,
+	}, {
+		summary: "set nonsense cpu-cores range",
+		args:    []string{"cpu-cores=2..cheese"},
+		err:     `bad "cpu-cores" constraint: must be a non-negative integer`,
+	}, {
+		summary: "double set cpu-cores range",
+		args:    []string{"cpu-cores=..8", "cpu-cores=..4"},
+		err:     `bad "cpu-cores" constraint: already set`,
 	},
 
 	// "cpu-power" in detail.
@@ -308,6 +356,47 @@ var parseConstraintsTests = []struct {
 	}, {
 		summary: "instance type empty",
 		args:    []string{"instance-type="},
+	}, {
+		summary: "set instance type alternatives",
+		args:    []string{"instance-type=m4.large|m4.xlarge"},
+	}, {
+		summary: "set preferred instance type alternatives",
+		args:    []string{"instance-type=prefer:m4.large|m4.xlarge"},
+	}, {
+		summary: "set empty instance type alternative",
+		args:    []string{"instance-type=|m4.large"},
+		err:     `bad "instance-type" constraint: empty alternative in "\|m4.large"`,
+	},
+
+	// ranges and preferences
+	{
+		summary: "set mem range",
+		args:    []string{"mem=4G..16G"},
+	}, {
+		summary: "set inverted mem range",
+		args:    []string{"mem=16G..4G"},
+		err:     `bad "mem" constraint: range "16G..4G" has a minimum greater than its maximum`,
+	}, {
+		summary: "set root-disk range",
+		args:    []string{"root-disk=..100G"},
+	}, {
+		summary: "double set mem range",
+		args:    []string{"mem=..4G", "mem=1G"},
+		err:     `bad "mem" constraint: already set`,
+	}, {
+		summary: "set preferred mem",
+		args:    []string{"mem=prefer:8G"},
+	}, {
+		summary: "set preferred mem range",
+		args:    []string{"mem=prefer:8G..16G cpu-cores=prefer:4"},
+	}, {
+		summary: "set preferred container",
+		args:    []string{"container=prefer:lxc"},
+		err:     `bad "container" constraint: cannot be a preference`,
+	}, {
+		summary: "set preferred spaces",
+		args:    []string{"spaces=prefer:space1"},
+		err:     `bad "spaces" constraint: cannot be a preference`,
 	},
 
 	// Everything at once.
@@ -369,6 +458,18 @@ func (s *ConstraintsSuite) TestMerge(c *gc.C) {
 	c.Assert(err, gc.NotNil)
 	c.Assert(err, gc.ErrorMatches, `bad "arch" constraint: "foo" not recognized`)
 	c.Assert(merged, jc.DeepEquals, constraints.Value{})
+	merged, err = constraints.Merge(
+		constraints.MustParse("mem=prefer:4G..16G"),
+		constraints.MustParse("arch=amd64|arm64 cpu-cores=..8"),
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(merged, jc.DeepEquals, constraints.Value{
+		Arch:        strp("amd64|arm64"),
+		MaxCpuCores: uint64p(8),
+		Mem:         uint64p(4096),
+		MaxMem:      uint64p(16384),
+		Prefer:      &[]string{"mem"},
+	})
 	merged, err = constraints.Merge(con1, con1)
 	c.Assert(err, gc.NotNil)
 	c.Assert(err, gc.ErrorMatches, `bad "arch" constraint: already set`)
@@ -488,6 +589,51 @@ func (s *ConstraintsSuite) TestIsEmpty(c *gc.C) {
 	c.Check(&con, gc.Not(jc.Satisfies), constraints.IsEmpty)
 }
 
+func (s *ConstraintsSuite) TestAlternatives(c *gc.C) {
+	cons := constraints.MustParse("mem=4G")
+	c.Check(cons.Arches(), gc.IsNil)
+	c.Check(cons.InstanceTypes(), gc.IsNil)
+	cons = constraints.MustParse("arch=arm64 instance-type=m4.large")
+	c.Check(cons.Arches(), jc.DeepEquals, []string{"arm64"})
+	c.Check(cons.InstanceTypes(), jc.DeepEquals, []string{"m4.large"})
+	cons = constraints.MustParse("arch=arm64|amd64 instance-type=m4.xlarge|m4.large")
+	c.Check(cons.Arches(), jc.DeepEquals, []string{"arm64", "amd64"})
+	c.Check(cons.InstanceTypes(), jc.DeepEquals, []string{"m4.xlarge", "m4.large"})
+}
+
+func (s *ConstraintsSuite) TestPreferred(c *gc.C) {
+	cons := constraints.MustParse("mem=4G arch=amd64")
+	c.Check(cons.Preferred(), gc.HasLen, 0)
+	c.Check(cons.Required(), jc.DeepEquals, cons)
+
+	cons = constraints.MustParse("tags=prefer:ssd mem=4G..8G instance-type=prefer:m4.large|m4.xlarge arch=amd64")
+	c.Check(cons.Preferred(), jc.DeepEquals, []string{"instance-type", "tags"})
+	c.Check(cons.IsPreferred("tags"), jc.IsTrue)
+	c.Check(cons.IsPreferred("mem"), jc.IsFalse)
+	c.Check(cons.Required(), jc.DeepEquals, constraints.MustParse("mem=4G..8G arch=amd64"))
+	c.Check(cons.String(), gc.Equals, "arch=amd64 instance-type=prefer:m4.large|m4.xlarge mem=4096M..8192M tags=prefer:ssd")
+}
+
+func (s *ConstraintsSuite) TestHasExtendedForms(c *gc.C) {
+	for i, test := range []struct {
+		cons     string
+		extended bool
+	}{
+		{"", false},
+		{"mem=4G cpu-cores=2 root-disk=8G arch=amd64 instance-type=m4.large", false},
+		{"mem=4G..16G", true},
+		{"cpu-cores=..8", true},
+		{"root-disk=8G..32G", true},
+		{"arch=amd64|arm64", true},
+		{"instance-type=m4.large|m4.xlarge", true},
+		{"tags=prefer:ssd", true},
+	} {
+		c.Logf("test %d: %q", i, test.cons)
+		cons := constraints.MustParse(test.cons)
+		c.Check(cons.HasExtendedForms(), gc.Equals, test.extended)
+	}
+}
+
 func uint64p(i uint64) *uint64 {
 	return &i
 }
@@ -536,6 +682,19 @@ var constraintsRoundtripTests = []roundTrip{
 	{"Networks3", constraints.Value{Networks: &[]string{"net1", "^net2"}}},
 	{"InstanceType1", constraints.Value{InstanceType: strp("")}},
 	{"InstanceType2", constraints.Value{InstanceType: strp("foo")}},
+	{"InstanceType3", constraints.Value{InstanceType: strp("foo|bar")}},
+	{"Arch3", constraints.Value{Arch: strp("amd64|arm64")}},
+	{"MemRange1", constraints.Value{Mem: uint64p(4096), MaxMem: uint64p(16384)}},
+	{"MemRange2", constraints.Value{Mem: uint64p(0), MaxMem: uint64p(16384)}},
+	{"MemRange3", constraints.Value{MaxMem: uint64p(16384)}},
+	{"CpuCoresRange", constraints.Value{CpuCores: uint64p(2), MaxCpuCores: uint64p(8)}},
+	{"RootDiskRange", constraints.Value{MaxRootDisk: uint64p(102400)}},
+	{"Prefer", constraints.Value{
+		Arch:     strp("arm64|amd64"),
+		Mem:      uint64p(8192),
+		RootDisk: uint64p(10240),
+		Prefer:   &[]string{"arch", "mem"},
+	}},
 	{"All", constraints.Value{
 		Arch:         strp("i386"),
 		Container:    ctypep("lxc"),
@@ -674,6 +833,10 @@ var withoutTests = []struct {
 	initial: initialWithoutCons,
 	without: []string{"root-disk", "mem", "arch"},
 	final:   "cpu-power=1000 cpu-cores=4 tags=foo spaces=space1,^space2 networks=net1,^net2 container=lxc instance-type=bar",
+}, {
+	initial: "mem=prefer:4G..8G cpu-cores=..4 arch=prefer:amd64",
+	without: []string{"mem", "cpu-cores"},
+	final:   "arch=prefer:amd64",
 }}
 
 func (s *ConstraintsSuite) TestWithout(c *gc.C) {
@@ -692,10 +855,21 @@ func (s *ConstraintsSuite) TestWithoutError(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, `unknown constraint "foo"`)
 }
 
+func (s *ConstraintsSuite) TestAttributesWithValuesRangesAndAlternatives(c *gc.C) {
+	cons := constraints.MustParse("mem=4G..8G cpu-cores=..4 arch=prefer:amd64|arm64 instance-type=foo|bar")
+	c.Check(constraints.AttributesWithValues(cons), jc.DeepEquals, map[string]interface{}{
+		"mem":           []uint64{4096, 8192},
+		"cpu-cores":     []uint64{4},
+		"arch":          []string{"amd64", "arm64"},
+		"instance-type": []string{"foo", "bar"},
+	})
+}
+
 func (s *ConstraintsSuite) TestAttributesWithValues(c *gc.C) {
 	for i, consStr := range []string{
 		"",
 		"root-disk=8G mem=4G arch=amd64 cpu-power=1000 cpu-cores=4 instance-type=foo tags=foo,bar spaces=space1,^space2",
+		"root-disk=prefer:8G mem=4G arch=prefer:amd64 instance-type=foo",
 	} {
 		c.Logf("test %d", i)
 		cons := constraints.MustParse(consStr)
@@ -772,6 +946,11 @@ var hasAnyTests = []struct {
 		attrs:    []string{"tags", "spaces", "networks"},
 		expected: []string{},
 	},
+	{
+		cons:     "mem=..4G cpu-cores=prefer:4",
+		attrs:    []string{"mem", "cpu-cores", "root-disk"},
+		expected: []string{"mem", "cpu-cores"},
+	},
 }
 
 func (s *ConstraintsSuite) TestHasAny(c *gc.C) {
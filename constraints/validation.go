@@ -216,14 +216,23 @@ func coerce(v interface{}) interface{} {
 }
 
 // withFallbacks returns a copy of v with nil values taken from vFallback.
+// An attribute's maximum and whether it is a preference are taken along
+// with its value.
 func withFallbacks(v Value, vFallback Value) Value {
 	result := vFallback
-	for _, fieldName := range fieldNames {
-		resultVal := reflect.ValueOf(&result).Elem().FieldByName(fieldName)
-		val := reflect.ValueOf(&v).Elem().FieldByName(fieldName)
-		if !val.IsNil() {
-			resultVal.Set(val)
+	for fieldTag, fieldName := range fieldNames {
+		if !v.hasAttribute(fieldTag) {
+			continue
+		}
+		names := []string{fieldName}
+		if maxFieldName, ok := maxFieldNames[fieldTag]; ok {
+			names = append(names, maxFieldName)
+		}
+		for _, name := range names {
+			resultVal := reflect.ValueOf(&result).Elem().FieldByName(name)
+			resultVal.Set(reflect.ValueOf(&v).Elem().FieldByName(name))
 		}
+		result.setPreferred(fieldTag, v.IsPreferred(fieldTag))
 	}
 	return result
 }
@@ -113,6 +113,41 @@ var validationTests = []struct {
 			"instance-type": {"foo", "bar"},
 			"arch":          {"amd64", "i386"}},
 	},
+	{
+		cons: "arch=i386|amd64 instance-type=prefer:foo|bar",
+		vocab: map[string][]interface{}{
+			"instance-type": {"foo", "bar"},
+			"arch":          {"amd64", "i386"}},
+	},
+	{
+		cons:  "arch=amd64|i386 mem=4G",
+		vocab: map[string][]interface{}{"arch": {"amd64"}},
+		err:   "invalid constraint value: arch=i386\nvalid values are:.*",
+	},
+	{
+		cons:  "instance-type=prefer:foo|baz",
+		vocab: map[string][]interface{}{"instance-type": {"foo", "bar"}},
+		err:   "invalid constraint value: instance-type=baz\nvalid values are:.*",
+	},
+	{
+		cons:  "cpu-cores=2..8",
+		vocab: map[string][]interface{}{"cpu-cores": {2, 4, 8}},
+	},
+	{
+		cons:  "cpu-cores=2..6",
+		vocab: map[string][]interface{}{"cpu-cores": {2, 4, 8}},
+		err:   "invalid constraint value: cpu-cores=6\nvalid values are:.*",
+	},
+	{
+		cons:        "mem=..4G cpu-power=prefer:100",
+		unsupported: []string{"mem", "cpu-power"},
+	},
+	{
+		cons:  "mem=4G..8G instance-type=prefer:foo",
+		reds:  []string{"mem"},
+		blues: []string{"instance-type"},
+		err:   `ambiguous constraints: "instance-type" overlaps with "mem"`,
+	},
 }
 
 func (s *validationSuite) TestValidation(c *gc.C) {
@@ -250,6 +285,42 @@ var mergeTests = []struct {
 		desc:         "root-disk from fallback",
 		consFallback: "root-disk=8G",
 		expected:     "root-disk=8G",
+	}, {
+		desc:         "mem range with ignored fallback",
+		cons:         "mem=..4G",
+		consFallback: "mem=8G",
+		expected:     "mem=..4G",
+	}, {
+		desc:         "mem with ignored fallback range",
+		cons:         "mem=8G",
+		consFallback: "mem=1G..4G",
+		expected:     "mem=8G",
+	}, {
+		desc:         "cpu-cores range from fallback",
+		consFallback: "cpu-cores=2..8",
+		expected:     "cpu-cores=2..8",
+	}, {
+		desc:         "preference with ignored fallback",
+		cons:         "mem=prefer:8G",
+		consFallback: "mem=4G",
+		expected:     "mem=prefer:8G",
+	}, {
+		desc:         "requirement with ignored fallback preference",
+		cons:         "arch=amd64",
+		consFallback: "arch=prefer:arm64 mem=prefer:8G",
+		expected:     "arch=amd64 mem=prefer:8G",
+	}, {
+		desc:         "alternatives with ignored fallback",
+		cons:         "instance-type=foo|bar",
+		consFallback: "instance-type=baz",
+		expected:     "instance-type=foo|bar",
+	}, {
+		desc:         "preference conflict masked from fallback",
+		consFallback: "root-disk=8G mem=prefer:4G",
+		cons:         "instance-type=prefer:bar",
+		reds:         []string{"mem", "arch"},
+		blues:        []string{"instance-type"},
+		expected:     "root-disk=8G instance-type=prefer:bar",
 	}, {
 		desc:         "non-overlapping mix",
 		cons:         "root-disk=8G mem=4G arch=amd64",
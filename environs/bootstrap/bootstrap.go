@@ -138,7 +138,7 @@ func Bootstrap(ctx environs.BootstrapContext, environ environs.Environ, args Boo
 	disableNetworkManagement, _ := cfg.DisableNetworkManagement()
 	logger.Debugf("network management by juju enabled: %v", !disableNetworkManagement)
 	availableTools, err := findAvailableTools(
-		environ, args.AgentVersion, bootstrapConstraints.Arch,
+		environ, args.AgentVersion, bootstrapArches(bootstrapConstraints),
 		bootstrapSeries, args.UploadTools,
 	)
 	if errors.IsNotFound(err) {
@@ -428,6 +428,17 @@ func setPrivateMetadataSources(env environs.Environ, metadataDir string) ([]*ima
 	return existingMetadata, nil
 }
 
+// bootstrapArches returns the architectures that bootstrap tools
+// must be found for to satisfy the given constraints, or nil if
+// any architecture will do. An arch constraint that is only a
+// preference does not restrict the tools.
+func bootstrapArches(cons constraints.Value) []string {
+	if !cons.HasArch() || cons.IsPreferred(constraints.Arch) {
+		return nil
+	}
+	return cons.Arches()
+}
+
 func validateConstraints(env environs.Environ, cons constraints.Value) error {
 	validator, err := env.ConstraintsValidator()
 	if err != nil {
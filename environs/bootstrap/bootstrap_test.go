@@ -116,6 +116,29 @@ func (s *bootstrapSuite) TestBootstrapSpecifiedConstraints(c *gc.C) {
 	c.Assert(env.args.EnvironConstraints, gc.DeepEquals, environCons)
 }
 
+func (s *bootstrapSuite) TestBootstrapArchAlternatives(c *gc.C) {
+	s.PatchValue(&arch.HostArch, func() string { return arch.AMD64 })
+	s.PatchValue(bootstrap.FindTools, func(_ environs.Environ, _, _ int, _ string, f tools.Filter) (tools.List, error) {
+		c.Assert(f.Arch, gc.Equals, "")
+		var list tools.List
+		for _, toolsArch := range []string{arch.ARM64, arch.PPC64EL} {
+			list = append(list, &tools.Tools{
+				Version: version.Binary{Number: version.Current, Series: "trusty", Arch: toolsArch},
+				URL:     "http://testing.invalid/" + toolsArch,
+			})
+		}
+		return list, nil
+	})
+	env := newEnviron("foo", useDefaultKeys, nil)
+	s.setDummyStorage(c, env)
+	err := bootstrap.Bootstrap(envtesting.BootstrapContext(c), env, bootstrap.BootstrapParams{
+		BootstrapConstraints: constraints.MustParse("arch=amd64|arm64"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(env.bootstrapCount, gc.Equals, 1)
+	c.Assert(env.args.AvailableTools.Arches(), jc.DeepEquals, []string{arch.ARM64})
+}
+
 func (s *bootstrapSuite) TestBootstrapSpecifiedPlacement(c *gc.C) {
 	env := newEnviron("foo", useDefaultKeys, nil)
 	s.setDummyStorage(c, env)
@@ -5,6 +5,7 @@ package bootstrap
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/juju/errors"
 	"github.com/juju/utils/arch"
@@ -65,11 +66,13 @@ func validateUploadAllowed(env environs.Environ, toolsArch, toolsSeries *string)
 // findAvailableTools returns a list of available tools,
 // including tools that may be locally built and then
 // uploaded. Tools that need to be built will have an
-// empty URL.
-func findAvailableTools(env environs.Environ, vers *version.Number, arch, series *string, upload bool) (coretools.List, error) {
+// empty URL. If arches is not empty, only tools for one
+// of the named architectures are returned.
+func findAvailableTools(env environs.Environ, vers *version.Number, arches []string, series *string, upload bool) (coretools.List, error) {
+	uploadArch := uploadToolsArch(arches)
 	if upload {
 		// We're forcing an upload: ensure we can do so.
-		if err := validateUploadAllowed(env, arch, series); err != nil {
+		if err := validateUploadAllowed(env, uploadArch, series); err != nil {
 			return nil, err
 		}
 		return locallyBuildableTools(series), nil
@@ -87,10 +90,20 @@ func findAvailableTools(env environs.Environ, vers *version.Number, arch, series
 		}
 	}
 	logger.Infof("looking for bootstrap tools: version=%v", vers)
+	var arch *string
+	if len(arches) == 1 {
+		arch = &arches[0]
+	}
 	toolsList, findToolsErr := findBootstrapTools(env, vers, arch, series)
 	if findToolsErr != nil && !errors.IsNotFound(findToolsErr) {
 		return nil, findToolsErr
 	}
+	if findToolsErr == nil && len(arches) > 1 {
+		toolsList = filterToolsArches(toolsList, arches)
+		if len(toolsList) == 0 {
+			findToolsErr = errors.NotFoundf("tools for architectures %s", strings.Join(arches, ", "))
+		}
+	}
 
 	preferredStream := envtools.PreferredStream(vers, env.Config().Development(), env.Config().AgentStream())
 	if preferredStream == envtools.ReleasedStream || vers != nil {
@@ -118,12 +131,43 @@ func findAvailableTools(env environs.Environ, vers *version.Number, arch, series
 			localToolsList = append(localToolsList, tools)
 		}
 	}
-	if len(localToolsList) == 0 || validateUploadAllowed(env, arch, series) != nil {
+	if len(localToolsList) == 0 || validateUploadAllowed(env, uploadArch, series) != nil {
 		return toolsList, findToolsErr
 	}
 	return append(toolsList, localToolsList...), nil
 }
 
+// uploadToolsArch returns the architecture that locally built tools
+// must match given the allowed architectures: the host architecture
+// if it is one of them, or else all of them, which will fail upload
+// validation with an error naming each one.
+func uploadToolsArch(arches []string) *string {
+	if len(arches) == 0 {
+		return nil
+	}
+	hostArch := arch.HostArch()
+	for _, a := range arches {
+		if a == hostArch {
+			return &hostArch
+		}
+	}
+	allowed := strings.Join(arches, "|")
+	return &allowed
+}
+
+// filterToolsArches returns the tools in the list whose
+// architecture is one of those given.
+func filterToolsArches(list coretools.List, arches []string) coretools.List {
+	allowed := set.NewStrings(arches...)
+	var result coretools.List
+	for _, tools := range list {
+		if allowed.Contains(tools.Version.Arch) {
+			result = append(result, tools)
+		}
+	}
+	return result
+}
+
 // locallyBuildableTools returns the list of tools that
 // can be built locally, for series of the same OS.
 func locallyBuildableTools(toolsSeries *string) (buildable coretools.List) {
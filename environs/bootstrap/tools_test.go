@@ -302,3 +302,53 @@ func (s *toolsSuite) TestFindAvailableToolsCompleteNoValidate(c *gc.C) {
 	c.Assert(availableTools, gc.HasLen, len(allTools))
 	c.Assert(env.supportedArchitecturesCount, gc.Equals, 0)
 }
+
+func (s *toolsSuite) TestFindAvailableToolsArchAlternatives(c *gc.C) {
+	toolsForArch := func(arch string) *tools.Tools {
+		return &tools.Tools{
+			Version: version.MustParseBinary("1.2.3-trusty-" + arch),
+			URL:     "http://testing.invalid/tools.tar.gz",
+		}
+	}
+	s.PatchValue(bootstrap.FindTools, func(_ environs.Environ, major, minor int, stream string, f tools.Filter) (tools.List, error) {
+		c.Assert(f.Arch, gc.Equals, "")
+		return tools.List{
+			toolsForArch(arch.AMD64),
+			toolsForArch(arch.ARM64),
+			toolsForArch(arch.PPC64EL),
+		}, nil
+	})
+	env := newEnviron("foo", useDefaultKeys, nil)
+	availableTools, err := bootstrap.FindAvailableTools(env, nil, []string{arch.AMD64, arch.ARM64}, nil, false)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(availableTools.Arches(), jc.SameContents, []string{arch.AMD64, arch.ARM64})
+}
+
+func (s *toolsSuite) TestFindAvailableToolsArchAlternativesNotFound(c *gc.C) {
+	s.PatchValue(bootstrap.FindTools, func(_ environs.Environ, major, minor int, stream string, f tools.Filter) (tools.List, error) {
+		return tools.List{&tools.Tools{
+			Version: version.MustParseBinary("1.2.3-trusty-ppc64el"),
+			URL:     "http://testing.invalid/tools.tar.gz",
+		}}, nil
+	})
+	env := newEnviron("foo", useDefaultKeys, map[string]interface{}{
+		"agent-version": "1.17.1",
+	})
+	_, err := bootstrap.FindAvailableTools(env, nil, []string{arch.AMD64, arch.ARM64}, nil, false)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *toolsSuite) TestFindAvailableToolsForceUploadArchAlternatives(c *gc.C) {
+	s.PatchValue(&arch.HostArch, func() string { return arch.ARM64 })
+	env := newEnviron("foo", useDefaultKeys, nil)
+	uploadedTools, err := bootstrap.FindAvailableTools(env, nil, []string{arch.AMD64, arch.ARM64}, nil, true)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(uploadedTools.Arches(), jc.DeepEquals, []string{arch.ARM64})
+}
+
+func (s *toolsSuite) TestFindAvailableToolsForceUploadArchAlternativesExcludeHost(c *gc.C) {
+	s.PatchValue(&arch.HostArch, func() string { return arch.AMD64 })
+	env := newEnviron("foo", useDefaultKeys, nil)
+	_, err := bootstrap.FindAvailableTools(env, nil, []string{arch.ARM64, arch.PPC64EL}, nil, true)
+	c.Assert(err, gc.ErrorMatches, `cannot build tools for "arm64\|ppc64el" using a machine running on "amd64"`)
+}
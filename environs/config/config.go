@@ -26,6 +26,7 @@ import (
 	"github.com/juju/juju/cert"
 	"github.com/juju/juju/environs/tags"
 	"github.com/juju/juju/juju/osenv"
+	"github.com/juju/juju/utils/cron"
 	"github.com/juju/juju/version"
 )
 
@@ -93,6 +94,34 @@ const (
 	// config setting. Only non-zero, positive integer values will
 	// have effect.
 	DefaultLXCDefaultMTU = 0
+
+	// DefaultAuditLogMaxSize is the default size in megabytes at
+	// which the audit log file is rotated.
+	DefaultAuditLogMaxSize = 100
+
+	// DefaultAuditLogMaxBackups is the default number of rotated
+	// audit log files to keep.
+	DefaultAuditLogMaxBackups = 5
+
+	// DefaultActionsMaxAge is the default age beyond which the
+	// records of finished actions are pruned.
+	DefaultActionsMaxAge = 14 * 24 * time.Hour
+
+	// DefaultActionsMaxCount is the default number of finished
+	// actions beyond which the records of the oldest are pruned.
+	DefaultActionsMaxCount = 10000
+
+	// DefaultBackupKeepDaily is the default number of days for which
+	// the newest scheduled backup of the day is kept.
+	DefaultBackupKeepDaily = 7
+
+	// DefaultBackupKeepWeekly is the default number of weeks for
+	// which the newest scheduled backup of the week is kept.
+	DefaultBackupKeepWeekly = 4
+
+	// DefaultResourceHistoryLimit is the default number of uploaded
+	// revisions of each resource that are retained.
+	DefaultResourceHistoryLimit = 5
 )
 
 // TODO(katco-): Please grow this over time.
@@ -184,6 +213,78 @@ const (
 	// automatically retry a hook that has failed
 	AutomaticallyRetryHooks = "automatically-retry-hooks"
 
+	// AuditLogFileKey is the name of a file, in the audit directory
+	// under the controller's log directory, to which audit entries
+	// are written in addition to the database.
+	AuditLogFileKey = "audit-log-file"
+
+	// AuditLogMaxSizeKey is the size in megabytes at which the
+	// audit log file is rotated.
+	AuditLogMaxSizeKey = "audit-log-max-size"
+
+	// AuditLogMaxBackupsKey is the number of rotated audit log
+	// files to keep.
+	AuditLogMaxBackupsKey = "audit-log-max-backups"
+
+	// AuditSyslogKey, when true, causes audit entries to be
+	// written to syslog on the controller.
+	AuditSyslogKey = "audit-syslog"
+
+	// AuditWebhookURLKey is an http or https URL to which audit
+	// entries are POSTed.
+	AuditWebhookURLKey = "audit-webhook-url"
+
+	// LogArchiveKey, when true, causes logs to be archived to
+	// controller storage before they are pruned from the database.
+	LogArchiveKey = "log-archive"
+
+	// LogsMaxAgeKey is the age, given as a duration such as "72h",
+	// beyond which the model's logs are pruned.
+	LogsMaxAgeKey = "logs-max-age"
+
+	// LogsMaxSizeKey is the size in megabytes beyond which the
+	// model's oldest logs are pruned.
+	LogsMaxSizeKey = "logs-max-size"
+
+	// ActionsMaxAgeKey is the age, given as a duration such as
+	// "336h", beyond which the records of finished actions are
+	// pruned.
+	ActionsMaxAgeKey = "actions-max-age"
+
+	// ActionsMaxCountKey is the number of finished actions beyond
+	// which the records of the oldest are pruned.
+	ActionsMaxCountKey = "actions-max-count"
+
+	// HookTimeoutKey is the duration, such as "30m", after which a
+	// running hook is killed and the unit marked in error. Hooks
+	// may run indefinitely if it is not set.
+	HookTimeoutKey = "hook-timeout"
+
+	// BackupScheduleKey is the cron-style schedule, such as
+	// "0 3 * * *", on which the controller creates full backups of
+	// itself. No backups are scheduled if it is not set. Like the
+	// other backup-* keys, it may only be set on the controller
+	// model.
+	BackupScheduleKey = "backup-schedule"
+
+	// BackupIncrementalScheduleKey is the cron-style schedule on
+	// which the controller creates incremental backups, holding the
+	// changes made since the previous scheduled backup.
+	BackupIncrementalScheduleKey = "backup-incremental-schedule"
+
+	// BackupKeepDailyKey is the number of days for which the newest
+	// scheduled backup of the day is kept.
+	BackupKeepDailyKey = "backup-keep-daily"
+
+	// BackupKeepWeeklyKey is the number of weeks for which the newest
+	// scheduled backup of the week is kept.
+	BackupKeepWeeklyKey = "backup-keep-weekly"
+
+	// ResourceHistoryLimitKey is the number of uploaded revisions of
+	// each resource that are retained, so that services can be rolled
+	// back to them.
+	ResourceHistoryLimitKey = "resource-history-limit"
+
 	//
 	// Deprecated Settings Attributes
 	//
@@ -261,6 +362,21 @@ var ProxyAttributes = []string{
 	AptFtpProxyKey,
 }
 
+// ControllerModelOnlyAttributes holds the attributes that configure the
+// controller itself. They may only be set on the controller model, so
+// that hosted model admins cannot change how the controller behaves.
+var ControllerModelOnlyAttributes = []string{
+	AuditLogFileKey,
+	AuditLogMaxSizeKey,
+	AuditLogMaxBackupsKey,
+	AuditSyslogKey,
+	AuditWebhookURLKey,
+	BackupScheduleKey,
+	BackupIncrementalScheduleKey,
+	BackupKeepDailyKey,
+	BackupKeepWeeklyKey,
+}
+
 // String returns the description of the harvesting mode.
 func (method HarvestMode) String() string {
 	if description, ok := harvestingMethodToFlag[method]; ok {
@@ -667,6 +783,44 @@ func Validate(cfg, old *Config) error {
 		}
 	}
 
+	if v, ok := cfg.defined[AuditLogFileKey].(string); ok && v != "" {
+		if v == "." || v == ".." || strings.ContainsAny(v, `/\`) {
+			return errors.Errorf("%s: expected a file name, got %q", AuditLogFileKey, v)
+		}
+	}
+	if v, ok := cfg.defined[AuditWebhookURLKey].(string); ok {
+		u, err := url.Parse(v)
+		if err != nil {
+			return fmt.Errorf("invalid audit webhook URL: %v", err)
+		}
+		if u.Scheme != "http" && u.Scheme != "https" {
+			return fmt.Errorf("audit webhook URL needs to be http or https")
+		}
+	}
+	for _, attr := range []string{LogsMaxAgeKey, ActionsMaxAgeKey, HookTimeoutKey} {
+		if v, ok := cfg.defined[attr].(string); ok {
+			d, err := time.ParseDuration(v)
+			if err != nil || d <= 0 {
+				return errors.Errorf("%s: expected positive duration, got %q", attr, v)
+			}
+		}
+	}
+	for _, attr := range []string{BackupScheduleKey, BackupIncrementalScheduleKey} {
+		if v, ok := cfg.defined[attr].(string); ok && v != "" {
+			if _, err := cron.Parse(v); err != nil {
+				return errors.Annotate(err, attr)
+			}
+		}
+	}
+	for _, attr := range []string{
+		AuditLogMaxSizeKey, AuditLogMaxBackupsKey, LogsMaxSizeKey, ActionsMaxCountKey,
+		BackupKeepDailyKey, BackupKeepWeeklyKey, ResourceHistoryLimitKey,
+	} {
+		if v, ok := cfg.defined[attr].(int); ok && v < 0 {
+			return errors.Errorf("%s: expected positive integer, got %v", attr, v)
+		}
+	}
+
 	// Check LXCDefaultMTU is a positive integer, when set.
 	if lxcDefaultMTU, ok := cfg.LXCDefaultMTU(); ok && lxcDefaultMTU < 0 {
 		return errors.Errorf("%s: expected positive integer, got %v", LXCDefaultMTU, lxcDefaultMTU)
@@ -1167,6 +1321,154 @@ func (c *Config) IgnoreMachineAddresses() (bool, bool) {
 	return v, ok
 }
 
+// AuditLogFile returns the name of the file in the controller's audit
+// log directory to which audit entries are written, and whether it is
+// set.
+func (c *Config) AuditLogFile() (string, bool) {
+	path := c.asString(AuditLogFileKey)
+	return path, path != ""
+}
+
+// AuditLogMaxSize returns the size in megabytes at which the audit log
+// file is rotated.
+func (c *Config) AuditLogMaxSize() int {
+	if v, ok := c.defined[AuditLogMaxSizeKey].(int); ok && v > 0 {
+		return v
+	}
+	return DefaultAuditLogMaxSize
+}
+
+// AuditLogMaxBackups returns the number of rotated audit log files
+// to keep. Zero means that all of them are kept.
+func (c *Config) AuditLogMaxBackups() int {
+	if v, ok := c.defined[AuditLogMaxBackupsKey].(int); ok {
+		return v
+	}
+	return DefaultAuditLogMaxBackups
+}
+
+// AuditSyslog reports whether audit entries are written to syslog
+// on the controller.
+func (c *Config) AuditSyslog() bool {
+	v, _ := c.defined[AuditSyslogKey].(bool)
+	return v
+}
+
+// AuditWebhookURL returns the URL to which audit entries are POSTed,
+// and whether it is set.
+func (c *Config) AuditWebhookURL() (string, bool) {
+	u := c.asString(AuditWebhookURLKey)
+	return u, u != ""
+}
+
+// LogArchive reports whether logs are archived before they are
+// pruned.
+func (c *Config) LogArchive() bool {
+	v, _ := c.defined[LogArchiveKey].(bool)
+	return v
+}
+
+// LogsMaxAge returns the age beyond which the model's logs are
+// pruned, and whether it is set.
+func (c *Config) LogsMaxAge() (time.Duration, bool) {
+	v := c.asString(LogsMaxAgeKey)
+	if v == "" {
+		return 0, false
+	}
+	// The value has already been validated.
+	d, _ := time.ParseDuration(v)
+	return d, true
+}
+
+// LogsMaxSize returns the size in megabytes beyond which the model's
+// oldest logs are pruned, and whether it is set.
+func (c *Config) LogsMaxSize() (int, bool) {
+	v, ok := c.defined[LogsMaxSizeKey].(int)
+	return v, ok && v > 0
+}
+
+// ActionsMaxAge returns the age beyond which the records of
+// finished actions are pruned.
+func (c *Config) ActionsMaxAge() time.Duration {
+	v := c.asString(ActionsMaxAgeKey)
+	if v == "" {
+		return DefaultActionsMaxAge
+	}
+	// The value has already been validated.
+	d, _ := time.ParseDuration(v)
+	return d
+}
+
+// HookTimeout returns the duration after which a running hook is
+// killed, and whether it is set.
+func (c *Config) HookTimeout() (time.Duration, bool) {
+	v := c.asString(HookTimeoutKey)
+	if v == "" {
+		return 0, false
+	}
+	// The value has already been validated.
+	d, _ := time.ParseDuration(v)
+	return d, true
+}
+
+// ActionsMaxCount returns the number of finished actions beyond which
+// the records of the oldest are pruned.
+func (c *Config) ActionsMaxCount() int {
+	if v, ok := c.defined[ActionsMaxCountKey].(int); ok && v > 0 {
+		return v
+	}
+	return DefaultActionsMaxCount
+}
+
+// BackupSchedule returns the schedule on which the controller creates
+// full backups, and whether it is set.
+func (c *Config) BackupSchedule() (*cron.Schedule, bool) {
+	return c.schedule(BackupScheduleKey)
+}
+
+// BackupIncrementalSchedule returns the schedule on which the
+// controller creates incremental backups, and whether it is set.
+func (c *Config) BackupIncrementalSchedule() (*cron.Schedule, bool) {
+	return c.schedule(BackupIncrementalScheduleKey)
+}
+
+func (c *Config) schedule(attr string) (*cron.Schedule, bool) {
+	v := c.asString(attr)
+	if v == "" {
+		return nil, false
+	}
+	// The value has already been validated.
+	schedule, _ := cron.Parse(v)
+	return schedule, true
+}
+
+// BackupKeepDaily returns the number of days for which the newest
+// scheduled backup of the day is kept.
+func (c *Config) BackupKeepDaily() int {
+	if v, ok := c.defined[BackupKeepDailyKey].(int); ok {
+		return v
+	}
+	return DefaultBackupKeepDaily
+}
+
+// BackupKeepWeekly returns the number of weeks for which the newest
+// scheduled backup of the week is kept.
+func (c *Config) BackupKeepWeekly() int {
+	if v, ok := c.defined[BackupKeepWeeklyKey].(int); ok {
+		return v
+	}
+	return DefaultBackupKeepWeekly
+}
+
+// ResourceHistoryLimit returns the number of uploaded revisions of
+// each resource that are retained.
+func (c *Config) ResourceHistoryLimit() int {
+	if v, ok := c.defined[ResourceHistoryLimitKey].(int); ok {
+		return v
+	}
+	return DefaultResourceHistoryLimit
+}
+
 // StorageDefaultBlockSource returns the default block storage
 // source for the environment.
 func (c *Config) StorageDefaultBlockSource() (string, bool) {
@@ -1323,6 +1625,22 @@ var alwaysOptional = schema.Defaults{
 	AllowLXCLoopMounts:           false,
 	ResourceTagsKey:              schema.Omit,
 	CloudImageBaseURL:            schema.Omit,
+	AuditLogFileKey:              schema.Omit,
+	AuditLogMaxSizeKey:           schema.Omit,
+	AuditLogMaxBackupsKey:        schema.Omit,
+	AuditSyslogKey:               schema.Omit,
+	AuditWebhookURLKey:           schema.Omit,
+	LogArchiveKey:                schema.Omit,
+	LogsMaxAgeKey:                schema.Omit,
+	LogsMaxSizeKey:               schema.Omit,
+	ActionsMaxAgeKey:             schema.Omit,
+	ActionsMaxCountKey:           schema.Omit,
+	HookTimeoutKey:               schema.Omit,
+	BackupScheduleKey:            schema.Omit,
+	BackupIncrementalScheduleKey: schema.Omit,
+	BackupKeepDailyKey:           schema.Omit,
+	BackupKeepWeeklyKey:          schema.Omit,
+	ResourceHistoryLimitKey:      schema.Omit,
 
 	// AutomaticallyRetryHooks is assumed to be true if missing
 	AutomaticallyRetryHooks: schema.Omit,
@@ -1914,4 +2232,84 @@ data of the store. (default false)`,
 		Immutable:   true,
 		Group:       environschema.EnvironGroup,
 	},
+	AuditLogFileKey: {
+		Description: "The name of a file in the controller's audit log directory to which audit entries are written",
+		Type:        environschema.Tstring,
+		Group:       environschema.JujuGroup,
+	},
+	AuditLogMaxSizeKey: {
+		Description: "The size in megabytes at which the audit log file is rotated",
+		Type:        environschema.Tint,
+		Group:       environschema.JujuGroup,
+	},
+	AuditLogMaxBackupsKey: {
+		Description: "The number of rotated audit log files to keep, or 0 to keep all of them",
+		Type:        environschema.Tint,
+		Group:       environschema.JujuGroup,
+	},
+	AuditSyslogKey: {
+		Description: "Whether audit entries are written to syslog on the controller",
+		Type:        environschema.Tbool,
+		Group:       environschema.JujuGroup,
+	},
+	AuditWebhookURLKey: {
+		Description: "An http or https URL to which audit entries are POSTed as JSON",
+		Type:        environschema.Tstring,
+		Group:       environschema.JujuGroup,
+	},
+	LogArchiveKey: {
+		Description: "Whether logs are archived to controller storage before being pruned",
+		Type:        environschema.Tbool,
+		Group:       environschema.JujuGroup,
+	},
+	LogsMaxAgeKey: {
+		Description: "The age, such as 72h, beyond which the model's logs are pruned",
+		Type:        environschema.Tstring,
+		Group:       environschema.JujuGroup,
+	},
+	LogsMaxSizeKey: {
+		Description: "The size in megabytes beyond which the model's oldest logs are pruned",
+		Type:        environschema.Tint,
+		Group:       environschema.JujuGroup,
+	},
+	ActionsMaxAgeKey: {
+		Description: "The age, such as 336h, beyond which the records of finished actions are pruned",
+		Type:        environschema.Tstring,
+		Group:       environschema.JujuGroup,
+	},
+	ActionsMaxCountKey: {
+		Description: "The number of finished actions beyond which the records of the oldest are pruned",
+		Type:        environschema.Tint,
+		Group:       environschema.JujuGroup,
+	},
+	HookTimeoutKey: {
+		Description: "The duration, such as 30m, after which a running hook is killed and the unit marked in error",
+		Type:        environschema.Tstring,
+		Group:       environschema.JujuGroup,
+	},
+	BackupScheduleKey: {
+		Description: "The cron-style schedule, such as \"0 3 * * *\", on which the controller creates full backups",
+		Type:        environschema.Tstring,
+		Group:       environschema.JujuGroup,
+	},
+	BackupIncrementalScheduleKey: {
+		Description: "The cron-style schedule on which the controller creates incremental backups",
+		Type:        environschema.Tstring,
+		Group:       environschema.JujuGroup,
+	},
+	BackupKeepDailyKey: {
+		Description: "The number of days for which the newest scheduled backup of the day is kept",
+		Type:        environschema.Tint,
+		Group:       environschema.JujuGroup,
+	},
+	BackupKeepWeeklyKey: {
+		Description: "The number of weeks for which the newest scheduled backup of the week is kept",
+		Type:        environschema.Tint,
+		Group:       environschema.JujuGroup,
+	},
+	ResourceHistoryLimitKey: {
+		Description: "The number of uploaded revisions of each resource that are retained",
+		Type:        environschema.Tint,
+		Group:       environschema.JujuGroup,
+	},
 }
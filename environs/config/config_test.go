@@ -259,6 +259,178 @@ var configTests = []configTest{
 			"lxc-default-mtu": -42,
 		},
 		err: `lxc-default-mtu: expected positive integer, got -42`,
+	}, {
+		about:       "Audit sinks set",
+		useDefaults: config.UseDefaults,
+		attrs: testing.Attrs{
+			"type":                  "my-type",
+			"name":                  "my-name",
+			"audit-log-file":        "audit.log",
+			"audit-log-max-size":    10,
+			"audit-log-max-backups": 2,
+			"audit-syslog":          true,
+			"audit-webhook-url":     "https://audit.example.com/events",
+		},
+	}, {
+		about:       "Audit log max backups zero",
+		useDefaults: config.UseDefaults,
+		attrs: testing.Attrs{
+			"type":                  "my-type",
+			"name":                  "my-name",
+			"audit-log-max-backups": 0,
+		},
+	}, {
+		about:       "Audit log file with a directory",
+		useDefaults: config.UseDefaults,
+		attrs: testing.Attrs{
+			"type":           "my-type",
+			"name":           "my-name",
+			"audit-log-file": "../../etc/cron.d/audit",
+		},
+		err: `audit-log-file: expected a file name, got "../../etc/cron.d/audit"`,
+	}, {
+		about:       "Audit log file parent directory",
+		useDefaults: config.UseDefaults,
+		attrs: testing.Attrs{
+			"type":           "my-type",
+			"name":           "my-name",
+			"audit-log-file": "..",
+		},
+		err: `audit-log-file: expected a file name, got ".."`,
+	}, {
+		about:       "Audit log max size invalid (negative)",
+		useDefaults: config.UseDefaults,
+		attrs: testing.Attrs{
+			"type":               "my-type",
+			"name":               "my-name",
+			"audit-log-max-size": -1,
+		},
+		err: `audit-log-max-size: expected positive integer, got -1`,
+	}, {
+		about:       "Audit webhook URL with unsupported scheme",
+		useDefaults: config.UseDefaults,
+		attrs: testing.Attrs{
+			"type":              "my-type",
+			"name":              "my-name",
+			"audit-webhook-url": "ftp://audit.example.com/",
+		},
+		err: `audit webhook URL needs to be http or https`,
+	}, {
+		about:       "Log archive enabled",
+		useDefaults: config.UseDefaults,
+		attrs: testing.Attrs{
+			"type":        "my-type",
+			"name":        "my-name",
+			"log-archive": true,
+		},
+	}, {
+		about:       "Model log retention set",
+		useDefaults: config.UseDefaults,
+		attrs: testing.Attrs{
+			"type":          "my-type",
+			"name":          "my-name",
+			"logs-max-age":  "72h",
+			"logs-max-size": 100,
+		},
+	}, {
+		about:       "Model log max age invalid",
+		useDefaults: config.UseDefaults,
+		attrs: testing.Attrs{
+			"type":         "my-type",
+			"name":         "my-name",
+			"logs-max-age": "3 days",
+		},
+		err: `logs-max-age: expected positive duration, got "3 days"`,
+	}, {
+		about:       "Model log max size invalid (negative)",
+		useDefaults: config.UseDefaults,
+		attrs: testing.Attrs{
+			"type":          "my-type",
+			"name":          "my-name",
+			"logs-max-size": -1,
+		},
+		err: `logs-max-size: expected positive integer, got -1`,
+	}, {
+		about:       "Action retention set",
+		useDefaults: config.UseDefaults,
+		attrs: testing.Attrs{
+			"type":              "my-type",
+			"name":              "my-name",
+			"actions-max-age":   "24h",
+			"actions-max-count": 50,
+		},
+	}, {
+		about:       "Action max age invalid",
+		useDefaults: config.UseDefaults,
+		attrs: testing.Attrs{
+			"type":            "my-type",
+			"name":            "my-name",
+			"actions-max-age": "-1h",
+		},
+		err: `actions-max-age: expected positive duration, got "-1h"`,
+	}, {
+		about:       "Hook timeout set",
+		useDefaults: config.UseDefaults,
+		attrs: testing.Attrs{
+			"type":         "my-type",
+			"name":         "my-name",
+			"hook-timeout": "30m",
+		},
+	}, {
+		about:       "Hook timeout invalid",
+		useDefaults: config.UseDefaults,
+		attrs: testing.Attrs{
+			"type":         "my-type",
+			"name":         "my-name",
+			"hook-timeout": "soon",
+		},
+		err: `hook-timeout: expected positive duration, got "soon"`,
+	}, {
+		about:       "Backup schedule set",
+		useDefaults: config.UseDefaults,
+		attrs: testing.Attrs{
+			"type":                        "my-type",
+			"name":                        "my-name",
+			"backup-schedule":             "0 3 * * 0",
+			"backup-incremental-schedule": "@hourly",
+			"backup-keep-daily":           0,
+			"backup-keep-weekly":          8,
+		},
+	}, {
+		about:       "Backup schedule invalid",
+		useDefaults: config.UseDefaults,
+		attrs: testing.Attrs{
+			"type":            "my-type",
+			"name":            "my-name",
+			"backup-schedule": "3am",
+		},
+		err: `backup-schedule: schedule "3am": expected 5 fields, got 1 not valid`,
+	}, {
+		about:       "Backup retention invalid",
+		useDefaults: config.UseDefaults,
+		attrs: testing.Attrs{
+			"type":              "my-type",
+			"name":              "my-name",
+			"backup-keep-daily": -1,
+		},
+		err: `backup-keep-daily: expected positive integer, got -1`,
+	}, {
+		about:       "Resource history limit set",
+		useDefaults: config.UseDefaults,
+		attrs: testing.Attrs{
+			"type":                   "my-type",
+			"name":                   "my-name",
+			"resource-history-limit": 10,
+		},
+	}, {
+		about:       "Resource history limit invalid",
+		useDefaults: config.UseDefaults,
+		attrs: testing.Attrs{
+			"type":                   "my-type",
+			"name":                   "my-name",
+			"resource-history-limit": -1,
+		},
+		err: `resource-history-limit: expected positive integer, got -1`,
 	}, {
 		about:       "CA cert & key from path",
 		useDefaults: config.UseDefaults,
@@ -1228,6 +1400,95 @@ func (test configTest) check(c *gc.C, home *gitjujutesting.FakeHome) {
 	if identityURL, ok := test.attrs["identity-url"]; ok {
 		c.Assert(cfg.IdentityURL(), gc.Equals, identityURL)
 	}
+	if path, ok := test.attrs["audit-log-file"]; ok {
+		got, ok := cfg.AuditLogFile()
+		c.Assert(ok, jc.IsTrue)
+		c.Assert(got, gc.Equals, path)
+	}
+	if maxSize, ok := test.attrs["audit-log-max-size"]; ok {
+		c.Assert(cfg.AuditLogMaxSize(), gc.Equals, maxSize)
+	} else {
+		c.Assert(cfg.AuditLogMaxSize(), gc.Equals, config.DefaultAuditLogMaxSize)
+	}
+	if maxBackups, ok := test.attrs["audit-log-max-backups"]; ok {
+		c.Assert(cfg.AuditLogMaxBackups(), gc.Equals, maxBackups)
+	} else {
+		c.Assert(cfg.AuditLogMaxBackups(), gc.Equals, config.DefaultAuditLogMaxBackups)
+	}
+	auditSyslog, _ := test.attrs["audit-syslog"].(bool)
+	c.Assert(cfg.AuditSyslog(), gc.Equals, auditSyslog)
+	if webhookURL, ok := test.attrs["audit-webhook-url"]; ok {
+		got, ok := cfg.AuditWebhookURL()
+		c.Assert(ok, jc.IsTrue)
+		c.Assert(got, gc.Equals, webhookURL)
+	}
+	logArchive, _ := test.attrs["log-archive"].(bool)
+	c.Assert(cfg.LogArchive(), gc.Equals, logArchive)
+	if maxAge, ok := test.attrs["logs-max-age"]; ok {
+		expected, err := time.ParseDuration(maxAge.(string))
+		c.Assert(err, jc.ErrorIsNil)
+		got, ok := cfg.LogsMaxAge()
+		c.Assert(ok, jc.IsTrue)
+		c.Assert(got, gc.Equals, expected)
+	}
+	if maxSize, ok := test.attrs["logs-max-size"]; ok {
+		got, ok := cfg.LogsMaxSize()
+		c.Assert(ok, jc.IsTrue)
+		c.Assert(got, gc.Equals, maxSize)
+	}
+	if maxAge, ok := test.attrs["actions-max-age"]; ok {
+		expected, err := time.ParseDuration(maxAge.(string))
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(cfg.ActionsMaxAge(), gc.Equals, expected)
+	} else {
+		c.Assert(cfg.ActionsMaxAge(), gc.Equals, config.DefaultActionsMaxAge)
+	}
+	if timeout, ok := test.attrs["hook-timeout"]; ok {
+		expected, err := time.ParseDuration(timeout.(string))
+		c.Assert(err, jc.ErrorIsNil)
+		got, ok := cfg.HookTimeout()
+		c.Assert(ok, jc.IsTrue)
+		c.Assert(got, gc.Equals, expected)
+	} else {
+		_, ok := cfg.HookTimeout()
+		c.Assert(ok, jc.IsFalse)
+	}
+	if spec, ok := test.attrs["backup-schedule"]; ok {
+		schedule, ok := cfg.BackupSchedule()
+		c.Assert(ok, jc.IsTrue)
+		c.Assert(schedule.String(), gc.Equals, spec)
+	} else {
+		_, ok := cfg.BackupSchedule()
+		c.Assert(ok, jc.IsFalse)
+	}
+	if spec, ok := test.attrs["backup-incremental-schedule"]; ok {
+		schedule, ok := cfg.BackupIncrementalSchedule()
+		c.Assert(ok, jc.IsTrue)
+		c.Assert(schedule.String(), gc.Equals, spec)
+	} else {
+		_, ok := cfg.BackupIncrementalSchedule()
+		c.Assert(ok, jc.IsFalse)
+	}
+	if keep, ok := test.attrs["backup-keep-daily"]; ok {
+		c.Assert(cfg.BackupKeepDaily(), gc.Equals, keep)
+	} else {
+		c.Assert(cfg.BackupKeepDaily(), gc.Equals, config.DefaultBackupKeepDaily)
+	}
+	if keep, ok := test.attrs["backup-keep-weekly"]; ok {
+		c.Assert(cfg.BackupKeepWeekly(), gc.Equals, keep)
+	} else {
+		c.Assert(cfg.BackupKeepWeekly(), gc.Equals, config.DefaultBackupKeepWeekly)
+	}
+	if limit, ok := test.attrs["resource-history-limit"]; ok {
+		c.Assert(cfg.ResourceHistoryLimit(), gc.Equals, limit)
+	} else {
+		c.Assert(cfg.ResourceHistoryLimit(), gc.Equals, config.DefaultResourceHistoryLimit)
+	}
+	if maxCount, ok := test.attrs["actions-max-count"]; ok {
+		c.Assert(cfg.ActionsMaxCount(), gc.Equals, maxCount)
+	} else {
+		c.Assert(cfg.ActionsMaxCount(), gc.Equals, config.DefaultActionsMaxCount)
+	}
 	if identityPublicKey, ok := test.attrs["identity-public-key"]; ok {
 		var pk bakery.PublicKey
 		err := pk.UnmarshalText([]byte(identityPublicKey.(string)))
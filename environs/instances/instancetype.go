@@ -36,12 +36,12 @@ func CpuPower(power uint64) *uint64 {
 func (itype InstanceType) match(cons constraints.Value) (InstanceType, bool) {
 	nothing := InstanceType{}
 	if cons.Arch != nil {
-		itype.Arches = filterArches(itype.Arches, []string{*cons.Arch})
+		itype.Arches = filterArches(itype.Arches, cons.Arches())
 	}
 	if itype.Deprecated && !cons.HasInstanceType() {
 		return nothing, false
 	}
-	if cons.HasInstanceType() && itype.Name != *cons.InstanceType {
+	if cons.HasInstanceType() && !nameMatches(itype.Name, cons.InstanceTypes()) {
 		return nothing, false
 	}
 	if len(itype.Arches) == 0 {
@@ -50,15 +50,24 @@ func (itype InstanceType) match(cons constraints.Value) (InstanceType, bool) {
 	if cons.CpuCores != nil && itype.CpuCores < *cons.CpuCores {
 		return nothing, false
 	}
+	if cons.MaxCpuCores != nil && itype.CpuCores > *cons.MaxCpuCores {
+		return nothing, false
+	}
 	if cons.CpuPower != nil && itype.CpuPower != nil && *itype.CpuPower < *cons.CpuPower {
 		return nothing, false
 	}
 	if cons.Mem != nil && itype.Mem < *cons.Mem {
 		return nothing, false
 	}
+	if cons.MaxMem != nil && itype.Mem > *cons.MaxMem {
+		return nothing, false
+	}
 	if cons.RootDisk != nil && itype.RootDisk > 0 && itype.RootDisk < *cons.RootDisk {
 		return nothing, false
 	}
+	if cons.MaxRootDisk != nil && itype.RootDisk > *cons.MaxRootDisk {
+		return nothing, false
+	}
 	if cons.Tags != nil && len(*cons.Tags) > 0 && !tagsMatch(*cons.Tags, itype.Tags) {
 		return nothing, false
 	}
@@ -78,6 +87,16 @@ func filterArches(src, filter []string) (dst []string) {
 	return dst
 }
 
+// nameMatches returns whether name is one of the given names.
+func nameMatches(name string, names []string) bool {
+	for _, match := range names {
+		if name == match {
+			return true
+		}
+	}
+	return false
+}
+
 // minMemoryHeuristic is the assumed minimum amount of memory (in MB) we prefer in order to run a server (1GB)
 const minMemoryHeuristic = 1024
 
@@ -96,7 +115,62 @@ func matchingTypesForConstraint(allTypes []InstanceType, cons constraints.Value)
 
 // MatchingInstanceTypes returns all instance types matching constraints and available
 // in region, sorted by increasing region-specific cost (if known).
+//
+// Preferred constraints are honoured if any instance type satisfies them
+// along with the required ones, trying the alternatives given for a
+// preferred arch or instance-type in order; otherwise they are ignored.
 func MatchingInstanceTypes(allInstanceTypes []InstanceType, region string, cons constraints.Value) ([]InstanceType, error) {
+	attempts := preferenceAttempts(cons)
+	attempts = append(attempts, cons.Required())
+	for _, attempt := range attempts {
+		if itypes := matchingInstanceTypes(allInstanceTypes, attempt); len(itypes) > 0 {
+			return itypes, nil
+		}
+	}
+	return nil, fmt.Errorf("no instance types in %s matching constraints %q", region, cons)
+}
+
+// preferenceAttempts returns the constraints to try, in order, so as to
+// honour cons's preferences: cons itself, with each combination of the
+// alternatives of any preferred arch and instance-type in turn.
+func preferenceAttempts(cons constraints.Value) []constraints.Value {
+	if len(cons.Preferred()) == 0 {
+		return nil
+	}
+	attempts := []constraints.Value{cons}
+	if cons.IsPreferred(constraints.Arch) && cons.Arch != nil {
+		attempts = withAlternatives(attempts, cons.Arches(), func(c *constraints.Value, arch string) {
+			c.Arch = &arch
+		})
+	}
+	if cons.IsPreferred(constraints.InstanceType) && cons.HasInstanceType() {
+		attempts = withAlternatives(attempts, cons.InstanceTypes(), func(c *constraints.Value, name string) {
+			c.InstanceType = &name
+		})
+	}
+	return attempts
+}
+
+// withAlternatives returns a copy of each of the given constraints for
+// each of the alternatives, set by calling set.
+func withAlternatives(
+	attempts []constraints.Value, alternatives []string, set func(*constraints.Value, string),
+) []constraints.Value {
+	var result []constraints.Value
+	for _, attempt := range attempts {
+		for _, alternative := range alternatives {
+			cons := attempt
+			set(&cons, alternative)
+			result = append(result, cons)
+		}
+	}
+	return result
+}
+
+// matchingInstanceTypes returns all instance types matching the
+// constraints, sorted by increasing cost, treating any preferred
+// constraints as required.
+func matchingInstanceTypes(allInstanceTypes []InstanceType, cons constraints.Value) []InstanceType {
 	var itypes []InstanceType
 
 	// Rules used to select instance types:
@@ -122,13 +196,8 @@ func MatchingInstanceTypes(allInstanceTypes []InstanceType, region string, cons
 		}
 	}
 	// If we have matching instance types, we can return those, sorted by cost.
-	if len(itypes) > 0 {
-		sort.Sort(byCost(itypes))
-		return itypes, nil
-	}
-
-	// No luck, so report the error.
-	return nil, fmt.Errorf("no instance types in %s matching constraints %q", region, origCons)
+	sort.Sort(byCost(itypes))
+	return itypes
 }
 
 // tagsMatch returns if the tags in wanted all exist in have.
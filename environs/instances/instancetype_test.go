@@ -224,6 +224,51 @@ var getInstanceTypesTest = []struct {
 		about:          "deprecated image type requested by name with constraints",
 		cons:           "instance-type=dep.small cpu-power=100",
 		expectedItypes: []string{"dep.small"},
+	}, {
+		about:          "mem range",
+		cons:           "mem=4G..8G",
+		expectedItypes: []string{"m1.large", "c1.xlarge"},
+	}, {
+		about:          "cpu-cores maximum",
+		cons:           "cpu-cores=..1",
+		expectedItypes: []string{"m1.small", "m1.medium"},
+	}, {
+		about:          "root-disk maximum",
+		cons:           "root-disk=..8G cpu-power=100",
+		expectedItypes: []string{"m1.small", "c1.medium", "m1.xlarge", "c1.xlarge", "cc1.4xlarge", "cc2.8xlarge"},
+	}, {
+		about:          "arch alternatives",
+		cons:           "cpu-power=100 arch=i386|armhf",
+		expectedItypes: []string{"m1.small", "m1.medium", "c1.medium"},
+		arches:         []string{"armhf"},
+	}, {
+		about:          "instance-type alternatives, cheapest first",
+		cons:           "instance-type=m1.large|m1.small",
+		expectedItypes: []string{"m1.small", "m1.large"},
+	}, {
+		about:          "preferred instance-type alternatives, in order",
+		cons:           "instance-type=prefer:m1.large|m1.small",
+		expectedItypes: []string{"m1.large"},
+	}, {
+		about: "preferred instance-type ignored if none match",
+		cons:  "instance-type=prefer:no.such|m1.medium cpu-cores=2",
+		expectedItypes: []string{
+			"c1.medium", "m1.large", "m1.xlarge", "c1.xlarge", "cc1.4xlarge",
+			"cc2.8xlarge",
+		},
+	}, {
+		about:          "preferred arch alternatives, in order",
+		cons:           "arch=prefer:armhf|amd64 cpu-cores=2",
+		expectedItypes: []string{"c1.medium"},
+		arches:         []string{"armhf"},
+	}, {
+		about:          "preferred mem honoured",
+		cons:           "mem=prefer:16G",
+		expectedItypes: []string{"cc1.4xlarge", "cc2.8xlarge"},
+	}, {
+		about:          "preferred mem ignored if none match",
+		cons:           "mem=prefer:100G cpu-cores=8",
+		expectedItypes: []string{"c1.xlarge", "cc1.4xlarge", "cc2.8xlarge"},
 	},
 }
 
@@ -264,6 +309,12 @@ func (s *instanceTypeSuite) TestGetMatchingInstanceTypesErrors(c *gc.C) {
 
 	_, err = MatchingInstanceTypes(instanceTypes, "test", constraints.MustParse("instance-type=dep.medium mem=8G"))
 	c.Check(err, gc.ErrorMatches, `no instance types in test matching constraints "instance-type=dep.medium mem=8192M"`)
+
+	_, err = MatchingInstanceTypes(instanceTypes, "test", constraints.MustParse("arch=armhf mem=..512M"))
+	c.Check(err, gc.ErrorMatches, `no instance types in test matching constraints "arch=armhf mem=..512M"`)
+
+	_, err = MatchingInstanceTypes(instanceTypes, "test", constraints.MustParse("cpu-cores=9000 mem=prefer:4G"))
+	c.Check(err, gc.ErrorMatches, `no instance types in test matching constraints "cpu-cores=9000 mem=prefer:4096M"`)
 }
 
 var instanceTypeMatchTests = []struct {
@@ -281,12 +332,19 @@ var instanceTypeMatchTests = []struct {
 	{"cpu-power=2000", "c1.xlarge", []string{"amd64"}},
 	{"cpu-power=2001", "cc1.4xlarge", []string{"amd64"}},
 	{"mem=2G", "m1.medium", []string{"amd64", "armhf"}},
+	{"mem=..2G", "m1.small", []string{"amd64", "armhf"}},
+	{"arch=i386|armhf", "m1.small", []string{"armhf"}},
+	{"instance-type=m1.small|m1.large", "m1.large", []string{"amd64"}},
 
 	{"arch=i386", "m1.small", nil},
 	{"cpu-power=100", "t1.micro", nil},
 	{"cpu-power=9001", "cc2.8xlarge", nil},
 	{"mem=1G", "t1.micro", nil},
 	{"arch=armhf", "c1.xlarge", nil},
+	{"mem=..2G", "m1.medium", nil},
+	{"cpu-cores=..1", "m1.large", nil},
+	{"root-disk=..8G", "m1.medium", nil},
+	{"instance-type=m1.small|m1.large", "m1.medium", nil},
 }
 
 func (s *instanceTypeSuite) TestMatch(c *gc.C) {
@@ -38,3 +38,7 @@ const VSphereProvider = "vsphere-provider"
 
 // ImageMetadata allows custom image metadata to be recorded in state.
 const ImageMetadata = "image-metadata"
+
+// BSONAPI makes API clients ask the controller to encode requests and
+// responses as BSON rather than JSON once they have logged in.
+const BSONAPI = "bson-api"
@@ -53,7 +53,7 @@ func (s *dumpLogsCommandSuite) TestRun(c *gc.C) {
 		w := state.NewDbLogger(st, names.NewMachineTag("42"))
 		defer w.Close()
 		for i := 0; i < 3; i++ {
-			err := w.Log(t, "module", "location", loggo.INFO, fmt.Sprintf("%d", i))
+			err := w.Log(t, "module", "location", loggo.INFO, fmt.Sprintf("%d", i), nil)
 			c.Assert(err, jc.ErrorIsNil)
 		}
 	}
@@ -16,6 +16,7 @@ import (
 
 	"github.com/juju/juju/agent"
 	"github.com/juju/juju/api"
+	apilogsender "github.com/juju/juju/api/logsender"
 	agentcmd "github.com/juju/juju/cmd/jujud/agent"
 	agenttesting "github.com/juju/juju/cmd/jujud/agent/testing"
 	"github.com/juju/juju/state"
@@ -151,8 +152,8 @@ func (s *debugLogDbSuite) TestLogsAPI(c *gc.C) {
 	defer dbLogger.Close()
 
 	t := time.Date(2015, 6, 23, 13, 8, 49, 0, time.UTC)
-	dbLogger.Log(t, "juju.foo", "code.go:42", loggo.INFO, "all is well")
-	dbLogger.Log(t.Add(time.Second), "juju.bar", "go.go:99", loggo.ERROR, "no it isn't")
+	dbLogger.Log(t, "juju.foo", "code.go:42", loggo.INFO, "all is well", nil)
+	dbLogger.Log(t.Add(time.Second), "juju.bar", "go.go:99", loggo.ERROR, "no it isn't", nil)
 
 	lines := make(chan string)
 	go func(numLines int) {
@@ -183,6 +184,57 @@ func (s *debugLogDbSuite) TestLogsAPI(c *gc.C) {
 	assertLine("machine-99: 2015-06-23 13:08:50 ERROR juju.bar go.go:99 no it isn't\n")
 
 	// Now write and observe another log. This should be read from the oplog.
-	dbLogger.Log(t.Add(2*time.Second), "ju.jitsu", "no.go:3", loggo.WARNING, "beep beep")
+	dbLogger.Log(t.Add(2*time.Second), "ju.jitsu", "no.go:3", loggo.WARNING, "beep beep", nil)
 	assertLine("machine-99: 2015-06-23 13:08:51 WARNING ju.jitsu no.go:3 beep beep\n")
 }
+
+func (s *debugLogDbSuite) TestLogsAPIFieldsFromAgent(c *gc.C) {
+	// Send hook output from an agent through the log sender, which
+	// derives the hook field from the name of the hook's logger.
+	agentAPI, machine := s.OpenAPIAsNewMachine(c)
+	defer agentAPI.Close()
+	writer := logsender.NewBufferedLogWriter(10)
+	defer writer.Close()
+	sender := logsender.New(writer.Logs(), apilogsender.NewAPI(agentAPI))
+	defer func() {
+		sender.Kill()
+		c.Check(sender.Wait(), jc.ErrorIsNil)
+	}()
+
+	t := time.Date(2015, 6, 23, 13, 8, 49, 0, time.UTC)
+	writer.Write(loggo.INFO, "unit.mysql/0.install", "runner.go", 10, t, "installing")
+	writer.Write(loggo.INFO, "unit.mysql/0.config-changed", "runner.go", 10, t.Add(time.Second), "configuring")
+	writer.Write(loggo.INFO, "unit.mysql/0.install", "runner.go", 10, t.Add(2*time.Second), "installed")
+
+	client := s.APIState.Client()
+	reader, err := client.WatchDebugLog(api.DebugLogParams{
+		IncludeFields: map[string][]string{"hook": {"install"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	defer reader.Close()
+
+	lines := make(chan string, 10)
+	go func() {
+		bufReader := bufio.NewReader(reader)
+		for {
+			line, err := bufReader.ReadString('\n')
+			if err != nil {
+				close(lines)
+				return
+			}
+			lines <- line
+		}
+	}()
+	prefix := machine.Tag().String() + ": "
+	for _, expected := range []string{
+		"2015-06-23 13:08:49 INFO unit.mysql/0.install runner.go:10 installing\n",
+		"2015-06-23 13:08:51 INFO unit.mysql/0.install runner.go:10 installed\n",
+	} {
+		select {
+		case actual := <-lines:
+			c.Assert(actual, gc.Equals, prefix+expected)
+		case <-time.After(coretesting.LongWait):
+			c.Fatal("timed out waiting for log line")
+		}
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"github.com/juju/names"
+	"github.com/juju/utils/featureflag"
 	"github.com/juju/utils/parallel"
 	"gopkg.in/macaroon-bakery.v1/httpbakery"
 
@@ -18,6 +19,7 @@ import (
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/environs/configstore"
+	"github.com/juju/juju/feature"
 	"github.com/juju/juju/network"
 )
 
@@ -283,6 +285,7 @@ func apiInfoConnect(info configstore.EnvironInfo, apiOpen api.OpenFunc, stop <-c
 
 	dialOpts := api.DefaultDialOpts()
 	dialOpts.BakeryClient = bClient
+	dialOpts.UseBSON = featureflag.Enabled(feature.BSONAPI)
 
 	st, err := apiOpen(apiInfo, dialOpts)
 	if err != nil {
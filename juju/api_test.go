@@ -27,6 +27,7 @@ import (
 	sstesting "github.com/juju/juju/environs/simplestreams/testing"
 	envtesting "github.com/juju/juju/environs/testing"
 	envtools "github.com/juju/juju/environs/tools"
+	"github.com/juju/juju/feature"
 	"github.com/juju/juju/juju"
 	"github.com/juju/juju/juju/osenv"
 	jujutesting "github.com/juju/juju/juju/testing"
@@ -431,6 +432,22 @@ func (s *NewAPIClientSuite) TestWithInfoNoModelTag(c *gc.C) {
 	c.Check(info.APIEndpoint().ModelUUID, gc.Equals, fakeUUID)
 }
 
+func (s *NewAPIClientSuite) TestWithInfoUseBSON(c *gc.C) {
+	s.SetFeatureFlags(feature.BSONAPI)
+	store := newConfigStore("noconfig", noTagStoreInfo)
+
+	var gotOpts api.DialOpts
+	expectState := mockedAPIState(mockedHostPort | mockedModelTag)
+	apiOpen := func(apiInfo *api.Info, opts api.DialOpts) (api.Connection, error) {
+		gotOpts = opts
+		return expectState, nil
+	}
+	st, err := juju.NewAPIFromStore("noconfig", store, apiOpen)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(st, gc.Equals, expectState)
+	c.Assert(gotOpts.UseBSON, jc.IsTrue)
+}
+
 func (s *NewAPIClientSuite) TestWithInfoNoAPIHostports(c *gc.C) {
 	// The local cache doesn't have an ModelTag, which the API does
 	// return. However, the API doesn't have apiHostPorts, we don't want to
@@ -3,6 +3,10 @@
 
 package api
 
+import (
+	"time"
+)
+
 // TODO(ericsnow) Move this file to the top-level "payload" package?
 
 // EnvListArgs are the arguments for the env-based List endpoint.
@@ -35,4 +39,31 @@ type Payload struct {
 	Unit string
 	// Machine identifies the machine tag associated with the payload.
 	Machine string
+
+	// Probe describes how the payload's health is checked, if at all.
+	Probe *HealthProbe
+	// History holds the payload's recent statuses, oldest first.
+	History []StatusHistoryEntry
+}
+
+// HealthProbe describes how the unit agent checks a payload's health.
+type HealthProbe struct {
+	// Type is the kind of probe (exec, tcp or http).
+	Type string
+	// Command is the shell command run by an exec probe.
+	Command string
+	// Port is the local port that a tcp or http probe connects to.
+	Port int
+	// Path is the URL path requested by an http probe.
+	Path string
+	// Interval is how often the probe is run.
+	Interval time.Duration
+}
+
+// StatusHistoryEntry is a status that a payload had.
+type StatusHistoryEntry struct {
+	// Status is the Juju-level status for the payload.
+	Status string
+	// Since is when the payload took the status.
+	Since time.Time
 }
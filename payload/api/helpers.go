@@ -26,7 +26,7 @@ func Payload2api(p payload.FullPayloadInfo) Payload {
 		machineTag = names.NewMachineTag(p.Machine).String()
 	}
 
-	return Payload{
+	result := Payload{
 		Class:   p.Name,
 		Type:    p.Type,
 		ID:      p.ID,
@@ -35,6 +35,22 @@ func Payload2api(p payload.FullPayloadInfo) Payload {
 		Unit:    unitTag,
 		Machine: machineTag,
 	}
+	if p.Probe != nil {
+		result.Probe = &HealthProbe{
+			Type:     p.Probe.Type,
+			Command:  p.Probe.Command,
+			Port:     p.Probe.Port,
+			Path:     p.Probe.Path,
+			Interval: p.Probe.Interval,
+		}
+	}
+	for _, entry := range p.History {
+		result.History = append(result.History, StatusHistoryEntry{
+			Status: entry.Status,
+			Since:  entry.Since,
+		})
+	}
+	return result
 }
 
 // API2Payload converts an API Payload info struct into
@@ -60,17 +76,34 @@ func API2Payload(apiInfo Payload) (payload.FullPayloadInfo, error) {
 		machine = tag.Id()
 	}
 
-	return payload.FullPayloadInfo{
-		Payload: payload.Payload{
-			PayloadClass: charm.PayloadClass{
-				Name: apiInfo.Class,
-				Type: apiInfo.Type,
-			},
-			ID:     apiInfo.ID,
-			Status: apiInfo.Status,
-			Labels: labels,
-			Unit:   unit,
+	pl := payload.Payload{
+		PayloadClass: charm.PayloadClass{
+			Name: apiInfo.Class,
+			Type: apiInfo.Type,
 		},
+		ID:     apiInfo.ID,
+		Status: apiInfo.Status,
+		Labels: labels,
+		Unit:   unit,
+	}
+	if apiInfo.Probe != nil {
+		pl.Probe = &payload.HealthProbe{
+			Type:     apiInfo.Probe.Type,
+			Command:  apiInfo.Probe.Command,
+			Port:     apiInfo.Probe.Port,
+			Path:     apiInfo.Probe.Path,
+			Interval: apiInfo.Probe.Interval,
+		}
+	}
+	for _, entry := range apiInfo.History {
+		pl.History = append(pl.History, payload.StatusHistoryEntry{
+			Status: entry.Status,
+			Since:  entry.Since,
+		})
+	}
+
+	return payload.FullPayloadInfo{
+		Payload: pl,
 		Machine: machine,
 	}, nil
 }
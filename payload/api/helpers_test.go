@@ -4,6 +4,8 @@
 package api
 
 import (
+	"time"
+
 	"github.com/juju/names"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
@@ -71,3 +73,53 @@ func (helpersSuite) TestAPI2Payload(c *gc.C) {
 		Machine: "1",
 	})
 }
+
+func (helpersSuite) TestProbeAndHistoryRoundTrip(c *gc.C) {
+	since := time.Date(2016, 3, 1, 12, 0, 0, 0, time.UTC)
+	original := payload.FullPayloadInfo{
+		Payload: payload.Payload{
+			PayloadClass: charm.PayloadClass{
+				Name: "spam",
+				Type: "docker",
+			},
+			ID:     "idspam",
+			Status: payload.StateUnhealthy,
+			Labels: []string{},
+			Unit:   "a-service/0",
+			Probe: &payload.HealthProbe{
+				Type:     payload.ProbeHTTP,
+				Port:     8080,
+				Path:     "/status",
+				Interval: time.Minute,
+			},
+			History: []payload.StatusHistoryEntry{{
+				Status: payload.StateRunning,
+				Since:  since,
+			}, {
+				Status: payload.StateUnhealthy,
+				Since:  since.Add(time.Minute),
+			}},
+		},
+		Machine: "1",
+	}
+	apiPayload := Payload2api(original)
+
+	c.Check(apiPayload.Probe, jc.DeepEquals, &HealthProbe{
+		Type:     "http",
+		Port:     8080,
+		Path:     "/status",
+		Interval: time.Minute,
+	})
+	c.Check(apiPayload.History, jc.DeepEquals, []StatusHistoryEntry{{
+		Status: "running",
+		Since:  since,
+	}, {
+		Status: "unhealthy",
+		Since:  since.Add(time.Minute),
+	}})
+
+	pl, err := API2Payload(apiPayload)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(pl, jc.DeepEquals, original)
+}
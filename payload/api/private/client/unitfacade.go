@@ -89,6 +89,22 @@ func (c UnitFacadeClient) SetStatus(status string, fullIDs ...string) ([]payload
 	return api2results(rs)
 }
 
+// SetHealthStatus calls the SetHealthStatus API server method.
+func (c UnitFacadeClient) SetHealthStatus(status string, fullIDs ...string) ([]payload.Result, error) {
+	ids, err := c.lookUp(fullIDs)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	args := internal.IDs2SetStatusArgs(ids, status)
+
+	var rs internal.PayloadResults
+	if err := c.FacadeCall("SetHealthStatus", &args, &rs); err != nil {
+		return nil, err
+	}
+
+	return api2results(rs)
+}
+
 // Untrack calls the Untrack API server method.
 func (c UnitFacadeClient) Untrack(fullIDs ...string) ([]payload.Result, error) {
 	logger.Tracef("Calling untrack API: %q", fullIDs)
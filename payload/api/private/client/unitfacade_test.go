@@ -321,6 +321,71 @@ func (s *clientSuite) TestSetStatus(c *gc.C) {
 	}})
 }
 
+func (s *clientSuite) TestSetHealthStatus(c *gc.C) {
+	id := "ce5bc2a7-65d8-4800-8199-a7c3356ab309"
+	responses := []interface{}{
+		&internal.PayloadResults{
+			Results: []internal.PayloadResult{{
+				Entity: params.Entity{
+					Tag: names.NewPayloadTag(id).String(),
+				},
+				Payload:  nil,
+				NotFound: false,
+				Error:    nil,
+			}},
+		},
+		&internal.PayloadResults{
+			Results: []internal.PayloadResult{{
+				Entity: params.Entity{
+					Tag: names.NewPayloadTag(id).String(),
+				},
+				Payload:  nil,
+				NotFound: false,
+				Error:    nil,
+			}},
+		},
+	}
+	s.facade.responses = append(s.facade.responses, responses...)
+
+	pclient := client.NewUnitFacadeClient(s.facade)
+	results, err := pclient.SetHealthStatus(payload.StateUnhealthy, "idfoo/bar")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(results, jc.DeepEquals, []payload.Result{{
+		ID:       id,
+		Payload:  nil,
+		NotFound: false,
+		Error:    nil,
+	}})
+	s.stub.CheckCalls(c, []testing.StubCall{{
+		FuncName: "FacadeCall",
+		Args: []interface{}{
+			"LookUp",
+			&internal.LookUpArgs{
+				Args: []internal.LookUpArg{{
+					Name: "idfoo",
+					ID:   "bar",
+				}},
+			},
+			responses[0],
+		},
+	}, {
+		FuncName: "FacadeCall",
+		Args: []interface{}{
+			"SetHealthStatus",
+			&internal.SetStatusArgs{
+				Args: []internal.SetStatusArg{{
+					Entity: params.Entity{
+						Tag: names.NewPayloadTag(id).String(),
+					},
+					Status: "unhealthy",
+				}},
+			},
+			responses[1],
+		},
+	}})
+}
+
 func (s *clientSuite) TestUntrack(c *gc.C) {
 	id := "ce5bc2a7-65d8-4800-8199-a7c3356ab309"
 	responses := []interface{}{
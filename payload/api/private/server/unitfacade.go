@@ -22,6 +22,9 @@ type UnitPayloads interface {
 	List(ids ...string) ([]payload.Result, error)
 	// Settatus sets the status for the payload with the given id on the unit.
 	SetStatus(id, status string) error
+	// SetHealthStatus sets the status for the payload with the given
+	// id on the unit, if it is running or unhealthy.
+	SetHealthStatus(id, status string) error
 	// LookUp returns the payload ID for the given name/rawID pair.
 	LookUp(name, rawID string) (string, error)
 	// Untrack removes the information for the payload with the given id.
@@ -150,6 +153,24 @@ func (uf UnitFacade) SetStatus(args internal.SetStatusArgs) (internal.PayloadRes
 	return r, nil
 }
 
+// SetHealthStatus sets the raw status of a payload to the result of
+// its health probe, unless the payload is no longer running or
+// unhealthy, in which case the result is NotFound.
+func (uf UnitFacade) SetHealthStatus(args internal.SetStatusArgs) (internal.PayloadResults, error) {
+	var r internal.PayloadResults
+	for _, arg := range args.Args {
+		id, err := internal.API2ID(arg.Tag)
+		if err != nil {
+			return r, errors.Trace(err)
+		}
+
+		err = uf.State.SetHealthStatus(id, arg.Status)
+		res := internal.NewPayloadResult(id, err)
+		r.Results = append(r.Results, res)
+	}
+	return r, nil
+}
+
 // Untrack marks the identified payload as no longer being tracked.
 func (uf UnitFacade) Untrack(args params.Entities) (internal.PayloadResults, error) {
 	var r internal.PayloadResults
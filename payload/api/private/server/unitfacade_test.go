@@ -280,6 +280,65 @@ func (s *suite) TestSetStatus(c *gc.C) {
 	c.Assert(res, gc.DeepEquals, expected)
 }
 
+func (s *suite) TestSetHealthStatus(c *gc.C) {
+	id := "ce5bc2a7-65d8-4800-8199-a7c3356ab309"
+	s.state.stateIDs = []string{id}
+
+	a := UnitFacade{s.state}
+	args := internal.SetStatusArgs{
+		Args: []internal.SetStatusArg{{
+			Entity: params.Entity{
+				Tag: names.NewPayloadTag(id).String(),
+			},
+			Status: payload.StateUnhealthy,
+		}},
+	}
+	res, err := a.SetHealthStatus(args)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.stub.CheckCallNames(c, "SetHealthStatus")
+	c.Check(s.state.id, gc.Equals, id)
+	c.Assert(s.state.status, gc.Equals, payload.StateUnhealthy)
+
+	expected := internal.PayloadResults{
+		Results: []internal.PayloadResult{{
+			Entity: params.Entity{
+				Tag: names.NewPayloadTag(id).String(),
+			},
+			Error: nil,
+		}},
+	}
+	c.Assert(res, gc.DeepEquals, expected)
+}
+
+func (s *suite) TestSetHealthStatusNotRunning(c *gc.C) {
+	id := "ce5bc2a7-65d8-4800-8199-a7c3356ab309"
+	notFound := errors.NotFoundf("running or unhealthy payload %s", id)
+	s.stub.SetErrors(notFound)
+
+	a := UnitFacade{s.state}
+	args := internal.SetStatusArgs{
+		Args: []internal.SetStatusArg{{
+			Entity: params.Entity{
+				Tag: names.NewPayloadTag(id).String(),
+			},
+			Status: payload.StateUnhealthy,
+		}},
+	}
+	res, err := a.SetHealthStatus(args)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(res, jc.DeepEquals, internal.PayloadResults{
+		Results: []internal.PayloadResult{{
+			Entity: params.Entity{
+				Tag: names.NewPayloadTag(id).String(),
+			},
+			NotFound: true,
+			Error:    common.ServerError(notFound),
+		}},
+	})
+}
+
 func (s *suite) TestUntrack(c *gc.C) {
 	id := "ce5bc2a7-65d8-4800-8199-a7c3356ab309"
 	s.state.stateIDs = []string{id}
@@ -397,6 +456,17 @@ func (f *FakeState) SetStatus(id, status string) error {
 	return nil
 }
 
+func (f *FakeState) SetHealthStatus(id, status string) error {
+	f.stub.AddCall("SetHealthStatus", id, status)
+	f.id = id
+	f.status = status
+	if err := f.stub.NextErr(); err != nil {
+		return errors.Trace(err)
+	}
+
+	return nil
+}
+
 func (f *FakeState) LookUp(name, rawID string) (string, error) {
 	f.stub.AddCall("LookUp", name, rawID)
 	id := f.nextID()
@@ -8,3 +8,11 @@ package payload
 
 // ComponentName is the name of the Juju component for payload management.
 const ComponentName = "payloads"
+
+// HookContextFacade is the name of the API facade through which a unit
+// agent manages its unit's payloads.
+const HookContextFacade = ComponentName + "-hook-context"
+
+// HookContextFacadeVersion is the version of the hook context facade.
+// Version 1 adds SetHealthStatus.
+const HookContextFacadeVersion = 1
@@ -4,9 +4,12 @@
 package context
 
 import (
+	"time"
+
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
 	"gopkg.in/juju/charm.v6-unstable"
+	"launchpad.net/gnuflag"
 
 	"github.com/juju/juju/payload"
 )
@@ -33,6 +36,10 @@ type RegisterCmd struct {
 	class  string
 	id     string
 	labels []string
+
+	healthCheck    string
+	healthInterval time.Duration
+	probe          *payload.HealthProbe
 }
 
 // TODO(ericsnow) Change "tags" to "labels" in the help text?
@@ -51,10 +58,25 @@ provided when "register" is run.
 The payload class must correspond to one of the payloads defined in
 the charm's metadata.yaml.
 
+If --health-check is given, the unit agent periodically probes the
+payload while it is running, and sets its status to "unhealthy" when
+the probe fails (and back to "running" when it passes again). A probe
+is one of:
+
+    exec:<command>          the command exits with status 0
+    tcp:<port>              a connection to the local port succeeds
+    http:<port>[/<path>]    a GET of the local URL returns 2xx or 3xx
+
 		`,
 	}
 }
 
+// SetFlags implements cmd.Command.
+func (c *RegisterCmd) SetFlags(f *gnuflag.FlagSet) {
+	f.StringVar(&c.healthCheck, "health-check", "", "how to check that the payload is healthy")
+	f.DurationVar(&c.healthInterval, "health-interval", payload.DefaultProbeInterval, "how often to check the payload's health")
+}
+
 // Init implements cmd.Command.
 func (c *RegisterCmd) Init(args []string) error {
 	if len(args) < 3 {
@@ -64,6 +86,18 @@ func (c *RegisterCmd) Init(args []string) error {
 	c.class = args[1]
 	c.id = args[2]
 	c.labels = args[3:]
+
+	if c.healthCheck != "" {
+		probe, err := payload.ParseHealthProbe(c.healthCheck)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		probe.Interval = c.healthInterval
+		if err := probe.Validate(); err != nil {
+			return errors.Trace(err)
+		}
+		c.probe = &probe
+	}
 	return nil
 }
 
@@ -81,6 +115,7 @@ func (c *RegisterCmd) Run(ctx *cmd.Context) error {
 		Status: payload.StateRunning,
 		Labels: c.labels,
 		Unit:   "a-service/0",
+		Probe:  c.probe,
 	}
 	if err := c.hctx.Track(pl); err != nil {
 		return errors.Trace(err)
@@ -6,6 +6,7 @@ package context
 import (
 	"io/ioutil"
 	"path/filepath"
+	"time"
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
@@ -15,6 +16,7 @@ import (
 	"gopkg.in/juju/charm.v6-unstable"
 
 	"github.com/juju/juju/payload"
+	coretesting "github.com/juju/juju/testing"
 )
 
 type registerSuite struct {
@@ -55,6 +57,31 @@ func (registerSuite) TestInitWithLabels(c *gc.C) {
 	c.Assert(r.labels, gc.DeepEquals, []string{"tag1", "tag 2"})
 }
 
+func (registerSuite) TestInitHealthCheck(c *gc.C) {
+	r := RegisterCmd{}
+	err := coretesting.InitCommand(&r, []string{
+		"--health-check", "http:8080/status",
+		"--health-interval", "1m",
+		"type", "class", "id",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(r.probe, jc.DeepEquals, &payload.HealthProbe{
+		Type:     payload.ProbeHTTP,
+		Port:     8080,
+		Path:     "/status",
+		Interval: time.Minute,
+	})
+}
+
+func (registerSuite) TestInitBadHealthCheck(c *gc.C) {
+	r := RegisterCmd{}
+	err := coretesting.InitCommand(&r, []string{
+		"--health-check", "ping:8080",
+		"type", "class", "id",
+	})
+	c.Check(err, gc.ErrorMatches, `health probe type "ping" not valid`)
+}
+
 func (registerSuite) TestRun(c *gc.C) {
 	f := &stubRegisterContext{}
 	r := RegisterCmd{hctx: f}
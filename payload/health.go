@@ -0,0 +1,125 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package payload
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+)
+
+// The kinds of health probe that the unit agent may run against a
+// payload. Network probes always target the local machine.
+const (
+	ProbeExec = "exec"
+	ProbeTCP  = "tcp"
+	ProbeHTTP = "http"
+)
+
+// DefaultProbeInterval is how often a health probe is run if the
+// charm does not say otherwise.
+const DefaultProbeInterval = 30 * time.Second
+
+// HealthProbe describes how the unit agent checks that a running
+// payload is healthy.
+type HealthProbe struct {
+	// Type is the kind of probe (exec, tcp or http).
+	Type string
+
+	// Command is the shell command run by an exec probe. The payload
+	// is healthy if the command exits with status 0.
+	Command string
+
+	// Port is the local port that a tcp or http probe connects to.
+	Port int
+
+	// Path is the URL path requested by an http probe. The payload
+	// is healthy if the response has a 2xx or 3xx status code.
+	Path string
+
+	// Interval is how often the probe is run.
+	Interval time.Duration
+}
+
+// ParseHealthProbe parses a probe spec of the form "exec:<command>",
+// "tcp:<port>" or "http:<port>[/<path>]". The interval of the returned
+// probe is DefaultProbeInterval.
+func ParseHealthProbe(spec string) (HealthProbe, error) {
+	var probe HealthProbe
+	parts := strings.SplitN(spec, ":", 2)
+	if len(parts) != 2 {
+		return probe, errors.NotValidf("health probe %q (expected <type>:<target>)", spec)
+	}
+	probe.Type = parts[0]
+	probe.Interval = DefaultProbeInterval
+	target := parts[1]
+
+	switch probe.Type {
+	case ProbeExec:
+		probe.Command = target
+	case ProbeTCP, ProbeHTTP:
+		port := target
+		if probe.Type == ProbeHTTP {
+			if i := strings.Index(target, "/"); i >= 0 {
+				port, probe.Path = target[:i], target[i:]
+			}
+		}
+		n, err := strconv.Atoi(port)
+		if err != nil {
+			return probe, errors.NotValidf("health probe %q port", spec)
+		}
+		probe.Port = n
+	}
+	if err := probe.Validate(); err != nil {
+		return probe, errors.Trace(err)
+	}
+	return probe, nil
+}
+
+// Validate checks the probe to ensure it is correct.
+func (p HealthProbe) Validate() error {
+	switch p.Type {
+	case ProbeExec:
+		if p.Command == "" {
+			return errors.NotValidf("exec health probe with no command")
+		}
+	case ProbeTCP, ProbeHTTP:
+		if p.Port <= 0 || p.Port > 65535 {
+			return errors.NotValidf("%s health probe port %d", p.Type, p.Port)
+		}
+		if p.Type == ProbeHTTP && p.Path != "" && !strings.HasPrefix(p.Path, "/") {
+			return errors.NotValidf("http health probe path %q", p.Path)
+		}
+	default:
+		return errors.NotValidf("health probe type %q", p.Type)
+	}
+	if p.Interval <= 0 {
+		return errors.NotValidf("health probe interval %v", p.Interval)
+	}
+	return nil
+}
+
+// String returns the probe in the form accepted by ParseHealthProbe.
+func (p HealthProbe) String() string {
+	switch p.Type {
+	case ProbeExec:
+		return fmt.Sprintf("%s:%s", p.Type, p.Command)
+	case ProbeHTTP:
+		return fmt.Sprintf("%s:%d%s", p.Type, p.Port, p.Path)
+	}
+	return fmt.Sprintf("%s:%d", p.Type, p.Port)
+}
+
+// StatusHistoryEntry records a status that a payload had and when it
+// was set.
+type StatusHistoryEntry struct {
+	// Status is the Juju-level status of the payload.
+	Status string
+
+	// Since is when the payload took the status.
+	Since time.Time
+}
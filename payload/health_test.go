@@ -0,0 +1,102 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package payload_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/payload"
+)
+
+var _ = gc.Suite(&healthSuite{})
+
+type healthSuite struct {
+	testing.IsolationSuite
+}
+
+func (s *healthSuite) TestParseHealthProbe(c *gc.C) {
+	for i, test := range []struct {
+		spec     string
+		expected payload.HealthProbe
+	}{{
+		spec: "exec:pgrep -f my-daemon",
+		expected: payload.HealthProbe{
+			Type:    payload.ProbeExec,
+			Command: "pgrep -f my-daemon",
+		},
+	}, {
+		spec: "tcp:8080",
+		expected: payload.HealthProbe{
+			Type: payload.ProbeTCP,
+			Port: 8080,
+		},
+	}, {
+		spec: "http:8080",
+		expected: payload.HealthProbe{
+			Type: payload.ProbeHTTP,
+			Port: 8080,
+		},
+	}, {
+		spec: "http:8080/status?full=1",
+		expected: payload.HealthProbe{
+			Type: payload.ProbeHTTP,
+			Port: 8080,
+			Path: "/status?full=1",
+		},
+	}} {
+		c.Logf("test %d: %s", i, test.spec)
+		test.expected.Interval = payload.DefaultProbeInterval
+
+		probe, err := payload.ParseHealthProbe(test.spec)
+		c.Assert(err, jc.ErrorIsNil)
+
+		c.Check(probe, jc.DeepEquals, test.expected)
+		c.Check(probe.String(), gc.Equals, test.spec)
+	}
+}
+
+func (s *healthSuite) TestParseHealthProbeInvalid(c *gc.C) {
+	for i, test := range []struct {
+		spec string
+		err  string
+	}{{
+		spec: "8080",
+		err:  `health probe "8080" \(expected <type>:<target>\) not valid`,
+	}, {
+		spec: "ping:localhost",
+		err:  `health probe type "ping" not valid`,
+	}, {
+		spec: "exec:",
+		err:  `exec health probe with no command not valid`,
+	}, {
+		spec: "tcp:http",
+		err:  `health probe "tcp:http" port not valid`,
+	}, {
+		spec: "http:70000/",
+		err:  `http health probe port 70000 not valid`,
+	}} {
+		c.Logf("test %d: %s", i, test.spec)
+
+		_, err := payload.ParseHealthProbe(test.spec)
+
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *healthSuite) TestValidateInterval(c *gc.C) {
+	probe := payload.HealthProbe{
+		Type:     payload.ProbeTCP,
+		Port:     8080,
+		Interval: -time.Second,
+	}
+	err := probe.Validate()
+
+	c.Check(err, gc.ErrorMatches, `health probe interval -1s not valid`)
+}
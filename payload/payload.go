@@ -26,6 +26,14 @@ type Payload struct {
 
 	// Unit identifies the Juju unit associated with the payload.
 	Unit string
+
+	// Probe is how the unit agent checks the health of the payload
+	// while it is running, if at all.
+	Probe *HealthProbe
+
+	// History holds the statuses the payload has had, oldest first.
+	// Only the most recent entries are retained.
+	History []StatusHistoryEntry
 }
 
 // FullID composes a unique ID for the payload (relative to the unit/charm).
@@ -47,6 +55,12 @@ func (p Payload) Validate() error {
 		return errors.Trace(err)
 	}
 
+	if p.Probe != nil {
+		if err := p.Probe.Validate(); err != nil {
+			return errors.Trace(err)
+		}
+	}
+
 	// TODO(ericsnow) Do not require Unit to be set?
 	if p.Unit == "" {
 		return errors.NotValidf("missing Unit")
@@ -100,3 +100,11 @@ func (s *payloadSuite) TestValidateMissingUnit(c *gc.C) {
 
 	c.Check(err, gc.ErrorMatches, `missing Unit .*`)
 }
+
+func (s *payloadSuite) TestValidateBadProbe(c *gc.C) {
+	pl := s.newPayload("spam", "docker")
+	pl.Probe = &payload.HealthProbe{Type: "ping"}
+	err := pl.Validate()
+
+	c.Check(err, gc.ErrorMatches, `health probe type "ping" not valid`)
+}
@@ -5,6 +5,7 @@ package persistence
 
 import (
 	"fmt"
+	"time"
 
 	gitjujutesting "github.com/juju/testing"
 	"github.com/juju/utils"
@@ -21,6 +22,7 @@ type BaseSuite struct {
 	Stub  *gitjujutesting.Stub
 	State *fakeStatePersistence
 	Unit  string
+	Now   time.Time
 }
 
 func (s *BaseSuite) SetUpTest(c *gc.C) {
@@ -29,6 +31,7 @@ func (s *BaseSuite) SetUpTest(c *gc.C) {
 	s.Stub = &gitjujutesting.Stub{}
 	s.State = &fakeStatePersistence{Stub: s.Stub}
 	s.Unit = "a-unit/0"
+	s.Now = time.Date(2016, 3, 1, 12, 0, 0, 0, time.UTC)
 }
 
 type PayloadDoc payloadDoc
@@ -38,7 +41,7 @@ func (doc PayloadDoc) convert() *payloadDoc {
 }
 
 func (s *BaseSuite) NewDoc(id string, pl payload.Payload) *payloadDoc {
-	return &payloadDoc{
+	doc := &payloadDoc{
 		DocID:  "payload#" + s.Unit + "#" + id,
 		UnitID: s.Unit,
 
@@ -47,6 +50,16 @@ func (s *BaseSuite) NewDoc(id string, pl payload.Payload) *payloadDoc {
 		RawID: pl.ID,
 		State: pl.Status,
 	}
+	if pl.Probe != nil {
+		doc.Probe = &healthProbeDoc{
+			Type:     pl.Probe.Type,
+			Command:  pl.Probe.Command,
+			Port:     pl.Probe.Port,
+			Path:     pl.Probe.Path,
+			Interval: pl.Probe.Interval,
+		}
+	}
+	return doc
 }
 
 func (s *BaseSuite) SetDoc(id string, pl payload.Payload) *payloadDoc {
@@ -61,7 +74,20 @@ func (s *BaseSuite) RemoveDoc(id string) {
 }
 
 func (s *BaseSuite) NewPersistence() *Persistence {
-	return NewPersistence(s.State, s.Unit)
+	pp := NewPersistence(s.State, s.Unit)
+	pp.now = func() time.Time {
+		return s.Now
+	}
+	return pp
+}
+
+// NewHistory returns the status history recorded in a payload doc
+// when the status is set at the suite's current time.
+func (s *BaseSuite) NewHistory(status string) []statusHistoryDoc {
+	return []statusHistoryDoc{{
+		Status: status,
+		Since:  s.Now,
+	}}
 }
 
 func (s *BaseSuite) SetUnit(id string) {
@@ -6,6 +6,7 @@ package persistence
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/names"
@@ -20,6 +21,10 @@ const (
 	payloadsC = "payloads"
 )
 
+// maxStatusHistory is the number of status changes that are retained
+// for each payload.
+const maxStatusHistory = 20
+
 // Collections is the list of names of the mongo collections where state
 // is stored for payloads.
 // TODO(ericsnow) Not needed anymore...modify for a new registration scheme?
@@ -77,19 +82,42 @@ func (pp Persistence) newInsertPayloadOps(id string, p payload.Payload) []txn.Op
 	return ops
 }
 
-func (pp Persistence) newSetRawStatusOps(id, status string) []txn.Op {
+func (pp Persistence) newSetRawStatusOps(id, status string, since time.Time) []txn.Op {
 	id = pp.payloadID(id)
 	updates := bson.D{
 		{"state", status},
 	}
+	entry := statusHistoryDoc{
+		Status: status,
+		Since:  since,
+	}
+	history := bson.D{
+		{"$each", []statusHistoryDoc{entry}},
+		{"$slice", -maxStatusHistory},
+	}
 	return []txn.Op{{
 		C:      payloadsC,
 		Id:     id,
 		Assert: txn.DocExists,
-		Update: bson.D{{"$set", updates}},
+		Update: bson.D{
+			{"$set", updates},
+			{"$push", bson.D{{"history", history}}},
+		},
 	}}
 }
 
+// newSetHealthStatusOps returns the ops that set the raw status of a
+// payload, asserting that it is running or unhealthy so that a status
+// set since the payload's health was probed is not overwritten.
+func (pp Persistence) newSetHealthStatusOps(id, status string, since time.Time) []txn.Op {
+	ops := pp.newSetRawStatusOps(id, status, since)
+	ops[0].Assert = bson.D{{"state", bson.D{{"$in", []string{
+		payload.StateRunning,
+		payload.StateUnhealthy,
+	}}}}}
+	return ops
+}
+
 func (pp Persistence) newRemovePayloadOps(id string) []txn.Op {
 	id = pp.payloadID(id)
 	return []txn.Op{{
@@ -119,6 +147,27 @@ type payloadDoc struct {
 	Labels []string `bson:"labels"`
 
 	RawID string `bson:"rawid"`
+
+	Probe *healthProbeDoc `bson:"probe,omitempty"`
+
+	// History holds the most recent statuses of the payload, oldest
+	// first.
+	History []statusHistoryDoc `bson:"history,omitempty"`
+}
+
+// healthProbeDoc describes the health probe of a payload.
+type healthProbeDoc struct {
+	Type     string        `bson:"type"`
+	Command  string        `bson:"command,omitempty"`
+	Port     int           `bson:"port,omitempty"`
+	Path     string        `bson:"path,omitempty"`
+	Interval time.Duration `bson:"interval"`
+}
+
+// statusHistoryDoc records a status that a payload had.
+type statusHistoryDoc struct {
+	Status string    `bson:"status"`
+	Since  time.Time `bson:"since"`
 }
 
 func (d payloadDoc) payload(unit string) payload.Payload {
@@ -131,6 +180,21 @@ func (d payloadDoc) payload(unit string) payload.Payload {
 		Labels:       labels,
 		Unit:         unit,
 	}
+	if d.Probe != nil {
+		p.Probe = &payload.HealthProbe{
+			Type:     d.Probe.Type,
+			Command:  d.Probe.Command,
+			Port:     d.Probe.Port,
+			Path:     d.Probe.Path,
+			Interval: d.Probe.Interval,
+		}
+	}
+	for _, entry := range d.History {
+		p.History = append(p.History, payload.StatusHistoryEntry{
+			Status: entry.Status,
+			Since:  entry.Since,
+		})
+	}
 	return p
 }
 
@@ -160,7 +224,7 @@ func (pp Persistence) newPayloadDoc(id string, p payload.Payload) *payloadDoc {
 	labels := make([]string, len(p.Labels))
 	copy(labels, p.Labels)
 
-	return &payloadDoc{
+	doc := &payloadDoc{
 		DocID:  id,
 		UnitID: pp.unit,
 
@@ -173,6 +237,22 @@ func (pp Persistence) newPayloadDoc(id string, p payload.Payload) *payloadDoc {
 
 		RawID: p.ID,
 	}
+	if p.Probe != nil {
+		doc.Probe = &healthProbeDoc{
+			Type:     p.Probe.Type,
+			Command:  p.Probe.Command,
+			Port:     p.Probe.Port,
+			Path:     p.Probe.Path,
+			Interval: p.Probe.Interval,
+		}
+	}
+	for _, entry := range p.History {
+		doc.History = append(doc.History, statusHistoryDoc{
+			Status: entry.Status,
+			Since:  entry.Since,
+		})
+	}
+	return doc
 }
 
 func (pp Persistence) allPayloads() (map[string]payloadDoc, error) {
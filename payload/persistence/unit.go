@@ -6,6 +6,8 @@ package persistence
 // TODO(ericsnow) Eliminate the mongo-related imports here.
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	jujutxn "github.com/juju/txn"
@@ -45,6 +47,9 @@ type PersistenceBase interface {
 type Persistence struct {
 	st   PersistenceBase
 	unit string
+
+	// now returns the time recorded against status changes.
+	now func() time.Time
 }
 
 // NewPersistence builds a new Persistence based on the provided info.
@@ -52,14 +57,20 @@ func NewPersistence(st PersistenceBase, unit string) *Persistence {
 	return &Persistence{
 		st:   st,
 		unit: unit,
+		now:  time.Now,
 	}
 }
 
 // Track adds records for the payload to persistence. If the payload
 // is already there then false gets returned (true if inserted).
-// Existing records are not checked for consistency.
+// Existing records are not checked for consistency. The payload's
+// status starts its status history.
 func (pp Persistence) Track(id string, pl payload.Payload) (bool, error) {
 	logger.Tracef("insertng %#v", pl)
+	pl.History = []payload.StatusHistoryEntry{{
+		Status: pl.Status,
+		Since:  pp.now().UTC(),
+	}}
 
 	_, err := pp.LookUp(pl.Name, pl.ID)
 	if err == nil {
@@ -92,15 +103,44 @@ func (pp Persistence) Track(id string, pl payload.Payload) (bool, error) {
 // persistence. The return value corresponds to whether or not the
 // record was found in persistence. Any other problem results in
 // an error. The payload is not checked for inconsistent records.
+// The status is added to the payload's status history, of which only
+// the most recent entries are kept.
 func (pp Persistence) SetStatus(id, status string) (bool, error) {
 	logger.Tracef("setting status for %q", id)
 
 	var found bool
 	var ops []txn.Op
 	// TODO(ericsnow) Add unitPersistence.newEnsureAliveOp(pp.unit)?
-	ops = append(ops, pp.newSetRawStatusOps(id, status)...)
+	ops = append(ops, pp.newSetRawStatusOps(id, status, pp.now().UTC())...)
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			found = false
+			return nil, jujutxn.ErrNoOperations
+		}
+		found = true
+		return ops, nil
+	}
+	if err := pp.st.Run(buildTxn); err != nil {
+		return false, errors.Trace(err)
+	}
+	return found, nil
+}
+
+// SetHealthStatus updates the raw status for the identified payload
+// in persistence to the result of its health probe, but only if it is
+// still running or unhealthy. The return value corresponds to whether
+// or not the payload was found in one of those states. Any other
+// problem results in an error.
+func (pp Persistence) SetHealthStatus(id, status string) (bool, error) {
+	logger.Tracef("setting health status for %q", id)
+
+	var found bool
+	ops := pp.newSetHealthStatusOps(id, status, pp.now().UTC())
 	buildTxn := func(attempt int) ([]txn.Op, error) {
 		if attempt > 0 {
+			// The payload was removed, or its status set by
+			// something other than its health probe, since it
+			// was probed.
 			found = false
 			return nil, jujutxn.ErrNoOperations
 		}
@@ -6,6 +6,7 @@ package persistence_test
 import (
 	"fmt"
 	"sort"
+	"time"
 
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
@@ -42,15 +43,43 @@ func (s *payloadsPersistenceSuite) TestTrackOkay(c *gc.C) {
 				DocID:  "payload#a-unit/0#f47ac10b-58cc-4372-a567-0e02b2c3d479",
 				UnitID: "a-unit/0",
 
-				Name:  "payloadA",
-				Type:  "docker",
-				RawID: "payloadA-xyz",
-				State: "running",
+				Name:    "payloadA",
+				Type:    "docker",
+				RawID:   "payloadA-xyz",
+				State:   "running",
+				History: s.NewHistory("running"),
 			},
 		},
 	}})
 }
 
+func (s *payloadsPersistenceSuite) TestTrackWithProbe(c *gc.C) {
+	pl := s.NewPayload("docker", "payloadA/payloadA-xyz")
+	pl.Probe = &payload.HealthProbe{
+		Type:     payload.ProbeHTTP,
+		Port:     8080,
+		Path:     "/status",
+		Interval: time.Minute,
+	}
+	id := "f47ac10b-58cc-4372-a567-0e02b2c3d479"
+	expected := s.NewDoc(id, pl)
+	expected.History = s.NewHistory("running")
+
+	wp := s.NewPersistence()
+	okay, err := wp.Track(id, pl)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(okay, jc.IsTrue)
+	s.State.CheckOps(c, [][]txn.Op{{
+		{
+			C:      "payloads",
+			Id:     "payload#a-unit/0#f47ac10b-58cc-4372-a567-0e02b2c3d479",
+			Assert: txn.DocMissing,
+			Insert: expected,
+		},
+	}})
+}
+
 func (s *payloadsPersistenceSuite) TestTrackAlreadyExists(c *gc.C) {
 	id := "f47ac10b-58cc-4372-a567-0e02b2c3d479"
 
@@ -100,6 +129,12 @@ func (s *payloadsPersistenceSuite) TestSetStatusOkay(c *gc.C) {
 				{"$set", bson.D{
 					{"state", payload.StateRunning},
 				}},
+				{"$push", bson.D{
+					{"history", bson.D{
+						{"$each", s.NewHistory(payload.StateRunning)},
+						{"$slice", -20},
+					}},
+				}},
 			},
 		},
 	}})
@@ -124,6 +159,12 @@ func (s *payloadsPersistenceSuite) TestSetStatusMissing(c *gc.C) {
 				{"$set", bson.D{
 					{"state", payload.StateRunning},
 				}},
+				{"$push", bson.D{
+					{"history", bson.D{
+						{"$each", s.NewHistory(payload.StateRunning)},
+						{"$slice", -20},
+					}},
+				}},
 			},
 		},
 	}})
@@ -142,6 +183,71 @@ func (s *payloadsPersistenceSuite) TestSetStatusFailed(c *gc.C) {
 	c.Check(errors.Cause(err), gc.Equals, failure)
 }
 
+func (s *payloadsPersistenceSuite) TestSetHealthStatusOkay(c *gc.C) {
+	id := "f47ac10b-58cc-4372-a567-0e02b2c3d479"
+	pl := s.NewPayload("docker", "payloadA/payloadA-xyz")
+	s.SetDoc(id, pl)
+
+	pp := s.NewPersistence()
+	okay, err := pp.SetHealthStatus(id, payload.StateUnhealthy)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(okay, jc.IsTrue)
+	s.Stub.CheckCallNames(c, "Run")
+	s.State.CheckOps(c, [][]txn.Op{{
+		{
+			C:  "payloads",
+			Id: "payload#a-unit/0#f47ac10b-58cc-4372-a567-0e02b2c3d479",
+			Assert: bson.D{
+				{"state", bson.D{{"$in", []string{
+					payload.StateRunning,
+					payload.StateUnhealthy,
+				}}}},
+			},
+			Update: bson.D{
+				{"$set", bson.D{
+					{"state", payload.StateUnhealthy},
+				}},
+				{"$push", bson.D{
+					{"history", bson.D{
+						{"$each", s.NewHistory(payload.StateUnhealthy)},
+						{"$slice", -20},
+					}},
+				}},
+			},
+		},
+	}})
+}
+
+func (s *payloadsPersistenceSuite) TestSetHealthStatusNotRunning(c *gc.C) {
+	id := "f47ac10b-58cc-4372-a567-0e02b2c3d479"
+	pl := s.NewPayload("docker", "payloadA/payloadA-xyz")
+	s.SetDoc(id, pl)
+	// The payload was stopped after its health was probed, so the
+	// assertion fails.
+	s.Stub.SetErrors(txn.ErrAborted)
+
+	pp := s.NewPersistence()
+	okay, err := pp.SetHealthStatus(id, payload.StateUnhealthy)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(okay, jc.IsFalse)
+	s.Stub.CheckCallNames(c, "Run")
+}
+
+func (s *payloadsPersistenceSuite) TestSetHealthStatusFailed(c *gc.C) {
+	id := "f47ac10b-58cc-4372-a567-0e02b2c3d479"
+	pl := s.NewPayload("docker", "payloadA/payloadA-xyz")
+	s.SetDoc(id, pl)
+	failure := errors.Errorf("<failed!>")
+	s.Stub.SetErrors(failure)
+
+	pp := s.NewPersistence()
+	_, err := pp.SetHealthStatus(id, payload.StateUnhealthy)
+
+	c.Check(errors.Cause(err), gc.Equals, failure)
+}
+
 func (s *payloadsPersistenceSuite) TestListOkay(c *gc.C) {
 	id := "f47ac10b-58cc-4372-a567-0e02b2c3d479"
 	pl := s.NewPayload("docker", "payloadA/xyz")
@@ -159,6 +265,28 @@ func (s *payloadsPersistenceSuite) TestListOkay(c *gc.C) {
 	c.Check(missing, gc.HasLen, 0)
 }
 
+func (s *payloadsPersistenceSuite) TestListWithProbeAndHistory(c *gc.C) {
+	id := "f47ac10b-58cc-4372-a567-0e02b2c3d479"
+	pl := s.NewPayload("docker", "payloadA/xyz")
+	pl.Probe = &payload.HealthProbe{
+		Type:     payload.ProbeExec,
+		Command:  "pgrep xyz",
+		Interval: time.Minute,
+	}
+	pl.History = []payload.StatusHistoryEntry{{
+		Status: payload.StateRunning,
+		Since:  s.Now,
+	}}
+	doc := s.SetDoc(id, pl)
+	doc.History = s.NewHistory(payload.StateRunning)
+
+	pp := s.NewPersistence()
+	payloads, _, err := pp.List(id)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(payloads, jc.DeepEquals, []payload.Payload{pl})
+}
+
 func (s *payloadsPersistenceSuite) TestListSomeMissing(c *gc.C) {
 	id := "f47ac10b-58cc-4372-a567-0e02b2c3d479"
 	pl := s.NewPayload("docker", "payloadB/abc")
@@ -96,6 +96,23 @@ func (s *fakePayloadsPersistence) SetStatus(id, status string) (bool, error) {
 	return true, nil
 }
 
+func (s *fakePayloadsPersistence) SetHealthStatus(id, status string) (bool, error) {
+	s.AddCall("SetHealthStatus", id, status)
+	if err := s.NextErr(); err != nil {
+		return false, errors.Trace(err)
+	}
+
+	pl, ok := s.payloads[id]
+	if !ok {
+		return false, nil
+	}
+	if pl.Status != payload.StateRunning && pl.Status != payload.StateUnhealthy {
+		return false, nil
+	}
+	pl.Status = status
+	return true, nil
+}
+
 func (s *fakePayloadsPersistence) List(ids ...string) ([]payload.Payload, []string, error) {
 	s.AddCall("List", ids)
 	if err := s.NextErr(); err != nil {
@@ -21,6 +21,9 @@ type payloadsPersistence interface {
 	Track(id string, info payload.Payload) (bool, error)
 	// SetStatus updates the status for a payload.
 	SetStatus(id, status string) (bool, error)
+	// SetHealthStatus updates the status for a payload that is
+	// running or unhealthy.
+	SetHealthStatus(id, status string) (bool, error)
 	List(ids ...string) ([]payload.Payload, []string, error)
 	ListAll() ([]payload.Payload, error)
 	LookUp(name, rawID string) (string, error)
@@ -109,6 +112,28 @@ func (uw UnitPayloads) SetStatus(id, status string) error {
 	return nil
 }
 
+// SetHealthStatus updates the raw status for the identified payload
+// to the result of its health probe, which must be either running or
+// unhealthy. If the payload is no longer running or unhealthy, for
+// example because it was stopped after it was probed, its status is
+// left alone and a NotFound error is returned.
+func (uw UnitPayloads) SetHealthStatus(id, status string) error {
+	logger.Tracef("setting payload health status for %q to %q", id, status)
+
+	if status != payload.StateRunning && status != payload.StateUnhealthy {
+		return errors.NotValidf("health status %q", status)
+	}
+
+	found, err := uw.Persist.SetHealthStatus(id, status)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !found {
+		return errors.NotFoundf("running or unhealthy payload %s", id)
+	}
+	return nil
+}
+
 // List builds the list of payload information for the provided payload
 // IDs. If none are provided then the list contains the info for all
 // payloads associated with the unit. Missing payloads
@@ -164,6 +164,52 @@ func (s *unitPayloadsSuite) TestSetStatusMissing(c *gc.C) {
 	c.Check(err, jc.Satisfies, errors.IsNotFound)
 }
 
+func (s *unitPayloadsSuite) TestSetHealthStatusOkay(c *gc.C) {
+	id := "f47ac10b-58cc-4372-a567-0e02b2c3d479"
+	pl := s.newPayload("docker", "payloadA/payloadA-xyz")
+	s.persist.setPayload(id, &pl)
+
+	ps := state.UnitPayloads{
+		Persist: s.persist,
+		Unit:    "a-service/0",
+	}
+
+	err := ps.SetHealthStatus(id, payload.StateUnhealthy)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.stub.CheckCallNames(c, "SetHealthStatus")
+	current := s.persist.payloads[id]
+	c.Check(current.Status, jc.DeepEquals, payload.StateUnhealthy)
+}
+
+func (s *unitPayloadsSuite) TestSetHealthStatusStopped(c *gc.C) {
+	id := "f47ac10b-58cc-4372-a567-0e02b2c3d479"
+	pl := s.newPayload("docker", "payloadA/payloadA-xyz")
+	pl.Status = payload.StateStopped
+	s.persist.setPayload(id, &pl)
+
+	ps := state.UnitPayloads{
+		Persist: s.persist,
+		Unit:    "a-service/0",
+	}
+
+	err := ps.SetHealthStatus(id, payload.StateUnhealthy)
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+	current := s.persist.payloads[id]
+	c.Check(current.Status, jc.DeepEquals, payload.StateStopped)
+}
+
+func (s *unitPayloadsSuite) TestSetHealthStatusInvalid(c *gc.C) {
+	ps := state.UnitPayloads{
+		Persist: s.persist,
+		Unit:    "a-service/0",
+	}
+
+	err := ps.SetHealthStatus("f47ac10b-58cc-4372-a567-0e02b2c3d479", payload.StateStopped)
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+	s.stub.CheckNoCalls(c)
+}
+
 func (s *unitPayloadsSuite) TestListOkay(c *gc.C) {
 	id := "f47ac10b-58cc-4372-a567-0e02b2c3d479"
 	otherID := "f47ac10b-58cc-4372-a567-0e02b2c3d480"
@@ -21,11 +21,17 @@ const (
 	StateRunning  = "running"
 	StateStopping = "stopping"
 	StateStopped  = "stopped"
+
+	// StateUnhealthy is set by the unit agent when the health probe
+	// of a running payload fails. It reverts to running once the
+	// probe passes again.
+	StateUnhealthy = "unhealthy"
 )
 
 var okayStates = set.NewStrings(
 	StateStarting,
 	StateRunning,
+	StateUnhealthy,
 	StateStopping,
 	StateStopped,
 )
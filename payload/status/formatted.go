@@ -15,4 +15,16 @@ type FormattedPayload struct {
 	Class   string   `json:"payload-class" yaml:"payload-class"`
 	Labels  []string `json:"tags,omitempty" yaml:"tags,omitempty"`
 	Status  string   `json:"status" yaml:"status"`
+
+	HealthCheck string `json:"health-check,omitempty" yaml:"health-check,omitempty"`
+
+	// History is only included when it is asked for.
+	History []FormattedStatus `json:"status-history,omitempty" yaml:"status-history,omitempty"`
+}
+
+// FormattedStatus holds the formatted representation of an entry in
+// a payload's status history.
+type FormattedStatus struct {
+	Status string `json:"status" yaml:"status"`
+	Since  string `json:"since" yaml:"since"`
 }
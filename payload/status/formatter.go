@@ -4,16 +4,19 @@
 package status
 
 import (
+	"github.com/juju/juju/cmd/juju/common"
 	"github.com/juju/juju/payload"
 )
 
 type listFormatter struct {
 	payloads []payload.FullPayloadInfo
+	history  bool
 }
 
-func newListFormatter(payloads []payload.FullPayloadInfo) *listFormatter {
+func newListFormatter(payloads []payload.FullPayloadInfo, history bool) *listFormatter {
 	lf := listFormatter{
 		payloads: payloads,
+		history:  history,
 	}
 	return &lf
 }
@@ -25,7 +28,11 @@ func (lf *listFormatter) format() []FormattedPayload {
 
 	var formatted []FormattedPayload
 	for _, payload := range lf.payloads {
-		formatted = append(formatted, FormatPayload(payload))
+		fp := FormatPayload(payload)
+		if lf.history {
+			fp.History = FormatHistory(payload.History)
+		}
+		formatted = append(formatted, fp)
 	}
 	return formatted
 }
@@ -37,6 +44,10 @@ func FormatPayload(payload payload.FullPayloadInfo) FormattedPayload {
 		labels = make([]string, len(payload.Labels))
 		copy(labels, payload.Labels)
 	}
+	var healthCheck string
+	if payload.Probe != nil {
+		healthCheck = payload.Probe.String()
+	}
 	return FormattedPayload{
 		Unit:    payload.Unit,
 		Machine: payload.Machine,
@@ -45,6 +56,21 @@ func FormatPayload(payload payload.FullPayloadInfo) FormattedPayload {
 		Class:   payload.Name,
 		Labels:  labels,
 		// TODO(ericsnow) Explicitly convert to a string?
-		Status: payload.Status,
+		Status:      payload.Status,
+		HealthCheck: healthCheck,
 	}
 }
+
+// FormatHistory converts a payload's status history into the
+// formatted representation.
+func FormatHistory(history []payload.StatusHistoryEntry) []FormattedStatus {
+	var formatted []FormattedStatus
+	for _, entry := range history {
+		since := entry.Since
+		formatted = append(formatted, FormattedStatus{
+			Status: entry.Status,
+			Since:  common.FormatTime(&since, true),
+		})
+	}
+	return formatted
+}
@@ -4,10 +4,13 @@
 package status_test
 
 import (
+	"time"
+
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/payload"
 	"github.com/juju/juju/payload/status"
 )
 
@@ -32,3 +35,35 @@ func (s *formatterSuite) TestFormatPayloadOkay(c *gc.C) {
 		Status:  "running",
 	})
 }
+
+func (s *formatterSuite) TestFormatPayloadHealthCheck(c *gc.C) {
+	pl := status.NewPayload("spam", "a-service", 1, 0)
+	pl.Probe = &payload.HealthProbe{
+		Type:     payload.ProbeTCP,
+		Port:     8080,
+		Interval: time.Minute,
+	}
+	formatted := status.FormatPayload(pl)
+
+	c.Check(formatted.HealthCheck, gc.Equals, "tcp:8080")
+	c.Check(formatted.History, gc.HasLen, 0)
+}
+
+func (s *formatterSuite) TestFormatHistory(c *gc.C) {
+	since := time.Date(2016, 3, 1, 12, 0, 0, 0, time.UTC)
+	formatted := status.FormatHistory([]payload.StatusHistoryEntry{{
+		Status: payload.StateRunning,
+		Since:  since,
+	}, {
+		Status: payload.StateUnhealthy,
+		Since:  since.Add(time.Minute),
+	}})
+
+	c.Check(formatted, jc.DeepEquals, []status.FormattedStatus{{
+		Status: "running",
+		Since:  "2016-03-01 12:00:00Z",
+	}, {
+		Status: "unhealthy",
+		Since:  "2016-03-01 12:01:00Z",
+	}})
+}
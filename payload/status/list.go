@@ -26,6 +26,7 @@ type ListCommand struct {
 	modelcmd.ModelCommandBase
 	out      cmd.Output
 	patterns []string
+	history  bool
 
 	newAPIClient func(c *ListCommand) (ListAPI, error)
 }
@@ -55,6 +56,9 @@ will be checked against the following info in Juju:
 - payload id
 - payload tag
 - payload status
+
+With --history, the recent status changes of each payload are shown
+too, including those made by the unit agent's health checks.
 `
 
 func (c *ListCommand) Info() *cmd.Info {
@@ -73,6 +77,7 @@ func (c *ListCommand) SetFlags(f *gnuflag.FlagSet) {
 		"yaml":    cmd.FormatYaml,
 		"json":    cmd.FormatJson,
 	})
+	f.BoolVar(&c.history, "history", false, "show the status history of each payload")
 }
 
 func (c *ListCommand) Init(args []string) error {
@@ -106,7 +111,7 @@ func (c *ListCommand) Run(ctx *cmd.Context) error {
 	}
 
 	// Note that we do not worry about c.CompatVersion for list-payloads...
-	formatter := newListFormatter(payloads)
+	formatter := newListFormatter(payloads, c.history)
 	formatted := formatter.format()
 	return c.out.Write(ctx, formatted)
 }
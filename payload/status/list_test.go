@@ -6,6 +6,7 @@ package status_test
 import (
 	"bytes"
 	"strings"
+	"time"
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
@@ -65,6 +66,9 @@ will be checked against the following info in Juju:
 - payload id
 - payload tag
 - payload status
+
+With --history, the recent status changes of each payload are shown
+too, including those made by the unit agent's health checks.
 `,
 	})
 }
@@ -89,6 +93,39 @@ another-service/1 2       eggs          running docker ideggs
 	c.Check(stderr, gc.Equals, "")
 }
 
+func (s *listSuite) TestHistory(c *gc.C) {
+	since := time.Date(2016, 3, 1, 12, 0, 0, 0, time.UTC)
+	p1 := status.NewPayload("spam", "a-service", 1, 0)
+	p1.Status = payload.StateUnhealthy
+	p1.History = []payload.StatusHistoryEntry{{
+		Status: payload.StateRunning,
+		Since:  since,
+	}, {
+		Status: payload.StateUnhealthy,
+		Since:  since.Add(5 * time.Minute),
+	}}
+	p2 := status.NewPayload("eggs", "another-service", 2, 1)
+	s.client.payloads = append(s.client.payloads, p1, p2)
+
+	command := status.NewListCommand(s.newAPIClient)
+	code, stdout, stderr := runList(c, command, "--history")
+	c.Assert(code, gc.Equals, 0)
+
+	c.Check(stdout, gc.Equals, `
+[Unit Payloads]
+UNIT              MACHINE PAYLOAD-CLASS STATUS    TYPE   ID     TAGS 
+a-service/0       1       spam          unhealthy docker idspam      
+another-service/1 2       eggs          running   docker ideggs      
+
+[Payload Status History]
+UNIT        PAYLOAD-CLASS ID     STATUS    SINCE                
+a-service/0 spam          idspam running   2016-03-01 12:00:00Z 
+a-service/0 spam          idspam unhealthy 2016-03-01 12:05:00Z 
+
+`[1:])
+	c.Check(stderr, gc.Equals, "")
+}
+
 func (s *listSuite) TestNoPayloads(c *gc.C) {
 	command := status.NewListCommand(s.newAPIClient)
 	code, stdout, stderr := runList(c, command)
@@ -12,7 +12,10 @@ import (
 	"github.com/juju/errors"
 )
 
-const tabularSection = "[Unit Payloads]"
+const (
+	tabularSection        = "[Unit Payloads]"
+	tabularHistorySection = "[Payload Status History]"
+)
 
 var (
 	tabularColumns = []string{
@@ -27,6 +30,17 @@ var (
 
 	tabularHeader = strings.Join(tabularColumns, "\t") + "\t"
 	tabularRow    = strings.Repeat("%s\t", len(tabularColumns))
+
+	tabularHistoryColumns = []string{
+		"UNIT",
+		"PAYLOAD-CLASS",
+		"ID",
+		"STATUS",
+		"SINCE",
+	}
+
+	tabularHistoryHeader = strings.Join(tabularHistoryColumns, "\t") + "\t"
+	tabularHistoryRow    = strings.Repeat("%s\t", len(tabularHistoryColumns))
 )
 
 // FormatTabular returns a tabular summary of payloads.
@@ -61,5 +75,39 @@ func FormatTabular(value interface{}) ([]byte, error) {
 	}
 	tw.Flush()
 
+	formatTabularHistory(&out, payloads)
+
 	return out.Bytes(), nil
 }
+
+// formatTabularHistory writes a section listing the status history of
+// the payloads, if any was asked for.
+func formatTabularHistory(out *bytes.Buffer, payloads []FormattedPayload) {
+	var withHistory []FormattedPayload
+	for _, payload := range payloads {
+		if len(payload.History) > 0 {
+			withHistory = append(withHistory, payload)
+		}
+	}
+	if len(withHistory) == 0 {
+		return
+	}
+
+	tw := tabwriter.NewWriter(out, 0, 1, 1, ' ', 0)
+	fmt.Fprintln(tw)
+	fmt.Fprintln(tw, tabularHistorySection)
+	fmt.Fprintln(tw, tabularHistoryHeader)
+	for _, payload := range withHistory {
+		// tabularHistoryColumns must be kept in sync with these.
+		for _, entry := range payload.History {
+			fmt.Fprintf(tw, tabularHistoryRow+"\n",
+				payload.Unit,
+				payload.Class,
+				payload.ID,
+				entry.Status,
+				entry.Since,
+			)
+		}
+	}
+	tw.Flush()
+}
@@ -16,6 +16,7 @@ var (
 	okayStates = []string{
 		payload.StateStarting,
 		payload.StateRunning,
+		payload.StateUnhealthy,
 		payload.StateStopping,
 		payload.StateStopped,
 	}
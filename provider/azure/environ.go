@@ -381,12 +381,20 @@ func (env *azureEnviron) PrecheckInstance(series string, cons constraints.Value,
 	if err != nil {
 		return err
 	}
-	for _, instanceType := range instanceTypes {
-		if instanceType.Name == *cons.InstanceType {
-			return nil
+	// Every alternative must be a valid instance type.
+	for _, name := range cons.InstanceTypes() {
+		valid := false
+		for _, instanceType := range instanceTypes {
+			if instanceType.Name == name {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			return fmt.Errorf("invalid instance type %q", name)
 		}
 	}
-	return fmt.Errorf("invalid instance type %q", *cons.InstanceType)
+	return nil
 }
 
 // MaintainInstance is specified in the InstanceBroker interface.
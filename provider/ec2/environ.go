@@ -280,13 +280,15 @@ func (e *environ) ConstraintsValidator() (constraints.Validator, error) {
 	return validator, nil
 }
 
-func archMatches(arches []string, arch *string) bool {
-	if arch == nil {
+func archMatches(arches []string, wanted []string) bool {
+	if wanted == nil {
 		return true
 	}
 	for _, a := range arches {
-		if a == *arch {
-			return true
+		for _, w := range wanted {
+			if a == w {
+				return true
+			}
 		}
 	}
 	return false
@@ -382,19 +384,29 @@ func (e *environ) PrecheckInstance(series string, cons constraints.Value, placem
 	if !cons.HasInstanceType() {
 		return nil
 	}
-	// Constraint has an instance-type constraint so let's see if it is valid.
+	// Constraint has an instance-type constraint so let's see if it is
+	// valid. Every alternative must be.
+	for _, name := range cons.InstanceTypes() {
+		if err := checkInstanceType(name, cons); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func checkInstanceType(name string, cons constraints.Value) error {
 	for _, itype := range allInstanceTypes {
-		if itype.Name != *cons.InstanceType {
+		if itype.Name != name {
 			continue
 		}
-		if archMatches(itype.Arches, cons.Arch) {
+		if archMatches(itype.Arches, cons.Arches()) {
 			return nil
 		}
 	}
 	if cons.Arch == nil {
-		return fmt.Errorf("invalid AWS instance type %q specified", *cons.InstanceType)
+		return fmt.Errorf("invalid AWS instance type %q specified", name)
 	}
-	return fmt.Errorf("invalid AWS instance type %q and arch %q specified", *cons.InstanceType, *cons.Arch)
+	return fmt.Errorf("invalid AWS instance type %q and arch %q specified", name, *cons.Arch)
 }
 
 // MetadataLookupParams returns parameters which are used to query simplestreams metadata.
@@ -790,6 +790,17 @@ func (t *localServerSuite) TestPrecheckInstanceInvalidInstanceType(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, `invalid AWS instance type "m1.invalid" specified`)
 }
 
+func (t *localServerSuite) TestPrecheckInstanceInstanceTypeAlternatives(c *gc.C) {
+	env := t.Prepare(c)
+	cons := constraints.MustParse("instance-type=m1.small|cc1.4xlarge arch=i386|amd64")
+	err := env.PrecheckInstance(coretesting.FakeDefaultSeries, cons, "")
+	c.Assert(err, jc.ErrorIsNil)
+
+	cons = constraints.MustParse("instance-type=m1.small|m1.invalid")
+	err = env.PrecheckInstance(coretesting.FakeDefaultSeries, cons, "")
+	c.Assert(err, gc.ErrorMatches, `invalid AWS instance type "m1.invalid" specified`)
+}
+
 func (t *localServerSuite) TestPrecheckInstanceUnsupportedArch(c *gc.C) {
 	env := t.Prepare(c)
 	cons := constraints.MustParse("instance-type=cc1.4xlarge arch=i386")
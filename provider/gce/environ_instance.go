@@ -149,13 +149,20 @@ func (env *environ) parsePlacement(placement string) (*instPlacement, error) {
 }
 
 // checkInstanceType is used to ensure the the provided constraints
-// specify a recognized instance type.
+// specify a recognized instance type, or only recognized alternatives.
 func checkInstanceType(cons constraints.Value) bool {
 	// Constraint has an instance-type constraint so let's see if it is valid.
-	for _, itype := range allInstanceTypes {
-		if itype.Name == *cons.InstanceType {
-			return true
+	for _, name := range cons.InstanceTypes() {
+		valid := false
+		for _, itype := range allInstanceTypes {
+			if itype.Name == name {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			return false
 		}
 	}
-	return false
+	return true
 }
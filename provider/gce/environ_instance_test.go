@@ -186,3 +186,11 @@ func (s *environInstSuite) TestCheckInstanceTypeUnknown(c *gc.C) {
 
 	c.Check(matched, jc.IsFalse)
 }
+
+func (s *environInstSuite) TestCheckInstanceTypeAlternatives(c *gc.C) {
+	cons := constraints.MustParse("instance-type=n1-standard-1|n1-standard-2")
+	c.Check(gce.CheckInstanceType(cons), jc.IsTrue)
+
+	cons = constraints.MustParse("instance-type=n1-standard-1|n1-standard-1.unknown")
+	c.Check(gce.CheckInstanceType(cons), jc.IsFalse)
+}
@@ -86,12 +86,20 @@ func (env *joyentEnviron) PrecheckInstance(series string, cons constraints.Value
 	if err != nil {
 		return err
 	}
-	for _, instanceType := range instanceTypes {
-		if instanceType.Name == *cons.InstanceType {
-			return nil
+	// Every alternative must be a valid instance type.
+	for _, name := range cons.InstanceTypes() {
+		valid := false
+		for _, instanceType := range instanceTypes {
+			if instanceType.Name == name {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			return fmt.Errorf("invalid Joyent instance %q specified", name)
 		}
 	}
-	return fmt.Errorf("invalid Joyent instance %q specified", *cons.InstanceType)
+	return nil
 }
 
 // SupportedArchitectures is specified on the EnvironCapability interface.
@@ -34,13 +34,32 @@ func (environ *maasEnviron) ConstraintsValidator() (constraints.Validator, error
 // convertConstraints converts the given constraints into an url.Values object
 // suitable to pass to MAAS when acquiring a node. CpuPower is ignored because
 // it cannot be translated into something meaningful for MAAS right now.
-func convertConstraints(cons constraints.Value) url.Values {
+// MAAS can only ask for nodes that meet the constraints, so preferences
+// are left out, and maximums are refused because nothing would stop MAAS
+// handing over a bigger node.
+func convertConstraints(cons constraints.Value) (url.Values, error) {
+	if preferred := cons.Preferred(); len(preferred) > 0 {
+		logger.Debugf("ignoring preferred constraints %q", preferred)
+	}
+	cons = cons.Required()
+	if cons.MaxCpuCores != nil {
+		return nil, errors.NotSupportedf("maximum cpu-cores on MAAS")
+	}
+	if cons.MaxMem != nil {
+		return nil, errors.NotSupportedf("maximum mem on MAAS")
+	}
+	if cons.MaxRootDisk != nil {
+		return nil, errors.NotSupportedf("maximum root-disk on MAAS")
+	}
 	params := url.Values{}
 	if cons.Arch != nil {
 		// Note: Juju and MAAS use the same architecture names.
 		// MAAS also accepts a subarchitecture (e.g. "highbank"
 		// for ARM), which defaults to "generic" if unspecified.
-		params.Add("arch", *cons.Arch)
+		// Any of several alternatives is accepted.
+		for _, arch := range cons.Arches() {
+			params.Add("arch", arch)
+		}
 	}
 	if cons.CpuCores != nil {
 		params.Add("cpu_count", fmt.Sprintf("%d", *cons.CpuCores))
@@ -52,7 +71,7 @@ func convertConstraints(cons constraints.Value) url.Values {
 	if cons.CpuPower != nil {
 		logger.Warningf("ignoring unsupported constraint 'cpu-power'")
 	}
-	return params
+	return params, nil
 }
 
 // convertTagsToParams converts a list of positive/negative tags from
@@ -64,10 +64,46 @@ func (*environSuite) TestConvertConstraints(c *gc.C) {
 		},
 	}} {
 		c.Logf("test #%d: cons=%s", i, test.cons.String())
-		c.Check(convertConstraints(test.cons), jc.DeepEquals, test.expected)
+		params, err := convertConstraints(test.cons)
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(params, jc.DeepEquals, test.expected)
 	}
 }
 
+func (*environSuite) TestConvertConstraintsLeavesOutPreferences(c *gc.C) {
+	cons := constraints.MustParse("arch=amd64 mem=prefer:8G tags=prefer:ssd cpu-cores=4")
+	params, err := convertConstraints(cons)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(params, jc.DeepEquals, url.Values{
+		"arch":      {"amd64"},
+		"cpu_count": {"4"},
+	})
+}
+
+func (*environSuite) TestConvertConstraintsRefusesMaxCpuCores(c *gc.C) {
+	_, err := convertConstraints(constraints.MustParse("cpu-cores=2..8"))
+	c.Assert(err, gc.ErrorMatches, "maximum cpu-cores on MAAS not supported")
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (*environSuite) TestConvertConstraintsRefusesMaxMem(c *gc.C) {
+	_, err := convertConstraints(constraints.MustParse("mem=4G..16G"))
+	c.Assert(err, gc.ErrorMatches, "maximum mem on MAAS not supported")
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (*environSuite) TestConvertConstraintsRefusesMaxRootDisk(c *gc.C) {
+	_, err := convertConstraints(constraints.MustParse("root-disk=..32G"))
+	c.Assert(err, gc.ErrorMatches, "maximum root-disk on MAAS not supported")
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (*environSuite) TestConvertConstraintsAllowsPreferredMaximum(c *gc.C) {
+	params, err := convertConstraints(constraints.MustParse("mem=prefer:4G..16G"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(params, jc.DeepEquals, url.Values{})
+}
+
 var nilStringSlice []string
 
 func (*environSuite) TestConvertTagsToParams(c *gc.C) {
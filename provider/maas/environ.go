@@ -638,9 +638,12 @@ func (environ *maasEnviron) acquireNode(
 	volumes []volumeInfo,
 ) (gomaasapi.MAASObject, error) {
 
-	acquireParams := convertConstraints(cons)
+	acquireParams, err := convertConstraints(cons)
+	if err != nil {
+		return gomaasapi.MAASObject{}, errors.Trace(err)
+	}
 	positiveSpaces, negativeSpaces := convertSpacesFromConstraints(cons.Spaces)
-	err := addInterfaces(acquireParams, interfaces, positiveSpaces, negativeSpaces)
+	err = addInterfaces(acquireParams, interfaces, positiveSpaces, negativeSpaces)
 	if err != nil {
 		return gomaasapi.MAASObject{}, err
 	}
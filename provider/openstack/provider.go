@@ -673,12 +673,20 @@ func (e *Environ) PrecheckInstance(series string, cons constraints.Value, placem
 	if err != nil {
 		return err
 	}
-	for _, flavor := range flavors {
-		if flavor.Name == *cons.InstanceType {
-			return nil
+	// Every alternative must be a valid flavour.
+	for _, name := range cons.InstanceTypes() {
+		valid := false
+		for _, flavor := range flavors {
+			if flavor.Name == name {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			return fmt.Errorf("invalid Openstack flavour %q specified", name)
 		}
 	}
-	return fmt.Errorf("invalid Openstack flavour %q specified", *cons.InstanceType)
+	return nil
 }
 
 func (e *Environ) Bootstrap(ctx environs.BootstrapContext, args environs.BootstrapParams) (*environs.BootstrapResult, error) {
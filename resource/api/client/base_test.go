@@ -100,8 +100,10 @@ func newResource(c *gc.C, name, username, data string) (resource.Resource, api.R
 type stubFacade struct {
 	basetesting.StubFacadeCaller
 
-	apiResults map[string]api.ResourcesResult
-	pendingIDs []string
+	apiResults   map[string]api.ResourcesResult
+	pendingIDs   []string
+	revisions    map[string]api.ResourceRevisionsResult
+	setRevisions params.ErrorResult
 }
 
 func newStubFacade(c *gc.C, stub *testing.Stub) *stubFacade {
@@ -110,6 +112,7 @@ func newStubFacade(c *gc.C, stub *testing.Stub) *stubFacade {
 			Stub: stub,
 		},
 		apiResults: make(map[string]api.ResourcesResult),
+		revisions:  make(map[string]api.ResourceRevisionsResult),
 	}
 
 	s.FacadeCallFn = func(_ string, args, response interface{}) error {
@@ -134,6 +137,17 @@ func newStubFacade(c *gc.C, stub *testing.Stub) *stubFacade {
 			}
 		case *api.AddPendingResourcesResult:
 			typedResponse.PendingIDs = s.pendingIDs
+		case *api.ResourceRevisionsResults:
+			typedArgs, ok := args.(*api.ListResourcesArgs)
+			c.Assert(ok, jc.IsTrue)
+
+			for _, e := range typedArgs.Entities {
+				tag, err := names.ParseTag(e.Tag)
+				c.Assert(err, jc.ErrorIsNil)
+				typedResponse.Results = append(typedResponse.Results, s.revisions[tag.Id()])
+			}
+		case *params.ErrorResult:
+			*typedResponse = s.setRevisions
 		default:
 			c.Errorf("bad type %T", response)
 		}
@@ -13,6 +13,7 @@ import (
 	charmresource "gopkg.in/juju/charm.v6-unstable/resource"
 
 	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/resource"
 	"github.com/juju/juju/resource/api"
 )
@@ -84,6 +85,49 @@ func (c Client) ListResources(services []string) ([]resource.ServiceResources, e
 	return results, nil
 }
 
+// ListResourceRevisions calls the ListResourceRevisions API server
+// method for the given service.
+func (c Client) ListResourceRevisions(service string) ([]resource.ResourceRevision, error) {
+	args, err := api.NewListResourcesArgs([]string{service})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	var apiResults api.ResourceRevisionsResults
+	if err := c.FacadeCall("ListResourceRevisions", &args, &apiResults); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(apiResults.Results) != 1 {
+		return nil, errors.Errorf("got invalid data from server (expected 1 result, got %d)", len(apiResults.Results))
+	}
+
+	revisions, err := api.API2ResourceRevisions(apiResults.Results[0])
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return revisions, nil
+}
+
+// SetResourceRevision calls the SetResourceRevision API server method,
+// making the service use the identified retained revision of the
+// resource.
+func (c Client) SetResourceRevision(service, name string, revision int) error {
+	args, err := api.NewSetResourceRevisionArgs(service, name, revision)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	var result params.ErrorResult
+	if err := c.FacadeCall("SetResourceRevision", &args, &result); err != nil {
+		return errors.Trace(err)
+	}
+	if result.Error != nil {
+		err, _ := common.RestoreError(result.Error)
+		return errors.Trace(err)
+	}
+	return nil
+}
+
 // Upload sends the provided resource blob up to Juju.
 func (c Client) Upload(service, name string, reader io.ReadSeeker) error {
 	uReq, err := api.NewUploadRequest(service, name, reader)
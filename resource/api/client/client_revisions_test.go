@@ -0,0 +1,121 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package client_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/resource"
+	"github.com/juju/juju/resource/api"
+	"github.com/juju/juju/resource/api/client"
+)
+
+var _ = gc.Suite(&RevisionsSuite{})
+
+type RevisionsSuite struct {
+	BaseSuite
+}
+
+func (s *RevisionsSuite) TestListOkay(c *gc.C) {
+	res1, apiRes1 := newResource(c, "spam", "a-user", "spamspamspam")
+	res2, apiRes2 := newResource(c, "spam", "a-user", "eggs")
+	s.facade.revisions["a-service"] = api.ResourceRevisionsResult{
+		Revisions: []api.ResourceRevision{{
+			Resource: apiRes1,
+			Number:   1,
+		}, {
+			Resource: apiRes2,
+			Number:   2,
+			Current:  true,
+		}},
+	}
+	cl := client.NewClient(s.facade, s, s.facade)
+
+	revisions, err := cl.ListResourceRevisions("a-service")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(revisions, jc.DeepEquals, []resource.ResourceRevision{{
+		Resource: res1,
+		Number:   1,
+	}, {
+		Resource: res2,
+		Number:   2,
+		Current:  true,
+	}})
+	s.stub.CheckCallNames(c, "FacadeCall")
+	s.stub.CheckCall(c, 0, "FacadeCall",
+		"ListResourceRevisions",
+		&api.ListResourcesArgs{[]params.Entity{{
+			Tag: "service-a-service",
+		}}},
+		&api.ResourceRevisionsResults{
+			Results: []api.ResourceRevisionsResult{
+				s.facade.revisions["a-service"],
+			},
+		},
+	)
+}
+
+func (s *RevisionsSuite) TestListBadService(c *gc.C) {
+	cl := client.NewClient(s.facade, s, s.facade)
+
+	_, err := cl.ListResourceRevisions("???")
+
+	c.Check(err, gc.ErrorMatches, `.*invalid service.*`)
+	s.stub.CheckNoCalls(c)
+}
+
+func (s *RevisionsSuite) TestListFailure(c *gc.C) {
+	failure := errors.New("<failure>")
+	s.stub.SetErrors(failure)
+	cl := client.NewClient(s.facade, s, s.facade)
+
+	_, err := cl.ListResourceRevisions("a-service")
+
+	c.Check(errors.Cause(err), gc.Equals, failure)
+	s.stub.CheckCallNames(c, "FacadeCall")
+}
+
+func (s *RevisionsSuite) TestSetOkay(c *gc.C) {
+	cl := client.NewClient(s.facade, s, s.facade)
+
+	err := cl.SetResourceRevision("a-service", "spam", 2)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.stub.CheckCallNames(c, "FacadeCall")
+	s.stub.CheckCall(c, 0, "FacadeCall",
+		"SetResourceRevision",
+		&api.SetResourceRevisionArgs{
+			Entity:   params.Entity{Tag: "service-a-service"},
+			Name:     "spam",
+			Revision: 2,
+		},
+		&params.ErrorResult{},
+	)
+}
+
+func (s *RevisionsSuite) TestSetNotFound(c *gc.C) {
+	s.facade.setRevisions.Error = &params.Error{
+		Message: `resource "a-service/spam" revision 7 not found`,
+		Code:    params.CodeNotFound,
+	}
+	cl := client.NewClient(s.facade, s, s.facade)
+
+	err := cl.SetResourceRevision("a-service", "spam", 7)
+
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+	c.Check(err, gc.ErrorMatches, `resource "a-service/spam" revision 7 not found`)
+}
+
+func (s *RevisionsSuite) TestSetBadRevision(c *gc.C) {
+	cl := client.NewClient(s.facade, s, s.facade)
+
+	err := cl.SetResourceRevision("a-service", "spam", 0)
+
+	c.Check(err, gc.ErrorMatches, `invalid revision 0`)
+	s.stub.CheckNoCalls(c)
+}
@@ -109,6 +109,66 @@ type UnitResources struct {
 	Resources []Resource
 }
 
+// ResourceRevisionsResults holds the resource revisions that result
+// from a bulk API call.
+type ResourceRevisionsResults struct {
+	// Results is the list of resource revision results.
+	Results []ResourceRevisionsResult
+}
+
+// ResourceRevisionsResult holds the retained resource revisions for a
+// single service.
+type ResourceRevisionsResult struct {
+	params.ErrorResult
+
+	// Revisions is the list of retained revisions of the service's
+	// resources.
+	Revisions []ResourceRevision
+}
+
+// ResourceRevision is a retained revision of a service's resource.
+type ResourceRevision struct {
+	Resource
+
+	// Number identifies the revision among those uploaded for the
+	// resource and service.
+	Number int `json:"number"`
+
+	// Current indicates whether the service currently uses the revision.
+	Current bool `json:"current"`
+}
+
+// SetResourceRevisionArgs holds the arguments to the SetResourceRevision
+// API endpoint.
+type SetResourceRevisionArgs struct {
+	params.Entity
+
+	// Name identifies the resource.
+	Name string `json:"name"`
+
+	// Revision is the number of the retained revision to use.
+	Revision int `json:"revision"`
+}
+
+// NewSetResourceRevisionArgs returns the arguments for the
+// SetResourceRevision API endpoint.
+func NewSetResourceRevisionArgs(service, name string, revision int) (SetResourceRevisionArgs, error) {
+	var args SetResourceRevisionArgs
+	if !names.IsValidService(service) {
+		return args, errors.Errorf("invalid service %q", service)
+	}
+	if name == "" {
+		return args, errors.New("missing resource name")
+	}
+	if revision <= 0 {
+		return args, errors.Errorf("invalid revision %d", revision)
+	}
+	args.Tag = names.NewServiceTag(service).String()
+	args.Name = name
+	args.Revision = revision
+	return args, nil
+}
+
 // UploadResult is the response from an upload request.
 type UploadResult struct {
 	params.ErrorResult
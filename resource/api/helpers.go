@@ -93,6 +93,43 @@ func API2Resource(apiRes Resource) (resource.Resource, error) {
 	return res, nil
 }
 
+// ResourceRevision2API converts a resource.ResourceRevision into
+// a ResourceRevision struct.
+func ResourceRevision2API(rev resource.ResourceRevision) ResourceRevision {
+	return ResourceRevision{
+		Resource: Resource2API(rev.Resource),
+		Number:   rev.Number,
+		Current:  rev.Current,
+	}
+}
+
+// API2ResourceRevisions converts a ResourceRevisionsResult into
+// a list of resource.ResourceRevision.
+func API2ResourceRevisions(apiResult ResourceRevisionsResult) ([]resource.ResourceRevision, error) {
+	if apiResult.Error != nil {
+		err, _ := common.RestoreError(apiResult.Error)
+		return nil, errors.Trace(err)
+	}
+
+	var revisions []resource.ResourceRevision
+	for _, apiRev := range apiResult.Revisions {
+		res, err := API2Resource(apiRev.Resource)
+		if err != nil {
+			return nil, errors.Annotate(err, "got bad data from server")
+		}
+		rev := resource.ResourceRevision{
+			Resource: res,
+			Number:   apiRev.Number,
+			Current:  apiRev.Current,
+		}
+		if err := rev.Validate(); err != nil {
+			return nil, errors.Annotate(err, "got bad data from server")
+		}
+		revisions = append(revisions, rev)
+	}
+	return revisions, nil
+}
+
 // CharmResource2API converts a charm resource into
 // a CharmResource struct.
 func CharmResource2API(res charmresource.Resource) CharmResource {
@@ -408,6 +408,80 @@ func (helpersSuite) TestAPI2Resource(c *gc.C) {
 	c.Check(res, jc.DeepEquals, expected)
 }
 
+func (helpersSuite) TestAPI2ResourceRevisions(c *gc.C) {
+	now := time.Now()
+	apiRes := api.Resource{
+		CharmResource: api.CharmResource{
+			Name:        "spam",
+			Type:        "file",
+			Path:        "spam.tgz",
+			Origin:      "upload",
+			Fingerprint: []byte(fingerprint),
+			Size:        10,
+		},
+		ID:        "a-service/spam",
+		ServiceID: "a-service",
+		Username:  "a-user",
+		Timestamp: now,
+	}
+
+	revisions, err := api.API2ResourceRevisions(api.ResourceRevisionsResult{
+		Revisions: []api.ResourceRevision{{
+			Resource: apiRes,
+			Number:   1,
+		}, {
+			Resource: apiRes,
+			Number:   2,
+			Current:  true,
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	res, err := api.API2Resource(apiRes)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(revisions, jc.DeepEquals, []resource.ResourceRevision{{
+		Resource: res,
+		Number:   1,
+	}, {
+		Resource: res,
+		Number:   2,
+		Current:  true,
+	}})
+}
+
+func (helpersSuite) TestAPI2ResourceRevisionsBadNumber(c *gc.C) {
+	_, err := api.API2ResourceRevisions(api.ResourceRevisionsResult{
+		Revisions: []api.ResourceRevision{{
+			Resource: api.Resource{
+				CharmResource: api.CharmResource{
+					Name:        "spam",
+					Type:        "file",
+					Path:        "spam.tgz",
+					Origin:      "upload",
+					Fingerprint: []byte(fingerprint),
+				},
+				ID:        "a-service/spam",
+				ServiceID: "a-service",
+			},
+		}},
+	})
+
+	c.Check(err, gc.ErrorMatches, `got bad data from server: revision number must be positive`)
+}
+
+func (helpersSuite) TestAPI2ResourceRevisionsFailure(c *gc.C) {
+	_, err := api.API2ResourceRevisions(api.ResourceRevisionsResult{
+		ErrorResult: params.ErrorResult{
+			Error: &params.Error{
+				Message: `service "a-service" not found`,
+				Code:    params.CodeNotFound,
+			},
+		},
+	})
+
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+}
+
 func (helpersSuite) TestCharmResource2API(c *gc.C) {
 	fp, err := charmresource.NewFingerprint([]byte(fingerprint))
 	c.Assert(err, jc.ErrorIsNil)
@@ -67,6 +67,8 @@ type stubDataStore struct {
 	ReturnGetPendingResource    resource.Resource
 	ReturnSetResource           resource.Resource
 	ReturnUpdatePendingResource resource.Resource
+	ReturnListResourceRevisions []resource.ResourceRevision
+	ReturnSetResourceRevision   resource.Resource
 }
 
 func (s *stubDataStore) ListResources(service string) (resource.ServiceResources, error) {
@@ -122,3 +124,21 @@ func (s *stubDataStore) UpdatePendingResource(serviceID, pendingID, userID strin
 
 	return s.ReturnUpdatePendingResource, nil
 }
+
+func (s *stubDataStore) ListResourceRevisions(service string) ([]resource.ResourceRevision, error) {
+	s.stub.AddCall("ListResourceRevisions", service)
+	if err := s.stub.NextErr(); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	return s.ReturnListResourceRevisions, nil
+}
+
+func (s *stubDataStore) SetResourceRevision(service, name string, number int) (resource.Resource, error) {
+	s.stub.AddCall("SetResourceRevision", service, name, number)
+	if err := s.stub.NextErr(); err != nil {
+		return resource.Resource{}, errors.Trace(err)
+	}
+
+	return s.ReturnSetResourceRevision, nil
+}
@@ -20,8 +20,9 @@ import (
 var logger = loggo.GetLogger("juju.resource.api.server")
 
 const (
-	// Version is the version number of the current Facade.
-	Version = 1
+	// Version is the version number of the current Facade. Version 2
+	// adds ListResourceRevisions and SetResourceRevision.
+	Version = 2
 )
 
 // DataStore is the functionality of Juju's state needed for the resources API.
@@ -54,6 +55,14 @@ type resourceInfoStore interface {
 	// it is resolved. The returned ID is used to identify the pending
 	// resources when resolving it.
 	AddPendingResource(serviceID, userID string, chRes charmresource.Resource, r io.Reader) (string, error)
+
+	// ListResourceRevisions returns the retained revisions of the
+	// service's resources.
+	ListResourceRevisions(service string) ([]resource.ResourceRevision, error)
+
+	// SetResourceRevision makes the identified retained revision the
+	// one the service uses.
+	SetResourceRevision(service, name string, number int) (resource.Resource, error)
 }
 
 // ListResources returns the list of resources for the given service.
@@ -97,6 +106,49 @@ func (f Facade) ListResources(args api.ListResourcesArgs) (api.ResourcesResults,
 	return r, nil
 }
 
+// ListResourceRevisions returns the retained revisions of the
+// resources of each of the given services.
+func (f Facade) ListResourceRevisions(args api.ListResourcesArgs) (api.ResourceRevisionsResults, error) {
+	var r api.ResourceRevisionsResults
+	r.Results = make([]api.ResourceRevisionsResult, len(args.Entities))
+
+	for i, e := range args.Entities {
+		logger.Tracef("Listing resource revisions for %q", e.Tag)
+		tag, apierr := parseServiceTag(e.Tag)
+		if apierr != nil {
+			r.Results[i].Error = apierr
+			continue
+		}
+
+		revisions, err := f.store.ListResourceRevisions(tag.Id())
+		if err != nil {
+			r.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		for _, rev := range revisions {
+			r.Results[i].Revisions = append(r.Results[i].Revisions, api.ResourceRevision2API(rev))
+		}
+	}
+	return r, nil
+}
+
+// SetResourceRevision rolls the identified resource of the service
+// back (or forward) to one of its retained revisions.
+func (f Facade) SetResourceRevision(args api.SetResourceRevisionArgs) (params.ErrorResult, error) {
+	var result params.ErrorResult
+
+	tag, apiErr := parseServiceTag(args.Tag)
+	if apiErr != nil {
+		result.Error = apiErr
+		return result, nil
+	}
+
+	if _, err := f.store.SetResourceRevision(tag.Id(), args.Name, args.Revision); err != nil {
+		result.Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
 // AddPendingResources adds the provided resources (info) to the Juju
 // model in a pending state, meaning they are not available until
 // resolved.
@@ -0,0 +1,124 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package server_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/resource"
+	"github.com/juju/juju/resource/api"
+	"github.com/juju/juju/resource/api/server"
+)
+
+var _ = gc.Suite(&RevisionsSuite{})
+
+type RevisionsSuite struct {
+	BaseSuite
+}
+
+func (s *RevisionsSuite) TestListOkay(c *gc.C) {
+	res1, apiRes1 := newResource(c, "spam", "a-user", "spamspamspam")
+	res2, apiRes2 := newResource(c, "spam", "a-user", "eggs")
+	s.data.ReturnListResourceRevisions = []resource.ResourceRevision{{
+		Resource: res1,
+		Number:   1,
+	}, {
+		Resource: res2,
+		Number:   2,
+		Current:  true,
+	}}
+	facade := server.NewFacade(s.data)
+
+	results, err := facade.ListResourceRevisions(api.ListResourcesArgs{
+		Entities: []params.Entity{{
+			Tag: "service-a-service",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(results, jc.DeepEquals, api.ResourceRevisionsResults{
+		Results: []api.ResourceRevisionsResult{{
+			Revisions: []api.ResourceRevision{{
+				Resource: apiRes1,
+				Number:   1,
+			}, {
+				Resource: apiRes2,
+				Number:   2,
+				Current:  true,
+			}},
+		}},
+	})
+	s.stub.CheckCallNames(c, "ListResourceRevisions")
+	s.stub.CheckCall(c, 0, "ListResourceRevisions", "a-service")
+}
+
+func (s *RevisionsSuite) TestListError(c *gc.C) {
+	failure := errors.New("<failure>")
+	s.stub.SetErrors(failure)
+	facade := server.NewFacade(s.data)
+
+	results, err := facade.ListResourceRevisions(api.ListResourcesArgs{
+		Entities: []params.Entity{{
+			Tag: "service-a-service",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(results, jc.DeepEquals, api.ResourceRevisionsResults{
+		Results: []api.ResourceRevisionsResult{{
+			ErrorResult: params.ErrorResult{Error: &params.Error{
+				Message: "<failure>",
+			}},
+		}},
+	})
+	s.stub.CheckCallNames(c, "ListResourceRevisions")
+}
+
+func (s *RevisionsSuite) TestSetOkay(c *gc.C) {
+	facade := server.NewFacade(s.data)
+
+	result, err := facade.SetResourceRevision(api.SetResourceRevisionArgs{
+		Entity:   params.Entity{Tag: "service-a-service"},
+		Name:     "spam",
+		Revision: 2,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(result, jc.DeepEquals, params.ErrorResult{})
+	s.stub.CheckCallNames(c, "SetResourceRevision")
+	s.stub.CheckCall(c, 0, "SetResourceRevision", "a-service", "spam", 2)
+}
+
+func (s *RevisionsSuite) TestSetNotFound(c *gc.C) {
+	s.stub.SetErrors(errors.NotFoundf(`resource "a-service/spam" revision 7`))
+	facade := server.NewFacade(s.data)
+
+	result, err := facade.SetResourceRevision(api.SetResourceRevisionArgs{
+		Entity:   params.Entity{Tag: "service-a-service"},
+		Name:     "spam",
+		Revision: 7,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(result.Error, jc.Satisfies, params.IsCodeNotFound)
+	c.Check(result.Error, gc.ErrorMatches, `resource "a-service/spam" revision 7 not found`)
+}
+
+func (s *RevisionsSuite) TestSetBadTag(c *gc.C) {
+	facade := server.NewFacade(s.data)
+
+	result, err := facade.SetResourceRevision(api.SetResourceRevisionArgs{
+		Entity:   params.Entity{Tag: "unit-a-service-0"},
+		Name:     "spam",
+		Revision: 2,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(result.Error, gc.NotNil)
+	c.Check(result.Error.Code, gc.Equals, params.CodeBadRequest)
+	s.stub.CheckNoCalls(c)
+}
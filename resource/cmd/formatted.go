@@ -57,3 +57,21 @@ type FormattedDetailResource struct {
 // FormattedDetailResource is the data for the tabular output for juju resources
 // <unit> --details.
 type FormattedUnitDetails []FormattedDetailResource
+
+// FormattedResourceRevision holds the formatted representation of a
+// retained revision of a service's resource.
+type FormattedResourceRevision struct {
+	// These fields are exported for the sake of serialization.
+	Name        string    `json:"name" yaml:"name"`
+	Number      int       `json:"number" yaml:"number"`
+	Current     bool      `json:"current" yaml:"current"`
+	Fingerprint string    `json:"fingerprint" yaml:"fingerprint"`
+	Size        int64     `json:"size" yaml:"size"`
+	Timestamp   time.Time `json:"timestamp,omitempty" yaml:"timestamp,omitempty"`
+	Username    string    `json:"username,omitempty" yaml:"username,omitempty"`
+
+	// These fields are not exported so they won't be serialized, since they are
+	// specific to the tabular output.
+	combinedRevision string
+	currentYesNo     string
+}
@@ -94,6 +94,22 @@ func FormatDetailResource(tag names.UnitTag, svc, unit resource.Resource) (Forma
 	}, nil
 }
 
+// FormatResourceRevision converts the revision info into a
+// FormattedResourceRevision.
+func FormatResourceRevision(rev resource.ResourceRevision) FormattedResourceRevision {
+	return FormattedResourceRevision{
+		Name:             rev.Name,
+		Number:           rev.Number,
+		Current:          rev.Current,
+		Fingerprint:      rev.Fingerprint.String(),
+		Size:             rev.Size,
+		Timestamp:        rev.Timestamp,
+		Username:         rev.Username,
+		combinedRevision: combinedRevision(rev.Resource),
+		currentYesNo:     usedYesNo(rev.Current),
+	}
+}
+
 func combinedRevision(r resource.Resource) string {
 	switch r.Origin {
 	case charmresource.OriginStore:
@@ -54,6 +54,8 @@ func FormatSvcTabular(value interface{}) ([]byte, error) {
 		return formatDetailTabular(resources), nil
 	case FormattedUnitDetails:
 		return formatUnitDetailTabular(resources), nil
+	case []FormattedResourceRevision:
+		return formatRevisionsTabular(resources), nil
 	default:
 		return nil, errors.Errorf("unexpected type for data: %T", resources)
 	}
@@ -165,6 +167,29 @@ func formatUnitDetailTabular(resources FormattedUnitDetails) []byte {
 	return out.Bytes()
 }
 
+func formatRevisionsTabular(revisions []FormattedResourceRevision) []byte {
+	var out bytes.Buffer
+	fmt.Fprintln(&out, "[History]")
+
+	// To format things into columns.
+	tw := tabwriter.NewWriter(&out, 0, 1, 1, ' ', 0)
+
+	// Write the header.
+	fmt.Fprintln(tw, "RESOURCE\tREVISION\tCURRENT\tUPLOADED BY\tUPLOADED")
+
+	for _, r := range revisions {
+		fmt.Fprintf(tw, "%v\t%v\t%v\t%v\t%v\n",
+			r.Name,
+			r.Number,
+			r.currentYesNo,
+			r.Username,
+			r.combinedRevision,
+		)
+	}
+	tw.Flush()
+	return out.Bytes()
+}
+
 type byUnitID []FormattedDetailResource
 
 func (b byUnitID) Len() int      { return len(b) }
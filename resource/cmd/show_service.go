@@ -17,6 +17,9 @@ import (
 type ShowServiceClient interface {
 	// ListResources returns info about resources for services in the model.
 	ListResources(services []string) ([]resource.ServiceResources, error)
+	// ListResourceRevisions returns the retained revisions of the
+	// service's resources.
+	ListResourceRevisions(service string) ([]resource.ResourceRevision, error)
 	// Close closes the connection.
 	Close() error
 }
@@ -34,6 +37,7 @@ type ShowServiceCommand struct {
 	modelcmd.ModelCommandBase
 
 	details bool
+	history bool
 	deps    ShowServiceDeps
 	out     cmd.Output
 	target  string
@@ -54,6 +58,10 @@ func (c *ShowServiceCommand) Info() *cmd.Info {
 		Purpose: "show the resources for a service or unit",
 		Doc: `
 This command shows the resources required by and those in use by an existing service or unit in your model.
+
+With --history, the revisions of the service's resources that the model
+has retained are shown instead. The service may be rolled back to any of
+them with "juju push-resource --revision".
 `,
 	}
 }
@@ -68,6 +76,7 @@ func (c *ShowServiceCommand) SetFlags(f *gnuflag.FlagSet) {
 	})
 
 	f.BoolVar(&c.details, "details", false, "show detailed information about resources used by each unit.")
+	f.BoolVar(&c.history, "history", false, "show the retained revisions of the service's resources.")
 }
 
 // Init implements cmd.Command.Init. It will return an error satisfying
@@ -80,6 +89,14 @@ func (c *ShowServiceCommand) Init(args []string) error {
 	if err := cmd.CheckEmpty(args[1:]); err != nil {
 		return errors.NewBadRequest(err, "")
 	}
+	if c.history {
+		if c.details {
+			return errors.NewBadRequest(nil, "--history and --details may not be used together")
+		}
+		if !names.IsValidService(c.target) {
+			return errors.NewBadRequest(nil, "--history requires a service name")
+		}
+	}
 	return nil
 }
 
@@ -91,6 +108,10 @@ func (c *ShowServiceCommand) Run(ctx *cmd.Context) error {
 	}
 	defer apiclient.Close()
 
+	if c.history {
+		return c.formatRevisions(ctx, apiclient)
+	}
+
 	var unit string
 	var service string
 	if names.IsValidService(c.target) {
@@ -135,6 +156,19 @@ func (c *ShowServiceCommand) formatServiceResources(ctx *cmd.Context, sr resourc
 	return c.out.Write(ctx, res)
 }
 
+func (c *ShowServiceCommand) formatRevisions(ctx *cmd.Context, apiclient ShowServiceClient) error {
+	revisions, err := apiclient.ListResourceRevisions(c.target)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	formatted := make([]FormattedResourceRevision, len(revisions))
+	for i, rev := range revisions {
+		formatted[i] = FormatResourceRevision(rev)
+	}
+	return c.out.Write(ctx, formatted)
+}
+
 func (c *ShowServiceCommand) formatUnitResources(ctx *cmd.Context, unit, service string, sr resource.ServiceResources) error {
 	if c.details {
 		formatted, err := detailedResources(unit, sr)
@@ -56,6 +56,21 @@ func (*ShowServiceSuite) TestInitTooManyArgs(c *gc.C) {
 	c.Assert(err, jc.Satisfies, errors.IsBadRequest)
 }
 
+func (*ShowServiceSuite) TestInitHistoryUnit(c *gc.C) {
+	s := ShowServiceCommand{history: true}
+
+	err := s.Init([]string{"foo/0"})
+	c.Assert(err, jc.Satisfies, errors.IsBadRequest)
+	c.Check(err, gc.ErrorMatches, "--history requires a service name")
+}
+
+func (*ShowServiceSuite) TestInitHistoryDetails(c *gc.C) {
+	s := ShowServiceCommand{history: true, details: true}
+
+	err := s.Init([]string{"foo"})
+	c.Assert(err, jc.Satisfies, errors.IsBadRequest)
+}
+
 func (s *ShowServiceSuite) TestInfo(c *gc.C) {
 	var command ShowServiceCommand
 	info := command.Info()
@@ -67,6 +82,10 @@ func (s *ShowServiceSuite) TestInfo(c *gc.C) {
 		Purpose: "show the resources for a service or unit",
 		Doc: `
 This command shows the resources required by and those in use by an existing service or unit in your model.
+
+With --history, the revisions of the service's resources that the model
+has retained are shown instead. The service may be rolled back to any of
+them with "juju push-resource --revision".
 `,
 	})
 }
@@ -461,6 +480,52 @@ charlie  2011-11-11T11:11 2012-12-12T12:12
 	s.stubDeps.stub.CheckCall(c, 1, "ListResources", []string{"svc"})
 }
 
+func (s *ShowServiceSuite) TestRunHistory(c *gc.C) {
+	uploaded := func(name, username string, number int, current bool) resource.ResourceRevision {
+		return resource.ResourceRevision{
+			Resource: resource.Resource{
+				Resource: charmresource.Resource{
+					Meta: charmresource.Meta{
+						Name: name,
+					},
+					Origin: charmresource.OriginUpload,
+				},
+				Username:  username,
+				Timestamp: time.Date(2012, 12, number, 12, 12, 12, 0, time.UTC),
+			},
+			Number:  number,
+			Current: current,
+		}
+	}
+	s.stubDeps.client.ReturnRevisions = []resource.ResourceRevision{
+		uploaded("website", "Bill User", 1, false),
+		uploaded("website", "Bill User", 2, true),
+		uploaded("website", "Ann User", 3, false),
+	}
+
+	cmd := &ShowServiceCommand{
+		deps: ShowServiceDeps{
+			NewClient: s.stubDeps.NewClient,
+		},
+	}
+
+	code, stdout, stderr := runCmd(c, cmd, "svc", "--history")
+	c.Assert(code, gc.Equals, 0)
+	c.Assert(stderr, gc.Equals, "")
+
+	c.Check(stdout, gc.Equals, `
+[History]
+RESOURCE REVISION CURRENT UPLOADED BY UPLOADED
+website  1        no      Bill User   2012-01-12T12:12
+website  2        yes     Bill User   2012-02-12T12:12
+website  3        no      Ann User    2012-03-12T12:12
+
+`[1:])
+
+	s.stubDeps.stub.CheckCallNames(c, "NewClient", "ListResourceRevisions", "Close")
+	s.stubDeps.stub.CheckCall(c, 1, "ListResourceRevisions", "svc")
+}
+
 type stubShowServiceDeps struct {
 	stub   *testing.Stub
 	client *stubServiceClient
@@ -478,6 +543,7 @@ func (s *stubShowServiceDeps) NewClient(c *ShowServiceCommand) (ShowServiceClien
 type stubServiceClient struct {
 	stub            *testing.Stub
 	ReturnResources []resource.ServiceResources
+	ReturnRevisions []resource.ResourceRevision
 }
 
 func (s *stubServiceClient) ListResources(services []string) ([]resource.ServiceResources, error) {
@@ -488,6 +554,14 @@ func (s *stubServiceClient) ListResources(services []string) ([]resource.Service
 	return s.ReturnResources, nil
 }
 
+func (s *stubServiceClient) ListResourceRevisions(service string) ([]resource.ResourceRevision, error) {
+	s.stub.AddCall("ListResourceRevisions", service)
+	if err := s.stub.NextErr(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return s.ReturnRevisions, nil
+}
+
 func (s *stubServiceClient) Close() error {
 	s.stub.AddCall("Close")
 	if err := s.stub.NextErr(); err != nil {
@@ -58,6 +58,15 @@ func (s *stubAPIClient) Upload(service, name string, resource io.ReadSeeker) err
 	return nil
 }
 
+func (s *stubAPIClient) SetResourceRevision(service, name string, revision int) error {
+	s.stub.AddCall("SetResourceRevision", service, name, revision)
+	if err := s.stub.NextErr(); err != nil {
+		return errors.Trace(err)
+	}
+
+	return nil
+}
+
 func (s *stubAPIClient) Close() error {
 	s.stub.AddCall("Close")
 	if err := s.stub.NextErr(); err != nil {
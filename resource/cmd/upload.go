@@ -5,9 +5,11 @@ package cmd
 
 import (
 	"io"
+	"strings"
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
+	"launchpad.net/gnuflag"
 
 	"github.com/juju/juju/cmd/modelcmd"
 )
@@ -17,6 +19,10 @@ type UploadClient interface {
 	// Upload sends the resource to Juju.
 	Upload(service, name string, resource io.ReadSeeker) error
 
+	// SetResourceRevision makes the service use the identified
+	// retained revision of the resource.
+	SetResourceRevision(service, name string, revision int) error
+
 	// Close closes the client.
 	Close() error
 }
@@ -43,6 +49,7 @@ type UploadCommand struct {
 	modelcmd.ModelCommandBase
 	service      string
 	resourceFile resourceFile
+	revision     int
 }
 
 // NewUploadCommand returns a new command that lists resources defined
@@ -55,15 +62,26 @@ func NewUploadCommand(deps UploadDeps) *UploadCommand {
 func (c *UploadCommand) Info() *cmd.Info {
 	return &cmd.Info{
 		Name:    "push-resource",
-		Args:    "service name=file",
+		Aliases: []string{"attach"},
+		Args:    "service name=file | service name --revision N",
 		Purpose: "upload a file as a resource for a service",
 		Doc: `
 This command uploads a file from your local disk to the juju controller to be
 used as a resource for a service.
+
+With --revision, nothing is uploaded; instead the service is rolled back
+(or forward) to a revision of the resource that was uploaded before and
+is still retained. Units get it the next time they fetch the resource.
+The retained revisions are shown by "juju list-resources --history".
 `,
 	}
 }
 
+// SetFlags implements cmd.Command.SetFlags.
+func (c *UploadCommand) SetFlags(f *gnuflag.FlagSet) {
+	f.IntVar(&c.revision, "revision", 0, "use this retained revision of the resource instead of uploading a file")
+}
+
 // Init implements cmd.Command.Init. It will return an error satisfying
 // errors.BadRequest if you give it an incorrect number of arguments.
 func (c *UploadCommand) Init(args []string) error {
@@ -80,6 +98,13 @@ func (c *UploadCommand) Init(args []string) error {
 	}
 	c.service = service
 
+	if c.revision < 0 {
+		return errors.NotValidf("revision %d", c.revision)
+	}
+	if c.revision > 0 {
+		return c.initRevision(args[1:])
+	}
+
 	if err := c.addResourceFile(args[1]); err != nil {
 		return errors.Trace(err)
 	}
@@ -90,6 +115,23 @@ func (c *UploadCommand) Init(args []string) error {
 	return nil
 }
 
+// initRevision handles the arguments when a retained revision is
+// being set rather than a file uploaded.
+func (c *UploadCommand) initRevision(args []string) error {
+	name := args[0]
+	if strings.Contains(name, "=") {
+		return errors.BadRequestf("a file may not be given with --revision")
+	}
+	c.resourceFile = resourceFile{
+		service: c.service,
+		name:    name,
+	}
+	if err := cmd.CheckEmpty(args[1:]); err != nil {
+		return errors.NewBadRequest(err, "")
+	}
+	return nil
+}
+
 // addResourceFile parses the given arg into a name and a resource file,
 // and saves it in c.resourceFiles.
 func (c *UploadCommand) addResourceFile(arg string) error {
@@ -114,6 +156,14 @@ func (c *UploadCommand) Run(*cmd.Context) error {
 	}
 	defer apiclient.Close()
 
+	if c.revision > 0 {
+		err := apiclient.SetResourceRevision(c.service, c.resourceFile.name, c.revision)
+		if err != nil {
+			return errors.Annotatef(err, "failed to set resource %q to revision %d", c.resourceFile.name, c.revision)
+		}
+		return nil
+	}
+
 	if err := c.upload(c.resourceFile, apiclient); err != nil {
 		return errors.Annotatef(err, "failed to upload resource %q", c.resourceFile.name)
 	}
@@ -84,17 +84,48 @@ func (*UploadSuite) TestInitTwoResources(c *gc.C) {
 	c.Assert(err, jc.Satisfies, errors.IsBadRequest)
 }
 
+func (*UploadSuite) TestInitRevision(c *gc.C) {
+	u := UploadCommand{revision: 3}
+
+	err := u.Init([]string{"foo", "bar"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(u.resourceFile, gc.DeepEquals, resourceFile{
+		service: "foo",
+		name:    "bar",
+	})
+}
+
+func (*UploadSuite) TestInitRevisionWithFile(c *gc.C) {
+	u := UploadCommand{revision: 3}
+
+	err := u.Init([]string{"foo", "bar=baz"})
+	c.Assert(err, jc.Satisfies, errors.IsBadRequest)
+}
+
+func (*UploadSuite) TestInitBadRevision(c *gc.C) {
+	u := UploadCommand{revision: -1}
+
+	err := u.Init([]string{"foo", "bar"})
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
+
 func (s *UploadSuite) TestInfo(c *gc.C) {
 	var command UploadCommand
 	info := command.Info()
 
 	c.Check(info, jc.DeepEquals, &jujucmd.Info{
 		Name:    "push-resource",
-		Args:    "service name=file",
+		Aliases: []string{"attach"},
+		Args:    "service name=file | service name --revision N",
 		Purpose: "upload a file as a resource for a service",
 		Doc: `
 This command uploads a file from your local disk to the juju controller to be
 used as a resource for a service.
+
+With --revision, nothing is uploaded; instead the service is rolled back
+(or forward) to a revision of the resource that was uploaded before and
+is still retained. Units get it the next time they fetch the resource.
+The retained revisions are shown by "juju list-resources --history".
 `,
 	})
 }
@@ -152,3 +183,47 @@ func (s *stubUploadDeps) OpenResource(path string) (ReadSeekCloser, error) {
 
 	return s.file, nil
 }
+
+func (s *UploadSuite) TestRunRevision(c *gc.C) {
+	u := UploadCommand{
+		deps: UploadDeps{
+			NewClient:    s.stubDeps.NewClient,
+			OpenResource: s.stubDeps.OpenResource,
+		},
+		resourceFile: resourceFile{
+			service: "svc",
+			name:    "foo",
+		},
+		service:  "svc",
+		revision: 2,
+	}
+
+	err := u.Run(nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.stub.CheckCallNames(c,
+		"NewClient",
+		"SetResourceRevision",
+		"Close",
+	)
+	s.stub.CheckCall(c, 1, "SetResourceRevision", "svc", "foo", 2)
+}
+
+func (s *UploadSuite) TestRunRevisionNotFound(c *gc.C) {
+	s.stub.SetErrors(nil, errors.NotFoundf(`resource "svc/foo" revision 9`))
+	u := UploadCommand{
+		deps: UploadDeps{
+			NewClient: s.stubDeps.NewClient,
+		},
+		resourceFile: resourceFile{
+			service: "svc",
+			name:    "foo",
+		},
+		service:  "svc",
+		revision: 9,
+	}
+
+	err := u.Run(nil)
+
+	c.Check(err, gc.ErrorMatches, `failed to set resource "foo" to revision 9: resource "svc/foo" revision 9 not found`)
+}
@@ -0,0 +1,35 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package resource
+
+import (
+	"github.com/juju/errors"
+)
+
+// ResourceRevision is an uploaded revision of a service's resource
+// that the model has retained, whether or not the service currently
+// uses it. Retaining revisions allows a service to be rolled back to
+// one of them.
+type ResourceRevision struct {
+	Resource
+
+	// Number identifies the revision among those uploaded for the
+	// resource and service. The first upload is revision 1.
+	Number int
+
+	// Current indicates whether this is the revision that the service
+	// currently uses (and that resource-get will retrieve).
+	Current bool
+}
+
+// Validate ensures that the revision is valid.
+func (rev ResourceRevision) Validate() error {
+	if err := rev.Resource.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	if rev.Number <= 0 {
+		return errors.NewNotValid(nil, "revision number must be positive")
+	}
+	return nil
+}
@@ -0,0 +1,251 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package persistence
+
+import (
+	"sort"
+
+	"github.com/juju/errors"
+	jujutxn "github.com/juju/txn"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// Resource content is stored by fingerprint, so resources with the
+// same content share it. Each piece of content has a blob doc that
+// counts the resource docs referring to it (unit docs aside, since
+// units already hold their copy). When the count drops to zero the
+// content is orphaned, and MarkOrphanedBlobs finds it so that it can
+// be removed from storage. Content stored before reference counting
+// was introduced has no blob doc until AddMissingBlobs is run, and is
+// not removed until then.
+
+// resourceBlobDoc records resource content held in blob storage.
+type resourceBlobDoc struct {
+	DocID       string `bson:"_id"`
+	StoragePath string `bson:"storage-path"`
+
+	// RefCount is the number of resource docs that refer to the
+	// content.
+	RefCount int `bson:"refcount"`
+
+	// Removing is set while orphaned content is being removed from
+	// storage. The content may not be referred to again until the
+	// doc has gone.
+	Removing bool `bson:"removing"`
+}
+
+// getBlob returns the blob doc for the content at the storage path.
+func getBlob(base PersistenceBase, storagePath string) (resourceBlobDoc, error) {
+	var doc resourceBlobDoc
+	if err := base.One(resourceBlobsC, storagePath, &doc); err != nil {
+		return doc, errors.Trace(err)
+	}
+	return doc, nil
+}
+
+// newStageBlobOps generates transaction operations that add a
+// reference to newly staged content, recording the content if it is
+// not already.
+func newStageBlobOps(base PersistenceBase, storagePath string) ([]txn.Op, error) {
+	doc, err := getBlob(base, storagePath)
+	if errors.IsNotFound(err) {
+		return []txn.Op{{
+			C:      resourceBlobsC,
+			Id:     storagePath,
+			Assert: txn.DocMissing,
+			Insert: &resourceBlobDoc{
+				DocID:       storagePath,
+				StoragePath: storagePath,
+				RefCount:    1,
+			},
+		}}, nil
+	}
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if doc.Removing {
+		return nil, errors.Errorf("resource content %q is being removed; try again", storagePath)
+	}
+	return []txn.Op{newIncBlobRefOp(storagePath, 1)}, nil
+}
+
+func newIncBlobRefOp(storagePath string, delta int) txn.Op {
+	assert := bson.D{{"removing", false}}
+	if delta < 0 {
+		assert = bson.D{{"refcount", bson.D{{"$gte", -delta}}}}
+	}
+	return txn.Op{
+		C:      resourceBlobsC,
+		Id:     storagePath,
+		Assert: assert,
+		Update: bson.D{{"$inc", bson.D{{"refcount", delta}}}},
+	}
+}
+
+// blobRefs accumulates the changes that a transaction makes to the
+// number of resource docs referring to each piece of content.
+type blobRefs map[string]int
+
+// add records a new reference to the content at the storage path.
+func (refs blobRefs) add(storagePath string) {
+	if storagePath != "" {
+		refs[storagePath]++
+	}
+}
+
+// remove records a dropped reference to the content at the storage
+// path.
+func (refs blobRefs) remove(storagePath string) {
+	if storagePath != "" {
+		refs[storagePath]--
+	}
+}
+
+// ops generates the transaction operations that apply the changes.
+// Content that has no blob doc is not reference counted, so is left
+// alone.
+func (refs blobRefs) ops(base PersistenceBase) ([]txn.Op, error) {
+	var storagePaths []string
+	for storagePath, delta := range refs {
+		if delta != 0 {
+			storagePaths = append(storagePaths, storagePath)
+		}
+	}
+	sort.Strings(storagePaths)
+
+	var ops []txn.Op
+	for _, storagePath := range storagePaths {
+		doc, err := getBlob(base, storagePath)
+		if errors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		delta := refs[storagePath]
+		if delta > 0 && doc.Removing {
+			return nil, errors.Errorf("resource content %q is being removed; try again", storagePath)
+		}
+		ops = append(ops, newIncBlobRefOp(storagePath, delta))
+	}
+	return ops, nil
+}
+
+// MarkOrphanedBlobs marks the content that no resource refers to any
+// more as being removed, and returns its storage paths. That includes
+// content already marked but not yet removed. Once the content has
+// been removed from storage, RemoveBlobs must be called with the paths.
+func (p Persistence) MarkOrphanedBlobs() ([]string, error) {
+	var storagePaths []string
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		storagePaths = nil
+
+		var docs []resourceBlobDoc
+		query := bson.D{{"refcount", 0}}
+		if err := p.base.All(resourceBlobsC, query, &docs); err != nil {
+			return nil, errors.Trace(err)
+		}
+		var ops []txn.Op
+		for _, doc := range docs {
+			storagePaths = append(storagePaths, doc.StoragePath)
+			if doc.Removing {
+				continue
+			}
+			ops = append(ops, txn.Op{
+				C:      resourceBlobsC,
+				Id:     doc.StoragePath,
+				Assert: bson.D{{"refcount", 0}, {"removing", false}},
+				Update: bson.D{{"$set", bson.D{{"removing", true}}}},
+			})
+		}
+		if len(ops) == 0 {
+			return nil, jujutxn.ErrNoOperations
+		}
+		return ops, nil
+	}
+	if err := p.base.Run(buildTxn); err != nil {
+		return nil, errors.Trace(err)
+	}
+	sort.Strings(storagePaths)
+	return storagePaths, nil
+}
+
+// RemoveBlobs removes the records of the identified content, which
+// must have been marked by MarkOrphanedBlobs and removed from storage.
+func (p Persistence) RemoveBlobs(storagePaths []string) error {
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		var ops []txn.Op
+		for _, storagePath := range storagePaths {
+			if _, err := getBlob(p.base, storagePath); errors.IsNotFound(err) {
+				continue
+			} else if err != nil {
+				return nil, errors.Trace(err)
+			}
+			ops = append(ops, txn.Op{
+				C:      resourceBlobsC,
+				Id:     storagePath,
+				Assert: bson.D{{"removing", true}},
+				Remove: true,
+			})
+		}
+		if len(ops) == 0 {
+			return nil, jujutxn.ErrNoOperations
+		}
+		return ops, nil
+	}
+	if err := p.base.Run(buildTxn); err != nil {
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+// AddMissingBlobs adds blob docs for the content stored before
+// reference counting was introduced, counting the resource docs that
+// already refer to it.
+func (p Persistence) AddMissingBlobs() error {
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		var docs []resourceDoc
+		query := bson.D{{"unit-id", ""}, {"storage-path", bson.D{{"$ne", ""}}}}
+		if err := p.base.All(resourcesC, query, &docs); err != nil {
+			return nil, errors.Trace(err)
+		}
+		refs := make(map[string]int)
+		var storagePaths []string
+		for _, doc := range docs {
+			if refs[doc.StoragePath] == 0 {
+				storagePaths = append(storagePaths, doc.StoragePath)
+			}
+			refs[doc.StoragePath]++
+		}
+		sort.Strings(storagePaths)
+
+		var ops []txn.Op
+		for _, storagePath := range storagePaths {
+			if _, err := getBlob(p.base, storagePath); err == nil {
+				continue
+			} else if !errors.IsNotFound(err) {
+				return nil, errors.Trace(err)
+			}
+			ops = append(ops, txn.Op{
+				C:      resourceBlobsC,
+				Id:     storagePath,
+				Assert: txn.DocMissing,
+				Insert: &resourceBlobDoc{
+					DocID:       storagePath,
+					StoragePath: storagePath,
+					RefCount:    refs[storagePath],
+				},
+			})
+		}
+		if len(ops) == 0 {
+			return nil, jujutxn.ErrNoOperations
+		}
+		return ops, nil
+	}
+	if err := p.base.Run(buildTxn); err != nil {
+		return errors.Trace(err)
+	}
+	return nil
+}
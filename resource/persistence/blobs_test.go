@@ -0,0 +1,182 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package persistence
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+var _ = gc.Suite(&BlobsSuite{})
+
+type BlobsSuite struct {
+	testing.IsolationSuite
+
+	stub *testing.Stub
+	base *stubStatePersistence
+}
+
+func (s *BlobsSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+
+	s.stub = &testing.Stub{}
+	s.base = &stubStatePersistence{
+		stub: s.stub,
+	}
+}
+
+func (s *BlobsSuite) TestBlobRefsOps(c *gc.C) {
+	s.base.blobs = []resourceBlobDoc{
+		newBlobDoc("resources/spam", 1),
+		newBlobDoc("resources/eggs", 1),
+		newBlobDoc("resources/ham", 1),
+	}
+	refs := blobRefs{}
+	refs.add("resources/spam")
+	refs.remove("resources/eggs")
+	refs.add("resources/ham")
+	refs.remove("resources/ham")
+	refs.add("resources/legacy")
+	refs.add("")
+
+	ops, err := refs.ops(s.base)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.stub.CheckCallNames(c, "One", "One", "One")
+	c.Check(ops, jc.DeepEquals, []txn.Op{{
+		C:      "resourceblobs",
+		Id:     "resources/eggs",
+		Assert: bson.D{{"refcount", bson.D{{"$gte", 1}}}},
+		Update: bson.D{{"$inc", bson.D{{"refcount", -1}}}},
+	}, {
+		C:      "resourceblobs",
+		Id:     "resources/spam",
+		Assert: bson.D{{"removing", false}},
+		Update: bson.D{{"$inc", bson.D{{"refcount", 1}}}},
+	}})
+}
+
+func (s *BlobsSuite) TestBlobRefsOpsBeingRemoved(c *gc.C) {
+	blob := newBlobDoc("resources/spam", 0)
+	blob.Removing = true
+	s.base.blobs = []resourceBlobDoc{blob}
+	refs := blobRefs{}
+	refs.add("resources/spam")
+
+	_, err := refs.ops(s.base)
+
+	c.Check(err, gc.ErrorMatches, `resource content "resources/spam" is being removed; try again`)
+}
+
+func (s *BlobsSuite) TestMarkOrphanedBlobs(c *gc.C) {
+	marked := newBlobDoc("resources/spam", 0)
+	marked.Removing = true
+	s.base.blobs = []resourceBlobDoc{
+		newBlobDoc("resources/eggs", 0),
+		marked,
+	}
+	ignoredErr := errors.New("<never reached>")
+	s.stub.SetErrors(nil, nil, nil, ignoredErr)
+	p := NewPersistence(s.base)
+
+	paths, err := p.MarkOrphanedBlobs()
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(paths, jc.DeepEquals, []string{"resources/eggs", "resources/spam"})
+	s.stub.CheckCallNames(c, "Run", "All", "RunTransaction")
+	s.stub.CheckCall(c, 1, "All", "resourceblobs", bson.D{{"refcount", 0}}, &s.base.blobs)
+	s.stub.CheckCall(c, 2, "RunTransaction", []txn.Op{{
+		C:      "resourceblobs",
+		Id:     "resources/eggs",
+		Assert: bson.D{{"refcount", 0}, {"removing", false}},
+		Update: bson.D{{"$set", bson.D{{"removing", true}}}},
+	}})
+}
+
+func (s *BlobsSuite) TestMarkOrphanedBlobsNone(c *gc.C) {
+	p := NewPersistence(s.base)
+
+	paths, err := p.MarkOrphanedBlobs()
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(paths, gc.HasLen, 0)
+	s.stub.CheckCallNames(c, "Run", "All")
+}
+
+func (s *BlobsSuite) TestRemoveBlobs(c *gc.C) {
+	marked := newBlobDoc("resources/spam", 0)
+	marked.Removing = true
+	s.base.blobs = []resourceBlobDoc{marked}
+	ignoredErr := errors.New("<never reached>")
+	s.stub.SetErrors(nil, nil, nil, nil, ignoredErr)
+	p := NewPersistence(s.base)
+
+	err := p.RemoveBlobs([]string{"resources/spam", "resources/eggs"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.stub.CheckCallNames(c, "Run", "One", "One", "RunTransaction")
+	s.stub.CheckCall(c, 3, "RunTransaction", []txn.Op{{
+		C:      "resourceblobs",
+		Id:     "resources/spam",
+		Assert: bson.D{{"removing", true}},
+		Remove: true,
+	}})
+}
+
+func (s *BlobsSuite) TestAddMissingBlobs(c *gc.C) {
+	s.base.docs = []resourceDoc{
+		{DocID: "resource#a-service/spam", StoragePath: "resources/spam"},
+		{DocID: "resource#a-service/spam#revision-1", StoragePath: "resources/spam"},
+		{DocID: "resource#a-service/eggs", StoragePath: "resources/eggs"},
+	}
+	s.base.blobs = []resourceBlobDoc{
+		newBlobDoc("resources/eggs", 1),
+	}
+	ignoredErr := errors.New("<never reached>")
+	s.stub.SetErrors(nil, nil, nil, nil, nil, ignoredErr)
+	p := NewPersistence(s.base)
+
+	err := p.AddMissingBlobs()
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.stub.CheckCallNames(c, "Run", "All", "One", "One", "RunTransaction")
+	s.stub.CheckCall(c, 1, "All", "resources", bson.D{
+		{"unit-id", ""},
+		{"storage-path", bson.D{{"$ne", ""}}},
+	}, &s.base.docs)
+	blob := newBlobDoc("resources/spam", 2)
+	s.stub.CheckCall(c, 4, "RunTransaction", []txn.Op{{
+		C:      "resourceblobs",
+		Id:     "resources/spam",
+		Assert: txn.DocMissing,
+		Insert: &blob,
+	}})
+}
+
+func (s *BlobsSuite) TestAddMissingBlobsNone(c *gc.C) {
+	s.base.docs = []resourceDoc{
+		{DocID: "resource#a-service/eggs", StoragePath: "resources/eggs"},
+	}
+	s.base.blobs = []resourceBlobDoc{
+		newBlobDoc("resources/eggs", 1),
+	}
+	p := NewPersistence(s.base)
+
+	err := p.AddMissingBlobs()
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.stub.CheckCallNames(c, "Run", "All", "One")
+}
+
+func newBlobDoc(storagePath string, refCount int) resourceBlobDoc {
+	return resourceBlobDoc{
+		DocID:       storagePath,
+		StoragePath: storagePath,
+		RefCount:    refCount,
+	}
+}
@@ -5,6 +5,7 @@ package persistence
 
 import (
 	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/juju/errors"
@@ -16,7 +17,8 @@ import (
 )
 
 const (
-	resourcesC = "resources"
+	resourcesC     = "resources"
+	resourceBlobsC = "resourceblobs"
 
 	stagedIDSuffix = "#staged"
 )
@@ -41,6 +43,10 @@ func unitResourceID(id, unitID string) string {
 	return resourceID(id, "unit", unitID)
 }
 
+func revisionResourceID(id string, number int) string {
+	return resourceID(id, "revision", strconv.Itoa(number))
+}
+
 // stagedID converts an external resource ID into an internal staged one.
 func stagedID(id string) string {
 	return serviceResourceID(id) + stagedIDSuffix
@@ -79,10 +85,10 @@ func newEnsureStagedSameOps(stored storedResource) []txn.Op {
 func newRemoveStagedOps(id string) []txn.Op {
 	fullID := stagedID(id)
 
-	// We don't assert that it exists. We want "missing" to be a noop.
 	return []txn.Op{{
 		C:      resourcesC,
 		Id:     fullID,
+		Assert: txn.DocExists,
 		Remove: true,
 	}}
 }
@@ -121,24 +127,83 @@ func newUpdateUnitResourceOps(unitID string, stored storedResource) []txn.Op {
 	}}, newInsertUnitResourceOps(unitID, stored)...)
 }
 
-func newUpdateResourceOps(stored storedResource) []txn.Op {
+// newUpdateResourceOps generates transaction operations that replace
+// the existing resource doc, which must still refer to the content at
+// the old storage path.
+func newUpdateResourceOps(stored storedResource, oldStoragePath string) []txn.Op {
 	doc := newResourceDoc(stored)
 
 	// TODO(ericsnow) Using "update" doesn't work right...
 	return append([]txn.Op{{
 		C:      resourcesC,
 		Id:     doc.DocID,
-		Assert: txn.DocExists,
+		Assert: bson.D{{"storage-path", oldStoragePath}},
 		Remove: true,
 	}}, newInsertResourceOps(stored)...)
 }
 
+// newInsertRevisionOps generates transaction operations that record
+// the resource as the numbered revision in the resource's history.
+func newInsertRevisionOps(stored storedResource, number int) []txn.Op {
+	doc := newRevisionDoc(stored, number)
+
+	return []txn.Op{{
+		C:      resourcesC,
+		Id:     doc.DocID,
+		Assert: txn.DocMissing,
+		Insert: doc,
+	}}
+}
+
+// newRemoveResourceDocOps generates transaction operations that remove
+// the given doc, whatever sort of resource doc it is.
+func newRemoveResourceDocOps(doc resourceDoc) []txn.Op {
+	return []txn.Op{{
+		C:      resourcesC,
+		Id:     doc.DocID,
+		Assert: txn.DocExists,
+		Remove: true,
+	}}
+}
+
+func newRemoveRevisionOps(id string, number int) []txn.Op {
+	return []txn.Op{{
+		C:      resourcesC,
+		Id:     revisionResourceID(id, number),
+		Assert: txn.DocExists,
+		Remove: true,
+	}}
+}
+
+// newSetRevisionOps generates transaction operations that make the
+// numbered revision of a resource the active resource, replacing the
+// one using the content at the old storage path.
+func newSetRevisionOps(revision storedResource, number int, oldStoragePath string) []txn.Op {
+	ops := []txn.Op{{
+		C:      resourcesC,
+		Id:     revisionResourceID(revision.ID, number),
+		Assert: txn.DocExists,
+	}}
+	return append(ops, newUpdateResourceOps(revision, oldStoragePath)...)
+}
+
+// isRetainedRevision indicates whether or not the resource is one
+// whose revisions are retained in its history. Only the uploaded
+// content of active resources is retained.
+func isRetainedRevision(res resource.Resource) bool {
+	return res.Origin == charmresource.OriginUpload && !res.IsPlaceholder() && res.PendingID == ""
+}
+
 // newResolvePendingResourceOps generates transaction operations that
 // will resolve a pending resource doc and make it active.
 //
 // We trust that the provided resource really is pending
 // and that it matches the existing doc with the same ID.
-func newResolvePendingResourceOps(pending storedResource, exists bool) []txn.Op {
+//
+// If the resource's revisions are retained then the resolved resource
+// is recorded as the given revision. If there is a current resource
+// then it is replaced.
+func newResolvePendingResourceOps(pending storedResource, current *resourceDoc, revision int) []txn.Op {
 	oldID := pendingResourceID(pending.ID, pending.PendingID)
 	newRes := pending
 	newRes.PendingID = ""
@@ -151,8 +216,11 @@ func newResolvePendingResourceOps(pending storedResource, exists bool) []txn.Op
 		Assert: txn.DocExists,
 		Remove: true,
 	}}
-	if exists {
-		return append(ops, newUpdateResourceOps(newRes)...)
+	if isRetainedRevision(newRes.Resource) {
+		ops = append(ops, newInsertRevisionOps(newRes, revision)...)
+	}
+	if current != nil {
+		return append(ops, newUpdateResourceOps(newRes, current.StoragePath)...)
 	} else {
 		return append(ops, newInsertResourceOps(newRes)...)
 	}
@@ -173,6 +241,15 @@ func newResourceDoc(stored storedResource) *resourceDoc {
 	return resource2doc(fullID, stored)
 }
 
+// newRevisionDoc generates a doc that represents the given resource as
+// the numbered revision in the resource's history.
+func newRevisionDoc(stored storedResource, number int) *resourceDoc {
+	fullID := revisionResourceID(stored.ID, number)
+	doc := resource2doc(fullID, stored)
+	doc.HistoryNumber = number
+	return doc
+}
+
 // newStagedDoc generates a staging doc that represents the given resource.
 func newStagedDoc(stored storedResource) *resourceDoc {
 	stagedID := stagedID(stored.ID)
@@ -191,6 +268,37 @@ func (p Persistence) resources(serviceID string) ([]resourceDoc, error) {
 	return docs, nil
 }
 
+// revisions returns the docs for the retained revisions of the given
+// resource.
+func revisions(base PersistenceBase, resID string) ([]resourceDoc, error) {
+	logger.Tracef("querying db for revisions of resource %q", resID)
+	var docs []resourceDoc
+	query := bson.D{
+		{"resource-id", resID},
+		{"history-number", bson.D{{"$gt", 0}}},
+	}
+	if err := base.All(resourcesC, query, &docs); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return docs, nil
+}
+
+// nextRevision returns the number of the next revision to be retained
+// for the given resource.
+func nextRevision(base PersistenceBase, resID string) (int, error) {
+	docs, err := revisions(base, resID)
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	next := 1
+	for _, doc := range docs {
+		if doc.HistoryNumber >= next {
+			next = doc.HistoryNumber + 1
+		}
+	}
+	return next, nil
+}
+
 // getOne returns the resource that matches the provided model ID.
 func (p Persistence) getOne(resID string) (resourceDoc, error) {
 	logger.Tracef("querying db for resource %q", resID)
@@ -213,6 +321,18 @@ func (p Persistence) getOnePending(resID, pendingID string) (resourceDoc, error)
 	return doc, nil
 }
 
+// getOneRevision returns the numbered revision of the resource that
+// matches the provided model ID.
+func (p Persistence) getOneRevision(resID string, number int) (resourceDoc, error) {
+	logger.Tracef("querying db for resource %q (revision %d)", resID, number)
+	id := revisionResourceID(resID, number)
+	var doc resourceDoc
+	if err := p.base.One(resourcesC, id, &doc); err != nil {
+		return doc, errors.Trace(err)
+	}
+	return doc, nil
+}
+
 // resourceDoc is the top-level document for resources.
 type resourceDoc struct {
 	DocID     string `bson:"_id"`
@@ -237,6 +357,10 @@ type resourceDoc struct {
 	Timestamp time.Time `bson:"timestamp-when-added"`
 
 	StoragePath string `bson:"storage-path"`
+
+	// HistoryNumber is set only on the docs that record the retained
+	// revisions of a resource.
+	HistoryNumber int `bson:"history-number,omitempty"`
 }
 
 func unitResource2Doc(id, unitID string, stored storedResource) *resourceDoc {
@@ -4,6 +4,9 @@
 package persistence
 
 import (
+	"sort"
+	"strings"
+
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"github.com/juju/names"
@@ -60,7 +63,7 @@ func (p Persistence) ListResources(serviceID string) (resource.ServiceResources,
 
 	var results resource.ServiceResources
 	for _, doc := range docs {
-		if doc.PendingID != "" {
+		if doc.PendingID != "" || doc.HistoryNumber != 0 {
 			continue
 		}
 
@@ -97,7 +100,7 @@ func (p Persistence) ListPendingResources(serviceID string) ([]resource.Resource
 		if doc.PendingID == "" {
 			continue
 		}
-		// doc.UnitID will always be empty here.
+		// doc.UnitID and doc.HistoryNumber will always be empty here.
 
 		res, err := doc2basicResource(doc)
 		if err != nil {
@@ -153,18 +156,9 @@ func (p Persistence) StageResource(res resource.Resource, storagePath string) (*
 	return staged, nil
 }
 
-// SetResource sets the info for the resource.
+// SetResource sets the info for the resource. Any content the
+// resource already refers to is kept.
 func (p Persistence) SetResource(res resource.Resource) error {
-	stored, err := p.getStored(res)
-	if errors.IsNotFound(err) {
-		stored = storedResource{Resource: res}
-	} else if err != nil {
-		return errors.Trace(err)
-	}
-	// TODO(ericsnow) Ensure that stored.Resource matches res? If we do
-	// so then the following line is unnecessary.
-	stored.Resource = res
-
 	// TODO(ericsnow) Ensure that the service is still there?
 
 	if err := res.Validate(); err != nil {
@@ -172,18 +166,22 @@ func (p Persistence) SetResource(res resource.Resource) error {
 	}
 
 	buildTxn := func(attempt int) ([]txn.Op, error) {
-		// This is an "upsert".
-		var ops []txn.Op
-		switch attempt {
-		case 0:
-			ops = newInsertResourceOps(stored)
-		case 1:
-			ops = newUpdateResourceOps(stored)
-		default:
-			// Either insert or update will work so we should not get here.
-			return nil, errors.New("setting the resource failed")
+		// This is an "upsert" of the doc the resource belongs in,
+		// which is the pending doc if the resource is pending.
+		var doc resourceDoc
+		docID := newResourceDoc(storedResource{Resource: res}).DocID
+		err := p.base.One(resourcesC, docID, &doc)
+		if errors.IsNotFound(err) {
+			return newInsertResourceOps(storedResource{Resource: res}), nil
 		}
-		return ops, nil
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		stored := storedResource{
+			Resource:    res,
+			storagePath: doc.StoragePath,
+		}
+		return newUpdateResourceOps(stored, doc.StoragePath), nil
 	}
 	if err := p.base.Run(buildTxn); err != nil {
 		return errors.Trace(err)
@@ -268,13 +266,208 @@ func (p Persistence) NewResolvePendingResourceOps(resID, pendingID string) ([]tx
 		return nil, errors.Trace(err)
 	}
 
-	exists := true
-	if _, err := p.getOne(resID); errors.IsNotFound(err) {
-		exists = false
-	} else if err != nil {
+	var current *resourceDoc
+	if doc, err := p.getOne(resID); err == nil {
+		current = &doc
+	} else if !errors.IsNotFound(err) {
 		return nil, errors.Trace(err)
 	}
 
-	ops := newResolvePendingResourceOps(pending, exists)
-	return ops, nil
+	// The pending doc's reference to its content passes to the
+	// resolved doc, so only the replaced content loses one.
+	refs := blobRefs{}
+	if current != nil {
+		refs.remove(current.StoragePath)
+	}
+
+	revision := 0
+	resolved := pending.Resource
+	resolved.PendingID = ""
+	if isRetainedRevision(resolved) {
+		revision, err = nextRevision(p.base, resID)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		refs.add(pending.storagePath)
+	}
+
+	ops := newResolvePendingResourceOps(pending, current, revision)
+	refOps, err := refs.ops(p.base)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return append(ops, refOps...), nil
+}
+
+// ListResourceRevisions returns the retained revisions of each of the
+// identified service's resources, ordered by resource name and then
+// revision number.
+func (p Persistence) ListResourceRevisions(serviceID string) ([]resource.ResourceRevision, error) {
+	docs, err := p.resources(serviceID)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	currentPaths := make(map[string]string)
+	var revisionDocs []resourceDoc
+	for _, doc := range docs {
+		switch {
+		case doc.HistoryNumber != 0:
+			revisionDocs = append(revisionDocs, doc)
+		case doc.PendingID == "" && doc.UnitID == "" && !strings.HasSuffix(doc.DocID, stagedIDSuffix):
+			currentPaths[doc.ID] = doc.StoragePath
+		}
+	}
+
+	var revisions []resource.ResourceRevision
+	for _, doc := range revisionDocs {
+		res, err := doc2basicResource(doc)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		revisions = append(revisions, resource.ResourceRevision{
+			Resource: res,
+			Number:   doc.HistoryNumber,
+			Current:  currentPaths[doc.ID] == doc.StoragePath,
+		})
+	}
+	sort.Sort(byNameAndNumber(revisions))
+	return revisions, nil
+}
+
+type byNameAndNumber []resource.ResourceRevision
+
+func (b byNameAndNumber) Len() int      { return len(b) }
+func (b byNameAndNumber) Swap(i, j int) { b[i], b[j] = b[j], b[i] }
+
+func (b byNameAndNumber) Less(i, j int) bool {
+	if b[i].Name != b[j].Name {
+		return b[i].Name < b[j].Name
+	}
+	return b[i].Number < b[j].Number
 }
+
+// SetResourceRevision makes the numbered revision of the identified
+// resource the active one, and returns it.
+func (p Persistence) SetResourceRevision(id string, number int) (resource.Resource, error) {
+	doc, err := p.getOneRevision(id, number)
+	if errors.IsNotFound(err) {
+		return resource.Resource{}, errors.NotFoundf("resource %q revision %d", id, number)
+	}
+	if err != nil {
+		return resource.Resource{}, errors.Trace(err)
+	}
+	revision, err := doc2resource(doc)
+	if err != nil {
+		return resource.Resource{}, errors.Trace(err)
+	}
+
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if _, err := p.getOneRevision(id, number); errors.IsNotFound(err) {
+				return nil, errors.NotFoundf("resource %q revision %d", id, number)
+			} else if err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		current, err := p.getOne(id)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if current.StoragePath == revision.storagePath {
+			return nil, jujutxn.ErrNoOperations
+		}
+
+		refs := blobRefs{}
+		refs.remove(current.StoragePath)
+		refs.add(revision.storagePath)
+		refOps, err := refs.ops(p.base)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops := newSetRevisionOps(revision, number, current.StoragePath)
+		return append(ops, refOps...), nil
+	}
+	if err := p.base.Run(buildTxn); err != nil {
+		return resource.Resource{}, errors.Trace(err)
+	}
+	return revision.Resource, nil
+}
+
+// PruneResourceRevisions removes the records of all but the newest
+// revisions of the identified resource, keeping the given number of
+// them. The active revision is never removed. Content left orphaned
+// by the removed revisions is removed along with other orphaned
+// content.
+func (p Persistence) PruneResourceRevisions(id string, keep int) error {
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		docs, err := revisions(p.base, id)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if len(docs) <= keep {
+			return nil, jujutxn.ErrNoOperations
+		}
+		currentPath := ""
+		if current, err := p.getOne(id); err == nil {
+			currentPath = current.StoragePath
+		} else if !errors.IsNotFound(err) {
+			return nil, errors.Trace(err)
+		}
+
+		// Newest first.
+		sort.Sort(sort.Reverse(byHistoryNumber(docs)))
+		var ops []txn.Op
+		refs := blobRefs{}
+		for i, doc := range docs {
+			if i < keep || doc.StoragePath == currentPath {
+				continue
+			}
+			ops = append(ops, newRemoveRevisionOps(id, doc.HistoryNumber)...)
+			refs.remove(doc.StoragePath)
+		}
+		if len(ops) == 0 {
+			return nil, jujutxn.ErrNoOperations
+		}
+		refOps, err := refs.ops(p.base)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return append(ops, refOps...), nil
+	}
+	if err := p.base.Run(buildTxn); err != nil {
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+// NewRemoveResourcesOps generates mongo transaction operations to
+// remove all the identified service's resources, including their
+// retained revisions and the records of the units' copies. Content
+// left orphaned is removed along with other orphaned content.
+func (p Persistence) NewRemoveResourcesOps(serviceID string) ([]txn.Op, error) {
+	docs, err := p.resources(serviceID)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	var ops []txn.Op
+	refs := blobRefs{}
+	for _, doc := range docs {
+		ops = append(ops, newRemoveResourceDocOps(doc)...)
+		if doc.UnitID == "" {
+			refs.remove(doc.StoragePath)
+		}
+	}
+	refOps, err := refs.ops(p.base)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return append(ops, refOps...), nil
+}
+
+type byHistoryNumber []resourceDoc
+
+func (b byHistoryNumber) Len() int           { return len(b) }
+func (b byHistoryNumber) Swap(i, j int)      { b[i], b[j] = b[j], b[i] }
+func (b byHistoryNumber) Less(i, j int) bool { return b[i].HistoryNumber < b[j].HistoryNumber }
@@ -4,6 +4,7 @@
 package persistence
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/juju/errors"
@@ -183,17 +184,26 @@ func (s *PersistenceSuite) TestStageResourceOkay(c *gc.C) {
 	doc.DocID += "#staged"
 	p := NewPersistence(s.base)
 	ignoredErr := errors.New("<never reached>")
-	s.stub.SetErrors(nil, nil, ignoredErr)
+	s.stub.SetErrors(nil, nil, nil, ignoredErr)
 
 	staged, err := p.StageResource(res.Resource, res.storagePath)
 	c.Assert(err, jc.ErrorIsNil)
 
-	s.stub.CheckCallNames(c, "Run", "RunTransaction")
-	s.stub.CheckCall(c, 1, "RunTransaction", []txn.Op{{
+	s.stub.CheckCallNames(c, "Run", "One", "RunTransaction")
+	s.stub.CheckCall(c, 2, "RunTransaction", []txn.Op{{
 		C:      "resources",
 		Id:     "resource#a-service/spam#staged",
 		Assert: txn.DocMissing,
 		Insert: &doc,
+	}, {
+		C:      "resourceblobs",
+		Id:     "service-a-service/resources/spam",
+		Assert: txn.DocMissing,
+		Insert: &resourceBlobDoc{
+			DocID:       "service-a-service/resources/spam",
+			StoragePath: "service-a-service/resources/spam",
+			RefCount:    1,
+		},
 	}})
 	c.Check(staged, jc.DeepEquals, &StagedResource{
 		base:   s.base,
@@ -237,11 +247,16 @@ func (s *PersistenceSuite) TestSetResourceOkay(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 
 	s.stub.CheckCallNames(c,
-		"One",
 		"Run",
+		"One",
 		"RunTransaction",
 	)
 	s.stub.CheckCall(c, 2, "RunTransaction", []txn.Op{{
+		C:      "resources",
+		Id:     "resource#a-service/spam",
+		Assert: bson.D{{"storage-path", "service-a-service/resources/spam"}},
+		Remove: true,
+	}, {
 		C:      "resources",
 		Id:     "resource#a-service/spam",
 		Assert: txn.DocMissing,
@@ -258,14 +273,14 @@ func (s *PersistenceSuite) TestSetResourceNotFound(c *gc.C) {
 	p := NewPersistence(s.base)
 	notFound := errors.NewNotFound(nil, "")
 	ignoredErr := errors.New("<never reached>")
-	s.stub.SetErrors(notFound, nil, nil, ignoredErr)
+	s.stub.SetErrors(nil, notFound, nil, ignoredErr)
 
 	err := p.SetResource(res.Resource)
 	c.Assert(err, jc.ErrorIsNil)
 
 	s.stub.CheckCallNames(c,
-		"One",
 		"Run",
+		"One",
 		"RunTransaction",
 	)
 	s.stub.CheckCall(c, 2, "RunTransaction", []txn.Op{{
@@ -276,6 +291,58 @@ func (s *PersistenceSuite) TestSetResourceNotFound(c *gc.C) {
 	}})
 }
 
+func (s *PersistenceSuite) TestSetResourcePending(c *gc.C) {
+	res, doc := newResource(c, "a-service", "spam")
+	res.PendingID = "some-unique-ID"
+	doc.DocID += "#pending-some-unique-ID"
+	doc.PendingID = "some-unique-ID"
+	s.base.oneDocs = map[string]resourceDoc{doc.DocID: doc}
+	p := NewPersistence(s.base)
+	ignoredErr := errors.New("<never reached>")
+	s.stub.SetErrors(nil, nil, nil, ignoredErr)
+
+	err := p.SetResource(res.Resource)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.stub.CheckCallNames(c, "Run", "One", "RunTransaction")
+	c.Check(s.stub.Calls()[1].Args[1], gc.Equals, "resource#a-service/spam#pending-some-unique-ID")
+	s.stub.CheckCall(c, 2, "RunTransaction", []txn.Op{{
+		C:      "resources",
+		Id:     "resource#a-service/spam#pending-some-unique-ID",
+		Assert: bson.D{{"storage-path", "service-a-service/resources/spam"}},
+		Remove: true,
+	}, {
+		C:      "resources",
+		Id:     "resource#a-service/spam#pending-some-unique-ID",
+		Assert: txn.DocMissing,
+		Insert: &doc,
+	}})
+}
+
+func (s *PersistenceSuite) TestSetResourcePendingAlongsideActive(c *gc.C) {
+	res, doc := newResource(c, "a-service", "spam")
+	active := doc
+	res.PendingID = "some-unique-ID"
+	doc.DocID += "#pending-some-unique-ID"
+	doc.PendingID = "some-unique-ID"
+	doc.StoragePath = ""
+	s.base.oneDocs = map[string]resourceDoc{active.DocID: active}
+	p := NewPersistence(s.base)
+	ignoredErr := errors.New("<never reached>")
+	s.stub.SetErrors(nil, errors.NotFoundf(""), nil, ignoredErr)
+
+	err := p.SetResource(res.Resource)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.stub.CheckCallNames(c, "Run", "One", "RunTransaction")
+	s.stub.CheckCall(c, 2, "RunTransaction", []txn.Op{{
+		C:      "resources",
+		Id:     "resource#a-service/spam#pending-some-unique-ID",
+		Assert: txn.DocMissing,
+		Insert: &doc,
+	}})
+}
+
 func (s *PersistenceSuite) TestSetUnitResourceOkay(c *gc.C) {
 	servicename := "a-service"
 	unitname := "a-service/0"
@@ -363,24 +430,70 @@ func (s *PersistenceSuite) TestNewResourcePendingResourceOpsExists(c *gc.C) {
 	doc := expected // a copy
 	doc.DocID = pendingResourceID(stored.ID, pendingID)
 	doc.PendingID = pendingID
+	revisionDoc := expected // a copy
+	revisionDoc.DocID += "#revision-1"
+	revisionDoc.HistoryNumber = 1
 	s.base.ReturnOne = doc
 	p := NewPersistence(s.base)
 
 	ops, err := p.NewResolvePendingResourceOps(stored.ID, stored.PendingID)
 	c.Assert(err, jc.ErrorIsNil)
 
-	s.stub.CheckCallNames(c, "One", "One")
+	s.stub.CheckCallNames(c, "One", "One", "All")
 	s.stub.CheckCall(c, 0, "One", "resources", "resource#a-service/spam#pending-some-unique-ID-001", &doc)
 	c.Check(ops, jc.DeepEquals, []txn.Op{{
 		C:      "resources",
 		Id:     doc.DocID,
 		Assert: txn.DocExists,
 		Remove: true,
+	}, {
+		C:      "resources",
+		Id:     revisionDoc.DocID,
+		Assert: txn.DocMissing,
+		Insert: &revisionDoc,
+	}, {
+		C:      "resources",
+		Id:     expected.DocID,
+		Assert: bson.D{{"storage-path", "service-a-service/resources/spam"}},
+		Remove: true,
 	}, {
 		C:      "resources",
 		Id:     expected.DocID,
+		Assert: txn.DocMissing,
+		Insert: &expected,
+	}})
+}
+
+func (s *PersistenceSuite) TestNewResourcePendingResourceOpsNotFound(c *gc.C) {
+	pendingID := "some-unique-ID-001"
+	stored, expected := newResource(c, "a-service", "spam")
+	stored.PendingID = pendingID
+	doc := expected // a copy
+	doc.DocID = pendingResourceID(stored.ID, pendingID)
+	doc.PendingID = pendingID
+	revisionDoc := expected // a copy
+	revisionDoc.DocID += "#revision-1"
+	revisionDoc.HistoryNumber = 1
+	s.base.ReturnOne = doc
+	notFound := errors.NewNotFound(nil, "")
+	s.stub.SetErrors(nil, notFound)
+	p := NewPersistence(s.base)
+
+	ops, err := p.NewResolvePendingResourceOps(stored.ID, stored.PendingID)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.stub.CheckCallNames(c, "One", "One", "All", "One")
+	s.stub.CheckCall(c, 0, "One", "resources", "resource#a-service/spam#pending-some-unique-ID-001", &doc)
+	c.Check(ops, jc.DeepEquals, []txn.Op{{
+		C:      "resources",
+		Id:     doc.DocID,
 		Assert: txn.DocExists,
 		Remove: true,
+	}, {
+		C:      "resources",
+		Id:     revisionDoc.DocID,
+		Assert: txn.DocMissing,
+		Insert: &revisionDoc,
 	}, {
 		C:      "resources",
 		Id:     expected.DocID,
@@ -389,10 +502,12 @@ func (s *PersistenceSuite) TestNewResourcePendingResourceOpsExists(c *gc.C) {
 	}})
 }
 
-func (s *PersistenceSuite) TestNewResourcePendingResourceOpsNotFound(c *gc.C) {
+func (s *PersistenceSuite) TestNewResourcePendingResourceOpsPlaceholder(c *gc.C) {
 	pendingID := "some-unique-ID-001"
 	stored, expected := newResource(c, "a-service", "spam")
 	stored.PendingID = pendingID
+	expected.Username = ""
+	expected.Timestamp = time.Time{}
 	doc := expected // a copy
 	doc.DocID = pendingResourceID(stored.ID, pendingID)
 	doc.PendingID = pendingID
@@ -405,7 +520,6 @@ func (s *PersistenceSuite) TestNewResourcePendingResourceOpsNotFound(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 
 	s.stub.CheckCallNames(c, "One", "One")
-	s.stub.CheckCall(c, 0, "One", "resources", "resource#a-service/spam#pending-some-unique-ID-001", &doc)
 	c.Check(ops, jc.DeepEquals, []txn.Op{{
 		C:      "resources",
 		Id:     doc.DocID,
@@ -419,6 +533,216 @@ func (s *PersistenceSuite) TestNewResourcePendingResourceOpsNotFound(c *gc.C) {
 	}})
 }
 
+func (s *PersistenceSuite) TestListResourcesIgnoresRevisions(c *gc.C) {
+	expected, docs := newResources(c, "a-service", "spam")
+	revisionDoc := newRevisionDocFor(docs[0], 1, "service-a-service/resources/spam-1")
+	docs = append(docs, revisionDoc)
+	s.base.docs = docs
+
+	p := NewPersistence(s.base)
+	resources, err := p.ListResources("a-service")
+	c.Assert(err, jc.ErrorIsNil)
+
+	checkResources(c, resources, expected)
+}
+
+func (s *PersistenceSuite) TestListResourceRevisions(c *gc.C) {
+	_, docs := newResources(c, "a-service", "spam", "eggs")
+	docs[0].StoragePath = "service-a-service/resources/spam-2"
+	docs = append(docs,
+		newRevisionDocFor(docs[0], 2, "service-a-service/resources/spam-2"),
+		newRevisionDocFor(docs[0], 1, "service-a-service/resources/spam-1"),
+		newRevisionDocFor(docs[1], 1, "service-a-service/resources/eggs-1"),
+	)
+	s.base.docs = docs
+
+	p := NewPersistence(s.base)
+	revisions, err := p.ListResourceRevisions("a-service")
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.stub.CheckCallNames(c, "All")
+	var summary []string
+	for _, rev := range revisions {
+		summary = append(summary, fmt.Sprintf("%s %d %v", rev.Name, rev.Number, rev.Current))
+	}
+	c.Check(summary, jc.DeepEquals, []string{
+		"eggs 1 false",
+		"spam 1 false",
+		"spam 2 true",
+	})
+}
+
+func (s *PersistenceSuite) TestSetResourceRevision(c *gc.C) {
+	stored, current := newResource(c, "a-service", "spam")
+	revisionDoc := newRevisionDocFor(current, 1, "service-a-service/resources/spam-1")
+	s.base.oneDocs = map[string]resourceDoc{
+		current.DocID:     current,
+		revisionDoc.DocID: revisionDoc,
+	}
+	s.base.blobs = []resourceBlobDoc{
+		newBlobDoc("service-a-service/resources/spam", 2),
+		newBlobDoc("service-a-service/resources/spam-1", 1),
+	}
+	expected := current
+	expected.StoragePath = revisionDoc.StoragePath
+	ignoredErr := errors.New("<never reached>")
+	s.stub.SetErrors(nil, nil, nil, nil, nil, nil, ignoredErr)
+	p := NewPersistence(s.base)
+
+	res, err := p.SetResourceRevision(stored.ID, 1)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(res, jc.DeepEquals, stored.Resource)
+	s.stub.CheckCallNames(c, "One", "Run", "One", "One", "One", "RunTransaction")
+	s.stub.CheckCall(c, 0, "One", "resources", "resource#a-service/spam#revision-1", &revisionDoc)
+	s.stub.CheckCall(c, 5, "RunTransaction", []txn.Op{{
+		C:      "resources",
+		Id:     "resource#a-service/spam#revision-1",
+		Assert: txn.DocExists,
+	}, {
+		C:      "resources",
+		Id:     "resource#a-service/spam",
+		Assert: bson.D{{"storage-path", "service-a-service/resources/spam"}},
+		Remove: true,
+	}, {
+		C:      "resources",
+		Id:     "resource#a-service/spam",
+		Assert: txn.DocMissing,
+		Insert: &expected,
+	}, {
+		C:      "resourceblobs",
+		Id:     "service-a-service/resources/spam",
+		Assert: bson.D{{"refcount", bson.D{{"$gte", 1}}}},
+		Update: bson.D{{"$inc", bson.D{{"refcount", -1}}}},
+	}, {
+		C:      "resourceblobs",
+		Id:     "service-a-service/resources/spam-1",
+		Assert: bson.D{{"removing", false}},
+		Update: bson.D{{"$inc", bson.D{{"refcount", 1}}}},
+	}})
+}
+
+func (s *PersistenceSuite) TestSetResourceRevisionAlreadyCurrent(c *gc.C) {
+	stored, current := newResource(c, "a-service", "spam")
+	revisionDoc := newRevisionDocFor(current, 1, current.StoragePath)
+	s.base.oneDocs = map[string]resourceDoc{
+		current.DocID:     current,
+		revisionDoc.DocID: revisionDoc,
+	}
+	p := NewPersistence(s.base)
+
+	_, err := p.SetResourceRevision(stored.ID, 1)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.stub.CheckCallNames(c, "One", "Run", "One")
+}
+
+func (s *PersistenceSuite) TestSetResourceRevisionNotFound(c *gc.C) {
+	s.stub.SetErrors(errors.NotFoundf(""))
+	p := NewPersistence(s.base)
+
+	_, err := p.SetResourceRevision("a-service/spam", 3)
+
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+	c.Check(err, gc.ErrorMatches, `resource "a-service/spam" revision 3 not found`)
+	s.stub.CheckCallNames(c, "One")
+}
+
+func (s *PersistenceSuite) TestPruneResourceRevisions(c *gc.C) {
+	_, current := newResource(c, "a-service", "spam")
+	// The active revision is an old one, which is kept.
+	current.StoragePath = "service-a-service/resources/spam-1"
+	s.base.ReturnOne = current
+	s.base.docs = []resourceDoc{
+		newRevisionDocFor(current, 1, "service-a-service/resources/spam-1"),
+		newRevisionDocFor(current, 2, "service-a-service/resources/spam-2"),
+		newRevisionDocFor(current, 3, "service-a-service/resources/spam-3"),
+		newRevisionDocFor(current, 4, "service-a-service/resources/spam-4"),
+	}
+	s.base.blobs = []resourceBlobDoc{
+		newBlobDoc("service-a-service/resources/spam-2", 1),
+	}
+	ignoredErr := errors.New("<never reached>")
+	s.stub.SetErrors(nil, nil, nil, nil, nil, ignoredErr)
+	p := NewPersistence(s.base)
+
+	err := p.PruneResourceRevisions("a-service/spam", 2)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.stub.CheckCallNames(c, "Run", "All", "One", "One", "RunTransaction")
+	s.stub.CheckCall(c, 4, "RunTransaction", []txn.Op{{
+		C:      "resources",
+		Id:     "resource#a-service/spam#revision-2",
+		Assert: txn.DocExists,
+		Remove: true,
+	}, {
+		C:      "resourceblobs",
+		Id:     "service-a-service/resources/spam-2",
+		Assert: bson.D{{"refcount", bson.D{{"$gte", 1}}}},
+		Update: bson.D{{"$inc", bson.D{{"refcount", -1}}}},
+	}})
+}
+
+func (s *PersistenceSuite) TestPruneResourceRevisionsNothingToDo(c *gc.C) {
+	_, current := newResource(c, "a-service", "spam")
+	s.base.docs = []resourceDoc{
+		newRevisionDocFor(current, 1, "service-a-service/resources/spam-1"),
+	}
+	p := NewPersistence(s.base)
+
+	err := p.PruneResourceRevisions("a-service/spam", 1)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.stub.CheckCallNames(c, "Run", "All")
+}
+
+func (s *PersistenceSuite) TestNewRemoveResourcesOps(c *gc.C) {
+	_, current := newResource(c, "a-service", "spam")
+	_, unitDoc := newUnitResource(c, "a-service", "a-service/0", "spam")
+	s.base.docs = []resourceDoc{
+		current,
+		unitDoc,
+		newRevisionDocFor(current, 1, current.StoragePath),
+	}
+	s.base.blobs = []resourceBlobDoc{
+		newBlobDoc("service-a-service/resources/spam", 2),
+	}
+	p := NewPersistence(s.base)
+
+	ops, err := p.NewRemoveResourcesOps("a-service")
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.stub.CheckCallNames(c, "All", "One")
+	c.Check(ops, jc.DeepEquals, []txn.Op{{
+		C:      "resources",
+		Id:     "resource#a-service/spam",
+		Assert: txn.DocExists,
+		Remove: true,
+	}, {
+		C:      "resources",
+		Id:     "resource#a-service/spam#unit-a-service/0",
+		Assert: txn.DocExists,
+		Remove: true,
+	}, {
+		C:      "resources",
+		Id:     "resource#a-service/spam#revision-1",
+		Assert: txn.DocExists,
+		Remove: true,
+	}, {
+		C:      "resourceblobs",
+		Id:     "service-a-service/resources/spam",
+		Assert: bson.D{{"refcount", bson.D{{"$gte", 2}}}},
+		Update: bson.D{{"$inc", bson.D{{"refcount", -2}}}},
+	}})
+}
+
+func newRevisionDocFor(doc resourceDoc, number int, storagePath string) resourceDoc {
+	doc.DocID = revisionResourceID(doc.ID, number)
+	doc.HistoryNumber = number
+	doc.StoragePath = storagePath
+	return doc
+}
+
 func newResources(c *gc.C, serviceID string, names ...string) (resource.ServiceResources, []resourceDoc) {
 	var resources []resource.Resource
 	var docs []resourceDoc
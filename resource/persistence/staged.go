@@ -5,6 +5,7 @@ package persistence
 
 import (
 	"github.com/juju/errors"
+	jujutxn "github.com/juju/txn"
 	"gopkg.in/mgo.v2/txn"
 )
 
@@ -28,11 +29,16 @@ func (staged StagedResource) stage() error {
 			ops = newStagedResourceOps(staged.stored)
 		case 1:
 			ops = newEnsureStagedSameOps(staged.stored)
+			return ops, nil
 		default:
 			return nil, errors.NewAlreadyExists(nil, "already staged")
 		}
 
-		return ops, nil
+		blobOps, err := newStageBlobOps(staged.base, staged.stored.storagePath)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return append(ops, blobOps...), nil
 	}
 	if err := staged.base.Run(buildTxn); err != nil {
 		return errors.Trace(err)
@@ -42,16 +48,27 @@ func (staged StagedResource) stage() error {
 
 // Unstage ensures that the resource is removed
 // from the staging area. If it isn't in the staging area
-// then this is a noop.
+// then this is a noop. The staged content is orphaned if
+// nothing else refers to it.
 func (staged StagedResource) Unstage() error {
 	buildTxn := func(attempt int) ([]txn.Op, error) {
-		if attempt > 0 {
-			// The op has no assert so we should not get here.
-			return nil, errors.New("unstaging the resource failed")
+		var doc resourceDoc
+		err := staged.base.One(resourcesC, stagedID(staged.id), &doc)
+		if errors.IsNotFound(err) {
+			return nil, jujutxn.ErrNoOperations
+		}
+		if err != nil {
+			return nil, errors.Trace(err)
 		}
 
 		ops := newRemoveStagedOps(staged.id)
-		return ops, nil
+		refs := blobRefs{}
+		refs.remove(doc.StoragePath)
+		refOps, err := refs.ops(staged.base)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return append(ops, refOps...), nil
 	}
 	if err := staged.base.Run(buildTxn); err != nil {
 		return errors.Trace(err)
@@ -59,24 +76,51 @@ func (staged StagedResource) Unstage() error {
 	return nil
 }
 
-// Activate makes the staged resource the active resource.
+// Activate makes the staged resource the active resource. If the
+// resource's revisions are retained then it is also recorded as the
+// newest revision in the resource's history.
 func (staged StagedResource) Activate() error {
 	// TODO(ericsnow) Ensure that the service is still there?
 
 	buildTxn := func(attempt int) ([]txn.Op, error) {
-		// This is an "upsert".
+		storagePath := staged.stored.storagePath
+		refs := blobRefs{}
+
+		// This is an "upsert". A pending resource replaces only the
+		// pending doc, leaving any active resource alone.
 		var ops []txn.Op
-		switch attempt {
-		case 0:
+		var current resourceDoc
+		docID := newResourceDoc(staged.stored).DocID
+		err := staged.base.One(resourcesC, docID, &current)
+		switch {
+		case errors.IsNotFound(err):
 			ops = newInsertResourceOps(staged.stored)
-		case 1:
-			ops = newUpdateResourceOps(staged.stored)
+		case err != nil:
+			return nil, errors.Trace(err)
 		default:
-			return nil, errors.New("setting the resource failed")
+			ops = newUpdateResourceOps(staged.stored, current.StoragePath)
+			refs.remove(current.StoragePath)
 		}
+		refs.add(storagePath)
+
 		// No matter what, we always remove any staging.
 		ops = append(ops, newRemoveStagedOps(staged.id)...)
-		return ops, nil
+		refs.remove(storagePath)
+
+		if isRetainedRevision(staged.stored.Resource) {
+			revision, err := nextRevision(staged.base, staged.id)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			ops = append(ops, newInsertRevisionOps(staged.stored, revision)...)
+			refs.add(storagePath)
+		}
+
+		refOps, err := refs.ops(staged.base)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return append(ops, refOps...), nil
 	}
 	if err := staged.base.Run(buildTxn); err != nil {
 		return errors.Trace(err)
@@ -4,10 +4,13 @@
 package persistence
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
+	"gopkg.in/mgo.v2/bson"
 	"gopkg.in/mgo.v2/txn"
 )
 
@@ -45,37 +48,78 @@ func (s *StagedResourceSuite) TestStageOkay(c *gc.C) {
 	staged, doc := s.newStagedResource(c, "a-service", "spam")
 	doc.DocID += "#staged"
 	ignoredErr := errors.New("<never reached>")
-	s.stub.SetErrors(nil, nil, ignoredErr)
+	s.stub.SetErrors(nil, nil, nil, ignoredErr)
 
 	err := staged.stage()
 	c.Assert(err, jc.ErrorIsNil)
 
-	s.stub.CheckCallNames(c, "Run", "RunTransaction")
-	s.stub.CheckCall(c, 1, "RunTransaction", []txn.Op{{
+	s.stub.CheckCallNames(c, "Run", "One", "RunTransaction")
+	s.stub.CheckCall(c, 1, "One", "resourceblobs", "service-a-service/resources/spam", &resourceBlobDoc{})
+	s.stub.CheckCall(c, 2, "RunTransaction", []txn.Op{{
 		C:      "resources",
 		Id:     "resource#a-service/spam#staged",
 		Assert: txn.DocMissing,
 		Insert: &doc,
+	}, {
+		C:      "resourceblobs",
+		Id:     "service-a-service/resources/spam",
+		Assert: txn.DocMissing,
+		Insert: &resourceBlobDoc{
+			DocID:       "service-a-service/resources/spam",
+			StoragePath: "service-a-service/resources/spam",
+			RefCount:    1,
+		},
 	}})
 }
 
-func (s *StagedResourceSuite) TestStageExists(c *gc.C) {
+func (s *StagedResourceSuite) TestStageSharedContent(c *gc.C) {
 	staged, doc := s.newStagedResource(c, "a-service", "spam")
 	doc.DocID += "#staged"
+	s.base.blobs = []resourceBlobDoc{newBlobDoc("service-a-service/resources/spam", 2)}
 	ignoredErr := errors.New("<never reached>")
-	s.stub.SetErrors(nil, txn.ErrAborted, nil, ignoredErr)
+	s.stub.SetErrors(nil, nil, nil, ignoredErr)
 
 	err := staged.stage()
 	c.Assert(err, jc.ErrorIsNil)
 
-	s.stub.CheckCallNames(c, "Run", "RunTransaction", "RunTransaction")
-	s.stub.CheckCall(c, 1, "RunTransaction", []txn.Op{{
+	s.stub.CheckCallNames(c, "Run", "One", "RunTransaction")
+	s.stub.CheckCall(c, 2, "RunTransaction", []txn.Op{{
 		C:      "resources",
 		Id:     "resource#a-service/spam#staged",
 		Assert: txn.DocMissing,
 		Insert: &doc,
+	}, {
+		C:      "resourceblobs",
+		Id:     "service-a-service/resources/spam",
+		Assert: bson.D{{"removing", false}},
+		Update: bson.D{{"$inc", bson.D{{"refcount", 1}}}},
 	}})
-	s.stub.CheckCall(c, 2, "RunTransaction", []txn.Op{{
+}
+
+func (s *StagedResourceSuite) TestStageContentBeingRemoved(c *gc.C) {
+	staged, _ := s.newStagedResource(c, "a-service", "spam")
+	blob := newBlobDoc("service-a-service/resources/spam", 0)
+	blob.Removing = true
+	s.base.blobs = []resourceBlobDoc{blob}
+	s.stub.SetErrors()
+
+	err := staged.stage()
+
+	c.Check(err, gc.ErrorMatches, `resource content "service-a-service/resources/spam" is being removed; try again`)
+	s.stub.CheckCallNames(c, "Run", "One")
+}
+
+func (s *StagedResourceSuite) TestStageExists(c *gc.C) {
+	staged, doc := s.newStagedResource(c, "a-service", "spam")
+	doc.DocID += "#staged"
+	ignoredErr := errors.New("<never reached>")
+	s.stub.SetErrors(nil, nil, txn.ErrAborted, nil, ignoredErr)
+
+	err := staged.stage()
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.stub.CheckCallNames(c, "Run", "One", "RunTransaction", "RunTransaction")
+	s.stub.CheckCall(c, 3, "RunTransaction", []txn.Op{{
 		C:      "resources",
 		Id:     "resource#a-service/spam#staged",
 		Assert: &doc,
@@ -83,31 +127,55 @@ func (s *StagedResourceSuite) TestStageExists(c *gc.C) {
 }
 
 func (s *StagedResourceSuite) TestUnstageOkay(c *gc.C) {
-	staged, _ := s.newStagedResource(c, "a-service", "spam")
+	staged, doc := s.newStagedResource(c, "a-service", "spam")
+	doc.DocID += "#staged"
+	s.base.ReturnOne = doc
+	s.base.blobs = []resourceBlobDoc{newBlobDoc("service-a-service/resources/spam", 1)}
 	ignoredErr := errors.New("<never reached>")
-	s.stub.SetErrors(nil, nil, ignoredErr)
+	s.stub.SetErrors(nil, nil, nil, nil, ignoredErr)
 
 	err := staged.Unstage()
 	c.Assert(err, jc.ErrorIsNil)
 
-	s.stub.CheckCallNames(c, "Run", "RunTransaction")
-	s.stub.CheckCall(c, 1, "RunTransaction", []txn.Op{{
+	s.stub.CheckCallNames(c, "Run", "One", "One", "RunTransaction")
+	s.stub.CheckCall(c, 1, "One", "resources", "resource#a-service/spam#staged", &doc)
+	s.stub.CheckCall(c, 3, "RunTransaction", []txn.Op{{
 		C:      "resources",
 		Id:     "resource#a-service/spam#staged",
+		Assert: txn.DocExists,
 		Remove: true,
+	}, {
+		C:      "resourceblobs",
+		Id:     "service-a-service/resources/spam",
+		Assert: bson.D{{"refcount", bson.D{{"$gte", 1}}}},
+		Update: bson.D{{"$inc", bson.D{{"refcount", -1}}}},
 	}})
 }
 
+func (s *StagedResourceSuite) TestUnstageNotStaged(c *gc.C) {
+	staged, _ := s.newStagedResource(c, "a-service", "spam")
+	s.stub.SetErrors(nil, errors.NotFoundf(""))
+
+	err := staged.Unstage()
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.stub.CheckCallNames(c, "Run", "One")
+}
+
 func (s *StagedResourceSuite) TestActivateOkay(c *gc.C) {
 	staged, doc := s.newStagedResource(c, "a-service", "spam")
+	revisionDoc := doc
+	revisionDoc.DocID += "#revision-1"
+	revisionDoc.HistoryNumber = 1
+	s.base.blobs = []resourceBlobDoc{newBlobDoc("service-a-service/resources/spam", 1)}
 	ignoredErr := errors.New("<never reached>")
-	s.stub.SetErrors(nil, nil, ignoredErr)
+	s.stub.SetErrors(nil, errors.NotFoundf(""), nil, nil, nil, ignoredErr)
 
 	err := staged.Activate()
 	c.Assert(err, jc.ErrorIsNil)
 
-	s.stub.CheckCallNames(c, "Run", "RunTransaction")
-	s.stub.CheckCall(c, 1, "RunTransaction", []txn.Op{{
+	s.stub.CheckCallNames(c, "Run", "One", "All", "One", "RunTransaction")
+	s.stub.CheckCall(c, 4, "RunTransaction", []txn.Op{{
 		C:      "resources",
 		Id:     "resource#a-service/spam",
 		Assert: txn.DocMissing,
@@ -115,20 +183,63 @@ func (s *StagedResourceSuite) TestActivateOkay(c *gc.C) {
 	}, {
 		C:      "resources",
 		Id:     "resource#a-service/spam#staged",
+		Assert: txn.DocExists,
 		Remove: true,
+	}, {
+		C:      "resources",
+		Id:     "resource#a-service/spam#revision-1",
+		Assert: txn.DocMissing,
+		Insert: &revisionDoc,
+	}, {
+		C:      "resourceblobs",
+		Id:     "service-a-service/resources/spam",
+		Assert: bson.D{{"removing", false}},
+		Update: bson.D{{"$inc", bson.D{{"refcount", 1}}}},
 	}})
 }
 
-func (s *StagedResourceSuite) TestActivateExists(c *gc.C) {
+func (s *StagedResourceSuite) TestActivateNextRevision(c *gc.C) {
 	staged, doc := s.newStagedResource(c, "a-service", "spam")
+	older := doc
+	older.HistoryNumber = 2
+	s.base.docs = []resourceDoc{older}
+	revisionDoc := doc
+	revisionDoc.DocID += "#revision-3"
+	revisionDoc.HistoryNumber = 3
 	ignoredErr := errors.New("<never reached>")
-	s.stub.SetErrors(nil, txn.ErrAborted, nil, ignoredErr)
+	s.stub.SetErrors(nil, errors.NotFoundf(""), nil, nil, nil, ignoredErr)
 
 	err := staged.Activate()
 	c.Assert(err, jc.ErrorIsNil)
 
-	s.stub.CheckCallNames(c, "Run", "RunTransaction", "RunTransaction")
-	s.stub.CheckCall(c, 1, "RunTransaction", []txn.Op{{
+	s.stub.CheckCallNames(c, "Run", "One", "All", "One", "RunTransaction")
+	s.stub.CheckCall(c, 2, "All", "resources", bson.D{
+		{"resource-id", "a-service/spam"},
+		{"history-number", bson.D{{"$gt", 0}}},
+	}, &[]resourceDoc{older})
+	ops := s.stub.Calls()[4].Args[0].([]txn.Op)
+	c.Check(ops[2], jc.DeepEquals, txn.Op{
+		C:      "resources",
+		Id:     "resource#a-service/spam#revision-3",
+		Assert: txn.DocMissing,
+		Insert: &revisionDoc,
+	})
+}
+
+func (s *StagedResourceSuite) TestActivatePlaceholder(c *gc.C) {
+	staged, doc := s.newStagedResource(c, "a-service", "spam")
+	staged.stored.Timestamp = time.Time{}
+	staged.stored.Username = ""
+	doc.Timestamp = time.Time{}
+	doc.Username = ""
+	ignoredErr := errors.New("<never reached>")
+	s.stub.SetErrors(nil, errors.NotFoundf(""), nil, ignoredErr)
+
+	err := staged.Activate()
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.stub.CheckCallNames(c, "Run", "One", "RunTransaction")
+	s.stub.CheckCall(c, 2, "RunTransaction", []txn.Op{{
 		C:      "resources",
 		Id:     "resource#a-service/spam",
 		Assert: txn.DocMissing,
@@ -136,12 +247,34 @@ func (s *StagedResourceSuite) TestActivateExists(c *gc.C) {
 	}, {
 		C:      "resources",
 		Id:     "resource#a-service/spam#staged",
+		Assert: txn.DocExists,
 		Remove: true,
 	}})
-	s.stub.CheckCall(c, 2, "RunTransaction", []txn.Op{{
+}
+
+func (s *StagedResourceSuite) TestActivateExists(c *gc.C) {
+	staged, doc := s.newStagedResource(c, "a-service", "spam")
+	current := doc
+	current.StoragePath = "service-a-service/resources/spam-old"
+	s.base.ReturnOne = current
+	s.base.blobs = []resourceBlobDoc{
+		newBlobDoc("service-a-service/resources/spam", 1),
+		newBlobDoc("service-a-service/resources/spam-old", 2),
+	}
+	revisionDoc := doc
+	revisionDoc.DocID += "#revision-1"
+	revisionDoc.HistoryNumber = 1
+	ignoredErr := errors.New("<never reached>")
+	s.stub.SetErrors(nil, nil, nil, nil, nil, nil, ignoredErr)
+
+	err := staged.Activate()
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.stub.CheckCallNames(c, "Run", "One", "All", "One", "One", "RunTransaction")
+	s.stub.CheckCall(c, 5, "RunTransaction", []txn.Op{{
 		C:      "resources",
 		Id:     "resource#a-service/spam",
-		Assert: txn.DocExists,
+		Assert: bson.D{{"storage-path", "service-a-service/resources/spam-old"}},
 		Remove: true,
 	}, {
 		C:      "resources",
@@ -151,6 +284,102 @@ func (s *StagedResourceSuite) TestActivateExists(c *gc.C) {
 	}, {
 		C:      "resources",
 		Id:     "resource#a-service/spam#staged",
+		Assert: txn.DocExists,
+		Remove: true,
+	}, {
+		C:      "resources",
+		Id:     "resource#a-service/spam#revision-1",
+		Assert: txn.DocMissing,
+		Insert: &revisionDoc,
+	}, {
+		C:      "resourceblobs",
+		Id:     "service-a-service/resources/spam",
+		Assert: bson.D{{"removing", false}},
+		Update: bson.D{{"$inc", bson.D{{"refcount", 1}}}},
+	}, {
+		C:      "resourceblobs",
+		Id:     "service-a-service/resources/spam-old",
+		Assert: bson.D{{"refcount", bson.D{{"$gte", 1}}}},
+		Update: bson.D{{"$inc", bson.D{{"refcount", -1}}}},
+	}})
+}
+
+func (s *StagedResourceSuite) newPendingStagedResource(c *gc.C) (*StagedResource, resourceDoc) {
+	staged, doc := s.newStagedResource(c, "a-service", "spam")
+	staged.stored.PendingID = "some-unique-ID"
+	doc.DocID += "#pending-some-unique-ID"
+	doc.PendingID = "some-unique-ID"
+	return staged, doc
+}
+
+func (s *StagedResourceSuite) TestActivatePendingExists(c *gc.C) {
+	staged, doc := s.newPendingStagedResource(c)
+	current := doc
+	current.StoragePath = "service-a-service/resources/spam-old"
+	s.base.oneDocs = map[string]resourceDoc{current.DocID: current}
+	s.base.blobs = []resourceBlobDoc{
+		newBlobDoc("service-a-service/resources/spam", 1),
+		newBlobDoc("service-a-service/resources/spam-old", 1),
+	}
+	ignoredErr := errors.New("<never reached>")
+	s.stub.SetErrors(nil, nil, nil, nil, ignoredErr)
+
+	err := staged.Activate()
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.stub.CheckCallNames(c, "Run", "One", "One", "RunTransaction")
+	c.Check(s.stub.Calls()[1].Args[1], gc.Equals, "resource#a-service/spam#pending-some-unique-ID")
+	s.stub.CheckCall(c, 3, "RunTransaction", []txn.Op{{
+		C:      "resources",
+		Id:     "resource#a-service/spam#pending-some-unique-ID",
+		Assert: bson.D{{"storage-path", "service-a-service/resources/spam-old"}},
+		Remove: true,
+	}, {
+		C:      "resources",
+		Id:     "resource#a-service/spam#pending-some-unique-ID",
+		Assert: txn.DocMissing,
+		Insert: &doc,
+	}, {
+		C:      "resources",
+		Id:     "resource#a-service/spam#staged",
+		Assert: txn.DocExists,
+		Remove: true,
+	}, {
+		C:      "resourceblobs",
+		Id:     "service-a-service/resources/spam-old",
+		Assert: bson.D{{"refcount", bson.D{{"$gte", 1}}}},
+		Update: bson.D{{"$inc", bson.D{{"refcount", -1}}}},
+	}})
+}
+
+func (s *StagedResourceSuite) TestActivatePendingAlongsideActive(c *gc.C) {
+	staged, doc := s.newPendingStagedResource(c)
+	_, active := newResource(c, "a-service", "spam")
+	active.StoragePath = "service-a-service/resources/spam-active"
+	s.base.oneDocs = map[string]resourceDoc{active.DocID: active}
+	s.base.blobs = []resourceBlobDoc{
+		newBlobDoc("service-a-service/resources/spam", 1),
+		newBlobDoc("service-a-service/resources/spam-active", 1),
+	}
+	ignoredErr := errors.New("<never reached>")
+	s.stub.SetErrors(nil, errors.NotFoundf(""), nil, ignoredErr)
+
+	err := staged.Activate()
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The active resource, and the refcount of its content, are left
+	// alone.
+	s.stub.CheckCallNames(c, "Run", "One", "RunTransaction")
+	c.Check(s.stub.Calls()[1].Args[1], gc.Equals, "resource#a-service/spam#pending-some-unique-ID")
+	s.stub.CheckCall(c, 2, "RunTransaction", []txn.Op{{
+		C:      "resources",
+		Id:     "resource#a-service/spam#pending-some-unique-ID",
+		Assert: txn.DocMissing,
+		Insert: &doc,
+	}, {
+		C:      "resources",
+		Id:     "resource#a-service/spam#staged",
+		Assert: txn.DocExists,
 		Remove: true,
 	}})
 }
@@ -15,6 +15,13 @@ type stubStatePersistence struct {
 
 	docs      []resourceDoc
 	ReturnOne resourceDoc
+
+	// oneDocs, if set, are the resource docs that One finds by ID in
+	// place of ReturnOne.
+	oneDocs map[string]resourceDoc
+
+	// blobs are the blob docs, which One finds by ID.
+	blobs []resourceBlobDoc
 }
 
 func (s stubStatePersistence) One(collName, id string, doc interface{}) error {
@@ -23,9 +30,23 @@ func (s stubStatePersistence) One(collName, id string, doc interface{}) error {
 		return errors.Trace(err)
 	}
 
-	actual := doc.(*resourceDoc)
-	*actual = s.ReturnOne
-	return nil
+	switch actual := doc.(type) {
+	case *resourceBlobDoc:
+		for _, blob := range s.blobs {
+			if blob.DocID == id {
+				*actual = blob
+				return nil
+			}
+		}
+		return errors.NotFoundf("blob %q", id)
+	default:
+		if found, ok := s.oneDocs[id]; ok {
+			*doc.(*resourceDoc) = found
+			return nil
+		}
+		*doc.(*resourceDoc) = s.ReturnOne
+		return nil
+	}
 }
 
 func (s stubStatePersistence) All(collName string, query, docs interface{}) error {
@@ -34,8 +55,12 @@ func (s stubStatePersistence) All(collName string, query, docs interface{}) erro
 		return errors.Trace(err)
 	}
 
-	actual := docs.(*[]resourceDoc)
-	*actual = s.docs
+	switch actual := docs.(type) {
+	case *[]resourceBlobDoc:
+		*actual = s.blobs
+	default:
+		*docs.(*[]resourceDoc) = s.docs
+	}
 	return nil
 }
 
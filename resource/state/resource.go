@@ -47,6 +47,34 @@ type resourcePersistence interface {
 	// NewResolvePendingResourceOps generates mongo transaction operations
 	// to set the identified resource as active.
 	NewResolvePendingResourceOps(resID, pendingID string) ([]txn.Op, error)
+
+	// ListResourceRevisions returns the retained revisions of each of
+	// the identified service's resources.
+	ListResourceRevisions(serviceID string) ([]resource.ResourceRevision, error)
+
+	// SetResourceRevision makes the numbered revision of the
+	// identified resource the active one, and returns it.
+	SetResourceRevision(id string, number int) (resource.Resource, error)
+
+	// PruneResourceRevisions removes the records of all but the given
+	// number of the newest revisions of the identified resource.
+	PruneResourceRevisions(id string, keep int) error
+
+	// NewRemoveResourcesOps generates mongo transaction operations to
+	// remove all the identified service's resources.
+	NewRemoveResourcesOps(serviceID string) ([]txn.Op, error)
+
+	// MarkOrphanedBlobs marks the content that no resource refers to
+	// any more as being removed, and returns its storage paths.
+	MarkOrphanedBlobs() ([]string, error)
+
+	// RemoveBlobs removes the records of the identified content, which
+	// must have been marked by MarkOrphanedBlobs.
+	RemoveBlobs(storagePaths []string) error
+
+	// AddMissingBlobs starts counting the references to the content
+	// stored before reference counting was introduced.
+	AddMissingBlobs() error
 }
 
 // StagedResource represents resource info that has been added to the
@@ -84,6 +112,7 @@ type resourceState struct {
 
 	newPendingID     func() (string, error)
 	currentTimestamp func() time.Time
+	historyLimit     func() (int, error)
 }
 
 // ListResources returns the resource data for the given service ID.
@@ -201,7 +230,9 @@ func (st resourceState) storeResource(res resource.Resource, r io.Reader) error
 	// is stored separately and adding to both should be an atomic
 	// operation.
 
-	storagePath := storagePath(res.Name, res.ServiceID, res.PendingID)
+	// Content is stored by fingerprint, so any resources (or
+	// retained revisions) with the same content share it.
+	storagePath := contentStoragePath(res.Fingerprint)
 	staged, err := st.persist.StageResource(res, storagePath)
 	if err != nil {
 		return errors.Trace(err)
@@ -216,18 +247,94 @@ func (st resourceState) storeResource(res resource.Resource, r io.Reader) error
 	}
 
 	if err := staged.Activate(); err != nil {
-		if err := st.storage.Remove(storagePath); err != nil {
-			logger.Errorf("could not remove resource %q (service %q) from storage: %v", res.Name, res.ServiceID, err)
-		}
+		// Other resources may share the content, so it is left for
+		// RemoveOrphanedContent to remove once unstaged.
 		if err := staged.Unstage(); err != nil {
 			logger.Errorf("could not unstage resource %q (service %q): %v", res.Name, res.ServiceID, err)
 		}
 		return errors.Trace(err)
 	}
 
+	if res.PendingID == "" {
+		// The upload has succeeded even if pruning fails; the old
+		// revisions will be pruned after the next one.
+		if err := st.pruneRevisions(res); err != nil {
+			logger.Errorf("could not prune revisions of resource %q (service %q): %v", res.Name, res.ServiceID, err)
+		}
+	}
 	return nil
 }
 
+// pruneRevisions removes the revisions of the resource beyond the
+// model's limit. Their content is removed by RemoveOrphanedContent
+// once nothing else refers to it.
+func (st resourceState) pruneRevisions(res resource.Resource) error {
+	limit, err := st.historyLimit()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := st.persist.PruneResourceRevisions(res.ID, limit); err != nil {
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+// AddContentRefCounts starts counting the references to the resource
+// content stored before reference counting was introduced, so that it
+// too is removed by RemoveOrphanedContent once orphaned.
+func (st resourceState) AddContentRefCounts() error {
+	if err := st.persist.AddMissingBlobs(); err != nil {
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+// RemoveOrphanedContent removes from storage the resource content that
+// no resource refers to any more, such as that of pruned revisions and
+// of removed services.
+func (st resourceState) RemoveOrphanedContent() error {
+	storagePaths, err := st.persist.MarkOrphanedBlobs()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(storagePaths) == 0 {
+		return nil
+	}
+	for _, storagePath := range storagePaths {
+		logger.Tracef("removing orphaned resource content %q", storagePath)
+		if err := st.storage.Remove(storagePath); err != nil && !errors.IsNotFound(err) {
+			return errors.Annotatef(err, "while removing %q from storage", storagePath)
+		}
+	}
+	if err := st.persist.RemoveBlobs(storagePaths); err != nil {
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+// ListResourceRevisions returns the retained revisions of each of the
+// given service's resources.
+func (st resourceState) ListResourceRevisions(serviceID string) ([]resource.ResourceRevision, error) {
+	revisions, err := st.persist.ListResourceRevisions(serviceID)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return revisions, nil
+}
+
+// SetResourceRevision rolls the service's resource back (or forward)
+// to the numbered revision, which must still be retained. Units pick
+// up the revision the next time they get the resource.
+func (st resourceState) SetResourceRevision(serviceID, name string, number int) (resource.Resource, error) {
+	logger.Tracef("setting resource %q for service %q to revision %d", name, serviceID, number)
+	id := newResourceID(serviceID, name)
+	res, err := st.persist.SetResourceRevision(id, number)
+	if err != nil {
+		return res, errors.Trace(err)
+	}
+	return res, nil
+}
+
 // OpenResource returns metadata about the resource, and a reader for
 // the resource.
 func (st resourceState) OpenResource(unit resource.Unit, name string) (resource.Resource, io.ReadCloser, error) {
@@ -295,6 +402,17 @@ func (st resourceState) newResolvePendingResourceOps(serviceID, name, pendingID
 	return st.persist.NewResolvePendingResourceOps(resID, pendingID)
 }
 
+// NewRemoveResourcesOps generates mongo transaction operations to
+// remove all the service's resources. Their content is removed by
+// RemoveOrphanedContent once nothing else refers to it.
+func (st resourceState) NewRemoveResourcesOps(serviceID string) ([]txn.Op, error) {
+	ops, err := st.persist.NewRemoveResourcesOps(serviceID)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return ops, nil
+}
+
 // TODO(ericsnow) Incorporate the service and resource name into the ID
 // instead of just using a UUID?
 
@@ -312,19 +430,12 @@ func newResourceID(serviceID, name string) string {
 	return fmt.Sprintf("%s/%s", serviceID, name)
 }
 
-// storagePath returns the path used as the location where the resource
-// is stored in state storage. This requires that the returned string
-// be unique and that it be organized in a structured way. In this case
-// we start with a top-level (the service), then under that service use
-// the "resources" section. The provided ID is located under there.
-func storagePath(name, serviceID, pendingID string) string {
-	// TODO(ericsnow) Use services/<service>/resources/<resource>?
-	id := name
-	if pendingID != "" {
-		// TODO(ericsnow) How to resolve this later?
-		id += "-" + pendingID
-	}
-	return path.Join("service-"+serviceID, "resources", id)
+// contentStoragePath returns the path used as the location where
+// resource content is stored in state storage. The content is
+// identified by its fingerprint, so that resources with the same
+// content share the stored copy.
+func contentStoragePath(fp charmresource.Fingerprint) string {
+	return path.Join("resources", fp.String())
 }
 
 // unitSetter records the resource as in use by a unit when the wrapped
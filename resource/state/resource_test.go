@@ -123,8 +123,9 @@ func (s *ResourceSuite) TestSetResourceOkay(c *gc.C) {
 	expected.Timestamp = s.timestamp
 	chRes := expected.Resource
 	hash := chRes.Fingerprint.String()
-	path := "service-a-service/resources/spam"
+	path := "resources/" + hash
 	file := &stubReader{stub: s.stub}
+	s.raw.ReturnResourceHistoryLimit = 5
 	st := NewState(s.raw)
 	st.currentTimestamp = s.now
 	s.stub.ResetCalls()
@@ -137,9 +138,12 @@ func (s *ResourceSuite) TestSetResourceOkay(c *gc.C) {
 		"StageResource",
 		"PutAndCheckHash",
 		"Activate",
+		"ResourceHistoryLimit",
+		"PruneResourceRevisions",
 	)
 	s.stub.CheckCall(c, 1, "StageResource", expected, path)
 	s.stub.CheckCall(c, 2, "PutAndCheckHash", path, file, res.Size, hash)
+	s.stub.CheckCall(c, 5, "PruneResourceRevisions", "a-service/spam", 5)
 	c.Check(res, jc.DeepEquals, resource.Resource{
 		Resource:  chRes,
 		ID:        "a-service/" + res.Name,
@@ -149,6 +153,51 @@ func (s *ResourceSuite) TestSetResourceOkay(c *gc.C) {
 	})
 }
 
+func (s *ResourceSuite) TestSetResourcePrunesRevisions(c *gc.C) {
+	expected := newUploadResource(c, "spam", "spamspamspam")
+	file := &stubReader{stub: s.stub}
+	s.raw.ReturnResourceHistoryLimit = 2
+	st := NewState(s.raw)
+	st.currentTimestamp = s.now
+	s.stub.ResetCalls()
+
+	_, err := st.SetResource("a-service", "a-user", expected.Resource, file)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.stub.CheckCallNames(c,
+		"currentTimestamp",
+		"StageResource",
+		"PutAndCheckHash",
+		"Activate",
+		"ResourceHistoryLimit",
+		"PruneResourceRevisions",
+	)
+	s.stub.CheckCall(c, 5, "PruneResourceRevisions", "a-service/spam", 2)
+}
+
+func (s *ResourceSuite) TestSetResourcePruneFailure(c *gc.C) {
+	expected := newUploadResource(c, "spam", "spamspamspam")
+	file := &stubReader{stub: s.stub}
+	st := NewState(s.raw)
+	st.currentTimestamp = s.now
+	s.stub.ResetCalls()
+	failure := errors.New("<failure>")
+	s.stub.SetErrors(nil, nil, nil, nil, nil, failure)
+
+	_, err := st.SetResource("a-service", "a-user", expected.Resource, file)
+
+	// The upload succeeded, so pruning failures are only logged.
+	c.Assert(err, jc.ErrorIsNil)
+	s.stub.CheckCallNames(c,
+		"currentTimestamp",
+		"StageResource",
+		"PutAndCheckHash",
+		"Activate",
+		"ResourceHistoryLimit",
+		"PruneResourceRevisions",
+	)
+}
+
 func (s *ResourceSuite) TestSetResourceInfoOnly(c *gc.C) {
 	expected := newUploadResource(c, "spam", "spamspamspam")
 	expected.Timestamp = time.Time{}
@@ -190,7 +239,8 @@ func (s *ResourceSuite) TestSetResourceBadResource(c *gc.C) {
 func (s *ResourceSuite) TestSetResourceStagingFailure(c *gc.C) {
 	expected := newUploadResource(c, "spam", "spamspamspam")
 	expected.Timestamp = s.timestamp
-	path := "service-a-service/resources/spam"
+	hash := expected.Fingerprint.String()
+	path := "resources/" + hash
 	file := &stubReader{stub: s.stub}
 	st := NewState(s.raw)
 	st.currentTimestamp = s.now
@@ -210,7 +260,7 @@ func (s *ResourceSuite) TestSetResourcePutFailureBasic(c *gc.C) {
 	expected := newUploadResource(c, "spam", "spamspamspam")
 	expected.Timestamp = s.timestamp
 	hash := expected.Fingerprint.String()
-	path := "service-a-service/resources/spam"
+	path := "resources/" + hash
 	file := &stubReader{stub: s.stub}
 	st := NewState(s.raw)
 	st.currentTimestamp = s.now
@@ -236,7 +286,7 @@ func (s *ResourceSuite) TestSetResourcePutFailureExtra(c *gc.C) {
 	expected := newUploadResource(c, "spam", "spamspamspam")
 	expected.Timestamp = s.timestamp
 	hash := expected.Fingerprint.String()
-	path := "service-a-service/resources/spam"
+	path := "resources/" + hash
 	file := &stubReader{stub: s.stub}
 	st := NewState(s.raw)
 	st.currentTimestamp = s.now
@@ -263,14 +313,14 @@ func (s *ResourceSuite) TestSetResourceSetFailureBasic(c *gc.C) {
 	expected := newUploadResource(c, "spam", "spamspamspam")
 	expected.Timestamp = s.timestamp
 	hash := expected.Fingerprint.String()
-	path := "service-a-service/resources/spam"
+	path := "resources/" + hash
 	file := &stubReader{stub: s.stub}
 	st := NewState(s.raw)
 	st.currentTimestamp = s.now
 	s.stub.ResetCalls()
 	failure := errors.New("<failure>")
 	ignoredErr := errors.New("<never reached>")
-	s.stub.SetErrors(nil, nil, nil, failure, nil, nil, ignoredErr)
+	s.stub.SetErrors(nil, nil, nil, failure, nil, ignoredErr)
 
 	_, err := st.SetResource("a-service", "a-user", expected.Resource, file)
 
@@ -280,28 +330,25 @@ func (s *ResourceSuite) TestSetResourceSetFailureBasic(c *gc.C) {
 		"StageResource",
 		"PutAndCheckHash",
 		"Activate",
-		"Remove",
 		"Unstage",
 	)
 	s.stub.CheckCall(c, 1, "StageResource", expected, path)
 	s.stub.CheckCall(c, 2, "PutAndCheckHash", path, file, expected.Size, hash)
-	s.stub.CheckCall(c, 4, "Remove", path)
 }
 
 func (s *ResourceSuite) TestSetResourceSetFailureExtra(c *gc.C) {
 	expected := newUploadResource(c, "spam", "spamspamspam")
 	expected.Timestamp = s.timestamp
 	hash := expected.Fingerprint.String()
-	path := "service-a-service/resources/spam"
+	path := "resources/" + hash
 	file := &stubReader{stub: s.stub}
 	st := NewState(s.raw)
 	st.currentTimestamp = s.now
 	s.stub.ResetCalls()
 	failure := errors.New("<failure>")
-	extraErr1 := errors.New("<just not your day>")
-	extraErr2 := errors.New("<wow...just wow>")
+	extraErr := errors.New("<just not your day>")
 	ignoredErr := errors.New("<never reached>")
-	s.stub.SetErrors(nil, nil, nil, failure, extraErr1, extraErr2, ignoredErr)
+	s.stub.SetErrors(nil, nil, nil, failure, extraErr, ignoredErr)
 
 	_, err := st.SetResource("a-service", "a-user", expected.Resource, file)
 
@@ -311,12 +358,10 @@ func (s *ResourceSuite) TestSetResourceSetFailureExtra(c *gc.C) {
 		"StageResource",
 		"PutAndCheckHash",
 		"Activate",
-		"Remove",
 		"Unstage",
 	)
 	s.stub.CheckCall(c, 1, "StageResource", expected, path)
 	s.stub.CheckCall(c, 2, "PutAndCheckHash", path, file, expected.Size, hash)
-	s.stub.CheckCall(c, 4, "Remove", path)
 }
 
 func (s *ResourceSuite) TestUpdatePendingResourceOkay(c *gc.C) {
@@ -325,7 +370,7 @@ func (s *ResourceSuite) TestUpdatePendingResourceOkay(c *gc.C) {
 	expected.Timestamp = s.timestamp
 	chRes := expected.Resource
 	hash := chRes.Fingerprint.String()
-	path := "service-a-service/resources/spam-some-unique-id"
+	path := "resources/" + hash
 	file := &stubReader{stub: s.stub}
 	st := NewState(s.raw)
 	st.currentTimestamp = s.now
@@ -359,7 +404,7 @@ func (s *ResourceSuite) TestAddPendingResourceOkay(c *gc.C) {
 	expected.Timestamp = s.timestamp
 	chRes := expected.Resource
 	hash := chRes.Fingerprint.String()
-	path := "service-a-service/resources/spam-some-unique-ID-001"
+	path := "resources/" + hash
 	file := &stubReader{stub: s.stub}
 	st := NewState(s.raw)
 	st.currentTimestamp = s.now
@@ -493,6 +538,124 @@ func (s *ResourceSuite) TestNewResourcePendingResourcesOps(c *gc.C) {
 	c.Check(ops, jc.DeepEquals, expected)
 }
 
+func (s *ResourceSuite) TestListResourceRevisions(c *gc.C) {
+	spam := newUploadResource(c, "spam", "spamspamspam")
+	expected := []resource.ResourceRevision{{
+		Resource: spam,
+		Number:   1,
+	}, {
+		Resource: spam,
+		Number:   2,
+		Current:  true,
+	}}
+	s.persist.ReturnListResourceRevisions = expected
+	st := NewState(s.raw)
+	s.stub.ResetCalls()
+
+	revisions, err := st.ListResourceRevisions("a-service")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(revisions, jc.DeepEquals, expected)
+	s.stub.CheckCallNames(c, "ListResourceRevisions")
+	s.stub.CheckCall(c, 0, "ListResourceRevisions", "a-service")
+}
+
+func (s *ResourceSuite) TestSetResourceRevision(c *gc.C) {
+	expected := newUploadResource(c, "spam", "spamspamspam")
+	s.persist.ReturnSetResourceRevision = expected
+	st := NewState(s.raw)
+	s.stub.ResetCalls()
+
+	res, err := st.SetResourceRevision("a-service", "spam", 3)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(res, jc.DeepEquals, expected)
+	s.stub.CheckCallNames(c, "SetResourceRevision")
+	s.stub.CheckCall(c, 0, "SetResourceRevision", "a-service/spam", 3)
+}
+
+func (s *ResourceSuite) TestSetResourceRevisionNotFound(c *gc.C) {
+	st := NewState(s.raw)
+	s.stub.ResetCalls()
+	s.stub.SetErrors(errors.NotFoundf(`resource "a-service/spam" revision 3`))
+
+	_, err := st.SetResourceRevision("a-service", "spam", 3)
+
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+	s.stub.CheckCallNames(c, "SetResourceRevision")
+}
+
+func (s *ResourceSuite) TestNewRemoveResourcesOps(c *gc.C) {
+	expected := []txn.Op{{
+		C:      "resources",
+		Id:     "resource#a-service/spam",
+		Assert: txn.DocExists,
+		Remove: true,
+	}}
+	s.persist.ReturnNewRemoveResourcesOps = expected
+	st := NewState(s.raw)
+	s.stub.ResetCalls()
+
+	ops, err := st.NewRemoveResourcesOps("a-service")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(ops, jc.DeepEquals, expected)
+	s.stub.CheckCallNames(c, "NewRemoveResourcesOps")
+	s.stub.CheckCall(c, 0, "NewRemoveResourcesOps", "a-service")
+}
+
+func (s *ResourceSuite) TestRemoveOrphanedContent(c *gc.C) {
+	paths := []string{"resources/abc", "resources/def"}
+	s.persist.ReturnMarkOrphanedBlobs = paths
+	st := NewState(s.raw)
+	s.stub.ResetCalls()
+	// Content already gone from storage is not an error.
+	s.stub.SetErrors(nil, errors.NotFoundf("blob"))
+
+	err := st.RemoveOrphanedContent()
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.stub.CheckCallNames(c, "MarkOrphanedBlobs", "Remove", "Remove", "RemoveBlobs")
+	s.stub.CheckCall(c, 1, "Remove", "resources/abc")
+	s.stub.CheckCall(c, 2, "Remove", "resources/def")
+	s.stub.CheckCall(c, 3, "RemoveBlobs", paths)
+}
+
+func (s *ResourceSuite) TestRemoveOrphanedContentNone(c *gc.C) {
+	st := NewState(s.raw)
+	s.stub.ResetCalls()
+
+	err := st.RemoveOrphanedContent()
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.stub.CheckCallNames(c, "MarkOrphanedBlobs")
+}
+
+func (s *ResourceSuite) TestRemoveOrphanedContentStorageFailure(c *gc.C) {
+	s.persist.ReturnMarkOrphanedBlobs = []string{"resources/abc"}
+	st := NewState(s.raw)
+	s.stub.ResetCalls()
+	failure := errors.New("<failure>")
+	s.stub.SetErrors(nil, failure)
+
+	err := st.RemoveOrphanedContent()
+
+	c.Check(errors.Cause(err), gc.Equals, failure)
+	c.Check(err, gc.ErrorMatches, `while removing "resources/abc" from storage: <failure>`)
+	// The content stays marked, so its removal is retried later.
+	s.stub.CheckCallNames(c, "MarkOrphanedBlobs", "Remove")
+}
+
+func (s *ResourceSuite) TestAddContentRefCounts(c *gc.C) {
+	st := NewState(s.raw)
+	s.stub.ResetCalls()
+
+	err := st.AddContentRefCounts()
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.stub.CheckCallNames(c, "AddMissingBlobs")
+}
+
 func (s *ResourceSuite) TestUnitSetterEOF(c *gc.C) {
 	r := unitSetter{
 		ReadCloser: ioutil.NopCloser(&bytes.Buffer{}),
@@ -28,6 +28,10 @@ type RawState interface {
 
 	// Storage exposes the state blob storage needed for resources.
 	Storage() Storage
+
+	// ResourceHistoryLimit returns the number of revisions of each
+	// resource that are retained.
+	ResourceHistoryLimit() (int, error)
 }
 
 // State exposes the state functionality needed for resources.
@@ -51,6 +55,7 @@ func NewState(raw RawState) *State {
 			currentTimestamp: func() time.Time {
 				return time.Now().UTC()
 			},
+			historyLimit: raw.ResourceHistoryLimit,
 		},
 	}
 	return st
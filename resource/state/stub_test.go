@@ -17,8 +17,9 @@ import (
 type stubRawState struct {
 	stub *testing.Stub
 
-	ReturnPersistence Persistence
-	ReturnStorage     Storage
+	ReturnPersistence          Persistence
+	ReturnStorage              Storage
+	ReturnResourceHistoryLimit int
 }
 
 func (s *stubRawState) Persistence() Persistence {
@@ -35,6 +36,15 @@ func (s *stubRawState) Storage() Storage {
 	return s.ReturnStorage
 }
 
+func (s *stubRawState) ResourceHistoryLimit() (int, error) {
+	s.stub.AddCall("ResourceHistoryLimit")
+	if err := s.stub.NextErr(); err != nil {
+		return 0, errors.Trace(err)
+	}
+
+	return s.ReturnResourceHistoryLimit, nil
+}
+
 type stubPersistence struct {
 	stub *testing.Stub
 
@@ -44,6 +54,10 @@ type stubPersistence struct {
 	ReturnGetResourcePath              string
 	ReturnStageResource                *stubStagedResource
 	ReturnNewResolvePendingResourceOps [][]txn.Op
+	ReturnListResourceRevisions        []resource.ResourceRevision
+	ReturnSetResourceRevision          resource.Resource
+	ReturnNewRemoveResourcesOps        []txn.Op
+	ReturnMarkOrphanedBlobs            []string
 
 	CallsForNewResolvePendingResourceOps map[string]string
 }
@@ -121,6 +135,69 @@ func (s *stubPersistence) NewResolvePendingResourceOps(resID, pendingID string)
 	return ops, nil
 }
 
+func (s *stubPersistence) ListResourceRevisions(serviceID string) ([]resource.ResourceRevision, error) {
+	s.stub.AddCall("ListResourceRevisions", serviceID)
+	if err := s.stub.NextErr(); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	return s.ReturnListResourceRevisions, nil
+}
+
+func (s *stubPersistence) SetResourceRevision(id string, number int) (resource.Resource, error) {
+	s.stub.AddCall("SetResourceRevision", id, number)
+	if err := s.stub.NextErr(); err != nil {
+		return resource.Resource{}, errors.Trace(err)
+	}
+
+	return s.ReturnSetResourceRevision, nil
+}
+
+func (s *stubPersistence) PruneResourceRevisions(id string, keep int) error {
+	s.stub.AddCall("PruneResourceRevisions", id, keep)
+	if err := s.stub.NextErr(); err != nil {
+		return errors.Trace(err)
+	}
+
+	return nil
+}
+
+func (s *stubPersistence) NewRemoveResourcesOps(serviceID string) ([]txn.Op, error) {
+	s.stub.AddCall("NewRemoveResourcesOps", serviceID)
+	if err := s.stub.NextErr(); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	return s.ReturnNewRemoveResourcesOps, nil
+}
+
+func (s *stubPersistence) MarkOrphanedBlobs() ([]string, error) {
+	s.stub.AddCall("MarkOrphanedBlobs")
+	if err := s.stub.NextErr(); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	return s.ReturnMarkOrphanedBlobs, nil
+}
+
+func (s *stubPersistence) RemoveBlobs(storagePaths []string) error {
+	s.stub.AddCall("RemoveBlobs", storagePaths)
+	if err := s.stub.NextErr(); err != nil {
+		return errors.Trace(err)
+	}
+
+	return nil
+}
+
+func (s *stubPersistence) AddMissingBlobs() error {
+	s.stub.AddCall("AddMissingBlobs")
+	if err := s.stub.NextErr(); err != nil {
+		return errors.Trace(err)
+	}
+
+	return nil
+}
+
 type stubStagedResource struct {
 	stub *testing.Stub
 }
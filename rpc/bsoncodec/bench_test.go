@@ -0,0 +1,171 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package bsoncodec_test
+
+import (
+	"fmt"
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/rpc"
+	"github.com/juju/juju/rpc/bsoncodec"
+	"github.com/juju/juju/state/multiwatcher"
+)
+
+// benchmarkSuite compares the cost of sending typical large API
+// results with JSON and BSON. Run it with:
+//
+//	go test github.com/juju/juju/rpc/bsoncodec -check.b -check.bmem
+type benchmarkSuite struct{}
+
+var _ = gc.Suite(&benchmarkSuite{})
+
+func (*benchmarkSuite) BenchmarkFullStatusJSON(c *gc.C) {
+	benchmarkRoundTrip(c, false, fullStatus(100, 10), new(params.FullStatus))
+}
+
+func (*benchmarkSuite) BenchmarkFullStatusBSON(c *gc.C) {
+	benchmarkRoundTrip(c, true, fullStatus(100, 10), new(params.FullStatus))
+}
+
+func (*benchmarkSuite) BenchmarkAllWatcherDeltasJSON(c *gc.C) {
+	benchmarkRoundTrip(c, false, allWatcherDeltas(1000), new(params.AllWatcherNextResults))
+}
+
+func (*benchmarkSuite) BenchmarkAllWatcherDeltasBSON(c *gc.C) {
+	benchmarkRoundTrip(c, true, allWatcherDeltas(1000), new(params.AllWatcherNextResults))
+}
+
+// benchmarkRoundTrip measures the time taken to write the
+// given response and read it back into result.
+func benchmarkRoundTrip(c *gc.C, isBSON bool, response, result interface{}) {
+	conn := &loopbackConn{}
+	codec := bsoncodec.New(conn)
+	codec.SetBSON(isBSON)
+	hdr := &rpc.Header{RequestId: 1}
+	c.ResetTimer()
+	for i := 0; i < c.N; i++ {
+		err := codec.WriteMessage(hdr, response)
+		c.Assert(err, jc.ErrorIsNil)
+		var readHdr rpc.Header
+		err = codec.ReadHeader(&readHdr)
+		c.Assert(err, jc.ErrorIsNil)
+		err = codec.ReadBody(result, false)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	c.StopTimer()
+	c.SetBytes(int64(conn.size))
+}
+
+// loopbackConn is a Conn that receives the last message sent.
+type loopbackConn struct {
+	data   []byte
+	isBSON bool
+	size   int
+}
+
+func (c *loopbackConn) Send(data []byte, isBSON bool) error {
+	c.data, c.isBSON, c.size = data, isBSON, len(data)
+	return nil
+}
+
+func (c *loopbackConn) Receive() ([]byte, bool, error) {
+	return c.data, c.isBSON, nil
+}
+
+func (c *loopbackConn) Close() error {
+	return nil
+}
+
+// fullStatus returns the status of a model with the given number
+// of machines, each running the given number of units.
+func fullStatus(numMachines, unitsPerMachine int) *params.FullStatus {
+	since := time.Date(2016, 4, 1, 12, 0, 0, 0, time.UTC)
+	agentStatus := func(status params.Status, info string) params.AgentStatus {
+		return params.AgentStatus{
+			Status:  status,
+			Info:    info,
+			Since:   &since,
+			Version: "2.0.0",
+		}
+	}
+	st := &params.FullStatus{
+		ModelName: "bench",
+		Machines:  make(map[string]params.MachineStatus),
+		Services:  make(map[string]params.ServiceStatus),
+	}
+	for m := 0; m < numMachines; m++ {
+		id := fmt.Sprint(m)
+		st.Machines[id] = params.MachineStatus{
+			Agent:         agentStatus(params.StatusStarted, ""),
+			DNSName:       fmt.Sprintf("10.0.%d.%d", m/256, m%256),
+			InstanceId:    "i-" + id,
+			InstanceState: "running",
+			Series:        "trusty",
+			Id:            id,
+			Hardware:      "arch=amd64 cpu-cores=4 mem=16384M root-disk=65536M",
+			Jobs:          []multiwatcher.MachineJob{multiwatcher.JobHostUnits},
+		}
+	}
+	for s := 0; s < unitsPerMachine; s++ {
+		name := fmt.Sprintf("service-%d", s)
+		svc := params.ServiceStatus{
+			Charm:     "cs:trusty/" + name + "-42",
+			Life:      "alive",
+			Relations: map[string][]string{"db": {"mysql"}},
+			Units:     make(map[string]params.UnitStatus),
+			Status:    agentStatus(params.StatusActive, "ready"),
+		}
+		for m := 0; m < numMachines; m++ {
+			unit := fmt.Sprintf("%s/%d", name, m)
+			svc.Units[unit] = params.UnitStatus{
+				UnitAgent:     agentStatus(params.StatusIdle, ""),
+				Workload:      agentStatus(params.StatusActive, "ready"),
+				AgentState:    params.StatusStarted,
+				AgentVersion:  "2.0.0",
+				Life:          "alive",
+				Machine:       fmt.Sprint(m),
+				OpenedPorts:   []string{"80/tcp", "443/tcp"},
+				PublicAddress: st.Machines[fmt.Sprint(m)].DNSName,
+				Charm:         svc.Charm,
+			}
+		}
+		st.Services[name] = svc
+	}
+	return st
+}
+
+// allWatcherDeltas returns a batch of the given number of unit
+// changes, as sent by the AllWatcher.
+func allWatcherDeltas(n int) *params.AllWatcherNextResults {
+	since := time.Date(2016, 4, 1, 12, 0, 0, 0, time.UTC)
+	deltas := make([]multiwatcher.Delta, n)
+	for i := range deltas {
+		deltas[i] = multiwatcher.Delta{
+			Entity: &multiwatcher.UnitInfo{
+				ModelUUID:      "deadbeef-0bad-400d-8000-4b1d0d06f00d",
+				Name:           fmt.Sprintf("wordpress/%d", i),
+				Service:        "wordpress",
+				Series:         "trusty",
+				CharmURL:       "cs:trusty/wordpress-42",
+				PublicAddress:  fmt.Sprintf("10.0.%d.%d", i/256, i%256),
+				PrivateAddress: fmt.Sprintf("192.168.%d.%d", i/256, i%256),
+				MachineId:      fmt.Sprint(i),
+				WorkloadStatus: multiwatcher.StatusInfo{
+					Current: "active",
+					Message: "ready",
+					Since:   &since,
+				},
+				AgentStatus: multiwatcher.StatusInfo{
+					Current: "idle",
+					Since:   &since,
+				},
+			},
+		}
+	}
+	return &params.AllWatcherNextResults{Deltas: deltas}
+}
@@ -0,0 +1,279 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// The bsoncodec package provides a BSON codec for the rpc package.
+//
+// BSON messages are sent as binary frames and JSON messages as text
+// frames, so a Codec can always read either format. It sends JSON
+// until told to use BSON, or until it receives a BSON message from
+// its peer, which makes it possible to fall back to JSON when talking
+// to peers that only understand rpc/jsoncodec.
+//
+// Message bodies are encoded with gopkg.in/mgo.v2/bson, so the usual
+// caveats apply: json struct tags and json.Marshaler implementations
+// are ignored, times are only kept to the millisecond, and values
+// decoded into interface{} fields hold bson types (documents become
+// bson.M, for example).
+//
+// In particular, because the json tags on the apiserver/params types
+// are ignored, the field names sent over the wire are the lowercased
+// Go field names (or those given by bson tags), not the names in the
+// JSON API. BSON messages can therefore only be exchanged between
+// peers built with the same params types; they are not a different
+// encoding of the JSON API, and clients written against the JSON API
+// must not use them.
+package bsoncodec
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/juju/loggo"
+	"gopkg.in/mgo.v2/bson"
+
+	"github.com/juju/juju/rpc"
+)
+
+var logger = loggo.GetLogger("juju.rpc.bsoncodec")
+
+// Conn sends and receives encoded messages over an underlying
+// connection that preserves message boundaries.
+type Conn interface {
+	// Send sends a message. The isBSON flag reports whether the
+	// message is encoded as BSON rather than JSON.
+	Send(data []byte, isBSON bool) error
+	// Receive receives a message, reporting whether it is encoded
+	// as BSON rather than JSON.
+	Receive() (data []byte, isBSON bool, err error)
+	Close() error
+}
+
+// Codec implements rpc.Codec for a connection.
+type Codec struct {
+	// msg holds the message that's just been read by ReadHeader, so
+	// that the body can be read by ReadBody.
+	msg         inMsg
+	conn        Conn
+	logMessages int32
+	sendBSON    int32
+	mu          sync.Mutex
+	closing     bool
+}
+
+// New returns an rpc codec that uses conn to send and receive
+// messages.
+func New(conn Conn) *Codec {
+	return &Codec{
+		conn: conn,
+	}
+}
+
+// SetLogging sets whether messages will be logged
+// by the codec.
+func (c *Codec) SetLogging(on bool) {
+	atomic.StoreInt32(&c.logMessages, boolToInt32(on))
+}
+
+func (c *Codec) isLogging() bool {
+	return atomic.LoadInt32(&c.logMessages) != 0
+}
+
+// SetBSON sets whether messages will be sent in BSON rather
+// than JSON format. It should only be turned on when the peer
+// is known to understand BSON.
+func (c *Codec) SetBSON(on bool) {
+	atomic.StoreInt32(&c.sendBSON, boolToInt32(on))
+}
+
+// IsBSON reports whether messages are being sent in BSON format.
+func (c *Codec) IsBSON() bool {
+	return atomic.LoadInt32(&c.sendBSON) != 0
+}
+
+func boolToInt32(b bool) int32 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// inMsg holds an incoming message. We don't know the type of the
+// parameters or response yet, so we delay parsing by storing them
+// in raw form; which fields are used depends on the format of
+// the message.
+type inMsg struct {
+	RequestId uint64        `bson:"r"`
+	Type      string        `bson:"t"`
+	Version   int           `bson:"v"`
+	Id        string        `bson:"i"`
+	Request   string        `bson:"q"`
+	Error     string        `bson:"e"`
+	ErrorCode string        `bson:"c"`
+	Timeout   time.Duration `bson:"d"`
+	Cancel    bool          `bson:"x"`
+
+	// Params and Response hold the body of a JSON message.
+	Params   json.RawMessage `bson:"-"`
+	Response json.RawMessage `bson:"-"`
+
+	// BSONParams and BSONResponse hold the body of a BSON message.
+	BSONParams   bson.Raw `bson:"p" json:"-"`
+	BSONResponse bson.Raw `bson:"s" json:"-"`
+
+	isBSON bool
+}
+
+// outMsg holds an outgoing message. When encoded as JSON it is
+// identical to the messages sent by rpc/jsoncodec.
+type outMsg struct {
+	RequestId uint64        `bson:"r"`
+	Type      string        `json:",omitempty" bson:"t,omitempty"`
+	Version   int           `json:",omitempty" bson:"v,omitempty"`
+	Id        string        `json:",omitempty" bson:"i,omitempty"`
+	Request   string        `json:",omitempty" bson:"q,omitempty"`
+	Params    interface{}   `json:",omitempty" bson:"p,omitempty"`
+	Error     string        `json:",omitempty" bson:"e,omitempty"`
+	ErrorCode string        `json:",omitempty" bson:"c,omitempty"`
+	Response  interface{}   `json:",omitempty" bson:"s,omitempty"`
+	Timeout   time.Duration `json:",omitempty" bson:"d,omitempty"`
+	Cancel    bool          `json:",omitempty" bson:"x,omitempty"`
+}
+
+func (c *Codec) Close() error {
+	c.mu.Lock()
+	c.closing = true
+	c.mu.Unlock()
+	return c.conn.Close()
+}
+
+func (c *Codec) isClosing() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.closing
+}
+
+func (c *Codec) ReadHeader(hdr *rpc.Header) error {
+	c.msg = inMsg{} // avoid any potential cross-message contamination.
+	data, isBSON, err := c.conn.Receive()
+	if err != nil {
+		if c.isLogging() {
+			logger.Tracef("<- error: %v (closing %v)", err, c.isClosing())
+		}
+		// If we've closed the connection, we may get a spurious error,
+		// so ignore it.
+		if c.isClosing() || err == io.EOF {
+			return io.EOF
+		}
+		return fmt.Errorf("error receiving message: %v", err)
+	}
+	if c.isLogging() {
+		logger.Tracef("<- %s", dump(data, isBSON))
+	}
+	if isBSON {
+		err = bson.Unmarshal(data, &c.msg)
+		// The peer understands BSON, so reply in kind.
+		c.SetBSON(true)
+	} else {
+		err = json.Unmarshal(data, &c.msg)
+	}
+	if err != nil {
+		return fmt.Errorf("error receiving message: %v", err)
+	}
+	c.msg.isBSON = isBSON
+	hdr.RequestId = c.msg.RequestId
+	hdr.Request = rpc.Request{
+		Type:    c.msg.Type,
+		Version: c.msg.Version,
+		Id:      c.msg.Id,
+		Action:  c.msg.Request,
+	}
+	hdr.Error = c.msg.Error
+	hdr.ErrorCode = c.msg.ErrorCode
+	hdr.Timeout = c.msg.Timeout
+	hdr.Cancel = c.msg.Cancel
+	return nil
+}
+
+func (c *Codec) ReadBody(body interface{}, isRequest bool) error {
+	if body == nil {
+		return nil
+	}
+	if c.msg.isBSON {
+		rawBody := c.msg.BSONResponse
+		if isRequest {
+			rawBody = c.msg.BSONParams
+		}
+		if len(rawBody.Data) == 0 {
+			// If the response or params are omitted, it's
+			// equivalent to an empty object.
+			return nil
+		}
+		return rawBody.Unmarshal(body)
+	}
+	rawBody := c.msg.Response
+	if isRequest {
+		rawBody = c.msg.Params
+	}
+	if len(rawBody) == 0 {
+		return nil
+	}
+	return json.Unmarshal(rawBody, body)
+}
+
+func (c *Codec) WriteMessage(hdr *rpc.Header, body interface{}) error {
+	var m outMsg
+	m.init(hdr, body)
+	isBSON := c.IsBSON()
+	var data []byte
+	var err error
+	if isBSON {
+		data, err = bson.Marshal(&m)
+	} else {
+		data, err = json.Marshal(&m)
+	}
+	if err != nil {
+		if c.isLogging() {
+			logger.Tracef("-> marshal error: %v", err)
+		}
+		return err
+	}
+	if c.isLogging() {
+		logger.Tracef("-> %s", dump(data, isBSON))
+	}
+	return c.conn.Send(data, isBSON)
+}
+
+// init fills out the receiving outMsg with information from the given
+// header and body.
+func (m *outMsg) init(hdr *rpc.Header, body interface{}) {
+	m.RequestId = hdr.RequestId
+	m.Type = hdr.Request.Type
+	m.Version = hdr.Request.Version
+	m.Id = hdr.Request.Id
+	m.Request = hdr.Request.Action
+	m.Error = hdr.Error
+	m.ErrorCode = hdr.ErrorCode
+	m.Timeout = hdr.Timeout
+	m.Cancel = hdr.Cancel
+	if hdr.IsRequest() {
+		m.Params = body
+	} else {
+		m.Response = body
+	}
+}
+
+// dump returns a printable form of the given encoded message.
+func dump(data []byte, isBSON bool) string {
+	if !isBSON {
+		return string(data)
+	}
+	var doc bson.M
+	if err := bson.Unmarshal(data, &doc); err != nil {
+		return fmt.Sprintf("bson %x", data)
+	}
+	return fmt.Sprintf("bson %v", doc)
+}
@@ -0,0 +1,335 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package bsoncodec_test
+
+import (
+	"errors"
+	"io"
+	"reflect"
+	stdtesting "testing"
+
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/mgo.v2/bson"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/rpc"
+	"github.com/juju/juju/rpc/bsoncodec"
+)
+
+type suite struct {
+	testing.LoggingSuite
+}
+
+var _ = gc.Suite(&suite{})
+
+func TestPackage(t *stdtesting.T) {
+	gc.TestingT(t)
+}
+
+type value struct {
+	X string
+}
+
+func mustMarshalBSON(v interface{}) []byte {
+	data, err := bson.Marshal(v)
+	if err != nil {
+		panic(err)
+	}
+	return data
+}
+
+var readTests = []struct {
+	msg        testMsg
+	expectHdr  rpc.Header
+	expectBody interface{}
+}{{
+	msg: testMsg{data: []byte(`{"RequestId": 1, "Type": "foo", "Id": "id", "Request": "frob", "Params": {"X": "param"}}`)},
+	expectHdr: rpc.Header{
+		RequestId: 1,
+		Request: rpc.Request{
+			Type:   "foo",
+			Id:     "id",
+			Action: "frob",
+		},
+	},
+	expectBody: &value{X: "param"},
+}, {
+	msg: testMsg{
+		data:   mustMarshalBSON(bson.M{"r": 1, "t": "foo", "v": 2, "i": "id", "q": "frob", "p": bson.M{"x": "param"}}),
+		isBSON: true,
+	},
+	expectHdr: rpc.Header{
+		RequestId: 1,
+		Request: rpc.Request{
+			Type:    "foo",
+			Version: 2,
+			Id:      "id",
+			Action:  "frob",
+		},
+	},
+	expectBody: &value{X: "param"},
+}, {
+	msg: testMsg{
+		data:   mustMarshalBSON(bson.M{"r": 2, "e": "an error", "c": "a code"}),
+		isBSON: true,
+	},
+	expectHdr: rpc.Header{
+		RequestId: 2,
+		Error:     "an error",
+		ErrorCode: "a code",
+	},
+	expectBody: new(map[string]interface{}),
+}, {
+	msg: testMsg{
+		data:   mustMarshalBSON(bson.M{"r": 3, "s": bson.M{"x": "result"}}),
+		isBSON: true,
+	},
+	expectHdr: rpc.Header{
+		RequestId: 3,
+	},
+	expectBody: &value{X: "result"},
+}}
+
+func (*suite) TestRead(c *gc.C) {
+	for i, test := range readTests {
+		c.Logf("test %d", i)
+		codec := bsoncodec.New(&testConn{
+			readMsgs: []testMsg{test.msg},
+		})
+		var hdr rpc.Header
+		err := codec.ReadHeader(&hdr)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(hdr, gc.DeepEquals, test.expectHdr)
+
+		body := reflect.New(reflect.ValueOf(test.expectBody).Type().Elem()).Interface()
+		err = codec.ReadBody(body, test.expectHdr.IsRequest())
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(body, gc.DeepEquals, test.expectBody)
+
+		err = codec.ReadHeader(&hdr)
+		c.Assert(err, gc.Equals, io.EOF)
+	}
+}
+
+func (*suite) TestWriteJSONByDefault(c *gc.C) {
+	var conn testConn
+	codec := bsoncodec.New(&conn)
+	c.Assert(codec.IsBSON(), jc.IsFalse)
+	hdr := &rpc.Header{RequestId: 3}
+	err := codec.WriteMessage(hdr, &value{X: "result"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(conn.writeMsgs, jc.DeepEquals, []testMsg{{
+		data: []byte(`{"RequestId":3,"Response":{"X":"result"}}`),
+	}})
+}
+
+func (*suite) TestWriteBSON(c *gc.C) {
+	var conn testConn
+	codec := bsoncodec.New(&conn)
+	codec.SetBSON(true)
+	hdr := &rpc.Header{
+		RequestId: 1,
+		Request: rpc.Request{
+			Type:    "foo",
+			Version: 2,
+			Action:  "frob",
+		},
+	}
+	err := codec.WriteMessage(hdr, &value{X: "param"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(conn.writeMsgs, gc.HasLen, 1)
+	c.Assert(conn.writeMsgs[0].isBSON, jc.IsTrue)
+
+	var m struct {
+		RequestId uint64 `bson:"r"`
+		Type      string `bson:"t"`
+		Version   int    `bson:"v"`
+		Request   string `bson:"q"`
+		Params    value  `bson:"p"`
+	}
+	err = bson.Unmarshal(conn.writeMsgs[0].data, &m)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(m.RequestId, gc.Equals, uint64(1))
+	c.Assert(m.Type, gc.Equals, "foo")
+	c.Assert(m.Version, gc.Equals, 2)
+	c.Assert(m.Request, gc.Equals, "frob")
+	c.Assert(m.Params, gc.Equals, value{X: "param"})
+
+	// Empty fields are omitted.
+	var doc bson.M
+	err = bson.Unmarshal(conn.writeMsgs[0].data, &doc)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(doc, gc.HasLen, 5)
+}
+
+func (*suite) TestRepliesInKind(c *gc.C) {
+	conn := &testConn{
+		readMsgs: []testMsg{{
+			data:   mustMarshalBSON(bson.M{"r": 1, "t": "foo", "q": "frob"}),
+			isBSON: true,
+		}},
+	}
+	codec := bsoncodec.New(conn)
+	var hdr rpc.Header
+	err := codec.ReadHeader(&hdr)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(codec.IsBSON(), jc.IsTrue)
+
+	err = codec.WriteMessage(&rpc.Header{RequestId: 1}, &value{X: "result"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(conn.writeMsgs, gc.HasLen, 1)
+	c.Assert(conn.writeMsgs[0].isBSON, jc.IsTrue)
+}
+
+func (*suite) TestRoundTrip(c *gc.C) {
+	for _, isBSON := range []bool{false, true} {
+		c.Logf("bson: %v", isBSON)
+		var conn testConn
+		codec := bsoncodec.New(&conn)
+		codec.SetBSON(isBSON)
+		hdr := &rpc.Header{RequestId: 4}
+		err := codec.WriteMessage(hdr, &value{X: "result"})
+		c.Assert(err, jc.ErrorIsNil)
+
+		conn.readMsgs, conn.writeMsgs = conn.writeMsgs, nil
+		var readHdr rpc.Header
+		err = codec.ReadHeader(&readHdr)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(readHdr, jc.DeepEquals, *hdr)
+		var body value
+		err = codec.ReadBody(&body, false)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(body, gc.Equals, value{X: "result"})
+	}
+}
+
+var roundTripParamsTests = []struct {
+	about  string
+	params interface{}
+}{{
+	about:  "string map",
+	params: &map[string]string{"foo": "bar", "baz": ""},
+}, {
+	about: "map of slices",
+	params: &map[string][]string{
+		"empty": {},
+		"names": {"a", "b"},
+	},
+}, {
+	about: "map of interface values",
+	params: &params.ModelSet{Config: map[string]interface{}{
+		"name":    "foo",
+		"enabled": true,
+	}},
+}, {
+	about: "nested structs",
+	params: &params.Entities{Entities: []params.Entity{
+		{Tag: "unit-mysql-0"},
+		{Tag: "machine-1"},
+	}},
+}, {
+	about: "structs with pointers",
+	params: &params.ErrorResults{Results: []params.ErrorResult{
+		{},
+		{Error: &params.Error{Message: "boom", Code: params.CodeNotFound}},
+	}},
+}}
+
+func (*suite) TestRoundTripParams(c *gc.C) {
+	for i, test := range roundTripParamsTests {
+		for _, isBSON := range []bool{false, true} {
+			c.Logf("test %d: %s (bson: %v)", i, test.about, isBSON)
+			var conn testConn
+			codec := bsoncodec.New(&conn)
+			codec.SetBSON(isBSON)
+			hdr := &rpc.Header{
+				RequestId: 1,
+				Request:   rpc.Request{Type: "foo", Version: 1, Action: "frob"},
+			}
+			err := codec.WriteMessage(hdr, test.params)
+			c.Assert(err, jc.ErrorIsNil)
+
+			conn.readMsgs, conn.writeMsgs = conn.writeMsgs, nil
+			var readHdr rpc.Header
+			err = codec.ReadHeader(&readHdr)
+			c.Assert(err, jc.ErrorIsNil)
+			c.Assert(readHdr, jc.DeepEquals, *hdr)
+			body := reflect.New(reflect.ValueOf(test.params).Type().Elem()).Interface()
+			err = codec.ReadBody(body, true)
+			c.Assert(err, jc.ErrorIsNil)
+			c.Assert(body, jc.DeepEquals, test.params)
+		}
+	}
+}
+
+func (*suite) TestReadHeaderLogsBSONRequests(c *gc.C) {
+	codecLogger := loggo.GetLogger("juju.rpc.bsoncodec")
+	defer codecLogger.SetLogLevel(codecLogger.LogLevel())
+	codecLogger.SetLogLevel(loggo.TRACE)
+	codec := bsoncodec.New(&testConn{
+		readMsgs: []testMsg{{
+			data:   mustMarshalBSON(bson.M{"r": 1, "q": "frob"}),
+			isBSON: true,
+		}},
+	})
+	codec.SetLogging(true)
+	var hdr rpc.Header
+	err := codec.ReadHeader(&hdr)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(c.GetTestLog(), gc.Matches, `.*TRACE juju.rpc.bsoncodec <- bson map\[.*q:frob.*\]\n`)
+}
+
+func (*suite) TestErrorAfterClose(c *gc.C) {
+	conn := &testConn{
+		err: errors.New("some error"),
+	}
+	codec := bsoncodec.New(conn)
+	var hdr rpc.Header
+	err := codec.ReadHeader(&hdr)
+	c.Assert(err, gc.ErrorMatches, "error receiving message: some error")
+
+	err = codec.Close()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(conn.closed, jc.IsTrue)
+
+	err = codec.ReadHeader(&hdr)
+	c.Assert(err, gc.Equals, io.EOF)
+}
+
+type testMsg struct {
+	data   []byte
+	isBSON bool
+}
+
+type testConn struct {
+	readMsgs  []testMsg
+	err       error
+	writeMsgs []testMsg
+	closed    bool
+}
+
+func (c *testConn) Receive() ([]byte, bool, error) {
+	if len(c.readMsgs) > 0 {
+		m := c.readMsgs[0]
+		c.readMsgs = c.readMsgs[1:]
+		return m.data, m.isBSON, nil
+	}
+	if c.err != nil {
+		return nil, false, c.err
+	}
+	return nil, false, io.EOF
+}
+
+func (c *testConn) Send(data []byte, isBSON bool) error {
+	c.writeMsgs = append(c.writeMsgs, testMsg{data, isBSON})
+	return nil
+}
+
+func (c *testConn) Close() error {
+	c.closed = true
+	return nil
+}
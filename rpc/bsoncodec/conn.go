@@ -0,0 +1,57 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package bsoncodec
+
+import (
+	"golang.org/x/net/websocket"
+)
+
+// NewWebsocket returns an rpc codec that uses the given websocket
+// connection to send and receive messages. BSON messages are sent
+// as binary frames and JSON messages as text frames.
+func NewWebsocket(conn *websocket.Conn) *Codec {
+	return New(wsConn{conn})
+}
+
+type wsConn struct {
+	conn *websocket.Conn
+}
+
+// frame holds the contents of a single websocket message.
+type frame struct {
+	data   []byte
+	isBSON bool
+}
+
+var frameCodec = websocket.Codec{
+	Marshal: func(v interface{}) ([]byte, byte, error) {
+		f := v.(frame)
+		if f.isBSON {
+			return f.data, websocket.BinaryFrame, nil
+		}
+		return f.data, websocket.TextFrame, nil
+	},
+	Unmarshal: func(data []byte, payloadType byte, v interface{}) error {
+		f := v.(*frame)
+		f.data = data
+		f.isBSON = payloadType == websocket.BinaryFrame
+		return nil
+	},
+}
+
+func (conn wsConn) Send(data []byte, isBSON bool) error {
+	return frameCodec.Send(conn.conn, frame{data, isBSON})
+}
+
+func (conn wsConn) Receive() ([]byte, bool, error) {
+	var f frame
+	if err := frameCodec.Receive(conn.conn, &f); err != nil {
+		return nil, false, err
+	}
+	return f.data, f.isBSON, nil
+}
+
+func (conn wsConn) Close() error {
+	return conn.conn.Close()
+}
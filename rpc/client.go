@@ -5,8 +5,10 @@ package rpc
 
 import (
 	"strings"
+	"time"
 
 	"github.com/juju/errors"
+	"golang.org/x/net/context"
 )
 
 var ErrShutdown = errors.New("connection is shut down")
@@ -18,6 +20,12 @@ type Call struct {
 	Response interface{}
 	Error    error
 	Done     chan *Call
+
+	// timeout holds the timeout sent to the server, if any.
+	timeout time.Duration
+
+	// reqId holds the id of the request once it has been sent.
+	reqId uint64
 }
 
 // RequestError represents an error returned from an RPC request.
@@ -54,6 +62,7 @@ func (conn *Conn) send(call *Call) {
 	}
 	conn.reqId++
 	reqId := conn.reqId
+	call.reqId = reqId
 	conn.clientPending[reqId] = call
 	conn.mutex.Unlock()
 
@@ -61,6 +70,7 @@ func (conn *Conn) send(call *Call) {
 	hdr := &Header{
 		RequestId: reqId,
 		Request:   call.Request,
+		Timeout:   call.timeout,
 	}
 	params := call.Params
 	if params == nil {
@@ -148,6 +158,60 @@ func (conn *Conn) Call(req Request, params, response interface{}) error {
 	return errors.Trace(call.Error)
 }
 
+// CallContext is like Call, except that the call is abandoned when
+// the given context is done, in which case the context's error is
+// returned. The server is asked to cancel an abandoned call, and is
+// told the context's deadline, if any, so that it can give up on
+// the call by itself once the deadline has passed.
+func (conn *Conn) CallContext(ctx context.Context, req Request, params, response interface{}) error {
+	if err := ctx.Err(); err != nil {
+		return errors.Trace(err)
+	}
+	call := &Call{
+		Request:  req,
+		Params:   params,
+		Response: response,
+		Done:     make(chan *Call, 1),
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		call.timeout = deadline.Sub(time.Now())
+		if call.timeout <= 0 {
+			return errors.Trace(context.DeadlineExceeded)
+		}
+	}
+	conn.send(call)
+	select {
+	case <-call.Done:
+		return errors.Trace(call.Error)
+	case <-ctx.Done():
+		conn.cancel(call)
+		return errors.Trace(ctx.Err())
+	}
+}
+
+// cancel abandons the given call, asking the server to cancel
+// it if it is still pending.
+func (conn *Conn) cancel(call *Call) {
+	conn.sending.Lock()
+	defer conn.sending.Unlock()
+
+	conn.mutex.Lock()
+	_, pending := conn.clientPending[call.reqId]
+	delete(conn.clientPending, call.reqId)
+	stopped := conn.closing || conn.shutdown
+	conn.mutex.Unlock()
+	if !pending || stopped {
+		return
+	}
+	hdr := &Header{
+		RequestId: call.reqId,
+		Cancel:    true,
+	}
+	if err := conn.codec.WriteMessage(hdr, struct{}{}); err != nil {
+		logger.Debugf("cannot cancel request %d: %v", call.reqId, err)
+	}
+}
+
 // Go invokes the request asynchronously.  It returns the Call structure representing
 // the invocation.  The done channel will signal when the call is complete by returning
 // the same Call object.  If done is nil, Go will allocate a new channel.
@@ -7,6 +7,7 @@ import (
 	"io"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/juju/loggo"
 
@@ -71,19 +72,23 @@ type inMsg struct {
 	Error     string
 	ErrorCode string
 	Response  json.RawMessage
+	Timeout   time.Duration
+	Cancel    bool
 }
 
 // outMsg holds an outgoing message.
 type outMsg struct {
 	RequestId uint64
-	Type      string      `json:",omitempty"`
-	Version   int         `json:",omitempty"`
-	Id        string      `json:",omitempty"`
-	Request   string      `json:",omitempty"`
-	Params    interface{} `json:",omitempty"`
-	Error     string      `json:",omitempty"`
-	ErrorCode string      `json:",omitempty"`
-	Response  interface{} `json:",omitempty"`
+	Type      string        `json:",omitempty"`
+	Version   int           `json:",omitempty"`
+	Id        string        `json:",omitempty"`
+	Request   string        `json:",omitempty"`
+	Params    interface{}   `json:",omitempty"`
+	Error     string        `json:",omitempty"`
+	ErrorCode string        `json:",omitempty"`
+	Response  interface{}   `json:",omitempty"`
+	Timeout   time.Duration `json:",omitempty"`
+	Cancel    bool          `json:",omitempty"`
 }
 
 func (c *Codec) Close() error {
@@ -131,6 +136,8 @@ func (c *Codec) ReadHeader(hdr *rpc.Header) error {
 	}
 	hdr.Error = c.msg.Error
 	hdr.ErrorCode = c.msg.ErrorCode
+	hdr.Timeout = c.msg.Timeout
+	hdr.Cancel = c.msg.Cancel
 	return nil
 }
 
@@ -191,6 +198,8 @@ func (m *outMsg) init(hdr *rpc.Header, body interface{}) {
 	m.Request = hdr.Request.Action
 	m.Error = hdr.Error
 	m.ErrorCode = hdr.ErrorCode
+	m.Timeout = hdr.Timeout
+	m.Cancel = hdr.Cancel
 	if hdr.IsRequest() {
 		m.Params = body
 	} else {
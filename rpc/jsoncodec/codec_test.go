@@ -7,6 +7,7 @@ import (
 	"reflect"
 	"regexp"
 	stdtesting "testing"
+	"time"
 
 	"github.com/juju/loggo"
 	"github.com/juju/testing"
@@ -72,6 +73,24 @@ var readTests = []struct {
 		},
 	},
 	expectBody: &value{X: "param"},
+}, {
+	msg: `{"RequestId": 5, "Type": "foo", "Request": "frob", "Timeout": 5000000000, "Params": {"X": "param"}}`,
+	expectHdr: rpc.Header{
+		RequestId: 5,
+		Request: rpc.Request{
+			Type:   "foo",
+			Action: "frob",
+		},
+		Timeout: 5 * time.Second,
+	},
+	expectBody: &value{X: "param"},
+}, {
+	msg: `{"RequestId": 6, "Cancel": true}`,
+	expectHdr: rpc.Header{
+		RequestId: 6,
+		Cancel:    true,
+	},
+	expectBody: new(map[string]interface{}),
 }}
 
 func (*suite) TestRead(c *gc.C) {
@@ -255,6 +274,23 @@ var writeTests = []struct {
 	},
 	body:   &value{X: "param"},
 	expect: `{"RequestId": 4, "Type": "foo", "Version": 2, "Request": "frob", "Params": {"X": "param"}}`,
+}, {
+	hdr: &rpc.Header{
+		RequestId: 5,
+		Request: rpc.Request{
+			Type:   "foo",
+			Action: "frob",
+		},
+		Timeout: 5 * time.Second,
+	},
+	body:   &value{X: "param"},
+	expect: `{"RequestId": 5, "Type": "foo", "Request": "frob", "Params": {"X": "param"}, "Timeout": 5000000000}`,
+}, {
+	hdr: &rpc.Header{
+		RequestId: 6,
+		Cancel:    true,
+	},
+	expect: `{"RequestId": 6, "Cancel": true}`,
 }}
 
 func (*suite) TestWrite(c *gc.C) {
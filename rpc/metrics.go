@@ -0,0 +1,255 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package rpc
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultLatencyBuckets holds the upper bounds, in seconds, of the
+// buckets into which call latencies are counted by default.
+var DefaultLatencyBuckets = []float64{
+	0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10,
+}
+
+// unboundRequest is the request under which the metrics for calls
+// that did not bind to a method are collected.
+var unboundRequest = Request{Type: "unknown", Action: "unknown"}
+
+// Metrics collects statistics about the requests served by RPC
+// connections. A single Metrics may be shared by many connections,
+// and is safe for concurrent use.
+type Metrics struct {
+	buckets []float64
+
+	mu    sync.Mutex
+	calls map[callKey]*callStats
+}
+
+// callKey identifies the method called by a request.
+type callKey struct {
+	facade  string
+	version int
+	method  string
+}
+
+// callStats holds the statistics for a single method.
+type callStats struct {
+	count      int64
+	inFlight   int64
+	errorCodes map[string]int64
+	latencies  []int64
+	latencySum time.Duration
+}
+
+// CallMetrics holds the statistics collected for a single method.
+type CallMetrics struct {
+	// Facade, Version and Method identify the method.
+	Facade  string
+	Version int
+	Method  string
+
+	// Count holds the number of calls that have completed.
+	Count int64
+
+	// InFlight holds the number of calls that are being served.
+	InFlight int64
+
+	// ErrorCodes holds the number of calls that failed, by error
+	// code. Errors without a code are counted under "unknown".
+	ErrorCodes map[string]int64
+
+	// Buckets holds the upper bounds, in seconds, of the latency
+	// buckets.
+	Buckets []float64
+
+	// LatencyBuckets holds the number of completed calls that took
+	// at most the corresponding number of seconds in Buckets.
+	LatencyBuckets []int64
+
+	// LatencySum holds the total time spent serving completed
+	// calls.
+	LatencySum time.Duration
+}
+
+// NewMetrics returns a Metrics that counts call latencies into
+// buckets with the given upper bounds, in seconds, which must be
+// sorted. If no buckets are given, DefaultLatencyBuckets are used.
+func NewMetrics(buckets ...float64) *Metrics {
+	if len(buckets) == 0 {
+		buckets = DefaultLatencyBuckets
+	}
+	return &Metrics{
+		buckets: buckets,
+		calls:   make(map[callKey]*callStats),
+	}
+}
+
+func (m *Metrics) stats(req Request) *callStats {
+	key := callKey{req.Type, req.Version, req.Action}
+	stats := m.calls[key]
+	if stats == nil {
+		stats = &callStats{
+			errorCodes: make(map[string]int64),
+			latencies:  make([]int64, len(m.buckets)),
+		}
+		m.calls[key] = stats
+	}
+	return stats
+}
+
+// callStarted records that a call to the requested method is being
+// served.
+func (m *Metrics) callStarted(req Request) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.stats(req).inFlight++
+}
+
+// callDone records that a call recorded with callStarted is no longer
+// being served.
+func (m *Metrics) callDone(req Request) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.stats(req).inFlight--
+}
+
+// callReplied records the reply to a call to the requested method.
+func (m *Metrics) callReplied(req Request, hdr *Header, timeSpent time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	stats := m.stats(req)
+	stats.count++
+	if hdr.Error != "" {
+		code := hdr.ErrorCode
+		if code == "" {
+			code = "unknown"
+		}
+		stats.errorCodes[code]++
+	}
+	seconds := timeSpent.Seconds()
+	for i, bound := range m.buckets {
+		if seconds <= bound {
+			stats.latencies[i]++
+		}
+	}
+	stats.latencySum += timeSpent
+}
+
+// Calls returns the statistics collected for each method that has
+// been called, ordered by facade, version and method.
+func (m *Metrics) Calls() []CallMetrics {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	calls := make([]CallMetrics, 0, len(m.calls))
+	for key, stats := range m.calls {
+		errorCodes := make(map[string]int64)
+		for code, count := range stats.errorCodes {
+			errorCodes[code] = count
+		}
+		calls = append(calls, CallMetrics{
+			Facade:         key.facade,
+			Version:        key.version,
+			Method:         key.method,
+			Count:          stats.count,
+			InFlight:       stats.inFlight,
+			ErrorCodes:     errorCodes,
+			Buckets:        m.buckets,
+			LatencyBuckets: append([]int64(nil), stats.latencies...),
+			LatencySum:     stats.latencySum,
+		})
+	}
+	sort.Sort(byMethod(calls))
+	return calls
+}
+
+type byMethod []CallMetrics
+
+func (b byMethod) Len() int      { return len(b) }
+func (b byMethod) Swap(i, j int) { b[i], b[j] = b[j], b[i] }
+func (b byMethod) Less(i, j int) bool {
+	if b[i].Facade != b[j].Facade {
+		return b[i].Facade < b[j].Facade
+	}
+	if b[i].Version != b[j].Version {
+		return b[i].Version < b[j].Version
+	}
+	return b[i].Method < b[j].Method
+}
+
+// WritePrometheus writes the statistics to w in the Prometheus text
+// exposition format.
+func (m *Metrics) WritePrometheus(w io.Writer) error {
+	calls := m.Calls()
+	bw := bufio.NewWriter(w)
+	p := func(format string, args ...interface{}) {
+		fmt.Fprintf(bw, format, args...)
+	}
+
+	p("# HELP juju_rpc_calls_total Number of RPC calls served.\n")
+	p("# TYPE juju_rpc_calls_total counter\n")
+	for _, call := range calls {
+		p("juju_rpc_calls_total{%s} %d\n", callLabels(call), call.Count)
+	}
+
+	p("# HELP juju_rpc_calls_in_flight Number of RPC calls being served.\n")
+	p("# TYPE juju_rpc_calls_in_flight gauge\n")
+	for _, call := range calls {
+		p("juju_rpc_calls_in_flight{%s} %d\n", callLabels(call), call.InFlight)
+	}
+
+	p("# HELP juju_rpc_call_errors_total Number of RPC calls that failed, by error code.\n")
+	p("# TYPE juju_rpc_call_errors_total counter\n")
+	for _, call := range calls {
+		codes := make([]string, 0, len(call.ErrorCodes))
+		for code := range call.ErrorCodes {
+			codes = append(codes, code)
+		}
+		sort.Strings(codes)
+		for _, code := range codes {
+			p("juju_rpc_call_errors_total{%s,code=%s} %d\n",
+				callLabels(call), quoteLabel(code), call.ErrorCodes[code])
+		}
+	}
+
+	p("# HELP juju_rpc_call_duration_seconds Time taken to serve RPC calls.\n")
+	p("# TYPE juju_rpc_call_duration_seconds histogram\n")
+	for _, call := range calls {
+		labels := callLabels(call)
+		for i, bound := range call.Buckets {
+			p("juju_rpc_call_duration_seconds_bucket{%s,le=%s} %d\n",
+				labels, quoteLabel(formatFloat(bound)), call.LatencyBuckets[i])
+		}
+		p("juju_rpc_call_duration_seconds_bucket{%s,le=\"+Inf\"} %d\n", labels, call.Count)
+		p("juju_rpc_call_duration_seconds_sum{%s} %s\n", labels, formatFloat(call.LatencySum.Seconds()))
+		p("juju_rpc_call_duration_seconds_count{%s} %d\n", labels, call.Count)
+	}
+	return bw.Flush()
+}
+
+func callLabels(call CallMetrics) string {
+	return fmt.Sprintf("facade=%s,version=%s,method=%s",
+		quoteLabel(call.Facade),
+		quoteLabel(strconv.Itoa(call.Version)),
+		quoteLabel(call.Method),
+	)
+}
+
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// quoteLabel returns v quoted as a Prometheus label value.
+func quoteLabel(v string) string {
+	return `"` + labelEscaper.Replace(v) + `"`
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
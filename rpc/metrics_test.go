@@ -0,0 +1,137 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package rpc_test
+
+import (
+	"bytes"
+	"strings"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/rpc"
+)
+
+type metricsSuite struct{}
+
+var _ = gc.Suite(&metricsSuite{})
+
+func (*metricsSuite) TestCalls(c *gc.C) {
+	root := &Root{
+		simple:    make(map[string]*SimpleMethods),
+		errorInst: &ErrorMethods{&codedError{"message", "code"}},
+	}
+	root.simple["a"] = &SimpleMethods{root: root, id: "a"}
+	metrics := rpc.NewMetrics(1000)
+	client, srvDone, _, _ := newRPCClientServerWithMetrics(c, root, nil, false, metrics)
+
+	for i := 0; i < 2; i++ {
+		err := client.Call(rpc.Request{"SimpleMethods", 0, "a", "Call0r0"}, nil, nil)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	err := client.Call(rpc.Request{"ErrorMethods", 0, "", "Call"}, nil, nil)
+	c.Assert(err, gc.ErrorMatches, `message \(code\)`)
+	// Calls that do not bind to a method are all counted together,
+	// so that clients cannot create metrics with arbitrary labels.
+	err = client.Call(rpc.Request{"SimpleMethods", 0, "a", "NoMethod"}, nil, nil)
+	c.Assert(err, gc.NotNil)
+	err = client.Call(rpc.Request{"NoFacade", 99, "", "Anything"}, nil, nil)
+	c.Assert(err, gc.NotNil)
+	closeClient(c, client, srvDone)
+
+	calls := metrics.Calls()
+	for i := range calls {
+		// The time taken varies from run to run.
+		calls[i].LatencySum = 0
+	}
+	c.Assert(calls, jc.DeepEquals, []rpc.CallMetrics{{
+		Facade:         "ErrorMethods",
+		Method:         "Call",
+		Count:          1,
+		ErrorCodes:     map[string]int64{"code": 1},
+		Buckets:        []float64{1000},
+		LatencyBuckets: []int64{1},
+	}, {
+		Facade:         "SimpleMethods",
+		Method:         "Call0r0",
+		Count:          2,
+		ErrorCodes:     map[string]int64{},
+		Buckets:        []float64{1000},
+		LatencyBuckets: []int64{2},
+	}, {
+		Facade:         "unknown",
+		Method:         "unknown",
+		Count:          2,
+		ErrorCodes:     map[string]int64{rpc.CodeNotImplemented: 2},
+		Buckets:        []float64{1000},
+		LatencyBuckets: []int64{2},
+	}})
+}
+
+func (*metricsSuite) TestInFlight(c *gc.C) {
+	ready := make(chan struct{})
+	done := make(chan string)
+	root := &Root{
+		delayed: map[string]*DelayedMethods{
+			"1": {ready: ready, done: done},
+		},
+	}
+	metrics := rpc.NewMetrics()
+	client, srvDone, _, _ := newRPCClientServerWithMetrics(c, root, nil, false, metrics)
+	defer closeClient(c, client, srvDone)
+
+	result := make(chan error)
+	go func() {
+		result <- client.Call(rpc.Request{"DelayedMethods", 0, "1", "Delay"}, nil, nil)
+	}()
+	chanRead(c, ready, "method ready")
+
+	calls := metrics.Calls()
+	c.Assert(calls, gc.HasLen, 1)
+	c.Assert(calls[0].InFlight, gc.Equals, int64(1))
+	c.Assert(calls[0].Count, gc.Equals, int64(0))
+
+	done <- "finished"
+	c.Assert(chanReadError(c, result, "method result"), jc.ErrorIsNil)
+	calls = metrics.Calls()
+	c.Assert(calls[0].InFlight, gc.Equals, int64(0))
+	c.Assert(calls[0].Count, gc.Equals, int64(1))
+}
+
+func (*metricsSuite) TestWritePrometheus(c *gc.C) {
+	root := &Root{
+		errorInst: &ErrorMethods{&codedError{"message", `a "quoted" code`}},
+	}
+	metrics := rpc.NewMetrics(1000)
+	client, srvDone, _, _ := newRPCClientServerWithMetrics(c, root, nil, false, metrics)
+	err := client.Call(rpc.Request{"ErrorMethods", 0, "", "Call"}, nil, nil)
+	c.Assert(err, gc.NotNil)
+	closeClient(c, client, srvDone)
+
+	var buf bytes.Buffer
+	err = metrics.WritePrometheus(&buf)
+	c.Assert(err, jc.ErrorIsNil)
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	c.Assert(lines, gc.HasLen, 15)
+	const labels = `facade="ErrorMethods",version="0",method="Call"`
+	c.Check(lines[:8], jc.DeepEquals, []string{
+		"# HELP juju_rpc_calls_total Number of RPC calls served.",
+		"# TYPE juju_rpc_calls_total counter",
+		"juju_rpc_calls_total{" + labels + "} 1",
+		"# HELP juju_rpc_calls_in_flight Number of RPC calls being served.",
+		"# TYPE juju_rpc_calls_in_flight gauge",
+		"juju_rpc_calls_in_flight{" + labels + "} 0",
+		"# HELP juju_rpc_call_errors_total Number of RPC calls that failed, by error code.",
+		"# TYPE juju_rpc_call_errors_total counter",
+	})
+	c.Check(lines[8], gc.Equals, "juju_rpc_call_errors_total{"+labels+`,code="a \"quoted\" code"} 1`)
+	c.Check(lines[9:13], jc.DeepEquals, []string{
+		"# HELP juju_rpc_call_duration_seconds Time taken to serve RPC calls.",
+		"# TYPE juju_rpc_call_duration_seconds histogram",
+		"juju_rpc_call_duration_seconds_bucket{" + labels + `,le="1000"} 1`,
+		"juju_rpc_call_duration_seconds_bucket{" + labels + `,le="+Inf"} 1`,
+	})
+	c.Check(lines[13], gc.Matches, `juju_rpc_call_duration_seconds_sum\{.*\} [0-9.e-]+`)
+	c.Check(lines[14], gc.Equals, "juju_rpc_call_duration_seconds_count{"+labels+"} 1")
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	jc "github.com/juju/testing/checkers"
+	"golang.org/x/net/context"
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/rpc"
@@ -198,6 +199,7 @@ type DelayedMethods struct {
 	ready     chan struct{}
 	done      chan string
 	doneError chan error
+	cancelled chan error
 }
 
 func (a *DelayedMethods) Delay() (stringVal, error) {
@@ -212,6 +214,27 @@ func (a *DelayedMethods) Delay() (stringVal, error) {
 	}
 }
 
+// Wait waits until its context is done, then reports
+// the context's error on the cancelled channel.
+func (a *DelayedMethods) Wait(ctx context.Context) error {
+	if a.ready != nil {
+		a.ready <- struct{}{}
+	}
+	<-ctx.Done()
+	a.cancelled <- ctx.Err()
+	return ctx.Err()
+}
+
+// Deadline returns the time remaining before its
+// context's deadline, or an error if it has none.
+func (a *DelayedMethods) Deadline(ctx context.Context) (stringVal, error) {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return stringVal{}, errors.New("no deadline")
+	}
+	return stringVal{deadline.Sub(time.Now()).String()}, nil
+}
+
 type ErrorMethods struct {
 	err error
 }
@@ -301,7 +324,7 @@ func (c customMethodCaller) ResultType() reflect.Type {
 	return c.objMethod.Result
 }
 
-func (c customMethodCaller) Call(objId string, arg reflect.Value) (reflect.Value, error) {
+func (c customMethodCaller) Call(ctx context.Context, objId string, arg reflect.Value) (reflect.Value, error) {
 	sm, err := c.root.SimpleMethods(objId)
 	if err != nil {
 		return reflect.Value{}, err
@@ -311,7 +334,7 @@ func (c customMethodCaller) Call(objId string, arg reflect.Value) (reflect.Value
 		logger.Errorf("got the wrong type back, expected %s got %T", c.expectedType, obj)
 	}
 	logger.Debugf("calling: %T %v %#v", obj, obj, c.objMethod)
-	return c.objMethod.Call(obj, arg)
+	return c.objMethod.Call(ctx, obj, arg)
 }
 
 func (cc *CustomMethodFinder) FindMethod(
@@ -854,6 +877,67 @@ func (*rpcSuite) TestServerWaitsForOutstandingCalls(c *gc.C) {
 	start <- "xxx"
 }
 
+func (*rpcSuite) TestCallContextCancel(c *gc.C) {
+	ready := make(chan struct{})
+	cancelled := make(chan error, 1)
+	root := &Root{
+		delayed: map[string]*DelayedMethods{
+			"1": {ready: ready, cancelled: cancelled},
+		},
+	}
+	client, srvDone, _, _ := newRPCClientServer(c, root, nil, false)
+	defer closeClient(c, client, srvDone)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	result := make(chan error)
+	go func() {
+		result <- client.CallContext(ctx, rpc.Request{"DelayedMethods", 0, "1", "Wait"}, nil, nil)
+	}()
+	chanRead(c, ready, "DelayedMethods.Wait ready")
+	cancel()
+
+	err := chanReadError(c, result, "call result")
+	c.Assert(errors.Cause(err), gc.Equals, context.Canceled)
+	err = chanReadError(c, cancelled, "server cancellation")
+	c.Assert(err, gc.Equals, context.Canceled)
+
+	// The connection is still usable after a cancelled call.
+	var r stringVal
+	err = client.Call(rpc.Request{"DelayedMethods", 0, "1", "Deadline"}, nil, &r)
+	c.Assert(err, gc.ErrorMatches, "no deadline")
+}
+
+func (*rpcSuite) TestCallContextAlreadyDone(c *gc.C) {
+	root := &Root{}
+	client, srvDone, clientNotifier, _ := newRPCClientServer(c, root, nil, false)
+	defer closeClient(c, client, srvDone)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err := client.CallContext(ctx, rpc.Request{"DelayedMethods", 0, "1", "Wait"}, nil, nil)
+	c.Assert(errors.Cause(err), gc.Equals, context.Canceled)
+	c.Assert(clientNotifier.clientRequests, gc.HasLen, 0)
+}
+
+func (*rpcSuite) TestCallContextDeadline(c *gc.C) {
+	root := &Root{
+		delayed: map[string]*DelayedMethods{
+			"1": {},
+		},
+	}
+	client, srvDone, _, _ := newRPCClientServer(c, root, nil, false)
+	defer closeClient(c, client, srvDone)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	var r stringVal
+	err := client.CallContext(ctx, rpc.Request{"DelayedMethods", 0, "1", "Deadline"}, nil, &r)
+	c.Assert(err, jc.ErrorIsNil)
+	remaining, err := time.ParseDuration(r.Val)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(remaining > 0 && remaining <= time.Minute, jc.IsTrue, gc.Commentf("remaining %v", remaining))
+}
+
 func chanRead(c *gc.C, ch <-chan struct{}, what string) {
 	select {
 	case <-ch:
@@ -1183,6 +1267,12 @@ func chanReadError(c *gc.C, ch <-chan error, what string) error {
 // it sends a value on the returned channel.
 // If bidir is true, requests can flow in both directions.
 func newRPCClientServer(c *gc.C, root interface{}, tfErr func(error) error, bidir bool) (client *rpc.Conn, srvDone chan error, clientNotifier, serverNotifier *notifier) {
+	return newRPCClientServerWithMetrics(c, root, tfErr, bidir, nil)
+}
+
+// newRPCClientServerWithMetrics is like newRPCClientServer, but the
+// server collects statistics in the given metrics.
+func newRPCClientServerWithMetrics(c *gc.C, root interface{}, tfErr func(error) error, bidir bool, metrics *rpc.Metrics) (client *rpc.Conn, srvDone chan error, clientNotifier, serverNotifier *notifier) {
 	l, err := net.Listen("tcp", "127.0.0.1:0")
 	c.Assert(err, jc.ErrorIsNil)
 
@@ -1201,6 +1291,7 @@ func newRPCClientServer(c *gc.C, root interface{}, tfErr func(error) error, bidi
 			role = roleBoth
 		}
 		rpcConn := rpc.NewConn(NewJSONCodec(conn, role), serverNotifier)
+		rpcConn.SetMetrics(metrics)
 		if custroot, ok := root.(*CustomMethodFinder); ok {
 			rpcConn.ServeFinder(custroot, tfErr)
 			custroot.root.conn = rpcConn
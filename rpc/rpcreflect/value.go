@@ -6,6 +6,8 @@ package rpcreflect
 import (
 	"fmt"
 	"reflect"
+
+	"golang.org/x/net/context"
 )
 
 // CallNotImplementedError is an error, returned an attempt to call to
@@ -107,12 +109,12 @@ func (v Value) FindMethod(rootMethodName string, version int, objMethodName stri
 	return caller, nil
 }
 
-func (caller methodCaller) Call(objId string, arg reflect.Value) (reflect.Value, error) {
+func (caller methodCaller) Call(ctx context.Context, objId string, arg reflect.Value) (reflect.Value, error) {
 	obj, err := caller.rootMethod.Call(caller.rootValue, objId)
 	if err != nil {
 		return reflect.Value{}, err
 	}
-	return caller.objMethod.Call(obj, arg)
+	return caller.objMethod.Call(ctx, obj, arg)
 }
 
 func (caller methodCaller) ParamsType() reflect.Type {
@@ -131,6 +133,7 @@ type MethodCaller interface {
 	ResultType() reflect.Type
 
 	// Call is actually placing a call to instantiate an given instance and
-	// call the method on that instance.
-	Call(objId string, arg reflect.Value) (reflect.Value, error)
+	// call the method on that instance. The context is passed to
+	// methods that take one; it must not be nil.
+	Call(ctx context.Context, objId string, arg reflect.Value) (reflect.Value, error)
 }
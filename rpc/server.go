@@ -11,6 +11,7 @@ import (
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
+	"golang.org/x/net/context"
 
 	"github.com/juju/juju/rpc/rpcreflect"
 )
@@ -62,6 +63,16 @@ type Header struct {
 
 	// ErrorCode holds the code of the error, if any.
 	ErrorCode string
+
+	// Timeout holds, in a request, how long the caller is
+	// prepared to wait for the reply. If it is non-zero, the
+	// server cancels the call once it has elapsed.
+	Timeout time.Duration
+
+	// Cancel is set on a message asking for the request with
+	// the given RequestId to be cancelled. Such a message is
+	// neither a request nor a response, and has no body.
+	Cancel bool
 }
 
 // Request represents an RPC to be performed, absent its parameters.
@@ -99,9 +110,18 @@ type Conn struct {
 	// notifier is informed about RPC requests. It may be nil.
 	notifier RequestNotifier
 
+	// metrics, if not nil, collects statistics about the requests
+	// served by the connection.
+	metrics *Metrics
+
 	// srvPending represents the current server requests.
 	srvPending sync.WaitGroup
 
+	// srvContext is the context from which the contexts of all
+	// server requests are derived. It is cancelled by Close.
+	srvContext       context.Context
+	cancelSrvContext context.CancelFunc
+
 	// sending guards the write side of the codec - it ensures
 	// that codec.WriteMessage is not called concurrently.
 	// It also guards shutdown.
@@ -130,6 +150,10 @@ type Conn struct {
 	// clientPending holds all pending client requests.
 	clientPending map[uint64]*Call
 
+	// srvCancel holds the functions that cancel the server
+	// requests that are currently running, by request id.
+	srvCancel map[uint64]context.CancelFunc
+
 	// closing is set when the connection is shutting down via
 	// Close.  When this is set, no more client or server requests
 	// will be initiated.
@@ -191,13 +215,23 @@ type RequestNotifier interface {
 // any requests are sent or received. If notifier is non-nil, the
 // appropriate method will be called for every RPC request.
 func NewConn(codec Codec, notifier RequestNotifier) *Conn {
+	ctx, cancel := context.WithCancel(context.Background())
 	return &Conn{
-		codec:         codec,
-		clientPending: make(map[uint64]*Call),
-		notifier:      notifier,
+		codec:            codec,
+		clientPending:    make(map[uint64]*Call),
+		srvCancel:        make(map[uint64]context.CancelFunc),
+		srvContext:       ctx,
+		cancelSrvContext: cancel,
+		notifier:         notifier,
 	}
 }
 
+// SetMetrics causes statistics about the requests served by the
+// connection to be collected in m. It must be called before Start.
+func (conn *Conn) SetMetrics(m *Metrics) {
+	conn.metrics = m
+}
+
 // Start starts the RPC connection running.  It must be called at least
 // once for any RPC connection (client or server side) It has no effect
 // if it has already been called.  By default, a connection serves no
@@ -240,6 +274,11 @@ func (conn *Conn) Start() {
 //	Method(T) (R, error)
 //	Method(T) error
 //
+// Any of these forms may also take a context.Context as its first
+// argument. The context is cancelled when the client cancels the
+// call, when the timeout given by the client expires, or when the
+// connection is closed.
+//
 // If transformErrors is non-nil, it will be called on all returned
 // non-nil errors, for example to transform the errors into ServerErrors
 // with specified codes.  There will be a panic if transformErrors
@@ -317,6 +356,7 @@ func (conn *Conn) Close() error {
 	}
 	conn.closing = true
 	conn.killRequests()
+	conn.cancelSrvContext()
 	conn.mutex.Unlock()
 
 	// Wait for any outstanding server requests to complete
@@ -410,10 +450,14 @@ func (conn *Conn) loop() error {
 			logger.Tracef("codec.ReadHeader error: %v", err)
 			return err
 		}
-		if hdr.IsRequest() {
+		switch {
+		case hdr.IsRequest():
 			err = conn.handleRequest(&hdr)
 			logger.Tracef("codec.handleRequest %#v error: %v", hdr, err)
-		} else {
+		case hdr.Cancel:
+			err = conn.handleCancel(&hdr)
+			logger.Tracef("codec.handleCancel %#v error: %v", hdr, err)
+		default:
 			err = conn.handleResponse(&hdr)
 			logger.Tracef("codec.handleResponse %#v error: %v", hdr, err)
 		}
@@ -442,7 +486,7 @@ func (conn *Conn) handleRequest(hdr *Header) error {
 		}
 		// We don't transform the error here. bindRequest will have
 		// already transformed it and returned a zero req.
-		return conn.writeErrorResponse(hdr, err, startTime)
+		return conn.writeErrorResponse(hdr, false, err, startTime)
 	}
 	var argp interface{}
 	var arg reflect.Value
@@ -468,7 +512,7 @@ func (conn *Conn) handleRequest(hdr *Header) error {
 		// the error is actually a framing or syntax
 		// problem, then the next ReadHeader should pick
 		// up the problem and abort.
-		return conn.writeErrorResponse(hdr, req.transformErrors(err), startTime)
+		return conn.writeErrorResponse(hdr, true, req.transformErrors(err), startTime)
 	}
 	if conn.notifier != nil {
 		if req.ParamsType() != nil {
@@ -481,17 +525,46 @@ func (conn *Conn) handleRequest(hdr *Header) error {
 	closing := conn.closing
 	if !closing {
 		conn.srvPending.Add(1)
-		go conn.runRequest(req, arg, startTime)
+		if conn.metrics != nil {
+			conn.metrics.callStarted(hdr.Request)
+		}
+		ctx, cancel := conn.requestContext(hdr)
+		conn.srvCancel[hdr.RequestId] = cancel
+		go conn.runRequest(ctx, req, arg, startTime)
 	}
 	conn.mutex.Unlock()
 	if closing {
 		// We're closing down - no new requests may be initiated.
-		return conn.writeErrorResponse(hdr, req.transformErrors(ErrShutdown), startTime)
+		return conn.writeErrorResponse(hdr, true, req.transformErrors(ErrShutdown), startTime)
 	}
 	return nil
 }
 
-func (conn *Conn) writeErrorResponse(reqHdr *Header, err error, startTime time.Time) error {
+// requestContext returns the context for the server request with
+// the given header, and a function that cancels it.
+func (conn *Conn) requestContext(hdr *Header) (context.Context, context.CancelFunc) {
+	if hdr.Timeout > 0 {
+		return context.WithTimeout(conn.srvContext, hdr.Timeout)
+	}
+	return context.WithCancel(conn.srvContext)
+}
+
+// handleCancel cancels the server request identified
+// by the given header, if it is still running.
+func (conn *Conn) handleCancel(hdr *Header) error {
+	conn.mutex.Lock()
+	cancel := conn.srvCancel[hdr.RequestId]
+	conn.mutex.Unlock()
+	if cancel != nil {
+		cancel()
+	}
+	return conn.readBody(nil, false)
+}
+
+// writeErrorResponse replies to the request with the given header
+// with an error. If bound is false, the request did not bind to a
+// method.
+func (conn *Conn) writeErrorResponse(reqHdr *Header, bound bool, err error, startTime time.Time) error {
 	conn.sending.Lock()
 	defer conn.sending.Unlock()
 	hdr := &Header{
@@ -503,9 +576,7 @@ func (conn *Conn) writeErrorResponse(reqHdr *Header, err error, startTime time.T
 		hdr.ErrorCode = ""
 	}
 	hdr.Error = err.Error()
-	if conn.notifier != nil {
-		conn.notifier.ServerReply(reqHdr.Request, hdr, struct{}{}, time.Since(startTime))
-	}
+	conn.serverReply(reqHdr.Request, bound, hdr, struct{}{}, time.Since(startTime))
 	return conn.codec.WriteMessage(hdr, struct{}{})
 }
 
@@ -549,11 +620,15 @@ func (conn *Conn) bindRequest(hdr *Header) (boundRequest, error) {
 }
 
 // runRequest runs the given request and sends the reply.
-func (conn *Conn) runRequest(req boundRequest, arg reflect.Value, startTime time.Time) {
+func (conn *Conn) runRequest(ctx context.Context, req boundRequest, arg reflect.Value, startTime time.Time) {
 	defer conn.srvPending.Done()
-	rv, err := req.Call(req.hdr.Request.Id, arg)
+	if conn.metrics != nil {
+		defer conn.metrics.callDone(req.hdr.Request)
+	}
+	defer conn.requestDone(req.hdr.RequestId)
+	rv, err := req.Call(ctx, req.hdr.Request.Id, arg)
 	if err != nil {
-		err = conn.writeErrorResponse(&req.hdr, req.transformErrors(err), startTime)
+		err = conn.writeErrorResponse(&req.hdr, true, req.transformErrors(err), startTime)
 	} else {
 		hdr := &Header{
 			RequestId: req.hdr.RequestId,
@@ -564,9 +639,7 @@ func (conn *Conn) runRequest(req boundRequest, arg reflect.Value, startTime time
 		} else {
 			rvi = struct{}{}
 		}
-		if conn.notifier != nil {
-			conn.notifier.ServerReply(req.hdr.Request, hdr, rvi, time.Since(startTime))
-		}
+		conn.serverReply(req.hdr.Request, true, hdr, rvi, time.Since(startTime))
 		conn.sending.Lock()
 		err = conn.codec.WriteMessage(hdr, rvi)
 		conn.sending.Unlock()
@@ -576,6 +649,33 @@ func (conn *Conn) runRequest(req boundRequest, arg reflect.Value, startTime time
 	}
 }
 
+// requestDone releases the context of the server request
+// with the given id.
+func (conn *Conn) requestDone(reqId uint64) {
+	conn.mutex.Lock()
+	defer conn.mutex.Unlock()
+	if cancel := conn.srvCancel[reqId]; cancel != nil {
+		cancel()
+		delete(conn.srvCancel, reqId)
+	}
+}
+
+// serverReply informs the notifier and metrics, if any, of a reply
+// to a server request. If bound is false, the request did not bind
+// to a method, and is counted in the metrics as unboundRequest so
+// that clients cannot add arbitrary labels to them.
+func (conn *Conn) serverReply(req Request, bound bool, hdr *Header, body interface{}, timeSpent time.Duration) {
+	if conn.notifier != nil {
+		conn.notifier.ServerReply(req, hdr, body, timeSpent)
+	}
+	if conn.metrics != nil {
+		if !bound {
+			req = unboundRequest
+		}
+		conn.metrics.callReplied(req, hdr, timeSpent)
+	}
+}
+
 type serverError struct {
 	error
 }
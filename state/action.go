@@ -9,6 +9,7 @@ import (
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"github.com/juju/names"
+	jujutxn "github.com/juju/txn"
 	"github.com/juju/utils"
 	"gopkg.in/mgo.v2"
 	"gopkg.in/mgo.v2/bson"
@@ -31,9 +32,14 @@ const (
 	// ActionCompleted indicates that the action ran to completion as intended.
 	ActionCompleted ActionStatus = "completed"
 
-	// ActionCancelled means that the Action was cancelled before being run.
+	// ActionCancelled means that the Action was cancelled before it
+	// completed.
 	ActionCancelled ActionStatus = "cancelled"
 
+	// ActionTimedOut indicates that the Action was stopped because it
+	// ran for longer than its timeout.
+	ActionTimedOut ActionStatus = "timed-out"
+
 	// ActionPending is the default status when an Action is first queued.
 	ActionPending ActionStatus = "pending"
 
@@ -59,6 +65,11 @@ type actionNotificationDoc struct {
 	// ActionID is the unique identifier for the Action this notification
 	// represents.
 	ActionID string `bson:"actionid"`
+
+	// CancelRequested is set when the running Action has been asked
+	// to stop, so that watchers of the receiver's notifications see
+	// the request.
+	CancelRequested bool `bson:"cancel-requested,omitempty"`
 }
 
 type actionDoc struct {
@@ -83,6 +94,20 @@ type actionDoc struct {
 	// Enqueued is the time the action was added.
 	Enqueued time.Time `bson:"enqueued"`
 
+	// Timeout holds how long the action may run for before it is
+	// stopped. Zero means that it may run indefinitely.
+	Timeout time.Duration `bson:"timeout,omitempty"`
+
+	// CancelRequested is set when a running action has been asked
+	// to stop. The unit running it is responsible for stopping it
+	// and recording it as cancelled.
+	CancelRequested bool `bson:"cancel-requested,omitempty"`
+
+	// Group identifies the set of actions, queued together on the
+	// units of a service, to which this action belongs. It is empty
+	// for an action queued on its own.
+	Group string `bson:"group,omitempty"`
+
 	// Started reflects the time the action began running.
 	Started time.Time `bson:"started"`
 
@@ -137,6 +162,25 @@ func (a *Action) Enqueued() time.Time {
 	return a.doc.Enqueued
 }
 
+// Timeout returns how long the Action may run for before it is
+// stopped, or zero if it may run indefinitely.
+func (a *Action) Timeout() time.Duration {
+	return a.doc.Timeout
+}
+
+// CancelRequested returns whether the running Action has been
+// asked to stop.
+func (a *Action) CancelRequested() bool {
+	return a.doc.CancelRequested
+}
+
+// Group returns the id of the group of Actions, queued together on
+// the units of a service, to which the Action belongs, or the empty
+// string if it was queued on its own.
+func (a *Action) Group() string {
+	return a.doc.Group
+}
+
 // Started returns the time that the Action execution began.
 func (a *Action) Started() time.Time {
 	return a.doc.Started
@@ -202,6 +246,60 @@ func (a *Action) Begin() (*Action, error) {
 	return a.st.Action(a.Id())
 }
 
+// Cancel cancels the action. A pending action is cancelled straight
+// away; a running action is asked to stop, which is reported to the
+// receiver's action notification watchers, and is recorded as
+// cancelled by its receiver once it has done so. It is an error to
+// cancel an action that has already finished.
+func (a *Action) Cancel() (*Action, error) {
+	action := a
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			var err error
+			if action, err = a.st.Action(a.Id()); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		switch status := action.Status(); status {
+		case ActionPending:
+			return action.removeAndLogOps(ActionCancelled, nil, "action cancelled", ActionPending), nil
+		case ActionRunning:
+			if action.CancelRequested() {
+				return nil, jujutxn.ErrNoOperations
+			}
+			return []txn.Op{{
+				C:      actionsC,
+				Id:     action.doc.DocId,
+				Assert: bson.D{{"status", ActionRunning}},
+				Update: bson.D{{"$set", bson.D{{"cancel-requested", true}}}},
+			}, {
+				C:      actionNotificationsC,
+				Id:     a.st.docID(ensureActionMarker(action.Receiver()) + action.Id()),
+				Assert: txn.DocExists,
+				Update: bson.D{{"$set", bson.D{{"cancel-requested", true}}}},
+			}}, nil
+		default:
+			return nil, errors.Errorf("action %s has already %s", action.Id(), finishedVerb(status))
+		}
+	}
+	if err := a.st.run(buildTxn); err != nil {
+		return nil, errors.Annotatef(err, "cannot cancel action %s", a.Id())
+	}
+	return a.st.Action(a.Id())
+}
+
+// finishedVerb describes how an action with the given final
+// status finished.
+func finishedVerb(status ActionStatus) string {
+	switch status {
+	case ActionCancelled:
+		return "been cancelled"
+	case ActionTimedOut:
+		return "timed out"
+	}
+	return string(status)
+}
+
 // Finish removes action from the pending queue and captures the output
 // and end state of the action.
 func (a *Action) Finish(results ActionResults) (*Action, error) {
@@ -212,16 +310,32 @@ func (a *Action) Finish(results ActionResults) (*Action, error) {
 // an actionresult to capture the outcome of the action. It asserts that
 // the action is not already completed.
 func (a *Action) removeAndLog(finalStatus ActionStatus, results map[string]interface{}, message string) (*Action, error) {
-	err := a.st.runTransaction([]txn.Op{
+	err := a.st.runTransaction(a.removeAndLogOps(finalStatus, results, message))
+	if err != nil {
+		return nil, err
+	}
+	return a.st.Action(a.Id())
+}
+
+// removeAndLogOps returns the operations needed by removeAndLog. If
+// any statuses are given, the action must have one of them;
+// otherwise it must not already be finished.
+func (a *Action) removeAndLogOps(finalStatus ActionStatus, results map[string]interface{}, message string, statuses ...ActionStatus) []txn.Op {
+	assert := bson.D{{"status", bson.D{
+		{"$nin", []interface{}{
+			ActionCompleted,
+			ActionCancelled,
+			ActionFailed,
+			ActionTimedOut,
+		}}}}}
+	if len(statuses) > 0 {
+		assert = bson.D{{"status", bson.D{{"$in", statuses}}}}
+	}
+	return []txn.Op{
 		{
-			C:  actionsC,
-			Id: a.doc.DocId,
-			Assert: bson.D{{"status", bson.D{
-				{"$nin", []interface{}{
-					ActionCompleted,
-					ActionCancelled,
-					ActionFailed,
-				}}}}},
+			C:      actionsC,
+			Id:     a.doc.DocId,
+			Assert: assert,
 			Update: bson.D{{"$set", bson.D{
 				{"status", finalStatus},
 				{"message", message},
@@ -232,11 +346,7 @@ func (a *Action) removeAndLog(finalStatus ActionStatus, results map[string]inter
 			C:      actionNotificationsC,
 			Id:     a.st.docID(ensureActionMarker(a.Receiver()) + a.Id()),
 			Remove: true,
-		}})
-	if err != nil {
-		return nil, err
-	}
-	return a.st.Action(a.Id())
+		}}
 }
 
 // newActionTagFromNotification converts an actionNotificationDoc into
@@ -254,8 +364,9 @@ func newAction(st *State, adoc actionDoc) *Action {
 	}
 }
 
-// newActionDoc builds the actionDoc with the given name and parameters.
-func newActionDoc(st *State, receiverTag names.Tag, actionName string, parameters map[string]interface{}) (actionDoc, actionNotificationDoc, error) {
+// newActionDoc builds the actionDoc with the given name, parameters,
+// timeout and group.
+func newActionDoc(st *State, receiverTag names.Tag, actionName string, parameters map[string]interface{}, timeout time.Duration, group string) (actionDoc, actionNotificationDoc, error) {
 	prefix := ensureActionMarker(receiverTag.Id())
 	actionId, err := NewUUID()
 	if err != nil {
@@ -270,6 +381,8 @@ func newActionDoc(st *State, receiverTag names.Tag, actionName string, parameter
 			Name:       actionName,
 			Parameters: parameters,
 			Enqueued:   nowToTheSecond(),
+			Timeout:    timeout,
+			Group:      group,
 			Status:     ActionPending,
 		}, actionNotificationDoc{
 			DocId:     st.docID(prefix + actionId.String()),
@@ -329,8 +442,23 @@ func (st *State) FindActionTagsByPrefix(prefix string) []names.ActionTag {
 	return results
 }
 
-// EnqueueAction
+// EnqueueAction queues an action with the given name and payload
+// for the given receiver.
 func (st *State) EnqueueAction(receiver names.Tag, actionName string, payload map[string]interface{}) (*Action, error) {
+	return st.EnqueueActionWithTimeout(receiver, actionName, payload, 0)
+}
+
+// EnqueueActionWithTimeout is like EnqueueAction, except that the
+// action will be stopped and marked as timed out if it runs for
+// longer than the given timeout. A zero timeout means that the action
+// may run indefinitely.
+func (st *State) EnqueueActionWithTimeout(receiver names.Tag, actionName string, payload map[string]interface{}, timeout time.Duration) (*Action, error) {
+	return st.enqueueAction(receiver, actionName, payload, timeout, "")
+}
+
+// enqueueAction queues an action as a member of the given group, which
+// may be empty.
+func (st *State) enqueueAction(receiver names.Tag, actionName string, payload map[string]interface{}, timeout time.Duration, group string) (*Action, error) {
 	if len(actionName) == 0 {
 		return nil, errors.New("action name required")
 	}
@@ -340,7 +468,10 @@ func (st *State) EnqueueAction(receiver names.Tag, actionName string, payload ma
 		return nil, errors.Trace(err)
 	}
 
-	doc, ndoc, err := newActionDoc(st, receiver, actionName, payload)
+	if timeout < 0 {
+		return nil, errors.NotValidf("negative action timeout %v", timeout)
+	}
+	doc, ndoc, err := newActionDoc(st, receiver, actionName, payload, timeout, group)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -377,6 +508,40 @@ func (st *State) EnqueueAction(receiver names.Tag, actionName string, payload ma
 	return nil, err
 }
 
+// NewActionGroup returns a new id with which Actions queued together
+// on the units of a service can be grouped.
+func NewActionGroup() (string, error) {
+	uuid, err := NewUUID()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return uuid.String(), nil
+}
+
+// ActionsInGroup returns the Actions in the given group.
+func (st *State) ActionsInGroup(group string) ([]*Action, error) {
+	if group == "" {
+		return nil, errors.NotValidf("empty action group")
+	}
+	var doc actionDoc
+	var actions []*Action
+
+	actionsCollection, closer := st.getCollection(actionsC)
+	defer closer()
+
+	iter := actionsCollection.Find(bson.D{{"group", group}}).Iter()
+	for iter.Next(&doc) {
+		actions = append(actions, newAction(st, doc))
+	}
+	if err := iter.Close(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(actions) == 0 {
+		return nil, errors.NotFoundf("action group %q", group)
+	}
+	return actions, nil
+}
+
 // matchingActions finds actions that match ActionReceiver.
 func (st *State) matchingActions(ar ActionReceiver) ([]*Action, error) {
 	return st.matchingActionsByReceiverId(ar.Tag().Id())
@@ -438,6 +603,7 @@ func (st *State) matchingActionsCompleted(ar ActionReceiver) ([]*Action, error)
 		{{"status", ActionCompleted}},
 		{{"status", ActionCancelled}},
 		{{"status", ActionFailed}},
+		{{"status", ActionTimedOut}},
 	}}}
 	return st.matchingActionsByReceiverAndStatus(ar.Tag(), completed)
 }
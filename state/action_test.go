@@ -7,6 +7,7 @@ import (
 	"encoding/hex"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/names"
@@ -463,6 +464,127 @@ func (s *ActionSuite) TestComplete(c *gc.C) {
 	c.Assert(len(actions), gc.Equals, 0)
 }
 
+func (s *ActionSuite) TestAddActionWithTimeout(c *gc.C) {
+	a, err := s.unit.AddActionWithTimeout("snapshot", nil, time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(a.Timeout(), gc.Equals, time.Minute)
+
+	action, err := s.State.Action(a.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(action.Timeout(), gc.Equals, time.Minute)
+
+	_, err = s.unit.AddActionWithTimeout("snapshot", nil, -time.Minute)
+	c.Assert(err, gc.ErrorMatches, "negative action timeout -1m0s not valid")
+}
+
+func (s *ActionSuite) TestActionsInGroup(c *gc.C) {
+	group, err := state.NewActionGroup()
+	c.Assert(err, jc.ErrorIsNil)
+	a1, err := s.unit.AddActionInGroup(group, "snapshot", nil, 0)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(a1.Group(), gc.Equals, group)
+	a2, err := s.unit2.AddActionInGroup(group, "snapshot", nil, 0)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.unit.AddAction("snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	actions, err := s.State.ActionsInGroup(group)
+	c.Assert(err, jc.ErrorIsNil)
+	var ids []string
+	for _, action := range actions {
+		ids = append(ids, action.Id())
+	}
+	c.Assert(ids, jc.SameContents, []string{a1.Id(), a2.Id()})
+
+	_, err = s.State.ActionsInGroup("no-such-group")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	_, err = s.unit.AddActionInGroup("", "snapshot", nil, 0)
+	c.Assert(err, gc.ErrorMatches, "empty action group not valid")
+}
+
+func (s *ActionSuite) TestCancelPending(c *gc.C) {
+	a, err := s.unit.AddAction("snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	cancelled, err := a.Cancel()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cancelled.Status(), gc.Equals, state.ActionCancelled)
+	_, message := cancelled.Results()
+	c.Assert(message, gc.Equals, "action cancelled")
+
+	pending, err := s.unit.PendingActions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(pending, gc.HasLen, 0)
+}
+
+func (s *ActionSuite) TestCancelRunning(c *gc.C) {
+	a, err := s.unit.AddAction("snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	a, err = a.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(a.CancelRequested(), jc.IsFalse)
+
+	a, err = a.Cancel()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(a.Status(), gc.Equals, state.ActionRunning)
+	c.Assert(a.CancelRequested(), jc.IsTrue)
+
+	// Cancelling again is a no-op.
+	a, err = a.Cancel()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(a.CancelRequested(), jc.IsTrue)
+
+	// The unit records the action as cancelled once it has stopped it.
+	a, err = a.Finish(state.ActionResults{Status: state.ActionCancelled})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(a.Status(), gc.Equals, state.ActionCancelled)
+}
+
+func (s *ActionSuite) TestCancelRunningNotifies(c *gc.C) {
+	a, err := s.unit.AddAction("snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	a, err = a.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+
+	w := s.unit.WatchActionNotifications()
+	defer statetesting.AssertStop(c, w)
+	wc := statetesting.NewStringsWatcherC(c, s.State, w)
+	wc.AssertChange(a.Id())
+	wc.AssertNoChange()
+
+	_, err = a.Cancel()
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChange(a.Id())
+	wc.AssertNoChange()
+}
+
+func (s *ActionSuite) TestCancelFinished(c *gc.C) {
+	a, err := s.unit.AddAction("snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	a, err = a.Finish(state.ActionResults{Status: state.ActionTimedOut})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = a.Cancel()
+	c.Assert(err, gc.ErrorMatches, `cannot cancel action .*: action .* has already timed out`)
+}
+
+func (s *ActionSuite) TestTimedOutActionsAreCompleted(c *gc.C) {
+	a, err := s.unit.AddActionWithTimeout("snapshot", nil, time.Second)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = a.Finish(state.ActionResults{Status: state.ActionTimedOut, Message: "timed out"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	completed, err := s.unit.CompletedActions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(completed, gc.HasLen, 1)
+	c.Assert(completed[0].Status(), gc.Equals, state.ActionTimedOut)
+
+	// A timed out action cannot be finished again.
+	_, err = a.Finish(state.ActionResults{Status: state.ActionCompleted})
+	c.Assert(err, gc.NotNil)
+}
+
 func (s *ActionSuite) TestFindActionTagsByPrefix(c *gc.C) {
 	prefix := "feedbeef"
 	uuidMock := uuidMockHelper{}
@@ -852,6 +974,9 @@ var _ state.ActionReceiver = (*mockAR)(nil)
 func (r mockAR) AddAction(name string, payload map[string]interface{}) (*state.Action, error) {
 	return nil, nil
 }
+func (r mockAR) AddActionWithTimeout(name string, payload map[string]interface{}, timeout time.Duration) (*state.Action, error) {
+	return nil, nil
+}
 func (r mockAR) CancelAction(*state.Action) (*state.Action, error) { return nil, nil }
 func (r mockAR) WatchActionNotifications() state.StringsWatcher    { return nil }
 func (r mockAR) Actions() ([]*state.Action, error)                 { return nil, nil }
@@ -0,0 +1,170 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// finishedActionStatuses holds the statuses of Actions that will not
+// change again.
+var finishedActionStatuses = []ActionStatus{
+	ActionCompleted,
+	ActionCancelled,
+	ActionFailed,
+	ActionTimedOut,
+}
+
+// ActionsQuery describes the Actions to be returned by FindActions.
+// Zero values match any Action.
+type ActionsQuery struct {
+	// Receiver holds the name of the unit, or other ActionReceiver,
+	// on which the Actions were queued.
+	Receiver string
+
+	// Name holds the name of the Actions.
+	Name string
+
+	// Statuses holds the statuses the Actions may have.
+	Statuses []ActionStatus
+
+	// EnqueuedAfter and EnqueuedBefore bound the time at which the
+	// Actions were queued.
+	EnqueuedAfter  time.Time
+	EnqueuedBefore time.Time
+
+	// Offset holds the number of matching Actions to skip, and Limit
+	// the greatest number to return.
+	Offset int
+	Limit  int
+}
+
+// FindActions returns the Actions matching the given query, most
+// recently queued first.
+func (st *State) FindActions(query ActionsQuery) ([]*Action, error) {
+	if query.Offset < 0 || query.Limit < 0 {
+		return nil, errors.NotValidf("negative offset or limit")
+	}
+	sel := bson.D{}
+	if query.Receiver != "" {
+		sel = append(sel, bson.DocElem{"receiver", query.Receiver})
+	}
+	if query.Name != "" {
+		sel = append(sel, bson.DocElem{"name", query.Name})
+	}
+	if len(query.Statuses) > 0 {
+		sel = append(sel, bson.DocElem{"status", bson.D{{"$in", query.Statuses}}})
+	}
+	enqueued := bson.D{}
+	if !query.EnqueuedAfter.IsZero() {
+		enqueued = append(enqueued, bson.DocElem{"$gte", query.EnqueuedAfter})
+	}
+	if !query.EnqueuedBefore.IsZero() {
+		enqueued = append(enqueued, bson.DocElem{"$lt", query.EnqueuedBefore})
+	}
+	if len(enqueued) > 0 {
+		sel = append(sel, bson.DocElem{"enqueued", enqueued})
+	}
+
+	actionsCollection, closer := st.getCollection(actionsC)
+	defer closer()
+
+	q := actionsCollection.Find(sel).Sort("-enqueued", "_id").Skip(query.Offset)
+	if query.Limit > 0 {
+		q = q.Limit(query.Limit)
+	}
+	var docs []actionDoc
+	if err := q.All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot find actions")
+	}
+	actions := make([]*Action, len(docs))
+	for i, doc := range docs {
+		actions[i] = newAction(st, doc)
+	}
+	return actions, nil
+}
+
+// pruneActionsBatchSize is the greatest number of Actions removed in
+// a single transaction when pruning.
+var pruneActionsBatchSize = 1000
+
+// PruneActions removes the records of finished Actions that completed
+// more than maxAge ago, and then the oldest of those remaining until
+// at most maxCount are left. A zero maxAge or maxCount disables the
+// corresponding limit. Pending and running Actions are never removed.
+func PruneActions(st *State, maxAge time.Duration, maxCount int) error {
+	actionsCollection, closer := st.getCollection(actionsC)
+	defer closer()
+
+	finished := bson.DocElem{"status", bson.D{{"$in", finishedActionStatuses}}}
+	if maxAge > 0 {
+		cutoff := nowToTheSecond().Add(-maxAge)
+		removed, err := removeFinishedActions(st, actionsCollection.Find(bson.D{
+			finished,
+			{"completed", bson.D{{"$lt", cutoff}}},
+		}))
+		if err != nil {
+			return errors.Annotate(err, "cannot prune actions by age")
+		}
+		actionLogger.Debugf("pruned %d actions completed before %v", removed, cutoff)
+	}
+	if maxCount > 0 {
+		var doc actionDoc
+		err := actionsCollection.Find(bson.D{finished}).Sort("-completed").Skip(maxCount - 1).One(&doc)
+		if err == mgo.ErrNotFound {
+			return nil
+		} else if err != nil {
+			return errors.Annotate(err, "cannot find oldest action to keep")
+		}
+		removed, err := removeFinishedActions(st, actionsCollection.Find(bson.D{
+			finished,
+			{"completed", bson.D{{"$lt", doc.Completed}}},
+		}))
+		if err != nil {
+			return errors.Annotate(err, "cannot prune actions by count")
+		}
+		actionLogger.Debugf("pruned %d actions beyond the newest %d", removed, maxCount)
+	}
+	return nil
+}
+
+// removeFinishedActions removes the Actions matched by the query, which
+// must only match finished Actions, in batches of transactions. It
+// returns the number of Actions removed.
+func removeFinishedActions(st *State, query *mgo.Query) (int, error) {
+	var docs []struct {
+		DocID string `bson:"_id"`
+	}
+	if err := query.Select(bson.D{{"_id", 1}}).All(&docs); err != nil {
+		return 0, errors.Trace(err)
+	}
+	removed := 0
+	for len(docs) > 0 {
+		batch := docs
+		if len(batch) > pruneActionsBatchSize {
+			batch = batch[:pruneActionsBatchSize]
+		}
+		docs = docs[len(batch):]
+
+		ops := make([]txn.Op, len(batch))
+		for i, doc := range batch {
+			ops[i] = txn.Op{
+				C:      actionsC,
+				Id:     st.localID(doc.DocID),
+				Assert: bson.D{{"status", bson.D{{"$in", finishedActionStatuses}}}},
+				Remove: true,
+			}
+		}
+		if err := st.runTransaction(ops); err != nil {
+			return removed, errors.Trace(err)
+		}
+		removed += len(batch)
+	}
+	return removed, nil
+}
@@ -0,0 +1,159 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/mgo.v2/bson"
+
+	"github.com/juju/juju/state"
+)
+
+type ActionHistorySuite struct {
+	ConnSuite
+	unit  *state.Unit
+	unit2 *state.Unit
+}
+
+var _ = gc.Suite(&ActionHistorySuite{})
+
+func (s *ActionHistorySuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	ch := s.AddTestingCharm(c, "dummy")
+	service := s.AddTestingService(c, "dummy", ch)
+	curl, _ := service.CharmURL()
+	var err error
+	s.unit, err = service.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.SetCharmURL(curl)
+	c.Assert(err, jc.ErrorIsNil)
+	s.unit2, err = service.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit2.SetCharmURL(curl)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+// addAction queues an Action on the given unit, and sets the times
+// at which it was queued and, if it has finished, completed.
+func (s *ActionHistorySuite) addAction(c *gc.C, unit *state.Unit, name string, status state.ActionStatus, enqueued time.Time) *state.Action {
+	action, err := unit.AddAction(name, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	if status != state.ActionPending {
+		_, err = action.Finish(state.ActionResults{Status: status})
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	actions, closer := state.GetCollection(s.State, "actions")
+	defer closer()
+	err = actions.Writeable().UpdateId(state.DocID(s.State, action.Id()), bson.D{{"$set", bson.D{
+		{"enqueued", enqueued},
+		{"completed", enqueued.Add(time.Minute)},
+	}}})
+	c.Assert(err, jc.ErrorIsNil)
+	return action
+}
+
+func actionIds(actions []*state.Action) []string {
+	ids := make([]string, len(actions))
+	for i, action := range actions {
+		ids[i] = action.Id()
+	}
+	return ids
+}
+
+func (s *ActionHistorySuite) TestFindActions(c *gc.C) {
+	now := time.Now().Round(time.Second).UTC()
+	a1 := s.addAction(c, s.unit, "snapshot", state.ActionCompleted, now.Add(-3*time.Hour))
+	a2 := s.addAction(c, s.unit, "snapshot", state.ActionFailed, now.Add(-2*time.Hour))
+	a3 := s.addAction(c, s.unit2, "snapshot", state.ActionCompleted, now.Add(-time.Hour))
+	a4 := s.addAction(c, s.unit, "snapshot", state.ActionPending, now)
+
+	for i, test := range []struct {
+		query    state.ActionsQuery
+		expected []*state.Action
+	}{{
+		query:    state.ActionsQuery{},
+		expected: []*state.Action{a4, a3, a2, a1},
+	}, {
+		query:    state.ActionsQuery{Receiver: s.unit.Name()},
+		expected: []*state.Action{a4, a2, a1},
+	}, {
+		query:    state.ActionsQuery{Name: "no-such-action"},
+		expected: []*state.Action{},
+	}, {
+		query:    state.ActionsQuery{Statuses: []state.ActionStatus{state.ActionCompleted}},
+		expected: []*state.Action{a3, a1},
+	}, {
+		query: state.ActionsQuery{
+			EnqueuedAfter:  now.Add(-2 * time.Hour),
+			EnqueuedBefore: now,
+		},
+		expected: []*state.Action{a3, a2},
+	}, {
+		query:    state.ActionsQuery{Offset: 1, Limit: 2},
+		expected: []*state.Action{a3, a2},
+	}} {
+		c.Logf("test %d: %+v", i, test.query)
+		actions, err := s.State.FindActions(test.query)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(actionIds(actions), jc.DeepEquals, actionIds(test.expected))
+	}
+
+	_, err := s.State.FindActions(state.ActionsQuery{Limit: -1})
+	c.Assert(err, gc.ErrorMatches, "negative offset or limit not valid")
+}
+
+func (s *ActionHistorySuite) TestPruneActionsByAge(c *gc.C) {
+	now := time.Now().Round(time.Second).UTC()
+	s.addAction(c, s.unit, "snapshot", state.ActionCompleted, now.Add(-48*time.Hour))
+	old := s.addAction(c, s.unit, "snapshot", state.ActionPending, now.Add(-48*time.Hour))
+	recent := s.addAction(c, s.unit, "snapshot", state.ActionCompleted, now.Add(-time.Hour))
+
+	err := state.PruneActions(s.State, 24*time.Hour, 0)
+	c.Assert(err, jc.ErrorIsNil)
+
+	actions, err := s.State.FindActions(state.ActionsQuery{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(actionIds(actions), jc.DeepEquals, actionIds([]*state.Action{recent, old}))
+}
+
+func (s *ActionHistorySuite) TestPruneActionsByCount(c *gc.C) {
+	now := time.Now().Round(time.Second).UTC()
+	s.addAction(c, s.unit, "snapshot", state.ActionCompleted, now.Add(-3*time.Hour))
+	a2 := s.addAction(c, s.unit2, "snapshot", state.ActionCancelled, now.Add(-2*time.Hour))
+	a3 := s.addAction(c, s.unit, "snapshot", state.ActionFailed, now.Add(-time.Hour))
+	a4 := s.addAction(c, s.unit, "snapshot", state.ActionPending, now)
+
+	err := state.PruneActions(s.State, 0, 2)
+	c.Assert(err, jc.ErrorIsNil)
+
+	actions, err := s.State.FindActions(state.ActionsQuery{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(actionIds(actions), jc.DeepEquals, actionIds([]*state.Action{a4, a3, a2}))
+
+	// Pruning again changes nothing.
+	err = state.PruneActions(s.State, 0, 2)
+	c.Assert(err, jc.ErrorIsNil)
+	actions, err = s.State.FindActions(state.ActionsQuery{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(actions, gc.HasLen, 3)
+}
+
+func (s *ActionHistorySuite) TestPruneActionsInBatches(c *gc.C) {
+	s.PatchValue(state.PruneActionsBatchSize, 2)
+	now := time.Now().Round(time.Second).UTC()
+	for i := 0; i < 5; i++ {
+		s.addAction(c, s.unit, "snapshot", state.ActionCompleted, now.Add(-48*time.Hour))
+	}
+	pending := s.addAction(c, s.unit, "snapshot", state.ActionPending, now.Add(-48*time.Hour))
+
+	err := state.PruneActions(s.State, 24*time.Hour, 0)
+	c.Assert(err, jc.ErrorIsNil)
+
+	actions, err := s.State.FindActions(state.ActionsQuery{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(actionIds(actions), jc.DeepEquals, actionIds([]*state.Action{pending}))
+}
@@ -146,6 +146,21 @@ func allCollections() collectionSchema {
 		// was implemented.
 		actionresultsC: {global: true},
 
+		// This collection holds the audit log of API calls made
+		// against all models in the controller. It is written with
+		// plain inserts and never takes part in transactions.
+		auditLogC: {
+			global:    true,
+			rawAccess: true,
+			indexes: []mgo.Index{{
+				Key: []string{"model-uuid", "timestamp"},
+			}, {
+				Key: []string{"origin-tag", "timestamp"},
+			}, {
+				Key: []string{"timestamp"},
+			}},
+		},
+
 		// -----------------
 
 		// Local collections
@@ -320,7 +335,17 @@ func allCollections() collectionSchema {
 		// -----
 
 		// These collections hold information associated with actions.
-		actionsC:             {},
+		actionsC: {
+			indexes: []mgo.Index{{
+				Key: []string{"model-uuid", "receiver", "-enqueued"},
+			}, {
+				Key: []string{"model-uuid", "-enqueued"},
+			}, {
+				Key: []string{"model-uuid", "completed"},
+			}, {
+				Key: []string{"model-uuid", "group"},
+			}},
+		},
 		actionNotificationsC: {},
 
 		// -----
@@ -335,6 +360,11 @@ func allCollections() collectionSchema {
 		// See resource/persistence/mongo.go.
 		"resources": {},
 
+		// This collection holds the reference counts of the resource
+		// content in blob storage.
+		// See resource/persistence/blobs.go.
+		"resourceblobs": {},
+
 		// -----
 
 		// The remaining non-global collections share the property of being
@@ -361,6 +391,14 @@ func allCollections() collectionSchema {
 			}},
 		},
 
+		// This collection holds a bounded record of the hooks run by
+		// each unit, written by the unit agents.
+		hookHistoryC: {
+			indexes: []mgo.Index{{
+				Key: []string{"model-uuid", "unit", "-started"},
+			}},
+		},
+
 		// This collection holds information about cloud image metadata.
 		cloudimagemetadataC: {},
 
@@ -383,6 +421,7 @@ const (
 	actionsC                 = "actions"
 	annotationsC             = "annotations"
 	assignUnitC              = "assignUnits"
+	auditLogC                = "auditlog"
 	blockDevicesC            = "blockdevices"
 	blocksC                  = "blocks"
 	charmsC                  = "charms"
@@ -393,6 +432,7 @@ const (
 	controllersC             = "controllers"
 	filesystemAttachmentsC   = "filesystemAttachments"
 	filesystemsC             = "filesystems"
+	hookHistoryC             = "hookhistory"
 	instanceDataC            = "instanceData"
 	ipaddressesC             = "ipaddresses"
 	leaseC                   = "lease"
@@ -440,4 +480,5 @@ const (
 	volumesC                 = "volumes"
 	// "payloads" (see payload/persistence/mongo.go)
 	// "resources" (see resource/persistence/mongo.go)
+	// "resourceblobs" (see resource/persistence/blobs.go)
 )
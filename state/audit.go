@@ -0,0 +1,132 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+
+	"github.com/juju/juju/audit"
+)
+
+// auditEntryDoc is the persistent representation of an
+// audit.AuditEntry.
+type auditEntryDoc struct {
+	Id        bson.ObjectId `bson:"_id"`
+	Timestamp time.Time     `bson:"timestamp"`
+	ModelUUID string        `bson:"model-uuid"`
+	OriginTag string        `bson:"origin-tag"`
+	Facade    string        `bson:"facade"`
+	Version   int           `bson:"version"`
+	Method    string        `bson:"method"`
+	Args      string        `bson:"args,omitempty"`
+	Outcome   string        `bson:"outcome"`
+	Error     string        `bson:"error,omitempty"`
+}
+
+// AuditEntryFilter describes which audit entries should be returned
+// by State.AuditEntries. Zero-valued fields are not used to filter.
+type AuditEntryFilter struct {
+	// OriginTag restricts the entries to those performed by the
+	// entity with the given tag.
+	OriginTag string
+
+	// ModelUUID restricts the entries to those performed against
+	// the given model.
+	ModelUUID string
+
+	// Facade restricts the entries to calls made on the given facade.
+	Facade string
+
+	// After and Before restrict the entries to those recorded
+	// within the given time range.
+	After  time.Time
+	Before time.Time
+
+	// Limit restricts the number of entries returned.
+	Limit int
+}
+
+// AddAuditEntry records the given audit entry. The audit log is
+// shared by all models on the controller, so the entry's ModelUUID
+// is stored as supplied.
+func (st *State) AddAuditEntry(entry audit.AuditEntry) error {
+	if err := entry.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	auditLog, closer := st.getRawCollection(auditLogC)
+	defer closer()
+
+	doc := auditEntryDoc{
+		Id:        bson.NewObjectId(),
+		Timestamp: entry.Timestamp.UTC(),
+		ModelUUID: entry.ModelUUID,
+		OriginTag: entry.OriginTag,
+		Facade:    entry.Facade,
+		Version:   entry.Version,
+		Method:    entry.Method,
+		Args:      entry.Args,
+		Outcome:   entry.Outcome,
+		Error:     entry.Error,
+	}
+	if err := auditLog.Insert(&doc); err != nil {
+		return errors.Annotate(err, "cannot add audit entry")
+	}
+	return nil
+}
+
+// AuditEntries returns the audit entries matching the given filter,
+// most recent first.
+func (st *State) AuditEntries(filter AuditEntryFilter) ([]audit.AuditEntry, error) {
+	auditLog, closer := st.getRawCollection(auditLogC)
+	defer closer()
+
+	sel := bson.D{}
+	if filter.OriginTag != "" {
+		sel = append(sel, bson.DocElem{"origin-tag", filter.OriginTag})
+	}
+	if filter.ModelUUID != "" {
+		sel = append(sel, bson.DocElem{"model-uuid", filter.ModelUUID})
+	}
+	if filter.Facade != "" {
+		sel = append(sel, bson.DocElem{"facade", filter.Facade})
+	}
+	timeSel := bson.D{}
+	if !filter.After.IsZero() {
+		timeSel = append(timeSel, bson.DocElem{"$gte", filter.After.UTC()})
+	}
+	if !filter.Before.IsZero() {
+		timeSel = append(timeSel, bson.DocElem{"$lt", filter.Before.UTC()})
+	}
+	if len(timeSel) > 0 {
+		sel = append(sel, bson.DocElem{"timestamp", timeSel})
+	}
+
+	query := auditLog.Find(sel).Sort("-timestamp", "-_id")
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
+	var docs []auditEntryDoc
+	if err := query.All(&docs); err != nil && err != mgo.ErrNotFound {
+		return nil, errors.Annotate(err, "cannot get audit entries")
+	}
+	entries := make([]audit.AuditEntry, len(docs))
+	for i, doc := range docs {
+		entries[i] = audit.AuditEntry{
+			Timestamp: doc.Timestamp.UTC(),
+			ModelUUID: doc.ModelUUID,
+			OriginTag: doc.OriginTag,
+			Facade:    doc.Facade,
+			Version:   doc.Version,
+			Method:    doc.Method,
+			Args:      doc.Args,
+			Outcome:   doc.Outcome,
+			Error:     doc.Error,
+		}
+	}
+	return entries, nil
+}
@@ -0,0 +1,123 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/audit"
+	"github.com/juju/juju/state"
+)
+
+type auditSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&auditSuite{})
+
+var auditBaseTime = time.Date(2016, 4, 1, 12, 0, 0, 0, time.UTC)
+
+func (s *auditSuite) addEntry(c *gc.C, offset time.Duration, origin, modelUUID, facade, method string) audit.AuditEntry {
+	entry := audit.AuditEntry{
+		Timestamp: auditBaseTime.Add(offset),
+		ModelUUID: modelUUID,
+		OriginTag: origin,
+		Facade:    facade,
+		Version:   1,
+		Method:    method,
+		Args:      `{"foo":"bar"}`,
+		Outcome:   audit.OutcomeSucceeded,
+	}
+	err := s.State.AddAuditEntry(entry)
+	c.Assert(err, jc.ErrorIsNil)
+	return entry
+}
+
+func (s *auditSuite) addEntries(c *gc.C) []audit.AuditEntry {
+	return []audit.AuditEntry{
+		s.addEntry(c, 0, "user-alice", "model-1", "Service", "Deploy"),
+		s.addEntry(c, time.Minute, "user-bob", "model-1", "Service", "Destroy"),
+		s.addEntry(c, 2*time.Minute, "user-alice", "model-2", "Client", "AddMachinesV2"),
+		s.addEntry(c, 3*time.Minute, "user-bob", "model-2", "Service", "Expose"),
+	}
+}
+
+func (s *auditSuite) TestAddAuditEntryValidates(c *gc.C) {
+	err := s.State.AddAuditEntry(audit.AuditEntry{})
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *auditSuite) TestAuditEntriesNoFilter(c *gc.C) {
+	added := s.addEntries(c)
+	entries, err := s.State.AuditEntries(state.AuditEntryFilter{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(entries, jc.DeepEquals, []audit.AuditEntry{
+		added[3], added[2], added[1], added[0],
+	})
+}
+
+func (s *auditSuite) TestAuditEntriesEmpty(c *gc.C) {
+	entries, err := s.State.AuditEntries(state.AuditEntryFilter{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(entries, gc.HasLen, 0)
+}
+
+func (s *auditSuite) TestAuditEntriesFilters(c *gc.C) {
+	added := s.addEntries(c)
+	for i, test := range []struct {
+		about    string
+		filter   state.AuditEntryFilter
+		expected []audit.AuditEntry
+	}{{
+		about:    "origin",
+		filter:   state.AuditEntryFilter{OriginTag: "user-alice"},
+		expected: []audit.AuditEntry{added[2], added[0]},
+	}, {
+		about:    "model",
+		filter:   state.AuditEntryFilter{ModelUUID: "model-1"},
+		expected: []audit.AuditEntry{added[1], added[0]},
+	}, {
+		about:    "facade",
+		filter:   state.AuditEntryFilter{Facade: "Service"},
+		expected: []audit.AuditEntry{added[3], added[1], added[0]},
+	}, {
+		about: "time range",
+		filter: state.AuditEntryFilter{
+			After:  auditBaseTime.Add(time.Minute),
+			Before: auditBaseTime.Add(3 * time.Minute),
+		},
+		expected: []audit.AuditEntry{added[2], added[1]},
+	}, {
+		about:    "limit",
+		filter:   state.AuditEntryFilter{Limit: 1},
+		expected: []audit.AuditEntry{added[3]},
+	}, {
+		about: "combined",
+		filter: state.AuditEntryFilter{
+			OriginTag: "user-bob",
+			Facade:    "Service",
+			After:     auditBaseTime.Add(2 * time.Minute),
+		},
+		expected: []audit.AuditEntry{added[3]},
+	}} {
+		c.Logf("test %d: %s", i, test.about)
+		entries, err := s.State.AuditEntries(test.filter)
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(entries, jc.DeepEquals, test.expected)
+	}
+}
+
+func (s *auditSuite) TestAuditEntriesSharedBetweenModels(c *gc.C) {
+	added := s.addEntry(c, 0, "user-alice", s.State.ModelUUID(), "Service", "Deploy")
+
+	otherState := s.Factory.MakeModel(c, nil)
+	defer otherState.Close()
+	entries, err := otherState.AuditEntries(state.AuditEntryFilter{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(entries, jc.DeepEquals, []audit.AuditEntry{added})
+}
@@ -19,10 +19,12 @@ import (
 )
 
 const (
-	contentDir   = "juju-backup"
-	filesBundle  = "root.tar"
-	dbDumpDir    = "dump"
-	metadataFile = "metadata.json"
+	contentDir    = "juju-backup"
+	filesBundle   = "root.tar"
+	dbDumpDir     = "dump"
+	metadataFile  = "metadata.json"
+	manifestFile  = "manifest.json"
+	signatureFile = "manifest.sig"
 )
 
 var legacyVersion = version.Number{Major: 1, Minor: 20}
@@ -48,6 +50,14 @@ type ArchivePaths struct {
 
 	// MetadataFile is the path to the metadata file.
 	MetadataFile string
+
+	// ManifestFile is the path to the manifest file, which records
+	// the hash of every other file in the archive.
+	ManifestFile string
+
+	// SignatureFile is the path to the signature of the manifest
+	// file. Archives with unsigned manifests do not have one.
+	SignatureFile string
 }
 
 // NewCanonicalArchivePaths composes a new ArchivePaths with default
@@ -56,10 +66,12 @@ type ArchivePaths struct {
 // resolving the paths in a backup archive file (which is a tar file).
 func NewCanonicalArchivePaths() ArchivePaths {
 	return ArchivePaths{
-		ContentDir:   contentDir,
-		FilesBundle:  path.Join(contentDir, filesBundle),
-		DBDumpDir:    path.Join(contentDir, dbDumpDir),
-		MetadataFile: path.Join(contentDir, metadataFile),
+		ContentDir:    contentDir,
+		FilesBundle:   path.Join(contentDir, filesBundle),
+		DBDumpDir:     path.Join(contentDir, dbDumpDir),
+		MetadataFile:  path.Join(contentDir, metadataFile),
+		ManifestFile:  path.Join(contentDir, manifestFile),
+		SignatureFile: path.Join(contentDir, signatureFile),
 	}
 }
 
@@ -70,10 +82,12 @@ func NewCanonicalArchivePaths() ArchivePaths {
 // been unpacked.
 func NewNonCanonicalArchivePaths(rootDir string) ArchivePaths {
 	return ArchivePaths{
-		ContentDir:   filepath.Join(rootDir, contentDir),
-		FilesBundle:  filepath.Join(rootDir, contentDir, filesBundle),
-		DBDumpDir:    filepath.Join(rootDir, contentDir, dbDumpDir),
-		MetadataFile: filepath.Join(rootDir, contentDir, metadataFile),
+		ContentDir:    filepath.Join(rootDir, contentDir),
+		FilesBundle:   filepath.Join(rootDir, contentDir, filesBundle),
+		DBDumpDir:     filepath.Join(rootDir, contentDir, dbDumpDir),
+		MetadataFile:  filepath.Join(rootDir, contentDir, metadataFile),
+		ManifestFile:  filepath.Join(rootDir, contentDir, manifestFile),
+		SignatureFile: filepath.Join(rootDir, contentDir, signatureFile),
 	}
 }
 
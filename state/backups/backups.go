@@ -58,6 +58,7 @@ var logger = loggo.GetLogger("juju.state.backups")
 var (
 	getFilesToBackUp = GetFilesToBackUp
 	getDBDumper      = NewDBDumper
+	getOplogDumper   = NewOplogDumper
 	runCreate        = create
 	finishMeta       = func(meta *Metadata, result *createResult) error {
 		return meta.MarkComplete(result.size, result.checksum)
@@ -83,9 +84,15 @@ func StoreArchive(stor filestorage.FileStorage, meta *Metadata, file io.Reader)
 
 // Backups is an abstraction around all juju backup-related functionality.
 type Backups interface {
-	// Create creates and stores a new juju backup archive. It updates
-	// the provided metadata.
-	Create(meta *Metadata, paths *Paths, dbInfo *DBInfo) error
+	// Create creates and stores a new juju backup archive, protected
+	// with the given keys. It updates the provided metadata.
+	Create(meta *Metadata, paths *Paths, dbInfo *DBInfo, keys ArchiveKeys) error
+
+	// CreateIncremental creates and stores a new backup archive
+	// holding only the database changes made since the identified
+	// parent backup, protected with the given keys. It updates the
+	// provided metadata.
+	CreateIncremental(meta *Metadata, paths *Paths, dbInfo *DBInfo, parentID string, keys ArchiveKeys) error
 
 	// Add stores the backup archive and returns its new ID.
 	Add(archive io.Reader, meta *Metadata) (string, error)
@@ -119,8 +126,45 @@ func NewBackups(stor filestorage.FileStorage) Backups {
 
 // Create creates and stores a new juju backup archive and updates the
 // provided metadata.
-func (b *backups) Create(meta *Metadata, paths *Paths, dbInfo *DBInfo) error {
+func (b *backups) Create(meta *Metadata, paths *Paths, dbInfo *DBInfo, keys ArchiveKeys) error {
+	meta.OplogEnd = dbInfo.Oplog.Last
+	return b.createArchive(meta, paths, keys, func() (DBDumper, error) {
+		return getDBDumper(dbInfo)
+	})
+}
+
+// CreateIncremental creates and stores a new backup archive holding
+// only the database changes made since the identified parent backup,
+// and updates the provided metadata.
+func (b *backups) CreateIncremental(meta *Metadata, paths *Paths, dbInfo *DBInfo, parentID string, keys ArchiveKeys) error {
+	rawParent, err := b.storage.Metadata(parentID)
+	if err != nil {
+		return errors.Annotatef(err, "cannot get parent backup %q", parentID)
+	}
+	parent, ok := rawParent.(*Metadata)
+	if !ok {
+		return errors.Errorf("expected backups.Metadata value from storage for %q, got %T", parentID, rawParent)
+	}
+	if parent.OplogEnd == 0 {
+		return errors.Errorf("backup %q has no oplog position; create a full backup", parentID)
+	}
+	if parent.OplogEnd < dbInfo.Oplog.First {
+		return errors.Errorf("oplog no longer holds the changes made since backup %q; create a full backup", parentID)
+	}
+	meta.Parent = parentID
+	meta.OplogStart = parent.OplogEnd
+	meta.OplogEnd = dbInfo.Oplog.Last
+	return b.createArchive(meta, paths, keys, func() (DBDumper, error) {
+		return getOplogDumper(dbInfo, parent.OplogEnd)
+	})
+}
+
+// createArchive creates and stores a new backup archive, holding the
+// database dump made by the dumper that newDumper returns, and
+// updates the provided metadata.
+func (b *backups) createArchive(meta *Metadata, paths *Paths, keys ArchiveKeys, newDumper func() (DBDumper, error)) error {
 	meta.Started = time.Now().UTC()
+	meta.Encrypted = keys.Secret != ""
 
 	// The metadata file will not contain the ID or the "finished" data.
 	// However, that information is not as critical. The alternatives
@@ -137,11 +181,11 @@ func (b *backups) Create(meta *Metadata, paths *Paths, dbInfo *DBInfo) error {
 	if err != nil {
 		return errors.Annotate(err, "while listing files to back up")
 	}
-	dumper, err := getDBDumper(dbInfo)
+	dumper, err := newDumper()
 	if err != nil {
 		return errors.Annotate(err, "while preparing for DB dump")
 	}
-	args := createArgs{filesToBackUp, dumper, metadataFile}
+	args := createArgs{filesToBackUp, dumper, metadataFile, keys}
 	result, err := runCreate(&args)
 	if err != nil {
 		return errors.Annotate(err, "while creating backup archive")
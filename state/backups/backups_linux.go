@@ -53,23 +53,39 @@ func ensureMongoService(agentConfig agent.Config) error {
 // Restore handles either returning or creating a controller to a backed up status:
 // * extracts the content of the given backup file and:
 // * runs mongorestore with the backed up mongo dump
+// * replays the oplog held by each incremental backup in its chain
 // * updates and writes configuration files
 // * updates existing db entries to make sure they hold no references to
 // old instances
 // * updates config in all agents.
 func (b *backups) Restore(backupId string, args RestoreArgs) (names.Tag, error) {
-	meta, backupReader, err := b.Get(backupId)
+	list, err := b.List()
 	if err != nil {
-		return nil, errors.Annotatef(err, "could not fetch backup %q", backupId)
+		return nil, errors.Annotate(err, "cannot list backups")
 	}
-
-	defer backupReader.Close()
-
-	workspace, err := NewArchiveWorkspaceReader(backupReader)
+	chain, err := Chain(list, backupId)
 	if err != nil {
-		return nil, errors.Annotate(err, "cannot unpack backup file")
+		return nil, errors.Annotatef(err, "could not fetch backup %q", backupId)
+	}
+	meta := chain[len(chain)-1]
+
+	// The database is restored from the full backup at the root of
+	// the chain, then brought forward by each incremental backup; the
+	// files come from the backup being restored.
+	workspaces := make([]*ArchiveWorkspace, len(chain))
+	for i, link := range chain {
+		workspace, err := b.unpack(link.ID(), args.secret(link.ID()))
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		defer workspace.Close()
+		workspaces[i] = workspace
+	}
+	workspace := workspaces[len(workspaces)-1]
+	var incrementalDumpDirs []string
+	for _, incremental := range workspaces[1:] {
+		incrementalDumpDirs = append(incrementalDumpDirs, incremental.DBDumpDir)
 	}
-	defer workspace.Close()
 
 	// TODO(perrito666) Create a compatibility table of sorts.
 	version := meta.Origin.Version
@@ -142,7 +158,7 @@ func (b *backups) Restore(backupId string, args RestoreArgs) (names.Tag, error)
 
 	logger.Infof("new mongo will be restored")
 	// Restore mongodb from backup
-	if err := placeNewMongoService(workspace.DBDumpDir, version); err != nil {
+	if err := placeNewMongoService(workspaces[0].DBDumpDir, version, incrementalDumpDirs...); err != nil {
 		return nil, errors.Annotate(err, "error restoring state from backup")
 	}
 
@@ -209,3 +225,32 @@ func (b *backups) Restore(backupId string, args RestoreArgs) (names.Tag, error)
 
 	return backupMachine, errors.Annotate(err, "failed to set status to finished")
 }
+
+// unpack fetches the identified backup archive, decrypting it with
+// the given secret if it is encrypted, and unpacks it into a new
+// workspace.
+func (b *backups) unpack(id, secret string) (*ArchiveWorkspace, error) {
+	_, backupReader, err := b.Get(id)
+	if err != nil {
+		return nil, errors.Annotatef(err, "could not fetch backup %q", id)
+	}
+	defer backupReader.Close()
+
+	encrypted, archive, err := IsEncrypted(backupReader)
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot read backup file %q", id)
+	}
+	if encrypted {
+		if secret == "" {
+			return nil, errors.Errorf("backup %q is encrypted; a key is needed to restore it", id)
+		}
+		if archive, err = NewDecryptingReader(archive, secret); err != nil {
+			return nil, errors.Annotatef(err, "cannot decrypt backup file %q", id)
+		}
+	}
+	workspace, err := NewArchiveWorkspaceReader(archive)
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot unpack backup file %q", id)
+	}
+	return workspace, nil
+}
@@ -53,10 +53,10 @@ func (s *backupsSuite) checkFailure(c *gc.C, expected string) {
 
 	paths := backups.Paths{DataDir: "/var/lib/juju"}
 	targets := set.NewStrings("juju", "admin")
-	dbInfo := backups.DBInfo{"a", "b", "c", targets}
+	dbInfo := backups.DBInfo{Address: "a", Username: "b", Password: "c", Targets: targets}
 	meta := backupstesting.NewMetadataStarted()
 	meta.Notes = "some notes"
-	err := s.api.Create(meta, &paths, &dbInfo)
+	err := s.api.Create(meta, &paths, &dbInfo, backups.ArchiveKeys{})
 
 	c.Check(err, gc.ErrorMatches, expected)
 }
@@ -92,11 +92,17 @@ func (s *backupsSuite) TestCreateOkay(c *gc.C) {
 	// Run the backup.
 	paths := backups.Paths{DataDir: "/var/lib/juju"}
 	targets := set.NewStrings("juju", "admin")
-	dbInfo := backups.DBInfo{"a", "b", "c", targets}
+	dbInfo := backups.DBInfo{
+		Address:  "a",
+		Username: "b",
+		Password: "c",
+		Targets:  targets,
+		Oplog:    backups.OplogRange{First: 50, Last: 200},
+	}
 	meta := backupstesting.NewMetadataStarted()
 	backupstesting.SetOrigin(meta, "<model ID>", "<machine ID>", "<hostname>")
 	meta.Notes = "some notes"
-	err := s.api.Create(meta, &paths, &dbInfo)
+	err := s.api.Create(meta, &paths, &dbInfo, backups.ArchiveKeys{})
 
 	// Test the call values.
 	s.Storage.CheckCalled(c, "spam", meta, archiveFile, "Add", "Metadata")
@@ -120,6 +126,8 @@ func (s *backupsSuite) TestCreateOkay(c *gc.C) {
 	c.Check(meta.Origin.Machine, gc.Equals, "<machine ID>")
 	c.Check(meta.Origin.Hostname, gc.Equals, "<hostname>")
 	c.Check(meta.Notes, gc.Equals, "some notes")
+	c.Check(meta.Incremental(), jc.IsFalse)
+	c.Check(meta.OplogEnd, gc.Equals, int64(200))
 
 	// Check the file storage.
 	s.Storage.Meta = meta
@@ -172,6 +180,60 @@ func (s *backupsSuite) TestCreateFailToStoreArchive(c *gc.C) {
 	s.checkFailure(c, "while storing backup archive: failed!")
 }
 
+func (s *backupsSuite) setParent(oplogEnd int64) {
+	s.setStored("spam")
+	parent := s.Storage.Meta.(*backups.Metadata)
+	parent.OplogEnd = oplogEnd
+}
+
+func (s *backupsSuite) TestCreateIncremental(c *gc.C) {
+	archiveFile := ioutil.NopCloser(bytes.NewBufferString("<compressed tarball>"))
+	result := backups.NewTestCreateResult(archiveFile, 10, "<checksum>")
+	received, testCreate := backups.NewTestCreate(result)
+	s.PatchValue(backups.RunCreate, testCreate)
+	s.PatchValue(backups.TestGetFilesToBackUp, func(root string, paths *backups.Paths, oldmachine string) ([]string, error) {
+		return []string{"<some file>"}, nil
+	})
+	var since int64
+	s.PatchValue(backups.GetOplogDumper, func(info *backups.DBInfo, oplogSince int64) (backups.DBDumper, error) {
+		since = oplogSince
+		return &fakeDumper{}, nil
+	})
+	s.setParent(100)
+
+	paths := backups.Paths{DataDir: "/var/lib/juju"}
+	dbInfo := backups.DBInfo{Oplog: backups.OplogRange{First: 50, Last: 200}}
+	meta := backupstesting.NewMetadataStarted()
+	err := s.api.CreateIncremental(meta, &paths, &dbInfo, "parent-id", backups.ArchiveKeys{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(s.Storage.Calls, jc.DeepEquals, []string{"Metadata", "Add", "Metadata"})
+	_, dumper := backups.ExposeCreateArgs(received)
+	c.Check(dumper, gc.FitsTypeOf, &fakeDumper{})
+	c.Check(since, gc.Equals, int64(100))
+	c.Check(meta.ID(), gc.Equals, "spam")
+	c.Check(meta.Incremental(), jc.IsTrue)
+	c.Check(meta.Parent, gc.Equals, "parent-id")
+	c.Check(meta.OplogStart, gc.Equals, int64(100))
+	c.Check(meta.OplogEnd, gc.Equals, int64(200))
+}
+
+func (s *backupsSuite) TestCreateIncrementalOplogRolledOver(c *gc.C) {
+	s.setParent(100)
+
+	dbInfo := backups.DBInfo{Oplog: backups.OplogRange{First: 150, Last: 200}}
+	err := s.api.CreateIncremental(backupstesting.NewMetadataStarted(), &backups.Paths{}, &dbInfo, "parent-id", backups.ArchiveKeys{})
+	c.Check(err, gc.ErrorMatches, `oplog no longer holds the changes made since backup "parent-id"; create a full backup`)
+}
+
+func (s *backupsSuite) TestCreateIncrementalParentWithoutPosition(c *gc.C) {
+	s.setParent(0)
+
+	dbInfo := backups.DBInfo{Oplog: backups.OplogRange{First: 50, Last: 200}}
+	err := s.api.CreateIncremental(backupstesting.NewMetadataStarted(), &backups.Paths{}, &dbInfo, "parent-id", backups.ArchiveKeys{})
+	c.Check(err, gc.ErrorMatches, `backup "parent-id" has no oplog position; create a full backup`)
+}
+
 func (s *backupsSuite) TestStoreArchive(c *gc.C) {
 	stored := s.setStored("spam")
 
@@ -0,0 +1,141 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"sort"
+
+	"github.com/juju/errors"
+)
+
+// Chain returns the backups needed to restore the identified backup,
+// starting with the full backup at the root of its chain and ending
+// with the backup itself.
+func Chain(list []*Metadata, id string) ([]*Metadata, error) {
+	byID := make(map[string]*Metadata)
+	for _, meta := range list {
+		byID[meta.ID()] = meta
+	}
+	var chain []*Metadata
+	for next := id; next != ""; {
+		meta, ok := byID[next]
+		if !ok {
+			return nil, errors.NotFoundf("backup %q in the chain of %q", next, id)
+		}
+		if len(chain) == len(list) {
+			return nil, errors.Errorf("backup %q has a cyclic chain", id)
+		}
+		chain = append(chain, meta)
+		next = meta.Parent
+	}
+	for i, j := 0, len(chain)-1; i < j; i, j = i+1, j-1 {
+		chain[i], chain[j] = chain[j], chain[i]
+	}
+	return chain, nil
+}
+
+// Latest returns the newest of the listed backups that an incremental
+// backup can build on, or nil if there is none.
+func Latest(list []*Metadata) *Metadata {
+	var latest *Metadata
+	for _, meta := range list {
+		if meta.Finished == nil || meta.OplogEnd == 0 {
+			continue
+		}
+		if latest == nil || meta.Started.After(latest.Started) {
+			latest = meta
+		}
+	}
+	return latest
+}
+
+// RetentionPolicy describes which scheduled backups are kept.
+type RetentionPolicy struct {
+	// KeepDaily is the number of days for which the newest scheduled
+	// full backup of the day is kept.
+	KeepDaily int
+
+	// KeepWeekly is the number of weeks for which the newest
+	// scheduled full backup of the week is kept.
+	KeepWeekly int
+}
+
+// Expired returns the scheduled backups in the list that the policy no
+// longer keeps. Incremental backups are kept for as long as the full
+// backup at the root of their chain, and no backup is expired while a
+// backup that is kept builds on it. Backups that were not scheduled
+// are never expired. If the policy keeps no days and no weeks, no
+// backups are expired.
+func Expired(list []*Metadata, policy RetentionPolicy) []*Metadata {
+	if policy.KeepDaily <= 0 && policy.KeepWeekly <= 0 {
+		return nil
+	}
+	byID := make(map[string]*Metadata)
+	var full []*Metadata
+	for _, meta := range list {
+		byID[meta.ID()] = meta
+		if meta.Scheduled && !meta.Incremental() {
+			full = append(full, meta)
+		}
+	}
+	sort.Sort(byStartedDesc(full))
+
+	kept := make(map[string]bool)
+	days := make(map[string]bool)
+	weeks := make(map[[2]int]bool)
+	for _, meta := range full {
+		started := meta.Started.UTC()
+		day := started.Format("2006-01-02")
+		if !days[day] && len(days) < policy.KeepDaily {
+			days[day] = true
+			kept[meta.ID()] = true
+		}
+		year, week := started.ISOWeek()
+		if !weeks[[2]int{year, week}] && len(weeks) < policy.KeepWeekly {
+			weeks[[2]int{year, week}] = true
+			kept[meta.ID()] = true
+		}
+	}
+
+	// root returns the full backup at the root of the chain of the
+	// given backup, or nil if the chain is broken.
+	root := func(meta *Metadata) *Metadata {
+		for i := 0; meta != nil && meta.Incremental() && i < len(list); i++ {
+			meta = byID[meta.Parent]
+		}
+		return meta
+	}
+	expired := make(map[string]bool)
+	for _, meta := range list {
+		if !meta.Scheduled {
+			continue
+		}
+		if r := root(meta); r != nil && r.Scheduled && !r.Incremental() && !kept[r.ID()] {
+			expired[meta.ID()] = true
+		}
+	}
+	// Keep everything that a kept backup builds on.
+	for _, meta := range list {
+		if expired[meta.ID()] {
+			continue
+		}
+		for parent := byID[meta.Parent]; parent != nil && expired[parent.ID()]; parent = byID[parent.Parent] {
+			delete(expired, parent.ID())
+		}
+	}
+
+	var result []*Metadata
+	for _, meta := range list {
+		if expired[meta.ID()] {
+			result = append(result, meta)
+		}
+	}
+	return result
+}
+
+type byStartedDesc []*Metadata
+
+func (b byStartedDesc) Len() int           { return len(b) }
+func (b byStartedDesc) Swap(i, j int)      { b[i], b[j] = b[j], b[i] }
+func (b byStartedDesc) Less(i, j int) bool { return b[i].Started.After(b[j].Started) }
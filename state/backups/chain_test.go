@@ -0,0 +1,110 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups_test
+
+import (
+	"fmt"
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state/backups"
+	"github.com/juju/juju/testing"
+)
+
+type chainSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&chainSuite{})
+
+func newBackup(id string, started time.Time, parent string, scheduled bool) *backups.Metadata {
+	meta := backups.NewMetadata()
+	meta.SetID(id)
+	meta.Started = started
+	meta.Finished = &started
+	meta.Parent = parent
+	meta.Scheduled = scheduled
+	meta.OplogEnd = started.Unix() << 32
+	return meta
+}
+
+func ids(list []*backups.Metadata) []string {
+	result := make([]string, len(list))
+	for i, meta := range list {
+		result[i] = meta.ID()
+	}
+	return result
+}
+
+// day returns 3am on the given day of April 2016; the 1st is a Friday.
+func day(n int) time.Time {
+	return time.Date(2016, 4, n, 3, 0, 0, 0, time.UTC)
+}
+
+func (s *chainSuite) TestChain(c *gc.C) {
+	list := []*backups.Metadata{
+		newBackup("inc-2", day(1).Add(2*time.Hour), "inc-1", true),
+		newBackup("full", day(1), "", true),
+		newBackup("inc-1", day(1).Add(time.Hour), "full", true),
+		newBackup("orphan", day(2), "missing", true),
+	}
+	chain, err := backups.Chain(list, "inc-2")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(ids(chain), jc.DeepEquals, []string{"full", "inc-1", "inc-2"})
+
+	chain, err = backups.Chain(list, "full")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(ids(chain), jc.DeepEquals, []string{"full"})
+
+	_, err = backups.Chain(list, "orphan")
+	c.Check(err, gc.ErrorMatches, `backup "missing" in the chain of "orphan" not found`)
+}
+
+func (s *chainSuite) TestLatest(c *gc.C) {
+	unfinished := newBackup("unfinished", day(3), "", false)
+	unfinished.Finished = nil
+	noPosition := newBackup("no-position", day(3), "", false)
+	noPosition.OplogEnd = 0
+	list := []*backups.Metadata{
+		newBackup("older", day(1), "", false),
+		newBackup("newer", day(2), "", false),
+		unfinished,
+		noPosition,
+	}
+	c.Check(backups.Latest(list).ID(), gc.Equals, "newer")
+	c.Check(backups.Latest(nil), gc.IsNil)
+}
+
+func (s *chainSuite) TestExpired(c *gc.C) {
+	var list []*backups.Metadata
+	for n := 1; n <= 10; n++ {
+		list = append(list, newBackup(fmt.Sprintf("full-%02d", n), day(n), "", true))
+	}
+	list = append(list,
+		// Scheduled incremental backups go with their chain.
+		newBackup("inc-07", day(7).Add(time.Hour), "full-07", true),
+		// Backups built on by a backup that is kept are kept.
+		newBackup("manual-02", day(2).Add(time.Hour), "full-02", false),
+		// Backups that were not scheduled are kept.
+		newBackup("manual", day(1), "", false),
+	)
+
+	// Keep the 8th to the 10th, and the newest of the week the
+	// 1st to the 3rd fall in.
+	expired := backups.Expired(list, backups.RetentionPolicy{KeepDaily: 3, KeepWeekly: 2})
+	c.Check(ids(expired), jc.DeepEquals, []string{
+		"full-01", "full-04", "full-05", "full-06", "full-07", "inc-07",
+	})
+}
+
+func (s *chainSuite) TestExpiredDisabled(c *gc.C) {
+	list := []*backups.Metadata{
+		newBackup("full-01", day(1), "", true),
+		newBackup("full-02", day(2), "", true),
+	}
+	c.Check(backups.Expired(list, backups.RetentionPolicy{}), gc.HasLen, 0)
+	c.Check(ids(backups.Expired(list, backups.RetentionPolicy{KeepDaily: 1})), jc.DeepEquals, []string{"full-01"})
+}
@@ -31,6 +31,7 @@ type createArgs struct {
 	filesToBackUp  []string
 	db             DBDumper
 	metadataReader io.Reader
+	keys           ArchiveKeys
 }
 
 type createResult struct {
@@ -43,7 +44,7 @@ type createResult struct {
 // updates the metadata with the file info.
 func create(args *createArgs) (_ *createResult, err error) {
 	// Prepare the backup builder.
-	builder, err := newBuilder(args.filesToBackUp, args.db)
+	builder, err := newBuilder(args.filesToBackUp, args.db, args.keys)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -96,6 +97,9 @@ type builder struct {
 	filesToBackUp []string
 	// db is the wrapper around the DB dump command and args.
 	db DBDumper
+	// keys holds the keys with which the archive is encrypted and its
+	// manifest signed.
+	keys ArchiveKeys
 	// checksum is the checksum of the archive file.
 	checksum string
 	// archiveFile is the backup archive file.
@@ -109,7 +113,13 @@ type builder struct {
 // directories which backup uses as its staging area while building the
 // archive.  It also creates the archive
 // (temp root, tarball root, DB dumpdir), along with any error.
-func newBuilder(filesToBackUp []string, db DBDumper) (b *builder, err error) {
+func newBuilder(filesToBackUp []string, db DBDumper, keys ArchiveKeys) (b *builder, err error) {
+	if keys.CAPrivateKey != "" && keys.Secret == "" {
+		// The CA key is itself in the archive, so anyone holding
+		// an unencrypted archive could forge its signature.
+		return nil, errors.New("cannot sign the manifest of an unencrypted backup")
+	}
+
 	// Create the backups workspace root directory.
 	rootDir, err := ioutil.TempDir("", tempPrefix)
 	if err != nil {
@@ -123,6 +133,7 @@ func newBuilder(filesToBackUp []string, db DBDumper) (b *builder, err error) {
 		filename:      filepath.Join(rootDir, tempFilename),
 		filesToBackUp: filesToBackUp,
 		db:            db,
+		keys:          keys,
 	}
 	defer func() {
 		if err != nil {
@@ -285,7 +296,25 @@ func (b *builder) buildDBDump() error {
 	return nil
 }
 
-func (b *builder) buildArchive(outFile io.Writer) error {
+func (b *builder) buildManifest() error {
+	logger.Infof("writing manifest")
+	err := writeManifest(b.archivePaths, b.keys.CACert, b.keys.CAPrivateKey)
+	return errors.Annotate(err, "while writing manifest")
+}
+
+func (b *builder) buildArchive(outFile io.Writer) (err error) {
+	if b.keys.Secret != "" {
+		encrypted, err := NewEncryptingWriter(outFile, b.keys.Secret)
+		if err != nil {
+			return errors.Annotate(err, "while preparing encryption")
+		}
+		defer func() {
+			if cerr := encrypted.Close(); cerr != nil && err == nil {
+				err = errors.Annotate(cerr, "while encrypting final archive")
+			}
+		}()
+		outFile = encrypted
+	}
 	tarball := gzip.NewWriter(outFile)
 	defer tarball.Close()
 
@@ -312,6 +341,8 @@ func (b *builder) buildArchiveAndChecksum() error {
 	// than to the uncompressed contents of the tarball.  This is so
 	// that users can compare the published checksum against the
 	// checksum of the file without having to decompress it first.
+	// Likewise, the hash of an encrypted archive is that of the
+	// encrypted file.
 	hasher := hash.NewHashingWriter(b.archiveFile, sha1.New())
 	if err := b.buildArchive(hasher); err != nil {
 		return errors.Trace(err)
@@ -337,6 +368,11 @@ func (b *builder) buildAll() error {
 		return errors.Trace(err)
 	}
 
+	// Record what there is.
+	if err := b.buildManifest(); err != nil {
+		return errors.Trace(err)
+	}
+
 	// Bundle it all into a tarball.
 	if err := b.buildArchiveAndChecksum(); err != nil {
 		return errors.Trace(err)
@@ -4,13 +4,18 @@
 package backups
 
 import (
+	"fmt"
 	"io/ioutil"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"regexp"
+	"strings"
 
 	"github.com/juju/errors"
 	"github.com/juju/utils/set"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
 
 	"github.com/juju/juju/agent"
 	"github.com/juju/juju/juju/paths"
@@ -41,6 +46,44 @@ type DBInfo struct {
 	Password string
 	// Targets is a list of databases to dump.
 	Targets set.Strings
+	// Oplog holds the range of the replica set oplog when the backup
+	// was requested; see NewOplogRange.
+	Oplog OplogRange
+}
+
+// OplogRange holds the timestamps of the oldest and newest entries in
+// the replica set oplog. Incremental backups hold the entries written
+// since the newest entry recorded by their parent, which must still
+// be in the oplog.
+type OplogRange struct {
+	First int64
+	Last  int64
+}
+
+// oplogEntry holds the part of an oplog entry backups is interested in.
+type oplogEntry struct {
+	Timestamp bson.MongoTimestamp `bson:"ts"`
+}
+
+// NewOplogRange returns the range of the replica set oplog as seen by
+// the given session. The range is empty if there is no oplog, in which
+// case no incremental backup can build on the backup being made.
+func NewOplogRange(session *mgo.Session) (OplogRange, error) {
+	oplog := session.DB("local").C("oplog.rs")
+	var first, last oplogEntry
+	err := oplog.Find(nil).Sort("$natural").One(&first)
+	if err == mgo.ErrNotFound {
+		return OplogRange{}, nil
+	} else if err != nil {
+		return OplogRange{}, errors.Annotate(err, "cannot read oldest oplog entry")
+	}
+	if err := oplog.Find(nil).Sort("-$natural").One(&last); err != nil {
+		return OplogRange{}, errors.Annotate(err, "cannot read newest oplog entry")
+	}
+	return OplogRange{
+		First: int64(first.Timestamp),
+		Last:  int64(last.Timestamp),
+	}, nil
 }
 
 // ignoredDatabases is the list of databases that should not be
@@ -157,6 +200,79 @@ func (md *mongoDumper) dump(dumpDir string) error {
 	return nil
 }
 
+// oplogDumper dumps the oplog entries written since a given time.
+type oplogDumper struct {
+	*DBInfo
+	// binPath is the path to the dump executable.
+	binPath string
+	// since holds the timestamp after which oplog entries are dumped.
+	since int64
+}
+
+// NewOplogDumper returns a new value with a Dump method for dumping
+// the oplog entries written after the given oplog timestamp. The
+// entries are left in the dump directory as mongodump --oplog would
+// leave them, so that mongorestore --oplogReplay can replay them.
+func NewOplogDumper(info *DBInfo, since int64) (DBDumper, error) {
+	mongodumpPath, err := getMongodumpPath()
+	if err != nil {
+		return nil, errors.Annotate(err, "mongodump not available")
+	}
+
+	dumper := oplogDumper{
+		DBInfo:  info,
+		binPath: mongodumpPath,
+		since:   since,
+	}
+	return &dumper, nil
+}
+
+func (od *oplogDumper) options(dumpDir string) []string {
+	// Oplog timestamps hold the seconds in the high 32 bits and an
+	// ordinal in the low ones.
+	// Writes to the databases that are not backed up are left out,
+	// as they are from full backups.
+	query := fmt.Sprintf(`{"ts": {"$gt": {"$timestamp": {"t": %d, "i": %d}}}, "ns": {"$not": %s}}`,
+		uint64(od.since)>>32, uint32(od.since), ignoredNamespaces())
+	options := []string{
+		"--ssl",
+		"--authenticationDatabase", "admin",
+		"--host", od.Address,
+		"--username", od.Username,
+		"--password", od.Password,
+		"--out", dumpDir,
+		"--db", "local",
+		"--collection", "oplog.rs",
+		"--query", query,
+	}
+	return options
+}
+
+// ignoredNamespaces returns a regular expression literal, as accepted
+// by mongodump's --query, that matches the namespaces of the ignored
+// databases.
+func ignoredNamespaces() string {
+	var names []string
+	for _, name := range ignoredDatabases.SortedValues() {
+		names = append(names, regexp.QuoteMeta(name))
+	}
+	return fmt.Sprintf(`/^(%s)\./`, strings.Join(names, "|"))
+}
+
+// Dump dumps the oplog entries written since the dumper's timestamp
+// into dumpDir/oplog.bson.
+func (od *oplogDumper) Dump(dumpDir string) error {
+	if err := runCommandFn(od.binPath, od.options(dumpDir)...); err != nil {
+		return errors.Annotate(err, "error dumping oplog")
+	}
+	localDir := filepath.Join(dumpDir, "local")
+	dumped := filepath.Join(localDir, "oplog.rs.bson")
+	if err := os.Rename(dumped, filepath.Join(dumpDir, "oplog.bson")); err != nil {
+		return errors.Annotate(err, "cannot move oplog dump")
+	}
+	return errors.Trace(os.RemoveAll(localDir))
+}
+
 // Dump dumps the juju state-related databases.  To do this we dump all
 // databases and then remove any ignored databases from the dump results.
 func (md *mongoDumper) Dump(baseDumpDir string) error {
@@ -234,13 +350,22 @@ func mongoRestoreArgsForVersion(ver version.Number, dumpPath string) ([]string,
 	}
 }
 
+// mongoRestoreIncrementalArgs returns the args used to call mongo
+// restore to replay the oplog entries held by an incremental backup,
+// once the backups it builds on have been restored.
+func mongoRestoreIncrementalArgs(dumpPath string) []string {
+	dbDir := filepath.Join(agent.DefaultPaths.DataDir, "db")
+	return []string{"--journal", "--oplogReplay", "--dbpath", dbDir, dumpPath}
+}
+
 var restorePath = paths.MongorestorePath
 var restoreArgsForVersion = mongoRestoreArgsForVersion
 
 // placeNewMongoService wraps placeNewMongo with the proper service stopping
 // and starting before dumping the new mongo db, it is mainly to easy testing
-// of placeNewMongo.
-func placeNewMongoService(newMongoDumpPath string, ver version.Number) error {
+// of placeNewMongo. The oplog entries in each of incrementalDumpPaths are
+// then replayed in order.
+func placeNewMongoService(newMongoDumpPath string, ver version.Number, incrementalDumpPaths ...string) error {
 	err := mongo.StopService()
 	if err != nil {
 		return errors.Annotate(err, "failed to stop mongo")
@@ -249,6 +374,11 @@ func placeNewMongoService(newMongoDumpPath string, ver version.Number) error {
 	if err := placeNewMongo(newMongoDumpPath, ver); err != nil {
 		return errors.Annotate(err, "cannot place new mongo")
 	}
+	for _, dumpPath := range incrementalDumpPaths {
+		if err := replayOplog(dumpPath); err != nil {
+			return errors.Annotate(err, "cannot replay incremental backup")
+		}
+	}
 	err = mongo.StartService()
 	return errors.Annotate(err, "failed to start mongo")
 }
@@ -274,3 +404,17 @@ func placeNewMongo(newMongoDumpPath string, ver version.Number) error {
 
 	return nil
 }
+
+// replayOplog uses mongorestore to replay the oplog entries dumped in
+// dumpPath on top of the restored database.
+func replayOplog(dumpPath string) error {
+	mongoRestore, err := restorePath()
+	if err != nil {
+		return errors.Annotate(err, "mongorestore not available")
+	}
+	err = runCommandFn(mongoRestore, mongoRestoreIncrementalArgs(dumpPath)...)
+	if err != nil {
+		return errors.Annotate(err, "failed to replay oplog")
+	}
+	return nil
+}
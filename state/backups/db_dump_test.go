@@ -4,6 +4,7 @@
 package backups_test
 
 import (
+	"io/ioutil"
 	"os"
 	"path/filepath"
 
@@ -30,7 +31,7 @@ func (s *dumpSuite) SetUpTest(c *gc.C) {
 	s.BaseSuite.SetUpTest(c)
 
 	targets := set.NewStrings("juju", "admin")
-	s.dbInfo = &backups.DBInfo{"a", "b", "c", targets}
+	s.dbInfo = &backups.DBInfo{Address: "a", Username: "b", Password: "c", Targets: targets}
 	s.targets = targets
 	s.dumpDir = c.MkDir()
 }
@@ -125,3 +126,38 @@ func (s *dumpSuite) TestDumpNothingIgnored(c *gc.C) {
 
 	s.checkDBs(c, "juju", "admin")
 }
+
+func (s *dumpSuite) TestOplogDump(c *gc.C) {
+	s.PatchValue(backups.GetMongodumpPath, func() (string, error) {
+		return "bogusmongodump", nil
+	})
+	var ranArgs []string
+	s.PatchValue(backups.RunCommand, func(cmd string, args ...string) error {
+		ranArgs = args
+		// mongodump writes the collection under its database.
+		dirName := s.prepDB(c, "local")
+		return ioutil.WriteFile(filepath.Join(dirName, "oplog.rs.bson"), []byte("<oplog>"), 0644)
+	})
+	// Second 1459513800, ordinal 3.
+	dumper, err := backups.NewOplogDumper(s.dbInfo, 1459513800<<32|3)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = dumper.Dump(s.dumpDir)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(ranArgs, jc.DeepEquals, []string{
+		"--ssl",
+		"--authenticationDatabase", "admin",
+		"--host", "a",
+		"--username", "b",
+		"--password", "c",
+		"--out", s.dumpDir,
+		"--db", "local",
+		"--collection", "oplog.rs",
+		"--query", `{"ts": {"$gt": {"$timestamp": {"t": 1459513800, "i": 3}}}, "ns": {"$not": /^(backups|osimages|presence)\./}}`,
+	})
+	data, err := ioutil.ReadFile(filepath.Join(s.dumpDir, "oplog.bson"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(data), gc.Equals, "<oplog>")
+	s.checkStripped(c, "local")
+}
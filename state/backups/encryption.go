@@ -0,0 +1,205 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"bufio"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"io"
+
+	"github.com/juju/errors"
+	"golang.org/x/crypto/scrypt"
+)
+
+// An encrypted archive starts with encryptedMagic and a random salt,
+// from which and the user's secret the AES-256 key is derived. The
+// gzipped tarball follows, sealed with AES-GCM in chunks so that it
+// can be streamed. Every chunk holds encryptedChunkSize bytes of the
+// tarball except the last, which holds fewer (possibly none) and is
+// marked as last in its additional data, so that a truncated archive
+// cannot pass for a whole one. Each chunk's nonce is its index.
+const (
+	encryptedMagic     = "JUJUBKE1"
+	encryptionSaltSize = 16
+	encryptedChunkSize = 64 * 1024
+)
+
+var (
+	notLastChunk = []byte{0}
+	lastChunk    = []byte{1}
+)
+
+func newArchiveCipher(secret string, salt []byte) (cipher.AEAD, error) {
+	key, err := scrypt.Key([]byte(secret), salt, 1<<15, 8, 1, 32)
+	if err != nil {
+		return nil, errors.Annotate(err, "while deriving encryption key")
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	aead, err := cipher.NewGCM(block)
+	return aead, errors.Trace(err)
+}
+
+func chunkNonce(aead cipher.AEAD, index uint64) []byte {
+	nonce := make([]byte, aead.NonceSize())
+	binary.BigEndian.PutUint64(nonce[len(nonce)-8:], index)
+	return nonce
+}
+
+// IsEncrypted reports whether the archive read from r is encrypted.
+// Since it has to read the start of the archive to find out, it also
+// returns a reader from which the whole archive can be read.
+func IsEncrypted(r io.Reader) (bool, io.Reader, error) {
+	buffered := bufio.NewReader(r)
+	magic, err := buffered.Peek(len(encryptedMagic))
+	if err != nil && err != io.EOF {
+		return false, nil, errors.Trace(err)
+	}
+	return string(magic) == encryptedMagic, buffered, nil
+}
+
+type encryptingWriter struct {
+	out   io.Writer
+	aead  cipher.AEAD
+	chunk []byte
+	index uint64
+}
+
+// NewEncryptingWriter returns a writer that encrypts what is written
+// to it with the given secret, writing the encrypted archive to out.
+// The writer must be closed to write the end of the archive; doing so
+// does not close out.
+func NewEncryptingWriter(out io.Writer, secret string) (io.WriteCloser, error) {
+	if secret == "" {
+		return nil, errors.New("missing secret")
+	}
+	salt := make([]byte, encryptionSaltSize)
+	if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+		return nil, errors.Trace(err)
+	}
+	aead, err := newArchiveCipher(secret, salt)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if _, err := io.WriteString(out, encryptedMagic); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if _, err := out.Write(salt); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &encryptingWriter{
+		out:   out,
+		aead:  aead,
+		chunk: make([]byte, 0, encryptedChunkSize),
+	}, nil
+}
+
+// Write implements io.Writer.
+func (w *encryptingWriter) Write(data []byte) (int, error) {
+	written := 0
+	for len(data) > 0 {
+		n := encryptedChunkSize - len(w.chunk)
+		if n > len(data) {
+			n = len(data)
+		}
+		w.chunk = append(w.chunk, data[:n]...)
+		data = data[n:]
+		written += n
+		// A full chunk is never the last one, so it can be sealed
+		// as soon as it is full.
+		if len(w.chunk) == encryptedChunkSize {
+			if err := w.seal(notLastChunk); err != nil {
+				return written, errors.Trace(err)
+			}
+		}
+	}
+	return written, nil
+}
+
+// Close implements io.Closer.
+func (w *encryptingWriter) Close() error {
+	return errors.Trace(w.seal(lastChunk))
+}
+
+func (w *encryptingWriter) seal(additionalData []byte) error {
+	sealed := w.aead.Seal(nil, chunkNonce(w.aead, w.index), w.chunk, additionalData)
+	if _, err := w.out.Write(sealed); err != nil {
+		return errors.Trace(err)
+	}
+	w.index++
+	w.chunk = w.chunk[:0]
+	return nil
+}
+
+type decryptingReader struct {
+	in     io.Reader
+	aead   cipher.AEAD
+	sealed []byte
+	chunk  []byte
+	index  uint64
+	done   bool
+}
+
+// NewDecryptingReader returns a reader of the archive that was
+// encrypted with the given secret and is read from in. Reading fails
+// if the secret is wrong or the archive has been tampered with.
+func NewDecryptingReader(in io.Reader, secret string) (io.Reader, error) {
+	header := make([]byte, len(encryptedMagic)+encryptionSaltSize)
+	if _, err := io.ReadFull(in, header); err != nil {
+		return nil, errors.Annotate(err, "while reading encryption header")
+	}
+	if string(header[:len(encryptedMagic)]) != encryptedMagic {
+		return nil, errors.New("archive is not encrypted")
+	}
+	aead, err := newArchiveCipher(secret, header[len(encryptedMagic):])
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &decryptingReader{
+		in:     in,
+		aead:   aead,
+		sealed: make([]byte, encryptedChunkSize+aead.Overhead()),
+	}, nil
+}
+
+// Read implements io.Reader.
+func (r *decryptingReader) Read(data []byte) (int, error) {
+	for len(r.chunk) == 0 {
+		if r.done {
+			return 0, io.EOF
+		}
+		if err := r.open(); err != nil {
+			return 0, errors.Trace(err)
+		}
+	}
+	n := copy(data, r.chunk)
+	r.chunk = r.chunk[n:]
+	return n, nil
+}
+
+func (r *decryptingReader) open() error {
+	additionalData := notLastChunk
+	n, err := io.ReadFull(r.in, r.sealed)
+	switch err {
+	case nil:
+	case io.EOF, io.ErrUnexpectedEOF:
+		// Only the last chunk is short.
+		additionalData = lastChunk
+		r.done = true
+	default:
+		return errors.Trace(err)
+	}
+	chunk, err := r.aead.Open(r.sealed[:0], chunkNonce(r.aead, r.index), r.sealed[:n], additionalData)
+	if err != nil {
+		return errors.New("cannot decrypt archive: wrong key, or archive corrupted")
+	}
+	r.index++
+	r.chunk = chunk
+	return nil
+}
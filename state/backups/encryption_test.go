@@ -0,0 +1,86 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"strings"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state/backups"
+	"github.com/juju/juju/testing"
+)
+
+type encryptionSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&encryptionSuite{})
+
+func encrypt(c *gc.C, plain, secret string) []byte {
+	var buf bytes.Buffer
+	w, err := backups.NewEncryptingWriter(&buf, secret)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = w.Write([]byte(plain))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(w.Close(), jc.ErrorIsNil)
+	return buf.Bytes()
+}
+
+func decrypt(encrypted []byte, secret string) (string, error) {
+	r, err := backups.NewDecryptingReader(bytes.NewReader(encrypted), secret)
+	if err != nil {
+		return "", err
+	}
+	plain, err := ioutil.ReadAll(r)
+	return string(plain), err
+}
+
+func (s *encryptionSuite) TestRoundTrip(c *gc.C) {
+	for i, size := range []int{0, 10, 64 * 1024, 200 * 1024} {
+		c.Logf("test %d: %d bytes", i, size)
+		plain := strings.Repeat("x", size)
+		encrypted := encrypt(c, plain, "sekrit")
+		c.Check(bytes.Contains(encrypted, []byte("xxxxxxxx")), jc.IsFalse)
+
+		decrypted, err := decrypt(encrypted, "sekrit")
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(decrypted, gc.Equals, plain)
+	}
+}
+
+func (s *encryptionSuite) TestWrongSecret(c *gc.C) {
+	encrypted := encrypt(c, "some data", "sekrit")
+	_, err := decrypt(encrypted, "guess")
+	c.Assert(err, gc.ErrorMatches, "cannot decrypt archive: wrong key, or archive corrupted")
+}
+
+func (s *encryptionSuite) TestTruncated(c *gc.C) {
+	encrypted := encrypt(c, strings.Repeat("x", 100*1024), "sekrit")
+	// Drop the last chunk, leaving only the first, full, one.
+	truncated := encrypted[:len(encrypted)-(100-64)*1024-16]
+	_, err := decrypt(truncated, "sekrit")
+	c.Assert(err, gc.ErrorMatches, "cannot decrypt archive: wrong key, or archive corrupted")
+}
+
+func (s *encryptionSuite) TestIsEncrypted(c *gc.C) {
+	encrypted, r, err := backups.IsEncrypted(bytes.NewReader(encrypt(c, "data", "sekrit")))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(encrypted, jc.IsTrue)
+	decrypted, err := backups.NewDecryptingReader(r, "sekrit")
+	c.Assert(err, jc.ErrorIsNil)
+	data, err := ioutil.ReadAll(decrypted)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(data), gc.Equals, "data")
+
+	encrypted, r, err = backups.IsEncrypted(strings.NewReader("plain"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(encrypted, jc.IsFalse)
+	data, err = ioutil.ReadAll(r)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(data), gc.Equals, "plain")
+}
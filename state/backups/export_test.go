@@ -22,6 +22,7 @@ var (
 
 	TestGetFilesToBackUp = &getFilesToBackUp
 	GetDBDumper          = &getDBDumper
+	GetOplogDumper       = &getOplogDumper
 	RunCreate            = &runCreate
 	FinishMeta           = &finishMeta
 	StoreArchiveRef      = &storeArchive
@@ -102,6 +103,12 @@ func NewTestCreateArgs(filesToBackUp []string, db DBDumper, metar io.Reader) *cr
 	return &args
 }
 
+// SetTestCreateKeys sets the keys with which create() protects the
+// archive.
+func SetTestCreateKeys(args *createArgs, keys ArchiveKeys) {
+	args.keys = keys
+}
+
 // ExposeCreateResult extracts the values in a create() args value.
 func ExposeCreateArgs(args *createArgs) ([]string, DBDumper) {
 	return args.filesToBackUp, args.db
@@ -159,6 +159,7 @@ func (s *LegacySuite) checkArchive(c *gc.C, file *os.File, bundle []tarContent)
 		{"juju-backup/dump", "", nil},
 		{"juju-backup/root.tar", "", bundle},
 		{"juju-backup/metadata.json", "", nil},
+		{"juju-backup/manifest.json", "", nil},
 	}
 
 	tarFile, err := gzip.NewReader(file)
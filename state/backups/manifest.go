@@ -0,0 +1,179 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/cert"
+)
+
+// Manifest records the hash of every file in a backup archive, other
+// than the manifest and its signature.
+type Manifest struct {
+	// Files maps the slash-separated path of each file, relative to
+	// the archive's content directory, to its hex-encoded SHA-256
+	// hash.
+	Files map[string]string `json:"files"`
+}
+
+// buildManifest returns the manifest of the files in the given
+// content directory.
+func buildManifest(contentDir string) (*Manifest, error) {
+	manifest := Manifest{Files: make(map[string]string)}
+	err := filepath.Walk(contentDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if info.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(contentDir, path)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		rel = filepath.ToSlash(rel)
+		if rel == manifestFile || rel == signatureFile {
+			return nil
+		}
+		sum, err := hashFile(path)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		manifest.Files[rel] = sum
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Annotate(err, "while hashing archive files")
+	}
+	return &manifest, nil
+}
+
+func hashFile(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	defer file.Close()
+
+	hasher := sha256.New()
+	if _, err := io.Copy(hasher, file); err != nil {
+		return "", errors.Trace(err)
+	}
+	return hex.EncodeToString(hasher.Sum(nil)), nil
+}
+
+// check returns an error unless the files in the given content
+// directory are exactly those in the manifest.
+func (m *Manifest) check(contentDir string) error {
+	actual, err := buildManifest(contentDir)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var names []string
+	for name := range m.Files {
+		names = append(names, name)
+	}
+	for name := range actual.Files {
+		if _, ok := m.Files[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		want, listed := m.Files[name]
+		got, present := actual.Files[name]
+		switch {
+		case !present:
+			return errors.Errorf("file %q is missing", name)
+		case !listed:
+			return errors.Errorf("file %q is not in the manifest", name)
+		case got != want:
+			return errors.Errorf("file %q has been modified", name)
+		}
+	}
+	return nil
+}
+
+// writeManifest writes the manifest of the files in the archive to
+// the archive, signing it with the CA key if one is given.
+func writeManifest(paths ArchivePaths, caCert, caPrivateKey string) error {
+	manifest, err := buildManifest(paths.ContentDir)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	data, err := json.Marshal(manifest)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := writeFile(paths.ManifestFile, data); err != nil {
+		return errors.Trace(err)
+	}
+	if caPrivateKey == "" {
+		return nil
+	}
+	signature, err := signManifest(data, caCert, caPrivateKey)
+	if err != nil {
+		return errors.Annotate(err, "while signing manifest")
+	}
+	return errors.Trace(writeFile(paths.SignatureFile, []byte(signature)))
+}
+
+func writeFile(filename string, data []byte) error {
+	file, err := os.Create(filename)
+	if err != nil {
+		return errors.Annotatef(err, "while creating file %q", filename)
+	}
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		return errors.Annotatef(err, "while writing file %q", filename)
+	}
+	return errors.Trace(file.Close())
+}
+
+// signManifest returns the base64-encoded signature of the manifest
+// data, made with the CA key.
+func signManifest(data []byte, caCert, caPrivateKey string) (string, error) {
+	_, key, err := cert.ParseCertAndKey(caCert, caPrivateKey)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	digest := sha256.Sum256(data)
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return base64.StdEncoding.EncodeToString(signature), nil
+}
+
+// checkManifestSignature returns an error unless the signature of the
+// manifest data was made with the key of the given CA certificate.
+func checkManifestSignature(data []byte, signature, caCert string) error {
+	caCertificate, err := cert.ParseCert(caCert)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	key, ok := caCertificate.PublicKey.(*rsa.PublicKey)
+	if !ok {
+		return errors.Errorf("unexpected CA public key type %T", caCertificate.PublicKey)
+	}
+	rawSignature, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	digest := sha256.Sum256(data)
+	return errors.Trace(rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], rawSignature))
+}
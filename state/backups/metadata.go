@@ -62,6 +62,22 @@ type Metadata struct {
 	Origin Origin
 	// Notes is an optional user-supplied annotation.
 	Notes string
+
+	// Parent holds the ID of the backup on which an incremental
+	// backup builds. It is empty for full backups.
+	Parent string
+	// OplogStart and OplogEnd hold the timestamps of the oplog range
+	// covered by the backup. Restoring an incremental backup replays
+	// the oplog from OplogStart, which is its parent's OplogEnd.
+	OplogStart int64
+	OplogEnd   int64
+	// Scheduled records whether the backup was created on the
+	// controller's backup schedule, and so may be removed under its
+	// retention policy.
+	Scheduled bool
+	// Encrypted records whether the archive is encrypted, and so
+	// needs a key to be restored.
+	Encrypted bool
 }
 
 // NewMetadata returns a new Metadata for a state backup archive.  Only
@@ -95,6 +111,12 @@ func NewMetadataState(db DB, machine string) (*Metadata, error) {
 	return meta, nil
 }
 
+// Incremental reports whether the backup holds only the changes made
+// since its parent backup.
+func (m *Metadata) Incremental() bool {
+	return m.Parent != ""
+}
+
 // MarkComplete populates the remaining metadata values.  The default
 // checksum format is used.
 func (m *Metadata) MarkComplete(size int64, checksum string) error {
@@ -134,6 +156,11 @@ type flatMetadata struct {
 	Machine     string
 	Hostname    string
 	Version     version.Number
+	Parent      string `json:",omitempty"`
+	OplogStart  int64  `json:",omitempty"`
+	OplogEnd    int64  `json:",omitempty"`
+	Scheduled   bool   `json:",omitempty"`
+	Encrypted   bool   `json:",omitempty"`
 }
 
 // TODO(ericsnow) Move AsJSONBuffer to filestorage.Metadata.
@@ -153,6 +180,11 @@ func (m *Metadata) AsJSONBuffer() (io.Reader, error) {
 		Machine:     m.Origin.Machine,
 		Hostname:    m.Origin.Hostname,
 		Version:     m.Origin.Version,
+		Parent:      m.Parent,
+		OplogStart:  m.OplogStart,
+		OplogEnd:    m.OplogEnd,
+		Scheduled:   m.Scheduled,
+		Encrypted:   m.Encrypted,
 	}
 
 	stored := m.Stored()
@@ -195,6 +227,11 @@ func NewMetadataJSONReader(in io.Reader) (*Metadata, error) {
 		meta.Finished = &flat.Finished
 	}
 	meta.Notes = flat.Notes
+	meta.Parent = flat.Parent
+	meta.OplogStart = flat.OplogStart
+	meta.OplogEnd = flat.OplogEnd
+	meta.Scheduled = flat.Scheduled
+	meta.Encrypted = flat.Encrypted
 	meta.Origin = Origin{
 		Model:    flat.Environment,
 		Machine:  flat.Machine,
@@ -4,9 +4,11 @@
 package backups
 
 import (
+	"github.com/juju/errors"
 	"github.com/juju/names"
 
 	"github.com/juju/juju/instance"
+	"github.com/juju/juju/state"
 )
 
 // RestoreArgs holds the args to be used to call state/backups.Restore
@@ -16,4 +18,59 @@ type RestoreArgs struct {
 	NewInstId      instance.Id
 	NewInstTag     names.Tag
 	NewInstSeries  string
+	// Secret is the key or passphrase with which the backup being
+	// restored was encrypted, if it was.
+	Secret string
+	// ChainSecrets holds the keys or passphrases of the earlier
+	// backups in the chain of an incremental backup, by backup ID.
+	// Those not given are assumed to have been encrypted with Secret.
+	ChainSecrets map[string]string
+}
+
+// secret returns the key or passphrase of the backup with the given ID.
+func (args RestoreArgs) secret(id string) string {
+	if secret, ok := args.ChainSecrets[id]; ok {
+		return secret
+	}
+	return args.Secret
+}
+
+// ArchiveKeys holds the keys with which a new backup archive is
+// protected. The zero value leaves the archive unencrypted and its
+// manifest unsigned.
+type ArchiveKeys struct {
+	// Secret is the key or passphrase with which the archive is
+	// encrypted. The archive is not encrypted if it is empty.
+	Secret string
+
+	// CACert and CAPrivateKey hold the controller's CA certificate
+	// and key, with which the archive's manifest is signed. The
+	// manifest is not signed if they are empty. Since the CA key is
+	// held in the archive itself, only encrypted archives are signed.
+	CACert       string
+	CAPrivateKey string
+}
+
+// NewArchiveKeys returns the keys with which to protect a new backup
+// of the controller: the given secret, and the controller's CA key if
+// the secret is not empty.
+func NewArchiveKeys(st *state.State, secret string) (ArchiveKeys, error) {
+	keys := ArchiveKeys{Secret: secret}
+	if secret == "" {
+		return keys, nil
+	}
+	info, err := st.StateServingInfo()
+	if errors.IsNotFound(err) {
+		logger.Warningf("no state serving info; backup manifest will not be signed")
+		return keys, nil
+	} else if err != nil {
+		return ArchiveKeys{}, errors.Trace(err)
+	}
+	if info.CAPrivateKey == "" {
+		logger.Warningf("no CA private key; backup manifest will not be signed")
+		return keys, nil
+	}
+	keys.CACert = st.CACert()
+	keys.CAPrivateKey = info.CAPrivateKey
+	return keys, nil
 }
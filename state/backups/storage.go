@@ -45,6 +45,14 @@ type storageMetaDoc struct {
 	Finished int64  `bson:"finished,minsize"`
 	Notes    string `bson:"notes,omitempty"`
 
+	// incremental backups and retention
+
+	Parent     string `bson:"parent,omitempty"`
+	OplogStart int64  `bson:"oplogstart,omitempty"`
+	OplogEnd   int64  `bson:"oplogend,omitempty"`
+	Scheduled  bool   `bson:"scheduled,omitempty"`
+	Encrypted  bool   `bson:"encrypted,omitempty"`
+
 	// origin
 
 	Model    string         `bson:"model"`
@@ -120,6 +128,11 @@ func docAsMetadata(doc *storageMetaDoc) *Metadata {
 	meta := NewMetadata()
 	meta.Started = metadocUnixToTime(doc.Started)
 	meta.Notes = doc.Notes
+	meta.Parent = doc.Parent
+	meta.OplogStart = doc.OplogStart
+	meta.OplogEnd = doc.OplogEnd
+	meta.Scheduled = doc.Scheduled
+	meta.Encrypted = doc.Encrypted
 
 	meta.Origin.Model = doc.Model
 	meta.Origin.Machine = doc.Machine
@@ -170,6 +183,11 @@ func newStorageMetaDoc(meta *Metadata) storageMetaDoc {
 		doc.Finished = metadocTimeToUnix(*meta.Finished)
 	}
 	doc.Notes = meta.Notes
+	doc.Parent = meta.Parent
+	doc.OplogStart = meta.OplogStart
+	doc.OplogEnd = meta.OplogEnd
+	doc.Scheduled = meta.Scheduled
+	doc.Encrypted = meta.Encrypted
 
 	doc.Model = meta.Origin.Model
 	doc.Machine = meta.Origin.Machine
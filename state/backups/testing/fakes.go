@@ -32,12 +32,16 @@ type FakeBackups struct {
 
 	// IDArg holds the ID that was passed in.
 	IDArg string
+	// ParentArg holds the parent backup ID that was passed in.
+	ParentArg string
 	// PathsArg holds the Paths that was passed in.
 	PathsArg *backups.Paths
 	// DBInfoArg holds the ConnInfo that was passed in.
 	DBInfoArg *backups.DBInfo
 	// MetaArg holds the backup metadata that was passed in.
 	MetaArg *backups.Metadata
+	// KeysArg holds the archive keys that were passed in.
+	KeysArg backups.ArchiveKeys
 	// PrivateAddr Holds the address for the internal network of the machine.
 	PrivateAddr string
 	// InstanceId Is the id of the machine to be restored.
@@ -50,12 +54,31 @@ var _ backups.Backups = (*FakeBackups)(nil)
 
 // Create creates and stores a new juju backup archive and returns
 // its associated metadata.
-func (b *FakeBackups) Create(meta *backups.Metadata, paths *backups.Paths, dbInfo *backups.DBInfo) error {
+func (b *FakeBackups) Create(meta *backups.Metadata, paths *backups.Paths, dbInfo *backups.DBInfo, keys backups.ArchiveKeys) error {
 	b.Calls = append(b.Calls, "Create")
 
 	b.PathsArg = paths
 	b.DBInfoArg = dbInfo
 	b.MetaArg = meta
+	b.KeysArg = keys
+
+	if b.Meta != nil {
+		*meta = *b.Meta
+	}
+
+	return b.Error
+}
+
+// CreateIncremental creates and stores a new incremental backup
+// archive and returns its associated metadata.
+func (b *FakeBackups) CreateIncremental(meta *backups.Metadata, paths *backups.Paths, dbInfo *backups.DBInfo, parentID string, keys backups.ArchiveKeys) error {
+	b.Calls = append(b.Calls, "CreateIncremental")
+
+	b.PathsArg = paths
+	b.DBInfoArg = dbInfo
+	b.MetaArg = meta
+	b.KeysArg = keys
+	b.ParentArg = parentID
 
 	if b.Meta != nil {
 		*meta = *b.Meta
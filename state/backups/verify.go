@@ -0,0 +1,243 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"archive/tar"
+	"crypto/sha1"
+	"encoding/binary"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/hash"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// maxBSONDocumentSize is the largest document mongo will dump, with
+// some room to spare.
+const maxBSONDocumentSize = 32 * 1024 * 1024
+
+// VerifyArgs holds what is needed to verify a backup archive.
+type VerifyArgs struct {
+	// Secret is the key or passphrase with which the archive was
+	// encrypted, if it was.
+	Secret string
+
+	// CACert is the certificate of the CA with whose key the
+	// manifest of an encrypted archive must be signed. If it is
+	// empty, the signature is not checked. The manifests of
+	// unencrypted archives are never signed, because the CA key is
+	// held in the archive.
+	CACert string
+
+	// Checksum is the checksum the archive must have, as recorded in
+	// its metadata. If it is empty, the checksum is not checked.
+	Checksum string
+}
+
+// VerifyResult describes a backup archive that has been verified.
+type VerifyResult struct {
+	// Metadata is the metadata held in the archive.
+	Metadata *Metadata
+
+	// Encrypted records whether the archive is encrypted.
+	Encrypted bool
+
+	// Signed records whether the signature of the archive's manifest
+	// was checked. It is never checked for unencrypted archives.
+	Signed bool
+
+	// Files is the number of files checked against the manifest.
+	Files int
+
+	// Documents is the number of documents read from the archive's
+	// database dump.
+	Documents int
+}
+
+// Verify checks that the backup archive read from archive is whole and
+// unmodified, and that it could be restored: that it can be decrypted
+// and unpacked, that its files match its manifest, and that its files
+// bundle and database dump can be read. Nothing is restored.
+func Verify(archive io.Reader, args VerifyArgs) (*VerifyResult, error) {
+	var result VerifyResult
+	var hasher *hash.HashingWriter
+	if args.Checksum != "" {
+		hasher = hash.NewHashingWriter(ioutil.Discard, sha1.New())
+		archive = io.TeeReader(archive, hasher)
+	}
+	raw := archive
+
+	encrypted, archive, err := IsEncrypted(archive)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if encrypted {
+		if args.Secret == "" {
+			return nil, errors.New("archive is encrypted; a key is needed to verify it")
+		}
+		result.Encrypted = true
+		archive, err = NewDecryptingReader(archive, args.Secret)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	workspace, err := NewArchiveWorkspaceReader(archive)
+	if workspace != nil {
+		defer workspace.Close()
+	}
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot unpack archive")
+	}
+
+	if hasher != nil {
+		// Unpacking need not read to the very end of the archive.
+		if _, err := io.Copy(ioutil.Discard, raw); err != nil {
+			return nil, errors.Trace(err)
+		}
+		if checksum := hasher.Base64Sum(); checksum != args.Checksum {
+			return nil, errors.Errorf("archive checksum %q does not match %q in its metadata", checksum, args.Checksum)
+		}
+	}
+
+	result.Metadata, err = workspace.Metadata()
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot read metadata")
+	}
+
+	var caCert string
+	if encrypted {
+		caCert = args.CACert
+	}
+	manifest, err := readManifest(workspace, caCert)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result.Signed = caCert != ""
+	if err := manifest.check(workspace.ContentDir); err != nil {
+		return nil, errors.Annotate(err, "archive does not match its manifest")
+	}
+	result.Files = len(manifest.Files)
+
+	if err := checkFilesBundle(workspace.FilesBundle); err != nil {
+		return nil, errors.Annotate(err, "cannot read files bundle")
+	}
+	result.Documents, err = checkDBDump(workspace.DBDumpDir)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot read database dump")
+	}
+	return &result, nil
+}
+
+// readManifest reads the manifest from the workspace, checking its
+// signature if a CA certificate is given.
+func readManifest(workspace *ArchiveWorkspace, caCert string) (*Manifest, error) {
+	data, err := ioutil.ReadFile(workspace.ManifestFile)
+	if os.IsNotExist(err) {
+		return nil, errors.New("archive has no manifest; it was created by an older juju")
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if caCert != "" {
+		signature, err := ioutil.ReadFile(workspace.SignatureFile)
+		if os.IsNotExist(err) {
+			return nil, errors.New("archive manifest is not signed")
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if err := checkManifestSignature(data, string(signature), caCert); err != nil {
+			return nil, errors.Annotate(err, "archive manifest signature not valid")
+		}
+	}
+	var manifest Manifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, errors.Annotate(err, "cannot parse manifest")
+	}
+	return &manifest, nil
+}
+
+func checkFilesBundle(filename string) error {
+	file, err := os.Open(filename)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer file.Close()
+
+	bundle := tar.NewReader(file)
+	for {
+		_, err := bundle.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if _, err := io.Copy(ioutil.Discard, bundle); err != nil {
+			return errors.Trace(err)
+		}
+	}
+}
+
+// checkDBDump parses every document in the BSON files of the database
+// dump, and returns how many there are.
+func checkDBDump(dumpDir string) (int, error) {
+	count, files := 0, 0
+	err := filepath.Walk(dumpDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if info.IsDir() || !strings.HasSuffix(path, ".bson") {
+			return nil
+		}
+		files++
+		n, err := checkBSONFile(path)
+		if err != nil {
+			rel, _ := filepath.Rel(dumpDir, path)
+			return errors.Annotatef(err, "in %q", filepath.ToSlash(rel))
+		}
+		count += n
+		return nil
+	})
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	if files == 0 {
+		return 0, errors.New("no collections found")
+	}
+	return count, nil
+}
+
+func checkBSONFile(filename string) (int, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	defer file.Close()
+
+	for count := 0; ; count++ {
+		var size int32
+		if err := binary.Read(file, binary.LittleEndian, &size); err == io.EOF {
+			return count, nil
+		} else if err != nil {
+			return 0, errors.Annotatef(err, "document %d", count)
+		}
+		if size < 5 || size > maxBSONDocumentSize {
+			return 0, errors.Errorf("document %d has invalid size %d", count, size)
+		}
+		data := make([]byte, size)
+		binary.LittleEndian.PutUint32(data, uint32(size))
+		if _, err := io.ReadFull(file, data[4:]); err != nil {
+			return 0, errors.Annotatef(err, "document %d", count)
+		}
+		var doc bson.M
+		if err := bson.Unmarshal(data, &doc); err != nil {
+			return 0, errors.Annotatef(err, "document %d", count)
+		}
+	}
+}
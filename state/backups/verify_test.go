@@ -0,0 +1,177 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups_test
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/mgo.v2/bson"
+
+	"github.com/juju/juju/state/backups"
+	backupstesting "github.com/juju/juju/state/backups/testing"
+	"github.com/juju/juju/testing"
+)
+
+type verifySuite struct {
+	LegacySuite
+}
+
+var _ = gc.Suite(&verifySuite{})
+
+func (s *verifySuite) SetUpTest(c *gc.C) {
+	if runtime.GOOS == "windows" {
+		c.Skip("bug 1403084: Currently does not work on windows, see comments inside backups.create function")
+	}
+	s.LegacySuite.SetUpTest(c)
+}
+
+// bsonDumper writes the given data as the dump of a collection.
+type bsonDumper struct {
+	data []byte
+}
+
+func (d *bsonDumper) Dump(dumpDir string) error {
+	if err := os.MkdirAll(filepath.Join(dumpDir, "juju"), 0755); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filepath.Join(dumpDir, "juju", "machines.bson"), d.data, 0644)
+}
+
+func machineDocs(c *gc.C, ids ...string) []byte {
+	var data []byte
+	for _, id := range ids {
+		doc, err := bson.Marshal(bson.M{"_id": id})
+		c.Assert(err, jc.ErrorIsNil)
+		data = append(data, doc...)
+	}
+	return data
+}
+
+func (s *verifySuite) createArchive(c *gc.C, dumped []byte, keys backups.ArchiveKeys) io.ReadCloser {
+	meta := backupstesting.NewMetadataStarted()
+	metadataFile, err := meta.AsJSONBuffer()
+	c.Assert(err, jc.ErrorIsNil)
+	_, testFiles, _ := s.createTestFiles(c)
+
+	args := backups.NewTestCreateArgs(testFiles, &bsonDumper{dumped}, metadataFile)
+	backups.SetTestCreateKeys(args, keys)
+	result, err := backups.Create(args)
+	c.Assert(err, jc.ErrorIsNil)
+	archiveFile, _, _ := backups.ExposeCreateResult(result)
+	s.AddCleanup(func(*gc.C) { archiveFile.Close() })
+	return archiveFile
+}
+
+func (s *verifySuite) TestVerify(c *gc.C) {
+	archive := s.createArchive(c, machineDocs(c, "0", "1"), backups.ArchiveKeys{})
+
+	result, err := backups.Verify(archive, backups.VerifyArgs{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result.Encrypted, jc.IsFalse)
+	c.Check(result.Signed, jc.IsFalse)
+	// metadata.json, root.tar and juju/machines.bson.
+	c.Check(result.Files, gc.Equals, 3)
+	c.Check(result.Documents, gc.Equals, 2)
+	c.Check(result.Metadata, gc.NotNil)
+}
+
+func (s *verifySuite) TestVerifyEncryptedAndSigned(c *gc.C) {
+	archive := s.createArchive(c, machineDocs(c, "0"), backups.ArchiveKeys{
+		Secret:       "sekrit",
+		CACert:       testing.CACert,
+		CAPrivateKey: testing.CAKey,
+	})
+
+	result, err := backups.Verify(archive, backups.VerifyArgs{
+		Secret: "sekrit",
+		CACert: testing.CACert,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result.Encrypted, jc.IsTrue)
+	c.Check(result.Signed, jc.IsTrue)
+	c.Check(result.Metadata.Encrypted, jc.IsTrue)
+	c.Check(result.Documents, gc.Equals, 1)
+}
+
+func (s *verifySuite) TestVerifyEncryptedWithoutKey(c *gc.C) {
+	archive := s.createArchive(c, machineDocs(c, "0"), backups.ArchiveKeys{Secret: "sekrit"})
+
+	_, err := backups.Verify(archive, backups.VerifyArgs{})
+	c.Assert(err, gc.ErrorMatches, "archive is encrypted; a key is needed to verify it")
+}
+
+func (s *verifySuite) TestVerifyWrongKey(c *gc.C) {
+	archive := s.createArchive(c, machineDocs(c, "0"), backups.ArchiveKeys{Secret: "sekrit"})
+
+	_, err := backups.Verify(archive, backups.VerifyArgs{Secret: "guess"})
+	c.Assert(err, gc.ErrorMatches, "cannot unpack archive: .*cannot decrypt archive: wrong key, or archive corrupted")
+}
+
+func (s *verifySuite) TestVerifyUnsigned(c *gc.C) {
+	archive := s.createArchive(c, machineDocs(c, "0"), backups.ArchiveKeys{Secret: "sekrit"})
+
+	_, err := backups.Verify(archive, backups.VerifyArgs{
+		Secret: "sekrit",
+		CACert: testing.CACert,
+	})
+	c.Assert(err, gc.ErrorMatches, "archive manifest is not signed")
+}
+
+func (s *verifySuite) TestVerifyUnencryptedSignatureNotChecked(c *gc.C) {
+	archive := s.createArchive(c, machineDocs(c, "0"), backups.ArchiveKeys{})
+
+	result, err := backups.Verify(archive, backups.VerifyArgs{CACert: testing.CACert})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result.Encrypted, jc.IsFalse)
+	c.Check(result.Signed, jc.IsFalse)
+}
+
+func (s *verifySuite) TestVerifySignedByOtherCA(c *gc.C) {
+	archive := s.createArchive(c, machineDocs(c, "0"), backups.ArchiveKeys{
+		Secret:       "sekrit",
+		CACert:       testing.OtherCACert,
+		CAPrivateKey: testing.OtherCAKey,
+	})
+
+	_, err := backups.Verify(archive, backups.VerifyArgs{
+		Secret: "sekrit",
+		CACert: testing.CACert,
+	})
+	c.Assert(err, gc.ErrorMatches, "archive manifest signature not valid: .*")
+}
+
+func (s *verifySuite) TestCreateRefusesToSignUnencrypted(c *gc.C) {
+	meta := backupstesting.NewMetadataStarted()
+	metadataFile, err := meta.AsJSONBuffer()
+	c.Assert(err, jc.ErrorIsNil)
+	_, testFiles, _ := s.createTestFiles(c)
+
+	args := backups.NewTestCreateArgs(testFiles, &bsonDumper{machineDocs(c, "0")}, metadataFile)
+	backups.SetTestCreateKeys(args, backups.ArchiveKeys{
+		CACert:       testing.CACert,
+		CAPrivateKey: testing.CAKey,
+	})
+	_, err = backups.Create(args)
+	c.Assert(err, gc.ErrorMatches, "cannot sign the manifest of an unencrypted backup")
+}
+
+func (s *verifySuite) TestVerifyChecksumMismatch(c *gc.C) {
+	archive := s.createArchive(c, machineDocs(c, "0"), backups.ArchiveKeys{})
+
+	_, err := backups.Verify(archive, backups.VerifyArgs{Checksum: "bogus"})
+	c.Assert(err, gc.ErrorMatches, `archive checksum ".*" does not match "bogus" in its metadata`)
+}
+
+func (s *verifySuite) TestVerifyBadDump(c *gc.C) {
+	archive := s.createArchive(c, []byte{2, 0, 0, 0}, backups.ArchiveKeys{})
+
+	_, err := backups.Verify(archive, backups.VerifyArgs{})
+	c.Assert(err, gc.ErrorMatches, `cannot read database dump: in "juju/machines.bson": document 0 has invalid size 2`)
+}
@@ -5,6 +5,7 @@ package state
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/names"
@@ -13,6 +14,7 @@ import (
 	"gopkg.in/mgo.v2/txn"
 
 	"github.com/juju/juju/state/multiwatcher"
+	"github.com/juju/juju/utils/cron"
 )
 
 // Customers and stakeholders want to be able to prevent accidental damage to their Juju deployments.
@@ -32,6 +34,100 @@ type Block interface {
 
 	// Message returns explanation that accompanies this block.
 	Message() string
+
+	// Expires returns when the block is lifted, or nil if it stays
+	// on until switched off.
+	Expires() *time.Time
+
+	// Schedule returns the recurring window outside which the block
+	// does not apply, or nil if it always applies.
+	Schedule() *BlockSchedule
+
+	// ExemptUsers returns the canonical names of the users the block
+	// does not apply to.
+	ExemptUsers() []string
+}
+
+// BlockSchedule describes a recurring window, such as working hours,
+// during which a block applies.
+type BlockSchedule struct {
+	// Start is a cron-style schedule of when each window opens.
+	Start string `bson:"start"`
+
+	// Duration is how long each window stays open.
+	Duration time.Duration `bson:"duration"`
+
+	// Location is the name of the time zone Start is interpreted
+	// in, such as "Europe/London". If it is empty, UTC is used.
+	Location string `bson:"location,omitempty"`
+}
+
+// Validate returns an error if the schedule is not valid.
+func (s BlockSchedule) Validate() error {
+	if _, err := cron.Parse(s.Start); err != nil {
+		return errors.Annotate(err, "invalid block schedule")
+	}
+	if s.Duration <= 0 {
+		return errors.NotValidf("block window duration %v", s.Duration)
+	}
+	if _, err := time.LoadLocation(s.Location); err != nil {
+		return errors.Annotate(err, "invalid block schedule")
+	}
+	return nil
+}
+
+// OpenAt returns whether a window of the schedule is open at the given
+// time.
+func (s BlockSchedule) OpenAt(t time.Time) (bool, error) {
+	schedule, err := cron.Parse(s.Start)
+	if err != nil {
+		return false, errors.Annotate(err, "invalid block schedule")
+	}
+	loc, err := time.LoadLocation(s.Location)
+	if err != nil {
+		return false, errors.Annotate(err, "invalid block schedule")
+	}
+	// A window is open if one opened within Duration of t.
+	opened := schedule.Next(t.Add(-s.Duration).In(loc))
+	return !opened.IsZero() && !opened.After(t), nil
+}
+
+// BlockArgs holds the details of a block being switched on.
+type BlockArgs struct {
+	// Message explains the block.
+	Message string
+
+	// Expires, if not nil, is when the block is lifted.
+	Expires *time.Time
+
+	// Schedule, if not nil, restricts the block to recurring windows.
+	Schedule *BlockSchedule
+
+	// ExemptUsers holds the users the block does not apply to.
+	ExemptUsers []names.UserTag
+}
+
+// Validate returns an error if the arguments are not valid.
+func (a BlockArgs) Validate() error {
+	if a.Schedule != nil {
+		if err := a.Schedule.Validate(); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// BlockAppliesAt returns whether the block applies at the given time:
+// that it has not expired and, if it has a schedule, that one of its
+// windows is open.
+func BlockAppliesAt(b Block, t time.Time) (bool, error) {
+	if expires := b.Expires(); expires != nil && !t.Before(*expires) {
+		return false, nil
+	}
+	if schedule := b.Schedule(); schedule != nil {
+		return schedule.OpenAt(t)
+	}
+	return true, nil
 }
 
 // BlockType specifies block type for enum benefit.
@@ -99,6 +195,10 @@ type blockDoc struct {
 	Tag       string    `bson:"tag"`
 	Type      BlockType `bson:"type"`
 	Message   string    `bson:"message,omitempty"`
+
+	Expires     *time.Time     `bson:"expires,omitempty"`
+	Schedule    *BlockSchedule `bson:"schedule,omitempty"`
+	ExemptUsers []string       `bson:"exempt-users,omitempty"`
 }
 
 // Id is part of the state.Block interface.
@@ -130,10 +230,46 @@ func (b *block) Type() BlockType {
 	return b.doc.Type
 }
 
+// Expires is part of the state.Block interface.
+func (b *block) Expires() *time.Time {
+	if b.doc.Expires == nil {
+		return nil
+	}
+	expires := b.doc.Expires.UTC()
+	return &expires
+}
+
+// Schedule is part of the state.Block interface.
+func (b *block) Schedule() *BlockSchedule {
+	if b.doc.Schedule == nil {
+		return nil
+	}
+	schedule := *b.doc.Schedule
+	return &schedule
+}
+
+// ExemptUsers is part of the state.Block interface.
+func (b *block) ExemptUsers() []string {
+	return b.doc.ExemptUsers
+}
+
 // SwitchBlockOn enables block of specified type for the
 // current model.
 func (st *State) SwitchBlockOn(t BlockType, msg string) error {
-	return setModelBlock(st, t, msg)
+	return setModelBlock(st, t, BlockArgs{Message: msg})
+}
+
+// SwitchBlockOnWithArgs enables a block of the specified type for the
+// current model, which may expire, apply only in recurring windows or
+// not apply to some users.
+func (st *State) SwitchBlockOnWithArgs(t BlockType, args BlockArgs) error {
+	if err := args.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	if args.Expires != nil && !args.Expires.After(time.Now()) {
+		return errors.Errorf("block expiry %v is not in the future", args.Expires.UTC())
+	}
+	return setModelBlock(st, t, args)
 }
 
 // SwitchBlockOff disables block of specified type for the
@@ -223,21 +359,64 @@ func (st *State) RemoveAllBlocksForController() error {
 	return st.runRawTransaction(ops)
 }
 
+// RemoveExpiredBlocks removes the blocks in any model on the
+// controller that expired before the given time.
+func (st *State) RemoveExpiredBlocks(now time.Time) error {
+	blocksCollection, closer := st.getRawCollection(blocksC)
+	defer closer()
+
+	var bdocs []blockDoc
+	err := blocksCollection.Find(bson.D{{"expires", bson.D{{"$lte", now}}}}).All(&bdocs)
+	if err != nil {
+		return errors.Annotate(err, "cannot get expired blocks")
+	}
+	var ops []txn.Op
+	for _, doc := range bdocs {
+		logger.Infof("removing expired block %v in model %s", doc.Type.String(), doc.ModelUUID)
+		ops = append(ops, txn.Op{
+			C:      blocksC,
+			Id:     doc.DocID,
+			Remove: true,
+		})
+	}
+	if len(ops) == 0 {
+		return nil
+	}
+	// Use runRawTransaction as we might be removing docs across
+	// multiple models.
+	return errors.Trace(st.runRawTransaction(ops))
+}
+
 // setModelBlock updates the blocks collection with the
 // specified block.
 // Only one instance of each block type can exist in model.
-func setModelBlock(st *State, t BlockType, msg string) error {
+func setModelBlock(st *State, t BlockType, args BlockArgs) error {
 	buildTxn := func(attempt int) ([]txn.Op, error) {
-		_, exists, err := st.GetBlockForType(t)
+		existing, exists, err := st.GetBlockForType(t)
 		if err != nil {
 			return nil, errors.Trace(err)
 		}
+		var ops []txn.Op
 		// Cannot create blocks of the same type more than once per model.
-		// Cannot update current blocks.
+		// Cannot update current blocks, but an expired block that has
+		// not been removed yet is replaced.
 		if exists {
-			return nil, errors.Errorf("block %v is already ON", t.String())
+			expires := existing.Expires()
+			if expires == nil || expires.After(time.Now()) {
+				return nil, errors.Errorf("block %v is already ON", t.String())
+			}
+			ops = append(ops, txn.Op{
+				C:      blocksC,
+				Id:     existing.Id(),
+				Assert: txn.DocExists,
+				Remove: true,
+			})
 		}
-		return createModelBlockOps(st, t, msg)
+		createOps, err := createModelBlockOps(st, t, args)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return append(ops, createOps...), nil
 	}
 	return st.run(buildTxn)
 }
@@ -251,7 +430,7 @@ func newBlockId(st *State) (string, error) {
 	return fmt.Sprint(seq), nil
 }
 
-func createModelBlockOps(st *State, t BlockType, msg string) ([]txn.Op, error) {
+func createModelBlockOps(st *State, t BlockType, args BlockArgs) ([]txn.Op, error) {
 	id, err := newBlockId(st)
 	if err != nil {
 		return nil, errors.Annotatef(err, "getting new block id")
@@ -261,7 +440,12 @@ func createModelBlockOps(st *State, t BlockType, msg string) ([]txn.Op, error) {
 		ModelUUID: st.ModelUUID(),
 		Tag:       st.ModelTag().String(),
 		Type:      t,
-		Message:   msg,
+		Message:   args.Message,
+		Expires:   args.Expires,
+		Schedule:  args.Schedule,
+	}
+	for _, user := range args.ExemptUsers {
+		newDoc.ExemptUsers = append(newDoc.ExemptUsers, user.Canonical())
 	}
 	insertOp := txn.Op{
 		C:      blocksC,
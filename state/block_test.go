@@ -5,6 +5,7 @@ package state_test
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/names"
@@ -186,6 +187,109 @@ func (s *blockSuite) TestModelUUID(c *gc.C) {
 	c.Assert(blocks[0].ModelUUID(), gc.Equals, st.ModelUUID())
 }
 
+func (s *blockSuite) TestSwitchBlockOnWithArgs(c *gc.C) {
+	expires := time.Now().Add(time.Hour).UTC().Round(time.Second)
+	schedule := &state.BlockSchedule{
+		Start:    "0 9 * * 1-5",
+		Duration: 8 * time.Hour,
+		Location: "Europe/London",
+	}
+	err := s.State.SwitchBlockOnWithArgs(state.ChangeBlock, state.BlockArgs{
+		Message:     "maintenance",
+		Expires:     &expires,
+		Schedule:    schedule,
+		ExemptUsers: []names.UserTag{names.NewUserTag("ops-oncall")},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	assertEnvHasBlock(c, s.State, state.ChangeBlock, "maintenance")
+	b, _, err := s.State.GetBlockForType(state.ChangeBlock)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(b.Expires(), jc.DeepEquals, &expires)
+	c.Assert(b.Schedule(), jc.DeepEquals, schedule)
+	c.Assert(b.ExemptUsers(), jc.DeepEquals, []string{"ops-oncall@local"})
+}
+
+func (s *blockSuite) TestSwitchBlockOnWithArgsInvalid(c *gc.C) {
+	past := time.Now().Add(-time.Hour)
+	err := s.State.SwitchBlockOnWithArgs(state.ChangeBlock, state.BlockArgs{Expires: &past})
+	c.Assert(err, gc.ErrorMatches, "block expiry .* is not in the future")
+
+	err = s.State.SwitchBlockOnWithArgs(state.ChangeBlock, state.BlockArgs{
+		Schedule: &state.BlockSchedule{Start: "0 9 * *", Duration: time.Hour},
+	})
+	c.Assert(err, gc.ErrorMatches, "invalid block schedule: .*")
+
+	err = s.State.SwitchBlockOnWithArgs(state.ChangeBlock, state.BlockArgs{
+		Schedule: &state.BlockSchedule{Start: "0 9 * * *"},
+	})
+	c.Assert(err, gc.ErrorMatches, "block window duration 0 not valid")
+
+	err = s.State.SwitchBlockOnWithArgs(state.ChangeBlock, state.BlockArgs{
+		Schedule: &state.BlockSchedule{Start: "0 9 * * *", Duration: time.Hour, Location: "Nowhere/Special"},
+	})
+	c.Assert(err, gc.ErrorMatches, "invalid block schedule: .*")
+	assertNoEnvBlock(c, s.State)
+}
+
+func (s *blockSuite) TestRemoveExpiredBlocks(c *gc.C) {
+	_, st2 := s.createTestEnv(c)
+	defer st2.Close()
+
+	soon := time.Now().Add(time.Minute)
+	later := time.Now().Add(time.Hour)
+	err := st2.SwitchBlockOnWithArgs(state.ChangeBlock, state.BlockArgs{Expires: &soon})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SwitchBlockOnWithArgs(state.ChangeBlock, state.BlockArgs{Expires: &later})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SwitchBlockOn(state.DestroyBlock, "forever")
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.RemoveExpiredBlocks(soon.Add(time.Second))
+	c.Assert(err, jc.ErrorIsNil)
+	assertNoEnvBlock(c, st2)
+	blocks, err := s.State.AllBlocks()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(blocks, gc.HasLen, 2)
+
+	err = s.State.RemoveExpiredBlocks(later.Add(time.Second))
+	c.Assert(err, jc.ErrorIsNil)
+	blocks, err = s.State.AllBlocks()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(blocks, gc.HasLen, 1)
+	c.Assert(blocks[0].Type(), gc.Equals, state.DestroyBlock)
+}
+
+func (s *blockSuite) TestBlockScheduleOpenAt(c *gc.C) {
+	schedule := state.BlockSchedule{Start: "0 9 * * 1-5", Duration: 8 * time.Hour}
+	for i, test := range []struct {
+		at   time.Time
+		open bool
+	}{
+		// Friday 1st April 2016.
+		{time.Date(2016, 4, 1, 8, 59, 0, 0, time.UTC), false},
+		{time.Date(2016, 4, 1, 9, 0, 0, 0, time.UTC), true},
+		{time.Date(2016, 4, 1, 16, 59, 0, 0, time.UTC), true},
+		{time.Date(2016, 4, 1, 17, 0, 0, 0, time.UTC), false},
+		// Saturday.
+		{time.Date(2016, 4, 2, 12, 0, 0, 0, time.UTC), false},
+	} {
+		c.Logf("test %d: %v", i, test.at)
+		open, err := schedule.OpenAt(test.at)
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(open, gc.Equals, test.open)
+	}
+
+	// In a time zone, the window follows local time.
+	schedule.Location = "America/New_York"
+	open, err := schedule.OpenAt(time.Date(2016, 4, 1, 14, 0, 0, 0, time.UTC))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(open, jc.IsTrue)
+	open, err = schedule.OpenAt(time.Date(2016, 4, 1, 22, 0, 0, 0, time.UTC))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(open, jc.IsFalse)
+}
+
 func (s *blockSuite) createTestEnv(c *gc.C) (*state.Model, *state.State) {
 	uuid, err := utils.NewUUID()
 	c.Assert(err, jc.ErrorIsNil)
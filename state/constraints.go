@@ -20,9 +20,12 @@ type constraintsDoc struct {
 	ModelUUID    string `bson:"model-uuid"`
 	Arch         *string
 	CpuCores     *uint64
+	MaxCpuCores  *uint64
 	CpuPower     *uint64
 	Mem          *uint64
+	MaxMem       *uint64
 	RootDisk     *uint64
+	MaxRootDisk  *uint64
 	InstanceType *string
 	Container    *instance.ContainerType
 	Tags         *[]string
@@ -30,20 +33,25 @@ type constraintsDoc struct {
 	// TODO(dimitern): Drop this once it's not possible to specify
 	// networks= in constraints.
 	Networks *[]string
+	Prefer   *[]string
 }
 
 func (doc constraintsDoc) value() constraints.Value {
 	return constraints.Value{
 		Arch:         doc.Arch,
 		CpuCores:     doc.CpuCores,
+		MaxCpuCores:  doc.MaxCpuCores,
 		CpuPower:     doc.CpuPower,
 		Mem:          doc.Mem,
+		MaxMem:       doc.MaxMem,
 		RootDisk:     doc.RootDisk,
+		MaxRootDisk:  doc.MaxRootDisk,
 		InstanceType: doc.InstanceType,
 		Container:    doc.Container,
 		Tags:         doc.Tags,
 		Spaces:       doc.Spaces,
 		Networks:     doc.Networks,
+		Prefer:       doc.Prefer,
 	}
 }
 
@@ -52,14 +60,18 @@ func newConstraintsDoc(st *State, cons constraints.Value) constraintsDoc {
 		ModelUUID:    st.ModelUUID(),
 		Arch:         cons.Arch,
 		CpuCores:     cons.CpuCores,
+		MaxCpuCores:  cons.MaxCpuCores,
 		CpuPower:     cons.CpuPower,
 		Mem:          cons.Mem,
+		MaxMem:       cons.MaxMem,
 		RootDisk:     cons.RootDisk,
+		MaxRootDisk:  cons.MaxRootDisk,
 		InstanceType: cons.InstanceType,
 		Container:    cons.Container,
 		Tags:         cons.Tags,
 		Spaces:       cons.Spaces,
 		Networks:     cons.Networks,
+		Prefer:       cons.Prefer,
 	}
 }
 
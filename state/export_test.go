@@ -51,6 +51,7 @@ var (
 	CurrentUpgradeId       = currentUpgradeId
 	NowToTheSecond         = nowToTheSecond
 	PickAddress            = &pickAddress
+	PruneActionsBatchSize  = &pruneActionsBatchSize
 	AddVolumeOps           = (*State).addVolumeOps
 	CombineMeterStatus     = combineMeterStatus
 	ServiceGlobalKey       = serviceGlobalKey
@@ -444,6 +445,7 @@ func MakeLogDoc(
 	location string,
 	level loggo.Level,
 	msg string,
+	fields map[string]string,
 ) *logDoc {
 	return &logDoc{
 		Id:        bson.NewObjectId(),
@@ -454,6 +456,7 @@ func MakeLogDoc(
 		Location:  location,
 		Level:     level,
 		Message:   msg,
+		Fields:    fields,
 	}
 }
 
@@ -0,0 +1,144 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+const (
+	// maxHookRecordsPerUnit holds the number of hook records kept
+	// for each unit; older records are removed as new ones are added.
+	maxHookRecordsPerUnit = 100
+
+	// maxHookRecordStderr holds the number of bytes of a hook's
+	// standard error output kept in its record.
+	maxHookRecordStderr = 4096
+)
+
+// HookRecord describes a hook run by a unit agent.
+type HookRecord struct {
+	// Hook holds the name of the hook.
+	Hook string
+
+	// Relation identifies the relation of a relation hook, as
+	// "<endpoint>:<relation id>"; it is empty for other hooks.
+	Relation string
+
+	// RemoteUnit holds the name of the remote unit of a relation
+	// hook, if any.
+	RemoteUnit string
+
+	// Started holds the time the hook started running, and Duration
+	// how long it ran for.
+	Started  time.Time
+	Duration time.Duration
+
+	// ExitCode holds the exit code of the hook process, or -1 if it
+	// did not exit normally.
+	ExitCode int
+
+	// Stderr holds the end of the hook's standard error output.
+	Stderr string
+}
+
+// hookRecordDoc is the persistent representation of a HookRecord.
+type hookRecordDoc struct {
+	Unit       string        `bson:"unit"`
+	Hook       string        `bson:"hook"`
+	Relation   string        `bson:"relation,omitempty"`
+	RemoteUnit string        `bson:"remote-unit,omitempty"`
+	Started    int64         `bson:"started"`
+	Duration   time.Duration `bson:"duration"`
+	ExitCode   int           `bson:"exit-code"`
+	Stderr     string        `bson:"stderr,omitempty"`
+}
+
+// AddHookRecord records that the unit ran a hook. Only the newest
+// records of each unit are kept, and long stderr output is truncated.
+func (u *Unit) AddHookRecord(record HookRecord) error {
+	if record.Hook == "" {
+		return errors.NotValidf("hook record without hook name")
+	}
+	stderr := record.Stderr
+	if len(stderr) > maxHookRecordStderr {
+		stderr = stderr[len(stderr)-maxHookRecordStderr:]
+	}
+	doc := &hookRecordDoc{
+		Unit:       u.Name(),
+		Hook:       record.Hook,
+		Relation:   record.Relation,
+		RemoteUnit: record.RemoteUnit,
+		Started:    record.Started.UTC().UnixNano(),
+		Duration:   record.Duration,
+		ExitCode:   record.ExitCode,
+		Stderr:     stderr,
+	}
+	history, closer := u.st.getCollection(hookHistoryC)
+	defer closer()
+	historyW := history.Writeable()
+	if err := historyW.Insert(doc); err != nil {
+		return errors.Annotatef(err, "cannot add hook record for unit %q", u)
+	}
+
+	var oldest hookRecordDoc
+	err := historyW.Find(bson.D{{"unit", u.Name()}}).Sort("-started").Skip(maxHookRecordsPerUnit - 1).One(&oldest)
+	if err == mgo.ErrNotFound {
+		return nil
+	} else if err != nil {
+		return errors.Annotatef(err, "cannot prune hook records for unit %q", u)
+	}
+	_, err = historyW.RemoveAll(bson.D{
+		{"unit", u.Name()},
+		{"started", bson.D{{"$lt", oldest.Started}}},
+	})
+	if err != nil {
+		return errors.Annotatef(err, "cannot prune hook records for unit %q", u)
+	}
+	return nil
+}
+
+// HookHistory returns up to size of the hooks most recently run by
+// the unit, newest first.
+func (u *Unit) HookHistory(size int) ([]HookRecord, error) {
+	if size <= 0 {
+		return nil, errors.NotValidf("non-positive history size %d", size)
+	}
+	history, closer := u.st.getCollection(hookHistoryC)
+	defer closer()
+
+	var docs []hookRecordDoc
+	err := history.Find(bson.D{{"unit", u.Name()}}).Sort("-started").Limit(size).All(&docs)
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot get hook history for unit %q", u)
+	}
+	records := make([]HookRecord, len(docs))
+	for i, doc := range docs {
+		records[i] = HookRecord{
+			Hook:       doc.Hook,
+			Relation:   doc.Relation,
+			RemoteUnit: doc.RemoteUnit,
+			Started:    time.Unix(0, doc.Started).UTC(),
+			Duration:   doc.Duration,
+			ExitCode:   doc.ExitCode,
+			Stderr:     doc.Stderr,
+		}
+	}
+	return records, nil
+}
+
+// eraseHookHistory removes the records of the hooks run by the unit.
+func (u *Unit) eraseHookHistory() error {
+	history, closer := u.st.getCollection(hookHistoryC)
+	defer closer()
+	historyW := history.Writeable()
+	if _, err := historyW.RemoveAll(bson.D{{"unit", u.Name()}}); err != nil {
+		return errors.Trace(err)
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"strings"
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+)
+
+type HookHistorySuite struct {
+	ConnSuite
+	unit *state.Unit
+}
+
+var _ = gc.Suite(&HookHistorySuite{})
+
+func (s *HookHistorySuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	service := s.AddTestingService(c, "dummy", s.AddTestingCharm(c, "dummy"))
+	var err error
+	s.unit, err = service.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *HookHistorySuite) TestHookHistory(c *gc.C) {
+	started := time.Date(2016, 4, 1, 12, 0, 0, 0, time.UTC)
+	install := state.HookRecord{
+		Hook:     "install",
+		Started:  started,
+		Duration: 90 * time.Second,
+	}
+	joined := state.HookRecord{
+		Hook:       "db-relation-joined",
+		Relation:   "db:2",
+		RemoteUnit: "mysql/0",
+		Started:    started.Add(2 * time.Minute),
+		Duration:   time.Second,
+		ExitCode:   1,
+		Stderr:     "cannot connect",
+	}
+	err := s.unit.AddHookRecord(install)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.AddHookRecord(joined)
+	c.Assert(err, jc.ErrorIsNil)
+
+	history, err := s.unit.HookHistory(10)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, jc.DeepEquals, []state.HookRecord{joined, install})
+
+	history, err = s.unit.HookHistory(1)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, jc.DeepEquals, []state.HookRecord{joined})
+
+	_, err = s.unit.HookHistory(0)
+	c.Assert(err, gc.ErrorMatches, "non-positive history size 0 not valid")
+}
+
+func (s *HookHistorySuite) TestAddHookRecordInvalid(c *gc.C) {
+	err := s.unit.AddHookRecord(state.HookRecord{})
+	c.Assert(err, gc.ErrorMatches, "hook record without hook name not valid")
+}
+
+func (s *HookHistorySuite) TestAddHookRecordTruncatesStderr(c *gc.C) {
+	stderr := strings.Repeat("x", 5000) + "the end"
+	err := s.unit.AddHookRecord(state.HookRecord{
+		Hook:    "install",
+		Started: time.Now(),
+		Stderr:  stderr,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	history, err := s.unit.HookHistory(1)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 1)
+	c.Assert(history[0].Stderr, gc.HasLen, 4096)
+	c.Assert(strings.HasSuffix(history[0].Stderr, "the end"), jc.IsTrue)
+}
+
+func (s *HookHistorySuite) TestAddHookRecordKeepsNewest(c *gc.C) {
+	started := time.Date(2016, 4, 1, 12, 0, 0, 0, time.UTC)
+	for i := 0; i < 105; i++ {
+		err := s.unit.AddHookRecord(state.HookRecord{
+			Hook:    "update-status",
+			Started: started.Add(time.Duration(i) * time.Minute),
+		})
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	history, err := s.unit.HookHistory(200)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 100)
+	c.Assert(history[0].Started, gc.Equals, started.Add(104*time.Minute))
+	c.Assert(history[99].Started, gc.Equals, started.Add(5*time.Minute))
+}
+
+func (s *HookHistorySuite) TestHookHistoryErasedWithUnit(c *gc.C) {
+	err := s.unit.AddHookRecord(state.HookRecord{Hook: "install", Started: time.Now()})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+
+	history, err := s.unit.HookHistory(10)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 0)
+}
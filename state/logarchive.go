@@ -0,0 +1,383 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"launchpad.net/tomb"
+
+	"github.com/juju/juju/state/storage"
+)
+
+// logArchivesC holds a document for each archive of pruned logs. It
+// lives alongside the logs collection, and the archives themselves
+// are kept in the model's blob storage.
+const logArchivesC = "logarchives"
+
+// archiveBatchSize is the maximum number of logs written to a single
+// archive.
+const archiveBatchSize = 10000
+
+// LogArchiver is used by PruneLogs to save logs before they are
+// removed.
+type LogArchiver interface {
+	// ArchiveLogs saves the given logs, all of which belong to the
+	// model with the given UUID. The logs are in time order.
+	ArchiveLogs(modelUUID string, logs []*LogRecord) error
+}
+
+// logArchiveDoc describes a single archive of logs.
+type logArchiveDoc struct {
+	Path      string    `bson:"_id"`
+	ModelUUID string    `bson:"model-uuid"`
+	Start     time.Time `bson:"start"`
+	End       time.Time `bson:"end"`
+	Count     int       `bson:"count"`
+	Size      int64     `bson:"size"`
+}
+
+// archivedLog is the form in which each log is written to an archive,
+// as a line of JSON.
+type archivedLog struct {
+	Time     time.Time         `json:"t"`
+	Entity   string            `json:"n"`
+	Module   string            `json:"m"`
+	Location string            `json:"l"`
+	Level    loggo.Level       `json:"v"`
+	Message  string            `json:"x"`
+	Fields   map[string]string `json:"f,omitempty"`
+}
+
+// NewLogArchiver returns a LogArchiver which writes logs to
+// compressed JSON-lines archives in controller storage, from where
+// they can be read with NewArchivedLogTailer.
+func NewLogArchiver(st LoggingState) LogArchiver {
+	return &logArchiver{st: st}
+}
+
+type logArchiver struct {
+	st LoggingState
+}
+
+// ArchiveLogs is part of the LogArchiver interface.
+func (a *logArchiver) ArchiveLogs(modelUUID string, logs []*LogRecord) error {
+	if len(logs) == 0 {
+		return nil
+	}
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	enc := json.NewEncoder(zw)
+	for _, rec := range logs {
+		if err := enc.Encode(archivedLog{
+			Time:     rec.Time,
+			Entity:   rec.Entity,
+			Module:   rec.Module,
+			Location: rec.Location,
+			Level:    rec.Level,
+			Message:  rec.Message,
+			Fields:   rec.Fields,
+		}); err != nil {
+			return errors.Annotate(err, "cannot encode log")
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return errors.Annotate(err, "cannot compress logs")
+	}
+
+	session := a.st.MongoSession().Copy()
+	defer session.Close()
+	doc := logArchiveDoc{
+		Path:      fmt.Sprintf("logarchive/%s/%s.jsonl.gz", modelUUID, bson.NewObjectId().Hex()),
+		ModelUUID: modelUUID,
+		Start:     logs[0].Time,
+		End:       logs[len(logs)-1].Time,
+		Count:     len(logs),
+		Size:      int64(buf.Len()),
+	}
+	stor := storage.NewStorage(modelUUID, session)
+	if err := stor.Put(doc.Path, &buf, doc.Size); err != nil {
+		return errors.Annotate(err, "cannot store log archive")
+	}
+	if err := session.DB(logsDB).C(logArchivesC).Insert(&doc); err != nil {
+		if err := stor.Remove(doc.Path); err != nil {
+			logger.Errorf("cannot remove orphaned log archive %q: %v", doc.Path, err)
+		}
+		return errors.Annotate(err, "cannot record log archive")
+	}
+	logger.Debugf("archived %d logs for model %s in %q", doc.Count, modelUUID, doc.Path)
+	return nil
+}
+
+// archiveAndRemoveLogs archives the logs for the given model that
+// match sel, before removing them. It returns the number of logs
+// removed.
+func archiveAndRemoveLogs(logsColl *mgo.Collection, archiver LogArchiver, modelUUID string, sel bson.M) (int, error) {
+	removed := 0
+	for {
+		var docs []logDoc
+		err := logsColl.Find(sel).Sort("t").Limit(archiveBatchSize).All(&docs)
+		if err != nil {
+			return removed, errors.Annotate(err, "cannot read logs to archive")
+		}
+		if len(docs) == 0 {
+			return removed, nil
+		}
+		logs := make([]*LogRecord, len(docs))
+		ids := make([]bson.ObjectId, len(docs))
+		for i := range docs {
+			logs[i] = logDocToRecord(&docs[i])
+			ids[i] = docs[i].Id
+		}
+		if err := archiver.ArchiveLogs(modelUUID, logs); err != nil {
+			return removed, errors.Trace(err)
+		}
+		// Remove exactly the logs archived, in case any matching
+		// logs have arrived since they were read.
+		info, err := logsColl.RemoveAll(bson.M{"_id": bson.M{"$in": ids}})
+		if err != nil {
+			return removed, errors.Annotate(err, "cannot remove archived logs")
+		}
+		removed += info.Removed
+		if len(docs) < archiveBatchSize {
+			return removed, nil
+		}
+	}
+}
+
+// NewArchivedLogTailer returns a LogTailer which returns the archived
+// logs for the model that match the parameters given, applying the
+// same filtering as a LogTailer returned by NewLogTailer. The tailer
+// stops once all the archives have been read; NoTail is implied.
+func NewArchivedLogTailer(st LoggingState, params *LogTailerParams) LogTailer {
+	session := st.MongoSession().Copy()
+	t := &archivedLogTailer{
+		modelUUID: st.ModelUUID(),
+		session:   session,
+		params:    params,
+		logCh:     make(chan *LogRecord),
+	}
+	go func() {
+		err := t.loop()
+		t.tomb.Kill(errors.Cause(err))
+		close(t.logCh)
+		session.Close()
+		t.tomb.Done()
+	}()
+	return t
+}
+
+type archivedLogTailer struct {
+	tomb      tomb.Tomb
+	modelUUID string
+	session   *mgo.Session
+	params    *LogTailerParams
+	logCh     chan *LogRecord
+}
+
+// Logs implements the LogTailer interface.
+func (t *archivedLogTailer) Logs() <-chan *LogRecord {
+	return t.logCh
+}
+
+// Dying implements the LogTailer interface.
+func (t *archivedLogTailer) Dying() <-chan struct{} {
+	return t.tomb.Dying()
+}
+
+// Stop implements the LogTailer interface.
+func (t *archivedLogTailer) Stop() error {
+	t.tomb.Kill(nil)
+	return t.tomb.Wait()
+}
+
+// Err implements the LogTailer interface.
+func (t *archivedLogTailer) Err() error {
+	return t.tomb.Err()
+}
+
+func (t *archivedLogTailer) loop() error {
+	filter, err := newLogFilter(t.params)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	sel := bson.M{"model-uuid": t.modelUUID}
+	if !t.params.StartTime.IsZero() {
+		sel["end"] = bson.M{"$gte": t.params.StartTime}
+	}
+	if !t.params.EndTime.IsZero() {
+		sel["start"] = bson.M{"$lt": t.params.EndTime}
+	}
+	var docs []logArchiveDoc
+	err = t.session.DB(logsDB).C(logArchivesC).Find(sel).Sort("start").All(&docs)
+	if err != nil {
+		return errors.Annotate(err, "cannot list log archives")
+	}
+
+	// When only the last few lines are wanted, they can't be sent
+	// until all the archives have been read.
+	var backlog []*LogRecord
+	send := func(rec *LogRecord) error {
+		if t.params.InitialLines > 0 {
+			backlog = append(backlog, rec)
+			if len(backlog) > t.params.InitialLines {
+				backlog = backlog[1:]
+			}
+			return nil
+		}
+		select {
+		case <-t.tomb.Dying():
+			return tomb.ErrDying
+		case t.logCh <- rec:
+			return nil
+		}
+	}
+
+	stor := storage.NewStorage(t.modelUUID, t.session)
+	for _, doc := range docs {
+		if err := t.readArchive(stor, doc.Path, filter, send); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	for _, rec := range backlog {
+		select {
+		case <-t.tomb.Dying():
+			return tomb.ErrDying
+		case t.logCh <- rec:
+		}
+	}
+	return nil
+}
+
+func (t *archivedLogTailer) readArchive(stor storage.Storage, path string, filter *logFilter, send func(*LogRecord) error) error {
+	r, _, err := stor.Get(path)
+	if err != nil {
+		return errors.Annotatef(err, "cannot open log archive %q", path)
+	}
+	defer r.Close()
+	zr, err := gzip.NewReader(r)
+	if err != nil {
+		return errors.Annotatef(err, "cannot read log archive %q", path)
+	}
+	defer zr.Close()
+	dec := json.NewDecoder(zr)
+	for {
+		var log archivedLog
+		if err := dec.Decode(&log); err == io.EOF {
+			break
+		} else if err != nil {
+			return errors.Annotatef(err, "cannot decode log in archive %q", path)
+		}
+		rec := &LogRecord{
+			Time:     log.Time,
+			Entity:   log.Entity,
+			Module:   log.Module,
+			Location: log.Location,
+			Level:    log.Level,
+			Message:  log.Message,
+			Fields:   log.Fields,
+		}
+		if !filter.matches(rec) {
+			continue
+		}
+		if err := send(rec); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// logFilter applies the filtering described by LogTailerParams to
+// individual log records, as the logs collection selector built by
+// paramsToSelector does to documents.
+type logFilter struct {
+	params         *LogTailerParams
+	includeEntity  *regexp.Regexp
+	excludeEntity  *regexp.Regexp
+	includeModule  *regexp.Regexp
+	excludeModule  *regexp.Regexp
+	includeMessage *regexp.Regexp
+	excludeMessage *regexp.Regexp
+}
+
+func newLogFilter(params *LogTailerParams) (*logFilter, error) {
+	f := &logFilter{params: params}
+	for _, p := range []struct {
+		re      **regexp.Regexp
+		pattern string
+		valid   bool
+	}{
+		{&f.includeEntity, makeEntityPattern(params.IncludeEntity), len(params.IncludeEntity) > 0},
+		{&f.excludeEntity, makeEntityPattern(params.ExcludeEntity), len(params.ExcludeEntity) > 0},
+		{&f.includeModule, makeModulePattern(params.IncludeModule), len(params.IncludeModule) > 0},
+		{&f.excludeModule, makeModulePattern(params.ExcludeModule), len(params.ExcludeModule) > 0},
+		{&f.includeMessage, params.IncludeMessage, params.IncludeMessage != ""},
+		{&f.excludeMessage, params.ExcludeMessage, params.ExcludeMessage != ""},
+	} {
+		if !p.valid {
+			continue
+		}
+		re, err := regexp.Compile(p.pattern)
+		if err != nil {
+			return nil, errors.Annotatef(err, "invalid filter %q", p.pattern)
+		}
+		*p.re = re
+	}
+	return f, nil
+}
+
+func (f *logFilter) matches(rec *LogRecord) bool {
+	p := f.params
+	if rec.Time.Before(p.StartTime) {
+		return false
+	}
+	if !p.EndTime.IsZero() && !rec.Time.Before(p.EndTime) {
+		return false
+	}
+	if p.MinLevel > loggo.UNSPECIFIED && rec.Level < p.MinLevel {
+		return false
+	}
+	for _, m := range []struct {
+		re      *regexp.Regexp
+		value   string
+		include bool
+	}{
+		{f.includeEntity, rec.Entity, true},
+		{f.excludeEntity, rec.Entity, false},
+		{f.includeModule, rec.Module, true},
+		{f.excludeModule, rec.Module, false},
+		{f.includeMessage, rec.Message, true},
+		{f.excludeMessage, rec.Message, false},
+	} {
+		if m.re != nil && m.re.MatchString(m.value) != m.include {
+			return false
+		}
+	}
+	for name, values := range p.IncludeFields {
+		value, ok := rec.Fields[name]
+		if !ok || !containsString(values, value) {
+			return false
+		}
+	}
+	return true
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,148 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	"github.com/juju/loggo"
+	"github.com/juju/names"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/mgo.v2/bson"
+
+	"github.com/juju/juju/state"
+)
+
+type LogArchiveSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&LogArchiveSuite{})
+
+func (s *LogArchiveSuite) log(c *gc.C, entity names.Tag, t time.Time, level loggo.Level, msg string, fields map[string]string) {
+	dbLogger := state.NewDbLogger(s.State, entity)
+	defer dbLogger.Close()
+	err := dbLogger.Log(t, "juju.test", "test.go:1", level, msg, fields)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *LogArchiveSuite) readArchive(c *gc.C, params *state.LogTailerParams) []*state.LogRecord {
+	tailer := state.NewArchivedLogTailer(s.State, params)
+	defer tailer.Stop()
+	var logs []*state.LogRecord
+	for log := range tailer.Logs() {
+		logs = append(logs, log)
+	}
+	c.Assert(tailer.Err(), jc.ErrorIsNil)
+	return logs
+}
+
+func logMessages(logs []*state.LogRecord) []string {
+	var msgs []string
+	for _, log := range logs {
+		msgs = append(msgs, log.Message)
+	}
+	return msgs
+}
+
+func (s *LogArchiveSuite) TestPruneLogsArchivesRemovedLogs(c *gc.C) {
+	machine := names.NewMachineTag("0")
+	now := time.Now().Truncate(time.Millisecond)
+	minLogTime := now.Add(-time.Minute)
+	s.log(c, machine, minLogTime.Add(-2*time.Second), loggo.INFO, "old 1", map[string]string{"hook": "install"})
+	s.log(c, machine, minLogTime.Add(-time.Second), loggo.INFO, "old 2", nil)
+	s.log(c, machine, now, loggo.INFO, "new", nil)
+
+	err := state.PruneLogs(s.State, minLogTime, int(1e9), nil, state.NewLogArchiver(s.State))
+	c.Assert(err, jc.ErrorIsNil)
+
+	count, err := s.State.MongoSession().DB("logs").C("logs").Find(bson.M{"e": s.State.ModelUUID()}).Count()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(count, gc.Equals, 1)
+
+	logs := s.readArchive(c, &state.LogTailerParams{})
+	for _, log := range logs {
+		log.Time = log.Time.UTC()
+	}
+	c.Assert(logs, jc.DeepEquals, []*state.LogRecord{{
+		Time:     minLogTime.Add(-2 * time.Second).UTC(),
+		Entity:   "machine-0",
+		Module:   "juju.test",
+		Location: "test.go:1",
+		Level:    loggo.INFO,
+		Message:  "old 1",
+		Fields:   map[string]string{"hook": "install"},
+	}, {
+		Time:     minLogTime.Add(-time.Second).UTC(),
+		Entity:   "machine-0",
+		Module:   "juju.test",
+		Location: "test.go:1",
+		Level:    loggo.INFO,
+		Message:  "old 2",
+	}})
+
+	var docs []bson.M
+	err = s.State.MongoSession().DB("logs").C("logarchives").Find(nil).All(&docs)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(docs, gc.HasLen, 1)
+	c.Assert(docs[0]["model-uuid"], gc.Equals, s.State.ModelUUID())
+	c.Assert(docs[0]["count"], gc.Equals, 2)
+}
+
+func (s *LogArchiveSuite) TestPruneLogsWithoutArchiver(c *gc.C) {
+	s.log(c, names.NewMachineTag("0"), time.Now().Add(-time.Hour), loggo.INFO, "old", nil)
+
+	err := state.PruneLogs(s.State, time.Now(), int(1e9), nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.readArchive(c, &state.LogTailerParams{}), gc.HasLen, 0)
+}
+
+func (s *LogArchiveSuite) TestArchivedLogTailerFiltering(c *gc.C) {
+	t0 := time.Now().Add(-time.Hour).Truncate(time.Millisecond)
+	machine := names.NewMachineTag("0")
+	unit := names.NewUnitTag("mysql/0")
+	s.log(c, machine, t0, loggo.DEBUG, "machine debug", nil)
+	s.log(c, machine, t0.Add(time.Second), loggo.ERROR, "machine error", nil)
+	s.log(c, unit, t0.Add(2*time.Second), loggo.INFO, "unit install", map[string]string{"hook": "install"})
+	s.log(c, unit, t0.Add(3*time.Second), loggo.INFO, "unit start", map[string]string{"hook": "start"})
+	err := state.PruneLogs(s.State, time.Now(), int(1e9), nil, state.NewLogArchiver(s.State))
+	c.Assert(err, jc.ErrorIsNil)
+
+	for i, test := range []struct {
+		params   state.LogTailerParams
+		expected []string
+	}{{
+		params:   state.LogTailerParams{},
+		expected: []string{"machine debug", "machine error", "unit install", "unit start"},
+	}, {
+		params:   state.LogTailerParams{MinLevel: loggo.INFO},
+		expected: []string{"machine error", "unit install", "unit start"},
+	}, {
+		params:   state.LogTailerParams{IncludeEntity: []string{"unit-mysql-*"}},
+		expected: []string{"unit install", "unit start"},
+	}, {
+		params:   state.LogTailerParams{ExcludeEntity: []string{"unit-mysql-*"}},
+		expected: []string{"machine debug", "machine error"},
+	}, {
+		params:   state.LogTailerParams{IncludeFields: map[string][]string{"hook": {"start"}}},
+		expected: []string{"unit start"},
+	}, {
+		params:   state.LogTailerParams{IncludeMessage: "^unit", ExcludeMessage: "start"},
+		expected: []string{"unit install"},
+	}, {
+		params: state.LogTailerParams{
+			StartTime: t0.Add(time.Second),
+			EndTime:   t0.Add(3 * time.Second),
+		},
+		expected: []string{"machine error", "unit install"},
+	}, {
+		params:   state.LogTailerParams{InitialLines: 2},
+		expected: []string{"unit install", "unit start"},
+	}} {
+		c.Logf("test %d: %+v", i, test.params)
+		params := test.params
+		c.Check(logMessages(s.readArchive(c, &params)), jc.DeepEquals, test.expected)
+	}
+}
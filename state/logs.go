@@ -8,6 +8,7 @@ package state
 
 import (
 	"regexp"
+	"sort"
 	"strings"
 	"time"
 
@@ -34,8 +35,9 @@ type LoggingState interface {
 	MongoSession() *mgo.Session
 }
 
-// InitDbLogs sets up the indexes for the logs collection. It should
-// be called as state is opened. It is idempotent.
+// InitDbLogs sets up the indexes for the logs and log archives
+// collections. It should be called as state is opened. It is
+// idempotent.
 func InitDbLogs(session *mgo.Session) error {
 	logsColl := session.DB(logsDB).C(logsC)
 	for _, key := range [][]string{{"e", "t"}, {"e", "n"}} {
@@ -44,6 +46,10 @@ func InitDbLogs(session *mgo.Session) error {
 			return errors.Annotate(err, "cannot create index for logs collection")
 		}
 	}
+	archivesColl := session.DB(logsDB).C(logArchivesC)
+	if err := archivesColl.EnsureIndex(mgo.Index{Key: []string{"model-uuid", "start"}}); err != nil {
+		return errors.Annotate(err, "cannot create index for log archives collection")
+	}
 	return nil
 }
 
@@ -61,6 +67,21 @@ type logDoc struct {
 	Location  string        `bson:"l"` // "filename:lineno"
 	Level     loggo.Level   `bson:"v"`
 	Message   string        `bson:"x"`
+
+	// Fields holds structured data about the message, e.g.
+	// {"hook": "config-changed", "relation-id": "db:2"}.
+	Fields map[string]string `bson:"f,omitempty"`
+}
+
+// validLogFieldName matches the names that may be used for log
+// record fields. Names are used as document keys, so may not
+// contain dots or start with a dollar sign.
+var validLogFieldName = regexp.MustCompile(`^[a-z][a-z0-9-_]*$`)
+
+// IsValidLogFieldName reports whether name may be used as the name
+// of a log record field.
+func IsValidLogFieldName(name string) bool {
+	return validLogFieldName.MatchString(name)
 }
 
 type DbLogger struct {
@@ -80,8 +101,9 @@ func NewDbLogger(st LoggingState, entity names.Tag) *DbLogger {
 	}
 }
 
-// Log writes a log message to the database.
-func (logger *DbLogger) Log(t time.Time, module string, location string, level loggo.Level, msg string) error {
+// Log writes a log message to the database, along with any fields
+// describing it. Fields with invalid names are discarded.
+func (logger *DbLogger) Log(t time.Time, module string, location string, level loggo.Level, msg string, fields map[string]string) error {
 	return logger.logsColl.Insert(&logDoc{
 		Id:        bson.NewObjectId(),
 		Time:      t,
@@ -91,9 +113,26 @@ func (logger *DbLogger) Log(t time.Time, module string, location string, level l
 		Location:  location,
 		Level:     level,
 		Message:   msg,
+		Fields:    validLogFields(fields),
 	})
 }
 
+// validLogFields returns the fields with valid names.
+func validLogFields(fields map[string]string) map[string]string {
+	var valid map[string]string
+	for name, value := range fields {
+		if !IsValidLogFieldName(name) {
+			logger.Debugf("discarding log field with invalid name %q", name)
+			continue
+		}
+		if valid == nil {
+			valid = make(map[string]string)
+		}
+		valid[name] = value
+	}
+	return valid
+}
+
 // Close cleans up resources used by the DbLogger instance.
 func (logger *DbLogger) Close() {
 	if logger.logsColl != nil {
@@ -132,12 +171,19 @@ type LogRecord struct {
 	Location string
 	Level    loggo.Level
 	Message  string
+	Fields   map[string]string
 }
 
 // LogTailerParams specifies the filtering a LogTailer should apply to
 // logs in order to decide which to return.
 type LogTailerParams struct {
-	StartTime     time.Time
+	StartTime time.Time
+
+	// EndTime, if not zero, restricts the logs returned to those
+	// logged before it. Once the end time has passed, the tailer
+	// stops rather than waiting for new logs.
+	EndTime time.Time
+
 	MinLevel      loggo.Level
 	InitialLines  int
 	NoTail        bool
@@ -145,7 +191,19 @@ type LogTailerParams struct {
 	ExcludeEntity []string
 	IncludeModule []string
 	ExcludeModule []string
-	Oplog         *mgo.Collection // For testing only
+
+	// IncludeFields, if not empty, restricts the logs returned to
+	// those with a matching value for every named field. Each field
+	// may be given several values, any of which may match.
+	IncludeFields map[string][]string
+
+	// IncludeMessage and ExcludeMessage, if set, are regular
+	// expressions which the message of each log returned must and
+	// must not match respectively.
+	IncludeMessage string
+	ExcludeMessage string
+
+	Oplog *mgo.Collection // For testing only
 }
 
 // oplogOverlap is used to decide on the initial oplog timestamp to
@@ -228,6 +286,10 @@ func (t *logTailer) loop() error {
 	if t.params.NoTail {
 		return nil
 	}
+	if !t.params.EndTime.IsZero() && !time.Now().Before(t.params.EndTime) {
+		// No new logs can fall within the requested window.
+		return nil
+	}
 
 	err = t.tailOplog()
 	return errors.Trace(err)
@@ -281,6 +343,11 @@ func (t *logTailer) tailOplog() error {
 	oplogTailer := mongo.NewOplogTailer(oplog, oplogSel, minOplogTs)
 	defer oplogTailer.Stop()
 
+	var endTimeReached <-chan time.Time
+	if !t.params.EndTime.IsZero() {
+		endTimeReached = time.After(t.params.EndTime.Sub(time.Now()))
+	}
+
 	logger.Tracef("LogTailer starting oplog tailing: recent id count=%d, lastTime=%s, minOplogTs=%s",
 		recentIds.Length(), t.lastTime, minOplogTs)
 
@@ -289,6 +356,8 @@ func (t *logTailer) tailOplog() error {
 		select {
 		case <-t.tomb.Dying():
 			return errors.Trace(tomb.ErrDying)
+		case <-endTimeReached:
+			return nil
 		case oplogDoc, ok := <-oplogTailer.Out():
 			if !ok {
 				return errors.Annotate(oplogTailer.Err(), "oplog tailer died")
@@ -323,6 +392,9 @@ func (t *logTailer) paramsToSelector(params *LogTailerParams, prefix string) bso
 		{"e", t.modelUUID},
 		{"t", bson.M{"$gte": params.StartTime}},
 	}
+	if !params.EndTime.IsZero() {
+		sel = append(sel, bson.DocElem{"t", bson.M{"$lt": params.EndTime}})
+	}
 	if params.MinLevel > loggo.UNSPECIFIED {
 		sel = append(sel, bson.DocElem{"v", bson.M{"$gte": params.MinLevel}})
 	}
@@ -342,6 +414,23 @@ func (t *logTailer) paramsToSelector(params *LogTailerParams, prefix string) bso
 		sel = append(sel,
 			bson.DocElem{"m", bson.M{"$not": bson.RegEx{Pattern: makeModulePattern(params.ExcludeModule)}}})
 	}
+	if params.IncludeMessage != "" {
+		sel = append(sel, bson.DocElem{"x", bson.RegEx{Pattern: params.IncludeMessage}})
+	}
+	if params.ExcludeMessage != "" {
+		sel = append(sel, bson.DocElem{"x", bson.M{"$not": bson.RegEx{Pattern: params.ExcludeMessage}}})
+	}
+	if len(params.IncludeFields) > 0 {
+		// Sort the names so the selector is deterministic.
+		var fieldNames []string
+		for name := range params.IncludeFields {
+			fieldNames = append(fieldNames, name)
+		}
+		sort.Strings(fieldNames)
+		for _, name := range fieldNames {
+			sel = append(sel, bson.DocElem{"f." + name, bson.M{"$in": params.IncludeFields[name]}})
+		}
+	}
 
 	if prefix != "" {
 		for i, elem := range sel {
@@ -425,14 +514,33 @@ func logDocToRecord(doc *logDoc) *LogRecord {
 		Location: doc.Location,
 		Level:    doc.Level,
 		Message:  doc.Message,
+		Fields:   doc.Fields,
 	}
 }
 
+// LogRetention describes the limits on the logs kept for a single
+// model, overriding those applied to the logs collection as a whole.
+type LogRetention struct {
+	// MinLogTime, if not zero, is the time before which the model's
+	// logs are removed.
+	MinLogTime time.Time
+
+	// MaxMB, if not zero, is the size in megabytes beyond which the
+	// model's oldest logs are removed. The logs of a model with a
+	// size limit are not removed to bring the logs collection under
+	// its maximum size, so other models cannot eat into them.
+	MaxMB int
+}
+
 // PruneLogs removes old log documents in order to control the size of
 // logs collection. All logs older than minLogTime are
-// removed. Further removal is also performed if the logs collection
-// size is greater than maxLogsMB.
-func PruneLogs(st LoggingState, minLogTime time.Time, maxLogsMB int) error {
+// removed, unless a model's retention gives it a different minimum
+// time. Logs are then removed from each model with a size limit in
+// its retention until it is within that limit. Further removal is
+// also performed, on models without a size limit, if the logs
+// collection size is greater than maxLogsMB. If archiver is not nil,
+// logs are passed to it before they are removed.
+func PruneLogs(st LoggingState, minLogTime time.Time, maxLogsMB int, retention map[string]LogRetention, archiver LogArchiver) error {
 	session, logsColl := initLogsSession(st)
 	defer session.Close()
 
@@ -446,14 +554,30 @@ func PruneLogs(st LoggingState, minLogTime time.Time, maxLogsMB int) error {
 	// Remove old log entries (per model UUID to take advantage
 	// of indexes on the logs collection).
 	for _, modelUUID := range modelUUIDs {
-		removeInfo, err := logsColl.RemoveAll(bson.M{
-			"e": modelUUID,
-			"t": bson.M{"$lt": minLogTime},
-		})
+		modelMinLogTime := minLogTime
+		if t := retention[modelUUID].MinLogTime; !t.IsZero() {
+			modelMinLogTime = t
+		}
+		removed, err := removeLogs(logsColl, archiver, modelUUID, modelMinLogTime)
 		if err != nil {
 			return errors.Annotate(err, "failed to prune logs by time")
 		}
-		pruneCounts[modelUUID] = removeInfo.Removed
+		pruneCounts[modelUUID] = removed
+	}
+
+	// Bring each model with its own size limit within that limit.
+	var unlimitedUUIDs []string
+	for _, modelUUID := range modelUUIDs {
+		maxMB := retention[modelUUID].MaxMB
+		if maxMB <= 0 {
+			unlimitedUUIDs = append(unlimitedUUIDs, modelUUID)
+			continue
+		}
+		removed, err := pruneModelLogsBySize(logsColl, archiver, modelUUID, maxMB)
+		if err != nil {
+			return errors.Annotate(err, "log pruning failed")
+		}
+		pruneCounts[modelUUID] += removed
 	}
 
 	// Do further pruning if the logs collection is over the maximum size.
@@ -466,7 +590,7 @@ func PruneLogs(st LoggingState, minLogTime time.Time, maxLogsMB int) error {
 			break
 		}
 
-		modelUUID, count, err := findEnvWithMostLogs(logsColl, modelUUIDs)
+		modelUUID, count, err := findEnvWithMostLogs(logsColl, unlimitedUUIDs)
 		if err != nil {
 			return errors.Annotate(err, "log count query failed")
 		}
@@ -476,30 +600,11 @@ func PruneLogs(st LoggingState, minLogTime time.Time, maxLogsMB int) error {
 
 		// Remove the oldest 1% of log records for the model.
 		toRemove := int(float64(count) * 0.01)
-
-		// Find the threshold timestammp to start removing from.
-		// NOTE: this assumes that there are no more logs being added
-		// for the time range being pruned (which should be true for
-		// any realistic minimum log collection size).
-		tsQuery := logsColl.Find(bson.M{"e": modelUUID}).Sort("t")
-		tsQuery = tsQuery.Skip(toRemove)
-		tsQuery = tsQuery.Select(bson.M{"t": 1})
-		var doc bson.M
-		err = tsQuery.One(&doc)
+		removed, err := removeOldestLogs(logsColl, archiver, modelUUID, toRemove)
 		if err != nil {
-			return errors.Annotate(err, "log pruning timestamp query failed")
-		}
-		thresholdTs := doc["t"].(time.Time)
-
-		// Remove old records.
-		removeInfo, err := logsColl.RemoveAll(bson.M{
-			"e": modelUUID,
-			"t": bson.M{"$lt": thresholdTs},
-		})
-		if err != nil {
-			return errors.Annotate(err, "log pruning failed")
+			return errors.Trace(err)
 		}
-		pruneCounts[modelUUID] += removeInfo.Removed
+		pruneCounts[modelUUID] += removed
 	}
 
 	for modelUUID, count := range pruneCounts {
@@ -510,6 +615,91 @@ func PruneLogs(st LoggingState, minLogTime time.Time, maxLogsMB int) error {
 	return nil
 }
 
+// pruneModelLogsBySize removes the oldest logs for the model until
+// they take up no more than maxMB megabytes. It returns the number of
+// logs removed.
+func pruneModelLogsBySize(logsColl *mgo.Collection, archiver LogArchiver, modelUUID string, maxMB int) (int, error) {
+	pruned := 0
+	for {
+		modelMB, count, err := getModelLogsMB(logsColl, modelUUID)
+		if err != nil {
+			return pruned, errors.Annotate(err, "failed to retrieve log counts")
+		}
+		if modelMB <= float64(maxMB) || count == 0 {
+			return pruned, nil
+		}
+		// Remove enough of the oldest logs to bring the model
+		// within its limit, assuming they're of average size.
+		toRemove := count - int(float64(count)*float64(maxMB)/modelMB)
+		if toRemove < 1 {
+			toRemove = 1
+		}
+		removed, err := removeOldestLogs(logsColl, archiver, modelUUID, toRemove)
+		if err != nil {
+			return pruned, errors.Trace(err)
+		}
+		if removed == 0 {
+			// All the remaining logs share a timestamp, so
+			// none can be removed by age.
+			return pruned, nil
+		}
+		pruned += removed
+	}
+}
+
+// removeOldestLogs removes approximately the oldest count logs for
+// the model, returning the number actually removed.
+func removeOldestLogs(logsColl *mgo.Collection, archiver LogArchiver, modelUUID string, count int) (int, error) {
+	// Find the threshold timestammp to start removing from.
+	// NOTE: this assumes that there are no more logs being added
+	// for the time range being pruned (which should be true for
+	// any realistic minimum log collection size).
+	tsQuery := logsColl.Find(bson.M{"e": modelUUID}).Sort("t")
+	tsQuery = tsQuery.Skip(count)
+	tsQuery = tsQuery.Select(bson.M{"t": 1})
+	var doc bson.M
+	err := tsQuery.One(&doc)
+	if err == mgo.ErrNotFound {
+		// Fewer than count logs remain, so remove them all: the
+		// threshold is just after the newest.
+		err = logsColl.Find(bson.M{"e": modelUUID}).Sort("-t").Select(bson.M{"t": 1}).One(&doc)
+		if err == nil {
+			doc["t"] = doc["t"].(time.Time).Add(time.Millisecond)
+		}
+	}
+	if err == mgo.ErrNotFound {
+		return 0, nil
+	} else if err != nil {
+		return 0, errors.Annotate(err, "log pruning timestamp query failed")
+	}
+	thresholdTs := doc["t"].(time.Time)
+
+	// Remove old records.
+	removed, err := removeLogs(logsColl, archiver, modelUUID, thresholdTs)
+	if err != nil {
+		return 0, errors.Annotate(err, "log pruning failed")
+	}
+	return removed, nil
+}
+
+// removeLogs removes the logs for the model that are older than
+// before, archiving them first if archiver is not nil. It returns the
+// number of logs removed.
+func removeLogs(logsColl *mgo.Collection, archiver LogArchiver, modelUUID string, before time.Time) (int, error) {
+	sel := bson.M{
+		"e": modelUUID,
+		"t": bson.M{"$lt": before},
+	}
+	if archiver != nil {
+		return archiveAndRemoveLogs(logsColl, archiver, modelUUID, sel)
+	}
+	removeInfo, err := logsColl.RemoveAll(sel)
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	return removeInfo.Removed, nil
+}
+
 // initLogsSession creates a new session suitable for logging updates,
 // returning the session and a logs mgo.Collection connected to that
 // session.
@@ -539,6 +729,45 @@ func getCollectionMB(coll *mgo.Collection) (int, error) {
 	return result["size"].(int), nil
 }
 
+// getModelLogsMB returns the approximate size in megabytes of the
+// logs stored for a model, along with their number.
+func getModelLogsMB(coll *mgo.Collection, modelUUID string) (float64, int, error) {
+	count, err := getLogCountForEnv(coll, modelUUID)
+	if err != nil {
+		return 0, -1, errors.Trace(err)
+	}
+	if count == 0 {
+		return 0, 0, nil
+	}
+	var result bson.M
+	err = coll.Database.Run(bson.D{{"collStats", coll.Name}}, &result)
+	if err != nil {
+		return 0, -1, errors.Trace(err)
+	}
+	// Depending on the version of MongoDB, the average object
+	// size may be reported as an integer or a double.
+	var avgObjSize float64
+	switch size := result["avgObjSize"].(type) {
+	case int:
+		avgObjSize = float64(size)
+	case float64:
+		avgObjSize = size
+	}
+	return float64(count) * avgObjSize / humanize.MiByte, count, nil
+}
+
+// ModelLogsMB returns the approximate size in megabytes of the logs
+// stored for the model.
+func ModelLogsMB(st LoggingState) (float64, error) {
+	session, logsColl := initLogsSession(st)
+	defer session.Close()
+	size, _, err := getModelLogsMB(logsColl, st.ModelUUID())
+	if err != nil {
+		return 0, errors.Annotate(err, "cannot get log usage")
+	}
+	return size, nil
+}
+
 // getEnvsInLogs returns the unique model UUIDs that exist in
 // the logs collection. This uses the one of the indexes on the
 // collection and should be fast.
@@ -53,9 +53,13 @@ func (s *LogsSuite) TestDbLogger(c *gc.C) {
 	logger := state.NewDbLogger(s.State, names.NewMachineTag("22"))
 	defer logger.Close()
 	t0 := time.Now().Truncate(time.Millisecond) // MongoDB only stores timestamps with ms precision.
-	logger.Log(t0, "some.where", "foo.go:99", loggo.INFO, "all is well")
+	logger.Log(t0, "some.where", "foo.go:99", loggo.INFO, "all is well", nil)
 	t1 := t0.Add(time.Second)
-	logger.Log(t1, "else.where", "bar.go:42", loggo.ERROR, "oh noes")
+	logger.Log(t1, "else.where", "bar.go:42", loggo.ERROR, "oh noes", map[string]string{
+		"hook":     "config-changed",
+		"bad.name": "discarded",
+		"$badname": "discarded",
+	})
 
 	var docs []bson.M
 	err := s.logsColl.Find(nil).Sort("t").All(&docs)
@@ -69,6 +73,8 @@ func (s *LogsSuite) TestDbLogger(c *gc.C) {
 	c.Assert(docs[0]["l"], gc.Equals, "foo.go:99")
 	c.Assert(docs[0]["v"], gc.Equals, int(loggo.INFO))
 	c.Assert(docs[0]["x"], gc.Equals, "all is well")
+	_, hasFields := docs[0]["f"]
+	c.Assert(hasFields, jc.IsFalse)
 
 	c.Assert(docs[1]["t"], gc.Equals, t1)
 	c.Assert(docs[1]["e"], gc.Equals, s.State.ModelUUID())
@@ -77,13 +83,14 @@ func (s *LogsSuite) TestDbLogger(c *gc.C) {
 	c.Assert(docs[1]["l"], gc.Equals, "bar.go:42")
 	c.Assert(docs[1]["v"], gc.Equals, int(loggo.ERROR))
 	c.Assert(docs[1]["x"], gc.Equals, "oh noes")
+	c.Assert(docs[1]["f"], jc.DeepEquals, bson.M{"hook": "config-changed"})
 }
 
 func (s *LogsSuite) TestPruneLogsByTime(c *gc.C) {
 	dbLogger := state.NewDbLogger(s.State, names.NewMachineTag("22"))
 	defer dbLogger.Close()
 	log := func(t time.Time, msg string) {
-		err := dbLogger.Log(t, "module", "loc", loggo.INFO, msg)
+		err := dbLogger.Log(t, "module", "loc", loggo.INFO, msg, nil)
 		c.Assert(err, jc.ErrorIsNil)
 	}
 
@@ -96,7 +103,7 @@ func (s *LogsSuite) TestPruneLogsByTime(c *gc.C) {
 	log(maxLogTime.Add(-(2 * time.Second)), "prune")
 
 	noPruneMB := 100
-	err := state.PruneLogs(s.State, maxLogTime, noPruneMB)
+	err := state.PruneLogs(s.State, maxLogTime, noPruneMB, nil, nil)
 	c.Assert(err, jc.ErrorIsNil)
 
 	// After pruning there should just be 3 "keep" messages left.
@@ -130,7 +137,7 @@ func (s *LogsSuite) TestPruneLogsBySize(c *gc.C) {
 
 	// Prune logs collection back to 1 MiB.
 	tsNoPrune := time.Now().Add(-3 * 24 * time.Hour)
-	err := state.PruneLogs(s.State, tsNoPrune, 1)
+	err := state.PruneLogs(s.State, tsNoPrune, 1, nil, nil)
 	c.Assert(err, jc.ErrorIsNil)
 
 	// Logs for first env should not be touched.
@@ -155,12 +162,82 @@ func (s *LogsSuite) TestPruneLogsBySize(c *gc.C) {
 	assertLatestTs(s2)
 }
 
+func (s *LogsSuite) TestPruneLogsModelMinLogTime(c *gc.C) {
+	now := time.Now().Truncate(time.Millisecond)
+	s.generateLogs(c, s.State, now, 10)
+	s1 := s.Factory.MakeModel(c, nil)
+	defer s1.Close()
+	s.generateLogs(c, s1, now, 10)
+
+	// Only keep the last 5 seconds of logs for the second model.
+	retention := map[string]state.LogRetention{
+		s1.ModelUUID(): {MinLogTime: now.Add(-4500 * time.Millisecond)},
+	}
+	err := state.PruneLogs(s.State, now.Add(-time.Hour), 100, retention, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(s.countLogs(c, s.State), gc.Equals, 10)
+	c.Assert(s.countLogs(c, s1), gc.Equals, 5)
+}
+
+func (s *LogsSuite) TestPruneLogsModelMaxMB(c *gc.C) {
+	now := time.Now().Truncate(time.Millisecond)
+
+	s0 := s.State
+	startingLogsS0 := 10
+	s.generateLogs(c, s0, now, startingLogsS0)
+
+	// The second model has its own size limit...
+	s1 := s.Factory.MakeModel(c, nil)
+	defer s1.Close()
+	startingLogsS1 := 12000
+	s.generateLogs(c, s1, now, startingLogsS1)
+
+	// ...while the third does not.
+	s2 := s.Factory.MakeModel(c, nil)
+	defer s2.Close()
+	startingLogsS2 := 12000
+	s.generateLogs(c, s2, now, startingLogsS2)
+
+	retention := map[string]state.LogRetention{
+		s1.ModelUUID(): {MaxMB: 1},
+	}
+	tsNoPrune := now.Add(-3 * 24 * time.Hour)
+	err := state.PruneLogs(s.State, tsNoPrune, 1, retention, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Logs for first env should not be touched.
+	c.Assert(s.countLogs(c, s0), gc.Equals, startingLogsS0)
+
+	// Logs for second env should be pruned to its own limit.
+	c.Assert(s.countLogs(c, s1), jc.LessThan, startingLogsS1)
+	size, err := state.ModelLogsMB(s1)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(size <= 1, jc.IsTrue, gc.Commentf("size %v", size))
+
+	// Logs for third env should be pruned to bring the collection
+	// towards its maximum size; those of the second env are
+	// untouched by this, so it keeps more.
+	c.Assert(s.countLogs(c, s2), jc.LessThan, s.countLogs(c, s1))
+}
+
+func (s *LogsSuite) TestModelLogsMB(c *gc.C) {
+	size, err := state.ModelLogsMB(s.State)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(size, gc.Equals, 0.0)
+
+	s.generateLogs(c, s.State, time.Now(), 10000)
+	size, err = state.ModelLogsMB(s.State)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(size > 0.1, jc.IsTrue, gc.Commentf("size %v", size))
+}
+
 func (s *LogsSuite) generateLogs(c *gc.C, st *state.State, endTime time.Time, count int) {
 	dbLogger := state.NewDbLogger(st, names.NewMachineTag("0"))
 	defer dbLogger.Close()
 	for i := 0; i < count; i++ {
 		ts := endTime.Add(-time.Duration(i) * time.Second)
-		err := dbLogger.Log(ts, "module", "loc", loggo.INFO, "message")
+		err := dbLogger.Log(ts, "module", "loc", loggo.INFO, "message", nil)
 		c.Assert(err, jc.ErrorIsNil)
 	}
 }
@@ -220,6 +297,54 @@ func (s *LogTailerSuite) TestTimeFiltering(c *gc.C) {
 
 }
 
+func (s *LogTailerSuite) TestEndTime(c *gc.C) {
+	// Logs are wanted from the five seconds before threshT.
+	threshT := time.Now().Add(-time.Minute)
+	want := logTemplate{Message: "want"}
+	s.writeLogsT(c, threshT.Add(-5*time.Second), threshT.Add(-time.Millisecond), 5, want)
+	s.writeLogsT(c, threshT, threshT.Add(5*time.Second), 5, logTemplate{Message: "dont want"})
+
+	tailer := state.NewLogTailer(s.State, &state.LogTailerParams{
+		EndTime: threshT,
+		Oplog:   s.oplogColl,
+	})
+	defer tailer.Stop()
+	s.assertTailer(c, tailer, 5, want)
+
+	// The end time has already passed, so the tailer should stop
+	// itself rather than tailing the oplog.
+	select {
+	case _, ok := <-tailer.Logs():
+		if ok {
+			c.Fatal("shouldn't be any further logs")
+		}
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for logs channel to close")
+	}
+}
+
+func (s *LogTailerSuite) TestMessageFiltering(c *gc.C) {
+	started := logTemplate{Message: "hook install started"}
+	failed := logTemplate{Message: "hook install failed: exit status 1"}
+	retry := logTemplate{Message: "hook install failed: will retry"}
+	other := logTemplate{Message: "something else"}
+	writeLogs := func() {
+		s.writeLogs(c, 1, started)
+		s.writeLogs(c, 1, other)
+		s.writeLogs(c, 2, failed)
+		s.writeLogs(c, 1, retry)
+	}
+	params := &state.LogTailerParams{
+		IncludeMessage: `^hook \w+ (started|failed)`,
+		ExcludeMessage: "retry$",
+	}
+	assert := func(tailer state.LogTailer) {
+		s.assertTailer(c, tailer, 1, started)
+		s.assertTailer(c, tailer, 2, failed)
+	}
+	s.checkLogTailerFiltering(params, writeLogs, assert)
+}
+
 func (s *LogTailerSuite) TestOplogTransition(c *gc.C) {
 	// Ensure that logs aren't repeated as the log tailer moves from
 	// reading from the logs collection to tailing the oplog.
@@ -509,6 +634,32 @@ func (s *LogTailerSuite) TestIncludeExcludeModule(c *gc.C) {
 	s.checkLogTailerFiltering(params, writeLogs, assert)
 }
 
+func (s *LogTailerSuite) TestIncludeFields(c *gc.C) {
+	install := logTemplate{Fields: map[string]string{"hook": "install"}}
+	changed0 := logTemplate{Fields: map[string]string{"hook": "config-changed", "unit": "mysql/0"}}
+	changed1 := logTemplate{Fields: map[string]string{"hook": "config-changed", "unit": "mysql/1"}}
+	started1 := logTemplate{Fields: map[string]string{"hook": "start", "unit": "mysql/1"}}
+	noFields := logTemplate{}
+	writeLogs := func() {
+		s.writeLogs(c, 1, install)
+		s.writeLogs(c, 1, changed0)
+		s.writeLogs(c, 1, noFields)
+		s.writeLogs(c, 1, changed1)
+		s.writeLogs(c, 1, started1)
+	}
+	params := &state.LogTailerParams{
+		IncludeFields: map[string][]string{
+			"hook": {"config-changed", "start"},
+			"unit": {"mysql/1"},
+		},
+	}
+	assert := func(tailer state.LogTailer) {
+		s.assertTailer(c, tailer, 1, changed1)
+		s.assertTailer(c, tailer, 1, started1)
+	}
+	s.checkLogTailerFiltering(params, writeLogs, assert)
+}
+
 func (s *LogTailerSuite) checkLogTailerFiltering(
 	params *state.LogTailerParams,
 	writeLogs func(),
@@ -535,6 +686,7 @@ type logTemplate struct {
 	Location  string
 	Level     loggo.Level
 	Message   string
+	Fields    map[string]string
 }
 
 // writeLogs creates count log messages at the current time using
@@ -566,8 +718,8 @@ func (s *LogTailerSuite) writeLogsT(c *gc.C, startTime, endTime time.Time, count
 func (s *LogTailerSuite) writeLogToOplog(doc interface{}) error {
 	return s.oplogColl.Insert(bson.D{
 		{"ts", bson.MongoTimestamp(time.Now().Unix() << 32)}, // an approximation which will do
-		{"h", rand.Int63()},                                  // again, a suitable fake
-		{"op", "i"},                                          // this will always be an insert
+		{"h", rand.Int63()}, // again, a suitable fake
+		{"op", "i"},         // this will always be an insert
 		{"ns", "logs.logs"},
 		{"o", doc},
 	})
@@ -604,6 +756,7 @@ func (s *LogTailerSuite) logTemplateToDoc(lt logTemplate, t time.Time) interface
 		lt.Location,
 		lt.Level,
 		lt.Message,
+		lt.Fields,
 	)
 }
 
@@ -623,6 +776,7 @@ func (s *LogTailerSuite) assertTailer(c *gc.C, tailer state.LogTailer, expectedC
 			c.Assert(log.Location, gc.Equals, lt.Location)
 			c.Assert(log.Level, gc.Equals, lt.Level)
 			c.Assert(log.Message, gc.Equals, lt.Message)
+			c.Assert(log.Fields, jc.DeepEquals, lt.Fields)
 			count++
 			if count == expectedCount {
 				return
@@ -148,6 +148,9 @@ func (st *State) NewModel(cfg *config.Config, owner names.UserTag) (_ *Model, _
 			newState.Close()
 		}
 	}()
+	if err := newState.checkControllerModelOnlyAttrs(cfg.AllAttrs()); err != nil {
+		return nil, nil, errors.Annotate(err, "failed to create new model")
+	}
 
 	ops, err := newState.envSetupOps(cfg, uuid, ssEnv.UUID(), owner)
 	if err != nil {
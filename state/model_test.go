@@ -65,6 +65,16 @@ func (s *ModelSuite) TestNewModelNonExistentLocalUser(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, `cannot create model: user "non-existent" not found`)
 }
 
+func (s *ModelSuite) TestNewModelControllerModelOnlyConfig(c *gc.C) {
+	cfg, _ := s.createTestEnvConfig(c)
+	cfg, err := cfg.Apply(map[string]interface{}{"audit-syslog": true})
+	c.Assert(err, jc.ErrorIsNil)
+	owner := s.Factory.MakeUser(c, nil).UserTag()
+
+	_, _, err = s.State.NewModel(cfg, owner)
+	c.Assert(err, gc.ErrorMatches, "failed to create new model: audit-syslog can only be set on the controller model")
+}
+
 func (s *ModelSuite) TestNewModelSameUserSameNameFails(c *gc.C) {
 	cfg, _ := s.createTestEnvConfig(c)
 	owner := s.Factory.MakeUser(c, nil).UserTag()
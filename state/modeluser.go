@@ -25,13 +25,15 @@ type ModelUser struct {
 }
 
 type modelUserDoc struct {
-	ID          string    `bson:"_id"`
-	ModelUUID   string    `bson:"model-uuid"`
-	UserName    string    `bson:"user"`
-	DisplayName string    `bson:"displayname"`
-	CreatedBy   string    `bson:"createdby"`
-	DateCreated time.Time `bson:"datecreated"`
-	ReadOnly    bool      `bson:"readonly"`
+	ID          string      `bson:"_id"`
+	ModelUUID   string      `bson:"model-uuid"`
+	UserName    string      `bson:"user"`
+	DisplayName string      `bson:"displayname"`
+	CreatedBy   string      `bson:"createdby"`
+	DateCreated time.Time   `bson:"datecreated"`
+	ReadOnly    bool        `bson:"readonly"`
+	Roles       []RoleGrant `bson:"roles,omitempty"`
+	TxnRevno    int64       `bson:"txn-revno"`
 }
 
 // modelUserLastConnectionDoc is updated by the apiserver whenever the user
@@ -87,6 +89,27 @@ func (e *ModelUser) ReadOnly() bool {
 	return e.doc.ReadOnly
 }
 
+// Roles returns the roles the user has been granted on the model. A
+// user who has never been granted a role has the read role if they
+// were added read-only, and the admin role otherwise.
+func (e *ModelUser) Roles() []RoleGrant {
+	if len(e.doc.Roles) > 0 {
+		return e.grantedRoles()
+	}
+	if e.doc.ReadOnly {
+		return []RoleGrant{{Role: ReadRole}}
+	}
+	return []RoleGrant{{Role: AdminRole}}
+}
+
+// grantedRoles returns the roles explicitly granted to the model
+// user, without the one implied when there are none.
+func (e *ModelUser) grantedRoles() []RoleGrant {
+	roles := make([]RoleGrant, len(e.doc.Roles))
+	copy(roles, e.doc.Roles)
+	return roles
+}
+
 // LastConnection returns when this ModelUser last connected through the API
 // in UTC. The resulting time will be nil if the user has never logged in.
 func (e *ModelUser) LastConnection() (time.Time, error) {
@@ -172,6 +172,101 @@ func (s *ModelUserSuite) TestRemoveModelUserFails(c *gc.C) {
 	c.Assert(err, jc.Satisfies, errors.IsNotFound)
 }
 
+func (s *ModelUserSuite) TestRolesDefault(c *gc.C) {
+	modelUser := s.Factory.MakeModelUser(c, nil)
+	c.Assert(modelUser.Roles(), jc.DeepEquals, []state.RoleGrant{{Role: state.AdminRole}})
+
+	modelUser = s.Factory.MakeModelUser(c, &factory.ModelUserParams{ReadOnly: true})
+	c.Assert(modelUser.Roles(), jc.DeepEquals, []state.RoleGrant{{Role: state.ReadRole}})
+}
+
+func (s *ModelUserSuite) TestGrantModelUserRole(c *gc.C) {
+	s.Factory.MakeService(c, &factory.ServiceParams{Name: "wordpress"})
+	modelUser := s.Factory.MakeModelUser(c, &factory.ModelUserParams{ReadOnly: true})
+	user := modelUser.UserTag()
+
+	err := s.State.GrantModelUserRole(user, state.RoleGrant{Role: state.OperatorRole})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.GrantModelUserRole(user, state.RoleGrant{Role: state.DeployerRole, Service: "wordpress"})
+	c.Assert(err, jc.ErrorIsNil)
+	// Granting a role twice is not an error.
+	err = s.State.GrantModelUserRole(user, state.RoleGrant{Role: state.OperatorRole})
+	c.Assert(err, jc.ErrorIsNil)
+
+	modelUser, err = s.State.ModelUser(user)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(modelUser.Roles(), jc.DeepEquals, []state.RoleGrant{
+		{Role: state.OperatorRole},
+		{Role: state.DeployerRole, Service: "wordpress"},
+	})
+	c.Assert(modelUser.ReadOnly(), jc.IsFalse)
+}
+
+func (s *ModelUserSuite) TestGrantModelUserRoleReplacesImpliedAdmin(c *gc.C) {
+	s.Factory.MakeService(c, &factory.ServiceParams{Name: "wordpress"})
+	modelUser := s.Factory.MakeModelUser(c, nil)
+	user := modelUser.UserTag()
+	c.Assert(modelUser.Roles(), jc.DeepEquals, []state.RoleGrant{{Role: state.AdminRole}})
+
+	err := s.State.GrantModelUserRole(user, state.RoleGrant{Role: state.DeployerRole, Service: "wordpress"})
+	c.Assert(err, jc.ErrorIsNil)
+	modelUser, err = s.State.ModelUser(user)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(modelUser.Roles(), jc.DeepEquals, []state.RoleGrant{
+		{Role: state.DeployerRole, Service: "wordpress"},
+	})
+}
+
+func (s *ModelUserSuite) TestGrantModelUserRoleInvalid(c *gc.C) {
+	modelUser := s.Factory.MakeModelUser(c, nil)
+	user := modelUser.UserTag()
+
+	err := s.State.GrantModelUserRole(user, state.RoleGrant{Role: "superuser"})
+	c.Assert(err, gc.ErrorMatches, `role "superuser" not valid`)
+	err = s.State.GrantModelUserRole(user, state.RoleGrant{Role: state.AdminRole, Service: "wordpress"})
+	c.Assert(err, gc.ErrorMatches, `admin role scoped to a service not valid`)
+	err = s.State.GrantModelUserRole(user, state.RoleGrant{Role: state.OperatorRole, Service: "wordpress"})
+	c.Assert(err, gc.ErrorMatches, `cannot grant "operator:wordpress" to model user ".*": service "wordpress" not found`)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	nonUser := s.Factory.MakeUser(c, &factory.UserParams{NoModelUser: true})
+	err = s.State.GrantModelUserRole(nonUser.UserTag(), state.RoleGrant{Role: state.OperatorRole})
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *ModelUserSuite) TestRevokeModelUserRole(c *gc.C) {
+	modelUser := s.Factory.MakeModelUser(c, &factory.ModelUserParams{
+		Roles: []state.RoleGrant{{Role: state.AdminRole}, {Role: state.OperatorRole}},
+	})
+	user := modelUser.UserTag()
+	c.Assert(modelUser.Roles(), jc.DeepEquals, []state.RoleGrant{
+		{Role: state.AdminRole},
+		{Role: state.OperatorRole},
+	})
+
+	err := s.State.RevokeModelUserRole(user, state.RoleGrant{Role: state.AdminRole})
+	c.Assert(err, jc.ErrorIsNil)
+	modelUser, err = s.State.ModelUser(user)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(modelUser.Roles(), jc.DeepEquals, []state.RoleGrant{{Role: state.OperatorRole}})
+	c.Assert(modelUser.ReadOnly(), jc.IsFalse)
+
+	err = s.State.RevokeModelUserRole(user, state.RoleGrant{Role: state.DeployerRole})
+	c.Assert(err, gc.ErrorMatches, `cannot revoke "deployer" from model user ".*": role "deployer" not found`)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	// Revoking the last role leaves the user able to read the model.
+	err = s.State.RevokeModelUserRole(user, state.RoleGrant{Role: state.OperatorRole})
+	c.Assert(err, jc.ErrorIsNil)
+	modelUser, err = s.State.ModelUser(user)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(modelUser.Roles(), jc.DeepEquals, []state.RoleGrant{{Role: state.ReadRole}})
+	c.Assert(modelUser.ReadOnly(), jc.IsTrue)
+
+	err = s.State.RevokeModelUserRole(user, state.RoleGrant{Role: state.ReadRole})
+	c.Assert(err, gc.ErrorMatches, `cannot revoke "read" from model user ".*": read access is implied by access to the model; remove the model user instead`)
+}
+
 func (s *ModelUserSuite) TestUpdateLastConnection(c *gc.C) {
 	now := state.NowToTheSecond()
 	createdBy := s.Factory.MakeUser(c, &factory.UserParams{Name: "createdby"})
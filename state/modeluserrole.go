@@ -0,0 +1,170 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+
+	"github.com/juju/errors"
+	"github.com/juju/names"
+	jujutxn "github.com/juju/txn"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// ModelUserRole names a set of things a model user may do.
+type ModelUserRole string
+
+const (
+	// ReadRole allows a user to look at the model but not change it.
+	ReadRole ModelUserRole = "read"
+
+	// OperatorRole allows a user to operate the services in the
+	// model: to run actions and commands, and to resolve errors.
+	OperatorRole ModelUserRole = "operator"
+
+	// DeployerRole allows a user to deploy, upgrade and configure
+	// services.
+	DeployerRole ModelUserRole = "deployer"
+
+	// AdminRole allows a user to do anything to the model.
+	AdminRole ModelUserRole = "admin"
+)
+
+// Validate returns an error if the role is not known.
+func (r ModelUserRole) Validate() error {
+	switch r {
+	case ReadRole, OperatorRole, DeployerRole, AdminRole:
+		return nil
+	}
+	return errors.NotValidf("role %q", string(r))
+}
+
+// RoleGrant records a role granted to a model user. A grant with a
+// service applies only to that service.
+type RoleGrant struct {
+	Role    ModelUserRole `bson:"role"`
+	Service string        `bson:"service,omitempty"`
+}
+
+// String returns the grant as "role" or "role:service".
+func (g RoleGrant) String() string {
+	if g.Service == "" {
+		return string(g.Role)
+	}
+	return fmt.Sprintf("%s:%s", g.Role, g.Service)
+}
+
+// Validate returns an error if the grant is not valid. Only the
+// operator and deployer roles may be scoped to a service.
+func (g RoleGrant) Validate() error {
+	if err := g.Role.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	if g.Service == "" {
+		return nil
+	}
+	if !names.IsValidService(g.Service) {
+		return errors.NotValidf("service name %q", g.Service)
+	}
+	if g.Role != OperatorRole && g.Role != DeployerRole {
+		return errors.NotValidf("%s role scoped to a service", g.Role)
+	}
+	return nil
+}
+
+// isWriteRole returns whether the role allows any change to the model.
+func (r ModelUserRole) isWriteRole() bool {
+	return r != ReadRole
+}
+
+// GrantModelUserRole grants the role to the model user. A user who has
+// never been granted a role has the admin or read role implied by
+// whether they were added read-only; the first grant replaces that
+// implied role rather than adding to it, so that granting a scoped role
+// to a user added with full access takes the rest of that access away.
+func (st *State) GrantModelUserRole(user names.UserTag, grant RoleGrant) error {
+	if err := grant.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		modelUser, err := st.ModelUser(user)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		roles := modelUser.grantedRoles()
+		for _, existing := range roles {
+			if existing == grant {
+				return nil, jujutxn.ErrNoOperations
+			}
+		}
+		roles = append(roles, grant)
+		ops := []txn.Op{setModelUserRolesOp(modelUser, roles)}
+		if grant.Service != "" {
+			if _, err := st.Service(grant.Service); err != nil {
+				return nil, errors.Trace(err)
+			}
+			ops = append(ops, txn.Op{
+				C:      servicesC,
+				Id:     st.docID(grant.Service),
+				Assert: isAliveDoc,
+			})
+		}
+		return ops, nil
+	}
+	err := st.run(buildTxn)
+	return errors.Annotatef(err, "cannot grant %q to model user %q", grant.String(), user.Canonical())
+}
+
+// RevokeModelUserRole revokes the role from the model user. A user
+// whose every role has been revoked may still read the model; to take
+// that away, remove the model user.
+func (st *State) RevokeModelUserRole(user names.UserTag, grant RoleGrant) error {
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		modelUser, err := st.ModelUser(user)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		var roles []RoleGrant
+		found := false
+		for _, existing := range modelUser.Roles() {
+			if existing == grant {
+				found = true
+				continue
+			}
+			roles = append(roles, existing)
+		}
+		if !found {
+			return nil, errors.NotFoundf("role %q", grant.String())
+		}
+		if grant.Role == ReadRole && len(roles) == 0 {
+			return nil, errors.New("read access is implied by access to the model; remove the model user instead")
+		}
+		return []txn.Op{setModelUserRolesOp(modelUser, roles)}, nil
+	}
+	err := st.run(buildTxn)
+	return errors.Annotatef(err, "cannot revoke %q from model user %q", grant.String(), user.Canonical())
+}
+
+// setModelUserRolesOp returns the operation that records the given
+// roles for the model user, keeping the read-only flag in step with
+// them. With no roles left, the user falls back to what the flag
+// implies.
+func setModelUserRolesOp(modelUser *ModelUser, roles []RoleGrant) txn.Op {
+	readOnly := true
+	for _, grant := range roles {
+		if grant.Role.isWriteRole() {
+			readOnly = false
+		}
+	}
+	return txn.Op{
+		C:      modelUsersC,
+		Id:     modelUserID(modelUser.UserTag()),
+		Assert: bson.D{{"txn-revno", modelUser.doc.TxnRevno}},
+		Update: bson.D{{"$set", bson.D{
+			{"readonly", readOnly},
+			{"roles", roles},
+		}}},
+	}
+}
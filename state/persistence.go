@@ -8,6 +8,7 @@ import (
 	jujutxn "github.com/juju/txn"
 	"gopkg.in/mgo.v2"
 
+	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/state/storage"
 )
 
@@ -27,6 +28,9 @@ type Persistence interface {
 
 	// NewStorage returns a new blob storage for the environment.
 	NewStorage() storage.Storage
+
+	// ModelConfig returns the current configuration of the model.
+	ModelConfig() (*config.Config, error)
 }
 
 type statePersistence struct {
@@ -80,3 +84,12 @@ func (sp *statePersistence) NewStorage() storage.Storage {
 	store := storage.NewStorage(envUUID, session)
 	return store
 }
+
+// ModelConfig returns the current configuration of the model.
+func (sp statePersistence) ModelConfig() (*config.Config, error) {
+	cfg, err := sp.st.ModelConfig()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return cfg, nil
+}
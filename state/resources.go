@@ -7,6 +7,7 @@ import (
 	"io"
 
 	"github.com/juju/errors"
+	"github.com/juju/names"
 	charmresource "gopkg.in/juju/charm.v6-unstable/resource"
 	"gopkg.in/mgo.v2/txn"
 
@@ -39,9 +40,30 @@ type Resources interface {
 	// OpenResource returns the metadata for a resource and a reader for the resource.
 	OpenResource(unit resource.Unit, name string) (resource.Resource, io.ReadCloser, error)
 
+	// ListResourceRevisions returns the retained revisions of the
+	// service's resources.
+	ListResourceRevisions(serviceID string) ([]resource.ResourceRevision, error)
+
+	// SetResourceRevision makes the identified retained revision the
+	// one the service uses.
+	SetResourceRevision(serviceID, name string, number int) (resource.Resource, error)
+
 	// NewResolvePendingResourcesOps generates mongo transaction operations
 	// to set the identified resources as active.
 	NewResolvePendingResourcesOps(serviceID string, pendingIDs map[string]string) ([]txn.Op, error)
+
+	// NewRemoveResourcesOps generates mongo transaction operations to
+	// remove all the service's resources.
+	NewRemoveResourcesOps(serviceID string) ([]txn.Op, error)
+
+	// RemoveOrphanedContent removes from blob storage the resource
+	// content that no resource refers to any more.
+	RemoveOrphanedContent() error
+
+	// AddContentRefCounts starts counting the references to the
+	// resource content stored before reference counting was
+	// introduced.
+	AddContentRefCounts() error
 }
 
 var newResources func(Persistence) Resources
@@ -62,3 +84,54 @@ func (st *State) Resources() (Resources, error) {
 	resources := newResources(persist)
 	return resources, nil
 }
+
+// removeResourcesOps returns the operations required to remove the
+// service's resources. If resources are not supported then there are
+// none to remove.
+func (st *State) removeResourcesOps(serviceID string) ([]txn.Op, error) {
+	if newResources == nil {
+		return nil, nil
+	}
+	resources := newResources(st.newPersistence())
+	ops, err := resources.NewRemoveResourcesOps(serviceID)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return ops, nil
+}
+
+// RemoveOrphanedResourceContent removes, in every model, the resource
+// content that no resource refers to any more. That includes content
+// of pruned revisions and of services that have been removed.
+func (st *State) RemoveOrphanedResourceContent() error {
+	if newResources == nil {
+		return nil
+	}
+	models, err := st.AllModels()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, model := range models {
+		if err := st.removeOrphanedResourceContent(model.ModelTag()); err != nil {
+			return errors.Annotatef(err, "model %q", model.UUID())
+		}
+	}
+	return nil
+}
+
+func (st *State) removeOrphanedResourceContent(tag names.ModelTag) error {
+	modelSt, err := st.ForModel(tag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer modelSt.Close()
+
+	resources, err := modelSt.Resources()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := resources.RemoveOrphanedContent(); err != nil {
+		return errors.Trace(err)
+	}
+	return nil
+}
@@ -9,10 +9,12 @@ import (
 
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
+	"gopkg.in/mgo.v2/bson"
 
 	"github.com/juju/juju/component/all"
 	"github.com/juju/juju/resource"
 	"github.com/juju/juju/resource/resourcetesting"
+	"github.com/juju/juju/state"
 )
 
 func init() {
@@ -38,7 +40,7 @@ func (s *ResourcesSuite) TestFunctional(c *gc.C) {
 	res := newResource(c, "spam", data)
 	file := bytes.NewBufferString(data)
 
-	_, err = st.SetResource("a-service", res.Username, res.Resource, file)
+	_, err = st.SetResource("mysql", res.Username, res.Resource, file)
 	c.Assert(err, jc.ErrorIsNil)
 
 	resources, err = st.ListResources("a-service")
@@ -52,6 +54,75 @@ func (s *ResourcesSuite) TestFunctional(c *gc.C) {
 	// TODO(ericsnow) Add more as state.Resources grows more functionality.
 }
 
+func (s *ResourcesSuite) TestRemoveOrphanedContent(c *gc.C) {
+	st, err := s.State.Resources()
+	c.Assert(err, jc.ErrorIsNil)
+	blobs := s.Session.DB("juju").C("resourceblobs")
+	wordpress := s.AddTestingService(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	mysql := s.AddTestingService(c, "mysql", s.AddTestingCharm(c, "mysql"))
+
+	// The same content is only stored once.
+	data := "spamspamspam"
+	res := newResource(c, "spam", data)
+	_, err = st.SetResource("wordpress", res.Username, res.Resource, bytes.NewBufferString(data))
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = st.SetResource("mysql", res.Username, res.Resource, bytes.NewBufferString(data))
+	c.Assert(err, jc.ErrorIsNil)
+	count, err := blobs.Count()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(count, gc.Equals, 1)
+
+	// Removing one service leaves the content for the other.
+	err = wordpress.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.RemoveOrphanedResourceContent()
+	c.Assert(err, jc.ErrorIsNil)
+	count, err = blobs.Count()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(count, gc.Equals, 1)
+
+	resources, err := st.ListResources("wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(resources.Resources, gc.HasLen, 0)
+
+	// Once nothing refers to the content, it is removed.
+	err = mysql.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.RemoveOrphanedResourceContent()
+	c.Assert(err, jc.ErrorIsNil)
+	count, err = blobs.Count()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(count, gc.Equals, 0)
+}
+
+func (s *ResourcesSuite) TestAddResourceContentRefCounts(c *gc.C) {
+	st, err := s.State.Resources()
+	c.Assert(err, jc.ErrorIsNil)
+	blobs := s.Session.DB("juju").C("resourceblobs")
+	s.AddTestingService(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	s.AddTestingService(c, "mysql", s.AddTestingCharm(c, "mysql"))
+	data := "spamspamspam"
+	res := newResource(c, "spam", data)
+	_, err = st.SetResource("wordpress", res.Username, res.Resource, bytes.NewBufferString(data))
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = st.SetResource("mysql", res.Username, res.Resource, bytes.NewBufferString(data))
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Content stored before reference counting has no blob doc.
+	_, err = blobs.RemoveAll(nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	for i := 0; i < 2; i++ {
+		err = state.AddResourceContentRefCounts(s.State)
+		c.Assert(err, jc.ErrorIsNil)
+		var docs []bson.M
+		err = blobs.Find(nil).All(&docs)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(docs, gc.HasLen, 1)
+		c.Check(docs[0]["refcount"], gc.Equals, 2)
+	}
+}
+
 func newResource(c *gc.C, name, data string) resource.Resource {
 	opened := resourcetesting.NewResource(c, nil, name, "a-service", data)
 	res := opened.Resource
@@ -48,6 +48,10 @@ type serviceDoc struct {
 	OwnerTag          string     `bson:"ownertag"`
 	TxnRevno          int64      `bson:"txn-revno"`
 	MetricCredentials []byte     `bson:"metric-credentials"`
+
+	// HookTimeout overrides the model's hook-timeout setting
+	// for the service's units when it is non-zero.
+	HookTimeout time.Duration `bson:"hook-timeout,omitempty"`
 }
 
 func newService(st *State, doc *serviceDoc) *Service {
@@ -171,7 +175,11 @@ func (s *Service) destroyOps() ([]txn.Op, error) {
 	// removed, the service can also be removed.
 	if s.doc.UnitCount == 0 && s.doc.RelationCount == removeCount {
 		hasLastRefs := bson.D{{"life", Alive}, {"unitcount", 0}, {"relationcount", removeCount}}
-		return append(ops, s.removeOps(hasLastRefs)...), nil
+		removeOps, err := s.removeOps(hasLastRefs)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return append(ops, removeOps...), nil
 	}
 	// In all other cases, service removal will be handled as a consequence
 	// of the removal of the last unit or relation referencing it. If any
@@ -209,7 +217,7 @@ func (s *Service) destroyOps() ([]txn.Op, error) {
 
 // removeOps returns the operations required to remove the service. Supplied
 // asserts will be included in the operation on the service document.
-func (s *Service) removeOps(asserts bson.D) []txn.Op {
+func (s *Service) removeOps(asserts bson.D) ([]txn.Op, error) {
 	settingsDocID := s.st.docID(s.settingsKey())
 	ops := []txn.Op{
 		{
@@ -234,7 +242,11 @@ func (s *Service) removeOps(asserts bson.D) []txn.Op {
 		removeLeadershipSettingsOp(s.Tag().Id()),
 		removeStatusOp(s.st, s.globalKey()),
 	}
-	return ops
+	resourcesOps, err := s.st.removeResourcesOps(s.doc.Name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return append(ops, resourcesOps...), nil
 }
 
 // IsExposed returns whether this service is exposed. The explicitly open
@@ -270,6 +282,33 @@ func (s *Service) setExposed(exposed bool) (err error) {
 	return nil
 }
 
+// HookTimeout returns the duration after which the hooks of the
+// service's units are killed, overriding the model's hook-timeout
+// setting, or zero if the model setting applies.
+func (s *Service) HookTimeout() time.Duration {
+	return s.doc.HookTimeout
+}
+
+// SetHookTimeout sets the duration after which the hooks of the
+// service's units are killed. A zero timeout removes the override,
+// so that the model's hook-timeout setting applies.
+func (s *Service) SetHookTimeout(timeout time.Duration) error {
+	if timeout < 0 {
+		return errors.NotValidf("negative hook timeout %v", timeout)
+	}
+	ops := []txn.Op{{
+		C:      servicesC,
+		Id:     s.doc.DocID,
+		Assert: isAliveDoc,
+		Update: bson.D{{"$set", bson.D{{"hook-timeout", timeout}}}},
+	}}
+	if err := s.st.runTransaction(ops); err != nil {
+		return errors.Annotatef(onAbort(err, errNotAlive), "cannot set hook timeout for service %q", s)
+	}
+	s.doc.HookTimeout = timeout
+	return nil
+}
+
 // Charm returns the service's charm and whether units should upgrade to that
 // charm even if they are in an error state.
 func (s *Service) Charm() (ch *Charm, force bool, err error) {
@@ -970,7 +1009,11 @@ func (s *Service) removeUnitOps(u *Unit, asserts bson.D) ([]txn.Op, error) {
 	}
 	if s.doc.Life == Dying && s.doc.RelationCount == 0 && s.doc.UnitCount == 1 {
 		hasLastRef := bson.D{{"life", Dying}, {"relationcount", 0}, {"unitcount", 1}}
-		return append(ops, s.removeOps(hasLastRef)...), nil
+		removeOps, err := s.removeOps(hasLastRef)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return append(ops, removeOps...), nil
 	}
 	svcOp := txn.Op{
 		C:      servicesC,
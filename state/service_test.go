@@ -6,6 +6,7 @@ package state_test
 import (
 	"fmt"
 	"sort"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
@@ -1992,6 +1993,36 @@ func (s *ServiceSuite) TestMetricCredentialsOnDying(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "cannot update metric credentials: service not found or not alive")
 }
 
+func (s *ServiceSuite) TestHookTimeout(c *gc.C) {
+	c.Assert(s.mysql.HookTimeout(), gc.Equals, time.Duration(0))
+	err := s.mysql.SetHookTimeout(10 * time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.HookTimeout(), gc.Equals, 10*time.Minute)
+
+	service, err := s.State.Service(s.mysql.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(service.HookTimeout(), gc.Equals, 10*time.Minute)
+
+	err = s.mysql.SetHookTimeout(0)
+	c.Assert(err, jc.ErrorIsNil)
+	err = service.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(service.HookTimeout(), gc.Equals, time.Duration(0))
+
+	err = s.mysql.SetHookTimeout(-time.Minute)
+	c.Assert(err, gc.ErrorMatches, "negative hook timeout -1m0s not valid")
+}
+
+func (s *ServiceSuite) TestSetHookTimeoutOnDying(c *gc.C) {
+	_, err := s.mysql.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	assertLife(c, s.mysql, state.Dying)
+	err = s.mysql.SetHookTimeout(time.Minute)
+	c.Assert(err, gc.ErrorMatches, `cannot set hook timeout for service "mysql": not found or not alive`)
+}
+
 func (s *ServiceSuite) testStatus(c *gc.C, status1, status2, expected state.Status) {
 	u1, err := s.mysql.AddUnit()
 	c.Assert(err, jc.ErrorIsNil)
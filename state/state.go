@@ -569,6 +569,9 @@ func (st *State) UpdateModelConfig(updateAttrs map[string]interface{}, removeAtt
 	if err != nil {
 		return errors.Trace(err)
 	}
+	if err := st.checkControllerModelOnlyAttrs(updateAttrs); err != nil {
+		return errors.Trace(err)
+	}
 	if additionalValidation != nil {
 		err = additionalValidation(updateAttrs, removeAttrs, oldConfig)
 		if err != nil {
@@ -591,6 +594,21 @@ func (st *State) UpdateModelConfig(updateAttrs map[string]interface{}, removeAtt
 	return errors.Trace(err)
 }
 
+// checkControllerModelOnlyAttrs returns an error if this is not the
+// controller model and attrs sets any attribute that may only be set
+// on the controller model.
+func (st *State) checkControllerModelOnlyAttrs(attrs map[string]interface{}) error {
+	if st.IsController() {
+		return nil
+	}
+	for _, attr := range config.ControllerModelOnlyAttributes {
+		if _, ok := attrs[attr]; ok {
+			return errors.Errorf("%s can only be set on the controller model", attr)
+		}
+	}
+	return nil
+}
+
 // EnvironConstraints returns the current model constraints.
 func (st *State) ModelConstraints() (constraints.Value, error) {
 	cons, err := readConstraints(st, modelGlobalKey)
@@ -2319,6 +2319,21 @@ func (s *StateSuite) TestModelConfig(c *gc.C) {
 	c.Assert(oldCfg, gc.DeepEquals, cfg)
 }
 
+func (s *StateSuite) TestUpdateModelConfigControllerModelOnly(c *gc.C) {
+	attrs := map[string]interface{}{"audit-log-file": "audit.log"}
+	err := s.State.UpdateModelConfig(attrs, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+	err = st.UpdateModelConfig(attrs, nil, nil)
+	c.Assert(err, gc.ErrorMatches, "audit-log-file can only be set on the controller model")
+	cfg, err := st.ModelConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	_, ok := cfg.AuditLogFile()
+	c.Assert(ok, jc.IsFalse)
+}
+
 func (s *StateSuite) TestModelConstraints(c *gc.C) {
 	// Environ constraints start out empty (for now).
 	cons, err := s.State.ModelConstraints()
@@ -339,7 +339,7 @@ func (u *Unit) eraseHistory() error {
 	if _, err := historyW.RemoveAll(bson.D{{"statusid", u.globalAgentKey()}}); err != nil {
 		return err
 	}
-	return nil
+	return u.eraseHookHistory()
 }
 
 // destroyOps returns the operations required to destroy the unit. If it
@@ -1792,6 +1792,19 @@ var hasNoContainersTerm = bson.DocElem{
 		{{"children", bson.D{{"$exists", false}}}},
 	}}
 
+// rangeTerm returns a query term matching the values allowed by a
+// constraint with the given minimum and maximum, or nil if any value is.
+func rangeTerm(min, max *uint64) bson.D {
+	var term bson.D
+	if min != nil && *min > 0 {
+		term = append(term, bson.DocElem{"$gte", *min})
+	}
+	if max != nil {
+		term = append(term, bson.DocElem{"$lte", *max})
+	}
+	return term
+}
+
 // findCleanMachineQuery returns a Mongo query to find clean (and possibly empty) machines with
 // characteristics matching the specified constraints.
 func (u *Unit) findCleanMachineQuery(requireEmpty bool, cons *constraints.Value) (bson.D, error) {
@@ -1840,24 +1853,26 @@ func (u *Unit) findCleanMachineQuery(requireEmpty bool, cons *constraints.Value)
 	// be suitable, but we don't know that right now and it's best
 	// to err on the side of caution and exclude such machines.
 	var suitableInstanceData []instanceData
+	// Preferred constraints do not rule out any machines.
 	var suitableTerms bson.D
-	if cons.Arch != nil && *cons.Arch != "" {
-		suitableTerms = append(suitableTerms, bson.DocElem{"arch", *cons.Arch})
+	required := cons.Required()
+	if required.HasArch() {
+		suitableTerms = append(suitableTerms, bson.DocElem{"arch", bson.D{{"$in", required.Arches()}}})
 	}
-	if cons.Mem != nil && *cons.Mem > 0 {
-		suitableTerms = append(suitableTerms, bson.DocElem{"mem", bson.D{{"$gte", *cons.Mem}}})
+	if term := rangeTerm(required.Mem, required.MaxMem); term != nil {
+		suitableTerms = append(suitableTerms, bson.DocElem{"mem", term})
 	}
-	if cons.RootDisk != nil && *cons.RootDisk > 0 {
-		suitableTerms = append(suitableTerms, bson.DocElem{"rootdisk", bson.D{{"$gte", *cons.RootDisk}}})
+	if term := rangeTerm(required.RootDisk, required.MaxRootDisk); term != nil {
+		suitableTerms = append(suitableTerms, bson.DocElem{"rootdisk", term})
 	}
-	if cons.CpuCores != nil && *cons.CpuCores > 0 {
-		suitableTerms = append(suitableTerms, bson.DocElem{"cpucores", bson.D{{"$gte", *cons.CpuCores}}})
+	if term := rangeTerm(required.CpuCores, required.MaxCpuCores); term != nil {
+		suitableTerms = append(suitableTerms, bson.DocElem{"cpucores", term})
 	}
-	if cons.CpuPower != nil && *cons.CpuPower > 0 {
-		suitableTerms = append(suitableTerms, bson.DocElem{"cpupower", bson.D{{"$gte", *cons.CpuPower}}})
+	if required.CpuPower != nil && *required.CpuPower > 0 {
+		suitableTerms = append(suitableTerms, bson.DocElem{"cpupower", bson.D{{"$gte", *required.CpuPower}}})
 	}
-	if cons.Tags != nil && len(*cons.Tags) > 0 {
-		suitableTerms = append(suitableTerms, bson.DocElem{"tags", bson.D{{"$all", *cons.Tags}}})
+	if required.Tags != nil && len(*required.Tags) > 0 {
+		suitableTerms = append(suitableTerms, bson.DocElem{"tags", bson.D{{"$all", *required.Tags}}})
 	}
 	if len(suitableTerms) > 0 {
 		instanceDataCollection, closer := db.GetCollection(instanceDataC)
@@ -2038,6 +2053,26 @@ type ActionSpecsByName map[string]charm.ActionSpec
 // this Unit, and returns its ID.  Note that the use of spec.InsertDefaults
 // mutates payload.
 func (u *Unit) AddAction(name string, payload map[string]interface{}) (*Action, error) {
+	return u.AddActionWithTimeout(name, payload, 0)
+}
+
+// AddActionWithTimeout is like AddAction, except that the Action is
+// stopped if it runs for longer than the given timeout.
+func (u *Unit) AddActionWithTimeout(name string, payload map[string]interface{}, timeout time.Duration) (*Action, error) {
+	return u.addAction(name, payload, timeout, "")
+}
+
+// AddActionInGroup is like AddActionWithTimeout, except that the
+// Action is recorded as a member of the given group, as created by
+// NewActionGroup.
+func (u *Unit) AddActionInGroup(group, name string, payload map[string]interface{}, timeout time.Duration) (*Action, error) {
+	if group == "" {
+		return nil, errors.NotValidf("empty action group")
+	}
+	return u.addAction(name, payload, timeout, group)
+}
+
+func (u *Unit) addAction(name string, payload map[string]interface{}, timeout time.Duration, group string) (*Action, error) {
 	if len(name) == 0 {
 		return nil, errors.New("no action name given")
 	}
@@ -2058,7 +2093,7 @@ func (u *Unit) AddAction(name string, payload map[string]interface{}) (*Action,
 	if err != nil {
 		return nil, err
 	}
-	return u.st.EnqueueAction(u.Tag(), name, payloadWithDefaults)
+	return u.st.enqueueAction(u.Tag(), name, payloadWithDefaults, timeout, group)
 }
 
 // ActionSpecs gets the ActionSpec map for the Unit's charm.
@@ -272,3 +272,19 @@ func addDefaultBindingsToServices(st *State) error {
 func AddDefaultEndpointBindingsToServices(st *State) error {
 	return runForAllEnvStates(st, addDefaultBindingsToServices)
 }
+
+// AddResourceContentRefCounts starts counting, in every model, the
+// references to the resource content stored before reference counting
+// was introduced, so that it can be removed once orphaned.
+func AddResourceContentRefCounts(st *State) error {
+	if newResources == nil {
+		return nil
+	}
+	return runForAllEnvStates(st, func(st *State) error {
+		resources, err := st.Resources()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		return errors.Trace(resources.AddContentRefCounts())
+	})
+}
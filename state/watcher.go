@@ -2369,7 +2369,7 @@ func (st *State) WatchActionResults() StringsWatcher {
 // that notifies on new ActionResults being added for the ActionRecevers
 // being watched.
 func (st *State) WatchActionResultsFilteredBy(receivers ...ActionReceiver) StringsWatcher {
-	return newActionStatusWatcher(st, receivers, []ActionStatus{ActionCompleted, ActionCancelled, ActionFailed}...)
+	return newActionStatusWatcher(st, receivers, []ActionStatus{ActionCompleted, ActionCancelled, ActionFailed, ActionTimedOut}...)
 }
 
 // openedPortsWatcher notifies of changes in the openedPorts
@@ -55,6 +55,7 @@ type ModelUserParams struct {
 	DisplayName string
 	CreatedBy   names.Tag
 	ReadOnly    bool
+	Roles       []state.RoleGrant
 }
 
 // CharmParams defines the parameters for creating a charm.
@@ -206,6 +207,15 @@ func (factory *Factory) MakeModelUser(c *gc.C, params *ModelUserParams) *state.M
 		ReadOnly:    params.ReadOnly,
 	})
 	c.Assert(err, jc.ErrorIsNil)
+	if len(params.Roles) == 0 {
+		return modelUser
+	}
+	for _, grant := range params.Roles {
+		err := factory.st.GrantModelUserRole(modelUser.UserTag(), grant)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	modelUser, err = factory.st.ModelUser(modelUser.UserTag())
+	c.Assert(err, jc.ErrorIsNil)
 	return modelUser
 }
 
@@ -73,5 +73,12 @@ func stateStepsFor126() []Step {
 				return state.AddDefaultEndpointBindingsToServices(context.State())
 			},
 		},
+		&upgradeStep{
+			description: "add reference counts for resource content",
+			targets:     []Target{DatabaseMaster},
+			run: func(context Context) error {
+				return state.AddResourceContentRefCounts(context.State())
+			},
+		},
 	}
 }
@@ -0,0 +1,189 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package cron parses cron-style schedules and works out when they
+// next fire.
+package cron
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+)
+
+// shortcuts holds the named schedules that may be used in place of
+// the five schedule fields.
+var shortcuts = map[string]string{
+	"@hourly":  "0 * * * *",
+	"@daily":   "0 0 * * *",
+	"@weekly":  "0 0 * * 0",
+	"@monthly": "0 0 1 * *",
+}
+
+// field describes the range of values accepted by a schedule field.
+type field struct {
+	name     string
+	min, max int
+}
+
+var fields = []field{
+	{"minute", 0, 59},
+	{"hour", 0, 23},
+	{"day of month", 1, 31},
+	{"month", 1, 12},
+	{"day of week", 0, 7},
+}
+
+// Schedule is a parsed cron-style schedule.
+type Schedule struct {
+	spec string
+
+	// minute, hour, dom, month and dow hold, as bit sets, the values
+	// of each field at which the schedule fires.
+	minute, hour, dom, month, dow uint64
+
+	// domAny and dowAny record whether the day of month and day of
+	// week fields were "*". As with cron, when both are restricted a
+	// day matches if either of them does.
+	domAny, dowAny bool
+}
+
+// Parse parses a schedule made of five space-separated fields,
+// minute, hour, day of month, month and day of week, as used by
+// cron. Each field is "*" or a comma-separated list of values or
+// ranges such as "1-5", optionally followed by a step such as "/15".
+// Both 0 and 7 mean Sunday. The shortcuts @hourly, @daily, @weekly
+// and @monthly are also accepted.
+func Parse(spec string) (*Schedule, error) {
+	expanded := strings.TrimSpace(spec)
+	if s, ok := shortcuts[expanded]; ok {
+		expanded = s
+	}
+	parts := strings.Fields(expanded)
+	if len(parts) != len(fields) {
+		return nil, errors.NotValidf("schedule %q: expected %d fields, got %d", spec, len(fields), len(parts))
+	}
+	var sets [5]uint64
+	for i, part := range parts {
+		set, err := parseField(part, fields[i])
+		if err != nil {
+			return nil, errors.Annotatef(err, "schedule %q", spec)
+		}
+		sets[i] = set
+	}
+	// Fold Sunday-as-7 onto Sunday-as-0.
+	if sets[4]&(1<<7) != 0 {
+		sets[4] |= 1
+	}
+	return &Schedule{
+		spec:   spec,
+		minute: sets[0],
+		hour:   sets[1],
+		dom:    sets[2],
+		month:  sets[3],
+		dow:    sets[4],
+		domAny: parts[2] == "*",
+		dowAny: parts[4] == "*",
+	}, nil
+}
+
+// parseField returns the bit set of values matched by the given
+// field of a schedule.
+func parseField(part string, f field) (uint64, error) {
+	var set uint64
+	for _, item := range strings.Split(part, ",") {
+		rangePart, step := item, 1
+		if i := strings.Index(item, "/"); i >= 0 {
+			var err error
+			rangePart = item[:i]
+			step, err = strconv.Atoi(item[i+1:])
+			if err != nil || step <= 0 {
+				return 0, errors.NotValidf("%s step %q", f.name, item[i+1:])
+			}
+		}
+		low, high := f.min, f.max
+		if rangePart != "*" {
+			bounds := strings.SplitN(rangePart, "-", 2)
+			var err error
+			if low, err = parseValue(bounds[0], f); err != nil {
+				return 0, errors.Trace(err)
+			}
+			high = low
+			if len(bounds) == 2 {
+				if high, err = parseValue(bounds[1], f); err != nil {
+					return 0, errors.Trace(err)
+				}
+			} else if step != 1 {
+				// "5/15" means every 15 starting from 5.
+				high = f.max
+			}
+			if high < low {
+				return 0, errors.NotValidf("%s range %q", f.name, rangePart)
+			}
+		}
+		for v := low; v <= high; v += step {
+			set |= 1 << uint(v)
+		}
+	}
+	return set, nil
+}
+
+func parseValue(s string, f field) (int, error) {
+	v, err := strconv.Atoi(s)
+	if err != nil || v < f.min || v > f.max {
+		return 0, errors.NotValidf("%s %q", f.name, s)
+	}
+	return v, nil
+}
+
+// String returns the specification the schedule was parsed from.
+func (s *Schedule) String() string {
+	return s.spec
+}
+
+// dayMatches reports whether the schedule fires on the day of t.
+func (s *Schedule) dayMatches(t time.Time) bool {
+	domMatch := s.dom&(1<<uint(t.Day())) != 0
+	dowMatch := s.dow&(1<<uint(t.Weekday())) != 0
+	switch {
+	case s.domAny && s.dowAny:
+		return true
+	case s.domAny:
+		return dowMatch
+	case s.dowAny:
+		return domMatch
+	}
+	return domMatch || dowMatch
+}
+
+// maxSearch bounds the search for the next firing time, so that
+// schedules that can never fire, such as "0 0 31 2 *", do not loop
+// forever.
+const maxSearch = 5 * 366 * 24 * time.Hour
+
+// Next returns the first time after t at which the schedule fires,
+// interpreting the schedule in t's location. It returns the zero
+// time if the schedule never fires.
+func (s *Schedule) Next(t time.Time) time.Time {
+	loc := t.Location()
+	next := t.Truncate(time.Minute).Add(time.Minute)
+	limit := next.Add(maxSearch)
+	for next.Before(limit) {
+		year, month, day := next.Date()
+		switch {
+		case s.month&(1<<uint(month)) == 0:
+			next = time.Date(year, month+1, 1, 0, 0, 0, 0, loc)
+		case !s.dayMatches(next):
+			next = time.Date(year, month, day+1, 0, 0, 0, 0, loc)
+		case s.hour&(1<<uint(next.Hour())) == 0:
+			next = time.Date(year, month, day, next.Hour()+1, 0, 0, 0, loc)
+		case s.minute&(1<<uint(next.Minute())) == 0:
+			next = next.Add(time.Minute)
+		default:
+			return next
+		}
+	}
+	return time.Time{}
+}
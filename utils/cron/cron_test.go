@@ -0,0 +1,108 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package cron_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/utils/cron"
+)
+
+type cronSuite struct{}
+
+var _ = gc.Suite(&cronSuite{})
+
+// start is a Friday.
+var start = time.Date(2016, 4, 1, 12, 34, 56, 0, time.UTC)
+
+func (s *cronSuite) TestNext(c *gc.C) {
+	for i, test := range []struct {
+		spec string
+		next time.Time
+	}{{
+		spec: "* * * * *",
+		next: time.Date(2016, 4, 1, 12, 35, 0, 0, time.UTC),
+	}, {
+		spec: "*/15 * * * *",
+		next: time.Date(2016, 4, 1, 12, 45, 0, 0, time.UTC),
+	}, {
+		spec: "30 3 * * *",
+		next: time.Date(2016, 4, 2, 3, 30, 0, 0, time.UTC),
+	}, {
+		spec: "@daily",
+		next: time.Date(2016, 4, 2, 0, 0, 0, 0, time.UTC),
+	}, {
+		spec: "@hourly",
+		next: time.Date(2016, 4, 1, 13, 0, 0, 0, time.UTC),
+	}, {
+		spec: "@weekly",
+		next: time.Date(2016, 4, 3, 0, 0, 0, 0, time.UTC),
+	}, {
+		spec: "0 2 * * 7",
+		next: time.Date(2016, 4, 3, 2, 0, 0, 0, time.UTC),
+	}, {
+		spec: "0 9 * * 1-5",
+		next: time.Date(2016, 4, 4, 9, 0, 0, 0, time.UTC),
+	}, {
+		spec: "0 0 15 * 1",
+		next: time.Date(2016, 4, 4, 0, 0, 0, 0, time.UTC),
+	}, {
+		spec: "0 0 29 2 *",
+		next: time.Date(2020, 2, 29, 0, 0, 0, 0, time.UTC),
+	}, {
+		spec: "5,35 12 1 4 *",
+		next: time.Date(2016, 4, 1, 12, 35, 0, 0, time.UTC),
+	}, {
+		spec: "0 0 31 2 *",
+		next: time.Time{},
+	}} {
+		c.Logf("test %d: %q", i, test.spec)
+		schedule, err := cron.Parse(test.spec)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(schedule.String(), gc.Equals, test.spec)
+		c.Check(schedule.Next(start), gc.Equals, test.next)
+	}
+}
+
+func (s *cronSuite) TestNextIsStrictlyLater(c *gc.C) {
+	schedule, err := cron.Parse("0 * * * *")
+	c.Assert(err, jc.ErrorIsNil)
+	at := time.Date(2016, 4, 1, 12, 0, 0, 0, time.UTC)
+	c.Assert(schedule.Next(at), gc.Equals, at.Add(time.Hour))
+}
+
+func (s *cronSuite) TestParseInvalid(c *gc.C) {
+	for i, test := range []struct {
+		spec string
+		err  string
+	}{{
+		spec: "",
+		err:  `schedule "": expected 5 fields, got 0 not valid`,
+	}, {
+		spec: "* * * *",
+		err:  `schedule "\* \* \* \*": expected 5 fields, got 4 not valid`,
+	}, {
+		spec: "60 * * * *",
+		err:  `schedule "60 \* \* \* \*": minute "60" not valid`,
+	}, {
+		spec: "* * 0 * *",
+		err:  `schedule "\* \* 0 \* \*": day of month "0" not valid`,
+	}, {
+		spec: "* 5-2 * * *",
+		err:  `schedule "\* 5-2 \* \* \*": hour range "5-2" not valid`,
+	}, {
+		spec: "*/0 * * * *",
+		err:  `schedule "\*/0 \* \* \* \*": minute step "0" not valid`,
+	}, {
+		spec: "* * * jan *",
+		err:  `schedule "\* \* \* jan \*": month "jan" not valid`,
+	}} {
+		c.Logf("test %d: %q", i, test.spec)
+		_, err := cron.Parse(test.spec)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
@@ -0,0 +1,59 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionpruner
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/worker"
+)
+
+// Facade represents an API that implements action history pruning.
+type Facade interface {
+	ModelConfig() (*config.Config, error)
+	Prune(maxAge time.Duration, maxCount int) error
+}
+
+// Config holds all necessary attributes to start a pruner worker.
+type Config struct {
+	Facade        Facade
+	PruneInterval time.Duration
+	NewTimer      worker.NewTimerFunc
+}
+
+// Validate will err unless basic requirements for a valid
+// config are met.
+func (c *Config) Validate() error {
+	if c.Facade == nil {
+		return errors.New("missing Facade")
+	}
+	if c.NewTimer == nil {
+		return errors.New("missing Timer")
+	}
+	return nil
+}
+
+// New returns a worker.Worker that periodically prunes the records of
+// finished actions, according to the limits in the model config.
+func New(conf Config) (worker.Worker, error) {
+	if err := conf.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	doPruning := func(stop <-chan struct{}) error {
+		modelConfig, err := conf.Facade.ModelConfig()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		err = conf.Facade.Prune(modelConfig.ActionsMaxAge(), modelConfig.ActionsMaxCount())
+		if err != nil {
+			return errors.Trace(err)
+		}
+		return nil
+	}
+
+	return worker.NewPeriodicWorker(doPruning, conf.PruneInterval, conf.NewTimer), nil
+}
@@ -0,0 +1,158 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionpruner_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/environs/config"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker"
+	"github.com/juju/juju/worker/actionpruner"
+)
+
+type actionPrunerSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&actionPrunerSuite{})
+
+func (s *actionPrunerSuite) TestWorkerCallsPrune(c *gc.C) {
+	fakeTimer := newMockTimer()
+	fakeTimerFunc := func(d time.Duration) worker.PeriodicTimer {
+		// The pruner runs once before waiting.
+		c.Assert(d, gc.Equals, 0*time.Nanosecond)
+		return fakeTimer
+	}
+	facade := newFakeFacade(coretesting.CustomModelConfig(c, coretesting.Attrs{
+		"actions-max-age":   "24h",
+		"actions-max-count": 50,
+	}))
+	pruner, err := actionpruner.New(actionpruner.Config{
+		Facade:        facade,
+		PruneInterval: coretesting.ShortWait,
+		NewTimer:      fakeTimerFunc,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddCleanup(func(*gc.C) {
+		c.Assert(worker.Stop(pruner), jc.ErrorIsNil)
+	})
+
+	err = fakeTimer.fire()
+	c.Assert(err, jc.ErrorIsNil)
+
+	select {
+	case args := <-facade.pruned:
+		c.Assert(args, jc.DeepEquals, pruneArgs{24 * time.Hour, 50})
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for pruner to prune")
+	}
+
+	select {
+	case period := <-fakeTimer.period:
+		c.Assert(period, gc.Equals, coretesting.ShortWait)
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for period reset by pruner")
+	}
+}
+
+func (s *actionPrunerSuite) TestWorkerUsesDefaults(c *gc.C) {
+	fakeTimer := newMockTimer()
+	facade := newFakeFacade(coretesting.ModelConfig(c))
+	pruner, err := actionpruner.New(actionpruner.Config{
+		Facade:        facade,
+		PruneInterval: coretesting.ShortWait,
+		NewTimer: func(time.Duration) worker.PeriodicTimer {
+			return fakeTimer
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddCleanup(func(*gc.C) {
+		c.Assert(worker.Stop(pruner), jc.ErrorIsNil)
+	})
+
+	err = fakeTimer.fire()
+	c.Assert(err, jc.ErrorIsNil)
+
+	select {
+	case args := <-facade.pruned:
+		c.Assert(args, jc.DeepEquals, pruneArgs{config.DefaultActionsMaxAge, config.DefaultActionsMaxCount})
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for pruner to prune")
+	}
+}
+
+func (s *actionPrunerSuite) TestValidate(c *gc.C) {
+	_, err := actionpruner.New(actionpruner.Config{})
+	c.Assert(err, gc.ErrorMatches, "missing Facade")
+}
+
+type mockTimer struct {
+	period chan time.Duration
+	c      chan time.Time
+}
+
+func newMockTimer() *mockTimer {
+	return &mockTimer{
+		period: make(chan time.Duration, 1),
+		c:      make(chan time.Time),
+	}
+}
+
+func (t *mockTimer) Reset(d time.Duration) bool {
+	select {
+	case t.period <- d:
+	default:
+	}
+	return true
+}
+
+func (t *mockTimer) CountDown() <-chan time.Time {
+	return t.c
+}
+
+func (t *mockTimer) fire() error {
+	select {
+	case t.c <- time.Time{}:
+	case <-time.After(coretesting.LongWait):
+		return errors.New("timed out waiting for pruner to run")
+	}
+	return nil
+}
+
+type pruneArgs struct {
+	maxAge   time.Duration
+	maxCount int
+}
+
+type fakeFacade struct {
+	config *config.Config
+	pruned chan pruneArgs
+}
+
+func newFakeFacade(cfg *config.Config) *fakeFacade {
+	return &fakeFacade{
+		config: cfg,
+		pruned: make(chan pruneArgs, 1),
+	}
+}
+
+// ModelConfig implements Facade.
+func (f *fakeFacade) ModelConfig() (*config.Config, error) {
+	return f.config, nil
+}
+
+// Prune implements Facade.
+func (f *fakeFacade) Prune(maxAge time.Duration, maxCount int) error {
+	select {
+	case f.pruned <- pruneArgs{maxAge, maxCount}:
+	case <-time.After(coretesting.LongWait):
+		return errors.New("timed out waiting for facade call Prune to run")
+	}
+	return nil
+}
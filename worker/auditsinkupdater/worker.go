@@ -0,0 +1,110 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package auditsinkupdater provides a worker that keeps the sinks to
+// which the API server sends audit entries in line with the model
+// configuration.
+package auditsinkupdater
+
+import (
+	"path/filepath"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+
+	"github.com/juju/juju/audit"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/watcher/legacy"
+	"github.com/juju/juju/worker"
+)
+
+var logger = loggo.GetLogger("juju.worker.auditsinkupdater")
+
+// webhookBufferDir is the directory, relative to the agent's data
+// directory, in which entries waiting to be sent to the audit webhook
+// are buffered.
+const webhookBufferDir = "audit-webhook"
+
+// auditLogDir is the directory, relative to the agent's log
+// directory, in which the audit log file is written. Only the file
+// name comes from the model config, so that it cannot be used to
+// write anywhere else.
+const auditLogDir = "audit"
+
+// ModelConfigWatcher is an interface that is provided to New, which
+// can be used to watch for changes to the model configuration.
+type ModelConfigWatcher interface {
+	WatchForModelConfigChanges() state.NotifyWatcher
+	ModelConfig() (*config.Config, error)
+}
+
+// updater implements legacy.NotifyWatchHandler.
+type updater struct {
+	st      ModelConfigWatcher
+	fanout  *audit.Fanout
+	dataDir string
+	logDir  string
+	current *audit.SinkConfig
+}
+
+// New returns a worker.Worker that sets the sinks of the given fanout
+// according to the audit settings in the controller model's
+// configuration, each time they change.
+func New(st ModelConfigWatcher, fanout *audit.Fanout, dataDir, logDir string) worker.Worker {
+	return legacy.NewNotifyWorker(&updater{
+		st:      st,
+		fanout:  fanout,
+		dataDir: dataDir,
+		logDir:  logDir,
+	})
+}
+
+// SetUp is defined on the NotifyWatchHandler interface.
+func (u *updater) SetUp() (state.NotifyWatcher, error) {
+	return u.st.WatchForModelConfigChanges(), nil
+}
+
+// Handle is defined on the NotifyWatchHandler interface.
+func (u *updater) Handle(_ <-chan struct{}) error {
+	cfg, err := u.st.ModelConfig()
+	if err != nil {
+		return errors.Annotate(err, "cannot read model config")
+	}
+	sinkConfig := u.sinkConfig(cfg)
+	if u.current != nil && *u.current == sinkConfig {
+		return nil
+	}
+	sinks, err := audit.NewSinks(sinkConfig)
+	if err != nil {
+		// A bad setting must not stop the worker, nor lose
+		// the sinks that were already working.
+		logger.Errorf("cannot update audit sinks: %v", err)
+		return nil
+	}
+	u.fanout.SetSinks(sinks...)
+	u.current = &sinkConfig
+	logger.Infof("audit sinks updated: %d sink(s) configured", len(sinks))
+	return nil
+}
+
+// TearDown is defined on the NotifyWatchHandler interface.
+func (u *updater) TearDown() error {
+	u.fanout.SetSinks()
+	return nil
+}
+
+func (u *updater) sinkConfig(cfg *config.Config) audit.SinkConfig {
+	var sinkConfig audit.SinkConfig
+	if name, ok := cfg.AuditLogFile(); ok {
+		sinkConfig.FilePath = filepath.Join(u.logDir, auditLogDir, name)
+		sinkConfig.FileMaxSizeMB = cfg.AuditLogMaxSize()
+		sinkConfig.FileMaxBackups = cfg.AuditLogMaxBackups()
+	}
+	sinkConfig.Syslog = cfg.AuditSyslog()
+	if url, ok := cfg.AuditWebhookURL(); ok {
+		sinkConfig.WebhookURL = url
+		sinkConfig.WebhookBufferDir = filepath.Join(u.dataDir, webhookBufferDir)
+	}
+	return sinkConfig
+}
@@ -0,0 +1,173 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditsinkupdater_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/audit"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker"
+	"github.com/juju/juju/worker/auditsinkupdater"
+)
+
+type WorkerSuite struct {
+	coretesting.BaseSuite
+	st      *mockState
+	fanout  *audit.Fanout
+	logDir  string
+	logPath string
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+func (s *WorkerSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.st = &mockState{
+		changes: make(chan struct{}),
+		cfg:     coretesting.ModelConfig(c),
+	}
+	s.fanout = audit.NewFanout()
+	s.logDir = c.MkDir()
+	s.logPath = filepath.Join(s.logDir, "audit", "audit.log")
+}
+
+type mockNotifyWatcher struct {
+	changes <-chan struct{}
+}
+
+func (w *mockNotifyWatcher) Changes() <-chan struct{} { return w.changes }
+func (*mockNotifyWatcher) Stop() error                { return nil }
+func (*mockNotifyWatcher) Kill()                      {}
+func (*mockNotifyWatcher) Wait() error                { return nil }
+func (*mockNotifyWatcher) Err() error                 { return nil }
+
+type mockState struct {
+	changes chan struct{}
+
+	mu  sync.Mutex
+	cfg *config.Config
+}
+
+func (m *mockState) WatchForModelConfigChanges() state.NotifyWatcher {
+	return &mockNotifyWatcher{m.changes}
+}
+
+func (m *mockState) ModelConfig() (*config.Config, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.cfg, nil
+}
+
+func (m *mockState) setConfig(c *gc.C, attrs coretesting.Attrs) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	cfg, err := m.cfg.Apply(attrs)
+	c.Assert(err, jc.ErrorIsNil)
+	m.cfg = cfg
+}
+
+func (m *mockState) removeConfig(c *gc.C, attrs ...string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	cfg, err := m.cfg.Remove(attrs)
+	c.Assert(err, jc.ErrorIsNil)
+	m.cfg = cfg
+}
+
+// notify sends a change to the worker. Since the changes channel is
+// unbuffered, a subsequent notify only succeeds once the worker has
+// finished handling this one.
+func (s *WorkerSuite) notify(c *gc.C) {
+	select {
+	case s.st.changes <- struct{}{}:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out sending change")
+	}
+}
+
+// update tells the worker that the config has changed, and waits for
+// the change to be handled.
+func (s *WorkerSuite) update(c *gc.C) {
+	s.notify(c)
+	s.notify(c)
+}
+
+func (s *WorkerSuite) startWorker(c *gc.C) worker.Worker {
+	return auditsinkupdater.New(s.st, s.fanout, c.MkDir(), s.logDir)
+}
+
+func (s *WorkerSuite) put(c *gc.C, method string) {
+	err := s.fanout.Put(audit.AuditEntry{
+		Timestamp: time.Now(),
+		OriginTag: "user-bob",
+		Facade:    "Service",
+		Version:   3,
+		Method:    method,
+		Outcome:   audit.OutcomeSucceeded,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *WorkerSuite) loggedMethods(c *gc.C) []string {
+	data, err := ioutil.ReadFile(s.logPath)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	c.Assert(err, jc.ErrorIsNil)
+	var methods []string
+	for _, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+		for _, method := range []string{"Deploy", "Expose", "Destroy"} {
+			if strings.Contains(line, `"method":"`+method+`"`) {
+				methods = append(methods, method)
+			}
+		}
+	}
+	return methods
+}
+
+func (s *WorkerSuite) TestFileSinkConfigured(c *gc.C) {
+	w := s.startWorker(c)
+	defer func() { c.Assert(worker.Stop(w), jc.ErrorIsNil) }()
+
+	s.st.setConfig(c, coretesting.Attrs{"audit-log-file": "audit.log"})
+	s.update(c)
+	s.put(c, "Deploy")
+	c.Check(s.loggedMethods(c), jc.DeepEquals, []string{"Deploy"})
+}
+
+func (s *WorkerSuite) TestSinkRemoved(c *gc.C) {
+	w := s.startWorker(c)
+	defer func() { c.Assert(worker.Stop(w), jc.ErrorIsNil) }()
+
+	s.st.setConfig(c, coretesting.Attrs{"audit-log-file": "audit.log"})
+	s.update(c)
+	s.put(c, "Deploy")
+
+	s.st.removeConfig(c, "audit-log-file")
+	s.update(c)
+	s.put(c, "Expose")
+	c.Check(s.loggedMethods(c), jc.DeepEquals, []string{"Deploy"})
+}
+
+func (s *WorkerSuite) TestSinksClosedOnStop(c *gc.C) {
+	w := s.startWorker(c)
+	s.st.setConfig(c, coretesting.Attrs{"audit-log-file": "audit.log"})
+	s.update(c)
+	s.put(c, "Deploy")
+
+	c.Assert(worker.Stop(w), jc.ErrorIsNil)
+	s.put(c, "Destroy")
+	c.Check(s.loggedMethods(c), jc.DeepEquals, []string{"Deploy"})
+}
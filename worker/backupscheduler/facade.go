@@ -0,0 +1,93 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backupscheduler
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/replicaset"
+
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/backups"
+)
+
+// NewFacade returns a Facade that creates backups of the controller
+// from the given state, in the same way as the backups API does.
+func NewFacade(st *state.State, paths backups.Paths, machineID string) Facade {
+	return &stateFacade{
+		st:        st,
+		paths:     paths,
+		machineID: machineID,
+	}
+}
+
+type stateFacade struct {
+	st        *state.State
+	paths     backups.Paths
+	machineID string
+}
+
+// ModelConfig implements Facade.
+func (f *stateFacade) ModelConfig() (*config.Config, error) {
+	return f.st.ModelConfig()
+}
+
+// List implements Facade.
+func (f *stateFacade) List() ([]*backups.Metadata, error) {
+	stor := backups.NewStorage(f.st)
+	defer stor.Close()
+	list, err := backups.NewBackups(stor).List()
+	return list, errors.Trace(err)
+}
+
+// Remove implements Facade.
+func (f *stateFacade) Remove(id string) error {
+	stor := backups.NewStorage(f.st)
+	defer stor.Close()
+	return errors.Trace(backups.NewBackups(stor).Remove(id))
+}
+
+// Create implements Facade.
+func (f *stateFacade) Create(parentID string) (*backups.Metadata, error) {
+	stor := backups.NewStorage(f.st)
+	defer stor.Close()
+
+	session := f.st.MongoSession().Copy()
+	defer session.Close()
+
+	// Don't go if HA isn't ready; the next check will try again.
+	if err := replicaset.WaitUntilReady(session, 60); err != nil {
+		return nil, errors.Annotatef(err, "HA not ready")
+	}
+	dbInfo, err := backups.NewDBInfo(f.st.MongoConnectionInfo(), session)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	dbInfo.Oplog, err = backups.NewOplogRange(session)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	meta, err := backups.NewMetadataState(f.st, f.machineID)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	meta.Scheduled = true
+	// Scheduled backups are neither encrypted nor signed, since the
+	// controller does not hold a key to encrypt them with.
+	keys, err := backups.NewArchiveKeys(f.st, "")
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	b := backups.NewBackups(stor)
+	if parentID == "" {
+		err = b.Create(meta, &f.paths, dbInfo, keys)
+	} else {
+		err = b.CreateIncremental(meta, &f.paths, dbInfo, parentID, keys)
+	}
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return meta, nil
+}
@@ -0,0 +1,167 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backupscheduler
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/utils/clock"
+
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/state/backups"
+	"github.com/juju/juju/worker"
+)
+
+var logger = loggo.GetLogger("juju.worker.backupscheduler")
+
+// Facade represents an API that creates and removes scheduled backups.
+type Facade interface {
+	ModelConfig() (*config.Config, error)
+	List() ([]*backups.Metadata, error)
+	// Create creates a new scheduled backup. If parentID is not empty,
+	// the backup holds only the changes made since the identified
+	// backup.
+	Create(parentID string) (*backups.Metadata, error)
+	Remove(id string) error
+}
+
+// Config holds all necessary attributes to start a scheduler worker.
+type Config struct {
+	Facade        Facade
+	Clock         clock.Clock
+	CheckInterval time.Duration
+	NewTimer      worker.NewTimerFunc
+}
+
+// Validate will err unless basic requirements for a valid
+// config are met.
+func (c *Config) Validate() error {
+	if c.Facade == nil {
+		return errors.New("missing Facade")
+	}
+	if c.Clock == nil {
+		return errors.New("missing Clock")
+	}
+	if c.NewTimer == nil {
+		return errors.New("missing Timer")
+	}
+	return nil
+}
+
+// New returns a worker.Worker that creates backups on the schedules
+// in the model config, and removes the scheduled backups that the
+// config's retention policy no longer keeps.
+func New(conf Config) (worker.Worker, error) {
+	if err := conf.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	s := &scheduler{
+		facade:  conf.Facade,
+		clock:   conf.Clock,
+		started: conf.Clock.Now(),
+	}
+	return worker.NewPeriodicWorker(s.run, conf.CheckInterval, conf.NewTimer), nil
+}
+
+type scheduler struct {
+	facade Facade
+	clock  clock.Clock
+	// started is used in place of the time of the last scheduled full
+	// backup until there is one, so that the first backup is taken
+	// when the schedule next fires rather than immediately.
+	started time.Time
+}
+
+func (s *scheduler) run(stop <-chan struct{}) error {
+	modelConfig, err := s.facade.ModelConfig()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	list, err := s.facade.List()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := s.backUp(modelConfig, list); err != nil {
+		return errors.Trace(err)
+	}
+	policy := backups.RetentionPolicy{
+		KeepDaily:  modelConfig.BackupKeepDaily(),
+		KeepWeekly: modelConfig.BackupKeepWeekly(),
+	}
+	if list, err = s.facade.List(); err != nil {
+		return errors.Trace(err)
+	}
+	for _, meta := range backups.Expired(list, policy) {
+		logger.Infof("removing expired backup %q", meta.ID())
+		if err := s.facade.Remove(meta.ID()); err != nil {
+			return errors.Annotatef(err, "removing backup %q", meta.ID())
+		}
+	}
+	return nil
+}
+
+// backUp creates a full backup if one is due on the backup schedule,
+// or else an incremental backup if one is due on the incremental
+// backup schedule. Incremental backups build on the newest scheduled
+// backup; if that fails, a full backup is created instead.
+func (s *scheduler) backUp(modelConfig *config.Config, list []*backups.Metadata) error {
+	var lastFull, last *backups.Metadata
+	for _, meta := range list {
+		if !meta.Scheduled || meta.Finished == nil {
+			continue
+		}
+		if last == nil || meta.Started.After(last.Started) {
+			last = meta
+		}
+		if !meta.Incremental() && (lastFull == nil || meta.Started.After(lastFull.Started)) {
+			lastFull = meta
+		}
+	}
+	now := s.clock.Now()
+
+	if schedule, ok := modelConfig.BackupSchedule(); ok {
+		since := s.started
+		if lastFull != nil {
+			since = lastFull.Started
+		}
+		if due(schedule.Next(since), now) {
+			return errors.Trace(s.create(""))
+		}
+	}
+	if schedule, ok := modelConfig.BackupIncrementalSchedule(); ok && last != nil {
+		if !due(schedule.Next(last.Started), now) {
+			return nil
+		}
+		if last.OplogEnd == 0 {
+			logger.Infof("backup %q has no oplog position; creating a full backup", last.ID())
+			return errors.Trace(s.create(""))
+		}
+		if err := s.create(last.ID()); err != nil {
+			logger.Warningf("cannot create incremental backup: %v; creating a full backup", err)
+			return errors.Trace(s.create(""))
+		}
+	}
+	return nil
+}
+
+func (s *scheduler) create(parentID string) error {
+	meta, err := s.facade.Create(parentID)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if parentID == "" {
+		logger.Infof("created scheduled backup %q", meta.ID())
+	} else {
+		logger.Infof("created scheduled incremental backup %q on %q", meta.ID(), parentID)
+	}
+	return nil
+}
+
+// due reports whether a schedule that next fires at next is due at now.
+// A schedule that never fires has a zero next time and is never due.
+func due(next, now time.Time) bool {
+	return !next.IsZero() && !next.After(now)
+}
@@ -0,0 +1,219 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backupscheduler_test
+
+import (
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/state/backups"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker"
+	"github.com/juju/juju/worker/backupscheduler"
+)
+
+type schedulerSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&schedulerSuite{})
+
+// midnight is the start of Friday the 1st of April 2016.
+var midnight = time.Date(2016, 4, 1, 0, 0, 0, 0, time.UTC)
+
+// runOnce starts a scheduler at the given time, lets it check its
+// schedules once, and returns the calls made to the facade.
+func (s *schedulerSuite) runOnce(c *gc.C, facade *fakeFacade, now time.Time) []string {
+	fakeTimer := newMockTimer()
+	scheduler, err := backupscheduler.New(backupscheduler.Config{
+		Facade:        facade,
+		Clock:         coretesting.NewClock(now),
+		CheckInterval: coretesting.ShortWait,
+		NewTimer: func(time.Duration) worker.PeriodicTimer {
+			return fakeTimer
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	defer func() {
+		c.Assert(worker.Stop(scheduler), jc.ErrorIsNil)
+	}()
+
+	err = fakeTimer.fire()
+	c.Assert(err, jc.ErrorIsNil)
+	select {
+	case <-fakeTimer.period:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for scheduler to check its schedules")
+	}
+	return facade.Calls()
+}
+
+func (s *schedulerSuite) newFacade(c *gc.C, attrs coretesting.Attrs, list ...*backups.Metadata) *fakeFacade {
+	return &fakeFacade{
+		config: coretesting.CustomModelConfig(c, attrs),
+		list:   list,
+	}
+}
+
+func (s *schedulerSuite) TestFullBackupDue(c *gc.C) {
+	facade := s.newFacade(c, coretesting.Attrs{
+		"backup-schedule": "@daily",
+	}, newBackup("yesterday", midnight.Add(-24*time.Hour), ""))
+	calls := s.runOnce(c, facade, midnight.Add(30*time.Minute))
+	c.Check(calls, jc.DeepEquals, []string{"List", "Create()", "List"})
+}
+
+func (s *schedulerSuite) TestFirstFullBackupWaitsForSchedule(c *gc.C) {
+	facade := s.newFacade(c, coretesting.Attrs{
+		"backup-schedule": "@daily",
+	})
+	calls := s.runOnce(c, facade, midnight.Add(30*time.Minute))
+	c.Check(calls, jc.DeepEquals, []string{"List", "List"})
+}
+
+func (s *schedulerSuite) TestIncrementalBackupDue(c *gc.C) {
+	facade := s.newFacade(c, coretesting.Attrs{
+		"backup-schedule":             "@daily",
+		"backup-incremental-schedule": "@hourly",
+	},
+		newBackup("full", midnight, ""),
+		newBackup("inc", midnight.Add(time.Hour), "full"),
+	)
+	calls := s.runOnce(c, facade, midnight.Add(150*time.Minute))
+	c.Check(calls, jc.DeepEquals, []string{"List", "Create(inc)", "List"})
+}
+
+func (s *schedulerSuite) TestIncrementalBackupNotDue(c *gc.C) {
+	facade := s.newFacade(c, coretesting.Attrs{
+		"backup-incremental-schedule": "@hourly",
+	}, newBackup("full", midnight, ""))
+	calls := s.runOnce(c, facade, midnight.Add(30*time.Minute))
+	c.Check(calls, jc.DeepEquals, []string{"List", "List"})
+}
+
+func (s *schedulerSuite) TestIncrementalBackupFallsBackToFull(c *gc.C) {
+	facade := s.newFacade(c, coretesting.Attrs{
+		"backup-incremental-schedule": "@hourly",
+	}, newBackup("full", midnight, ""))
+	facade.createErr = errors.New("oplog rolled over")
+	calls := s.runOnce(c, facade, midnight.Add(90*time.Minute))
+	c.Check(calls, jc.DeepEquals, []string{"List", "Create(full)", "Create()", "List"})
+}
+
+func (s *schedulerSuite) TestRemovesExpiredBackups(c *gc.C) {
+	facade := s.newFacade(c, coretesting.Attrs{
+		"backup-keep-daily":  1,
+		"backup-keep-weekly": 0,
+	},
+		newBackup("old", midnight.Add(-24*time.Hour), ""),
+		newBackup("new", midnight, ""),
+	)
+	calls := s.runOnce(c, facade, midnight.Add(30*time.Minute))
+	c.Check(calls, jc.DeepEquals, []string{"List", "List", "Remove(old)"})
+}
+
+func (s *schedulerSuite) TestValidate(c *gc.C) {
+	_, err := backupscheduler.New(backupscheduler.Config{})
+	c.Assert(err, gc.ErrorMatches, "missing Facade")
+}
+
+func newBackup(id string, started time.Time, parent string) *backups.Metadata {
+	meta := backups.NewMetadata()
+	meta.SetID(id)
+	meta.Started = started
+	meta.Finished = &started
+	meta.Parent = parent
+	meta.Scheduled = true
+	meta.OplogEnd = started.Unix() << 32
+	return meta
+}
+
+type mockTimer struct {
+	period chan time.Duration
+	c      chan time.Time
+}
+
+func newMockTimer() *mockTimer {
+	return &mockTimer{
+		period: make(chan time.Duration, 1),
+		c:      make(chan time.Time),
+	}
+}
+
+func (t *mockTimer) Reset(d time.Duration) bool {
+	select {
+	case t.period <- d:
+	default:
+	}
+	return true
+}
+
+func (t *mockTimer) CountDown() <-chan time.Time {
+	return t.c
+}
+
+func (t *mockTimer) fire() error {
+	select {
+	case t.c <- time.Time{}:
+	case <-time.After(coretesting.LongWait):
+		return errors.New("timed out waiting for scheduler to run")
+	}
+	return nil
+}
+
+type fakeFacade struct {
+	config    *config.Config
+	list      []*backups.Metadata
+	createErr error
+
+	mu    sync.Mutex
+	calls []string
+}
+
+func (f *fakeFacade) addCall(call string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls = append(f.calls, call)
+}
+
+// Calls returns the names of the facade calls made that change or
+// list backups, in order.
+func (f *fakeFacade) Calls() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]string(nil), f.calls...)
+}
+
+// ModelConfig implements Facade.
+func (f *fakeFacade) ModelConfig() (*config.Config, error) {
+	return f.config, nil
+}
+
+// List implements Facade.
+func (f *fakeFacade) List() ([]*backups.Metadata, error) {
+	f.addCall("List")
+	return f.list, nil
+}
+
+// Create implements Facade.
+func (f *fakeFacade) Create(parentID string) (*backups.Metadata, error) {
+	f.addCall("Create(" + parentID + ")")
+	if parentID != "" && f.createErr != nil {
+		return nil, f.createErr
+	}
+	meta := backups.NewMetadata()
+	meta.SetID("new-backup")
+	return meta, nil
+}
+
+// Remove implements Facade.
+func (f *fakeFacade) Remove(id string) error {
+	f.addCall("Remove(" + id + ")")
+	return nil
+}
@@ -0,0 +1,28 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package blockexpirer
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/worker"
+)
+
+// BlockRemover defines the interface for types capable of removing
+// the blocks that have expired.
+type BlockRemover interface {
+	RemoveExpiredBlocks(now time.Time) error
+}
+
+// New returns a worker which periodically lifts the blocks, in every
+// model, that have expired.
+func New(br BlockRemover, interval time.Duration) worker.Worker {
+	f := func(stop <-chan struct{}) error {
+		err := br.RemoveExpiredBlocks(time.Now())
+		return errors.Annotate(err, "cannot remove expired blocks")
+	}
+	return worker.NewPeriodicWorker(f, interval, worker.NewTimer)
+}
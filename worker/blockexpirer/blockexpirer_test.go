@@ -0,0 +1,76 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package blockexpirer_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/blockexpirer"
+)
+
+type BlockExpirerSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&BlockExpirerSuite{})
+
+func (s *BlockExpirerSuite) TestRemovesExpiredBlocks(c *gc.C) {
+	remover := newFakeBlockRemover(nil)
+	w := blockexpirer.New(remover, 10*time.Millisecond)
+	defer w.Kill()
+
+	var last time.Time
+	for i := 0; i < 3; i++ {
+		select {
+		case now := <-remover.removeCh:
+			c.Assert(now.After(last), jc.IsTrue)
+			last = now
+		case <-time.After(testing.LongWait):
+			c.Fatal("timed out waiting for expired blocks to be removed")
+		}
+	}
+}
+
+func (s *BlockExpirerSuite) TestRemoveError(c *gc.C) {
+	remover := newFakeBlockRemover(errors.New("boom"))
+	w := blockexpirer.New(remover, 10*time.Millisecond)
+	select {
+	case <-remover.removeCh:
+	case <-time.After(testing.LongWait):
+		c.Fatal("timed out waiting for expired blocks to be removed")
+	}
+	c.Assert(w.Wait(), gc.ErrorMatches, "cannot remove expired blocks: boom")
+}
+
+func (s *BlockExpirerSuite) TestStops(c *gc.C) {
+	w := blockexpirer.New(newFakeBlockRemover(nil), time.Minute)
+	w.Kill()
+	c.Assert(w.Wait(), jc.ErrorIsNil)
+}
+
+func newFakeBlockRemover(err error) *fakeBlockRemover {
+	return &fakeBlockRemover{
+		removeCh: make(chan time.Time, 1),
+		err:      err,
+	}
+}
+
+type fakeBlockRemover struct {
+	removeCh chan time.Time
+	err      error
+}
+
+// RemoveExpiredBlocks implements blockexpirer.BlockRemover.
+func (r *fakeBlockRemover) RemoveExpiredBlocks(now time.Time) error {
+	select {
+	case r.removeCh <- now:
+	default:
+	}
+	return r.err
+}
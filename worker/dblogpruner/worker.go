@@ -57,11 +57,60 @@ func (w *pruneWorker) loop(stopCh <-chan struct{}) error {
 		case <-stopCh:
 			return tomb.ErrDying
 		case <-time.After(p.PruneInterval):
-			minLogTime := time.Now().Add(-p.MaxLogAge)
-			err := state.PruneLogs(w.st, minLogTime, p.MaxCollectionMB)
+			archiver, err := w.archiver()
 			if err != nil {
 				return errors.Trace(err)
 			}
+			now := time.Now()
+			retention, err := w.retention(now)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			minLogTime := now.Add(-p.MaxLogAge)
+			err = state.PruneLogs(w.st, minLogTime, p.MaxCollectionMB, retention, archiver)
+			if err != nil {
+				return errors.Trace(err)
+			}
+		}
+	}
+}
+
+// archiver returns the archiver that pruned logs should be passed to,
+// or nil if log archival is not enabled in the controller model.
+func (w *pruneWorker) archiver() (state.LogArchiver, error) {
+	cfg, err := w.st.ModelConfig()
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot read model config")
+	}
+	if !cfg.LogArchive() {
+		return nil, nil
+	}
+	return state.NewLogArchiver(w.st), nil
+}
+
+// retention returns the log retention limits set in the config of
+// each model, keyed by model UUID.
+func (w *pruneWorker) retention(now time.Time) (map[string]state.LogRetention, error) {
+	models, err := w.st.AllModels()
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot list models")
+	}
+	retention := make(map[string]state.LogRetention)
+	for _, model := range models {
+		cfg, err := model.Config()
+		if err != nil {
+			return nil, errors.Annotatef(err, "cannot read config for model %q", model.UUID())
+		}
+		var r state.LogRetention
+		if maxAge, ok := cfg.LogsMaxAge(); ok {
+			r.MinLogTime = now.Add(-maxAge)
+		}
+		if maxSize, ok := cfg.LogsMaxSize(); ok {
+			r.MaxMB = maxSize
+		}
+		if !r.MinLogTime.IsZero() || r.MaxMB > 0 {
+			retention[model.UUID()] = r
 		}
 	}
+	return retention, nil
 }
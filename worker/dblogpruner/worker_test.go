@@ -106,12 +106,64 @@ func (s *suite) TestPrunesLogsBySize(c *gc.C) {
 	c.Fatal("pruning didn't happen as expected")
 }
 
+func (s *suite) TestPrunesByModelMaxAge(c *gc.C) {
+	err := s.State.UpdateModelConfig(map[string]interface{}{"logs-max-age": "1h"}, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	now := time.Now()
+	s.addLogs(c, now.Add(-2*time.Hour), "prune", 5)
+	s.addLogs(c, now, "keep", 5)
+	// The model's own limit is shorter than the controller's.
+	s.StartWorker(c, 24*time.Hour, int(1e9))
+
+	for attempt := testing.LongAttempt.Start(); attempt.Next(); {
+		pruneRemaining, err := s.logsColl.Find(bson.M{"x": "prune"}).Count()
+		c.Assert(err, jc.ErrorIsNil)
+		if pruneRemaining == 0 {
+			keepCount, err := s.logsColl.Find(bson.M{"x": "keep"}).Count()
+			c.Assert(err, jc.ErrorIsNil)
+			c.Assert(keepCount, gc.Equals, 5)
+			return
+		}
+	}
+	c.Fatal("pruning didn't happen as expected")
+}
+
+func (s *suite) TestArchivesLogsWhenEnabled(c *gc.C) {
+	err := s.State.UpdateModelConfig(map[string]interface{}{"log-archive": true}, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	maxLogAge := 24 * time.Hour
+	now := time.Now()
+	s.addLogs(c, now.Add(-maxLogAge-time.Minute), "prune", 5)
+	s.addLogs(c, now, "keep", 5)
+	s.StartWorker(c, maxLogAge, int(1e9))
+
+	for attempt := testing.LongAttempt.Start(); attempt.Next(); {
+		pruneRemaining, err := s.logsColl.Find(bson.M{"x": "prune"}).Count()
+		c.Assert(err, jc.ErrorIsNil)
+		if pruneRemaining > 0 {
+			continue
+		}
+		tailer := state.NewArchivedLogTailer(s.State, &state.LogTailerParams{})
+		defer tailer.Stop()
+		var messages []string
+		for log := range tailer.Logs() {
+			messages = append(messages, log.Message)
+		}
+		c.Assert(tailer.Err(), jc.ErrorIsNil)
+		c.Assert(messages, jc.DeepEquals, []string{"prune", "prune", "prune", "prune", "prune"})
+		return
+	}
+	c.Fatal("pruning didn't happen as expected")
+}
+
 func (s *suite) addLogs(c *gc.C, t0 time.Time, text string, count int) {
 	dbLogger := state.NewDbLogger(s.State, names.NewMachineTag("0"))
 	defer dbLogger.Close()
 
 	for offset := 0; offset < count; offset++ {
 		t := t0.Add(-time.Duration(offset) * time.Second)
-		dbLogger.Log(t, "some.module", "foo.go:42", loggo.INFO, text)
+		dbLogger.Log(t, "some.module", "foo.go:42", loggo.INFO, text, nil)
 	}
 }
@@ -6,6 +6,7 @@ package logsender
 import (
 	"fmt"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/juju/errors"
@@ -22,6 +23,9 @@ type LogRecord struct {
 	Level    loggo.Level
 	Message  string
 
+	// Fields holds structured data about the message.
+	Fields map[string]string
+
 	// Number of messages dropped after this one due to buffer limit.
 	DroppedAfter int
 }
@@ -129,7 +133,24 @@ func (w *BufferedLogWriter) Write(level loggo.Level, module, filename string, li
 		Location: fmt.Sprintf("%s:%d", filepath.Base(filename), line),
 		Level:    level,
 		Message:  message,
+		Fields:   moduleFields(module),
+	}
+}
+
+// moduleFields returns the structured fields implied by the name of
+// the logger that wrote a message. The uniter logs the output of
+// charm hooks and actions to "unit.<unit-name>.<hook-name>", and
+// juju-log messages to "unit.<unit-name>.juju-log".
+func moduleFields(module string) map[string]string {
+	parts := strings.Split(module, ".")
+	if len(parts) != 3 || parts[0] != "unit" {
+		return nil
+	}
+	fields := map[string]string{"unit": parts[1]}
+	if parts[2] != "juju-log" {
+		fields["hook"] = parts[2]
 	}
+	return fields
 }
 
 // Logs returns a channel which emits log messages that have been sent
@@ -174,6 +174,23 @@ func (s *bufferedLogWriterSuite) writeAndReceive(c *gc.C) {
 	})
 }
 
+func (s *bufferedLogWriterSuite) TestUnitFields(c *gc.C) {
+	now := time.Now()
+	s.writer.Write(loggo.INFO, "unit.mysql/0.config-changed", "filename", 99, now, "hook output")
+	c.Assert(s.receiveOne(c).Fields, jc.DeepEquals, map[string]string{
+		"unit": "mysql/0",
+		"hook": "config-changed",
+	})
+
+	s.writer.Write(loggo.INFO, "unit.mysql/0.juju-log", "filename", 99, now, "logged")
+	c.Assert(s.receiveOne(c).Fields, jc.DeepEquals, map[string]string{
+		"unit": "mysql/0",
+	})
+
+	s.writer.Write(loggo.INFO, "juju.worker.uniter", "filename", 99, now, "message")
+	c.Assert(s.receiveOne(c).Fields, gc.IsNil)
+}
+
 func (s *bufferedLogWriterSuite) receiveOne(c *gc.C) *logsender.LogRecord {
 	select {
 	case rec := <-s.writer.Logs():
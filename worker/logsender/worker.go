@@ -36,6 +36,7 @@ func New(logs LogRecordCh, logSenderAPI *logsender.API) worker.Worker {
 					Location: rec.Location,
 					Level:    rec.Level,
 					Message:  rec.Message,
+					Fields:   rec.Fields,
 				})
 				if err != nil {
 					return errors.Trace(err)
@@ -86,6 +86,7 @@ func (s *workerSuite) TestLogSending(c *gc.C) {
 			Location: location,
 			Level:    loggo.INFO,
 			Message:  message,
+			Fields:   map[string]string{"hook": "install"},
 		}
 
 		expectedDocs = append(expectedDocs, bson.M{
@@ -96,6 +97,7 @@ func (s *workerSuite) TestLogSending(c *gc.C) {
 			"l": location,
 			"v": int(loggo.INFO),
 			"x": message,
+			"f": bson.M{"hook": "install"},
 		})
 	}
 
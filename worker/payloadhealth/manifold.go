@@ -0,0 +1,41 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package payloadhealth
+
+import (
+	"time"
+
+	"github.com/juju/utils/clock"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/payload"
+	"github.com/juju/juju/payload/api/private/client"
+	"github.com/juju/juju/worker"
+	"github.com/juju/juju/worker/dependency"
+	"github.com/juju/juju/worker/util"
+)
+
+// checkPeriod is how often the worker looks for health probes that
+// are due.
+const checkPeriod = 5 * time.Second
+
+// ManifoldConfig defines the names of the manifolds on which a Manifold will depend.
+type ManifoldConfig util.ApiManifoldConfig
+
+// Manifold returns a dependency manifold that runs a payload health
+// worker, using the api connection resource named in the supplied
+// config.
+func Manifold(config ManifoldConfig) dependency.Manifold {
+	return util.ApiManifold(util.ApiManifoldConfig(config), newWorker)
+}
+
+func newWorker(apiCaller base.APICaller) (worker.Worker, error) {
+	facadeCaller := base.NewFacadeCallerForVersion(apiCaller, payload.HookContextFacade, payload.HookContextFacadeVersion)
+	return New(Config{
+		Facade: client.NewUnitFacadeClient(facadeCaller),
+		Probe:  RunProbe,
+		Clock:  clock.WallClock,
+		Period: checkPeriod,
+	})
+}
@@ -0,0 +1,194 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package payloadhealth provides a worker that runs the health probes
+// of a unit's payloads, and keeps the status of each payload in step
+// with the result.
+package payloadhealth
+
+import (
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/utils/clock"
+	"github.com/juju/utils/set"
+
+	"github.com/juju/juju/payload"
+	"github.com/juju/juju/worker"
+)
+
+var logger = loggo.GetLogger("juju.worker.payloadhealth")
+
+// Facade exposes the payload functionality needed by the worker.
+type Facade interface {
+	// List returns the unit's payloads.
+	List(fullIDs ...string) ([]payload.Result, error)
+
+	// SetHealthStatus sets the status of the identified payloads,
+	// unless they are no longer running or unhealthy.
+	SetHealthStatus(status string, fullIDs ...string) ([]payload.Result, error)
+}
+
+// Config holds the resources and configuration of a worker.
+type Config struct {
+	// Facade is used to read and update the unit's payloads.
+	Facade Facade
+
+	// Probe runs a health probe, returning an error if the payload is
+	// unhealthy.
+	Probe func(payload.HealthProbe) error
+
+	// Clock is responsible for reporting the passage of time.
+	Clock clock.Clock
+
+	// Period is how often the worker looks for probes that are due.
+	// Probes are run no more often than this, whatever their interval.
+	Period time.Duration
+}
+
+// Validate returns an error if the configuration is not valid.
+func (config Config) Validate() error {
+	if config.Facade == nil {
+		return errors.NotValidf("nil Facade")
+	}
+	if config.Probe == nil {
+		return errors.NotValidf("nil Probe")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.Period <= 0 {
+		return errors.NotValidf("non-positive Period")
+	}
+	return nil
+}
+
+// New returns a worker that periodically runs the health probes of
+// the unit's running payloads. A payload whose probe fails is set to
+// unhealthy, and set back to running once the probe passes again.
+// Payloads in any other state are left alone.
+func New(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &healthWorker{
+		config:     config,
+		lastProbed: make(map[string]time.Time),
+	}
+	return worker.NewSimpleWorker(w.loop), nil
+}
+
+type healthWorker struct {
+	config Config
+
+	// lastProbed records when each payload's probe was last run.
+	lastProbed map[string]time.Time
+}
+
+func (w *healthWorker) loop(stopCh <-chan struct{}) error {
+	for {
+		if err := w.check(); err != nil {
+			return errors.Trace(err)
+		}
+		select {
+		case <-stopCh:
+			return nil
+		case <-w.config.Clock.After(w.config.Period):
+		}
+	}
+}
+
+// dueProbe is a health probe to be run by check.
+type dueProbe struct {
+	id     string
+	probe  payload.HealthProbe
+	status string
+	err    error
+}
+
+// check runs the probes that are due, and updates the status of any
+// payload whose health has changed. The probes are run concurrently,
+// so that one slow probe does not hold up the others; each is bounded
+// by its own timeout.
+func (w *healthWorker) check() error {
+	results, err := w.config.Facade.List()
+	if err != nil {
+		return errors.Annotate(err, "cannot list payloads")
+	}
+	now := w.config.Clock.Now()
+	probed := set.NewStrings()
+	var due []*dueProbe
+	for _, result := range results {
+		if result.Error != nil {
+			logger.Warningf("cannot get payload %q: %v", result.ID, result.Error)
+			continue
+		}
+		pl := result.Payload
+		if pl == nil || pl.Probe == nil {
+			continue
+		}
+		if pl.Status != payload.StateRunning && pl.Status != payload.StateUnhealthy {
+			continue
+		}
+		id := pl.FullID()
+		probed.Add(id)
+		if last, ok := w.lastProbed[id]; ok && now.Sub(last) < pl.Probe.Interval {
+			continue
+		}
+		w.lastProbed[id] = now
+		due = append(due, &dueProbe{id: id, probe: *pl.Probe, status: pl.Status})
+	}
+	for id := range w.lastProbed {
+		if !probed.Contains(id) {
+			delete(w.lastProbed, id)
+		}
+	}
+
+	var wg sync.WaitGroup
+	for _, d := range due {
+		wg.Add(1)
+		go func(d *dueProbe) {
+			defer wg.Done()
+			d.err = w.config.Probe(d.probe)
+		}(d)
+	}
+	wg.Wait()
+
+	for _, d := range due {
+		status := payload.StateRunning
+		if d.err != nil {
+			logger.Infof("payload %q failed health check %q: %v", d.id, d.probe.String(), d.err)
+			status = payload.StateUnhealthy
+		}
+		if status == d.status {
+			continue
+		}
+		if err := w.setStatus(d.id, status); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+func (w *healthWorker) setStatus(id, status string) error {
+	logger.Debugf("setting status of payload %q to %q", id, status)
+	results, err := w.config.Facade.SetHealthStatus(status, id)
+	if err != nil {
+		return errors.Annotatef(err, "cannot set status of payload %q", id)
+	}
+	for _, result := range results {
+		if result.NotFound {
+			// The payload was untracked, or its status changed by
+			// something other than this worker, since it was
+			// listed; leave it alone.
+			logger.Debugf("not setting status of payload %q: %v", id, result.Error)
+			continue
+		}
+		if result.Error != nil {
+			return errors.Annotatef(result.Error, "cannot set status of payload %q", id)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,334 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package payloadhealth_test
+
+import (
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6-unstable"
+
+	"github.com/juju/juju/payload"
+	"github.com/juju/juju/testing"
+	"github.com/juju/juju/worker"
+	"github.com/juju/juju/worker/payloadhealth"
+)
+
+type PayloadHealthSuite struct {
+	testing.BaseSuite
+
+	clock  *testing.Clock
+	facade *fakeFacade
+	probes chan payload.HealthProbe
+	health chan error
+}
+
+var _ = gc.Suite(&PayloadHealthSuite{})
+
+func (s *PayloadHealthSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.clock = testing.NewClock(time.Date(2016, 3, 1, 12, 0, 0, 0, time.UTC))
+	s.facade = &fakeFacade{
+		lists:    make(chan struct{}, 10),
+		statuses: make(chan string, 10),
+	}
+	s.probes = make(chan payload.HealthProbe, 10)
+	s.health = make(chan error, 10)
+}
+
+func (s *PayloadHealthSuite) startWorker(c *gc.C) worker.Worker {
+	w, err := payloadhealth.New(payloadhealth.Config{
+		Facade: s.facade,
+		Probe:  s.probe,
+		Clock:  s.clock,
+		Period: 5 * time.Second,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddCleanup(func(c *gc.C) {
+		w.Kill()
+		c.Check(w.Wait(), jc.ErrorIsNil)
+	})
+	return w
+}
+
+func (s *PayloadHealthSuite) probe(probe payload.HealthProbe) error {
+	s.probes <- probe
+	select {
+	case err := <-s.health:
+		return err
+	default:
+		return nil
+	}
+}
+
+func (s *PayloadHealthSuite) TestValidate(c *gc.C) {
+	_, err := payloadhealth.New(payloadhealth.Config{
+		Facade: s.facade,
+		Probe:  s.probe,
+		Clock:  s.clock,
+	})
+	c.Check(err, gc.ErrorMatches, "non-positive Period not valid")
+}
+
+func (s *PayloadHealthSuite) TestSetsUnhealthy(c *gc.C) {
+	s.facade.payloads = []payload.Payload{newPayload("spam", payload.StateRunning)}
+	s.health <- errors.New("connection refused")
+	s.startWorker(c)
+
+	s.waitForProbe(c)
+	s.waitForStatus(c, "unhealthy spam/idspam")
+}
+
+func (s *PayloadHealthSuite) TestSetsRunningWhenHealthyAgain(c *gc.C) {
+	s.facade.payloads = []payload.Payload{newPayload("spam", payload.StateUnhealthy)}
+	s.startWorker(c)
+
+	s.waitForProbe(c)
+	s.waitForStatus(c, "running spam/idspam")
+}
+
+func (s *PayloadHealthSuite) TestRunsProbesAtTheirInterval(c *gc.C) {
+	s.facade.payloads = []payload.Payload{newPayload("spam", payload.StateRunning)}
+	s.startWorker(c)
+	s.waitForProbe(c)
+
+	// The next check is too soon to run the probe again.
+	s.advance(c, 10*time.Second)
+	s.waitForList(c)
+	s.waitForList(c)
+	s.checkNoProbe(c)
+
+	s.advance(c, 20*time.Second)
+	s.waitForProbe(c)
+	s.checkNoStatus(c)
+}
+
+func (s *PayloadHealthSuite) TestIgnoresPayloadsNotRunning(c *gc.C) {
+	noProbe := newPayload("eggs", payload.StateRunning)
+	noProbe.Probe = nil
+	s.facade.payloads = []payload.Payload{
+		newPayload("spam", payload.StateStopped),
+		noProbe,
+	}
+	s.startWorker(c)
+
+	s.waitForList(c)
+	s.advance(c, time.Minute)
+	s.waitForList(c)
+	s.checkNoProbe(c)
+	s.checkNoStatus(c)
+}
+
+func (s *PayloadHealthSuite) TestLeavesPayloadStoppedWhileProbing(c *gc.C) {
+	s.facade.payloads = []payload.Payload{newPayload("spam", payload.StateRunning)}
+	w, err := payloadhealth.New(payloadhealth.Config{
+		Facade: s.facade,
+		Probe: func(payload.HealthProbe) error {
+			// The charm stops the payload while it is being probed.
+			s.facade.setStatus("spam/idspam", payload.StateStopped)
+			return errors.New("connection refused")
+		},
+		Clock:  s.clock,
+		Period: 5 * time.Second,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddCleanup(func(c *gc.C) {
+		w.Kill()
+		c.Check(w.Wait(), jc.ErrorIsNil)
+	})
+
+	s.waitForStatus(c, "unhealthy spam/idspam")
+	s.advance(c, time.Minute)
+	s.waitForList(c)
+	s.waitForList(c)
+	c.Check(s.facade.status("spam/idspam"), gc.Equals, payload.StateStopped)
+	s.checkNoStatus(c)
+}
+
+func (s *PayloadHealthSuite) TestRunsProbesConcurrently(c *gc.C) {
+	s.facade.payloads = []payload.Payload{
+		newPayload("spam", payload.StateRunning),
+		newPayload("eggs", payload.StateRunning),
+	}
+	started := make(chan struct{}, 2)
+	release := make(chan struct{})
+	w, err := payloadhealth.New(payloadhealth.Config{
+		Facade: s.facade,
+		Probe: func(payload.HealthProbe) error {
+			started <- struct{}{}
+			<-release
+			return errors.New("connection refused")
+		},
+		Clock:  s.clock,
+		Period: 5 * time.Second,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddCleanup(func(c *gc.C) {
+		w.Kill()
+		c.Check(w.Wait(), jc.ErrorIsNil)
+	})
+
+	// Both probes are running before either finishes.
+	for i := 0; i < 2; i++ {
+		select {
+		case <-started:
+		case <-time.After(testing.LongWait):
+			c.Fatal("timed out waiting for probes to start")
+		}
+	}
+	close(release)
+	s.waitForStatus(c, "unhealthy spam/idspam")
+	s.waitForStatus(c, "unhealthy eggs/ideggs")
+}
+
+func (s *PayloadHealthSuite) TestListError(c *gc.C) {
+	s.facade.err = errors.New("boom")
+	w, err := payloadhealth.New(payloadhealth.Config{
+		Facade: s.facade,
+		Probe:  s.probe,
+		Clock:  s.clock,
+		Period: 5 * time.Second,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(w.Wait(), gc.ErrorMatches, "cannot list payloads: boom")
+}
+
+func (s *PayloadHealthSuite) advance(c *gc.C, d time.Duration) {
+	select {
+	case <-s.clock.Alarms():
+	case <-time.After(testing.LongWait):
+		c.Fatal("timed out waiting for the worker to wait")
+	}
+	s.clock.Advance(d)
+}
+
+func (s *PayloadHealthSuite) waitForList(c *gc.C) {
+	select {
+	case <-s.facade.lists:
+	case <-time.After(testing.LongWait):
+		c.Fatal("timed out waiting for payloads to be listed")
+	}
+}
+
+func (s *PayloadHealthSuite) waitForProbe(c *gc.C) {
+	select {
+	case <-s.probes:
+	case <-time.After(testing.LongWait):
+		c.Fatal("timed out waiting for a probe")
+	}
+}
+
+func (s *PayloadHealthSuite) waitForStatus(c *gc.C, expected string) {
+	select {
+	case status := <-s.facade.statuses:
+		c.Check(status, gc.Equals, expected)
+	case <-time.After(testing.LongWait):
+		c.Fatal("timed out waiting for status to be set")
+	}
+}
+
+func (s *PayloadHealthSuite) checkNoProbe(c *gc.C) {
+	select {
+	case <-s.probes:
+		c.Fatal("unexpected probe")
+	case <-time.After(testing.ShortWait):
+	}
+}
+
+func (s *PayloadHealthSuite) checkNoStatus(c *gc.C) {
+	select {
+	case status := <-s.facade.statuses:
+		c.Fatalf("unexpected status %q", status)
+	case <-time.After(testing.ShortWait):
+	}
+}
+
+func newPayload(name, status string) payload.Payload {
+	return payload.Payload{
+		PayloadClass: charm.PayloadClass{
+			Name: name,
+			Type: "docker",
+		},
+		ID:     "id" + name,
+		Status: status,
+		Unit:   "a-service/0",
+		Probe: &payload.HealthProbe{
+			Type:     payload.ProbeTCP,
+			Port:     8080,
+			Interval: 30 * time.Second,
+		},
+	}
+}
+
+type fakeFacade struct {
+	mu       sync.Mutex
+	payloads []payload.Payload
+	err      error
+
+	lists    chan struct{}
+	statuses chan string
+}
+
+func (f *fakeFacade) List(fullIDs ...string) ([]payload.Result, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.lists <- struct{}{}
+	if f.err != nil {
+		return nil, f.err
+	}
+	var results []payload.Result
+	for _, pl := range f.payloads {
+		results = append(results, payload.Result{
+			ID:      pl.FullID(),
+			Payload: &payload.FullPayloadInfo{Payload: pl},
+		})
+	}
+	return results, nil
+}
+
+func (f *fakeFacade) SetHealthStatus(status string, fullIDs ...string) ([]payload.Result, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var results []payload.Result
+	for _, id := range fullIDs {
+		f.statuses <- status + " " + id
+		result := payload.Result{ID: id, NotFound: true}
+		for i, pl := range f.payloads {
+			if pl.FullID() != id {
+				continue
+			}
+			if pl.Status == payload.StateRunning || pl.Status == payload.StateUnhealthy {
+				f.payloads[i].Status = status
+				result.NotFound = false
+			}
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+func (f *fakeFacade) status(id string) string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, pl := range f.payloads {
+		if pl.FullID() == id {
+			return pl.Status
+		}
+	}
+	return ""
+}
+
+func (f *fakeFacade) setStatus(id, status string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for i, pl := range f.payloads {
+		if pl.FullID() == id {
+			f.payloads[i].Status = status
+		}
+	}
+}
@@ -0,0 +1,80 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package payloadhealth
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"os/exec"
+	"strconv"
+	"time"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/payload"
+)
+
+// probeTimeout is how long a probe may take before the payload is
+// considered unhealthy.
+const probeTimeout = 10 * time.Second
+
+// RunProbe runs the health probe, returning an error if it fails.
+func RunProbe(probe payload.HealthProbe) error {
+	return runProbe(probe, probeTimeout)
+}
+
+func runProbe(probe payload.HealthProbe, timeout time.Duration) error {
+	switch probe.Type {
+	case payload.ProbeExec:
+		return runExecProbe(probe.Command, timeout)
+	case payload.ProbeTCP:
+		return runTCPProbe(probe.Port, timeout)
+	case payload.ProbeHTTP:
+		return runHTTPProbe(probe.Port, probe.Path, timeout)
+	}
+	return errors.NotValidf("health probe type %q", probe.Type)
+}
+
+func runExecProbe(command string, timeout time.Duration) error {
+	cmd := exec.Command("/bin/sh", "-c", command)
+	if err := cmd.Start(); err != nil {
+		return errors.Trace(err)
+	}
+	done := make(chan error, 1)
+	go func() {
+		done <- cmd.Wait()
+	}()
+	select {
+	case err := <-done:
+		return errors.Trace(err)
+	case <-time.After(timeout):
+		cmd.Process.Kill()
+		<-done
+		return errors.Errorf("command timed out after %v", timeout)
+	}
+}
+
+func runTCPProbe(port int, timeout time.Duration) error {
+	addr := net.JoinHostPort("localhost", strconv.Itoa(port))
+	conn, err := net.DialTimeout("tcp", addr, timeout)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return conn.Close()
+}
+
+func runHTTPProbe(port int, path string, timeout time.Duration) error {
+	client := &http.Client{Timeout: timeout}
+	url := fmt.Sprintf("http://localhost:%d%s", port, path)
+	resp, err := client.Get(url)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode >= http.StatusBadRequest {
+		return errors.Errorf("GET %s: %s", url, resp.Status)
+	}
+	return nil
+}
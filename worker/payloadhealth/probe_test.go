@@ -0,0 +1,87 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package payloadhealth_test
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/payload"
+	"github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/payloadhealth"
+)
+
+type ProbeSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&ProbeSuite{})
+
+func (s *ProbeSuite) TestExec(c *gc.C) {
+	err := payloadhealth.RunProbe(payload.HealthProbe{
+		Type:    payload.ProbeExec,
+		Command: "true",
+	})
+	c.Check(err, jc.ErrorIsNil)
+
+	err = payloadhealth.RunProbe(payload.HealthProbe{
+		Type:    payload.ProbeExec,
+		Command: "exit 3",
+	})
+	c.Check(err, gc.ErrorMatches, "exit status 3")
+}
+
+func (s *ProbeSuite) TestExecTimeout(c *gc.C) {
+	err := payloadhealth.RunProbeWithTimeout(payload.HealthProbe{
+		Type:    payload.ProbeExec,
+		Command: "sleep 10",
+	}, 10*time.Millisecond)
+	c.Check(err, gc.ErrorMatches, "command timed out after 10ms")
+}
+
+func (s *ProbeSuite) TestTCP(c *gc.C) {
+	listener, err := net.Listen("tcp", "localhost:0")
+	c.Assert(err, jc.ErrorIsNil)
+	port := listener.Addr().(*net.TCPAddr).Port
+	probe := payload.HealthProbe{
+		Type: payload.ProbeTCP,
+		Port: port,
+	}
+
+	err = payloadhealth.RunProbe(probe)
+	c.Check(err, jc.ErrorIsNil)
+
+	listener.Close()
+	err = payloadhealth.RunProbe(probe)
+	c.Check(err, gc.NotNil)
+}
+
+func (s *ProbeSuite) TestHTTP(c *gc.C) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != "/status" {
+			http.NotFound(w, req)
+		}
+	}))
+	defer server.Close()
+	port := server.Listener.Addr().(*net.TCPAddr).Port
+
+	err := payloadhealth.RunProbe(payload.HealthProbe{
+		Type: payload.ProbeHTTP,
+		Port: port,
+		Path: "/status",
+	})
+	c.Check(err, jc.ErrorIsNil)
+
+	err = payloadhealth.RunProbe(payload.HealthProbe{
+		Type: payload.ProbeHTTP,
+		Port: port,
+		Path: "/missing",
+	})
+	c.Check(err, gc.ErrorMatches, `GET http://localhost:\d+/missing: 404 Not Found`)
+}
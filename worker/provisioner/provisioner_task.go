@@ -628,9 +628,11 @@ func (task *provisionerTask) startMachines(machines []*apiprovisioner.Machine) e
 
 		assocProvInfoAndMachCfg(pInfo, instanceCfg)
 
+		// If alternative arches are allowed, look for tools for any
+		// of them; the instance chosen determines which are used.
 		var arch string
-		if pInfo.Constraints.Arch != nil {
-			arch = *pInfo.Constraints.Arch
+		if arches := pInfo.Constraints.Arches(); len(arches) == 1 {
+			arch = arches[0]
 		}
 
 		possibleTools, err := task.toolsFinder.FindTools(
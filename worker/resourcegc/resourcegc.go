@@ -0,0 +1,28 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package resourcegc
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/worker"
+)
+
+// ContentRemover defines the interface for types capable of removing
+// the resource content that no resource refers to any more.
+type ContentRemover interface {
+	RemoveOrphanedResourceContent() error
+}
+
+// New returns a worker which periodically removes, in every model, the
+// resource content that no resource refers to any more.
+func New(cr ContentRemover, interval time.Duration) worker.Worker {
+	f := func(stop <-chan struct{}) error {
+		err := cr.RemoveOrphanedResourceContent()
+		return errors.Annotate(err, "cannot remove orphaned resource content")
+	}
+	return worker.NewPeriodicWorker(f, interval, worker.NewTimer)
+}
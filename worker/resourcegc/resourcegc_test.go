@@ -0,0 +1,73 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package resourcegc_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/resourcegc"
+)
+
+type ResourceGCSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&ResourceGCSuite{})
+
+func (s *ResourceGCSuite) TestRemovesOrphanedContent(c *gc.C) {
+	remover := newFakeContentRemover(nil)
+	w := resourcegc.New(remover, 10*time.Millisecond)
+	defer w.Kill()
+
+	for i := 0; i < 3; i++ {
+		select {
+		case <-remover.removeCh:
+		case <-time.After(testing.LongWait):
+			c.Fatal("timed out waiting for orphaned content to be removed")
+		}
+	}
+}
+
+func (s *ResourceGCSuite) TestRemoveError(c *gc.C) {
+	remover := newFakeContentRemover(errors.New("boom"))
+	w := resourcegc.New(remover, 10*time.Millisecond)
+	select {
+	case <-remover.removeCh:
+	case <-time.After(testing.LongWait):
+		c.Fatal("timed out waiting for orphaned content to be removed")
+	}
+	c.Assert(w.Wait(), gc.ErrorMatches, "cannot remove orphaned resource content: boom")
+}
+
+func (s *ResourceGCSuite) TestStops(c *gc.C) {
+	w := resourcegc.New(newFakeContentRemover(nil), time.Minute)
+	w.Kill()
+	c.Assert(w.Wait(), jc.ErrorIsNil)
+}
+
+func newFakeContentRemover(err error) *fakeContentRemover {
+	return &fakeContentRemover{
+		removeCh: make(chan struct{}, 1),
+		err:      err,
+	}
+}
+
+type fakeContentRemover struct {
+	removeCh chan struct{}
+	err      error
+}
+
+// RemoveOrphanedResourceContent implements resourcegc.ContentRemover.
+func (r *fakeContentRemover) RemoveOrphanedResourceContent() error {
+	select {
+	case r.removeCh <- struct{}{}:
+	default:
+	}
+	return r.err
+}
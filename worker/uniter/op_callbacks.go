@@ -12,6 +12,7 @@ import (
 	"gopkg.in/juju/charm.v6-unstable/hooks"
 
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/watcher"
 	"github.com/juju/juju/worker/uniter/charm"
 	"github.com/juju/juju/worker/uniter/hook"
 	"github.com/juju/juju/worker/uniter/runner"
@@ -90,6 +91,11 @@ func (opc *operationCallbacks) NotifyHookFailed(hook string, ctx runner.Context)
 	}
 }
 
+// RecordHookRun is part of the operation.Callbacks interface.
+func (opc *operationCallbacks) RecordHookRun(record params.HookRecord) error {
+	return opc.u.unit.AddHookRecord(record)
+}
+
 // FailAction is part of the operation.Callbacks interface.
 func (opc *operationCallbacks) FailAction(actionId, message string) error {
 	if !names.IsValidAction(actionId) {
@@ -103,6 +109,19 @@ func (opc *operationCallbacks) FailAction(actionId, message string) error {
 	return err
 }
 
+// ActionCancelRequested is part of the operation.Callbacks interface.
+func (opc *operationCallbacks) ActionCancelRequested(actionId string) (bool, error) {
+	if !names.IsValidAction(actionId) {
+		return false, errors.Errorf("invalid action id %q", actionId)
+	}
+	return opc.u.st.ActionCancelRequested(names.NewActionTag(actionId))
+}
+
+// WatchActionNotifications is part of the operation.Callbacks interface.
+func (opc *operationCallbacks) WatchActionNotifications() (watcher.StringsWatcher, error) {
+	return opc.u.unit.WatchActionNotifications()
+}
+
 // GetArchiveInfo is part of the operation.Callbacks interface.
 func (opc *operationCallbacks) GetArchiveInfo(charmURL *corecharm.URL) (charm.BundleInfo, error) {
 	ch, err := opc.u.st.Charm(charmURL)
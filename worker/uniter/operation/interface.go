@@ -9,6 +9,8 @@ import (
 	utilexec "github.com/juju/utils/exec"
 	corecharm "gopkg.in/juju/charm.v6-unstable"
 
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/watcher"
 	"github.com/juju/juju/worker/uniter/charm"
 	"github.com/juju/juju/worker/uniter/hook"
 	"github.com/juju/juju/worker/uniter/runner"
@@ -142,6 +144,10 @@ type Callbacks interface {
 	NotifyHookCompleted(string, runner.Context)
 	NotifyHookFailed(string, runner.Context)
 
+	// RecordHookRun records that a hook was run, so it can be shown
+	// in the unit's hook history. It's only used by RunHook operations.
+	RecordHookRun(params.HookRecord) error
+
 	// The following methods exist primarily to allow us to test operation code
 	// without using a live api connection.
 
@@ -149,6 +155,15 @@ type Callbacks interface {
 	// RunActions operations.
 	FailAction(actionId, message string) error
 
+	// ActionCancelRequested reports whether the supplied action has
+	// been asked to stop. It's only used by RunActions operations.
+	ActionCancelRequested(actionId string) (bool, error)
+
+	// WatchActionNotifications returns a watcher that reports the ids
+	// of the unit's actions when they are queued or asked to stop. It's
+	// only used by RunActions operations.
+	WatchActionNotifications() (watcher.StringsWatcher, error)
+
 	// GetArchiveInfo is used to find out how to download a charm archive. It's
 	// only used by Deploy operations.
 	GetArchiveInfo(charmURL *corecharm.URL) (charm.BundleInfo, error)
@@ -5,9 +5,13 @@ package operation
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/juju/errors"
+	"github.com/juju/utils/set"
 
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/watcher"
 	"github.com/juju/juju/worker/uniter/runner"
 )
 
@@ -17,8 +21,9 @@ type runAction struct {
 	callbacks     Callbacks
 	runnerFactory runner.Factory
 
-	name   string
-	runner runner.Runner
+	name    string
+	timeout time.Duration
+	runner  runner.Runner
 
 	RequiresMachineLock
 }
@@ -54,6 +59,7 @@ func (ra *runAction) Prepare(state State) (*State, error) {
 		return nil, errors.Trace(err)
 	}
 	ra.name = actionData.Name
+	ra.timeout = actionData.Timeout
 	ra.runner = rnr
 	return stateChange{
 		Kind:     RunAction,
@@ -72,7 +78,15 @@ func (ra *runAction) Execute(state State) (*State, error) {
 		return nil, err
 	}
 
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		ra.watchAction(done)
+	}()
 	err := ra.runner.RunAction(ra.name)
+	close(done)
+	<-stopped
 	if err != nil {
 		// This indicates an actual error -- an action merely failing should
 		// be handled inside the Runner, and returned as nil.
@@ -86,6 +100,64 @@ func (ra *runAction) Execute(state State) (*State, error) {
 	}.apply(state), nil
 }
 
+// watchAction stops the running action if it runs for longer than
+// its timeout, or if it is cancelled, until done is closed.
+func (ra *runAction) watchAction(done <-chan struct{}) {
+	var timeout <-chan time.Time
+	if ra.timeout > 0 {
+		timeout = time.After(ra.timeout)
+	}
+	var changes watcher.StringsChannel
+	w, err := ra.callbacks.WatchActionNotifications()
+	if err != nil {
+		logger.Warningf("cannot watch for cancellation of action %s: %v", ra.actionId, err)
+	} else {
+		defer func() {
+			w.Kill()
+			if err := w.Wait(); err != nil {
+				logger.Warningf("stopping action watcher: %v", err)
+			}
+		}()
+		changes = w.Changes()
+	}
+	for {
+		select {
+		case <-done:
+			return
+		case <-timeout:
+			ra.stopAction(params.ActionTimedOut)
+			return
+		case ids, ok := <-changes:
+			if !ok {
+				logger.Warningf("action watcher closed while running action %s", ra.actionId)
+				changes = nil
+				continue
+			}
+			if !set.NewStrings(ids...).Contains(ra.actionId) {
+				continue
+			}
+			requested, err := ra.callbacks.ActionCancelRequested(ra.actionId)
+			if err != nil {
+				logger.Warningf("cannot check whether action %s was cancelled: %v", ra.actionId, err)
+				continue
+			}
+			if requested {
+				ra.stopAction(params.ActionCancelled)
+				return
+			}
+		}
+	}
+}
+
+// stopAction kills the running action, which will finish with the
+// given status.
+func (ra *runAction) stopAction(status string) {
+	logger.Infof("stopping action %s: %s", ra.actionId, status)
+	if err := ra.runner.Context().CancelAction(status); err != nil {
+		logger.Errorf("cannot stop action %s: %v", ra.actionId, err)
+	}
+}
+
 // Commit preserves the recorded hook, and returns a neutral state.
 // Commit is part of the Operation interface.
 func (ra *runAction) Commit(state State) (*State, error) {
@@ -4,12 +4,16 @@
 package operation_test
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/charm.v6-unstable/hooks"
 
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
 	"github.com/juju/juju/worker/uniter/hook"
 	"github.com/juju/juju/worker/uniter/operation"
 	"github.com/juju/juju/worker/uniter/runner"
@@ -227,6 +231,64 @@ func (s *RunActionSuite) TestExecuteSuccess(c *gc.C) {
 	}
 }
 
+func (s *RunActionSuite) TestExecuteTimedOut(c *gc.C) {
+	runnerFactory := NewRunActionRunnerFactory(nil)
+	mockContext := stopActionContext(runnerFactory)
+	mockContext.actionData.Timeout = time.Millisecond
+	s.checkExecuteStopped(c, runnerFactory, &RunActionCallbacks{})
+	c.Assert(mockContext.cancelStatus, gc.Equals, params.ActionTimedOut)
+}
+
+func (s *RunActionSuite) TestExecuteCancelled(c *gc.C) {
+	runnerFactory := NewRunActionRunnerFactory(nil)
+	mockContext := stopActionContext(runnerFactory)
+	changes := make(chan []string, 1)
+	changes <- []string{"other-action-id", someActionId}
+	s.checkExecuteStopped(c, runnerFactory, &RunActionCallbacks{
+		cancelRequested: true,
+		actionChanges:   changes,
+	})
+	c.Assert(mockContext.cancelStatus, gc.Equals, params.ActionCancelled)
+}
+
+func (s *RunActionSuite) TestExecuteIgnoresOtherActions(c *gc.C) {
+	runnerFactory := NewRunActionRunnerFactory(nil)
+	mockContext := stopActionContext(runnerFactory)
+	mockContext.actionData.Timeout = coretesting.ShortWait
+	changes := make(chan []string, 1)
+	changes <- []string{"other-action-id"}
+	s.checkExecuteStopped(c, runnerFactory, &RunActionCallbacks{
+		cancelRequested: true,
+		actionChanges:   changes,
+	})
+	c.Assert(mockContext.cancelStatus, gc.Equals, params.ActionTimedOut)
+}
+
+// stopActionContext arranges for the action run by runnerFactory to
+// block until it is cancelled, and returns its context.
+func stopActionContext(runnerFactory *MockRunnerFactory) *MockContext {
+	mockRunner := runnerFactory.MockNewActionRunner.runner
+	mockContext := mockRunner.context.(*MockContext)
+	mockContext.cancelled = make(chan struct{})
+	mockRunner.MockRunAction.wait = mockContext.cancelled
+	return mockContext
+}
+
+func (s *RunActionSuite) checkExecuteStopped(c *gc.C, runnerFactory *MockRunnerFactory, callbacks *RunActionCallbacks) {
+	factory := operation.NewFactory(operation.FactoryParams{
+		RunnerFactory: runnerFactory,
+		Callbacks:     callbacks,
+	})
+	op, err := factory.NewAction(someActionId)
+	c.Assert(err, jc.ErrorIsNil)
+	midState, err := op.Prepare(operation.State{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	newState, err := op.Execute(*midState)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(newState.Step, gc.Equals, operation.Done)
+}
+
 func (s *RunActionSuite) TestCommit(c *gc.C) {
 	var stateChangeTests = []struct {
 		description string
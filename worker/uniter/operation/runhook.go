@@ -5,6 +5,7 @@ package operation
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/juju/errors"
 	"gopkg.in/juju/charm.v6-unstable/hooks"
@@ -91,7 +92,9 @@ func (rh *runHook) Execute(state State) (*State, error) {
 	ranHook := true
 	step := Done
 
+	started := time.Now()
 	err := rh.runner.RunHook(rh.name)
+	duration := time.Since(started)
 	cause := errors.Cause(err)
 	switch {
 	case context.IsMissingHookError(cause):
@@ -105,12 +108,23 @@ func (rh *runHook) Execute(state State) (*State, error) {
 	case err == nil:
 	default:
 		logger.Errorf("hook %q failed: %v", rh.name, err)
+		rh.recordHookRun(started, duration, runner.HookExitCode(cause))
 		rh.callbacks.NotifyHookFailed(rh.name, rh.runner.Context())
+		if timedOut, ok := cause.(*runner.HookTimedOutError); ok {
+			// Record the timeout so that it can be reported for as
+			// long as the hook remains failed, even across restarts.
+			state.HookTimedOut = &HookTimedOut{
+				Timeout: timedOut.Timeout,
+				Output:  timedOut.Output,
+			}
+			return &state, ErrHookFailed
+		}
 		return nil, ErrHookFailed
 	}
 
 	if ranHook {
 		logger.Infof("ran %q hook", rh.name)
+		rh.recordHookRun(started, duration, 0)
 		rh.callbacks.NotifyHookCompleted(rh.name, rh.runner.Context())
 	} else {
 		logger.Infof("skipped %q hook (missing)", rh.name)
@@ -129,6 +143,26 @@ func (rh *runHook) Execute(state State) (*State, error) {
 	}.apply(state), err
 }
 
+// recordHookRun adds the hook that was run to the unit's hook history.
+// The history is only used for diagnosis, so failing to record the
+// hook does not fail the operation.
+func (rh *runHook) recordHookRun(started time.Time, duration time.Duration, exitCode int) {
+	record := params.HookRecord{
+		Hook:       rh.name,
+		RemoteUnit: rh.info.RemoteUnit,
+		Started:    started,
+		Duration:   duration,
+		ExitCode:   exitCode,
+		Stderr:     rh.runner.Stderr(),
+	}
+	if relation, err := rh.runner.Context().HookRelation(); err == nil {
+		record.Relation = relation.FakeId()
+	}
+	if err := rh.callbacks.RecordHookRun(record); err != nil {
+		logger.Warningf("cannot record %q hook run: %v", rh.name, err)
+	}
+}
+
 func (rh *runHook) beforeHook() error {
 	var err error
 	switch rh.info.Kind {
@@ -4,14 +4,18 @@
 package operation_test
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/charm.v6-unstable/hooks"
 
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/worker/uniter/hook"
 	"github.com/juju/juju/worker/uniter/operation"
+	"github.com/juju/juju/worker/uniter/runner"
 	"github.com/juju/juju/worker/uniter/runner/context"
 	"github.com/juju/juju/worker/uniter/runner/jujuc"
 )
@@ -212,6 +216,7 @@ func (s *RunHookSuite) TestExecuteMissingHookError(c *gc.C) {
 		c.Assert(*runnerFactory.MockNewHookRunner.runner.MockRunHook.gotName, gc.Equals, "some-hook-name")
 		c.Assert(callbacks.MockNotifyHookCompleted.gotName, gc.IsNil)
 		c.Assert(callbacks.MockNotifyHookFailed.gotName, gc.IsNil)
+		c.Assert(callbacks.gotHookRecords, gc.HasLen, 0)
 
 		status, err := runnerFactory.MockNewHookRunner.runner.Context().UnitStatus()
 		c.Assert(err, jc.ErrorIsNil)
@@ -272,6 +277,55 @@ func (s *RunHookSuite) TestExecuteOtherError(c *gc.C) {
 	c.Assert(callbacks.MockNotifyHookCompleted.gotName, gc.IsNil)
 }
 
+func (s *RunHookSuite) TestExecuteRecordsHookRun(c *gc.C) {
+	runErr := errors.New("graaargh")
+	op, callbacks, runnerFactory := s.getExecuteRunnerTest(c, (operation.Factory).NewRunHook, hooks.ConfigChanged, runErr)
+	runnerFactory.MockNewHookRunner.runner.MockRunHook.stderr = "oops"
+	_, err := op.Prepare(operation.State{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = op.Execute(operation.State{})
+	c.Assert(err, gc.Equals, operation.ErrHookFailed)
+	c.Assert(callbacks.gotHookRecords, gc.HasLen, 1)
+	record := callbacks.gotHookRecords[0]
+	c.Assert(record.Started.IsZero(), jc.IsFalse)
+	record.Started = time.Time{}
+	record.Duration = 0
+	c.Assert(record, jc.DeepEquals, params.HookRecord{
+		Hook:     "some-hook-name",
+		ExitCode: -1,
+		Stderr:   "oops",
+	})
+}
+
+func (s *RunHookSuite) TestExecuteTimedOut(c *gc.C) {
+	runErr := &runner.HookTimedOutError{
+		Hook:    "some-hook-name",
+		Timeout: time.Minute,
+		Output:  []string{"still working"},
+	}
+	op, callbacks, runnerFactory := s.getExecuteRunnerTest(c, (operation.Factory).NewRunHook, hooks.ConfigChanged, runErr)
+	_, err := op.Prepare(operation.State{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	before := operation.State{
+		Kind: operation.RunHook,
+		Step: operation.Pending,
+		Hook: &hook.Info{Kind: hooks.ConfigChanged},
+	}
+	newState, err := op.Execute(before)
+	c.Assert(err, gc.Equals, operation.ErrHookFailed)
+	c.Assert(*callbacks.MockNotifyHookFailed.gotName, gc.Equals, "some-hook-name")
+	c.Assert(*callbacks.MockNotifyHookFailed.gotContext, gc.Equals, runnerFactory.MockNewHookRunner.runner.context)
+	c.Assert(callbacks.MockNotifyHookCompleted.gotName, gc.IsNil)
+	after := before
+	after.HookTimedOut = &operation.HookTimedOut{
+		Timeout: time.Minute,
+		Output:  []string{"still working"},
+	}
+	c.Assert(newState, jc.DeepEquals, &after)
+}
+
 func (s *RunHookSuite) testExecuteSuccess(
 	c *gc.C, before, after operation.State, setStatusCalled bool,
 ) {
@@ -285,6 +339,9 @@ func (s *RunHookSuite) testExecuteSuccess(
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(newState, gc.DeepEquals, &after)
 	c.Check(callbacks.executingMessage, gc.Equals, "running some-hook-name hook")
+	c.Assert(callbacks.gotHookRecords, gc.HasLen, 1)
+	c.Check(callbacks.gotHookRecords[0].Hook, gc.Equals, "some-hook-name")
+	c.Check(callbacks.gotHookRecords[0].ExitCode, gc.Equals, 0)
 }
 
 func (s *RunHookSuite) TestExecuteSuccess_BlankSlate(c *gc.C) {
@@ -5,6 +5,7 @@ package operation
 
 import (
 	"os"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/utils"
@@ -93,6 +94,21 @@ type State struct {
 	// Charm describes the charm being deployed by an Install or Upgrade
 	// operation, and is otherwise blank.
 	CharmURL *charm.URL `yaml:"charm,omitempty"`
+
+	// HookTimedOut is set if Kind is RunHook and the hook failed
+	// because it was killed for running too long.
+	HookTimedOut *HookTimedOut `yaml:"hook-timed-out,omitempty"`
+}
+
+// HookTimedOut records the details of a hook that was killed for
+// running too long.
+type HookTimedOut struct {
+	// Timeout holds the time the hook was allowed to run for.
+	Timeout time.Duration `yaml:"timeout"`
+
+	// Output holds the last lines the hook wrote before it was
+	// killed.
+	Output []string `yaml:"output,omitempty"`
 }
 
 // validate returns an error if the state violates expectations.
@@ -174,6 +190,7 @@ func (change stateChange) apply(state State) *State {
 	state.ActionId = change.ActionId
 	state.CharmURL = change.CharmURL
 	state.StatusSet = state.StatusSet || change.HasRunStatusSet
+	state.HookTimedOut = nil
 	return &state
 }
 
@@ -5,6 +5,7 @@ package operation_test
 
 import (
 	"path/filepath"
+	"time"
 
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils"
@@ -137,6 +138,16 @@ var stateTests = []struct {
 			Step: operation.Pending,
 			Hook: relhook,
 		},
+	}, {
+		st: operation.State{
+			Kind: operation.RunHook,
+			Step: operation.Done,
+			Hook: &hook.Info{Kind: hooks.ConfigChanged},
+			HookTimedOut: &operation.HookTimedOut{
+				Timeout: time.Minute,
+				Output:  []string{"still working"},
+			},
+		},
 	},
 	// Upgrade operation.
 	{
@@ -10,6 +10,8 @@ import (
 	corecharm "gopkg.in/juju/charm.v6-unstable"
 	"gopkg.in/juju/charm.v6-unstable/hooks"
 
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/watcher"
 	"github.com/juju/juju/worker/uniter/charm"
 	"github.com/juju/juju/worker/uniter/hook"
 	"github.com/juju/juju/worker/uniter/operation"
@@ -124,12 +126,36 @@ type RunActionCallbacks struct {
 	operation.Callbacks
 	*MockFailAction
 	executingMessage string
+	cancelRequested  bool
+	actionChanges    chan []string
 }
 
 func (cb *RunActionCallbacks) FailAction(actionId, message string) error {
 	return cb.MockFailAction.Call(actionId, message)
 }
 
+func (cb *RunActionCallbacks) ActionCancelRequested(actionId string) (bool, error) {
+	return cb.cancelRequested, nil
+}
+
+func (cb *RunActionCallbacks) WatchActionNotifications() (watcher.StringsWatcher, error) {
+	return &MockStringsWatcher{changes: cb.actionChanges}, nil
+}
+
+type MockStringsWatcher struct {
+	changes chan []string
+}
+
+func (w *MockStringsWatcher) Changes() watcher.StringsChannel {
+	return w.changes
+}
+
+func (w *MockStringsWatcher) Kill() {}
+
+func (w *MockStringsWatcher) Wait() error {
+	return nil
+}
+
 func (cb *RunActionCallbacks) SetExecutingStatus(message string) error {
 	cb.executingMessage = message
 	return nil
@@ -185,6 +211,7 @@ type ExecuteHookCallbacks struct {
 	*PrepareHookCallbacks
 	MockNotifyHookCompleted *MockNotify
 	MockNotifyHookFailed    *MockNotify
+	gotHookRecords          []params.HookRecord
 }
 
 func (cb *ExecuteHookCallbacks) NotifyHookCompleted(hookName string, ctx runner.Context) {
@@ -195,6 +222,11 @@ func (cb *ExecuteHookCallbacks) NotifyHookFailed(hookName string, ctx runner.Con
 	cb.MockNotifyHookFailed.Call(hookName, ctx)
 }
 
+func (cb *ExecuteHookCallbacks) RecordHookRun(record params.HookRecord) error {
+	cb.gotHookRecords = append(cb.gotHookRecords, record)
+	return nil
+}
+
 type MockCommitHook struct {
 	gotHook *hook.Info
 	err     error
@@ -289,6 +321,8 @@ type MockContext struct {
 	testing.Stub
 	actionData      *context.ActionData
 	setStatusCalled bool
+	cancelStatus    string
+	cancelled       chan struct{}
 	status          jujuc.StatusInfo
 }
 
@@ -299,6 +333,12 @@ func (mock *MockContext) ActionData() (*context.ActionData, error) {
 	return mock.actionData, nil
 }
 
+func (mock *MockContext) CancelAction(status string) error {
+	mock.cancelStatus = status
+	close(mock.cancelled)
+	return nil
+}
+
 func (mock *MockContext) HasExecutionSetUnitStatus() bool {
 	return mock.setStatusCalled
 }
@@ -313,6 +353,10 @@ func (mock *MockContext) SetUnitStatus(status jujuc.StatusInfo) error {
 	return nil
 }
 
+func (mock *MockContext) HookRelation() (jujuc.ContextRelation, error) {
+	return nil, errors.NotFoundf("hook relation")
+}
+
 func (mock *MockContext) UnitName() string {
 	return "unit/0"
 }
@@ -328,11 +372,15 @@ func (mock *MockContext) Prepare() error {
 
 type MockRunAction struct {
 	gotName *string
+	wait    <-chan struct{}
 	err     error
 }
 
 func (mock *MockRunAction) Call(actionName string) error {
 	mock.gotName = &actionName
+	if mock.wait != nil {
+		<-mock.wait
+	}
 	return mock.err
 }
 
@@ -351,6 +399,7 @@ type MockRunHook struct {
 	gotName         *string
 	err             error
 	setStatusCalled bool
+	stderr          string
 }
 
 func (mock *MockRunHook) Call(hookName string) error {
@@ -377,6 +426,10 @@ func (r *MockRunner) RunCommands(commands string) (*utilexec.ExecResponse, error
 	return r.MockRunCommands.Call(commands)
 }
 
+func (r *MockRunner) Stderr() string {
+	return r.MockRunHook.stderr
+}
+
 func (r *MockRunner) RunHook(hookName string) error {
 	r.Context().(*MockContext).setStatusCalled = r.MockRunHook.setStatusCalled
 	return r.MockRunHook.Call(hookName)
@@ -10,6 +10,7 @@ import (
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"github.com/juju/names"
+	"github.com/juju/utils/set"
 
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/core/leadership"
@@ -616,7 +617,16 @@ func (w *RemoteStateWatcher) storageAttachmentChanged(change storageAttachmentCh
 func (w *RemoteStateWatcher) actionsChanged(actions []string) error {
 	w.mu.Lock()
 	defer w.mu.Unlock()
-	w.current.Actions = append(w.current.Actions, actions...)
+	// The watcher reports an action again whenever its notification
+	// changes, as it does when cancellation of a running action is
+	// requested, so only record actions not already known.
+	known := set.NewStrings(w.current.Actions...)
+	for _, action := range actions {
+		if !known.Contains(action) {
+			known.Add(action)
+			w.current.Actions = append(w.current.Actions, action)
+		}
+	}
 	return nil
 }
 
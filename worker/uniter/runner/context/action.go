@@ -4,6 +4,8 @@
 package context
 
 import (
+	"time"
+
 	"github.com/juju/names"
 )
 
@@ -15,6 +17,10 @@ type ActionData struct {
 	Failed         bool
 	ResultsMessage string
 	ResultsMap     map[string]interface{}
+
+	// Timeout holds the maximum time the Action may run for;
+	// zero means there is no limit.
+	Timeout time.Duration
 }
 
 // NewActionData builds a suitable ActionData struct with no nil members.
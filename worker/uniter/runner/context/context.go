@@ -126,6 +126,14 @@ type HookContext struct {
 	// its tag, its parameters, and its results.
 	actionData *ActionData
 
+	// actionCancelStatus holds the status the running Action will
+	// finish with if it was stopped before it completed.
+	actionCancelStatus string
+
+	// hookTimeout holds the maximum time a hook may run for; zero
+	// means hooks are never timed out.
+	hookTimeout time.Duration
+
 	// uuid is the universally unique identifier of the environment.
 	uuid string
 
@@ -249,6 +257,24 @@ func (ctx *HookContext) RequestReboot(priority jujuc.RebootPriority) error {
 	return err
 }
 
+// CancelAction kills the process running the current Action, which
+// is then recorded with the given status rather than as failed.
+func (ctx *HookContext) CancelAction(status string) error {
+	if ctx.actionData == nil {
+		return errors.New("not running an action")
+	}
+	mutex.Lock()
+	ctx.actionCancelStatus = status
+	mutex.Unlock()
+	return ctx.killCharmHook()
+}
+
+func (ctx *HookContext) getActionCancelStatus() string {
+	mutex.Lock()
+	defer mutex.Unlock()
+	return ctx.actionCancelStatus
+}
+
 func (ctx *HookContext) GetRebootPriority() jujuc.RebootPriority {
 	mutex.Lock()
 	defer mutex.Unlock()
@@ -562,6 +588,12 @@ func (c *HookContext) ActionData() (*ActionData, error) {
 	return c.actionData, nil
 }
 
+// HookTimeout returns the maximum time the context's hook may run
+// for, or zero if it may run indefinitely.
+func (ctx *HookContext) HookTimeout() time.Duration {
+	return ctx.hookTimeout
+}
+
 // HookVars returns an os.Environ-style list of strings necessary to run a hook
 // such that it can know what environment it's operating in, and can call back
 // into context.
@@ -746,6 +778,17 @@ func (ctx *HookContext) finalizeAction(err, unhandledErr error) error {
 		status = params.ActionFailed
 	}
 
+	// An Action that was stopped reports why, whatever the outcome
+	// of its process.
+	switch cancelStatus := ctx.getActionCancelStatus(); cancelStatus {
+	case params.ActionCancelled:
+		status = cancelStatus
+		message = "action cancelled"
+	case params.ActionTimedOut:
+		status = cancelStatus
+		message = fmt.Sprintf("action timed out after %v", ctx.actionData.Timeout)
+	}
+
 	callErr := ctx.state.ActionFinish(tag, status, results, message)
 	if callErr != nil {
 		unhandledErr = errors.Wrap(unhandledErr, callErr)
@@ -20,6 +20,10 @@ import (
 	"github.com/juju/juju/worker/uniter/runner/jujuc"
 )
 
+// defaultHookTimeout is the hook timeout used when the controller
+// cannot report it. Zero means hooks are never timed out.
+const defaultHookTimeout = time.Duration(0)
+
 // CommandInfo specifies the information necessary to run a command.
 type CommandInfo struct {
 	// RelationId is the relation context to execute the commands in.
@@ -205,6 +209,14 @@ func (f *contextFactory) HookContext(hookInfo hook.Info) (*HookContext, error) {
 		}
 		hookName = fmt.Sprintf("%s-%s", storageName, hookName)
 	}
+	hookTimeout, err := f.unit.HookTimeout()
+	if err != nil {
+		// The controller may not support hook timeouts; run
+		// the hook anyway rather than block the unit.
+		logger.Warningf("cannot get hook timeout, using default %v: %v", defaultHookTimeout, err)
+		hookTimeout = defaultHookTimeout
+	}
+	ctx.hookTimeout = hookTimeout
 	ctx.id = f.newId(hookName)
 	return ctx, nil
 }
@@ -16,6 +16,8 @@ import (
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/charm.v6-unstable/hooks"
 
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/api/uniter"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/core/leadership"
 	"github.com/juju/juju/state"
@@ -162,6 +164,56 @@ func (s *ContextFactorySuite) TestRelationHookContext(c *gc.C) {
 	s.AssertNotStorageContext(c, ctx)
 }
 
+func (s *ContextFactorySuite) TestNewHookContextHookTimeout(c *gc.C) {
+	ctx, err := s.factory.HookContext(hook.Info{Kind: hooks.ConfigChanged})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ctx.HookTimeout(), gc.Equals, time.Duration(0))
+
+	err = s.service.SetHookTimeout(10 * time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+	ctx, err = s.factory.HookContext(hook.Info{Kind: hooks.ConfigChanged})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ctx.HookTimeout(), gc.Equals, 10*time.Minute)
+}
+
+func (s *ContextFactorySuite) TestNewHookContextHookTimeoutError(c *gc.C) {
+	// A controller that does not support hook timeouts
+	// does not stop hooks from being run.
+	caller := hookTimeoutsErrorCaller{s.st}
+	contextFactory, err := context.NewContextFactory(
+		uniter.NewState(caller, s.unit.UnitTag()),
+		s.unit.Tag().(names.UnitTag),
+		runnertesting.FakeTracker{},
+		s.getRelationInfos,
+		s.storage,
+		s.paths,
+		coretesting.NewClock(time.Time{}),
+	)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.service.SetHookTimeout(10 * time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+	ctx, err := contextFactory.HookContext(hook.Info{Kind: hooks.ConfigChanged})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ctx.HookTimeout(), gc.Equals, time.Duration(0))
+}
+
+// hookTimeoutsErrorCaller is an APICaller that fails calls to
+// Uniter.HookTimeouts as a controller without them would.
+type hookTimeoutsErrorCaller struct {
+	base.APICaller
+}
+
+func (c hookTimeoutsErrorCaller) APICall(objType string, version int, id, request string, args, response interface{}) error {
+	if objType == "Uniter" && request == "HookTimeouts" {
+		return &params.Error{
+			Code:    params.CodeNotImplemented,
+			Message: `no such request - method Uniter(4).HookTimeouts is not implemented`,
+		}
+	}
+	return c.APICaller.APICall(objType, version, id, request, args, response)
+}
+
 func (s *ContextFactorySuite) TestNewHookContextWithStorage(c *gc.C) {
 	// We need to set up a unit that has storage metadata defined.
 	ch := s.AddTestingCharm(c, "storage-block")
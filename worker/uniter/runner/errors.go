@@ -5,6 +5,9 @@ package runner
 
 import (
 	"fmt"
+	"os/exec"
+	"syscall"
+	"time"
 
 	"github.com/juju/errors"
 )
@@ -28,3 +31,36 @@ func IsBadActionError(err error) bool {
 func NewBadActionError(actionName, problem string) error {
 	return &badActionError{actionName, problem}
 }
+
+// HookTimedOutError is returned when a hook is killed because it ran
+// for longer than its timeout.
+type HookTimedOutError struct {
+	// Hook holds the name of the hook that was killed.
+	Hook string
+
+	// Timeout holds the time the hook was allowed to run for.
+	Timeout time.Duration
+
+	// Output holds the last lines the hook wrote before it
+	// was killed.
+	Output []string
+}
+
+// Error is part of the error interface.
+func (e *HookTimedOutError) Error() string {
+	return fmt.Sprintf("hook timed out after %v", e.Timeout)
+}
+
+// HookExitCode returns the exit code of a hook process that failed
+// with the given error, or -1 if the process did not exit normally.
+func HookExitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+	if exitErr, ok := errors.Cause(err).(*exec.ExitError); ok {
+		if status, ok := exitErr.Sys().(syscall.WaitStatus); ok {
+			return status.ExitStatus()
+		}
+	}
+	return -1
+}
@@ -115,6 +115,7 @@ func (f *factory) NewActionRunner(actionId string) (Runner, error) {
 	}
 
 	actionData := context.NewActionData(name, &tag, params)
+	actionData.Timeout = action.Timeout()
 	ctx, err := f.contextFactory.ActionContext(actionData)
 	runner := NewRunner(ctx, f.paths)
 	return runner, nil
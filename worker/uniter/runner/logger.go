@@ -12,12 +12,45 @@ import (
 	"github.com/juju/loggo"
 )
 
+// hookOutputLines holds the number of trailing lines of hook output
+// kept to report when a hook fails or times out.
+const hookOutputLines = 10
+
+// lineTail holds the last lines written to it.
+type lineTail struct {
+	mu   sync.Mutex
+	size int
+	buf  []string
+}
+
+func newLineTail(size int) *lineTail {
+	return &lineTail{size: size}
+}
+
+func (t *lineTail) add(line string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.buf = append(t.buf, line)
+	if len(t.buf) > t.size {
+		t.buf = t.buf[1:]
+	}
+}
+
+// lines returns the lines held by the tail, oldest first.
+func (t *lineTail) lines() []string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return append([]string(nil), t.buf...)
+}
+
 type hookLogger struct {
 	r       io.ReadCloser
 	done    chan struct{}
 	mu      sync.Mutex
 	stopped bool
 	logger  loggo.Logger
+	// tails hold the last lines logged from the hook.
+	tails []*lineTail
 }
 
 func (l *hookLogger) run() {
@@ -38,6 +71,9 @@ func (l *hookLogger) run() {
 			return
 		}
 		l.logger.Infof("%s", line)
+		for _, tail := range l.tails {
+			tail.add(string(line))
+		}
 		l.mu.Unlock()
 	}
 }
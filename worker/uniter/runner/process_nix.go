@@ -0,0 +1,26 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// +build !windows
+
+package runner
+
+import (
+	"os/exec"
+	"syscall"
+)
+
+// setProcessGroup arranges for the command to run in a process group
+// of its own, so that any processes it starts can be killed with it.
+func setProcessGroup(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+}
+
+// Kill kills the process and, if it leads a process group, every
+// other process in the group.
+func (p hookProcess) Kill() error {
+	if err := syscall.Kill(-p.Process.Pid, syscall.SIGKILL); err == nil {
+		return nil
+	}
+	return p.Process.Kill()
+}
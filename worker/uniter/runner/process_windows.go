@@ -0,0 +1,12 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package runner
+
+import (
+	"os/exec"
+)
+
+// setProcessGroup does nothing on windows; only the hook process
+// itself is killed.
+func setProcessGroup(cmd *exec.Cmd) {}
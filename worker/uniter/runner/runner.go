@@ -8,6 +8,8 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
@@ -36,6 +38,10 @@ type Runner interface {
 
 	// RunCommands executes the supplied script.
 	RunCommands(commands string) (*utilexec.ExecResponse, error)
+
+	// Stderr returns the last lines written to standard error by
+	// the most recently run hook or action.
+	Stderr() string
 }
 
 // Context exposes jujuc.Context, and additional methods needed by Runner.
@@ -45,8 +51,10 @@ type Context interface {
 	HookVars(paths context.Paths) ([]string, error)
 	ActionData() (*context.ActionData, error)
 	SetProcess(process context.HookProcess)
+	CancelAction(status string) error
 	HasExecutionSetUnitStatus() bool
 	ResetExecutionSetUnitStatus()
+	HookTimeout() time.Duration
 
 	Prepare() error
 	Flush(badge string, failure error) error
@@ -54,19 +62,27 @@ type Context interface {
 
 // NewRunner returns a Runner backed by the supplied context and paths.
 func NewRunner(context Context, paths context.Paths) Runner {
-	return &runner{context, paths}
+	return &runner{context: context, paths: paths}
 }
 
 // runner implements Runner.
 type runner struct {
 	context Context
 	paths   context.Paths
+	// stderr holds the end of the standard error output of
+	// the last hook or action run.
+	stderr string
 }
 
 func (runner *runner) Context() Context {
 	return runner.context
 }
 
+// Stderr exists to satisfy the Runner interface.
+func (runner *runner) Stderr() string {
+	return runner.stderr
+}
+
 // RunCommands exists to satisfy the Runner interface.
 func (runner *runner) RunCommands(commands string) (*utilexec.ExecResponse, error) {
 	srv, err := runner.startJujucServer()
@@ -101,15 +117,18 @@ func (runner *runner) RunAction(actionName string) error {
 	if _, err := runner.context.ActionData(); err != nil {
 		return errors.Trace(err)
 	}
-	return runner.runCharmHookWithLocation(actionName, "actions")
+	return runner.runCharmHookWithLocation(actionName, "actions", 0)
 }
 
 // RunHook exists to satisfy the Runner interface.
 func (runner *runner) RunHook(hookName string) error {
-	return runner.runCharmHookWithLocation(hookName, "hooks")
+	return runner.runCharmHookWithLocation(hookName, "hooks", runner.context.HookTimeout())
 }
 
-func (runner *runner) runCharmHookWithLocation(hookName, charmLocation string) error {
+// runCharmHookWithLocation runs the named hook or action from the given
+// location in the charm directory, killing it if it runs for longer than
+// the given timeout. A zero timeout means it may run indefinitely.
+func (runner *runner) runCharmHookWithLocation(hookName, charmLocation string, timeout time.Duration) error {
 	srv, err := runner.startJujucServer()
 	if err != nil {
 		return err
@@ -132,12 +151,12 @@ func (runner *runner) runCharmHookWithLocation(hookName, charmLocation string) e
 		logger.Infof("executing %s via debug-hooks", hookName)
 		err = session.RunHook(hookName, runner.paths.GetCharmDir(), env)
 	} else {
-		err = runner.runCharmHook(hookName, env, charmLocation)
+		err = runner.runCharmHook(hookName, env, charmLocation, timeout)
 	}
 	return runner.context.Flush(hookName, err)
 }
 
-func (runner *runner) runCharmHook(hookName string, env []string, charmLocation string) error {
+func (runner *runner) runCharmHook(hookName string, env []string, charmLocation string, timeout time.Duration) error {
 	charmDir := runner.paths.GetCharmDir()
 	hook, err := searchHook(charmDir, filepath.Join(charmLocation, hookName))
 	if err != nil {
@@ -147,30 +166,92 @@ func (runner *runner) runCharmHook(hookName string, env []string, charmLocation
 	ps := exec.Command(hookCmd[0], hookCmd[1:]...)
 	ps.Env = env
 	ps.Dir = charmDir
+	if charmLocation == "actions" {
+		// Actions may be cancelled, and killing one should also
+		// kill anything it started. Hooks are left in the agent's
+		// process group, as they always have been; a hook that
+		// times out has only its own process killed.
+		setProcessGroup(ps)
+	}
 	outReader, outWriter, err := os.Pipe()
 	if err != nil {
 		return errors.Errorf("cannot make logging pipe: %v", err)
 	}
+	errReader, errWriter, err := os.Pipe()
+	if err != nil {
+		outReader.Close()
+		outWriter.Close()
+		return errors.Errorf("cannot make logging pipe: %v", err)
+	}
 	ps.Stdout = outWriter
-	ps.Stderr = outWriter
-	hookLogger := &hookLogger{
+	ps.Stderr = errWriter
+	output := newLineTail(hookOutputLines)
+	stderr := newLineTail(hookOutputLines)
+	outLogger := &hookLogger{
 		r:      outReader,
 		done:   make(chan struct{}),
 		logger: runner.getLogger(hookName),
+		tails:  []*lineTail{output},
+	}
+	errLogger := &hookLogger{
+		r:      errReader,
+		done:   make(chan struct{}),
+		logger: runner.getLogger(hookName),
+		tails:  []*lineTail{output, stderr},
 	}
-	go hookLogger.run()
+	go outLogger.run()
+	go errLogger.run()
 	err = ps.Start()
 	outWriter.Close()
+	errWriter.Close()
 	if err == nil {
 		// Record the *os.Process of the hook
 		runner.context.SetProcess(hookProcess{ps.Process})
 		// Block until execution finishes
-		err = ps.Wait()
+		err = waitHook(ps, timeout)
+	}
+	outLogger.stop()
+	errLogger.stop()
+	runner.stderr = strings.Join(stderr.lines(), "\n")
+	if err == errHookTimedOut {
+		return &HookTimedOutError{
+			Hook:    hookName,
+			Timeout: timeout,
+			Output:  output.lines(),
+		}
 	}
-	hookLogger.stop()
 	return errors.Trace(err)
 }
 
+// errHookTimedOut is returned by waitHook when it kills a hook
+// process that ran for too long.
+var errHookTimedOut = errors.New("hook timed out")
+
+// waitHook waits for the started hook process to finish. If it is
+// still running after the given timeout, the hook process is killed,
+// along with any processes it started if it leads a process group,
+// and errHookTimedOut is returned.
+func waitHook(ps *exec.Cmd, timeout time.Duration) error {
+	if timeout <= 0 {
+		return ps.Wait()
+	}
+	done := make(chan error, 1)
+	go func() {
+		done <- ps.Wait()
+	}()
+	select {
+	case err := <-done:
+		return err
+	case <-time.After(timeout):
+	}
+	logger.Warningf("killing hook process %d after %v", ps.Process.Pid, timeout)
+	if err := (hookProcess{ps.Process}).Kill(); err != nil {
+		logger.Errorf("cannot kill hook process %d: %v", ps.Process.Pid, err)
+	}
+	<-done
+	return errHookTimedOut
+}
+
 func (runner *runner) startJujucServer() (*jujuc.Server, error) {
 	// Prepare server.
 	getCmd := func(ctxId, cmdName string) (cmd.Command, error) {
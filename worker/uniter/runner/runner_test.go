@@ -151,6 +151,7 @@ type MockContext struct {
 	flushBadge   string
 	flushFailure error
 	flushResult  error
+	hookTimeout  time.Duration
 }
 
 func (ctx *MockContext) UnitName() string {
@@ -176,6 +177,10 @@ func (ctx *MockContext) Prepare() error {
 	return nil
 }
 
+func (ctx *MockContext) HookTimeout() time.Duration {
+	return ctx.hookTimeout
+}
+
 func (ctx *MockContext) Flush(badge string, failure error) error {
 	ctx.flushBadge = badge
 	ctx.flushFailure = failure
@@ -234,7 +239,56 @@ func (s *RunMockContextSuite) TestRunHookFlushFailure(c *gc.C) {
 	c.Assert(actualErr, gc.Equals, expectErr)
 	c.Assert(ctx.flushBadge, gc.Equals, "something-happened")
 	c.Assert(ctx.flushFailure, gc.ErrorMatches, "exit status 123")
+	c.Assert(runner.HookExitCode(ctx.flushFailure), gc.Equals, 123)
+	s.assertRecordedPid(c, ctx.expectPid)
+}
+
+func (s *RunMockContextSuite) TestRunHookStderr(c *gc.C) {
+	if runtime.GOOS == "windows" {
+		c.Skip("bug 1403084: Have to figure out a good way to output to stderr from powershell")
+	}
+	ctx := &MockContext{}
+	makeCharm(c, hookSpec{
+		dir:    "hooks",
+		name:   hookName,
+		perm:   0700,
+		stdout: "working",
+		stderr: "oops",
+	}, s.paths.GetCharmDir())
+	rnr := runner.NewRunner(ctx, s.paths)
+	err := rnr.RunHook("something-happened")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ctx.flushFailure, gc.IsNil)
+	c.Assert(runner.HookExitCode(ctx.flushFailure), gc.Equals, 0)
+	c.Assert(rnr.Stderr(), gc.Equals, "oops")
+}
+
+func (s *RunMockContextSuite) TestRunHookTimeout(c *gc.C) {
+	if runtime.GOOS == "windows" {
+		c.Skip("hook scripts on windows cannot sleep")
+	}
+	ctx := &MockContext{
+		hookTimeout: 500 * time.Millisecond,
+	}
+	makeCharm(c, hookSpec{
+		dir:    "hooks",
+		name:   hookName,
+		perm:   0700,
+		stdout: "still working",
+		sleep:  30,
+	}, s.paths.GetCharmDir())
+	t0 := time.Now()
+	err := runner.NewRunner(ctx, s.paths).RunHook("something-happened")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(time.Since(t0) < 10*time.Second, jc.IsTrue)
+	c.Assert(ctx.flushBadge, gc.Equals, "something-happened")
+	c.Assert(ctx.flushFailure, gc.ErrorMatches, "hook timed out after 500ms")
+	timedOut, ok := ctx.flushFailure.(*runner.HookTimedOutError)
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(timedOut.Hook, gc.Equals, "something-happened")
+	c.Assert(timedOut.Output, jc.DeepEquals, []string{"still working"})
 	s.assertRecordedPid(c, ctx.expectPid)
+	c.Assert(processExists(ctx.expectPid), jc.IsFalse)
 }
 
 func (s *RunMockContextSuite) TestRunActionFlushSuccess(c *gc.C) {
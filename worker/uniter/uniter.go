@@ -559,5 +559,10 @@ func (u *Uniter) reportHookError(hookInfo hook.Info) error {
 	}
 	statusData["hook"] = hookName
 	statusMessage := fmt.Sprintf("hook failed: %q", hookName)
+	if timedOut := u.operationExecutor.State().HookTimedOut; timedOut != nil {
+		statusData["timeout"] = timedOut.Timeout.String()
+		statusData["output"] = strings.Join(timedOut.Output, "\n")
+		statusMessage = fmt.Sprintf("hook timed out: %q", hookName)
+	}
 	return setAgentStatus(u, params.StatusError, statusMessage, statusData)
 }